@@ -3,48 +3,303 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/bugzilla"
+	"github.com/quay/release-readiness/internal/cli"
+	"github.com/quay/release-readiness/internal/config"
 	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/demo"
+	"github.com/quay/release-readiness/internal/digest"
+	"github.com/quay/release-readiness/internal/errata"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/gitaudit"
+	"github.com/quay/release-readiness/internal/history"
 	"github.com/quay/release-readiness/internal/jira"
+	"github.com/quay/release-readiness/internal/logging"
+	"github.com/quay/release-readiness/internal/notify"
+	"github.com/quay/release-readiness/internal/registry"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/retention"
 	s3client "github.com/quay/release-readiness/internal/s3"
+	"github.com/quay/release-readiness/internal/scheduler"
 	"github.com/quay/release-readiness/internal/server"
+	"github.com/quay/release-readiness/internal/tekton"
+	"github.com/quay/release-readiness/internal/tracing"
+	"github.com/quay/release-readiness/internal/version"
+	"github.com/quay/release-readiness/internal/webhooks"
 )
 
+// flagEnv names the environment variable each flag defaults from, so that
+// a -config file does not override settings taken from the environment.
+// Keep it in step with the flag definitions in main.
+var flagEnv = map[string]string{
+	"db-dsn":                    "DB_DSN",
+	"admin-token":               "ADMIN_TOKEN",
+	"api-tokens-file":           "API_TOKENS_FILE",
+	"auth-groups-header":        "AUTH_GROUPS_HEADER",
+	"group-roles":               "GROUP_ROLES",
+	"storage-backend":           "STORAGE_BACKEND",
+	"s3-endpoint":               "S3_ENDPOINT",
+	"s3-region":                 "S3_REGION",
+	"s3-bucket":                 "S3_BUCKET",
+	"s3-access-key":             "AWS_ACCESS_KEY_ID",
+	"s3-secret-key":             "AWS_SECRET_ACCESS_KEY",
+	"s3-sqs-queue":              "S3_SQS_QUEUE_URL",
+	"s3-sources":                "S3_SOURCES_FILE",
+	"gcs-token":                 "GCS_ACCESS_TOKEN",
+	"azure-account":             "AZURE_STORAGE_ACCOUNT",
+	"azure-sas-token":           "AZURE_STORAGE_SAS_TOKEN",
+	"jira-url":                  "JIRA_URL",
+	"jira-email":                "JIRA_EMAIL",
+	"jira-token":                "JIRA_TOKEN",
+	"jira-project":              "JIRA_PROJECT",
+	"jira-qa-contact-field":     "JIRA_QA_CONTACT_FIELD",
+	"jira-severity-field":       "JIRA_SEVERITY_FIELD",
+	"jira-cve-id-field":         "JIRA_CVE_ID_FIELD",
+	"jira-cvss-field":           "JIRA_CVSS_FIELD",
+	"jira-embargo-field":        "JIRA_EMBARGO_FIELD",
+	"jira-target-version-field": "JIRA_TARGET_VERSION_FIELD",
+	"jira-templates-file":       "JIRA_TEMPLATES_FILE",
+	"app-mapping-file":          "APP_MAPPING_FILE",
+	"jira-api-version":          "JIRA_API_VERSION",
+	"jira-webhook-secret":       "JIRA_WEBHOOK_SECRET",
+	"bugzilla-url":              "BUGZILLA_URL",
+	"bugzilla-api-key":          "BUGZILLA_API_KEY",
+	"github-url":                "GITHUB_URL",
+	"github-token":              "GITHUB_TOKEN",
+	"slack-webhook":             "SLACK_WEBHOOK_URL",
+	"slack-routes":              "SLACK_ROUTES_FILE",
+	"notify-routes":             "NOTIFY_ROUTES_FILE",
+	"webhooks-file":             "WEBHOOKS_FILE",
+	"dashboard-url":             "DASHBOARD_URL",
+	"smtp-addr":                 "SMTP_ADDR",
+	"smtp-username":             "SMTP_USERNAME",
+	"smtp-password":             "SMTP_PASSWORD",
+	"smtp-from":                 "SMTP_FROM",
+	"registry-username":         "REGISTRY_USERNAME",
+	"registry-password":         "REGISTRY_PASSWORD",
+	"tekton-results-url":        "TEKTON_RESULTS_URL",
+	"tekton-results-token":      "TEKTON_RESULTS_TOKEN",
+	"errata-url":                "ERRATA_URL",
+	"errata-token":              "ERRATA_TOKEN",
+	"retention-rules":           "RETENTION_RULES_FILE",
+	"otlp-endpoint":             "OTEL_EXPORTER_OTLP_ENDPOINT",
+	"otlp-headers":              "OTEL_EXPORTER_OTLP_HEADERS",
+	"otel-service-name":         "OTEL_SERVICE_NAME",
+}
+
 func main() {
+	// Subcommands talk to a running dashboard; without one, the binary is
+	// the dashboard.
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := cli.Main(ctx, os.Args[1:], os.Stdout, os.Stderr)
+		stop()
+		os.Exit(code)
+	}
+
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML file of settings; nested keys join with \"-\" to name flags, and ${VAR} is expanded. Flags and environment variables take precedence")
 	addr := flag.String("addr", ":8080", "listen address")
-	dbPath := flag.String("db", "dashboard.db", "SQLite database path")
+	dbPath := flag.String("db", "dashboard.db", "SQLite database path (\":memory:\" for an ephemeral database)")
+	dbDriver := flag.String("db-driver", db.SQLite, "database engine: sqlite or postgres (postgres requires a -tags postgres build)")
+	dbDSN := flag.String("db-dsn", os.Getenv("DB_DSN"), "PostgreSQL connection string or URL; unset parameters come from PG* environment variables")
+	seed := flag.Bool("seed", false, "populate an empty database with sample data (implied by -db :memory:)")
+	demoMode := flag.Bool("demo", false, "serve generated demo data from an in-memory database; S3 and JIRA sync are disabled")
+	demoInterval := flag.Duration("demo-interval", time.Minute, "how often demo mode generates a new snapshot")
+	var logLevel slog.Level
+	flag.TextVar(&logLevel, "log-level", slog.LevelInfo, "minimum log level (debug, info, warn, error)")
+	adminToken := flag.String("admin-token", os.Getenv("ADMIN_TOKEN"), "bearer token with the admin role (admin API disabled if no token or group has it)")
+	apiTokensFile := flag.String("api-tokens-file", os.Getenv("API_TOKENS_FILE"), "JSON file of API tokens: [{\"name\", \"token\", \"role\": \"viewer|reporter|release-manager|admin\"}]")
+	authGroupsHeader := flag.String("auth-groups-header", os.Getenv("AUTH_GROUPS_HEADER"), "request header in which an authenticating proxy lists the user's groups, e.g. X-Forwarded-Groups (group roles disabled if empty)")
+	groupRoles := flag.String("group-roles", os.Getenv("GROUP_ROLES"), "comma-separated group=role pairs granting roles to the groups in -auth-groups-header")
+	publicReads := flag.Bool("public-reads", true, "serve read endpoints without a token; if false they require the viewer role")
 
 	// S3 flags
-	s3Endpoint := flag.String("s3-endpoint", os.Getenv("S3_ENDPOINT"), "S3 endpoint URL (e.g. http://localhost:3900)")
+	storageBackend := flag.String("storage-backend", envOrDefault("STORAGE_BACKEND", "s3"), "object store holding snapshots and test results: s3, gcs or azure")
+	s3Endpoint := flag.String("s3-endpoint", os.Getenv("S3_ENDPOINT"), "S3 endpoint URL (e.g. http://localhost:3900); with gcs or azure, that service's endpoint")
 	s3Region := flag.String("s3-region", envOrDefault("S3_REGION", "us-east-1"), "S3 region")
-	s3Bucket := flag.String("s3-bucket", os.Getenv("S3_BUCKET"), "S3 bucket name")
+	s3Bucket := flag.String("s3-bucket", os.Getenv("S3_BUCKET"), "S3 bucket name; with gcs the GCS bucket, with azure the blob container")
 	s3AccessKey := flag.String("s3-access-key", os.Getenv("AWS_ACCESS_KEY_ID"), "S3 access key")
 	s3SecretKey := flag.String("s3-secret-key", os.Getenv("AWS_SECRET_ACCESS_KEY"), "S3 secret key")
 	s3PollInterval := flag.Duration("s3-poll-interval", 30*time.Second, "S3 sync poll interval")
+	s3Concurrency := flag.Int("s3-concurrency", s3client.DefaultConcurrency, "number of applications synced from S3 in parallel")
+	s3MaxAttempts := flag.Int("s3-max-attempts", s3client.DefaultMaxAttempts, "times polls try to ingest a snapshot before giving up on it")
+	s3LateResults := flag.Duration("s3-late-results-window", s3client.DefaultLateResultsWindow, "how long after ingesting a snapshot polls look for late test results (0 disables)")
+	s3SQSQueue := flag.String("s3-sqs-queue", os.Getenv("S3_SQS_QUEUE_URL"), "SQS queue URL receiving the bucket's ObjectCreated notifications, for immediate ingestion")
+	s3MaxReportBytes := flag.Int64("s3-max-report-bytes", s3client.DefaultLimits.MaxReportBytes, "fail scenarios whose report, or any JUnit file, is larger than this many bytes (0 = no limit)")
+	s3MaxReportFiles := flag.Int("s3-max-report-files", s3client.DefaultLimits.MaxReportFiles, "fail scenarios with more JUnit files than this (0 = no limit)")
+	s3MaxCases := flag.Int("s3-max-cases", s3client.DefaultLimits.MaxCases, "maximum test cases retained per scenario (0 = no limit)")
+	s3MaxMessageBytes := flag.Int("s3-max-message-bytes", s3client.DefaultLimits.MaxMessageBytes, "truncate test failure messages and traces to this many bytes (0 = no limit)")
+	s3SourcesFile := flag.String("s3-sources", os.Getenv("S3_SOURCES_FILE"), "JSON file of further object stores to sync, each tagging its snapshots with its name: [{\"name\": \"omr\", \"bucket\": \"products\", \"prefix\": \"omr\", \"secret_key\": \"${OMR_SECRET_KEY}\"}]")
+	s3SBOMs := flag.Bool("s3-sboms", false, "record each snapshot component's SBOM reference and summarise SBOM documents uploaded with the snapshot")
+	gcsToken := flag.String("gcs-token", os.Getenv("GCS_ACCESS_TOKEN"), "OAuth2 access token for GCS; if unset, tokens come from the GCE metadata server")
+	azureAccount := flag.String("azure-account", os.Getenv("AZURE_STORAGE_ACCOUNT"), "Azure storage account name")
+	azureSASToken := flag.String("azure-sas-token", os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "Azure SAS token granting read and list on the container")
 
 	// JIRA flags
 	jiraURL := flag.String("jira-url", envOrDefault("JIRA_URL", "https://redhat.atlassian.net"), "JIRA Cloud URL")
 	jiraEmail := flag.String("jira-email", os.Getenv("JIRA_EMAIL"), "JIRA Cloud account email for API token auth")
 	jiraToken := flag.String("jira-token", os.Getenv("JIRA_TOKEN"), "JIRA Cloud API token")
-	jiraProject := flag.String("jira-project", envOrDefault("JIRA_PROJECT", "PROJQUAY"), "JIRA project key")
+	jiraProject := flag.String("jira-project", envOrDefault("JIRA_PROJECT", "PROJQUAY"), "JIRA project key; separate several with commas, e.g. PROJQUAY,SECURITY")
 	jiraQAContactField := flag.String("jira-qa-contact-field", envOrDefault("JIRA_QA_CONTACT_FIELD", "customfield_12315948"), "JIRA custom field name for QA Contact")
+	jiraSeverityField := flag.String("jira-severity-field", envOrDefault("JIRA_SEVERITY_FIELD", "customfield_12316142"), "JIRA custom field name for CVE severity")
+	jiraCVEIDField := flag.String("jira-cve-id-field", os.Getenv("JIRA_CVE_ID_FIELD"), "JIRA custom field name for the CVE ID (taken from labels or summary if empty)")
+	jiraCVSSField := flag.String("jira-cvss-field", os.Getenv("JIRA_CVSS_FIELD"), "JIRA custom field name for the CVSS score")
+	jiraEmbargoField := flag.String("jira-embargo-field", os.Getenv("JIRA_EMBARGO_FIELD"), "JIRA custom field name for the CVE embargo state")
+	jiraTargetVersionField := flag.String("jira-target-version-field", envOrDefault("JIRA_TARGET_VERSION_FIELD", "customfield_12319940"), "JIRA custom field name for Target Version")
+	jiraTemplates := flag.String("jira-templates-file", os.Getenv("JIRA_TEMPLATES_FILE"), "JSON file overriding the release discovery JQL, issue search JQL and summary pattern: {\"discovery_jql\", \"search_jql\", \"summary_pattern\"}")
+	appMappingFile := flag.String("app-mapping-file", os.Getenv("APP_MAPPING_FILE"), "JSON file of ordered rules mapping fixVersions to S3 applications, replacing the default ones: [{\"fix_version\": \"^widget-(\\\\d+)\\\\.(\\\\d+)\", \"application\": \"widget-v$1-$2\"}]")
+	jiraWebhookSecret := flag.String("jira-webhook-secret", os.Getenv("JIRA_WEBHOOK_SECRET"), "shared secret of the JIRA webhook; enables POST /api/v1/webhooks/jira")
+	jiraWebhookQuerySecret := flag.Bool("jira-webhook-query-secret", false, "also accept the JIRA webhook secret as the secret query parameter, for JIRA instances that cannot sign deliveries")
+	jiraAPIVersion := flag.String("jira-api-version", os.Getenv("JIRA_API_VERSION"), "JIRA REST API version: 3 (Cloud) or 2 (Server/Data Center); detected from -jira-url if empty")
+	jiraRPS := flag.Float64("jira-rps", jira.DefaultRequestsPerSecond, "average JIRA requests per second, shared by all sync and discovery calls (negative = unlimited)")
+	jiraBurst := flag.Int("jira-burst", jira.DefaultBurst, "JIRA requests allowed in a burst above -jira-rps")
 	jiraPollInterval := flag.Duration("jira-poll-interval", 5*time.Minute, "JIRA sync poll interval")
+	jiraFullSyncInterval := flag.Duration("jira-full-sync-interval", jira.DefaultFullSyncInterval, "how often each version's issues are fully re-synced; polls in between fetch only recently updated issues (0 = always full)")
+	jiraStaleAfter := flag.Duration("jira-stale-after", 30*time.Minute, "how long after its last successful JIRA sync a release's issue counts are marked stale (0 = never)")
+
+	// Bugzilla flags
+	bugzillaURL := flag.String("bugzilla-url", os.Getenv("BUGZILLA_URL"), "Bugzilla URL used to sync bugs of legacy components into release issues (disabled if empty)")
+	bugzillaAPIKey := flag.String("bugzilla-api-key", os.Getenv("BUGZILLA_API_KEY"), "Bugzilla API key")
+	bugzillaProduct := flag.String("bugzilla-product", "Red Hat Quay", "Bugzilla product bugs are searched in")
+	bugzillaComponents := flag.String("bugzilla-components", "", "comma-separated Bugzilla components to sync (all components of the product if empty)")
+	bugzillaPollInterval := flag.Duration("bugzilla-poll-interval", 5*time.Minute, "Bugzilla sync poll interval")
+
+	// Git audit flags
+	githubURL := flag.String("github-url", envOrDefault("GITHUB_URL", "https://api.github.com"), "GitHub API URL")
+	githubToken := flag.String("github-token", os.Getenv("GITHUB_TOKEN"), "GitHub token for post-release git audits and pull requests in snapshot diffs (disabled if empty)")
+	gitTagTemplate := flag.String("git-tag-template", "v{version}", "expected release tag; {release}, {version} and {minor} are expanded")
+	gitBranchTemplate := flag.String("git-branch-template", "", "release branch every component commit must be on, e.g. redhat-{minor} (not checked if empty)")
+	auditInterval := flag.Duration("audit-interval", 15*time.Minute, "post-release git audit interval")
+
+	// Readiness policy flags
+	cveSeverity := flag.String("readiness-cve-severity", "", "force readiness red while open CVEs at or above this severity remain (Low, Moderate, Important, Critical; disabled if empty)")
+	requireEC := flag.Bool("readiness-require-ec", false, "force readiness red while components of the selected snapshot fail Enterprise Contract, and require EC results for green")
+
+	// Planning flags
+	freezeWindow := flag.Duration("freeze-window", 7*24*time.Hour, "code freeze window before each release's due date, shown on the timeline (0 to hide)")
+
+	// Notification flags
+	slackWebhook := flag.String("slack-webhook", os.Getenv("SLACK_WEBHOOK_URL"), "Slack incoming webhook for readiness notifications of releases no route matches")
+	slackRoutes := flag.String("slack-routes", os.Getenv("SLACK_ROUTES_FILE"), "deprecated alias of -notify-routes")
+	notifyRoutes := flag.String("notify-routes", os.Getenv("NOTIFY_ROUTES_FILE"), "JSON file routing releases to Slack, Google Chat or Teams webhooks: [{\"release\": \"quay-v3.16.*\", \"webhook\": \"https://...\", \"provider\": \"slack|google-chat|teams\"}]")
+	dashboardURL := flag.String("dashboard-url", os.Getenv("DASHBOARD_URL"), "external URL of the dashboard, for links in notifications")
+	notifyInterval := flag.Duration("notify-interval", 5*time.Minute, "readiness notification check interval")
+	historyInterval := flag.Duration("history-interval", 5*time.Minute, "how often readiness changes are recorded to each release's history")
+	webhooksFile := flag.String("webhooks-file", os.Getenv("WEBHOOKS_FILE"), "JSON file of outbound webhooks fired on snapshot.ingested, readiness.changed and release.released: [{\"url\", \"secret\", \"events\": [...], \"release\": \"quay-v3.16.*\"}]")
+	webhooksInterval := flag.Duration("webhooks-interval", time.Minute, "how often readiness is checked for outbound webhook events")
+	smtpAddr := flag.String("smtp-addr", os.Getenv("SMTP_ADDR"), "host:port of the SMTP server email digests are sent through (digests disabled if empty)")
+	smtpUsername := flag.String("smtp-username", os.Getenv("SMTP_USERNAME"), "SMTP username (no auth if empty)")
+	smtpPassword := flag.String("smtp-password", os.Getenv("SMTP_PASSWORD"), "SMTP password")
+	smtpFrom := flag.String("smtp-from", os.Getenv("SMTP_FROM"), "sender address of email digests")
+	digestHour := flag.Int("digest-hour", 8, "hour of the day, in UTC, email digests are sent at")
+	digestWeekday := flag.String("digest-weekday", "monday", "day of the week weekly email digests are sent on")
+
+	// Registry flags
+	registryVerify := flag.Bool("registry-verify", false, "verify snapshot image digests in their registry and require them for a green readiness signal")
+	registryUsername := flag.String("registry-username", os.Getenv("REGISTRY_USERNAME"), "registry username for image verification")
+	registryPassword := flag.String("registry-password", os.Getenv("REGISTRY_PASSWORD"), "registry password or token for image verification")
+	registryInterval := flag.Duration("registry-verify-interval", 10*time.Minute, "image digest verification interval")
+
+	// Tekton Results flags
+	tektonURL := flag.String("tekton-results-url", os.Getenv("TEKTON_RESULTS_URL"), "Tekton Results API URL used to resolve test suites' PipelineRuns (disabled if empty)")
+	tektonToken := flag.String("tekton-results-token", os.Getenv("TEKTON_RESULTS_TOKEN"), "bearer token for the Tekton Results API")
+	tektonNamespace := flag.String("tekton-namespace", "", "namespace of PipelineRuns referenced by name only")
+	tektonInterval := flag.Duration("tekton-interval", 5*time.Minute, "PipelineRun resolution interval")
+
+	// Errata Tool flags
+	errataURL := flag.String("errata-url", os.Getenv("ERRATA_URL"), "Errata Tool URL used to sync release advisories (disabled if empty)")
+	errataToken := flag.String("errata-token", os.Getenv("ERRATA_TOKEN"), "bearer token for the Errata Tool API")
+	errataInterval := flag.Duration("errata-interval", 10*time.Minute, "advisory sync interval")
+
+	// Retention flags
+	retentionMaxCount := flag.Int("retention-max-count", 0, "snapshots kept per application before older ones are pruned (0 = no limit)")
+	retentionMaxAge := flag.Duration("retention-max-age", 0, "age after which snapshots are pruned (0 = no limit)")
+	retentionKeepCandidates := flag.Int("retention-keep-candidates", 3, "candidates of each released release kept besides the snapshot it shipped")
+	retentionRules := flag.String("retention-rules", os.Getenv("RETENTION_RULES_FILE"), "JSON file of per-application limits: [{\"application\": \"quay-v3-*\", \"max_count\": 50, \"max_age\": \"2160h\"}]")
+	retentionInterval := flag.Duration("retention-interval", 24*time.Hour, "snapshot pruning interval")
+	retentionSchedule := flag.String("retention-schedule", "", "cron schedule of snapshot pruning in UTC, e.g. \"0 3 * * *\"; overrides -retention-interval")
+
+	// Tracing flags
+	otlpEndpoint := flag.String("otlp-endpoint", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "OTLP/HTTP collector URL receiving traces, e.g. http://otel-collector:4318 (tracing disabled if empty)")
+	otlpHeaders := flag.String("otlp-headers", os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), "headers sent with trace exports, as comma-separated key=value pairs")
+	otelServiceName := flag.String("otel-service-name", envOrDefault("OTEL_SERVICE_NAME", "release-readiness"), "service name reported with traces")
 
 	flag.Parse()
+	if *configFile != "" {
+		if err := config.Apply(flag.CommandLine, *configFile, flagEnv); err != nil {
+			fmt.Fprintf(os.Stderr, "load -config: %v\n", err)
+			os.Exit(2)
+		}
+	}
 
-	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	logLevels := logging.NewLevels(logLevel)
+	logger := slog.New(requestid.NewHandler(logging.NewHandler(
+		slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}), logLevels)))
 	slog.SetDefault(logger)
+	bi := version.Get()
+	logger.Info("starting release-readiness", "version", bi.Version, "commit", bi.Commit, "date", bi.Date)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	database, err := db.Open(*dbPath)
+	if *otlpEndpoint != "" {
+		headers, err := tracing.ParseHeaders(*otlpHeaders)
+		if err != nil {
+			logger.Error("invalid -otlp-headers", "error", err)
+			os.Exit(2)
+		}
+		tracer, err := tracing.Configure(tracing.Config{
+			Endpoint:    *otlpEndpoint,
+			Headers:     headers,
+			ServiceName: *otelServiceName,
+		}, logger.With("component", "tracing"))
+		if err != nil {
+			logger.Error("configure tracing", "error", err)
+			os.Exit(2)
+		}
+		logger.Info("tracing enabled", "endpoint", *otlpEndpoint, "service", *otelServiceName)
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracer.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("flush traces", "error", err)
+			}
+		}()
+	}
+
+	if *demoMode {
+		*dbDriver = db.SQLite
+		*dbPath = db.MemoryPath
+		*s3Bucket = ""
+		*s3SourcesFile = ""
+		*jiraToken = ""
+		*bugzillaURL = ""
+		*githubToken = ""
+		*registryVerify = false
+		*tektonURL = ""
+		*errataURL = ""
+		*slackWebhook = ""
+		*slackRoutes = ""
+		*notifyRoutes = ""
+		*webhooksFile = ""
+		*smtpAddr = ""
+	}
+
+	dsn := *dbPath
+	if *dbDriver != db.SQLite {
+		dsn = *dbDSN
+	}
+	database, err := db.OpenDriver(*dbDriver, dsn)
 	if err != nil {
 		logger.Error("open database", "error", err)
 		os.Exit(1)
@@ -52,60 +307,438 @@ func main() {
 	defer func() { _ = database.Close() }()
 
 	var wg sync.WaitGroup
+	jobs := scheduler.New(logger.With("component", "scheduler"))
 
-	var s3c *s3client.Client
-	if *s3Bucket != "" {
-		s3Log := logger.With("component", "s3-sync")
-		s3c, err = s3client.New(ctx, s3client.Config{
-			Endpoint:  *s3Endpoint,
-			Region:    *s3Region,
-			Bucket:    *s3Bucket,
-			AccessKey: *s3AccessKey,
-			SecretKey: *s3SecretKey,
-		}, s3Log)
-		if err != nil {
-			logger.Error("create s3 client", "error", err)
-			os.Exit(1)
-		}
-		logger.Info("s3 sync enabled", "bucket", *s3Bucket, "endpoint", *s3Endpoint, "interval", *s3PollInterval)
-		s3Tx := func(ctx context.Context, fn func(s3client.Store) error) error {
+	if *demoMode {
+		demoLog := logger.With("component", "demo")
+		demoTx := func(ctx context.Context, fn func(demo.Store) error) error {
 			return database.InTx(ctx, func(txDB *db.DB) error {
 				return fn(txDB)
 			})
 		}
-		syncer := s3client.NewSyncer(s3c, database, s3Tx, s3Log)
+		gen := demo.NewGenerator(database, demoTx, uint64(time.Now().UnixNano()), demoLog)
+		if err := gen.Seed(ctx); err != nil {
+			logger.Error("seed demo data", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("demo mode enabled", "interval", *demoInterval)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			syncer.Run(ctx, *s3PollInterval)
+			gen.Run(ctx, *demoInterval)
 		}()
+	} else if *seed || (*dbDriver == db.SQLite && *dbPath == db.MemoryPath) {
+		if err := database.Seed(ctx); err != nil {
+			logger.Error("seed database", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("seeded database with sample data", "db", *dbPath)
+	}
+
+	var objects s3client.ObjectStore
+	var breakers []*breaker.Breaker
+	// broker relays ingested snapshots and JIRA changes to open pages.
+	broker := events.New()
+	s3Log := logger.With("component", "s3-sync")
+	// Without a bucket, pushed snapshots are still ingested, without test
+	// results.
+	ingester := s3client.NewSyncer(nil, database, s3Log)
+	// The -s3-* flags configure the default source, whose snapshots carry
+	// no source name; -s3-sources adds named ones.
+	var sources []s3client.Source
+	if *s3Bucket != "" {
+		sources = append(sources, s3client.Source{
+			Backend:       *storageBackend,
+			Endpoint:      *s3Endpoint,
+			Region:        *s3Region,
+			Bucket:        *s3Bucket,
+			AccessKey:     *s3AccessKey,
+			SecretKey:     *s3SecretKey,
+			GCSToken:      *gcsToken,
+			AzureAccount:  *azureAccount,
+			AzureSASToken: *azureSASToken,
+			SQSQueue:      *s3SQSQueue,
+		})
+	}
+	if *s3SourcesFile != "" {
+		loaded, err := s3client.LoadSources(*s3SourcesFile)
+		if err != nil {
+			logger.Error("load -s3-sources", "error", err)
+			os.Exit(1)
+		}
+		sources = append(sources, loaded...)
+	}
+	sourceStores := make(map[string]s3client.ObjectStore)
+	syncersBySource := make(map[string]*s3client.Syncer)
+	for i, src := range sources {
+		srcLog := s3Log
+		if src.Name != "" {
+			srcLog = s3Log.With("source", src.Name)
+		}
+		store, err := src.Open(ctx, srcLog)
+		if err != nil {
+			logger.Error("create object store client", "source", src.Name, "backend", src.Backend, "error", err)
+			os.Exit(1)
+		}
+		if src.SQSQueue != "" && src.Backend != "s3" {
+			logger.Error("-s3-sqs-queue requires -storage-backend s3", "backend", src.Backend)
+			os.Exit(1)
+		}
+		srcLog.Info("s3 sync enabled", "backend", src.Backend, "bucket", src.Bucket, "prefix", src.Prefix, "endpoint", src.Endpoint, "interval", *s3PollInterval)
+		if src.Name == "" {
+			objects = store
+		} else {
+			sourceStores[src.Name] = store
+		}
+		breakers = append(breakers, store.Breaker())
+		syncer := s3client.NewSyncer(store, database, srcLog)
+		syncer.SetSource(src.Name, src.Prefix)
+		syncer.SetLimits(s3client.Limits{
+			MaxReportBytes:  *s3MaxReportBytes,
+			MaxReportFiles:  *s3MaxReportFiles,
+			MaxCases:        *s3MaxCases,
+			MaxMessageBytes: *s3MaxMessageBytes,
+		})
+		syncer.SetConcurrency(*s3Concurrency)
+		syncer.SetMaxAttempts(*s3MaxAttempts)
+		syncer.SetLateResultsWindow(*s3LateResults)
+		syncer.SetSBOMs(*s3SBOMs)
+		syncer.SetEvents(broker)
+		// Pushed snapshots are ingested with the results uploaded to the
+		// first source: the default one if it is configured.
+		if i == 0 {
+			ingester = syncer
+		}
+		syncersBySource[src.Name] = syncer
+		jobs.Add(scheduler.Job{
+			Schedule: scheduler.Every(*s3PollInterval),
+			Jitter:   *s3PollInterval / 10,
+			Run:      syncer.SyncOnce,
+			Status:   syncer.RunStatus(),
+		})
+
+		if src.SQSQueue != "" {
+			queue, err := src.OpenQueue()
+			if err != nil {
+				logger.Error("create sqs client", "source", src.Name, "error", err)
+				os.Exit(1)
+			}
+			srcLog.Info("s3 event ingestion enabled", "queue", src.SQSQueue)
+			breakers = append(breakers, queue.Breaker())
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				syncer.Consume(ctx, queue)
+			}()
+		}
+	}
+
+	appMapping := jira.DefaultMapping
+	if *appMappingFile != "" {
+		m, err := jira.LoadMapping(*appMappingFile)
+		if err != nil {
+			logger.Error("load -app-mapping-file", "error", err)
+			os.Exit(1)
+		}
+		appMapping = m
 	}
 
 	// Start JIRA sync if token is configured
+	var jiraWebhook server.JiraWebhook
 	if *jiraToken != "" {
+		if v := *jiraAPIVersion; v != "" && v != "2" && v != "3" {
+			logger.Error("invalid -jira-api-version, want 2 or 3", "version", v)
+			os.Exit(2)
+		}
+		var templates *jira.Templates
+		if *jiraTemplates != "" {
+			t, err := jira.LoadTemplates(*jiraTemplates)
+			if err != nil {
+				logger.Error("load -jira-templates-file", "error", err)
+				os.Exit(1)
+			}
+			templates = t
+		}
 		jiraClient := jira.New(jira.Config{
-			BaseURL:        *jiraURL,
-			Email:          *jiraEmail,
-			Token:          *jiraToken,
-			Project:        *jiraProject,
-			QAContactField: *jiraQAContactField,
+			BaseURL:            *jiraURL,
+			Email:              *jiraEmail,
+			Token:              *jiraToken,
+			Projects:           splitList(*jiraProject),
+			QAContactField:     *jiraQAContactField,
+			SeverityField:      *jiraSeverityField,
+			CVEIDField:         *jiraCVEIDField,
+			CVSSField:          *jiraCVSSField,
+			EmbargoField:       *jiraEmbargoField,
+			TargetVersionField: *jiraTargetVersionField,
+			Templates:          templates,
+			Mapping:            appMapping,
+			APIVersion:         *jiraAPIVersion,
+			RequestsPerSecond:  *jiraRPS,
+			Burst:              *jiraBurst,
 		})
+		breakers = append(breakers, jiraClient.Breaker())
 		jiraLog := logger.With("component", "jira-sync")
-		logger.Info("jira sync enabled", "url", *jiraURL, "project", *jiraProject, "interval", *jiraPollInterval)
+		logger.Info("jira sync enabled", "url", *jiraURL, "project", *jiraProject, "interval", *jiraPollInterval, "full_sync_interval", *jiraFullSyncInterval)
 		jiraTx := func(ctx context.Context, fn func(jira.Store) error) error {
 			return database.InTx(ctx, func(txDB *db.DB) error {
 				return fn(txDB)
 			})
 		}
 		syncer := jira.NewSyncer(jiraClient, database, jiraTx, jiraLog)
+		syncer.SetFullSyncInterval(*jiraFullSyncInterval)
+		syncer.SetEvents(broker)
+		if *jiraWebhookSecret != "" {
+			logger.Info("jira webhook enabled")
+			jiraWebhook = syncer
+		}
+		jobs.Add(scheduler.Job{
+			Schedule: scheduler.Every(*jiraPollInterval),
+			Jitter:   *jiraPollInterval / 10,
+			Run:      syncer.SyncOnce,
+			Status:   syncer.RunStatus(),
+		})
+	}
+
+	// Sync Bugzilla bugs of legacy components if configured
+	if *bugzillaURL != "" {
+		bzClient := bugzilla.New(bugzilla.Config{
+			URL:        *bugzillaURL,
+			APIKey:     *bugzillaAPIKey,
+			Product:    *bugzillaProduct,
+			Components: splitList(*bugzillaComponents),
+		})
+		breakers = append(breakers, bzClient.Breaker())
+		logger.Info("bugzilla sync enabled", "url", *bugzillaURL, "product", *bugzillaProduct, "interval", *bugzillaPollInterval)
+		bzTx := func(ctx context.Context, fn func(bugzilla.Store) error) error {
+			return database.InTx(ctx, func(txDB *db.DB) error {
+				return fn(txDB)
+			})
+		}
+		bzSyncer := bugzilla.NewSyncer(bzClient, database, bzTx, logger.With("component", "bugzilla-sync"))
+		bzSyncer.SetEvents(broker)
+		jobs.Add(scheduler.Job{
+			Schedule: scheduler.Every(*bugzillaPollInterval),
+			Jitter:   *bugzillaPollInterval / 10,
+			Run:      bzSyncer.SyncOnce,
+			Status:   bzSyncer.RunStatus(),
+		})
+	}
+
+	// Audit released snapshots against git, and list the pull requests
+	// merged between snapshots, if a GitHub token is configured
+	var changelog *gitaudit.Changelog
+	if *githubToken != "" {
+		gh := gitaudit.NewGitHub(*githubURL, *githubToken)
+		changelog = gitaudit.NewChangelog(database, gh)
+		breakers = append(breakers, gh.Breaker())
+		auditLog := logger.With("component", "git-audit")
+		logger.Info("git audit enabled", "url", *githubURL, "tag_template", *gitTagTemplate, "branch_template", *gitBranchTemplate, "interval", *auditInterval)
+		auditor := gitaudit.NewAuditor(database, gh, gitaudit.Config{
+			TagTemplate:    *gitTagTemplate,
+			BranchTemplate: *gitBranchTemplate,
+		}, auditLog)
+		jobs.Add(every("git-audit", *auditInterval, auditor.AuditOnce))
+	}
+
+	// Verify snapshot image digests if enabled
+	if *registryVerify {
+		rc := registry.New(registry.Config{
+			Username: *registryUsername,
+			Password: *registryPassword,
+		})
+		breakers = append(breakers, rc.Breaker())
+		logger.Info("registry verification enabled", "interval", *registryInterval)
+		verifier := registry.NewVerifier(database, rc, logger.With("component", "registry-verify"))
+		jobs.Add(every("registry-verify", *registryInterval, verifier.VerifyOnce))
+	}
+
+	// Resolve test suites' PipelineRuns through Tekton Results if configured
+	if *tektonURL != "" {
+		tc := tekton.New(tekton.Config{URL: *tektonURL, Token: *tektonToken})
+		breakers = append(breakers, tc.Breaker())
+		logger.Info("tekton results enrichment enabled", "url", *tektonURL, "interval", *tektonInterval)
+		enricher := tekton.NewEnricher(database, tc, *tektonNamespace, logger.With("component", "tekton"))
+		jobs.Add(every("tekton", *tektonInterval, enricher.EnrichOnce))
+	}
+
+	// Sync the advisories configured for releases from the Errata Tool
+	if *errataURL != "" {
+		ec := errata.New(errata.Config{URL: *errataURL, Token: *errataToken})
+		breakers = append(breakers, ec.Breaker())
+		logger.Info("errata tool advisory sync enabled", "url", *errataURL, "interval", *errataInterval)
+		syncer := errata.NewSyncer(database, ec, logger.With("component", "errata"))
+		jobs.Add(every("errata", *errataInterval, syncer.SyncOnce))
+	}
+
+	// Notify chat webhooks of readiness transitions if any is configured
+	var notifyCfg notify.Config
+	var notifyProviders map[string]notify.Provider
+	if *notifyRoutes == "" {
+		*notifyRoutes = *slackRoutes
+	}
+	if *slackWebhook != "" || *notifyRoutes != "" {
+		notifyCfg = notify.Config{DefaultWebhook: *slackWebhook, DashboardURL: *dashboardURL}
+		if *notifyRoutes != "" {
+			routes, err := notify.LoadRoutes(*notifyRoutes)
+			if err != nil {
+				logger.Error("load -notify-routes", "error", err)
+				os.Exit(1)
+			}
+			notifyCfg.Routes = routes
+		}
+		var providerBreakers []*breaker.Breaker
+		notifyProviders, providerBreakers = notify.NewProviders(notifyCfg)
+		breakers = append(breakers, providerBreakers...)
+	}
+
+	// Fire outbound webhooks if any is configured
+	var hooks []webhooks.Hook
+	if *webhooksFile != "" {
+		var err error
+		hooks, err = webhooks.LoadHooks(*webhooksFile)
+		if err != nil {
+			logger.Error("load -webhooks-file", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Email digests to subscribers if a mail server is configured
+	var mailer *digest.SMTP
+	var digestCfg digest.Config
+	if *smtpAddr != "" {
+		weekday, err := parseWeekday(*digestWeekday)
+		if err != nil {
+			logger.Error("invalid -digest-weekday", "error", err)
+			os.Exit(1)
+		}
+		if *digestHour < 0 || *digestHour > 23 {
+			logger.Error("invalid -digest-hour", "hour", *digestHour)
+			os.Exit(1)
+		}
+		digestCfg = digest.Config{Hour: *digestHour, Weekday: weekday, DashboardURL: *dashboardURL}
+		mailer, err = digest.NewSMTP(digest.SMTPConfig{
+			Addr:     *smtpAddr,
+			Username: *smtpUsername,
+			Password: *smtpPassword,
+			From:     *smtpFrom,
+		})
+		if err != nil {
+			logger.Error("configure smtp", "error", err)
+			os.Exit(1)
+		}
+		breakers = append(breakers, mailer.Breaker())
+	}
+
+	// Prune old snapshots; nothing is deleted unless a limit is set
+	policy := retention.Policy{
+		MaxCount:       *retentionMaxCount,
+		MaxAge:         *retentionMaxAge,
+		KeepCandidates: *retentionKeepCandidates,
+	}
+	if *retentionRules != "" {
+		rules, err := retention.LoadRules(*retentionRules)
+		if err != nil {
+			logger.Error("load -retention-rules", "error", err)
+			os.Exit(1)
+		}
+		policy.Rules = rules
+	}
+	pruner := retention.NewPruner(database, policy, logger.With("component", "retention"))
+
+	srv := server.New(database, objects, *addr, *jiraURL, *jiraProject, logger)
+	srv.SetRetention(pruner)
+	srv.SetAppMapping(appMapping)
+	for name, store := range sourceStores {
+		srv.AddSource(name, store)
+	}
+	if changelog != nil {
+		srv.SetChangelog(changelog)
+	}
+	var dispatcher *webhooks.Dispatcher
+	if len(hooks) > 0 {
+		dispatcher = webhooks.NewDispatcher(database, srv, hooks, logger.With("component", "webhooks"))
+		breakers = append(breakers, dispatcher.Breakers()...)
+	}
+	srv.SetBreakers(breakers...)
+	srv.SetEvents(broker)
+	srv.SetRequireImageDigests(*registryVerify)
+	srv.SetRequireEC(*requireEC)
+	srv.SetFreezeWindow(*freezeWindow)
+	srv.SetIssueStaleAfter(*jiraStaleAfter)
+	srv.SetSnapshotIngester(ingester)
+	for source, syncer := range syncersBySource {
+		srv.SetSnapshotReingester(source, syncer)
+		srv.SetSnapshotRefresher(source, syncer)
+	}
+	srv.SetJiraWebhook(jiraWebhook, *jiraWebhookSecret, *jiraWebhookQuerySecret)
+	if err := srv.SetCVESeverityGate(*cveSeverity); err != nil {
+		logger.Error("invalid -readiness-cve-severity", "error", err)
+		os.Exit(1)
+	}
+	srv.SetAdmin(*adminToken, logLevels)
+	if *apiTokensFile != "" {
+		tokens, err := server.LoadAPITokens(*apiTokensFile)
+		if err != nil {
+			logger.Error("load api tokens", "error", err)
+			os.Exit(1)
+		}
+		srv.SetAPITokens(tokens)
+		logger.Info("api tokens loaded", "tokens", len(tokens))
+	}
+	if *authGroupsHeader != "" {
+		roles, err := server.ParseGroupRoles(*groupRoles)
+		if err != nil {
+			logger.Error("invalid -group-roles", "error", err)
+			os.Exit(1)
+		}
+		srv.SetGroupRoles(*authGroupsHeader, roles)
+		logger.Info("group roles enabled", "header", *authGroupsHeader, "groups", len(roles))
+	}
+	srv.SetPublicReads(*publicReads)
+	if notifyProviders != nil {
+		logger.Info("notifications enabled", "routes", len(notifyCfg.Routes), "providers", len(notifyProviders), "interval", *notifyInterval)
+		notifier := notify.NewNotifier(database, srv, notifyProviders, notifyCfg, logger.With("component", "notify"))
+		jobs.Add(every("notify", *notifyInterval, notifier.NotifyOnce))
+	}
+	if dispatcher != nil {
+		logger.Info("outbound webhooks enabled", "hooks", len(hooks), "interval", *webhooksInterval)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			syncer.Run(ctx, *jiraPollInterval)
+			dispatcher.Run(ctx, broker, *webhooksInterval)
 		}()
 	}
+	if mailer != nil {
+		logger.Info("email digests enabled", "hour", digestCfg.Hour, "weekday", digestCfg.Weekday)
+		digester := digest.NewDigester(database, srv, mailer, digestCfg, logger.With("component", "digest"))
+		jobs.Add(every("digest", digest.DefaultCheckInterval, func(ctx context.Context) {
+			digester.SendDue(ctx, time.Now())
+		}))
+	}
+	recorder := history.NewRecorder(database, srv, logger.With("component", "history"))
+	jobs.Add(every("history", *historyInterval, recorder.RecordOnce))
+	// The pruner runs even without limits, since applications can be
+	// given their own retention through the API.
+	pruning := every("retention", *retentionInterval, pruner.PruneOnce)
+	if *retentionSchedule != "" {
+		sched, err := scheduler.ParseCron(*retentionSchedule)
+		if err != nil {
+			logger.Error("invalid -retention-schedule", "error", err)
+			os.Exit(1)
+		}
+		pruning.Schedule, pruning.Jitter = sched, 0
+	}
+	if policy.Limited() {
+		logger.Info("snapshot retention enabled", "rules", len(policy.Rules), "interval", *retentionInterval, "schedule", *retentionSchedule)
+	}
+	jobs.Add(pruning)
 
-	srv := server.New(database, s3c, *addr, *jiraURL, *jiraProject, logger)
+	srv.SetSyncers(jobs.Trackers()...)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		jobs.Run(ctx)
+	}()
 	if err := srv.Run(ctx); err != nil {
 		logger.Error("server", "error", err)
 		os.Exit(1)
@@ -115,9 +748,39 @@ func main() {
 	logger.Info("all background tasks stopped")
 }
 
+// every returns a job running run every d, delayed by up to a tenth of d
+// so that jobs with the same interval do not start at once.
+func every(name string, d time.Duration, run func(context.Context)) scheduler.Job {
+	return scheduler.Job{Name: name, Schedule: scheduler.Every(d), Jitter: d / 10, Run: run}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func envOrDefault(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
 	}
 	return fallback
 }
+
+// parseWeekday parses the English name of a day of the week, such as
+// "monday" or "Mon".
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
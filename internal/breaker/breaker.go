@@ -0,0 +1,186 @@
+// Package breaker implements a circuit breaker for calls to external
+// dependencies (S3, JIRA). After a run of consecutive failures the breaker
+// opens and calls fail fast with ErrOpen until a cooldown elapses; then a
+// single probe call is let through. A failed probe re-opens the breaker with
+// a doubled cooldown (capped), a successful one closes it.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned (wrapped) by Do while the breaker is open.
+var ErrOpen = errors.New("circuit breaker open")
+
+// Defaults used by the S3 and JIRA clients.
+const (
+	DefaultThreshold   = 5
+	DefaultCooldown    = 30 * time.Second
+	DefaultMaxCooldown = 10 * time.Minute
+)
+
+// State is the breaker state.
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	name         string
+	threshold    int
+	baseCooldown time.Duration
+	maxCooldown  time.Duration
+	isFailure    func(error) bool
+	now          func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	cooldown time.Duration
+	openedAt time.Time
+	retryAt  time.Time
+	probing  bool
+	lastErr  error
+}
+
+// New creates a closed breaker that opens after threshold consecutive
+// failures, waiting cooldown (doubling up to maxCooldown) before probing.
+func New(name string, threshold int, cooldown, maxCooldown time.Duration) *Breaker {
+	return &Breaker{
+		name:         name,
+		threshold:    max(threshold, 1),
+		baseCooldown: cooldown,
+		maxCooldown:  max(maxCooldown, cooldown),
+		cooldown:     cooldown,
+		isFailure:    defaultIsFailure,
+		now:          time.Now,
+	}
+}
+
+// SetFailurePredicate overrides which errors count as failures. Errors for
+// which fn returns false (e.g. 404s) are passed through but reset the
+// failure count like a success, since the dependency did answer. Caller
+// cancellation (context.Canceled) is never recorded either way.
+func (b *Breaker) SetFailurePredicate(fn func(error) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.isFailure = fn
+}
+
+// Name returns the breaker name.
+func (b *Breaker) Name() string { return b.name }
+
+func defaultIsFailure(err error) bool {
+	return err != nil
+}
+
+// Do calls fn unless the breaker is open, and records the outcome.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.record(err)
+	return err
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case Open:
+		if b.now().Before(b.retryAt) {
+			return fmt.Errorf("%s: %w (retry at %s)", b.name, ErrOpen, b.retryAt.Format(time.RFC3339))
+		}
+		b.state = HalfOpen
+		b.probing = true
+	case HalfOpen:
+		if b.probing {
+			return fmt.Errorf("%s: %w (probe in progress)", b.name, ErrOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if !b.isFailure(err) {
+		b.failures = 0
+		if b.state != Closed {
+			b.state = Closed
+			b.cooldown = b.baseCooldown
+		}
+		return
+	}
+
+	b.lastErr = err
+	b.failures++
+	switch {
+	case b.state == HalfOpen:
+		b.cooldown = min(b.cooldown*2, b.maxCooldown)
+		b.trip()
+	case b.failures >= b.threshold:
+		b.trip()
+	}
+}
+
+func (b *Breaker) trip() {
+	b.state = Open
+	b.openedAt = b.now()
+	b.retryAt = b.openedAt.Add(b.cooldown)
+}
+
+// Status is a point-in-time view of a breaker, suitable for JSON.
+type Status struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// Status returns the current breaker state.
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := Status{
+		Name:                b.name,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != Closed {
+		openedAt, retryAt := b.openedAt, b.retryAt
+		st.OpenedAt, st.RetryAt = &openedAt, &retryAt
+	}
+	if b.lastErr != nil {
+		st.LastError = b.lastErr.Error()
+	}
+	return st
+}
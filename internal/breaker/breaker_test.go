@@ -0,0 +1,80 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold int) (*Breaker, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := New("test", threshold, time.Minute, 4*time.Minute)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreakerTripsAndRecovers(t *testing.T) {
+	b, now := newTestBreaker(3)
+	boom := errors.New("boom")
+	fail := func() error { return boom }
+	calls := 0
+	ok := func() error { calls++; return nil }
+
+	for i := 0; i < 3; i++ {
+		if err := b.Do(fail); !errors.Is(err, boom) {
+			t.Fatalf("call %d: got %v, want boom", i, err)
+		}
+	}
+	if st := b.Status(); st.State != "open" || st.LastError != "boom" {
+		t.Fatalf("after threshold: got %+v", st)
+	}
+	if err := b.Do(ok); !errors.Is(err, ErrOpen) || calls != 0 {
+		t.Fatalf("open breaker: got %v (calls=%d), want ErrOpen without calling fn", err, calls)
+	}
+
+	// Failed probe doubles the cooldown.
+	*now = now.Add(time.Minute)
+	if err := b.Do(fail); !errors.Is(err, boom) {
+		t.Fatalf("probe: got %v, want boom", err)
+	}
+	*now = now.Add(time.Minute)
+	if err := b.Do(ok); !errors.Is(err, ErrOpen) {
+		t.Fatalf("after failed probe, 1m later: got %v, want ErrOpen", err)
+	}
+	*now = now.Add(time.Minute)
+	if err := b.Do(ok); err != nil || calls != 1 {
+		t.Fatalf("successful probe: got %v (calls=%d)", err, calls)
+	}
+	if st := b.Status(); st.State != "closed" || st.ConsecutiveFailures != 0 || st.RetryAt != nil {
+		t.Errorf("after recovery: got %+v", st)
+	}
+}
+
+func TestBreakerIgnoresNonFailures(t *testing.T) {
+	b, _ := newTestBreaker(2)
+	notFound := errors.New("not found")
+	b.SetFailurePredicate(func(err error) bool { return err != nil && !errors.Is(err, notFound) })
+
+	for i := 0; i < 5; i++ {
+		_ = b.Do(func() error { return notFound })
+	}
+	for i := 0; i < 5; i++ {
+		_ = b.Do(func() error { return context.Canceled })
+	}
+	if st := b.Status(); st.State != "closed" {
+		t.Errorf("non-failures tripped the breaker: %+v", st)
+	}
+}
+
+func TestBreakerSuccessResetsCount(t *testing.T) {
+	b, _ := newTestBreaker(2)
+	boom := errors.New("boom")
+	for i := 0; i < 5; i++ {
+		_ = b.Do(func() error { return boom })
+		_ = b.Do(func() error { return nil })
+	}
+	if st := b.Status(); st.State != "closed" {
+		t.Errorf("interleaved successes should keep breaker closed: %+v", st)
+	}
+}
@@ -0,0 +1,178 @@
+// Package bugzilla syncs the Bugzilla bugs of legacy components into the
+// issue set of each release, alongside its JIRA issues.
+package bugzilla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// pageSize is the number of bugs requested per search page.
+const pageSize = 500
+
+// Config holds Bugzilla connection settings.
+type Config struct {
+	URL    string // e.g. https://bugzilla.redhat.com
+	APIKey string // Bugzilla API key
+	// Product is the Bugzilla product bugs are searched in, e.g.
+	// "Red Hat Quay".
+	Product string
+	// Components limits searches to these Bugzilla components. Empty means
+	// every component of Product.
+	Components []string
+}
+
+// Client searches bugs through the Bugzilla REST API.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// New creates a Bugzilla client.
+func New(cfg Config) *Client {
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	c := &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New("bugzilla", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500
+		}
+		return err != nil
+	})
+	return c
+}
+
+// Breaker returns the circuit breaker guarding calls to Bugzilla.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+// BugURL returns the web page of a bug.
+func (c *Client) BugURL(id int64) string {
+	return c.cfg.URL + "/show_bug.cgi?id=" + strconv.FormatInt(id, 10)
+}
+
+// User is the part of a Bugzilla user the client reads.
+type User struct {
+	Name     string `json:"name"`
+	RealName string `json:"real_name"`
+}
+
+// DisplayName returns the user's real name, or their login if it is unset.
+func (u *User) DisplayName() string {
+	if u == nil {
+		return ""
+	}
+	if u.RealName != "" {
+		return u.RealName
+	}
+	return u.Name
+}
+
+// Flag is a Bugzilla flag such as blocker+.
+type Flag struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Bug is the part of a Bugzilla bug the syncer reads.
+type Bug struct {
+	ID             int64    `json:"id"`
+	Summary        string   `json:"summary"`
+	Status         string   `json:"status"`
+	Resolution     string   `json:"resolution"`
+	Priority       string   `json:"priority"`
+	Severity       string   `json:"severity"`
+	Keywords       []string `json:"keywords"`
+	Components     []string `json:"component"`
+	AssignedTo     *User    `json:"assigned_to_detail"`
+	QAContact      *User    `json:"qa_contact_detail"`
+	LastChangeTime string   `json:"last_change_time"`
+	Flags          []Flag   `json:"flags"`
+}
+
+// bugFields are the fields requested with each bug.
+var bugFields = []string{
+	"id", "summary", "status", "resolution", "priority", "severity", "keywords",
+	"component", "assigned_to", "qa_contact", "last_change_time", "flags",
+}
+
+// SearchBugs returns the bugs of the configured product and components
+// whose target release is targetRelease.
+func (c *Client) SearchBugs(ctx context.Context, targetRelease string) ([]Bug, error) {
+	q := url.Values{
+		"product":        {c.cfg.Product},
+		"target_release": {targetRelease},
+		"include_fields": {strings.Join(bugFields, ",")},
+		"limit":          {strconv.Itoa(pageSize)},
+	}
+	for _, comp := range c.cfg.Components {
+		q.Add("component", comp)
+	}
+	var bugs []Bug
+	for offset := 0; ; offset += pageSize {
+		q.Set("offset", strconv.Itoa(offset))
+		var page struct {
+			Bugs []Bug `json:"bugs"`
+		}
+		err := c.breaker.Do(func() error {
+			return c.get(ctx, c.cfg.URL+"/rest/bug?"+q.Encode(), &page)
+		})
+		if err != nil {
+			return nil, err
+		}
+		bugs = append(bugs, page.Bugs...)
+		if len(page.Bugs) < pageSize {
+			return bugs, nil
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	if c.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return &statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("bugzilla returned %d: %s", e.statusCode, e.body)
+}
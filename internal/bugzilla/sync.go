@@ -0,0 +1,178 @@
+package bugzilla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/runstatus"
+)
+
+// Store is the persistence contract the Syncer depends on. Bugs are stored
+// as issues of model.BugzillaProject, next to the release's JIRA issues.
+type Store interface {
+	ListActiveReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error)
+	UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord) error
+	DeleteBugzillaBugsNotIn(ctx context.Context, fixVersion string, keys []string) error
+}
+
+// TxFunc wraps a function in a database transaction, passing a tx-scoped Store.
+type TxFunc func(ctx context.Context, fn func(Store) error) error
+
+// Syncer periodically mirrors the Bugzilla bugs targeted at each active
+// release into its issue set.
+type Syncer struct {
+	client *Client
+	store  Store
+	withTx TxFunc
+	logger *slog.Logger
+	status *runstatus.Tracker
+	events *events.Broker
+}
+
+// NewSyncer creates a Syncer.
+func NewSyncer(client *Client, store Store, withTx TxFunc, logger *slog.Logger) *Syncer {
+	return &Syncer{client: client, store: store, withTx: withTx, logger: logger, status: runstatus.New("bugzilla")}
+}
+
+// RunStatus returns the tracker of the syncer's polls, for the sync status
+// API.
+func (s *Syncer) RunStatus() *runstatus.Tracker {
+	return s.status
+}
+
+// SetEvents makes the syncer announce each release whose bugs changed on b.
+func (s *Syncer) SetEvents(b *events.Broker) {
+	s.events = b
+}
+
+// SyncOnce syncs the bugs of every active release. Releases are the ones
+// the JIRA syncer discovered; each is searched in Bugzilla by its
+// TargetRelease.
+func (s *Syncer) SyncOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	run := s.status.Start()
+	defer run.Finish()
+
+	releases, err := s.store.ListActiveReleaseVersions(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list active releases", "error", err)
+		run.Fail(err)
+		return
+	}
+	for _, rel := range releases {
+		n, err := s.syncRelease(ctx, rel.Name)
+		if errors.Is(err, breaker.ErrOpen) {
+			s.logger.WarnContext(ctx, "skipping sync, upstream unavailable", "error", err)
+			run.Fail(err)
+			return
+		}
+		run.Add(n)
+		run.Fail(err)
+	}
+}
+
+// syncRelease stores the bugs targeted at fixVersion and removes the ones
+// no longer targeted at it. It returns the number of bugs stored.
+func (s *Syncer) syncRelease(ctx context.Context, fixVersion string) (int, error) {
+	target := TargetRelease(fixVersion)
+	bugs, err := s.client.SearchBugs(ctx, target)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "search bugs", "version", fixVersion, "target_release", target, "error", err)
+		return 0, fmt.Errorf("search bugs of %s: %w", fixVersion, err)
+	}
+	if err := s.withTx(ctx, func(txStore Store) error {
+		keys := make([]string, 0, len(bugs))
+		for _, b := range bugs {
+			issue := s.issueRecord(b, fixVersion)
+			keys = append(keys, issue.Key)
+			if err := txStore.UpsertJiraIssue(ctx, issue); err != nil {
+				return fmt.Errorf("upsert bug %d: %w", b.ID, err)
+			}
+		}
+		if err := txStore.DeleteBugzillaBugsNotIn(ctx, fixVersion, keys); err != nil {
+			return fmt.Errorf("cleanup bugs: %w", err)
+		}
+		return nil
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "sync version", "version", fixVersion, "error", err)
+		return 0, fmt.Errorf("sync version %s: %w", fixVersion, err)
+	}
+
+	s.logger.InfoContext(ctx, "synced bugs", "count", len(bugs), "version", fixVersion)
+	if len(bugs) > 0 {
+		s.events.Publish(events.Event{Kind: events.KindIssues, Release: fixVersion})
+	}
+	return len(bugs), nil
+}
+
+// TargetRelease returns the Bugzilla target release of a JIRA fixVersion:
+// the fixVersion from its first digit on, e.g. 3.16.3 for quay-v3.16.3.
+func TargetRelease(fixVersion string) string {
+	if i := strings.IndexAny(fixVersion, "0123456789"); i >= 0 {
+		return fixVersion[i:]
+	}
+	return fixVersion
+}
+
+// cvePattern matches a CVE ID such as CVE-2026-1234.
+var cvePattern = regexp.MustCompile(`CVE-\d{4}-\d{4,}`)
+
+// Bugzilla priorities and severities mapped to the JIRA priority names and
+// the Red Hat impact scale the rest of the dashboard uses.
+var (
+	priorities = map[string]string{"urgent": "Critical", "high": "Major", "medium": "Normal", "low": "Minor"}
+	severities = map[string]string{"urgent": "Critical", "high": "Important", "medium": "Moderate", "low": "Low"}
+)
+
+// issueRecord converts a bug to the issue row stored under fixVersion.
+func (s *Syncer) issueRecord(b Bug, fixVersion string) *model.JiraIssueRecord {
+	key := model.BugzillaProject + "-" + strconv.FormatInt(b.ID, 10)
+
+	// RELEASE_PENDING follows VERIFIED; storing it as such counts it done.
+	status := b.Status
+	if status == "RELEASE_PENDING" {
+		status = "VERIFIED"
+	}
+
+	issueType := "Bug"
+	cveID := cvePattern.FindString(b.Summary)
+	if cveID != "" && slices.ContainsFunc(b.Keywords, func(k string) bool { return strings.HasPrefix(k, "Security") }) {
+		issueType = "Vulnerability"
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339, b.LastChangeTime)
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+
+	return &model.JiraIssueRecord{
+		Key:        key,
+		Project:    model.BugzillaProject,
+		Summary:    b.Summary,
+		Status:     status,
+		Priority:   priorities[strings.ToLower(b.Priority)],
+		Labels:     strings.Join(b.Keywords, ","),
+		FixVersion: fixVersion,
+		Assignee:   b.AssignedTo.DisplayName(),
+		IssueType:  issueType,
+		Resolution: b.Resolution,
+		Link:       s.client.BugURL(b.ID),
+		QAContact:  b.QAContact.DisplayName(),
+		Severity:   severities[strings.ToLower(b.Severity)],
+		Components: strings.Join(b.Components, ","),
+		UpdatedAt:  updatedAt,
+		CVEID:      cveID,
+		Blocker:    slices.Contains(b.Flags, Flag{Name: "blocker", Status: "+"}),
+	}
+}
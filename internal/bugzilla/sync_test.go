@@ -0,0 +1,114 @@
+package bugzilla
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestTargetRelease(t *testing.T) {
+	for fixVersion, want := range map[string]string{
+		"quay-v3.16.3": "3.16.3",
+		"3.15.0":       "3.15.0",
+		"unversioned":  "unversioned",
+	} {
+		if got := TargetRelease(fixVersion); got != want {
+			t.Errorf("TargetRelease(%q) = %q, want %q", fixVersion, got, want)
+		}
+	}
+}
+
+func TestSyncOnce(t *testing.T) {
+	bugs := []Bug{
+		{ID: 100, Summary: "builder image fails to start", Status: "ON_QA", Priority: "high", Severity: "medium",
+			Components: []string{"quay-builder"}, AssignedTo: &User{Name: "dev@example.com", RealName: "Dev One"},
+			QAContact: &User{Name: "qe@example.com"}, LastChangeTime: "2026-03-02T10:00:00Z",
+			Flags: []Flag{{Name: "blocker", Status: "+"}}},
+		{ID: 101, Summary: "CVE-2026-1111 quay-builder: path traversal", Status: "RELEASE_PENDING", Severity: "high",
+			Keywords: []string{"Security", "SecurityTracking"}, Components: []string{"quay-builder"}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/rest/bug" || r.Header.Get("Authorization") != "Bearer key" ||
+			q.Get("product") != "Red Hat Quay" || q.Get("component") != "quay-builder" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		out := struct {
+			Bugs []Bug `json:"bugs"`
+		}{Bugs: []Bug{}}
+		if q.Get("target_release") == "3.16.3" {
+			out.Bugs = bugs
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+	t.Cleanup(srv.Close)
+
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	jiraIssue := &model.JiraIssueRecord{Key: "PROJQUAY-1", Project: "PROJQUAY", Summary: "fix bug", Status: "Open",
+		IssueType: "Bug", FixVersion: "quay-v3.16.3", UpdatedAt: time.Now()}
+	if err := database.UpsertJiraIssue(ctx, jiraIssue); err != nil {
+		t.Fatal(err)
+	}
+
+	withTx := func(ctx context.Context, fn func(Store) error) error {
+		return database.InTx(ctx, func(txDB *db.DB) error { return fn(txDB) })
+	}
+	client := New(Config{URL: srv.URL, APIKey: "key", Product: "Red Hat Quay", Components: []string{"quay-builder"}})
+	syncer := NewSyncer(client, database, withTx, slog.Default())
+	syncer.SyncOnce(ctx)
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 3 || summary.Open != 2 || summary.Verified != 1 || summary.CVEs != 1 || summary.OpenBlockers != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.16.3", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey := map[string]model.JiraIssueRecord{}
+	for _, is := range issues {
+		byKey[is.Key] = is
+	}
+	if bz := byKey["BZ-100"]; bz.Project != model.BugzillaProject || bz.Priority != "Major" || bz.Assignee != "Dev One" ||
+		bz.QAContact != "qe@example.com" || !bz.Blocker || bz.Link != srv.URL+"/show_bug.cgi?id=100" {
+		t.Errorf("BZ-100 = %+v", bz)
+	}
+	if cve := byKey["BZ-101"]; cve.IssueType != "Vulnerability" || cve.CVEID != "CVE-2026-1111" ||
+		cve.Severity != "Important" || cve.Status != "VERIFIED" {
+		t.Errorf("BZ-101 = %+v", cve)
+	}
+
+	// A JIRA full sync leaves the bugs alone, and a bug retargeted away is
+	// removed without touching the JIRA issues.
+	if err := database.DeleteJiraIssuesNotIn(ctx, "quay-v3.16.3", []string{"PROJQUAY-1"}); err != nil {
+		t.Fatal(err)
+	}
+	bugs = bugs[:1]
+	syncer.SyncOnce(ctx)
+	if issues, err = database.ListJiraIssues(ctx, "quay-v3.16.3", model.IssueFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Key != "BZ-100" || issues[1].Key != "PROJQUAY-1" {
+		t.Errorf("issues after resync = %+v", issues)
+	}
+}
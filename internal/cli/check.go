@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// signalRank orders readiness signals from best to worst. Unknown signals
+// rank worst.
+var signalRank = map[string]int{"green": 0, "yellow": 1, "red": 2}
+
+func rank(signal string) int {
+	if r, ok := signalRank[signal]; ok {
+		return r
+	}
+	return len(signalRank)
+}
+
+// check gates on a release's readiness: it exits 1 while the signal is at
+// or below -fail-on, and with -wait polls until it passes or -timeout
+// elapses.
+func check(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	newClient := clientFlags(fs)
+	release := fs.String("release", "", "release (JIRA fixVersion) to check, e.g. quay-v3.16.3")
+	failOn := fs.String("fail-on", "yellow", "fail when the readiness signal is this or worse: yellow or red")
+	wait := fs.Bool("wait", false, "poll until the signal passes instead of failing at once")
+	timeout := fs.Duration("timeout", 30*time.Minute, "with -wait, how long to wait for the signal to pass")
+	interval := fs.Duration("interval", 30*time.Second, "with -wait, how often to poll")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: release-readiness check -release <name> [-fail-on yellow|red] [-wait [-timeout 30m]]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if *release == "" {
+		fmt.Fprintln(stderr, "check: -release is required")
+		return exitError
+	}
+	if *failOn != "yellow" && *failOn != "red" {
+		fmt.Fprintf(stderr, "check: -fail-on must be yellow or red, not %q\n", *failOn)
+		return exitError
+	}
+	if *wait && *interval <= 0 {
+		fmt.Fprintln(stderr, "check: -interval must be positive")
+		return exitError
+	}
+	c := newClient()
+
+	var deadline <-chan time.Time
+	if *wait {
+		timer := time.NewTimer(*timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	path := "/api/v1/releases/" + url.PathEscape(*release) + "/readiness"
+	for {
+		var r model.ReadinessResponse
+		err := c.get(ctx, path, nil, &r)
+		var apiErr *apiError
+		switch {
+		case err == nil:
+			fmt.Fprintf(stdout, "%s is %s: %s\n", *release, r.Signal, r.Message)
+			if rank(r.Signal) < rank(*failOn) {
+				return exitOK
+			}
+			if !*wait {
+				return exitFail
+			}
+		case errors.As(err, &apiErr) && apiErr.statusCode < 500:
+			// An unknown release or a missing token will not fix itself.
+			fmt.Fprintf(stderr, "check %s: %v\n", *release, err)
+			return exitError
+		case !*wait:
+			fmt.Fprintf(stderr, "check %s: %v\n", *release, err)
+			return exitError
+		default:
+			fmt.Fprintf(stderr, "check %s: %v; retrying\n", *release, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitError
+		case <-deadline:
+			fmt.Fprintf(stderr, "check %s: still not passing after %s\n", *release, *timeout)
+			return exitFail
+		case <-time.After(*interval):
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestCheck(t *testing.T) {
+	signals := map[string][]string{
+		"quay-v3.16.3": {"red", "yellow", "green"},
+		"quay-v3.17.0": {"yellow"},
+	}
+	var polls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer t0ken" {
+			t.Errorf("authorization: got %q", got)
+		}
+		release := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/releases/"), "/readiness")
+		seq, ok := signals[release]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "release not found"})
+			return
+		}
+		polls++
+		signal := seq[min(polls, len(seq))-1]
+		_ = json.NewEncoder(w).Encode(model.ReadinessResponse{Signal: signal, Message: "because"})
+	}))
+	defer srv.Close()
+
+	run := func(args ...string) (int, string, string) {
+		t.Helper()
+		polls = 0
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"check", "-server", srv.URL + "/", "-token", "t0ken"}, args...)
+		code := Main(t.Context(), args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	if code, out, _ := run("-release", "quay-v3.16.3"); code != exitFail || out != "quay-v3.16.3 is red: because\n" {
+		t.Errorf("red: got %d %q", code, out)
+	}
+	if code, _, _ := run("-release", "quay-v3.17.0", "-fail-on", "red"); code != exitOK {
+		t.Errorf("yellow with -fail-on red: got %d", code)
+	}
+	if code, out, _ := run("-release", "quay-v3.16.3", "-wait", "-interval", "1ms"); code != exitOK || polls != 3 || !strings.HasSuffix(out, "is green: because\n") {
+		t.Errorf("wait: got %d after %d polls: %q", code, polls, out)
+	}
+	if code, _, _ := run("-release", "quay-v3.17.0", "-wait", "-interval", "1ms", "-timeout", "20ms"); code != exitFail {
+		t.Errorf("wait timeout: got %d", code)
+	}
+	if code, _, errOut := run("-release", "quay-v9.9.9", "-wait"); code != exitError || !strings.Contains(errOut, "release not found") {
+		t.Errorf("unknown release: got %d %q", code, errOut)
+	}
+	for _, args := range [][]string{{}, {"-release", "quay-v3.16.3", "-fail-on", "green"}, {"-bogus"}} {
+		if code, _, _ := run(args...); code != exitError {
+			t.Errorf("check %v: got %d, want usage error", args, code)
+		}
+	}
+}
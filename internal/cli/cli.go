@@ -0,0 +1,175 @@
+// Package cli implements the release-readiness subcommands. Most talk to a
+// running dashboard over its API, such as the readiness gate and snapshot
+// reporting for CI pipelines; ingest-dir works on local files alone.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Exit codes of the subcommands.
+const (
+	exitOK    = 0
+	exitFail  = 1 // the command ran and its check failed
+	exitError = 2 // invalid usage, or the dashboard could not be queried
+)
+
+// command is a subcommand: it parses args and returns the exit code.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"check", "gate on a release's readiness signal", check},
+	{"get", "list releases, snapshots or issues", get},
+	{"report", "push a snapshot and its test results to the ingest API", report},
+	{"ingest-dir", "check and ingest a local directory laid out like the S3 bucket", ingestDir},
+}
+
+// IsCommand reports whether name is a subcommand, so that the binary runs
+// the server when it is invoked with flags alone.
+func IsCommand(name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Main runs the subcommand args[0] with the rest of args and returns the
+// process exit code.
+func Main(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		for _, c := range commands {
+			if c.name == args[0] {
+				return c.run(ctx, args[1:], stdout, stderr)
+			}
+		}
+	}
+	fmt.Fprintln(stderr, "usage: release-readiness [flags]            run the dashboard")
+	fmt.Fprintln(stderr, "       release-readiness <command> [flags]")
+	fmt.Fprintln(stderr, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(stderr, "  %-12s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(stderr, "\nRun release-readiness <command> -h for a command's flags.")
+	if len(args) > 0 && args[0] == "help" {
+		return exitOK
+	}
+	return exitError
+}
+
+// client calls the dashboard API.
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// clientFlags defines the flags locating the dashboard on fs and returns a
+// function creating the client once fs is parsed.
+func clientFlags(fs *flag.FlagSet) func() *client {
+	server := fs.String("server", envOrDefault("RELEASE_READINESS_URL", "http://localhost:8080"), "dashboard URL (env RELEASE_READINESS_URL)")
+	token := fs.String("token", os.Getenv("RELEASE_READINESS_TOKEN"), "API token, needed to report and, if reads require one, to check (env RELEASE_READINESS_TOKEN)")
+	return func() *client {
+		return &client{
+			baseURL:    strings.TrimSuffix(*server, "/"),
+			token:      *token,
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		}
+	}
+}
+
+// apiError is an error response of the dashboard API.
+type apiError struct {
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.statusCode, http.StatusText(e.statusCode), e.message)
+}
+
+// get decodes the JSON response to a GET of path, with query, into v.
+func (c *client) get(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, v)
+	return err
+}
+
+// post sends body as JSON to path and decodes the JSON response into v. It
+// returns the response status code.
+func (c *client) post(ctx context.Context, path string, body, v any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, v)
+}
+
+// do sends req and decodes its JSON response into v, if v is not nil. It
+// returns the response status code.
+func (c *client) do(req *http.Request, v any) (int, error) {
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &e) != nil || e.Error == "" {
+			e.Error = strings.TrimSpace(string(body))
+		}
+		return resp.StatusCode, &apiError{statusCode: resp.StatusCode, message: e.Error}
+	}
+	if v == nil {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp.StatusCode, fmt.Errorf("decode %s response: %w", req.URL.Path, err)
+	}
+	return resp.StatusCode, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// resource is a kind of object get lists.
+type resource struct {
+	name  string
+	usage string
+	flags func(fs *flag.FlagSet) lister
+}
+
+// lister fetches and prints the objects of a resource once the flags
+// defined by resource.flags are parsed.
+type lister struct {
+	fetch func(ctx context.Context, c *client) (any, error)
+	table func(w io.Writer, v any)
+}
+
+var resources = []resource{
+	{"releases", "[-all] [-name glob] [-signal green|yellow|red]", releasesResource},
+	{"snapshots", "[-application app] [-limit 20] [-failed]", snapshotsResource},
+	{"issues", "-release name [-type t] [-status s] [-label l] [-assignee a] [-open] [-blockers]", issuesResource},
+}
+
+// get lists releases, snapshots or issues as a table, JSON or YAML.
+func get(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var res *resource
+	if len(args) > 0 {
+		if i := slices.IndexFunc(resources, func(r resource) bool { return r.name == args[0] }); i >= 0 {
+			res = &resources[i]
+		}
+	}
+	if res == nil {
+		fmt.Fprintln(stderr, "usage: release-readiness get <resource> [flags]")
+		for _, r := range resources {
+			fmt.Fprintf(stderr, "       release-readiness get %s %s\n", r.name, r.usage)
+		}
+		return exitError
+	}
+
+	fs := flag.NewFlagSet("get "+res.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	newClient := clientFlags(fs)
+	format := fs.String("o", "table", "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: release-readiness get %s %s [-o table|json|yaml]\n", res.name, res.usage)
+		fs.PrintDefaults()
+	}
+	l := res.flags(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitError
+	}
+	if *format != "table" && *format != "json" && *format != "yaml" {
+		fmt.Fprintf(stderr, "get %s: -o must be table, json or yaml, not %q\n", res.name, *format)
+		return exitError
+	}
+
+	v, err := l.fetch(ctx, newClient())
+	if err != nil {
+		fmt.Fprintf(stderr, "get %s: %v\n", res.name, err)
+		return exitError
+	}
+	if err := writeOutput(stdout, *format, v, l.table); err != nil {
+		fmt.Fprintf(stderr, "get %s: %v\n", res.name, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// writeOutput writes v to w in format. YAML output uses the JSON field
+// names of the API.
+func writeOutput(w io.Writer, format string, v any, table func(io.Writer, any)) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		table(tw, v)
+		return tw.Flush()
+	}
+}
+
+// releasesResource lists the releases of the overview with their readiness,
+// active ones only unless -all is given.
+func releasesResource(fs *flag.FlagSet) lister {
+	all := fs.Bool("all", false, "include released and archived releases")
+	name := fs.String("name", "", "only releases matching this glob, e.g. quay-v3.16.*")
+	signal := fs.String("signal", "", "only releases with this readiness signal")
+	return lister{
+		fetch: func(ctx context.Context, c *client) (any, error) {
+			if _, err := path.Match(*name, ""); err != nil {
+				return nil, fmt.Errorf("-name %q: %w", *name, err)
+			}
+			var overviews []model.ReleaseOverview
+			if err := c.get(ctx, "/api/v1/releases/overview", nil, &overviews); err != nil {
+				return nil, err
+			}
+			return slices.DeleteFunc(overviews, func(o model.ReleaseOverview) bool {
+				if !*all && (o.Release.Released || o.Release.Archived) {
+					return true
+				}
+				if ok, _ := path.Match(*name, o.Release.Name); *name != "" && !ok {
+					return true
+				}
+				return *signal != "" && o.Readiness.Signal != *signal
+			}), nil
+		},
+		table: func(w io.Writer, v any) {
+			fmt.Fprintln(w, "NAME\tSIGNAL\tDUE\tISSUES\tSNAPSHOT\tMESSAGE")
+			for _, o := range v.([]model.ReleaseOverview) {
+				due, issues, snap := "-", "-", "-"
+				if o.Release.DueDate != nil {
+					due = o.Release.DueDate.Format(time.DateOnly)
+				}
+				if s := o.IssueSummary; s != nil {
+					issues = fmt.Sprintf("%d/%d open", s.Open, s.Total)
+				}
+				if o.Snapshot != nil {
+					snap = o.Snapshot.Name
+				}
+				signal := o.Readiness.Signal
+				if o.Release.Released {
+					signal = "released"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", o.Release.Name, signal, due, issues, snap, o.Readiness.Message)
+			}
+		},
+	}
+}
+
+// snapshotsResource lists the latest snapshots, newest first.
+func snapshotsResource(fs *flag.FlagSet) lister {
+	application := fs.String("application", "", "only snapshots of this application, e.g. quay-v3-16")
+	limit := fs.Int("limit", 20, "how many of the latest snapshots to list")
+	failed := fs.Bool("failed", false, "only snapshots whose tests did not pass")
+	return lister{
+		fetch: func(ctx context.Context, c *client) (any, error) {
+			if *limit <= 0 {
+				return nil, fmt.Errorf("-limit must be positive")
+			}
+			q := url.Values{"limit": {strconv.Itoa(*limit)}}
+			if *application != "" {
+				q.Set("application", *application)
+			}
+			var snaps []model.SnapshotRecord
+			if err := c.get(ctx, "/api/v1/snapshots", q, &snaps); err != nil {
+				return nil, err
+			}
+			if *failed {
+				snaps = slices.DeleteFunc(snaps, func(s model.SnapshotRecord) bool { return s.TestsPassed })
+			}
+			if snaps == nil {
+				snaps = []model.SnapshotRecord{}
+			}
+			return snaps, nil
+		},
+		table: func(w io.Writer, v any) {
+			fmt.Fprintln(w, "NAME\tAPPLICATION\tTESTS\tCREATED")
+			for _, s := range v.([]model.SnapshotRecord) {
+				tests := "none"
+				switch {
+				case s.TestsPassed:
+					tests = "passed"
+				case s.HasTests:
+					tests = "failed"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, s.Application, tests, s.CreatedAt.UTC().Format(time.DateTime))
+			}
+		},
+	}
+}
+
+// issuesResource lists the JIRA issues of a release. The type, status,
+// label and assignee filters are applied by the API.
+func issuesResource(fs *flag.FlagSet) lister {
+	release := fs.String("release", "", "release (JIRA fixVersion) whose issues to list")
+	issueType := fs.String("type", "", "only issues of this type, e.g. Bug")
+	status := fs.String("status", "", "only issues in this status")
+	label := fs.String("label", "", "only issues with this label")
+	assignee := fs.String("assignee", "", "only issues assigned to this user")
+	open := fs.Bool("open", false, "only issues that are not done")
+	blockers := fs.Bool("blockers", false, "only release blockers")
+	return lister{
+		fetch: func(ctx context.Context, c *client) (any, error) {
+			if *release == "" {
+				return nil, fmt.Errorf("-release is required")
+			}
+			q := url.Values{}
+			for key, v := range map[string]string{"type": *issueType, "status": *status, "label": *label, "assignee": *assignee} {
+				if v != "" {
+					q.Set(key, v)
+				}
+			}
+			var issues []model.JiraIssueRecord
+			if err := c.get(ctx, "/api/v1/releases/"+url.PathEscape(*release)+"/issues", q, &issues); err != nil {
+				return nil, err
+			}
+			return slices.DeleteFunc(issues, func(i model.JiraIssueRecord) bool {
+				return (*open && i.Done()) || (*blockers && !i.Blocker)
+			}), nil
+		},
+		table: func(w io.Writer, v any) {
+			fmt.Fprintln(w, "KEY\tTYPE\tSTATUS\tPRIORITY\tASSIGNEE\tSUMMARY")
+			for _, i := range v.([]model.JiraIssueRecord) {
+				assignee := i.Assignee
+				if assignee == "" {
+					assignee = "-"
+				}
+				key := i.Key
+				if i.Blocker {
+					key += " (blocker)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key, i.IssueType, i.Status, i.Priority, assignee, strings.TrimSpace(i.Summary))
+			}
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestGet(t *testing.T) {
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		var v any
+		switch r.URL.Path {
+		case "/api/v1/releases/overview":
+			v = []model.ReleaseOverview{{
+				Release:      model.ReleaseVersion{Name: "quay-v3.16.3", DueDate: &due},
+				IssueSummary: &model.IssueSummary{Total: 10, Open: 3},
+				Readiness:    model.ReadinessResponse{Signal: "red", Message: "1 open blocker"},
+				Snapshot:     &model.SnapshotRecord{Name: "quay-v3-16-snap-2"},
+			}, {
+				Release:   model.ReleaseVersion{Name: "quay-v3.17.0"},
+				Readiness: model.ReadinessResponse{Signal: "green", Message: "All clear"},
+			}, {
+				Release:   model.ReleaseVersion{Name: "quay-v3.15.9", Released: true},
+				Readiness: model.ReadinessResponse{Signal: "green", Message: "Released"},
+			}}
+		case "/api/v1/snapshots":
+			v = []model.SnapshotRecord{
+				{Name: "quay-v3-16-snap-2", Application: "quay-v3-16", HasTests: true, CreatedAt: due},
+				{Name: "quay-v3-16-snap-1", Application: "quay-v3-16", HasTests: true, TestsPassed: true, CreatedAt: due},
+			}
+		case "/api/v1/releases/quay-v3.16.3/issues":
+			v = []model.JiraIssueRecord{
+				{Key: "PROJQUAY-1", IssueType: "Bug", Status: "In Progress", Priority: "Major", Summary: "Mirror fails", Blocker: true},
+				{Key: "PROJQUAY-2", IssueType: "Bug", Status: "Verified", Priority: "Minor", Assignee: "alice", Summary: "Typo"},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(v)
+	}))
+	defer srv.Close()
+
+	run := func(args ...string) (int, string, string) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"get", args[0], "-server", srv.URL}, args[1:]...)
+		code := Main(t.Context(), args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("releases")
+	want := "NAME          SIGNAL  DUE         ISSUES     SNAPSHOT           MESSAGE\n" +
+		"quay-v3.16.3  red     2026-03-20  3/10 open  quay-v3-16-snap-2  1 open blocker\n" +
+		"quay-v3.17.0  green   -           -          -                  All clear\n"
+	if code != exitOK || out != want {
+		t.Errorf("releases: got %d\n%s\nwant\n%s", code, out, want)
+	}
+	if _, out, _ := run("releases", "-all", "-signal", "green", "-o", "json"); !strings.Contains(out, `"name": "quay-v3.15.9"`) || strings.Contains(out, "quay-v3.16.3") {
+		t.Errorf("releases -all -signal green: got %s", out)
+	}
+	if _, out, _ := run("releases", "-name", "quay-v3.17.*", "-o", "yaml"); !strings.Contains(out, "\n    name: quay-v3.17.0\n") || strings.Contains(out, "quay-v3.16.3") {
+		t.Errorf("releases -name -o yaml: got %s", out)
+	}
+
+	code, out, _ = run("snapshots", "-application", "quay-v3-16", "-limit", "5", "-failed")
+	if code != exitOK || query != "application=quay-v3-16&limit=5" || !strings.Contains(out, "quay-v3-16-snap-2  quay-v3-16   failed  2026-03-20 00:00:00\n") || strings.Contains(out, "snap-1") {
+		t.Errorf("snapshots: got %d, query %q\n%s", code, query, out)
+	}
+
+	code, out, _ = run("issues", "-release", "quay-v3.16.3", "-type", "Bug", "-open")
+	if code != exitOK || query != "type=Bug" || !strings.Contains(out, "PROJQUAY-1 (blocker)") || strings.Contains(out, "PROJQUAY-2") {
+		t.Errorf("issues: got %d, query %q\n%s", code, query, out)
+	}
+
+	for name, args := range map[string][]string{
+		"unknown resource": {"builds"},
+		"bad output":       {"releases", "-o", "xml"},
+		"no release":       {"issues"},
+		"unknown release":  {"issues", "-release", "quay-v9.9.9"},
+	} {
+		if code, _, _ := run(args...); code != exitError {
+			t.Errorf("%s: got %d, want %d", name, code, exitError)
+		}
+	}
+}
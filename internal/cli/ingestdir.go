@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/s3"
+)
+
+// ingestDir ingests a local directory laid out like the S3 bucket, into an
+// in-memory database to show what would be ingested, or into the SQLite
+// database given with -db. It exits 1 if a file does not fit the layout or
+// a snapshot fails to ingest.
+func ingestDir(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ingest-dir", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dbPath := fs.String("db", "", "SQLite database to ingest into; without it nothing is stored")
+	verbose := fs.Bool("v", false, "log each step of the ingestion, not only warnings")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: release-readiness ingest-dir [-db dashboard.db] [-v] <dir>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+	dir := fs.Arg(0)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(stderr, "ingest-dir: %s is not a directory\n", dir)
+		return exitError
+	}
+
+	store := s3.NewDirStore(dir)
+	problems, err := store.Check()
+	if err != nil {
+		fmt.Fprintf(stderr, "ingest-dir: %v\n", err)
+		return exitError
+	}
+	code := exitOK
+	for _, p := range problems {
+		fmt.Fprintf(stdout, "warning: %s\n", p)
+		code = exitFail
+	}
+
+	dsn := *dbPath
+	if dsn == "" {
+		dsn = db.MemoryPath
+	}
+	database, err := db.Open(dsn)
+	if err != nil {
+		fmt.Fprintf(stderr, "ingest-dir: %v\n", err)
+		return exitError
+	}
+	defer func() { _ = database.Close() }()
+
+	level := slog.LevelWarn
+	if *verbose {
+		level = slog.LevelDebug
+	}
+	syncer := s3.NewSyncer(store, database, slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: level})))
+
+	keys, err := snapshotKeys(ctx, store)
+	if err != nil {
+		fmt.Fprintf(stderr, "ingest-dir: %v\n", err)
+		return exitError
+	}
+	stored := make(map[string]bool)
+	for _, key := range keys {
+		name := snapshotName(key)
+		if stored[name], err = database.SnapshotExistsByName(ctx, name); err != nil {
+			fmt.Fprintf(stderr, "ingest-dir: %v\n", err)
+			return exitError
+		}
+	}
+
+	syncer.SyncOnce(ctx)
+
+	failures, err := database.ListIngestFailures(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "ingest-dir: %v\n", err)
+		return exitError
+	}
+	failed := make(map[string]string)
+	for _, f := range failures {
+		failed[f.Key] = f.LastError
+	}
+	for _, key := range keys {
+		name := snapshotName(key)
+		switch {
+		case failed[key] != "":
+			fmt.Fprintf(stdout, "%s: failed: %s\n", name, failed[key])
+			code = exitFail
+		case stored[name]:
+			fmt.Fprintf(stdout, "%s: already stored\n", name)
+		default:
+			snap, err := database.GetSnapshotByName(ctx, name)
+			if err != nil {
+				// The syncer skips a snapshot.json it cannot read without
+				// recording a failure, as it may still be uploading.
+				if _, _, readErr := store.GetSnapshotIfChanged(ctx, key, ""); readErr != nil {
+					err = readErr
+				}
+				fmt.Fprintf(stdout, "%s: not ingested: %v\n", name, err)
+				code = exitFail
+				continue
+			}
+			printSnapshot(stdout, snap)
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Fprintf(stdout, "no snapshots under %s\n", dir)
+	}
+	return code
+}
+
+// snapshotKeys returns the keys of the snapshot.json files the syncer
+// looks for in store.
+func snapshotKeys(ctx context.Context, store s3.ObjectStore) ([]string, error) {
+	apps, err := store.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, app := range apps {
+		snaps, err := store.ListSnapshots(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, snaps...)
+	}
+	return keys, nil
+}
+
+// snapshotName returns the name of the snapshot whose snapshot.json is at
+// key.
+func snapshotName(key string) string {
+	return path.Base(path.Dir(key))
+}
+
+// printSnapshot prints what was ingested of snap.
+func printSnapshot(w io.Writer, snap *model.SnapshotRecord) {
+	tests := "no tests"
+	switch {
+	case snap.TestsPassed:
+		tests = "tests passed"
+	case snap.HasTests:
+		tests = "tests failed"
+	}
+	fmt.Fprintf(w, "%s: %s, %d components, %s\n", snap.Name, snap.Application, len(snap.Components), tests)
+	for _, s := range snap.TestSuites {
+		fmt.Fprintf(w, "  %s: %s, %d tests, %d failed, %d skipped\n", s.Name, s.Status, s.Tests, s.Failed, s.Skipped)
+	}
+	if len(snap.ECResults) > 0 {
+		var failing []string
+		for _, r := range snap.ECResults {
+			if !r.Success {
+				failing = append(failing, r.Component)
+			}
+		}
+		if len(failing) == 0 {
+			fmt.Fprintln(w, "  enterprise contract: passed")
+		} else {
+			fmt.Fprintf(w, "  enterprise contract: failed for %s\n", strings.Join(failing, ", "))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIngestDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json":          `{"application":"quay-v3-17","components":[{"name":"quay","containerImage":"quay.io/quay/quay@sha256:abc"}]}`,
+		"quay-v3-17/snapshots/quay-v3-17-snap-1/junit/api-tests/a.xml":  `<testsuite name="api" tests="2"><testcase name="a"/><testcase name="b"><failure message="boom"/></testcase></testsuite>`,
+		"quay-v3-17/snapshots/quay-v3-17-snap-1/api-tests/logs/run.log": "artifact",
+		"quay-v3-17/snapshots/quay-v3-17-snap-1/junit/api-tests.xml":    `<testsuite/>`,
+		"quay-v3-17/snapshots/quay-v3-17-snap-2/snapshot.json":          `{"application":`,
+		"quay-v3-17/snapshots/quay-v3-17-snap-3/junit/ui-tests/a.xml":   `<testsuite/>`,
+		"README.md": "notes",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(args ...string) (int, string) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		code := Main(t.Context(), append([]string{"ingest-dir"}, args...), &stdout, &stderr)
+		return code, stdout.String()
+	}
+
+	code, out := run(dir)
+	for _, want := range []string{
+		"warning: README.md: not part of the bucket layout\n",
+		"warning: quay-v3-17/snapshots/quay-v3-17-snap-1/junit/api-tests.xml: not part of the bucket layout\n",
+		"warning: quay-v3-17/snapshots/quay-v3-17-snap-3: no snapshot.json\n",
+		"quay-v3-17-snap-1: quay-v3-17, 1 components, tests failed\n  api-tests: failed, 2 tests, 1 failed, 0 skipped\n",
+		"quay-v3-17-snap-2: not ingested: decode snapshot",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run: missing %q in:\n%s", want, out)
+		}
+	}
+	if code != exitFail || strings.Contains(out, "artifact") || strings.Contains(out, "run.log") {
+		t.Errorf("dry run: got %d:\n%s", code, out)
+	}
+
+	// Ingesting into a database stores the snapshots once.
+	dbPath := filepath.Join(t.TempDir(), "dashboard.db")
+	if code, out := run("-db", dbPath, dir); code != exitFail || !strings.Contains(out, "quay-v3-17-snap-1: quay-v3-17") {
+		t.Errorf("ingest: got %d:\n%s", code, out)
+	}
+	if code, out := run("-db", dbPath, dir); code != exitFail || !strings.Contains(out, "quay-v3-17-snap-1: already stored\n") {
+		t.Errorf("ingest again: got %d:\n%s", code, out)
+	}
+
+	if code, _ := run(filepath.Join(dir, "README.md")); code != exitError {
+		t.Errorf("not a directory: got %d", code)
+	}
+	if code, _ := run(); code != exitError {
+		t.Errorf("no directory: got %d", code)
+	}
+}
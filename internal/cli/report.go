@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/quay/release-readiness/internal/junit"
+	"github.com/quay/release-readiness/internal/konflux"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// report pushes data to the dashboard's ingest API. Its only subcommand is
+// snapshot.
+func report(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "snapshot" {
+		fmt.Fprintln(stderr, "usage: release-readiness report snapshot [flags]")
+		return exitError
+	}
+	return reportSnapshot(ctx, args[1:], stdout, stderr)
+}
+
+// junitFiles collects the -junit flags of report snapshot.
+type junitFiles []junitFile
+
+// junitFile is a JUnit XML file, or a directory of them, holding the
+// results of a test scenario.
+type junitFile struct {
+	scenario string
+	path     string
+}
+
+func (f *junitFiles) String() string {
+	var s []string
+	for _, j := range *f {
+		s = append(s, j.scenario+"="+j.path)
+	}
+	return strings.Join(s, ",")
+}
+
+func (f *junitFiles) Set(v string) error {
+	scenario, path, ok := strings.Cut(v, "=")
+	if !ok || scenario == "" || path == "" || strings.Contains(scenario, "/") {
+		return fmt.Errorf("want scenario=path, got %q", v)
+	}
+	*f = append(*f, junitFile{scenario: scenario, path: path})
+	return nil
+}
+
+// reportSnapshot pushes a Konflux Snapshot CR, read from a YAML or JSON
+// file, to the snapshot ingest endpoint along with the JUnit results of its
+// test scenarios.
+func reportSnapshot(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("report snapshot", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	newClient := clientFlags(fs)
+	file := fs.String("f", "", "Snapshot CR to push, as YAML or JSON; - reads standard input")
+	var reports junitFiles
+	fs.Var(&reports, "junit", "scenario=path of a JUnit XML file, or of a directory of them, to attach; repeatable")
+	dryRun := fs.Bool("dry-run", false, "validate and print what would be pushed without pushing it")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: release-readiness report snapshot -f snapshot.yaml [-junit scenario=path ...] [-dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if *file == "" {
+		fmt.Fprintln(stderr, "report snapshot: -f is required")
+		return exitError
+	}
+
+	push, err := readSnapshot(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "report snapshot: %v\n", err)
+		return exitError
+	}
+	if push.TestResults, err = readJUnit(reports); err != nil {
+		fmt.Fprintf(stderr, "report snapshot: %v\n", err)
+		return exitError
+	}
+	snap := konflux.Convert(push.Spec, push.Metadata.Name)
+	scenarios := make(map[string]bool)
+	for _, tr := range push.TestResults {
+		scenarios[tr.Scenario] = true
+	}
+	fmt.Fprintf(stdout, "%s of %s: %d components, %d JUnit reports of %d scenarios\n",
+		snap.Snapshot, snap.Application, len(snap.Components), len(push.TestResults), len(scenarios))
+	if *dryRun {
+		return exitOK
+	}
+
+	var record model.SnapshotRecord
+	status, err := newClient().post(ctx, "/api/v1/ingest/snapshot", push, &record)
+	if err != nil {
+		fmt.Fprintf(stderr, "report snapshot: push %s: %v\n", snap.Snapshot, err)
+		return exitError
+	}
+	if status == http.StatusCreated {
+		fmt.Fprintf(stdout, "%s ingested\n", record.Name)
+	} else {
+		fmt.Fprintf(stdout, "%s was already ingested\n", record.Name)
+	}
+	return exitOK
+}
+
+// readSnapshot reads and validates the Snapshot CR in the file at path,
+// or on standard input if path is "-".
+func readSnapshot(path string) (*konflux.SnapshotPush, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML, so both decode here; converting to JSON then applies
+	// the field names of the CR types.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if data, err = json.Marshal(doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	var push konflux.SnapshotPush
+	if err := json.Unmarshal(data, &push.Snapshot); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	switch {
+	case push.Kind != "" && push.Kind != "Snapshot":
+		return nil, fmt.Errorf("%s: kind is %q, want Snapshot", path, push.Kind)
+	case push.Metadata.Name == "":
+		return nil, fmt.Errorf("%s: metadata.name is required", path)
+	case push.Spec.Application == "":
+		return nil, fmt.Errorf("%s: spec.application is required", path)
+	}
+	return &push, nil
+}
+
+// readJUnit reads and checks the JUnit files given with -junit. A
+// directory contributes the .xml files directly in it.
+func readJUnit(files junitFiles) ([]konflux.TestResult, error) {
+	var results []konflux.TestResult
+	for _, f := range files {
+		paths := []string{f.path}
+		if info, err := os.Stat(f.path); err != nil {
+			return nil, err
+		} else if info.IsDir() {
+			if paths, err = filepath.Glob(filepath.Join(f.path, "*.xml")); err != nil {
+				return nil, err
+			}
+			if len(paths) == 0 {
+				return nil, fmt.Errorf("%s: no .xml files", f.path)
+			}
+		}
+		for _, p := range paths {
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := junit.Parse(data); err != nil {
+				return nil, fmt.Errorf("decode junit report %s: %w", p, err)
+			}
+			results = append(results, konflux.TestResult{Scenario: f.scenario, JUnit: string(data)})
+		}
+	}
+	return results, nil
+}
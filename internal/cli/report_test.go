@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/konflux"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReportSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		t.Helper()
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	cr := write("snapshot.yaml", `apiVersion: appstudio.redhat.com/v1alpha1
+kind: Snapshot
+metadata:
+  name: quay-v3-17-abc12
+  namespace: quay-tenant
+spec:
+  application: quay-v3-17
+  components:
+    - name: quay-server
+      containerImage: quay.io/quay/quay@sha256:abc
+      source:
+        git:
+          url: https://github.com/quay/quay
+          revision: abc123
+`)
+	api := write("api.xml", `<testsuite name="api" tests="1"><testcase name="test_a"/></testsuite>`)
+	write("ui/one.xml", `<testsuite name="ui" tests="1"><testcase name="test_b"/></testsuite>`)
+	write("ui/two.xml", `<testsuite name="ui" tests="1"><testcase name="test_c"/></testsuite>`)
+	bad := write("bad.xml", `<testsuite`)
+
+	var pushes []konflux.SnapshotPush
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/ingest/snapshot" {
+			t.Errorf("request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer t0ken" {
+			t.Errorf("authorization: got %q", got)
+		}
+		var push konflux.SnapshotPush
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			t.Fatal(err)
+		}
+		status := http.StatusCreated
+		if len(pushes) > 0 {
+			status = http.StatusOK
+		}
+		pushes = append(pushes, push)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(model.SnapshotRecord{Name: push.Metadata.Name, Application: push.Spec.Application})
+	}))
+	defer srv.Close()
+
+	run := func(args ...string) (int, string, string) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"report", "snapshot", "-server", srv.URL, "-token", "t0ken"}, args...)
+		code := Main(t.Context(), args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, errOut := run("-f", cr, "-junit", "api-tests="+api, "-junit", "ui-tests="+filepath.Join(dir, "ui"))
+	if code != exitOK || out != "quay-v3-17-abc12 of quay-v3-17: 1 components, 3 JUnit reports of 2 scenarios\nquay-v3-17-abc12 ingested\n" {
+		t.Fatalf("push: got %d %q %q", code, out, errOut)
+	}
+	if len(pushes) != 1 {
+		t.Fatalf("pushes: got %d", len(pushes))
+	}
+	push := pushes[0]
+	if push.Kind != "Snapshot" || push.Spec.Application != "quay-v3-17" || len(push.Spec.Components) != 1 ||
+		push.Spec.Components[0].Source.Git.Revision != "abc123" {
+		t.Errorf("pushed snapshot: got %+v", push.Snapshot)
+	}
+	if len(push.TestResults) != 3 || push.TestResults[0].Scenario != "api-tests" || push.TestResults[2].Scenario != "ui-tests" ||
+		!strings.Contains(push.TestResults[2].JUnit, "test_c") {
+		t.Errorf("pushed test results: got %+v", push.TestResults)
+	}
+
+	if code, out, _ := run("-f", cr); code != exitOK || !strings.HasSuffix(out, "quay-v3-17-abc12 was already ingested\n") {
+		t.Errorf("repeat push: got %d %q", code, out)
+	}
+	if code, _, _ := run("-f", cr, "-dry-run"); code != exitOK || len(pushes) != 2 {
+		t.Errorf("dry run: got %d after %d pushes", code, len(pushes))
+	}
+
+	noApp := write("no-app.json", `{"kind":"Snapshot","metadata":{"name":"x"},"spec":{}}`)
+	for name, args := range map[string][]string{
+		"no file":        {},
+		"no application": {"-f", noApp},
+		"bad junit":      {"-f", cr, "-junit", "api-tests=" + bad},
+		"bad flag":       {"-f", cr, "-junit", api},
+		"missing junit":  {"-f", cr, "-junit", "api-tests=" + filepath.Join(dir, "missing.xml")},
+	} {
+		if code, _, _ := run(args...); code != exitError {
+			t.Errorf("%s: got %d, want %d", name, code, exitError)
+		}
+	}
+	if len(pushes) != 2 {
+		t.Errorf("invalid invocations pushed: got %d pushes", len(pushes))
+	}
+}
@@ -0,0 +1,140 @@
+// Package config loads command-line settings from a YAML file, so that
+// deployments need not pass long flag lists, or secrets, as process
+// arguments.
+//
+// Keys name flags. Nested mappings join their keys with "-", so
+//
+//	jira:
+//	  token: ${JIRA_TOKEN}
+//	  poll-interval: 5m
+//
+// sets -jira-token and -jira-poll-interval; "jira-token" at the top level
+// works too. Sequences of scalars are joined with commas. ${VAR} in a value
+// is replaced with the environment variable VAR, or "" if it is unset.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Apply sets the flags of fs from the YAML file at path. A flag keeps its
+// value if it was given on the command line, or if env names an environment
+// variable for it that is set: flags take precedence over the environment,
+// which takes precedence over the file. Keys that name no flag are an
+// error, so typos do not go unnoticed. Apply must be called after fs is
+// parsed.
+func Apply(fs *flag.FlagSet, path string, env map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	values, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	for _, v := range values {
+		if fs.Lookup(v.Flag) == nil {
+			return fmt.Errorf("%s: %s: unknown setting", path, v.Key)
+		}
+		if set[v.Flag] {
+			continue
+		}
+		if key := env[v.Flag]; key != "" && os.Getenv(key) != "" {
+			continue
+		}
+		if err := fs.Set(v.Flag, v.Value); err != nil {
+			return fmt.Errorf("%s: %s: %w", path, v.Key, err)
+		}
+	}
+	return nil
+}
+
+// value is one setting read from a config file.
+type value struct {
+	Key   string // dotted path in the file, e.g. "jira.token"
+	Flag  string // flag it sets, e.g. "jira-token"
+	Value string // with environment variables expanded
+}
+
+// parse flattens a YAML document into flag values, in file order.
+func parse(data []byte) ([]value, error) {
+	var doc yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of settings", root.Line)
+	}
+	var values []value
+	if err := flatten(root, "", "", &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func flatten(node *yaml.Node, key, name string, values *[]value) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i].Value
+			childKey, childName := k, k
+			if key != "" {
+				childKey = key + "." + k
+				childName = name + "-" + k
+			}
+			if err := flatten(node.Content[i+1], childKey, childName, values); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: %s: lists may only hold plain values", item.Line, key)
+			}
+			items = append(items, expand(item.Value))
+		}
+		*values = append(*values, value{Key: key, Flag: name, Value: strings.Join(items, ",")})
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil
+		}
+		*values = append(*values, value{Key: key, Flag: name, Value: expand(node.Value)})
+	case yaml.AliasNode:
+		return flatten(node.Alias, key, name, values)
+	default:
+		return fmt.Errorf("line %d: %s: unsupported value", node.Line, key)
+	}
+	return nil
+}
+
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expand replaces ${VAR} references with the value of the environment
+// variable VAR.
+func expand(s string) string {
+	return envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
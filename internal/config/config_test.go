@@ -0,0 +1,91 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	t.Setenv("TEST_JIRA_TOKEN", "s3cret")
+	t.Setenv("TEST_S3_BUCKET", "from-env")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(`
+addr: ":9090"
+db-driver: postgres
+s3:
+  bucket: from-file
+jira:
+  token: ${TEST_JIRA_TOKEN}
+  project:
+    - PROJQUAY
+    - SECURITY
+  poll-interval: 10m
+public-reads: false
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "")
+	driver := fs.String("db-driver", "sqlite", "")
+	bucket := fs.String("s3-bucket", "", "")
+	token := fs.String("jira-token", "", "")
+	project := fs.String("jira-project", "PROJQUAY", "")
+	poll := fs.Duration("jira-poll-interval", 5*time.Minute, "")
+	publicReads := fs.Bool("public-reads", true, "")
+	if err := fs.Parse([]string{"-db-driver", "sqlite"}); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{"s3-bucket": "TEST_S3_BUCKET", "jira-token": "TEST_UNSET_TOKEN"}
+	if err := Apply(fs, path, env); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if *addr != ":9090" {
+		t.Errorf("addr: got %q, want the file's value", *addr)
+	}
+	if *driver != "sqlite" {
+		t.Errorf("db-driver: got %q, want the command line's value", *driver)
+	}
+	if *bucket != "" {
+		t.Errorf("s3-bucket: got %q, want it left to the environment", *bucket)
+	}
+	if *token != "s3cret" {
+		t.Errorf("jira-token: got %q, want the interpolated value", *token)
+	}
+	if *project != "PROJQUAY,SECURITY" {
+		t.Errorf("jira-project: got %q, want a comma-joined list", *project)
+	}
+	if *poll != 10*time.Minute {
+		t.Errorf("jira-poll-interval: got %v, want 10m", *poll)
+	}
+	if *publicReads {
+		t.Error("public-reads: got true, want false")
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown key":   "jira:\n  tokn: x\n",
+		"invalid value": "jira:\n  poll-interval: soon\n",
+		"not a mapping": "- addr\n",
+		"nested list":   "jira:\n  project:\n    - {key: PROJQUAY}\n",
+	} {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.String("jira-token", "", "")
+		fs.String("jira-project", "", "")
+		fs.Duration("jira-poll-interval", 0, "")
+		if err := Apply(fs, path, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -7,9 +7,17 @@ type Report struct {
 
 // Results contains the tool info, summary, and individual test outcomes.
 type Results struct {
-	Tool    Tool    `json:"tool"`
-	Summary Summary `json:"summary"`
-	Tests   []Test  `json:"tests"`
+	Tool        Tool        `json:"tool"`
+	Summary     Summary     `json:"summary"`
+	Tests       []Test      `json:"tests"`
+	Environment Environment `json:"environment"`
+}
+
+// Environment describes where the tests ran. Konflux integration pipelines
+// set the build fields to the PipelineRun that produced the report.
+type Environment struct {
+	BuildName string `json:"buildName,omitempty"`
+	BuildURL  string `json:"buildUrl,omitempty"`
 }
 
 // Tool identifies the test runner that produced the report.
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListReleaseAdvisories returns the configured advisories of every
+// release, by release name.
+func (d *DB) ListReleaseAdvisories(ctx context.Context) ([]model.Advisory, error) {
+	rows, err := d.queries().ListReleaseAdvisories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	advisories := make([]model.Advisory, len(rows))
+	for i, r := range rows {
+		advisories[i] = toAdvisory(r)
+	}
+	return advisories, nil
+}
+
+// GetReleaseAdvisory returns the advisory configured for release. It
+// returns ErrNotFound if none is.
+func (d *DB) GetReleaseAdvisory(ctx context.Context, release string) (*model.Advisory, error) {
+	row, err := d.queries().GetReleaseAdvisory(ctx, release)
+	if err != nil {
+		return nil, classify(err)
+	}
+	a := toAdvisory(row)
+	return &a, nil
+}
+
+// SetReleaseAdvisory configures the advisory tracked for release. Setting a
+// different advisory clears the state synced from the previous one;
+// setting the same advisory again leaves it untouched.
+func (d *DB) SetReleaseAdvisory(ctx context.Context, release string, advisoryID int64) (*model.Advisory, error) {
+	if a, err := d.GetReleaseAdvisory(ctx, release); err == nil && a.AdvisoryID == advisoryID {
+		return a, nil
+	}
+	if err := d.queries().UpsertReleaseAdvisory(ctx, dbsqlc.UpsertReleaseAdvisoryParams{
+		Release:    release,
+		AdvisoryID: advisoryID,
+	}); err != nil {
+		return nil, err
+	}
+	return d.GetReleaseAdvisory(ctx, release)
+}
+
+// DeleteReleaseAdvisory stops tracking the advisory of release. It returns
+// ErrNotFound if none is configured.
+func (d *DB) DeleteReleaseAdvisory(ctx context.Context, release string) error {
+	n, err := d.queries().DeleteReleaseAdvisory(ctx, release)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("advisory for %s: %w", release, ErrNotFound)
+	}
+	return nil
+}
+
+// SaveAdvisorySync records the state synced from the Errata Tool for the
+// advisory of a.Release, clearing any previous sync error. It does nothing
+// if the release has since been configured with a different advisory.
+func (d *DB) SaveAdvisorySync(ctx context.Context, a *model.Advisory) error {
+	var syncedAt string
+	if a.SyncedAt != nil {
+		syncedAt = a.SyncedAt.UTC().Format(time.RFC3339)
+	}
+	return d.queries().UpdateAdvisorySync(ctx, dbsqlc.UpdateAdvisorySyncParams{
+		Name:     a.Name,
+		Type:     a.Type,
+		State:    a.State,
+		Synopsis: a.Synopsis,
+		// Build NVRs and CVE IDs never contain commas.
+		Builds:     strings.Join(a.Builds, ","),
+		Cves:       strings.Join(a.CVEs, ","),
+		SyncedAt:   syncedAt,
+		Release:    a.Release,
+		AdvisoryID: a.AdvisoryID,
+	})
+}
+
+// SaveAdvisorySyncError records why the last sync of the advisory of
+// release failed, keeping the state synced before.
+func (d *DB) SaveAdvisorySyncError(ctx context.Context, release string, advisoryID int64, syncErr string) error {
+	return d.queries().UpdateAdvisorySyncError(ctx, dbsqlc.UpdateAdvisorySyncErrorParams{
+		SyncError:  syncErr,
+		Release:    release,
+		AdvisoryID: advisoryID,
+	})
+}
+
+func toAdvisory(r dbsqlc.ReleaseAdvisory) model.Advisory {
+	return model.Advisory{
+		Release:    r.Release,
+		AdvisoryID: r.AdvisoryID,
+		Name:       r.Name,
+		Type:       r.Type,
+		State:      r.State,
+		Synopsis:   r.Synopsis,
+		Builds:     splitList(r.Builds),
+		CVEs:       splitList(r.Cves),
+		SyncedAt:   parseOptionalTime(r.SyncedAt),
+		SyncError:  r.SyncError,
+	}
+}
+
+// splitList splits a comma-separated column, returning an empty slice for
+// an empty column.
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListApplicationConfigs returns the metadata configured for applications,
+// by name.
+func (d *DB) ListApplicationConfigs(ctx context.Context) ([]model.ApplicationConfig, error) {
+	rows, err := d.queries().ListApplicationConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]model.ApplicationConfig, len(rows))
+	for i, r := range rows {
+		configs[i] = model.ApplicationConfig{
+			Name:        r.Name,
+			DisplayName: r.DisplayName,
+			Product:     r.Product,
+			FixVersions: splitList(r.FixVersions),
+			UpdatedAt:   parseOptionalTime(r.UpdatedAt),
+		}
+		if r.RetentionSet == 1 {
+			configs[i].Retention = &model.RetentionLimits{MaxCount: int(r.RetentionMaxCount), MaxAge: r.RetentionMaxAge}
+		}
+	}
+	return configs, nil
+}
+
+// SaveApplicationConfig replaces the metadata configured for c.Name,
+// setting c.UpdatedAt, and maps the stored releases of c.FixVersions to the
+// application.
+func (d *DB) SaveApplicationConfig(ctx context.Context, c *model.ApplicationConfig) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	c.UpdatedAt = &now
+	params := dbsqlc.UpsertApplicationConfigParams{
+		Name:        c.Name,
+		DisplayName: c.DisplayName,
+		Product:     c.Product,
+		// fixVersions never contain commas.
+		FixVersions: strings.Join(c.FixVersions, ","),
+		UpdatedAt:   now.Format(time.RFC3339),
+	}
+	if c.Retention != nil {
+		params.RetentionSet = 1
+		params.RetentionMaxCount = int64(c.Retention.MaxCount)
+		params.RetentionMaxAge = c.Retention.MaxAge
+	}
+	return d.InTx(ctx, func(tx *DB) error {
+		if err := tx.queries().UpsertApplicationConfig(ctx, params); err != nil {
+			return err
+		}
+		for _, fv := range c.FixVersions {
+			if err := tx.queries().SetReleaseS3Application(ctx, dbsqlc.SetReleaseS3ApplicationParams{
+				S3Application: c.Name,
+				Name:          fv,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// CreateReleaseApproval records an approval, setting its ID and, if unset,
+// its creation time.
+func (d *DB) CreateReleaseApproval(ctx context.Context, a *model.Approval) error {
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now().UTC()
+	}
+	id, err := d.queries().CreateReleaseApproval(ctx, dbsqlc.CreateReleaseApprovalParams{
+		Release:   a.Release,
+		Approver:  a.Approver,
+		Role:      a.Role,
+		Comment:   a.Comment,
+		CreatedAt: a.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	a.ID = id
+	return nil
+}
+
+// ListReleaseApprovals returns the approvals recorded for release, oldest
+// first.
+func (d *DB) ListReleaseApprovals(ctx context.Context, release string) ([]model.Approval, error) {
+	rows, err := d.queries().ListReleaseApprovals(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+	approvals := make([]model.Approval, len(rows))
+	for i, r := range rows {
+		approvals[i] = model.Approval{
+			ID:        r.ID,
+			Release:   r.Release,
+			Approver:  r.Approver,
+			Role:      r.Role,
+			Comment:   r.Comment,
+			CreatedAt: parseTime(r.CreatedAt),
+		}
+	}
+	return approvals, nil
+}
+
+// ListApprovedRoles returns the roles that have approved each release,
+// keyed by release name.
+func (d *DB) ListApprovedRoles(ctx context.Context) (map[string][]string, error) {
+	rows, err := d.queries().ListApprovedRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string][]string)
+	for _, r := range rows {
+		roles[r.Release] = append(roles[r.Release], r.Role)
+	}
+	return roles, nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// SaveReleaseAudit stores audit, replacing any previous audit of the same
+// release. It runs in its own transaction.
+func (d *DB) SaveReleaseAudit(ctx context.Context, audit *model.ReleaseAudit) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		if err := q.DeleteReleaseAudit(ctx, audit.Release); err != nil {
+			return err
+		}
+		id, err := q.CreateReleaseAudit(ctx, dbsqlc.CreateReleaseAuditParams{
+			Release:      audit.Release,
+			SnapshotName: audit.SnapshotName,
+			AuditedAt:    audit.AuditedAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return classify(err)
+		}
+		for _, f := range audit.Findings {
+			if err := q.CreateReleaseAuditFinding(ctx, dbsqlc.CreateReleaseAuditFindingParams{
+				AuditID:   id,
+				Component: f.Component,
+				Kind:      f.Kind,
+				Expected:  f.Expected,
+				Actual:    f.Actual,
+				Message:   f.Message,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetReleaseAudit returns the audit of release with its findings.
+func (d *DB) GetReleaseAudit(ctx context.Context, release string) (*model.ReleaseAudit, error) {
+	row, err := d.queries().GetReleaseAudit(ctx, release)
+	if err != nil {
+		return nil, classify(err)
+	}
+	rows, err := d.queries().ListReleaseAuditFindings(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit := &model.ReleaseAudit{
+		Release:      row.Release,
+		SnapshotName: row.SnapshotName,
+		AuditedAt:    parseTime(row.AuditedAt),
+		Findings:     make([]model.AuditFinding, len(rows)),
+	}
+	for i, r := range rows {
+		audit.Findings[i] = model.AuditFinding{
+			Component: r.Component,
+			Kind:      r.Kind,
+			Expected:  r.Expected,
+			Actual:    r.Actual,
+			Message:   r.Message,
+		}
+	}
+	return audit, nil
+}
+
+// ReleaseAuditExists reports whether release has been audited.
+func (d *DB) ReleaseAuditExists(ctx context.Context, release string) (bool, error) {
+	_, err := d.queries().GetReleaseAudit(ctx, release)
+	if err == nil {
+		return true, nil
+	}
+	if err = classify(err); errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return false, err
+}
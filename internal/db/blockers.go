@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// replaceIssueBlockers stores the blockers of an issue in place of those
+// recorded at its previous sync.
+func (d *DB) replaceIssueBlockers(ctx context.Context, issue *model.JiraIssueRecord) error {
+	q := d.queries()
+	if err := q.DeleteJiraIssueBlockers(ctx, issue.Key); err != nil {
+		return err
+	}
+	for _, b := range issue.BlockedBy {
+		if err := q.CreateJiraIssueBlocker(ctx, dbsqlc.CreateJiraIssueBlockerParams{
+			IssueKey:       issue.Key,
+			BlockerKey:     b.Key,
+			BlockerSummary: b.Summary,
+			BlockerStatus:  b.Status,
+			BlockerLink:    b.Link,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachBlockers fills in the BlockedBy list of the issues of fixVersion.
+func (d *DB) attachBlockers(ctx context.Context, fixVersion string, issues []model.JiraIssueRecord) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	rows, err := d.queries().ListReleaseIssueBlockers(ctx, fixVersion)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	byKey := make(map[string][]model.IssueBlocker)
+	for _, r := range rows {
+		byKey[r.IssueKey] = append(byKey[r.IssueKey], model.IssueBlocker{
+			Key:     r.BlockerKey,
+			Summary: r.BlockerSummary,
+			Status:  r.BlockerStatus,
+			Link:    r.BlockerLink,
+		})
+	}
+	for i := range issues {
+		issues[i].BlockedBy = byKey[issues[i].Key]
+	}
+	return nil
+}
+
+// countBlocked moves the done issues that still have an unresolved blocker
+// from the verified to the open count of summaries, which are keyed by
+// fixVersion.
+// Stays hand-written due to variable IN clause.
+func (d *DB) countBlocked(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	for fixVersion := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+	}
+
+	query := `
+		SELECT ri.fix_version, COUNT(*)
+		FROM release_issues ri
+		WHERE ri.fix_version IN (` + strings.Join(placeholders, ",") + `)
+			AND LOWER(ri.status) IN ('closed', 'verified', 'done')
+			AND EXISTS (
+				SELECT 1 FROM jira_issue_blockers b
+				WHERE b.issue_key = ri.key
+					AND LOWER(COALESCE(
+						(SELECT ji.status FROM jira_issues ji WHERE ji.key = b.blocker_key ORDER BY ji.updated_at DESC LIMIT 1),
+						b.blocker_status)) NOT IN ('closed', 'verified', 'done'))
+		GROUP BY ri.fix_version`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion string
+		var n int
+		if err := rows.Scan(&fixVersion, &n); err != nil {
+			return err
+		}
+		if s := summaries[fixVersion]; s != nil {
+			s.Blocked = n
+			s.Verified -= n
+			s.Open += n
+		}
+	}
+	return rows.Err()
+}
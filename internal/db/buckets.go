@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListIssueBuckets returns the configured issue buckets in order.
+func (d *DB) ListIssueBuckets(ctx context.Context) ([]model.IssueBucket, error) {
+	rows, err := d.queries().ListIssueBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]model.IssueBucket, len(rows))
+	for i, r := range rows {
+		buckets[i] = model.IssueBucket{Name: r.Name, Labels: splitLabels(r.Labels)}
+	}
+	return buckets, nil
+}
+
+// ReplaceIssueBuckets replaces the configured issue buckets. It runs in its
+// own transaction and returns ErrConflict if two buckets share a name.
+func (d *DB) ReplaceIssueBuckets(ctx context.Context, buckets []model.IssueBucket) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		if err := q.DeleteIssueBuckets(ctx); err != nil {
+			return err
+		}
+		for _, b := range buckets {
+			if err := q.CreateIssueBucket(ctx, dbsqlc.CreateIssueBucketParams{
+				Name:   b.Name,
+				Labels: strings.Join(b.Labels, ","),
+			}); err != nil {
+				return classify(err)
+			}
+		}
+		return nil
+	})
+}
+
+// countBuckets fills in the bucket counts of summaries, which are keyed by
+// fixVersion. It does nothing if no buckets are configured.
+// Stays hand-written due to variable IN clause.
+func (d *DB) countBuckets(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	buckets, err := d.ListIssueBuckets(ctx)
+	if err != nil || len(buckets) == 0 || len(summaries) == 0 {
+		return err
+	}
+
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	for fixVersion, s := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+		s.Buckets = make([]model.BucketCount, len(buckets))
+		for i, b := range buckets {
+			s.Buckets[i].Name = b.Name
+		}
+	}
+
+	query := `
+		SELECT fix_version, labels,
+			CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 0 ELSE 1 END AS open
+		FROM release_issues
+		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `) AND labels != ''`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion, labels string
+		var open bool
+		if err := rows.Scan(&fixVersion, &labels, &open); err != nil {
+			return err
+		}
+		s := summaries[fixVersion]
+		issueLabels := splitLabels(labels)
+		for i, b := range buckets {
+			if !hasAnyLabel(issueLabels, b.Labels) {
+				continue
+			}
+			s.Buckets[i].Total++
+			if open {
+				s.Buckets[i].Open++
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// splitLabels splits a comma-separated label list, dropping empty entries.
+func splitLabels(s string) []string {
+	var labels []string
+	for _, l := range strings.Split(s, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// hasAnyLabel reports whether labels contains any of want, ignoring case.
+func hasAnyLabel(labels, want []string) bool {
+	for _, l := range labels {
+		for _, w := range want {
+			if strings.EqualFold(l, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListReleaseCandidates returns up to limit of the newest snapshots of
+// application with their candidate state for release.
+func (d *DB) ListReleaseCandidates(ctx context.Context, release, application string, limit int) ([]model.ReleaseCandidate, error) {
+	rows, err := d.queries().ListReleaseCandidates(ctx, dbsqlc.ListReleaseCandidatesParams{
+		Release:     release,
+		Application: application,
+		Limit:       int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]model.ReleaseCandidate, len(rows))
+	for i, r := range rows {
+		c := model.ReleaseCandidate{
+			Snapshot: model.SnapshotRecord{
+				ID:          r.ID,
+				Application: r.Application,
+				Name:        r.Name,
+				TestsPassed: r.TestsPassed == 1,
+				HasTests:    r.TestCount > 0,
+				CreatedAt:   parseTime(r.CreatedAt),
+			},
+			State: model.CandidateDefault,
+		}
+		if r.State != "" {
+			c.State = r.State
+			t := parseTime(r.ChangedAt)
+			c.ChangedAt = &t
+		}
+		candidates[i] = c
+	}
+	markSelected(candidates)
+	return candidates, nil
+}
+
+// markSelected flags the promoted candidate, or failing that the newest one
+// that has not been demoted. candidates must be ordered newest first.
+func markSelected(candidates []model.ReleaseCandidate) {
+	for i := range candidates {
+		if candidates[i].State == model.CandidatePromoted {
+			candidates[i].Selected = true
+			return
+		}
+	}
+	for i := range candidates {
+		if candidates[i].State != model.CandidateDemoted {
+			candidates[i].Selected = true
+			return
+		}
+	}
+}
+
+// SetCandidateState records the state of a snapshot for release. Promoting
+// a snapshot returns any previously promoted one to a plain candidate.
+//
+// The snapshot's lifecycle follows: promoting a new or rejected snapshot
+// moves it to candidate, recording changedBy as the one who moved it. A
+// rejected snapshot can only be promoted or stay demoted, so returning it
+// to a plain candidate fails with ErrConflict.
+func (d *DB) SetCandidateState(ctx context.Context, release string, snapshotID int64, state, changedBy string) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		snap, err := q.GetSnapshotByID(ctx, snapshotID)
+		if err != nil {
+			return classify(err)
+		}
+		switch {
+		case state == model.CandidateDefault && snap.Lifecycle == model.LifecycleRejected:
+			return ErrConflict
+		case state == model.CandidatePromoted && (snap.Lifecycle == model.LifecycleNew || snap.Lifecycle == model.LifecycleRejected):
+			if err := tx.transitionSnapshot(ctx, snapshotID, &model.LifecycleTransition{
+				From:      snap.Lifecycle,
+				To:        model.LifecycleCandidate,
+				Reason:    "promoted for " + release,
+				ChangedBy: changedBy,
+			}); err != nil {
+				return err
+			}
+		}
+		if state == model.CandidatePromoted {
+			if err := q.ClearPromotedCandidate(ctx, release); err != nil {
+				return err
+			}
+		}
+		if state == model.CandidateDefault {
+			return q.DeleteReleaseCandidate(ctx, dbsqlc.DeleteReleaseCandidateParams{
+				Release:    release,
+				SnapshotID: snapshotID,
+			})
+		}
+		return q.UpsertReleaseCandidate(ctx, dbsqlc.UpsertReleaseCandidateParams{
+			Release:    release,
+			SnapshotID: snapshotID,
+			State:      state,
+			ChangedAt:  time.Now().UTC().Format(time.RFC3339),
+		})
+	})
+}
+
+// GetSelectedCandidate returns the snapshot that feeds readiness for
+// release: the promoted snapshot if there is one, otherwise the newest
+// snapshot of the release's application that has not been demoted.
+func (d *DB) GetSelectedCandidate(ctx context.Context, release string) (*model.SnapshotRecord, error) {
+	r, err := d.queries().GetSelectedCandidate(ctx, release)
+	if err != nil {
+		return nil, classify(err)
+	}
+	return &model.SnapshotRecord{
+		ID:          r.ID,
+		Application: r.Application,
+		Name:        r.Name,
+		TestsPassed: r.TestsPassed == 1,
+		HasTests:    r.TestCount > 0,
+		CreatedAt:   parseTime(r.CreatedAt),
+		ImageDigests: &model.ImageDigestSummary{
+			Components: int(r.ComponentCount),
+			Verified:   int(r.ImagesVerified),
+			Failed:     int(r.ImagesFailed),
+			Missing:    int(r.ImagesMissing),
+		},
+		ReleasePipelines: &model.ReleasePipelineSummary{
+			Succeeded:   int(r.ReleasesSucceeded),
+			Failed:      int(r.ReleasesFailed),
+			Progressing: int(r.ReleasesProgressing),
+		},
+		EC: &model.ECSummary{
+			Components: int(r.EcComponents),
+			Failed:     int(r.EcFailed),
+		},
+	}, nil
+}
+
+// ListSelectedCandidates returns the selected candidate of every release
+// that has one, keyed by release name.
+func (d *DB) ListSelectedCandidates(ctx context.Context) (map[string]*model.SnapshotRecord, error) {
+	rows, err := d.queries().ListSelectedCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]*model.SnapshotRecord, len(rows))
+	for _, r := range rows {
+		selected[r.Release] = &model.SnapshotRecord{
+			ID:          r.ID,
+			Application: r.Application,
+			Name:        r.Name,
+			TestsPassed: r.TestsPassed == 1,
+			HasTests:    r.TestCount > 0,
+			CreatedAt:   parseTime(r.CreatedAt),
+			ImageDigests: &model.ImageDigestSummary{
+				Components: int(r.ComponentCount),
+				Verified:   int(r.ImagesVerified),
+				Failed:     int(r.ImagesFailed),
+				Missing:    int(r.ImagesMissing),
+			},
+			ReleasePipelines: &model.ReleasePipelineSummary{
+				Succeeded:   int(r.ReleasesSucceeded),
+				Failed:      int(r.ReleasesFailed),
+				Progressing: int(r.ReleasesProgressing),
+			},
+			EC: &model.ECSummary{
+				Components: int(r.EcComponents),
+				Failed:     int(r.EcFailed),
+			},
+		}
+	}
+	return selected, nil
+}
@@ -2,6 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/quay/release-readiness/internal/db/sqlc"
 	"github.com/quay/release-readiness/internal/model"
@@ -25,7 +28,7 @@ func (d *DB) CreateComponent(ctx context.Context, name, description string) (*mo
 		Description: description,
 	})
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return &model.Component{ID: id, Name: name, Description: description}, nil
 }
@@ -33,7 +36,7 @@ func (d *DB) CreateComponent(ctx context.Context, name, description string) (*mo
 func (d *DB) GetComponentByName(ctx context.Context, name string) (*model.Component, error) {
 	row, err := d.queries().GetComponentByName(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	c := toComponent(row)
 	return &c, nil
@@ -41,17 +44,104 @@ func (d *DB) GetComponentByName(ctx context.Context, name string) (*model.Compon
 
 func (d *DB) EnsureComponent(ctx context.Context, name string) (*model.Component, error) {
 	comp, err := d.GetComponentByName(ctx, name)
-	if err == nil {
-		return comp, nil
+	if errors.Is(err, ErrNotFound) {
+		return d.CreateComponent(ctx, name, "")
+	}
+	return comp, err
+}
+
+// SetComponentJIRAComponents sets the JIRA components whose issues count
+// towards component. An empty list restores the default of the JIRA
+// component of the same name. It returns ErrNotFound if no component has
+// that name.
+func (d *DB) SetComponentJIRAComponents(ctx context.Context, component string, jiraComponents []string) error {
+	n, err := d.queries().SetComponentJiraComponents(ctx, dbsqlc.SetComponentJiraComponentsParams{
+		JiraComponents: strings.Join(jiraComponents, ","),
+		Name:           component,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("component %s: %w", component, ErrNotFound)
+	}
+	return nil
+}
+
+// countComponents fills in the per-component counts of summaries, which are
+// keyed by fixVersion. An issue counts towards every component that one of
+// its JIRA components is mapped to.
+// Stays hand-written due to variable IN clause.
+func (d *DB) countComponents(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	components, err := d.ListComponents(ctx)
+	if err != nil || len(components) == 0 || len(summaries) == 0 {
+		return err
+	}
+
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	counts := make(map[string][]model.ComponentCount, len(summaries))
+	for fixVersion := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+		counts[fixVersion] = make([]model.ComponentCount, len(components))
+	}
+
+	query := `
+		SELECT fix_version, components,
+			CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 0 ELSE 1 END AS open,
+			blocker
+		FROM release_issues
+		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `) AND components != ''`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion, names string
+		var open, blocker bool
+		if err := rows.Scan(&fixVersion, &names, &open, &blocker); err != nil {
+			return err
+		}
+		issueComponents := splitLabels(names)
+		for i, c := range components {
+			if !hasAnyLabel(issueComponents, c.IssueComponents()) {
+				continue
+			}
+			cc := &counts[fixVersion][i]
+			cc.Total++
+			if open {
+				cc.Open++
+				if blocker {
+					cc.OpenBlockers++
+				}
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for fixVersion, s := range summaries {
+		for i, cc := range counts[fixVersion] {
+			if cc.Total > 0 {
+				cc.Component = components[i].Name
+				s.Components = append(s.Components, cc)
+			}
+		}
 	}
-	return d.CreateComponent(ctx, name, "")
+	return nil
 }
 
 func toComponent(r dbsqlc.Component) model.Component {
 	return model.Component{
-		ID:          r.ID,
-		Name:        r.Name,
-		Description: r.Description,
-		CreatedAt:   parseTime(r.CreatedAt),
+		ID:             r.ID,
+		Name:           r.Name,
+		Description:    r.Description,
+		CreatedAt:      parseTime(r.CreatedAt),
+		JIRAComponents: splitLabels(r.JiraComponents),
 	}
 }
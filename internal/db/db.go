@@ -3,28 +3,68 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/tracing"
 	_ "modernc.org/sqlite"
 )
 
 //go:generate sqlc generate -f ../../sqlc.yaml
 
+// Driver names accepted by OpenDriver.
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
 type DB struct {
-	conn *sql.DB
-	dbtx dbsqlc.DBTX
+	conn   *sql.DB
+	dbtx   dbsqlc.DBTX
+	driver string
 }
 
+// MemoryPath is the special database path that selects an ephemeral,
+// in-memory database which is discarded when the DB is closed.
+const MemoryPath = ":memory:"
+
+// postgresDriver is the database/sql driver registered for PostgreSQL. It is
+// set by postgres.go, which is only built with the postgres build tag.
+var postgresDriver string
+
+// Open opens (creating if needed) the SQLite database at path and applies
+// the schema. Passing MemoryPath opens a private in-memory database.
 func Open(path string) (*DB, error) {
-	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode%%3DWAL&_pragma=foreign_keys%%3DON&_pragma=busy_timeout%%3D5000&_pragma=synchronous%%3DNORMAL", path)
-	sqlDB, err := sql.Open("sqlite", dsn)
+	return OpenDriver(SQLite, path)
+}
+
+// OpenDriver opens a database with the named driver and applies the schema.
+// For SQLite the dsn is a file path, as for Open; for PostgreSQL it is a
+// connection string or URL, with unset parameters taken from the usual PG*
+// environment variables.
+func OpenDriver(driver, dsn string) (*DB, error) {
+	var sqlDB *sql.DB
+	var err error
+	switch driver {
+	case SQLite:
+		sqlDB, err = openSQLite(dsn)
+	case Postgres:
+		if postgresDriver == "" {
+			return nil, errors.New("open database: PostgreSQL support requires building with -tags postgres")
+		}
+		sqlDB, err = sql.Open(postgresDriver, dsn)
+	default:
+		return nil, fmt.Errorf("open database: unknown driver %q", driver)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	db := &DB{conn: sqlDB, dbtx: sqlDB}
+	db := &DB{conn: sqlDB, driver: driver}
+	db.dbtx = db.wrapDBTX(sqlDB)
 	if err := db.migrate(); err != nil {
 		_ = sqlDB.Close()
 		return nil, fmt.Errorf("migrate: %w", err)
@@ -33,6 +73,25 @@ func Open(path string) (*DB, error) {
 	return db, nil
 }
 
+func openSQLite(path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode%%3DWAL&_pragma=foreign_keys%%3DON&_pragma=busy_timeout%%3D5000&_pragma=synchronous%%3DNORMAL", path)
+	if path == MemoryPath {
+		dsn = "file::memory:?_pragma=foreign_keys%3DON"
+	}
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if path == MemoryPath {
+		// Every connection to :memory: is a separate database, so pin the
+		// pool to a single long-lived connection.
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
+	return sqlDB, nil
+}
+
 func (d *DB) Close() error {
 	return d.conn.Close()
 }
@@ -44,17 +103,33 @@ func (d *DB) Ping() error {
 // InTx runs fn inside a database transaction. The fn receives a tx-scoped *DB
 // whose queries all run on the same transaction.
 func (d *DB) InTx(ctx context.Context, fn func(*DB) error) error {
+	ctx, span := tracing.Start(ctx, "db transaction", slog.String("db.system", d.driver))
+	defer span.End()
 	tx, err := d.conn.BeginTx(ctx, nil)
 	if err != nil {
+		span.RecordError(err)
 		return fmt.Errorf("begin tx: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	txDB := &DB{conn: d.conn, dbtx: tx}
+	txDB := &DB{conn: d.conn, driver: d.driver}
+	txDB.dbtx = txDB.wrapDBTX(tx)
 	if err := fn(txDB); err != nil {
+		span.RecordError(err)
 		return err
 	}
-	return tx.Commit()
+	err = tx.Commit()
+	span.RecordError(err)
+	return err
+}
+
+// wrapDBTX adapts a connection or transaction for the driver and traces
+// its queries.
+func (d *DB) wrapDBTX(dbtx dbsqlc.DBTX) dbsqlc.DBTX {
+	if d.driver == Postgres {
+		dbtx = rebindDBTX{dbtx}
+	}
+	return tracingDBTX{DBTX: dbtx, driver: d.driver}
 }
 
 func (d *DB) queries() *dbsqlc.Queries {
@@ -77,6 +152,13 @@ func parseOptionalTime(s string) *time.Time {
 	return &t
 }
 
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func boolToInt64(b bool) int64 {
 	if b {
 		return 1
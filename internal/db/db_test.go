@@ -0,0 +1,405 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	database, err := Open(MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+// TestQueryPlans guards the hot read paths against regressing to full table
+// scans when the schema or queries change.
+func TestQueryPlans(t *testing.T) {
+	database := openTestDB(t)
+
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		index string
+	}{
+		{
+			name:  "jira issues by fix_version and issue_type",
+			query: `SELECT key FROM jira_issues WHERE fix_version = ? AND issue_type = ? ORDER BY key`,
+			args:  []interface{}{"quay-v3.16.2", "Bug"},
+			index: "idx_jira_issues_fix_version_type",
+		},
+		{
+			name:  "issue summary by fix_version",
+			query: `SELECT COUNT(*) FROM jira_issues WHERE fix_version = ?`,
+			args:  []interface{}{"quay-v3.16.2"},
+			index: "idx_jira_issues_fix_version_type",
+		},
+		{
+			name:  "release issues view by fix_version",
+			query: `SELECT COUNT(*) FROM release_issues WHERE fix_version = ?`,
+			args:  []interface{}{"quay-v3.16.2"},
+			index: "idx_jira_issues_fix_version_type",
+		},
+		{
+			name:  "snapshots by application",
+			query: `SELECT id, application, name, tests_passed, created_at FROM snapshots WHERE application = ? ORDER BY created_at DESC`,
+			args:  []interface{}{"quay-v3-16"},
+			index: "idx_snapshots_application_created",
+		},
+		{
+			name:  "latest snapshot per application",
+			query: `SELECT application, MAX(id), COUNT(*) FROM snapshots GROUP BY application`,
+			index: "idx_snapshots_application_created",
+		},
+		{
+			name:  "test suites by snapshot",
+			query: `SELECT id, name FROM test_suites WHERE snapshot_id = ? ORDER BY name`,
+			args:  []interface{}{1},
+			index: "idx_test_suites_snapshot_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := explain(t, database, tt.query, tt.args...)
+			if !strings.Contains(plan, tt.index) {
+				t.Errorf("query plan does not use %s:\n%s", tt.index, plan)
+			}
+			if strings.Contains(plan, "USE TEMP B-TREE") {
+				t.Errorf("query plan requires a temp b-tree:\n%s", plan)
+			}
+		})
+	}
+}
+
+func explain(t *testing.T, d *DB, query string, args ...interface{}) string {
+	t.Helper()
+	rows, err := d.conn.QueryContext(t.Context(), "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var lines []string
+	for rows.Next() {
+		var id, parent, notused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notused, &detail); err != nil {
+			t.Fatalf("scan plan: %v", err)
+		}
+		lines = append(lines, detail)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("explain rows: %v", err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestSeed(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+
+	for i := 0; i < 2; i++ {
+		if err := database.Seed(ctx); err != nil {
+			t.Fatalf("seed (run %d): %v", i+1, err)
+		}
+	}
+
+	releases, err := database.ListAllReleaseVersions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 2 {
+		t.Errorf("releases: got %d, want 2", len(releases))
+	}
+	snaps, err := database.ListSnapshots(ctx, "", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 1 {
+		t.Errorf("snapshots: got %d, want 1 (seed should be idempotent)", len(snaps))
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+
+	if _, err := database.GetReleaseVersion(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetReleaseVersion: got %v, want ErrNotFound", err)
+	}
+	if _, err := database.GetSnapshotByID(ctx, 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSnapshotByID: got %v, want ErrNotFound", err)
+	}
+	if _, err := database.GetTestSuiteByID(ctx, 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetTestSuiteByID: got %v, want ErrNotFound", err)
+	}
+
+	if _, err := database.CreateSnapshot(ctx, "app", "snap-1", true, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.CreateSnapshot(ctx, "app", "snap-1", true, time.Now()); !errors.Is(err, ErrConflict) {
+		t.Errorf("duplicate CreateSnapshot: got %v, want ErrConflict", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := database.EnsureComponent(ctx, "quay"); err != nil {
+			t.Fatalf("EnsureComponent (run %d): %v", i+1, err)
+		}
+	}
+
+	if err := classify(sqlStateError("23505")); !errors.Is(err, ErrConflict) {
+		t.Errorf("PostgreSQL unique violation: got %v, want ErrConflict", err)
+	}
+}
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "SQLSTATE " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestRebind(t *testing.T) {
+	for in, want := range map[string]string{
+		"SELECT 1": "SELECT 1",
+		"SELECT * FROM t WHERE a = ? AND b IN (?,?)":      "SELECT * FROM t WHERE a = $1 AND b IN ($2,$3)",
+		"-- name: X? :one\nSELECT ? FROM t":               "-- name: X? :one\nSELECT $1 FROM t",
+		"SELECT '?', \"a?\", ? FROM t WHERE c = 'it''s?'": "SELECT '?', \"a?\", $1 FROM t WHERE c = 'it''s?'",
+	} {
+		if got := rebind(in); got != want {
+			t.Errorf("rebind(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOpenDriver(t *testing.T) {
+	if _, err := OpenDriver("mysql", ""); err == nil {
+		t.Error("unknown driver: got nil error")
+	}
+}
+
+func TestBackfillIssueProjects(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+	for _, key := range []string{"PROJQUAY-1", "SECURITY-2"} {
+		if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{Key: key, FixVersion: "quay-v3.17.0", UpdatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.backfillIssueProjects(); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.17.0", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range issues {
+		if i.Project != model.IssueProject(i.Key) {
+			t.Errorf("%s: project %q", i.Key, i.Project)
+		}
+	}
+}
+
+func TestBackfillBlockers(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+	for key, labels := range map[string]string{
+		"PROJQUAY-1": "Release-Blocker",
+		"PROJQUAY-2": "CVE-2026-1,blocker",
+		"PROJQUAY-3": "blocker-candidate",
+	} {
+		if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{Key: key, FixVersion: "quay-v3.17.0", Labels: labels, UpdatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.backfillBlockers(); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.17.0", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range issues {
+		if want := i.Key != "PROJQUAY-3"; i.Blocker != want {
+			t.Errorf("%s: blocker %v, want %v", i.Key, i.Blocker, want)
+		}
+	}
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+
+	snap := &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-abc",
+		TestsPassed: false,
+		CreatedAt:   time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Components:  []model.ComponentRecord{{Component: "quay", GitSHA: "abc"}},
+		TestSuites: []model.TestSuite{{
+			Name: "e2e", Status: "failed", Tests: 2, Passed: 1, Failed: 1,
+			TestCases: []model.TestCase{
+				{Name: "login", Status: "passed"},
+				{Name: "push", Status: "failed", Message: "timeout"},
+			},
+		}},
+		VulnerabilityReports: []model.VulnerabilityReport{{
+			Component: "quay", Arch: "amd64", Total: 1, High: 1,
+			Vulnerabilities: []model.Vulnerability{{Name: "CVE-2026-1", Severity: "High"}},
+		}},
+	}
+	if err := database.SaveSnapshot(ctx, snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.ID == 0 || snap.TestSuites[0].ID == 0 || snap.VulnerabilityReports[0].ID == 0 {
+		t.Fatalf("IDs not set: %+v", snap)
+	}
+
+	cases, err := database.ListTestCases(ctx, snap.TestSuites[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 {
+		t.Errorf("test cases: got %d, want 2", len(cases))
+	}
+	reports, err := database.ListVulnerabilityReports(ctx, snap.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].High != 1 {
+		t.Errorf("vulnerability reports: got %+v", reports)
+	}
+
+	// A failure part-way through leaves nothing behind.
+	if _, err := database.conn.ExecContext(ctx, "DROP TABLE vulnerabilities"); err != nil {
+		t.Fatal(err)
+	}
+	failed := &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-def",
+		CreatedAt:   time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC),
+		Components:  []model.ComponentRecord{{Component: "quay", GitSHA: "def"}},
+		VulnerabilityReports: []model.VulnerabilityReport{{
+			Component: "quay", Arch: "amd64", Total: 1,
+			Vulnerabilities: []model.Vulnerability{{Name: "CVE-2026-2"}},
+		}},
+	}
+	if err := database.SaveSnapshot(ctx, failed); err == nil {
+		t.Fatal("save without vulnerabilities table: got nil error")
+	}
+	exists, err := database.SnapshotExistsByName(ctx, failed.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Error("snapshot stored despite failed save")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	database := openTestDB(t)
+	ctx := t.Context()
+
+	snap := &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-20261001-000",
+		CreatedAt:   time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Components:  []model.ComponentRecord{{Component: "quay-bundle", GitSHA: "abc"}},
+		TestSuites: []model.TestSuite{{
+			Name: "e2e", Status: "failed", Tests: 3, Passed: 1, Failed: 1, Skipped: 1,
+			TestCases: []model.TestCase{
+				{Name: "test_login", Status: "passed"},
+				{Name: "test_mirror_sync", Status: "failed", Message: "AssertionError: mirror did not complete within 60s"},
+				{Name: "test_mirror_gc", Status: "skipped", Message: "mirror disabled"},
+			},
+		}},
+	}
+	if err := database.SaveSnapshot(ctx, snap); err != nil {
+		t.Fatal(err)
+	}
+	issue := &model.JiraIssueRecord{Key: "PROJQUAY-123", Summary: "Repository mirror stalls on large tags", FixVersion: "quay-v3.17.0", Status: "Open", UpdatedAt: time.Now()}
+	if err := database.UpsertJiraIssue(ctx, issue); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		results, err := database.Search(ctx, query, 10)
+		if err != nil {
+			t.Fatalf("search %q: %v", query, err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Type+":"+r.Name)
+		}
+		return got
+	}
+	for query, want := range map[string][]string{
+		"mirror":          {"issue:PROJQUAY-123", "failure:test_mirror_sync"},
+		"MIRR stall":      {"issue:PROJQUAY-123"},
+		"projquay-12":     {"issue:PROJQUAY-123"},
+		"quay-v3-17-2026": {"snapshot:quay-v3-17-20261001-000"},
+		"bundle":          {"component:quay-bundle"},
+		`"60s" OR`:        nil,
+		"-- *":            nil,
+	} {
+		if got := search(query); !slices.Equal(got, want) {
+			t.Errorf("%q: got %v, want %v", query, got, want)
+		}
+	}
+
+	results, err := database.Search(ctx, "within", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Scenario != "e2e" || results[0].Snapshot != snap.Name ||
+		results[0].Application != "quay-v3-17" || results[0].CreatedAt == nil || results[0].Summary == "" {
+		t.Errorf("failure: got %+v", results)
+	}
+	results, err = database.Search(ctx, "quay-bundle", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Snapshot != snap.Name {
+		t.Errorf("component: got %+v", results)
+	}
+
+	// Indexes created on an existing database are filled from it.
+	for _, idx := range searchIndexes {
+		if _, err := database.conn.ExecContext(ctx, "DROP TABLE "+idx.table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.createSearchIndexes(); err != nil {
+		t.Fatal(err)
+	}
+	if got := search("mirror"); len(got) != 2 {
+		t.Errorf("after refill: got %v", got)
+	}
+
+	// Updates and deletes, including cascaded ones, are followed.
+	issue.Summary = "Repository mirror hangs"
+	if err := database.UpsertJiraIssue(ctx, issue); err != nil {
+		t.Fatal(err)
+	}
+	if got := search("stall"); got != nil {
+		t.Errorf("updated issue: got %v", got)
+	}
+	if err := database.DeleteSnapshots(ctx, []int64{snap.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if got := search("mirror"); !slices.Equal(got, []string{"issue:PROJQUAY-123"}) {
+		t.Errorf("after delete: got %v", got)
+	}
+	if got := search("quay-v3-17"); got != nil {
+		t.Errorf("deleted snapshot: got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// CreateDigestSubscription records a subscription, setting its ID and, if
+// unset, its creation time. It returns ErrConflict if the recipient is
+// already subscribed to the same releases at the same frequency.
+func (d *DB) CreateDigestSubscription(ctx context.Context, sub *model.DigestSubscription) error {
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now().UTC()
+	}
+	id, err := d.queries().CreateDigestSubscription(ctx, dbsqlc.CreateDigestSubscriptionParams{
+		Email:     sub.Email,
+		Release:   sub.Release,
+		Frequency: sub.Frequency,
+		CreatedBy: sub.CreatedBy,
+		CreatedAt: sub.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return classify(err)
+	}
+	sub.ID = id
+	return nil
+}
+
+// ListDigestSubscriptions returns every digest subscription, by recipient.
+func (d *DB) ListDigestSubscriptions(ctx context.Context) ([]model.DigestSubscription, error) {
+	rows, err := d.queries().ListDigestSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs := make([]model.DigestSubscription, len(rows))
+	for i, r := range rows {
+		subs[i] = model.DigestSubscription{
+			ID:        r.ID,
+			Email:     r.Email,
+			Release:   r.Release,
+			Frequency: r.Frequency,
+			CreatedBy: r.CreatedBy,
+			CreatedAt: parseTime(r.CreatedAt),
+		}
+	}
+	return subs, nil
+}
+
+// DeleteDigestSubscription removes a subscription. It returns ErrNotFound
+// if there is none with that ID.
+func (d *DB) DeleteDigestSubscription(ctx context.Context, id int64) error {
+	n, err := d.queries().DeleteDigestSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("digest subscription %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// ListDigestStates returns what the last digest of frequency reported of
+// each release, keyed by release name.
+func (d *DB) ListDigestStates(ctx context.Context, frequency string) (map[string]model.DigestState, error) {
+	rows, err := d.queries().ListDigestStates(ctx, frequency)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]model.DigestState, len(rows))
+	for _, r := range rows {
+		states[r.Release] = model.DigestState{
+			Frequency:       r.Frequency,
+			Release:         r.Release,
+			SentAt:          parseTime(r.SentAt),
+			Blockers:        splitList(r.Blockers),
+			FailedScenarios: splitList(r.FailedScenarios),
+		}
+	}
+	return states, nil
+}
+
+// SaveDigestState records what a digest reported of a release.
+func (d *DB) SaveDigestState(ctx context.Context, state model.DigestState) error {
+	return d.queries().UpsertDigestState(ctx, dbsqlc.UpsertDigestStateParams{
+		Frequency:       state.Frequency,
+		Release:         state.Release,
+		SentAt:          state.SentAt.UTC().Format(time.RFC3339),
+		Blockers:        strings.Join(state.Blockers, ","),
+		FailedScenarios: strings.Join(state.FailedScenarios, ","),
+	})
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Kinds of stored Enterprise Contract findings.
+const (
+	ecViolation = "violation"
+	ecWarning   = "warning"
+)
+
+// createECResult stores the Enterprise Contract result of a snapshot
+// component along with its violations and warnings.
+func (d *DB) createECResult(ctx context.Context, snapshotID int64, r *model.ECResult) error {
+	var success int64
+	if r.Success {
+		success = 1
+	}
+	id, err := d.queries().CreateECResult(ctx, dbsqlc.CreateECResultParams{
+		SnapshotID: snapshotID,
+		Component:  r.Component,
+		ImageUrl:   r.ImageURL,
+		Success:    success,
+	})
+	if err != nil {
+		return classify(err)
+	}
+	groups := []struct {
+		kind     string
+		findings []model.ECFinding
+	}{{ecViolation, r.Violations}, {ecWarning, r.Warnings}}
+	for _, g := range groups {
+		for _, f := range g.findings {
+			if err := d.queries().CreateECFinding(ctx, dbsqlc.CreateECFindingParams{
+				ResultID: id,
+				Kind:     g.kind,
+				Code:     f.Code,
+				Title:    f.Title,
+				Message:  f.Message,
+				Solution: f.Solution,
+			}); err != nil {
+				return fmt.Errorf("create %s %s: %w", g.kind, f.Code, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetECReport returns the Enterprise Contract results of the named
+// snapshot, or ErrNotFound if the snapshot is not stored. A snapshot
+// without results yields a report with no components that has not passed.
+func (d *DB) GetECReport(ctx context.Context, name string) (*model.ECReport, error) {
+	snap, err := d.queries().GetSnapshotRow(ctx, name)
+	if err != nil {
+		return nil, classify(err)
+	}
+	rows, err := d.queries().ListECResultsBySnapshot(ctx, snap.ID)
+	if err != nil {
+		return nil, err
+	}
+	findings, err := d.queries().ListECFindingsBySnapshot(ctx, snap.ID)
+	if err != nil {
+		return nil, err
+	}
+	report := &model.ECReport{
+		Snapshot:   name,
+		Success:    len(rows) > 0,
+		Components: make([]model.ECResult, len(rows)),
+	}
+	index := make(map[int64]*model.ECResult, len(rows))
+	for i, r := range rows {
+		report.Components[i] = model.ECResult{
+			Component:  r.Component,
+			ImageURL:   r.ImageUrl,
+			Success:    r.Success == 1,
+			Violations: []model.ECFinding{},
+			Warnings:   []model.ECFinding{},
+		}
+		index[r.ID] = &report.Components[i]
+		report.Success = report.Success && r.Success == 1
+	}
+	for _, f := range findings {
+		c := index[f.ResultID]
+		finding := model.ECFinding{Code: f.Code, Title: f.Title, Message: f.Message, Solution: f.Solution}
+		if f.Kind == ecViolation {
+			c.Violations = append(c.Violations, finding)
+		} else {
+			c.Warnings = append(c.Warnings, finding)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Sentinel errors returned (wrapped) by DB methods. Callers should test for
+// them with errors.Is rather than inspecting driver errors.
+var (
+	// ErrNotFound is returned when a lookup matches no row.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write violates a UNIQUE or PRIMARY KEY
+	// constraint, e.g. creating a snapshot whose name already exists.
+	ErrConflict = errors.New("conflict")
+)
+
+// pgUniqueViolation is the PostgreSQL SQLSTATE for a UNIQUE or PRIMARY KEY
+// constraint violation.
+const pgUniqueViolation = "23505"
+
+// classify wraps driver errors in the matching sentinel. The original error
+// stays in the chain so its message and type are preserved.
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	var se *sqlite.Error
+	if errors.As(err, &se) {
+		switch se.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return fmt.Errorf("%w: %w", ErrConflict, err)
+		}
+	}
+	// PostgreSQL drivers expose the SQLSTATE of server errors; matching on
+	// it keeps this package free of a hard dependency on one of them.
+	var pe interface{ SQLState() string }
+	if errors.As(err, &pe) && pe.SQLState() == pgUniqueViolation {
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// GetGitRange returns the cached changes in repo (owner/name) between the
+// commits from and to. It returns ErrNotFound if the range has not been
+// fetched, or was fetched before commit lists were cached.
+func (d *DB) GetGitRange(ctx context.Context, repo, from, to string) (*model.GitRange, error) {
+	q := d.queries()
+	total, err := q.GetGitRange(ctx, dbsqlc.GetGitRangeParams{Repo: repo, FromSha: from, ToSha: to})
+	if err != nil {
+		return nil, classify(err)
+	}
+	if total < 0 {
+		return nil, fmt.Errorf("commits of %s %s...%s: %w", repo, from, to, ErrNotFound)
+	}
+	commits, err := q.ListGitRangeCommits(ctx, dbsqlc.ListGitRangeCommitsParams{Repo: repo, FromSha: from, ToSha: to})
+	if err != nil {
+		return nil, err
+	}
+	prs, err := q.ListGitRangePullRequests(ctx, dbsqlc.ListGitRangePullRequestsParams{Repo: repo, FromSha: from, ToSha: to})
+	if err != nil {
+		return nil, err
+	}
+	r := &model.GitRange{
+		Commits:      make([]model.Commit, len(commits)),
+		TotalCommits: int(total),
+		PullRequests: make([]model.PullRequest, len(prs)),
+	}
+	for i, c := range commits {
+		r.Commits[i] = model.Commit{SHA: c.Sha, Subject: c.Subject, Author: c.Author, URL: c.Url}
+	}
+	for i, p := range prs {
+		r.PullRequests[i] = model.PullRequest{
+			Number:   int(p.Number),
+			Title:    p.Title,
+			Author:   p.Author,
+			URL:      p.Url,
+			MergedAt: parseTime(p.MergedAt),
+		}
+	}
+	return r, nil
+}
+
+// SaveGitRange caches the changes in repo between the commits from and to,
+// replacing any cached before. It runs in its own transaction.
+func (d *DB) SaveGitRange(ctx context.Context, repo, from, to string, r *model.GitRange) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		if err := q.UpsertGitRange(ctx, dbsqlc.UpsertGitRangeParams{
+			Repo:         repo,
+			FromSha:      from,
+			ToSha:        to,
+			FetchedAt:    time.Now().UTC().Format(time.RFC3339),
+			TotalCommits: int64(r.TotalCommits),
+		}); err != nil {
+			return err
+		}
+		if err := q.DeleteGitRangeCommits(ctx, dbsqlc.DeleteGitRangeCommitsParams{Repo: repo, FromSha: from, ToSha: to}); err != nil {
+			return err
+		}
+		for i, c := range r.Commits {
+			if err := q.CreateGitRangeCommit(ctx, dbsqlc.CreateGitRangeCommitParams{
+				Repo:     repo,
+				FromSha:  from,
+				ToSha:    to,
+				Position: int64(i),
+				Sha:      c.SHA,
+				Subject:  c.Subject,
+				Author:   c.Author,
+				Url:      c.URL,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := q.DeleteGitRangePullRequests(ctx, dbsqlc.DeleteGitRangePullRequestsParams{Repo: repo, FromSha: from, ToSha: to}); err != nil {
+			return err
+		}
+		for _, pr := range r.PullRequests {
+			if err := q.CreateGitRangePullRequest(ctx, dbsqlc.CreateGitRangePullRequestParams{
+				Repo:     repo,
+				FromSha:  from,
+				ToSha:    to,
+				Number:   int64(pr.Number),
+				Title:    pr.Title,
+				Author:   pr.Author,
+				Url:      pr.URL,
+				MergedAt: pr.MergedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// CreateReadinessPoint appends p to its release's readiness history,
+// recording it now if p.RecordedAt is unset.
+func (d *DB) CreateReadinessPoint(ctx context.Context, p *model.ReadinessPoint) error {
+	if p.RecordedAt.IsZero() {
+		p.RecordedAt = time.Now().UTC()
+	}
+	return d.queries().CreateReadinessHistory(ctx, dbsqlc.CreateReadinessHistoryParams{
+		Release:      p.Release,
+		Signal:       p.Signal,
+		Total:        int64(p.Total),
+		Open:         int64(p.Open),
+		Verified:     int64(p.Verified),
+		Cves:         int64(p.CVEs),
+		Bugs:         int64(p.Bugs),
+		BlockingCves: int64(p.BlockingCVEs),
+		RecordedAt:   p.RecordedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// ListReadinessHistory returns the readiness history of release, oldest
+// first.
+func (d *DB) ListReadinessHistory(ctx context.Context, release string) ([]model.ReadinessPoint, error) {
+	rows, err := d.queries().ListReadinessHistory(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]model.ReadinessPoint, len(rows))
+	for i, r := range rows {
+		points[i] = toReadinessPoint(r)
+	}
+	return points, nil
+}
+
+// LatestReadinessPoints returns the most recent point of each release's
+// history, keyed by release name.
+func (d *DB) LatestReadinessPoints(ctx context.Context) (map[string]model.ReadinessPoint, error) {
+	rows, err := d.queries().ListLatestReadinessHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	points := make(map[string]model.ReadinessPoint, len(rows))
+	for _, r := range rows {
+		points[r.Release] = toReadinessPoint(r)
+	}
+	return points, nil
+}
+
+func toReadinessPoint(r dbsqlc.ReleaseReadinessHistory) model.ReadinessPoint {
+	return model.ReadinessPoint{
+		Release:      r.Release,
+		Signal:       r.Signal,
+		Total:        int(r.Total),
+		Open:         int(r.Open),
+		Verified:     int(r.Verified),
+		CVEs:         int(r.Cves),
+		Bugs:         int(r.Bugs),
+		BlockingCVEs: int(r.BlockingCves),
+		RecordedAt:   parseTime(r.RecordedAt),
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// SaveImageVerifications replaces the image verification results of a
+// snapshot. It runs in its own transaction.
+func (d *DB) SaveImageVerifications(ctx context.Context, snapshotID int64, results []model.ImageVerification) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		if err := q.DeleteImageVerifications(ctx, snapshotID); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if err := q.CreateImageVerification(ctx, dbsqlc.CreateImageVerificationParams{
+				SnapshotID: snapshotID,
+				Component:  r.Component,
+				ImageUrl:   r.ImageURL,
+				Tag:        r.Tag,
+				Status:     r.Status,
+				Message:    r.Message,
+				CheckedAt:  r.CheckedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return classify(err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListImageVerifications returns the image verification results of a snapshot.
+func (d *DB) ListImageVerifications(ctx context.Context, snapshotID int64) ([]model.ImageVerification, error) {
+	rows, err := d.queries().ListImageVerifications(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.ImageVerification, len(rows))
+	for i, r := range rows {
+		results[i] = model.ImageVerification{
+			Component: r.Component,
+			ImageURL:  r.ImageUrl,
+			Tag:       r.Tag,
+			Status:    r.Status,
+			Message:   r.Message,
+			CheckedAt: parseTime(r.CheckedAt),
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// RecordIngestFailure records a failed attempt to ingest the snapshot of f,
+// identified by its Source and Key, and updates f from the stored failure:
+// its ID, attempt count and first failure. The failure becomes dead once
+// it has been attempted maxAttempts times.
+func (d *DB) RecordIngestFailure(ctx context.Context, f *model.IngestFailure, maxAttempts int) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	row, err := d.queries().RecordIngestFailure(ctx, dbsqlc.RecordIngestFailureParams{
+		Source:        f.Source,
+		Key:           f.Key,
+		Application:   f.Application,
+		Snapshot:      f.Snapshot,
+		LastError:     f.LastError,
+		Dead:          boolToInt64(maxAttempts <= 1),
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+		MaxAttempts:   int64(maxAttempts),
+	})
+	if err != nil {
+		return err
+	}
+	*f = toIngestFailure(row)
+	return nil
+}
+
+// ListIngestFailures returns the snapshots whose ingestion failed, most
+// recently failed first.
+func (d *DB) ListIngestFailures(ctx context.Context) ([]model.IngestFailure, error) {
+	rows, err := d.queries().ListIngestFailures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	failures := make([]model.IngestFailure, len(rows))
+	for i, r := range rows {
+		failures[i] = toIngestFailure(r)
+	}
+	return failures, nil
+}
+
+// GetIngestFailure returns the ingestion failure with id. It returns
+// ErrNotFound if there is none.
+func (d *DB) GetIngestFailure(ctx context.Context, id int64) (*model.IngestFailure, error) {
+	row, err := d.queries().GetIngestFailure(ctx, id)
+	if err != nil {
+		return nil, classify(err)
+	}
+	f := toIngestFailure(row)
+	return &f, nil
+}
+
+// ClearIngestFailure forgets the failures of the snapshot at key in source,
+// once it has been ingested or is to be retried afresh. It is not an error
+// if there are none.
+func (d *DB) ClearIngestFailure(ctx context.Context, source, key string) error {
+	return d.queries().DeleteIngestFailure(ctx, dbsqlc.DeleteIngestFailureParams{
+		Source: source,
+		Key:    key,
+	})
+}
+
+func toIngestFailure(r dbsqlc.IngestFailure) model.IngestFailure {
+	return model.IngestFailure{
+		ID:            r.ID,
+		Source:        r.Source,
+		Key:           r.Key,
+		Application:   r.Application,
+		Snapshot:      r.Snapshot,
+		Attempts:      int(r.Attempts),
+		LastError:     r.LastError,
+		Dead:          r.Dead == 1,
+		FirstFailedAt: parseTime(r.FirstFailedAt),
+		LastFailedAt:  parseTime(r.LastFailedAt),
+	}
+}
@@ -9,8 +9,15 @@ import (
 	"github.com/quay/release-readiness/internal/model"
 )
 
+// UpsertJiraIssue stores an issue under its fixVersion along with its
+// blockers and sub-tasks, which replace those of the issue's previous sync.
+// An issue new to a fixVersion that was synced before is recorded as a
+// scope change.
 func (d *DB) UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord) error {
-	return d.queries().UpsertJiraIssue(ctx, dbsqlc.UpsertJiraIssueParams{
+	if err := d.recordScopeAddition(ctx, issue); err != nil {
+		return err
+	}
+	err := d.queries().UpsertJiraIssue(ctx, dbsqlc.UpsertJiraIssueParams{
 		Key:        issue.Key,
 		Summary:    issue.Summary,
 		Status:     issue.Status,
@@ -23,29 +30,120 @@ func (d *DB) UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord)
 		Link:       issue.Link,
 		QaContact:  issue.QAContact,
 		UpdatedAt:  issue.UpdatedAt.UTC().Format(time.RFC3339),
+		Severity:   issue.Severity,
+		Clones:     issue.Clones,
+		Project:    issue.Project,
+		CveID:      issue.CVEID,
+		CvssScore:  issue.CVSSScore,
+		Embargoed:  boolToInt64(issue.Embargoed),
+		Blocker:    boolToInt64(issue.Blocker),
+		Components: issue.Components,
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.replaceIssueBlockers(ctx, issue); err != nil {
+		return err
+	}
+	return d.replaceSubtasks(ctx, issue)
+}
+
+// ListJiraIssueVersions returns the fixVersions an issue is stored under.
+func (d *DB) ListJiraIssueVersions(ctx context.Context, key string) ([]string, error) {
+	return d.queries().ListJiraIssueVersions(ctx, key)
+}
+
+// DeleteJiraIssue removes an issue from a fixVersion, recording it as a
+// scope change.
+func (d *DB) DeleteJiraIssue(ctx context.Context, key, fixVersion string) error {
+	if err := d.queries().RecordScopeRemoval(ctx, dbsqlc.RecordScopeRemovalParams{
+		ChangedAt:  time.Now().UTC().Format(time.RFC3339),
+		Key:        key,
+		FixVersion: fixVersion,
+	}); err != nil {
+		return err
+	}
+	return d.queries().DeleteJiraIssue(ctx, dbsqlc.DeleteJiraIssueParams{Key: key, FixVersion: fixVersion})
+}
+
+// ListJiraSyncStates returns the sync state of every fixVersion that has
+// been synced, keyed by fixVersion.
+func (d *DB) ListJiraSyncStates(ctx context.Context) (map[string]model.JiraSyncState, error) {
+	rows, err := d.queries().ListJiraSyncStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]model.JiraSyncState, len(rows))
+	for _, r := range rows {
+		states[r.FixVersion] = model.JiraSyncState{
+			FixVersion:   r.FixVersion,
+			SyncedAt:     parseOptionalTime(r.SyncedAt),
+			ReconciledAt: parseOptionalTime(r.ReconciledAt),
+		}
+	}
+	return states, nil
+}
+
+// SaveJiraSyncState records the sync state of a fixVersion.
+func (d *DB) SaveJiraSyncState(ctx context.Context, state model.JiraSyncState) error {
+	return d.queries().UpsertJiraSyncState(ctx, dbsqlc.UpsertJiraSyncStateParams{
+		FixVersion:   state.FixVersion,
+		SyncedAt:     formatOptionalTime(state.SyncedAt),
+		ReconciledAt: formatOptionalTime(state.ReconciledAt),
 	})
 }
 
-// ListJiraIssues returns issues for a fixVersion with optional filters.
-// Stays hand-written due to dynamic WHERE clause construction.
-func (d *DB) ListJiraIssues(ctx context.Context, fixVersion string, issueType, status, label string) ([]model.JiraIssueRecord, error) {
-	query := `SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at
-		FROM jira_issues WHERE fix_version = ?`
+// issueFilterWhere returns the WHERE clause selecting the issues of a
+// fixVersion matching filter, with its arguments.
+func issueFilterWhere(fixVersion string, filter model.IssueFilter) (string, []interface{}) {
+	where := ` WHERE fix_version = ?`
 	args := []interface{}{fixVersion}
 
-	if issueType != "" {
-		query += ` AND issue_type = ?`
-		args = append(args, issueType)
+	if filter.Type != "" {
+		where += ` AND issue_type = ?`
+		args = append(args, filter.Type)
 	}
-	if status != "" {
-		query += ` AND status = ?`
-		args = append(args, status)
+	if filter.Status != "" {
+		where += ` AND status = ?`
+		args = append(args, filter.Status)
 	}
-	if label != "" {
-		query += ` AND labels LIKE ?`
-		args = append(args, "%"+label+"%")
+	if filter.Assignee != "" {
+		where += ` AND assignee = ?`
+		args = append(args, filter.Assignee)
 	}
+	if filter.Resolution != "" {
+		where += ` AND resolution = ?`
+		args = append(args, filter.Resolution)
+	}
+	if filter.Label != "" {
+		// LOWER keeps the match case-insensitive on PostgreSQL, as LIKE
+		// already is on SQLite.
+		where += ` AND LOWER(labels) LIKE ?`
+		args = append(args, "%"+strings.ToLower(filter.Label)+"%")
+	}
+	return where, args
+}
+
+// CountJiraIssues returns the number of issues for a fixVersion matching
+// filter, ignoring its limit and offset.
+func (d *DB) CountJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) (int, error) {
+	where, args := issueFilterWhere(fixVersion, filter)
+	var n int
+	err := d.dbtx.QueryRowContext(ctx, `SELECT COUNT(*) FROM release_issues`+where, args...).Scan(&n)
+	return n, err
+}
+
+// ListJiraIssues returns issues for a fixVersion matching filter, ordered by
+// key. Stays hand-written due to dynamic WHERE clause construction.
+func (d *DB) ListJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) ([]model.JiraIssueRecord, error) {
+	where, args := issueFilterWhere(fixVersion, filter)
+	query := `SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+		FROM release_issues` + where
 	query += ` ORDER BY key`
+	if filter.Limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, filter.Limit, filter.Offset)
+	}
 
 	rows, err := d.dbtx.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -57,15 +155,64 @@ func (d *DB) ListJiraIssues(ctx context.Context, fixVersion string, issueType, s
 	for rows.Next() {
 		var i model.JiraIssueRecord
 		var ts string
+		var embargoed, blocker int64
 		if err := rows.Scan(&i.ID, &i.Key, &i.Summary, &i.Status, &i.Priority,
 			&i.Labels, &i.FixVersion, &i.Assignee, &i.IssueType, &i.Resolution,
-			&i.Link, &i.QAContact, &ts); err != nil {
+			&i.Link, &i.QAContact, &ts, &i.Severity, &i.Clones, &i.Project,
+			&i.CVEID, &i.CVSSScore, &embargoed, &blocker, &i.Components); err != nil {
 			return nil, err
 		}
 		i.UpdatedAt = parseTime(ts)
+		i.Embargoed = embargoed == 1
+		i.Blocker = blocker == 1
 		issues = append(issues, i)
 	}
-	return issues, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := d.attachBlockers(ctx, fixVersion, issues); err != nil {
+		return nil, err
+	}
+	if err := d.attachSubtasks(ctx, fixVersion, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// ListReleaseCVEs returns the CVE issues of a fixVersion (type
+// Vulnerability or a CVE label), most severe first.
+func (d *DB) ListReleaseCVEs(ctx context.Context, fixVersion string) ([]model.JiraIssueRecord, error) {
+	rows, err := d.queries().ListReleaseCVEs(ctx, fixVersion)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]model.JiraIssueRecord, len(rows))
+	for i, r := range rows {
+		issues[i] = model.JiraIssueRecord{
+			ID:         r.ID,
+			Key:        r.Key,
+			Project:    r.Project,
+			Summary:    r.Summary,
+			Status:     r.Status,
+			Priority:   r.Priority,
+			Labels:     r.Labels,
+			FixVersion: r.FixVersion,
+			Assignee:   r.Assignee,
+			IssueType:  r.IssueType,
+			Resolution: r.Resolution,
+			Link:       r.Link,
+			QAContact:  r.QaContact,
+			Severity:   r.Severity,
+			Clones:     r.Clones,
+			UpdatedAt:  parseTime(r.UpdatedAt),
+			CVEID:      r.CveID,
+			CVSSScore:  r.CvssScore,
+			Embargoed:  r.Embargoed == 1,
+			Blocker:    r.Blocker == 1,
+			Components: r.Components,
+		}
+	}
+	return issues, nil
 }
 
 func (d *DB) GetIssueSummary(ctx context.Context, fixVersion string) (*model.IssueSummary, error) {
@@ -73,13 +220,41 @@ func (d *DB) GetIssueSummary(ctx context.Context, fixVersion string) (*model.Iss
 	if err != nil {
 		return nil, err
 	}
-	return &model.IssueSummary{
-		Total:    int(row.Total),
-		Verified: int(row.Verified),
-		Open:     int(row.Open),
-		CVEs:     int(row.Cves),
-		Bugs:     int(row.Bugs),
-	}, nil
+	severities, err := d.queries().CountOpenCVEsBySeverity(ctx, fixVersion)
+	if err != nil {
+		return nil, err
+	}
+	s := &model.IssueSummary{
+		Total:        int(row.Total),
+		Verified:     int(row.Verified),
+		Open:         int(row.Open),
+		CVEs:         int(row.Cves),
+		Bugs:         int(row.Bugs),
+		OpenBlockers: int(row.OpenBlockers),
+	}
+	for _, r := range severities {
+		s.AddOpenCVE(r.Severity, int(r.Cnt))
+	}
+	summaries := map[string]*model.IssueSummary{fixVersion: s}
+	if err := d.countBlocked(ctx, summaries); err != nil {
+		return nil, err
+	}
+	if err := d.countSubtasks(ctx, summaries); err != nil {
+		return nil, err
+	}
+	if err := d.countBuckets(ctx, summaries); err != nil {
+		return nil, err
+	}
+	if err := d.countProjects(ctx, summaries); err != nil {
+		return nil, err
+	}
+	if err := d.countCVESeverities(ctx, summaries); err != nil {
+		return nil, err
+	}
+	if err := d.countComponents(ctx, summaries); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // GetIssueSummariesBatch returns aggregate counts for multiple fixVersions in a single query.
@@ -102,8 +277,9 @@ func (d *DB) GetIssueSummariesBatch(ctx context.Context, fixVersions []string) (
 			SUM(CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS verified,
 			SUM(CASE WHEN LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS open,
 			SUM(CASE WHEN LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%' THEN 1 ELSE 0 END) AS cves,
-			SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END) AS bugs
-		FROM jira_issues
+			SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END) AS bugs,
+			SUM(CASE WHEN blocker = 1 AND LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS open_blockers
+		FROM release_issues
 		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `)
 		GROUP BY fix_version`
 
@@ -117,12 +293,154 @@ func (d *DB) GetIssueSummariesBatch(ctx context.Context, fixVersions []string) (
 	for rows.Next() {
 		var fixVersion string
 		var s model.IssueSummary
-		if err := rows.Scan(&fixVersion, &s.Total, &s.Verified, &s.Open, &s.CVEs, &s.Bugs); err != nil {
+		if err := rows.Scan(&fixVersion, &s.Total, &s.Verified, &s.Open, &s.CVEs, &s.Bugs, &s.OpenBlockers); err != nil {
 			return nil, err
 		}
 		result[fixVersion] = &s
 	}
-	return result, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT fix_version, severity, COUNT(*)
+		FROM release_issues
+		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `)
+			AND LOWER(status) NOT IN ('closed', 'verified', 'done')
+			AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+		GROUP BY fix_version, severity`
+
+	sevRows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = sevRows.Close() }()
+
+	for sevRows.Next() {
+		var fixVersion, severity string
+		var n int
+		if err := sevRows.Scan(&fixVersion, &severity, &n); err != nil {
+			return nil, err
+		}
+		if s := result[fixVersion]; s != nil {
+			s.AddOpenCVE(severity, n)
+		}
+	}
+	if err := sevRows.Err(); err != nil {
+		return nil, err
+	}
+	if err := d.countBlocked(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := d.countSubtasks(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := d.countBuckets(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := d.countProjects(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := d.countCVESeverities(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := d.countComponents(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// countCVESeverities fills in the per-severity CVE counts of each summary,
+// keyed by fixVersion.
+func (d *DB) countCVESeverities(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	for fixVersion := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+	}
+
+	query := `
+		SELECT fix_version, severity,
+			COUNT(*) AS total,
+			SUM(CASE WHEN LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS open,
+			SUM(CASE WHEN embargoed = 1 THEN 1 ELSE 0 END) AS embargoed,
+			MAX(cvss_score) AS max_cvss
+		FROM release_issues
+		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `)
+			AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+		GROUP BY fix_version, severity
+		ORDER BY
+			CASE severity
+				WHEN 'Critical' THEN 0
+				WHEN 'Important' THEN 1
+				WHEN 'Moderate' THEN 2
+				WHEN 'Low' THEN 3
+				ELSE 4
+			END,
+			severity`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion string
+		var c model.CVESeverityCount
+		if err := rows.Scan(&fixVersion, &c.Severity, &c.Total, &c.Open, &c.Embargoed, &c.MaxCVSS); err != nil {
+			return err
+		}
+		if s := summaries[fixVersion]; s != nil {
+			s.CVESeverities = append(s.CVESeverities, c)
+		}
+	}
+	return rows.Err()
+}
+
+// countProjects fills in the per-project counts of each summary, keyed by
+// fixVersion.
+func (d *DB) countProjects(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	for fixVersion := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+	}
+
+	query := `
+		SELECT fix_version, project,
+			COUNT(*) AS total,
+			SUM(CASE WHEN LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS open
+		FROM release_issues
+		WHERE fix_version IN (` + strings.Join(placeholders, ",") + `)
+		GROUP BY fix_version, project
+		ORDER BY project`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion string
+		var c model.ProjectCount
+		if err := rows.Scan(&fixVersion, &c.Project, &c.Total, &c.Open); err != nil {
+			return err
+		}
+		if s := summaries[fixVersion]; s != nil {
+			s.Projects = append(s.Projects, c)
+		}
+	}
+	return rows.Err()
 }
 
 func (d *DB) UpsertReleaseVersion(ctx context.Context, v *model.ReleaseVersion) error {
@@ -150,10 +468,10 @@ func (d *DB) UpsertReleaseVersion(ctx context.Context, v *model.ReleaseVersion)
 func (d *DB) GetReleaseVersion(ctx context.Context, name string) (*model.ReleaseVersion, error) {
 	row, err := d.queries().GetReleaseVersion(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return toReleaseVersion(row.Name, row.Description, row.ReleaseDate, row.Released, row.Archived,
-		row.ReleaseTicketKey, row.ReleaseTicketAssignee, row.S3Application, row.DueDate), nil
+		row.ReleaseTicketKey, row.ReleaseTicketAssignee, row.S3Application, row.DueDate, row.Hidden), nil
 }
 
 func (d *DB) ListActiveReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error) {
@@ -164,7 +482,7 @@ func (d *DB) ListActiveReleaseVersions(ctx context.Context) ([]model.ReleaseVers
 	versions := make([]model.ReleaseVersion, len(rows))
 	for i, r := range rows {
 		versions[i] = *toReleaseVersion(r.Name, r.Description, r.ReleaseDate, r.Released, r.Archived,
-			r.ReleaseTicketKey, r.ReleaseTicketAssignee, r.S3Application, r.DueDate)
+			r.ReleaseTicketKey, r.ReleaseTicketAssignee, r.S3Application, r.DueDate, r.Hidden)
 	}
 	return versions, nil
 }
@@ -177,30 +495,100 @@ func (d *DB) ListAllReleaseVersions(ctx context.Context) ([]model.ReleaseVersion
 	versions := make([]model.ReleaseVersion, len(rows))
 	for i, r := range rows {
 		versions[i] = *toReleaseVersion(r.Name, r.Description, r.ReleaseDate, r.Released, r.Archived,
-			r.ReleaseTicketKey, r.ReleaseTicketAssignee, r.S3Application, r.DueDate)
+			r.ReleaseTicketKey, r.ReleaseTicketAssignee, r.S3Application, r.DueDate, r.Hidden)
 	}
 	return versions, nil
 }
 
-// DeleteJiraIssuesNotIn removes issues for a fixVersion that are not in the given keys slice.
-// Stays hand-written due to variable NOT IN clause.
+// ListHiddenReleaseVersions returns the names of the releases hidden by
+// an admin.
+func (d *DB) ListHiddenReleaseVersions(ctx context.Context) ([]string, error) {
+	return d.queries().ListHiddenReleaseVersions(ctx)
+}
+
+// SetReleaseVersionHidden hides a release from the overview and from
+// syncs, or shows it again. It returns ErrNotFound if the release is
+// unknown.
+func (d *DB) SetReleaseVersionHidden(ctx context.Context, name string, hidden bool) error {
+	n, err := d.queries().SetReleaseVersionHidden(ctx, dbsqlc.SetReleaseVersionHiddenParams{
+		Hidden: boolToInt64(hidden),
+		Name:   name,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ArchiveReleaseIssues freezes the issue set of a released version by
+// copying it into the archive, which is served from then on in place of
+// live JIRA data. Only the first call for a version copies anything; it
+// reports whether it did. It runs in its own transaction.
+func (d *DB) ArchiveReleaseIssues(ctx context.Context, fixVersion string) (bool, error) {
+	var archived bool
+	err := d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		n, err := q.MarkReleaseIssuesArchived(ctx, dbsqlc.MarkReleaseIssuesArchivedParams{
+			IssuesArchivedAt: time.Now().UTC().Format(time.RFC3339),
+			Name:             fixVersion,
+		})
+		if err != nil || n == 0 {
+			return err
+		}
+		archived = true
+		return q.ArchiveReleaseIssues(ctx, fixVersion)
+	})
+	return archived, err
+}
+
+// DeleteJiraIssuesNotIn removes the JIRA issues of a fixVersion that are
+// not in the given keys slice, recording them as scope changes. Bugzilla
+// bugs synced into the version are left alone.
 func (d *DB) DeleteJiraIssuesNotIn(ctx context.Context, fixVersion string, keys []string) error {
-	if len(keys) == 0 {
-		return d.queries().DeleteAllJiraIssuesForVersion(ctx, fixVersion)
+	return d.deleteIssuesNotIn(ctx, fixVersion, "!=", keys)
+}
+
+// DeleteBugzillaBugsNotIn removes the Bugzilla bugs of a fixVersion that
+// are not in the given keys slice, recording them as scope changes. JIRA
+// issues are left alone.
+func (d *DB) DeleteBugzillaBugsNotIn(ctx context.Context, fixVersion string, keys []string) error {
+	return d.deleteIssuesNotIn(ctx, fixVersion, "=", keys)
+}
+
+// deleteIssuesNotIn removes the issues of a fixVersion whose project
+// compares to model.BugzillaProject by op and whose keys are not in keys.
+// Stays hand-written due to variable NOT IN clause.
+func (d *DB) deleteIssuesNotIn(ctx context.Context, fixVersion, op string, keys []string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	where := func(t string) string {
+		w := t + "fix_version = ? AND " + t + "project " + op + " ?"
+		if len(keys) > 0 {
+			w += " AND " + t + "key NOT IN (" + strings.Repeat("?,", len(keys)-1) + "?)"
+		}
+		return w
 	}
-	placeholders := make([]string, len(keys))
-	args := make([]interface{}, 0, len(keys)+1)
-	args = append(args, fixVersion)
-	for i, k := range keys {
-		placeholders[i] = "?"
+	args := make([]interface{}, 0, len(keys)+2)
+	args = append(args, fixVersion, model.BugzillaProject)
+	for _, k := range keys {
 		args = append(args, k)
 	}
-	query := `DELETE FROM jira_issues WHERE fix_version = ? AND key NOT IN (` + strings.Join(placeholders, ",") + `)`
+	record := `INSERT INTO issue_scope_changes (fix_version, issue_key, summary, change, changed_at)
+		SELECT ji.fix_version, ji.key, ji.summary, 'removed', ?
+		FROM jira_issues ji
+		JOIN jira_sync_states s ON s.fix_version = ji.fix_version
+		WHERE ` + where("ji.")
+	if _, err := d.dbtx.ExecContext(ctx, record, append([]interface{}{now}, args...)...); err != nil {
+		return err
+	}
+	query := `DELETE FROM jira_issues WHERE ` + where("")
 	_, err := d.dbtx.ExecContext(ctx, query, args...)
 	return err
 }
 
-func toReleaseVersion(name, description, relDate string, released, archived int64, ticketKey, ticketAssignee, s3App, dueDate string) *model.ReleaseVersion {
+func toReleaseVersion(name, description, relDate string, released, archived int64, ticketKey, ticketAssignee, s3App, dueDate string, hidden int64) *model.ReleaseVersion {
 	return &model.ReleaseVersion{
 		Name:                  name,
 		Description:           description,
@@ -211,5 +599,6 @@ func toReleaseVersion(name, description, relDate string, released, archived int6
 		ReleaseTicketAssignee: ticketAssignee,
 		S3Application:         s3App,
 		DueDate:               parseOptionalTime(dueDate),
+		Hidden:                hidden == 1,
 	}
 }
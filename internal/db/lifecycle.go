@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// TransitionSnapshot moves the snapshot with snapshotID from t.From to t.To
+// and records t, setting its time if unset. It returns ErrConflict if the
+// snapshot is no longer in t.From, e.g. because another transition won a
+// race, and ErrNotFound if there is no such snapshot.
+//
+// The snapshot's candidate states follow: rejecting it demotes it for
+// every release of its application, including one that had it promoted,
+// and proposing a rejected snapshot again lifts those demotions.
+func (d *DB) TransitionSnapshot(ctx context.Context, snapshotID int64, t *model.LifecycleTransition) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		return tx.transitionSnapshot(ctx, snapshotID, t)
+	})
+}
+
+// transitionSnapshot is TransitionSnapshot for a DB already in a
+// transaction.
+func (d *DB) transitionSnapshot(ctx context.Context, snapshotID int64, t *model.LifecycleTransition) error {
+	if t.ChangedAt.IsZero() {
+		t.ChangedAt = time.Now().UTC().Truncate(time.Second)
+	}
+	changedAt := t.ChangedAt.UTC().Format(time.RFC3339)
+	q := d.queries()
+	n, err := q.SetSnapshotLifecycle(ctx, dbsqlc.SetSnapshotLifecycleParams{
+		Lifecycle:   t.To,
+		ID:          snapshotID,
+		Lifecycle_2: t.From,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, err := q.GetSnapshotByID(ctx, snapshotID); err != nil {
+			return classify(err)
+		}
+		return ErrConflict
+	}
+	if err := q.CreateLifecycleTransition(ctx, dbsqlc.CreateLifecycleTransitionParams{
+		SnapshotID: snapshotID,
+		FromState:  t.From,
+		ToState:    t.To,
+		Reason:     t.Reason,
+		ChangedBy:  t.ChangedBy,
+		ChangedAt:  changedAt,
+	}); err != nil {
+		return err
+	}
+	switch {
+	case t.To == model.LifecycleRejected:
+		return q.DemoteSnapshot(ctx, dbsqlc.DemoteSnapshotParams{ChangedAt: changedAt, ID: snapshotID})
+	case t.From == model.LifecycleRejected:
+		return q.ClearDemotedCandidate(ctx, snapshotID)
+	}
+	return nil
+}
+
+// ListLifecycleTransitions returns the lifecycle changes of the snapshot
+// with snapshotID, oldest first.
+func (d *DB) ListLifecycleTransitions(ctx context.Context, snapshotID int64) ([]model.LifecycleTransition, error) {
+	rows, err := d.queries().ListLifecycleTransitions(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	var transitions []model.LifecycleTransition
+	for _, r := range rows {
+		transitions = append(transitions, model.LifecycleTransition{
+			From:      r.FromState,
+			To:        r.ToState,
+			Reason:    r.Reason,
+			ChangedBy: r.ChangedBy,
+			ChangedAt: parseTime(r.ChangedAt),
+		})
+	}
+	return transitions, nil
+}
@@ -1,16 +1,198 @@
 package db
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
 )
 
 //go:embed schema.sql
 var schemaSQL string
 
+//go:embed schema_postgres.sql
+var postgresSchemaSQL string
+
+//go:embed views.sql
+var viewsSQL string
+
+//go:embed search.sql
+var searchSQL string
+
+// columnMigrations adds columns introduced after a table was first created.
+// schema.sql and schema_postgres.sql always carry the full table definitions
+// for fresh databases; these entries bring databases created by older
+// releases up to date. Definitions must be valid on both engines.
+var columnMigrations = []struct {
+	table, column, definition string
+}{
+	{"test_suites", "truncated", "INTEGER NOT NULL DEFAULT 0"},
+	{"jira_issues", "severity", "TEXT NOT NULL DEFAULT ''"},
+	{"jira_issues", "clones", "TEXT NOT NULL DEFAULT ''"},
+	{"release_versions", "issues_archived_at", "TEXT NOT NULL DEFAULT ''"},
+	{"jira_issues", "project", "TEXT NOT NULL DEFAULT ''"},
+	{"release_issue_archive", "project", "TEXT NOT NULL DEFAULT ''"},
+	{"jira_issues", "cve_id", "TEXT NOT NULL DEFAULT ''"},
+	{"jira_issues", "cvss_score", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+	{"jira_issues", "embargoed", "INTEGER NOT NULL DEFAULT 0"},
+	{"release_issue_archive", "cve_id", "TEXT NOT NULL DEFAULT ''"},
+	{"release_issue_archive", "cvss_score", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+	{"release_issue_archive", "embargoed", "INTEGER NOT NULL DEFAULT 0"},
+	{"jira_issues", "blocker", "INTEGER NOT NULL DEFAULT 0"},
+	{"release_issue_archive", "blocker", "INTEGER NOT NULL DEFAULT 0"},
+	{"jira_issues", "components", "TEXT NOT NULL DEFAULT ''"},
+	{"release_issue_archive", "components", "TEXT NOT NULL DEFAULT ''"},
+	{"components", "jira_components", "TEXT NOT NULL DEFAULT ''"},
+	{"image_verifications", "tag", "TEXT NOT NULL DEFAULT ''"},
+	{"git_ranges", "total_commits", "INTEGER NOT NULL DEFAULT -1"},
+	{"snapshots", "source", "TEXT NOT NULL DEFAULT ''"},
+	{"release_versions", "hidden", "INTEGER NOT NULL DEFAULT 0"},
+	{"snapshots", "lifecycle", "TEXT NOT NULL DEFAULT 'new'"},
+}
+
 func (d *DB) migrate() error {
-	if _, err := d.conn.Exec(schemaSQL); err != nil {
+	schema := schemaSQL
+	if d.driver == Postgres {
+		schema = postgresSchemaSQL
+	}
+	if _, err := d.conn.Exec(schema); err != nil {
 		return fmt.Errorf("exec schema: %w", err)
 	}
+	for _, m := range columnMigrations {
+		if err := d.addColumnIfMissing(m.table, m.column, m.definition); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", m.table, m.column, err)
+		}
+	}
+	if err := d.backfillIssueProjects(); err != nil {
+		return fmt.Errorf("backfill issue projects: %w", err)
+	}
+	if err := d.backfillBlockers(); err != nil {
+		return fmt.Errorf("backfill blockers: %w", err)
+	}
+	if _, err := d.conn.Exec(viewsSQL); err != nil {
+		return fmt.Errorf("exec views: %w", err)
+	}
+	if d.driver == SQLite {
+		if err := d.createSearchIndexes(); err != nil {
+			return fmt.Errorf("create search indexes: %w", err)
+		}
+	}
+	return nil
+}
+
+// searchIndexes are the full-text indexes of search.sql, each with the
+// statement filling it from the rows stored before it existed.
+var searchIndexes = []struct {
+	table, fill string
+}{
+	{"jira_issues_fts", "INSERT INTO jira_issues_fts (jira_issues_fts) VALUES ('rebuild')"},
+	{"snapshots_fts", "INSERT INTO snapshots_fts (snapshots_fts) VALUES ('rebuild')"},
+	{"components_fts", "INSERT INTO components_fts (components_fts) VALUES ('rebuild')"},
+	{"test_failures_fts", "INSERT INTO test_failures_fts (rowid, name, message) SELECT id, name, message FROM test_cases WHERE status = 'failed'"},
+}
+
+// createSearchIndexes creates the full-text indexes and their triggers,
+// filling the indexes that did not exist yet.
+func (d *DB) createSearchIndexes() error {
+	var fills []string
+	for _, idx := range searchIndexes {
+		var count int
+		if err := d.conn.QueryRow(
+			`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, idx.table,
+		).Scan(&count); err != nil {
+			return err
+		}
+		if count == 0 {
+			fills = append(fills, idx.fill)
+		}
+	}
+	if _, err := d.conn.Exec(searchSQL); err != nil {
+		return err
+	}
+	for _, fill := range fills {
+		if _, err := d.conn.Exec(fill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing runs ALTER TABLE ADD COLUMN unless the column already exists.
+func (d *DB) addColumnIfMissing(table, column, definition string) error {
+	if d.driver == Postgres {
+		_, err := d.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", table, column, definition))
+		return err
+	}
+	var count int
+	if err := d.conn.QueryRow(
+		`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column,
+	).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := d.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// backfillIssueProjects tags issues stored before the project column existed
+// with the project of their key. Issues synced since carry it already, so
+// this only does work once.
+func (d *DB) backfillIssueProjects() error {
+	ctx := context.Background()
+	for _, table := range []string{"jira_issues", "release_issue_archive"} {
+		rows, err := d.dbtx.QueryContext(ctx, "SELECT DISTINCT key FROM "+table+" WHERE project = ''")
+		if err != nil {
+			return err
+		}
+		projects := make(map[string]bool)
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			if p := model.IssueProject(key); p != "" {
+				projects[p] = true
+			}
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return err
+		}
+		for p := range projects {
+			if _, err := d.dbtx.ExecContext(ctx,
+				"UPDATE "+table+" SET project = ? WHERE project = '' AND key LIKE ?", p, p+"-%",
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// backfillBlockers flags issues stored before the blocker column existed
+// whose labels include one of model.BlockerLabels. Labels are stored
+// comma-separated, so each is matched between commas. Issues synced since
+// carry the flag already, so this only does work once.
+func (d *DB) backfillBlockers() error {
+	ctx := context.Background()
+	conds := make([]string, len(model.BlockerLabels))
+	args := make([]interface{}, len(model.BlockerLabels))
+	for i, l := range model.BlockerLabels {
+		conds[i] = "',' || LOWER(labels) || ',' LIKE ?"
+		args[i] = "%," + strings.ToLower(l) + ",%"
+	}
+	for _, table := range []string{"jira_issues", "release_issue_archive"} {
+		if _, err := d.dbtx.ExecContext(ctx,
+			"UPDATE "+table+" SET blocker = 1 WHERE blocker = 0 AND ("+strings.Join(conds, " OR ")+")", args...,
+		); err != nil {
+			return err
+		}
+	}
 	return nil
 }
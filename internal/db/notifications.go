@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListNotificationStates returns the last notified state of each release,
+// keyed by release name.
+func (d *DB) ListNotificationStates(ctx context.Context) (map[string]model.NotificationState, error) {
+	rows, err := d.queries().ListNotificationStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]model.NotificationState, len(rows))
+	for _, r := range rows {
+		states[r.Release] = model.NotificationState{
+			Release:        r.Release,
+			Signal:         r.Signal,
+			BlockingCVEs:   int(r.BlockingCves),
+			FailedSnapshot: r.FailedSnapshot,
+		}
+	}
+	return states, nil
+}
+
+// SaveNotificationState records the notified state of a release.
+func (d *DB) SaveNotificationState(ctx context.Context, state model.NotificationState) error {
+	return d.queries().UpsertNotificationState(ctx, dbsqlc.UpsertNotificationStateParams{
+		Release:        state.Release,
+		Signal:         state.Signal,
+		BlockingCves:   int64(state.BlockingCVEs),
+		FailedSnapshot: state.FailedSnapshot,
+	})
+}
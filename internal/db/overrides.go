@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// CreateReadinessOverride records an override, setting its ID and, if
+// unset, its creation time.
+func (d *DB) CreateReadinessOverride(ctx context.Context, o *model.ReadinessOverride) error {
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = time.Now().UTC()
+	}
+	id, err := d.queries().CreateReadinessOverride(ctx, dbsqlc.CreateReadinessOverrideParams{
+		Release:   o.Release,
+		Rule:      o.Rule,
+		Reason:    o.Reason,
+		CreatedBy: o.CreatedBy,
+		CreatedAt: o.CreatedAt.UTC().Format(time.RFC3339),
+		ExpiresAt: o.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	o.ID = id
+	return nil
+}
+
+// ListReadinessOverrides returns the overrides of release that have not
+// expired, oldest first.
+func (d *DB) ListReadinessOverrides(ctx context.Context, release string) ([]model.ReadinessOverride, error) {
+	rows, err := d.queries().ListReadinessOverrides(ctx, dbsqlc.ListReadinessOverridesParams{
+		Release:   release,
+		ExpiresAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+	overrides := make([]model.ReadinessOverride, len(rows))
+	for i, r := range rows {
+		overrides[i] = toReadinessOverride(r)
+	}
+	return overrides, nil
+}
+
+// ListActiveReadinessOverrides returns the overrides that have not expired,
+// keyed by release name.
+func (d *DB) ListActiveReadinessOverrides(ctx context.Context) (map[string][]model.ReadinessOverride, error) {
+	rows, err := d.queries().ListActiveReadinessOverrides(ctx, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string][]model.ReadinessOverride)
+	for _, r := range rows {
+		overrides[r.Release] = append(overrides[r.Release], toReadinessOverride(r))
+	}
+	return overrides, nil
+}
+
+// DeleteReadinessOverride revokes an override of release. It returns
+// ErrNotFound if release has no override with that ID.
+func (d *DB) DeleteReadinessOverride(ctx context.Context, release string, id int64) error {
+	n, err := d.queries().DeleteReadinessOverride(ctx, dbsqlc.DeleteReadinessOverrideParams{
+		ID:      id,
+		Release: release,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("override %d of %s: %w", id, release, ErrNotFound)
+	}
+	return nil
+}
+
+func toReadinessOverride(r dbsqlc.ReadinessOverride) model.ReadinessOverride {
+	return model.ReadinessOverride{
+		ID:        r.ID,
+		Release:   r.Release,
+		Rule:      r.Rule,
+		Reason:    r.Reason,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: parseTime(r.CreatedAt),
+		ExpiresAt: parseTime(r.ExpiresAt),
+	}
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListPendingPipelineRuns returns up to limit test suites, newest first,
+// that name a PipelineRun which has not been resolved yet or was still
+// running when it was last resolved.
+func (d *DB) ListPendingPipelineRuns(ctx context.Context, limit int) ([]model.PipelineRunRef, error) {
+	rows, err := d.queries().ListPendingPipelineRuns(ctx, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]model.PipelineRunRef, len(rows))
+	for i, r := range rows {
+		refs[i] = model.PipelineRunRef{
+			TestSuiteID: r.ID,
+			Snapshot:    r.Snapshot,
+			Suite:       r.Name,
+			PipelineRun: r.PipelineRun,
+		}
+	}
+	return refs, nil
+}
+
+// SavePipelineRun stores the resolved PipelineRun of a test suite,
+// replacing any earlier resolution along with its tasks.
+func (d *DB) SavePipelineRun(ctx context.Context, testSuiteID int64, run *model.PipelineRun) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		if err := q.DeletePipelineRun(ctx, testSuiteID); err != nil {
+			return err
+		}
+		id, err := q.CreatePipelineRun(ctx, dbsqlc.CreatePipelineRunParams{
+			TestSuiteID:    testSuiteID,
+			Namespace:      run.Namespace,
+			Name:           run.Name,
+			Status:         run.Status,
+			Reason:         run.Reason,
+			Message:        run.Message,
+			StartTime:      formatOptionalTime(run.StartTime),
+			CompletionTime: formatOptionalTime(run.CompletionTime),
+			ResolvedAt:     run.ResolvedAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return classify(err)
+		}
+		for _, t := range run.Tasks {
+			if err := q.CreatePipelineRunTask(ctx, dbsqlc.CreatePipelineRunTaskParams{
+				PipelineRunID:  id,
+				Name:           t.Name,
+				Status:         t.Status,
+				Reason:         t.Reason,
+				Message:        t.Message,
+				StartTime:      formatOptionalTime(t.StartTime),
+				CompletionTime: formatOptionalTime(t.CompletionTime),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// listPipelineRuns returns the resolved PipelineRuns of a snapshot's test
+// suites, keyed by test suite ID.
+func (d *DB) listPipelineRuns(ctx context.Context, snapshotID int64) (map[int64]*model.PipelineRun, error) {
+	rows, err := d.queries().ListPipelineRunsBySnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := d.queries().ListPipelineRunTasksBySnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	runs := make(map[int64]*model.PipelineRun, len(rows))
+	byID := make(map[int64]*model.PipelineRun, len(rows))
+	for _, r := range rows {
+		run := &model.PipelineRun{
+			Namespace:      r.Namespace,
+			Name:           r.Name,
+			Status:         r.Status,
+			Reason:         r.Reason,
+			Message:        r.Message,
+			StartTime:      parseOptionalTime(r.StartTime),
+			CompletionTime: parseOptionalTime(r.CompletionTime),
+			Tasks:          []model.PipelineTask{},
+			ResolvedAt:     parseTime(r.ResolvedAt),
+		}
+		run.DurationMs = durationMs(run.StartTime, run.CompletionTime)
+		runs[r.TestSuiteID] = run
+		byID[r.ID] = run
+	}
+	for _, t := range tasks {
+		run := byID[t.PipelineRunID]
+		task := model.PipelineTask{
+			Name:           t.Name,
+			Status:         t.Status,
+			Reason:         t.Reason,
+			Message:        t.Message,
+			StartTime:      parseOptionalTime(t.StartTime),
+			CompletionTime: parseOptionalTime(t.CompletionTime),
+		}
+		task.DurationMs = durationMs(task.StartTime, task.CompletionTime)
+		run.Tasks = append(run.Tasks, task)
+	}
+	return runs, nil
+}
+
+// durationMs returns the time from start to end in milliseconds, or zero
+// unless both are known.
+func durationMs(start, end *time.Time) int64 {
+	if start == nil || end == nil {
+		return 0
+	}
+	return end.Sub(*start).Milliseconds()
+}
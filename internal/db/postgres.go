@@ -0,0 +1,9 @@
+//go:build postgres
+
+package db
+
+import _ "github.com/jackc/pgx/v5/stdlib"
+
+func init() {
+	postgresDriver = "pgx"
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+)
+
+// preparedDBTX prepares each distinct query the first time it runs and
+// reuses the statement after that, so that a transaction inserting many
+// rows with the same query parses it once. close must be called before the
+// transaction ends.
+type preparedDBTX struct {
+	dbsqlc.DBTX
+	stmts map[string]*sql.Stmt
+}
+
+func newPreparedDBTX(inner dbsqlc.DBTX) *preparedDBTX {
+	return &preparedDBTX{DBTX: inner, stmts: make(map[string]*sql.Stmt)}
+}
+
+func (p *preparedDBTX) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	if s, ok := p.stmts[query]; ok {
+		return s, nil
+	}
+	s, err := p.DBTX.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	p.stmts[query] = s
+	return s, nil
+}
+
+func (p *preparedDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	s, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.ExecContext(ctx, args...)
+}
+
+func (p *preparedDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	s, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.QueryContext(ctx, args...)
+}
+
+func (p *preparedDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	s, err := p.stmt(ctx, query)
+	if err != nil {
+		// *sql.Row cannot carry the error; running the query unprepared
+		// reports it from Scan.
+		return p.DBTX.QueryRowContext(ctx, query, args...)
+	}
+	return s.QueryRowContext(ctx, args...)
+}
+
+// close releases the prepared statements.
+func (p *preparedDBTX) close() error {
+	var errs []error
+	for _, s := range p.stmts {
+		errs = append(errs, s.Close())
+	}
+	clear(p.stmts)
+	return errors.Join(errs...)
+}
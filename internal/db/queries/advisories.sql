@@ -0,0 +1,36 @@
+-- name: ListReleaseAdvisories :many
+SELECT release, advisory_id, name, type, state, synopsis, builds, cves, synced_at, sync_error
+FROM release_advisories
+ORDER BY release;
+
+-- name: GetReleaseAdvisory :one
+SELECT release, advisory_id, name, type, state, synopsis, builds, cves, synced_at, sync_error
+FROM release_advisories
+WHERE release = ?;
+
+-- name: UpsertReleaseAdvisory :exec
+INSERT INTO release_advisories (release, advisory_id)
+VALUES (?, ?)
+ON CONFLICT(release) DO UPDATE SET
+    advisory_id=excluded.advisory_id,
+    name='',
+    type='',
+    state='',
+    synopsis='',
+    builds='',
+    cves='',
+    synced_at='',
+    sync_error='';
+
+-- name: UpdateAdvisorySync :exec
+UPDATE release_advisories
+SET name = ?, type = ?, state = ?, synopsis = ?, builds = ?, cves = ?, synced_at = ?, sync_error = ''
+WHERE release = ? AND advisory_id = ?;
+
+-- name: UpdateAdvisorySyncError :exec
+UPDATE release_advisories
+SET sync_error = ?
+WHERE release = ? AND advisory_id = ?;
+
+-- name: DeleteReleaseAdvisory :execrows
+DELETE FROM release_advisories WHERE release = ?;
@@ -0,0 +1,19 @@
+-- name: ListApplicationConfigs :many
+SELECT name, display_name, product, fix_versions, retention_set, retention_max_count, retention_max_age, updated_at
+FROM applications
+ORDER BY name;
+
+-- name: UpsertApplicationConfig :exec
+INSERT INTO applications (name, display_name, product, fix_versions, retention_set, retention_max_count, retention_max_age, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    display_name=excluded.display_name,
+    product=excluded.product,
+    fix_versions=excluded.fix_versions,
+    retention_set=excluded.retention_set,
+    retention_max_count=excluded.retention_max_count,
+    retention_max_age=excluded.retention_max_age,
+    updated_at=excluded.updated_at;
+
+-- name: SetReleaseS3Application :exec
+UPDATE release_versions SET s3_application = ? WHERE name = ?;
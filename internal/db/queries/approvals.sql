@@ -0,0 +1,13 @@
+-- name: CreateReleaseApproval :one
+INSERT INTO release_approvals (release, approver, role, comment, created_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: ListReleaseApprovals :many
+SELECT id, release, approver, role, comment, created_at
+FROM release_approvals
+WHERE release = ?
+ORDER BY id;
+
+-- name: ListApprovedRoles :many
+SELECT DISTINCT release, role FROM release_approvals ORDER BY release, role;
@@ -0,0 +1,21 @@
+-- name: DeleteReleaseAudit :exec
+DELETE FROM release_audits WHERE release = ?;
+
+-- name: CreateReleaseAudit :one
+INSERT INTO release_audits (release, snapshot_name, audited_at)
+VALUES (?, ?, ?)
+RETURNING id;
+
+-- name: CreateReleaseAuditFinding :exec
+INSERT INTO release_audit_findings (audit_id, component, kind, expected, actual, message)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetReleaseAudit :one
+SELECT id, release, snapshot_name, audited_at
+FROM release_audits WHERE release = ?;
+
+-- name: ListReleaseAuditFindings :many
+SELECT component, kind, expected, actual, message
+FROM release_audit_findings
+WHERE audit_id = ?
+ORDER BY component, id;
@@ -0,0 +1,18 @@
+-- name: DeleteJiraIssueBlockers :exec
+DELETE FROM jira_issue_blockers WHERE issue_key = ?;
+
+-- name: CreateJiraIssueBlocker :exec
+INSERT INTO jira_issue_blockers (issue_key, blocker_key, blocker_summary, blocker_status, blocker_link)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(issue_key, blocker_key) DO NOTHING;
+
+-- name: ListReleaseIssueBlockers :many
+-- A blocker synced under any fixVersion reports its synced status.
+SELECT b.issue_key, b.blocker_key, b.blocker_summary,
+    CAST(COALESCE(
+        (SELECT ji.status FROM jira_issues ji WHERE ji.key = b.blocker_key ORDER BY ji.updated_at DESC LIMIT 1),
+        b.blocker_status) AS TEXT) AS blocker_status,
+    b.blocker_link
+FROM jira_issue_blockers b
+WHERE b.issue_key IN (SELECT key FROM release_issues WHERE fix_version = ?)
+ORDER BY b.issue_key, b.blocker_key;
@@ -0,0 +1,8 @@
+-- name: DeleteIssueBuckets :exec
+DELETE FROM issue_buckets;
+
+-- name: CreateIssueBucket :exec
+INSERT INTO issue_buckets (name, labels) VALUES (?, ?);
+
+-- name: ListIssueBuckets :many
+SELECT id, name, labels FROM issue_buckets ORDER BY id;
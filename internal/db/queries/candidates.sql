@@ -0,0 +1,80 @@
+-- name: ListReleaseCandidates :many
+SELECT s.id, s.application, s.name, s.tests_passed, s.created_at,
+       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count,
+       CAST(COALESCE(c.state, '') AS TEXT) AS state,
+       CAST(COALESCE(c.changed_at, '') AS TEXT) AS changed_at
+FROM snapshots s
+LEFT JOIN release_candidates c ON c.snapshot_id = s.id AND c.release = ?
+WHERE s.application = ?
+ORDER BY s.id DESC
+LIMIT ?;
+
+-- name: GetSelectedCandidate :one
+SELECT s.id, s.application, s.name, s.tests_passed, s.created_at,
+       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count,
+       (SELECT COUNT(*) FROM snapshot_components WHERE snapshot_id = s.id) AS component_count,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'ok') AS images_verified,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status IN ('missing', 'mismatch')) AS images_failed,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'missing') AS images_missing,
+       (SELECT COUNT(*) FROM snapshot_releases WHERE snapshot_id = s.id AND status = 'succeeded') AS releases_succeeded,
+       (SELECT COUNT(*) FROM snapshot_releases sr WHERE sr.snapshot_id = s.id AND sr.status = 'failed'
+           AND NOT EXISTS (SELECT 1 FROM snapshot_releases ok WHERE ok.snapshot_id = s.id AND ok.release_plan = sr.release_plan AND ok.status = 'succeeded')) AS releases_failed,
+       (SELECT COUNT(*) FROM snapshot_releases WHERE snapshot_id = s.id AND status = 'progressing') AS releases_progressing,
+       (SELECT COUNT(*) FROM ec_results WHERE snapshot_id = s.id) AS ec_components,
+       (SELECT COUNT(*) FROM ec_results WHERE snapshot_id = s.id AND success = 0) AS ec_failed
+FROM release_versions r
+JOIN snapshots s ON s.application = r.s3_application
+LEFT JOIN release_candidates c ON c.snapshot_id = s.id AND c.release = r.name
+WHERE r.name = ? AND COALESCE(c.state, '') != 'demoted'
+ORDER BY COALESCE(c.state, '') = 'promoted' DESC, s.id DESC
+LIMIT 1;
+
+-- name: ListSelectedCandidates :many
+SELECT r.name AS release, s.id, s.application, s.name, s.tests_passed, s.created_at,
+       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count,
+       (SELECT COUNT(*) FROM snapshot_components WHERE snapshot_id = s.id) AS component_count,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'ok') AS images_verified,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status IN ('missing', 'mismatch')) AS images_failed,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'missing') AS images_missing,
+       (SELECT COUNT(*) FROM snapshot_releases WHERE snapshot_id = s.id AND status = 'succeeded') AS releases_succeeded,
+       (SELECT COUNT(*) FROM snapshot_releases sr WHERE sr.snapshot_id = s.id AND sr.status = 'failed'
+           AND NOT EXISTS (SELECT 1 FROM snapshot_releases ok WHERE ok.snapshot_id = s.id AND ok.release_plan = sr.release_plan AND ok.status = 'succeeded')) AS releases_failed,
+       (SELECT COUNT(*) FROM snapshot_releases WHERE snapshot_id = s.id AND status = 'progressing') AS releases_progressing,
+       (SELECT COUNT(*) FROM ec_results WHERE snapshot_id = s.id) AS ec_components,
+       (SELECT COUNT(*) FROM ec_results WHERE snapshot_id = s.id AND success = 0) AS ec_failed
+FROM release_versions r
+JOIN snapshots s ON s.id = (
+    SELECT s2.id
+    FROM snapshots s2
+    LEFT JOIN release_candidates c ON c.snapshot_id = s2.id AND c.release = r.name
+    WHERE s2.application = r.s3_application AND COALESCE(c.state, '') != 'demoted'
+    ORDER BY COALESCE(c.state, '') = 'promoted' DESC, s2.id DESC
+    LIMIT 1
+)
+ORDER BY r.name;
+
+-- name: ClearPromotedCandidate :exec
+DELETE FROM release_candidates WHERE release = ? AND state = 'promoted';
+
+-- name: DeleteReleaseCandidate :exec
+DELETE FROM release_candidates WHERE release = ? AND snapshot_id = ?;
+
+-- name: UpsertReleaseCandidate :exec
+INSERT INTO release_candidates (release, snapshot_id, state, changed_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(release, snapshot_id) DO UPDATE SET
+    state=excluded.state,
+    changed_at=excluded.changed_at;
+
+-- name: DemoteSnapshot :exec
+INSERT INTO release_candidates (release, snapshot_id, state, changed_at)
+SELECT r.name, s.id, 'demoted', ?
+FROM snapshots s
+JOIN release_versions r ON r.s3_application = s.application
+WHERE s.id = ?
+ON CONFLICT(release, snapshot_id) DO UPDATE SET
+    state=excluded.state,
+    changed_at=excluded.changed_at;
+
+-- name: ClearDemotedCandidate :exec
+DELETE FROM release_candidates WHERE snapshot_id = ? AND state = 'demoted';
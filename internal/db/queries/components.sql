@@ -1,8 +1,12 @@
 -- name: ListComponents :many
-SELECT id, name, description, created_at FROM components ORDER BY name;
+SELECT id, name, description, created_at, jira_components FROM components ORDER BY name;
 
--- name: CreateComponent :execlastid
-INSERT INTO components (name, description) VALUES (?, ?);
+-- name: CreateComponent :one
+INSERT INTO components (name, description) VALUES (?, ?)
+RETURNING id;
 
 -- name: GetComponentByName :one
-SELECT id, name, description, created_at FROM components WHERE name = ?;
+SELECT id, name, description, created_at, jira_components FROM components WHERE name = ?;
+
+-- name: SetComponentJiraComponents :execrows
+UPDATE components SET jira_components = ? WHERE name = ?;
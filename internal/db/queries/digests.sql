@@ -0,0 +1,25 @@
+-- name: CreateDigestSubscription :one
+INSERT INTO digest_subscriptions (email, release, frequency, created_by, created_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: ListDigestSubscriptions :many
+SELECT id, email, release, frequency, created_by, created_at
+FROM digest_subscriptions
+ORDER BY email, id;
+
+-- name: DeleteDigestSubscription :execrows
+DELETE FROM digest_subscriptions WHERE id = ?;
+
+-- name: ListDigestStates :many
+SELECT frequency, release, sent_at, blockers, failed_scenarios
+FROM digest_states
+WHERE frequency = ?;
+
+-- name: UpsertDigestState :exec
+INSERT INTO digest_states (frequency, release, sent_at, blockers, failed_scenarios)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(frequency, release) DO UPDATE SET
+    sent_at=excluded.sent_at,
+    blockers=excluded.blockers,
+    failed_scenarios=excluded.failed_scenarios;
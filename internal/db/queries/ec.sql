@@ -0,0 +1,21 @@
+-- name: CreateECResult :one
+INSERT INTO ec_results (snapshot_id, component, image_url, success)
+VALUES (?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreateECFinding :exec
+INSERT INTO ec_findings (result_id, kind, code, title, message, solution)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListECResultsBySnapshot :many
+SELECT id, component, image_url, success
+FROM ec_results
+WHERE snapshot_id = ?
+ORDER BY component;
+
+-- name: ListECFindingsBySnapshot :many
+SELECT f.result_id, f.kind, f.code, f.title, f.message, f.solution
+FROM ec_findings f
+JOIN ec_results r ON r.id = f.result_id
+WHERE r.snapshot_id = ?
+ORDER BY f.id;
@@ -0,0 +1,38 @@
+-- name: GetGitRange :one
+SELECT total_commits FROM git_ranges
+WHERE repo = ? AND from_sha = ? AND to_sha = ?;
+
+-- name: UpsertGitRange :exec
+INSERT INTO git_ranges (repo, from_sha, to_sha, fetched_at, total_commits)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(repo, from_sha, to_sha) DO UPDATE SET
+    fetched_at=excluded.fetched_at,
+    total_commits=excluded.total_commits;
+
+-- name: ListGitRangeCommits :many
+SELECT sha, subject, author, url
+FROM git_range_commits
+WHERE repo = ? AND from_sha = ? AND to_sha = ?
+ORDER BY position;
+
+-- name: DeleteGitRangeCommits :exec
+DELETE FROM git_range_commits
+WHERE repo = ? AND from_sha = ? AND to_sha = ?;
+
+-- name: CreateGitRangeCommit :exec
+INSERT INTO git_range_commits (repo, from_sha, to_sha, position, sha, subject, author, url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListGitRangePullRequests :many
+SELECT number, title, author, url, merged_at
+FROM git_range_pull_requests
+WHERE repo = ? AND from_sha = ? AND to_sha = ?
+ORDER BY merged_at, number;
+
+-- name: DeleteGitRangePullRequests :exec
+DELETE FROM git_range_pull_requests
+WHERE repo = ? AND from_sha = ? AND to_sha = ?;
+
+-- name: CreateGitRangePullRequest :exec
+INSERT INTO git_range_pull_requests (repo, from_sha, to_sha, number, title, author, url, merged_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,14 @@
+-- name: CreateReadinessHistory :exec
+INSERT INTO release_readiness_history (release, signal, total, open, verified, cves, bugs, blocking_cves, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListReadinessHistory :many
+SELECT id, release, signal, total, open, verified, cves, bugs, blocking_cves, recorded_at
+FROM release_readiness_history
+WHERE release = ?
+ORDER BY id;
+
+-- name: ListLatestReadinessHistory :many
+SELECT id, release, signal, total, open, verified, cves, bugs, blocking_cves, recorded_at
+FROM release_readiness_history h
+WHERE h.id = (SELECT MAX(id) FROM release_readiness_history WHERE release = h.release);
@@ -0,0 +1,12 @@
+-- name: DeleteImageVerifications :exec
+DELETE FROM image_verifications WHERE snapshot_id = ?;
+
+-- name: CreateImageVerification :exec
+INSERT INTO image_verifications (snapshot_id, component, image_url, tag, status, message, checked_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListImageVerifications :many
+SELECT component, image_url, tag, status, message, checked_at
+FROM image_verifications
+WHERE snapshot_id = ?
+ORDER BY component;
@@ -0,0 +1,24 @@
+-- name: RecordIngestFailure :one
+INSERT INTO ingest_failures (source, key, application, snapshot, attempts, last_error, dead, first_failed_at, last_failed_at)
+VALUES (?, ?, ?, ?, 1, ?, ?, ?, ?)
+ON CONFLICT(source, key) DO UPDATE SET
+    application=excluded.application,
+    snapshot=excluded.snapshot,
+    attempts=ingest_failures.attempts + 1,
+    last_error=excluded.last_error,
+    dead=CASE WHEN ingest_failures.attempts + 1 >= sqlc.arg(max_attempts) THEN 1 ELSE 0 END,
+    last_failed_at=excluded.last_failed_at
+RETURNING id, source, key, application, snapshot, attempts, last_error, dead, first_failed_at, last_failed_at;
+
+-- name: ListIngestFailures :many
+SELECT id, source, key, application, snapshot, attempts, last_error, dead, first_failed_at, last_failed_at
+FROM ingest_failures
+ORDER BY last_failed_at DESC, id DESC;
+
+-- name: GetIngestFailure :one
+SELECT id, source, key, application, snapshot, attempts, last_error, dead, first_failed_at, last_failed_at
+FROM ingest_failures
+WHERE id = ?;
+
+-- name: DeleteIngestFailure :exec
+DELETE FROM ingest_failures WHERE source = ? AND key = ?;
@@ -1,6 +1,6 @@
 -- name: UpsertJiraIssue :exec
-INSERT INTO jira_issues (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO jira_issues (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(key, fix_version) DO UPDATE SET
     summary=excluded.summary,
     status=excluded.status,
@@ -11,7 +11,15 @@ ON CONFLICT(key, fix_version) DO UPDATE SET
     resolution=excluded.resolution,
     link=excluded.link,
     qa_contact=excluded.qa_contact,
-    updated_at=excluded.updated_at;
+    updated_at=excluded.updated_at,
+    severity=excluded.severity,
+    clones=excluded.clones,
+    project=excluded.project,
+    cve_id=excluded.cve_id,
+    cvss_score=excluded.cvss_score,
+    embargoed=excluded.embargoed,
+    blocker=excluded.blocker,
+    components=excluded.components;
 
 -- name: GetIssueSummary :one
 SELECT
@@ -19,10 +27,19 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS verified,
     CAST(COALESCE(SUM(CASE WHEN LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS open,
     CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%' THEN 1 ELSE 0 END), 0) AS INTEGER) AS cves,
-    CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END), 0) AS INTEGER) AS bugs
-FROM jira_issues
+    CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END), 0) AS INTEGER) AS bugs,
+    CAST(COALESCE(SUM(CASE WHEN blocker = 1 AND LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS open_blockers
+FROM release_issues
 WHERE fix_version = ?;
 
+-- name: CountOpenCVEsBySeverity :many
+SELECT severity, CAST(COUNT(*) AS INTEGER) AS cnt
+FROM release_issues
+WHERE fix_version = ?
+  AND LOWER(status) NOT IN ('closed', 'verified', 'done')
+  AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+GROUP BY severity;
+
 -- name: UpsertReleaseVersion :exec
 INSERT INTO release_versions (name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
@@ -37,19 +54,62 @@ ON CONFLICT(name) DO UPDATE SET
     due_date=excluded.due_date;
 
 -- name: GetReleaseVersion :one
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions WHERE name = ?;
 
 -- name: ListActiveReleaseVersions :many
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions
-WHERE released = 0 AND archived = 0
+WHERE released = 0 AND archived = 0 AND hidden = 0
 ORDER BY name;
 
 -- name: ListAllReleaseVersions :many
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions
 ORDER BY name;
 
--- name: DeleteAllJiraIssuesForVersion :exec
-DELETE FROM jira_issues WHERE fix_version = ?;
+-- name: ListHiddenReleaseVersions :many
+SELECT name FROM release_versions WHERE hidden = 1 ORDER BY name;
+
+-- name: SetReleaseVersionHidden :execrows
+UPDATE release_versions SET hidden = ? WHERE name = ?;
+
+-- name: DeleteJiraIssue :exec
+DELETE FROM jira_issues WHERE key = ? AND fix_version = ?;
+
+-- name: ListJiraIssueVersions :many
+SELECT fix_version FROM jira_issues WHERE key = ? ORDER BY fix_version;
+
+-- name: MarkReleaseIssuesArchived :execrows
+UPDATE release_versions SET issues_archived_at = ? WHERE name = ? AND issues_archived_at = '';
+
+-- name: ArchiveReleaseIssues :exec
+INSERT INTO release_issue_archive (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components)
+SELECT key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM jira_issues WHERE fix_version = ?;
+
+-- name: ListReleaseCVEs :many
+SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM release_issues
+WHERE fix_version = ?
+  AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+ORDER BY
+    CASE severity
+        WHEN 'Critical' THEN 0
+        WHEN 'Important' THEN 1
+        WHEN 'Moderate' THEN 2
+        WHEN 'Low' THEN 3
+        ELSE 4
+    END,
+    cvss_score DESC,
+    key;
+
+-- name: ListJiraSyncStates :many
+SELECT fix_version, synced_at, reconciled_at FROM jira_sync_states;
+
+-- name: UpsertJiraSyncState :exec
+INSERT INTO jira_sync_states (fix_version, synced_at, reconciled_at)
+VALUES (?, ?, ?)
+ON CONFLICT(fix_version) DO UPDATE SET
+    synced_at=excluded.synced_at,
+    reconciled_at=excluded.reconciled_at;
@@ -0,0 +1,12 @@
+-- name: CreateLifecycleTransition :exec
+INSERT INTO snapshot_lifecycle_transitions (snapshot_id, from_state, to_state, reason, changed_by, changed_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListLifecycleTransitions :many
+SELECT id, snapshot_id, from_state, to_state, reason, changed_by, changed_at
+FROM snapshot_lifecycle_transitions
+WHERE snapshot_id = ?
+ORDER BY id;
+
+-- name: SetSnapshotLifecycle :execrows
+UPDATE snapshots SET lifecycle = ? WHERE id = ? AND lifecycle = ?;
@@ -0,0 +1,10 @@
+-- name: ListNotificationStates :many
+SELECT release, signal, blocking_cves, failed_snapshot FROM notification_states;
+
+-- name: UpsertNotificationState :exec
+INSERT INTO notification_states (release, signal, blocking_cves, failed_snapshot)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(release) DO UPDATE SET
+    signal=excluded.signal,
+    blocking_cves=excluded.blocking_cves,
+    failed_snapshot=excluded.failed_snapshot;
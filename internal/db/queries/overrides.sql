@@ -0,0 +1,19 @@
+-- name: CreateReadinessOverride :one
+INSERT INTO readiness_overrides (release, rule, reason, created_by, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: ListReadinessOverrides :many
+SELECT id, release, rule, reason, created_by, created_at, expires_at
+FROM readiness_overrides
+WHERE release = ? AND expires_at > ?
+ORDER BY id;
+
+-- name: ListActiveReadinessOverrides :many
+SELECT id, release, rule, reason, created_by, created_at, expires_at
+FROM readiness_overrides
+WHERE expires_at > ?
+ORDER BY release, id;
+
+-- name: DeleteReadinessOverride :execrows
+DELETE FROM readiness_overrides WHERE id = ? AND release = ?;
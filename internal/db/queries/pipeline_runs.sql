@@ -0,0 +1,34 @@
+-- name: DeletePipelineRun :exec
+DELETE FROM pipeline_runs WHERE test_suite_id = ?;
+
+-- name: CreatePipelineRun :one
+INSERT INTO pipeline_runs (test_suite_id, namespace, name, status, reason, message, start_time, completion_time, resolved_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreatePipelineRunTask :exec
+INSERT INTO pipeline_run_tasks (pipeline_run_id, name, status, reason, message, start_time, completion_time)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListPendingPipelineRuns :many
+SELECT ts.id, s.name AS snapshot, ts.name, ts.pipeline_run
+FROM test_suites ts
+JOIN snapshots s ON s.id = ts.snapshot_id
+LEFT JOIN pipeline_runs pr ON pr.test_suite_id = ts.id
+WHERE ts.pipeline_run != '' AND (pr.id IS NULL OR pr.status = 'running')
+ORDER BY ts.id DESC
+LIMIT ?;
+
+-- name: ListPipelineRunsBySnapshot :many
+SELECT pr.id, pr.test_suite_id, pr.namespace, pr.name, pr.status, pr.reason, pr.message, pr.start_time, pr.completion_time, pr.resolved_at
+FROM pipeline_runs pr
+JOIN test_suites ts ON ts.id = pr.test_suite_id
+WHERE ts.snapshot_id = ?;
+
+-- name: ListPipelineRunTasksBySnapshot :many
+SELECT t.pipeline_run_id, t.name, t.status, t.reason, t.message, t.start_time, t.completion_time
+FROM pipeline_run_tasks t
+JOIN pipeline_runs pr ON pr.id = t.pipeline_run_id
+JOIN test_suites ts ON ts.id = pr.test_suite_id
+WHERE ts.snapshot_id = ?
+ORDER BY t.start_time, t.id;
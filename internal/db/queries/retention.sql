@@ -0,0 +1,31 @@
+-- name: ListRetentionSnapshots :many
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
+FROM snapshots
+ORDER BY application, id DESC;
+
+-- name: ListAllReleaseCandidates :many
+SELECT release, snapshot_id, state, changed_at
+FROM release_candidates
+ORDER BY release, snapshot_id;
+
+-- name: DeleteSnapshot :exec
+DELETE FROM snapshots WHERE id = ?;
+
+-- name: ListAuditHolds :many
+SELECT release, reason, created_at
+FROM release_audit_holds
+ORDER BY release;
+
+-- name: GetAuditHold :one
+SELECT release, reason, created_at
+FROM release_audit_holds
+WHERE release = ?;
+
+-- name: UpsertAuditHold :exec
+INSERT INTO release_audit_holds (release, reason, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT(release) DO UPDATE SET
+    reason=excluded.reason;
+
+-- name: DeleteAuditHold :execrows
+DELETE FROM release_audit_holds WHERE release = ?;
@@ -0,0 +1,9 @@
+-- name: CreateComponentSBOM :exec
+INSERT INTO component_sboms (snapshot_id, component, reference, format, packages, licenses)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListComponentSBOMs :many
+SELECT component, reference, format, packages, licenses
+FROM component_sboms
+WHERE snapshot_id = ?
+ORDER BY component;
@@ -0,0 +1,93 @@
+-- name: ListScenarioRuns :many
+SELECT s.name AS snapshot, s.created_at, ts.status, ts.tests, ts.passed, ts.failed, ts.skipped, ts.duration_ms
+FROM test_suites ts
+JOIN snapshots s ON s.id = ts.snapshot_id
+WHERE s.application = ? AND ts.name = ?
+ORDER BY s.id DESC
+LIMIT ?;
+
+-- name: ListScenarioRequirements :many
+SELECT application, scenario, required, updated_at
+FROM scenario_requirements
+WHERE application = ?
+ORDER BY scenario;
+
+-- name: UpsertScenarioRequirement :exec
+INSERT INTO scenario_requirements (application, scenario, required, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(application, scenario) DO UPDATE SET
+    required=excluded.required,
+    updated_at=excluded.updated_at;
+
+-- name: RecomputeTestsPassed :exec
+UPDATE snapshots SET tests_passed = CASE
+    WHEN EXISTS (SELECT 1 FROM test_suites ts WHERE ts.snapshot_id = snapshots.id)
+     AND NOT EXISTS (
+        SELECT 1 FROM test_suites ts
+        WHERE ts.snapshot_id = snapshots.id AND (ts.failed > 0 OR ts.status = 'pending')
+          AND NOT EXISTS (
+            SELECT 1 FROM scenario_requirements sr
+            WHERE sr.application = snapshots.application AND sr.scenario = ts.name AND sr.required = 0))
+    THEN 1 ELSE 0 END
+WHERE application = ?;
+
+-- name: RecomputeSnapshotTestsPassed :exec
+UPDATE snapshots SET tests_passed = CASE
+    WHEN EXISTS (SELECT 1 FROM test_suites ts WHERE ts.snapshot_id = snapshots.id)
+     AND NOT EXISTS (
+        SELECT 1 FROM test_suites ts
+        WHERE ts.snapshot_id = snapshots.id AND (ts.failed > 0 OR ts.status = 'pending')
+          AND NOT EXISTS (
+            SELECT 1 FROM scenario_requirements sr
+            WHERE sr.application = snapshots.application AND sr.scenario = ts.name AND sr.required = 0))
+    THEN 1 ELSE 0 END
+WHERE id = ?;
+
+-- name: CreateScenarioTransition :exec
+INSERT INTO scenario_transitions (snapshot_id, scenario, from_status, to_status, changed_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListScenarioTransitions :many
+SELECT id, snapshot_id, scenario, from_status, to_status, changed_at
+FROM scenario_transitions
+WHERE snapshot_id = ?
+ORDER BY id;
+
+-- name: ScenarioPassCounts :many
+SELECT ts.name,
+    COUNT(*) AS runs,
+    CAST(COALESCE(SUM(CASE WHEN ts.status = 'failed' THEN 1 ELSE 0 END), 0) AS INTEGER) AS failed_runs,
+    CAST(COALESCE(SUM(ts.passed), 0) AS INTEGER) AS passed,
+    CAST(COALESCE(SUM(ts.tests - ts.skipped), 0) AS INTEGER) AS executed
+FROM test_suites ts
+JOIN snapshots s ON s.id = ts.snapshot_id
+WHERE s.application = ? AND s.created_at >= ? AND ts.status != 'pending'
+GROUP BY ts.name
+ORDER BY ts.name;
+
+-- name: LastScenarioFailures :many
+SELECT ts.name AS scenario, s.name AS snapshot, s.created_at, ts.failed
+FROM test_suites ts
+JOIN snapshots s ON s.id = ts.snapshot_id
+WHERE s.application = ? AND ts.status = 'failed'
+  AND s.id = (
+    SELECT MAX(s2.id) FROM test_suites ts2
+    JOIN snapshots s2 ON s2.id = ts2.snapshot_id
+    WHERE s2.application = s.application AND ts2.name = ts.name AND ts2.status = 'failed')
+ORDER BY ts.name;
+
+-- name: ListAllTestFailuresSince :many
+SELECT s.application, s.name, s.created_at, ts.name AS scenario, tc.name AS test_name, tc.message
+FROM test_cases tc
+JOIN test_suites ts ON ts.id = tc.test_suite_id
+JOIN snapshots s ON s.id = ts.snapshot_id
+WHERE s.created_at >= ? AND tc.status = 'failed'
+ORDER BY s.id DESC, tc.id;
+
+-- name: ListTestFailuresSinceByApplication :many
+SELECT s.application, s.name, s.created_at, ts.name AS scenario, tc.name AS test_name, tc.message
+FROM test_cases tc
+JOIN test_suites ts ON ts.id = tc.test_suite_id
+JOIN snapshots s ON s.id = ts.snapshot_id
+WHERE s.application = ? AND s.created_at >= ? AND tc.status = 'failed'
+ORDER BY s.id DESC, tc.id;
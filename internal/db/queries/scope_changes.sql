@@ -0,0 +1,21 @@
+-- name: RecordScopeAddition :exec
+-- Records an issue entering a synced fixVersion; call before storing it.
+INSERT INTO issue_scope_changes (fix_version, issue_key, summary, change, changed_at)
+SELECT s.fix_version, ?, ?, 'added', ?
+FROM jira_sync_states s
+WHERE s.fix_version = ?
+  AND NOT EXISTS (SELECT 1 FROM jira_issues ji WHERE ji.key = ? AND ji.fix_version = s.fix_version);
+
+-- name: RecordScopeRemoval :exec
+-- Records an issue leaving a synced fixVersion; call before deleting it.
+INSERT INTO issue_scope_changes (fix_version, issue_key, summary, change, changed_at)
+SELECT ji.fix_version, ji.key, ji.summary, 'removed', ?
+FROM jira_issues ji
+JOIN jira_sync_states s ON s.fix_version = ji.fix_version
+WHERE ji.key = ? AND ji.fix_version = ?;
+
+-- name: ListScopeChanges :many
+SELECT id, fix_version, issue_key, summary, change, changed_at
+FROM issue_scope_changes
+WHERE fix_version = ?
+ORDER BY id;
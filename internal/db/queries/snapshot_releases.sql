@@ -0,0 +1,17 @@
+-- name: UpsertSnapshotRelease :exec
+INSERT INTO snapshot_releases (snapshot_id, name, release_plan, target, status, reason, message, start_time, completion_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(snapshot_id, name) DO UPDATE SET
+    release_plan=excluded.release_plan,
+    target=excluded.target,
+    status=excluded.status,
+    reason=excluded.reason,
+    message=excluded.message,
+    start_time=excluded.start_time,
+    completion_time=excluded.completion_time;
+
+-- name: ListSnapshotReleases :many
+SELECT name, release_plan, target, status, reason, message, start_time, completion_time
+FROM snapshot_releases
+WHERE snapshot_id = ?
+ORDER BY start_time, name;
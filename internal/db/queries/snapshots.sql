@@ -1,12 +1,13 @@
--- name: CreateSnapshot :execlastid
-INSERT INTO snapshots (application, name, tests_passed, created_at)
-VALUES (?, ?, ?, ?);
+-- name: CreateSnapshot :one
+INSERT INTO snapshots (application, name, tests_passed, created_at, source)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id;
 
 -- name: SnapshotExistsByName :one
 SELECT COUNT(*) FROM snapshots WHERE name = ?;
 
 -- name: GetSnapshotRow :one
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots WHERE name = ?;
 
 -- name: CreateSnapshotComponent :exec
@@ -19,20 +20,36 @@ FROM snapshot_components
 WHERE snapshot_id = ?
 ORDER BY component;
 
+-- name: CountAllSnapshots :one
+SELECT COUNT(*) FROM snapshots;
+
+-- name: CountSnapshotsByApplication :one
+SELECT COUNT(*) FROM snapshots WHERE application = ?;
+
 -- name: ListAllSnapshots :many
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots
 ORDER BY id DESC LIMIT ? OFFSET ?;
 
+-- name: ListSnapshotsSince :many
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
+FROM snapshots
+WHERE created_at >= ? AND source = ?
+ORDER BY created_at DESC;
+
 -- name: ListSnapshotsByApplication :many
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots
 WHERE application = ?
 ORDER BY id DESC LIMIT ? OFFSET ?;
 
 -- name: LatestSnapshotPerApplication :many
 SELECT s.id, s.application, s.name, s.tests_passed, s.created_at, CAST(counts.cnt AS INTEGER) AS cnt,
-       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count
+       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count,
+       (SELECT COUNT(*) FROM snapshot_components WHERE snapshot_id = s.id) AS component_count,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'ok') AS images_verified,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status IN ('missing', 'mismatch')) AS images_failed,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'missing') AS images_missing
 FROM snapshots s
 JOIN (
     SELECT application, MAX(id) AS max_id, COUNT(*) AS cnt
@@ -42,22 +59,26 @@ JOIN (
 ORDER BY s.application;
 
 -- name: GetSnapshotByID :one
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots WHERE id = ?;
 
 -- name: GetTestSuiteByID :one
 SELECT id, snapshot_id, name FROM test_suites WHERE id = ?;
 
--- name: CreateTestSuite :execlastid
-INSERT INTO test_suites (snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: CreateTestSuite :one
+INSERT INTO test_suites (snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, truncated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
 
 -- name: CreateTestCase :exec
 INSERT INTO test_cases (test_suite_id, name, status, duration_ms, message, trace, file_path, suite, retries, flaky)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
+-- name: DeleteTestSuite :exec
+DELETE FROM test_suites WHERE id = ?;
+
 -- name: ListTestSuitesBySnapshot :many
-SELECT id, snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, created_at
+SELECT id, snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, created_at, truncated
 FROM test_suites
 WHERE snapshot_id = ?
 ORDER BY name;
@@ -68,9 +89,10 @@ FROM test_cases
 WHERE test_suite_id = ?
 ORDER BY name;
 
--- name: CreateVulnerabilityReport :execlastid
+-- name: CreateVulnerabilityReport :one
 INSERT INTO vulnerability_reports (snapshot_id, component, arch, total, critical, high, medium, low, unknown, fixable)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
 
 -- name: CreateVulnerability :exec
 INSERT INTO vulnerabilities (report_id, name, severity, package_name, package_version, fixed_in_version, description, link)
@@ -95,3 +117,20 @@ ORDER BY
         ELSE 4
     END,
     name;
+
+-- name: LatestSnapshotBefore :one
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
+FROM snapshots
+WHERE application = ? AND created_at <= ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListS3SyncStates :many
+SELECT key, etag FROM s3_sync_states;
+
+-- name: UpsertS3SyncState :exec
+INSERT INTO s3_sync_states (key, etag, synced_at)
+VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET
+    etag=excluded.etag,
+    synced_at=excluded.synced_at;
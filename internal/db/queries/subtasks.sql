@@ -0,0 +1,18 @@
+-- name: DeleteJiraSubtasks :exec
+DELETE FROM jira_subtasks WHERE parent_key = ?;
+
+-- name: CreateJiraSubtask :exec
+INSERT INTO jira_subtasks (parent_key, subtask_key, summary, status, link)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(parent_key, subtask_key) DO NOTHING;
+
+-- name: ListReleaseSubtasks :many
+-- A sub-task synced under any fixVersion reports its synced status.
+SELECT t.parent_key, t.subtask_key, t.summary,
+    CAST(COALESCE(
+        (SELECT ji.status FROM jira_issues ji WHERE ji.key = t.subtask_key ORDER BY ji.updated_at DESC LIMIT 1),
+        t.status) AS TEXT) AS status,
+    t.link
+FROM jira_subtasks t
+WHERE t.parent_key IN (SELECT key FROM release_issues WHERE fix_version = ?)
+ORDER BY t.parent_key, t.subtask_key;
@@ -0,0 +1,9 @@
+-- name: ListWebhookStates :many
+SELECT release, signal, released FROM webhook_states;
+
+-- name: UpsertWebhookState :exec
+INSERT INTO webhook_states (release, signal, released)
+VALUES (?, ?, ?)
+ON CONFLICT(release) DO UPDATE SET
+    signal=excluded.signal,
+    released=excluded.released;
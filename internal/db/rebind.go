@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+)
+
+// rebindDBTX runs the SQLite-style queries shared by both engines against
+// PostgreSQL by rewriting their placeholders on the way through.
+type rebindDBTX struct {
+	dbsqlc.DBTX
+}
+
+func (r rebindDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.DBTX.ExecContext(ctx, rebind(query), args...)
+}
+
+func (r rebindDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.DBTX.PrepareContext(ctx, rebind(query))
+}
+
+func (r rebindDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.DBTX.QueryContext(ctx, rebind(query), args...)
+}
+
+func (r rebindDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.DBTX.QueryRowContext(ctx, rebind(query), args...)
+}
+
+// rebind rewrites "?" placeholders as PostgreSQL's numbered "$1", "$2", ...
+// Question marks inside quoted strings, quoted identifiers, and comments are
+// left alone.
+func rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
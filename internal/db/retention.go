@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListRetentionSnapshots returns every snapshot, grouped by application and
+// newest first within each.
+func (d *DB) ListRetentionSnapshots(ctx context.Context) ([]model.SnapshotRecord, error) {
+	rows, err := d.queries().ListRetentionSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]model.SnapshotRecord, len(rows))
+	for i, r := range rows {
+		snapshots[i] = toSnapshotRecord(r)
+	}
+	return snapshots, nil
+}
+
+// ListCandidateStates returns the candidate state of every snapshot that
+// has one, keyed by release and then snapshot ID. Snapshots without a
+// recorded state are plain candidates.
+func (d *DB) ListCandidateStates(ctx context.Context) (map[string]map[int64]string, error) {
+	rows, err := d.queries().ListAllReleaseCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]map[int64]string)
+	for _, r := range rows {
+		if states[r.Release] == nil {
+			states[r.Release] = make(map[int64]string)
+		}
+		states[r.Release][r.SnapshotID] = r.State
+	}
+	return states, nil
+}
+
+// DeleteSnapshots deletes the snapshots with the given IDs in one
+// transaction. Their components, test results, scans and candidate states
+// go with them.
+func (d *DB) DeleteSnapshots(ctx context.Context, ids []int64) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		q := tx.queries()
+		for _, id := range ids {
+			if err := q.DeleteSnapshot(ctx, id); err != nil {
+				return fmt.Errorf("delete snapshot %d: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListAuditHolds returns the releases on audit hold, by name.
+func (d *DB) ListAuditHolds(ctx context.Context) ([]model.AuditHold, error) {
+	rows, err := d.queries().ListAuditHolds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	holds := make([]model.AuditHold, len(rows))
+	for i, r := range rows {
+		holds[i] = toAuditHold(r)
+	}
+	return holds, nil
+}
+
+// SetAuditHold puts release on audit hold, or updates the reason of its
+// existing hold.
+func (d *DB) SetAuditHold(ctx context.Context, release, reason string) (*model.AuditHold, error) {
+	q := d.queries()
+	if err := q.UpsertAuditHold(ctx, dbsqlc.UpsertAuditHoldParams{
+		Release:   release,
+		Reason:    reason,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return nil, err
+	}
+	row, err := q.GetAuditHold(ctx, release)
+	if err != nil {
+		return nil, classify(err)
+	}
+	hold := toAuditHold(row)
+	return &hold, nil
+}
+
+// DeleteAuditHold lifts the audit hold of release. It returns ErrNotFound
+// if the release is not on hold.
+func (d *DB) DeleteAuditHold(ctx context.Context, release string) error {
+	n, err := d.queries().DeleteAuditHold(ctx, release)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("audit hold for %s: %w", release, ErrNotFound)
+	}
+	return nil
+}
+
+func toAuditHold(r dbsqlc.ReleaseAuditHold) model.AuditHold {
+	return model.AuditHold{
+		Release:   r.Release,
+		Reason:    r.Reason,
+		CreatedAt: parseTime(r.CreatedAt),
+	}
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// createComponentSBOM stores the SBOM of a snapshot component.
+func (d *DB) createComponentSBOM(ctx context.Context, snapshotID int64, component string, s *model.ComponentSBOM) error {
+	return classify(d.queries().CreateComponentSBOM(ctx, dbsqlc.CreateComponentSBOMParams{
+		SnapshotID: snapshotID,
+		Component:  component,
+		Reference:  s.Reference,
+		Format:     s.Format,
+		Packages:   int64(s.Packages),
+		// Licenses are stored comma-separated, as labels are; SPDX license
+		// expressions do not contain commas.
+		Licenses: strings.Join(s.Licenses, ","),
+	}))
+}
+
+// ListComponentSBOMs returns the SBOMs of a snapshot's components, keyed
+// by component.
+func (d *DB) ListComponentSBOMs(ctx context.Context, snapshotID int64) (map[string]*model.ComponentSBOM, error) {
+	rows, err := d.queries().ListComponentSBOMs(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	sboms := make(map[string]*model.ComponentSBOM, len(rows))
+	for _, r := range rows {
+		s := &model.ComponentSBOM{
+			Reference: r.Reference,
+			Format:    r.Format,
+			Packages:  int(r.Packages),
+		}
+		if r.Licenses != "" {
+			s.Licenses = strings.Split(r.Licenses, ",")
+		}
+		sboms[r.Component] = s
+	}
+	return sboms, nil
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListScenarioRuns returns the results of the test scenario named scenario
+// in the newest limit snapshots of application that ran it, oldest first.
+func (d *DB) ListScenarioRuns(ctx context.Context, application, scenario string, limit int) ([]model.ScenarioRun, error) {
+	rows, err := d.queries().ListScenarioRuns(ctx, dbsqlc.ListScenarioRunsParams{
+		Application: application,
+		Name:        scenario,
+		Limit:       int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]model.ScenarioRun, len(rows))
+	for i, r := range rows {
+		run := model.ScenarioRun{
+			Snapshot:   r.Snapshot,
+			CreatedAt:  parseTime(r.CreatedAt),
+			Status:     r.Status,
+			Tests:      int(r.Tests),
+			Passed:     int(r.Passed),
+			Failed:     int(r.Failed),
+			Skipped:    int(r.Skipped),
+			DurationMs: r.DurationMs,
+		}
+		if executed := run.Tests - run.Skipped; executed > 0 {
+			rate := float64(run.Passed) / float64(executed)
+			run.PassRate = &rate
+		}
+		runs[len(rows)-1-i] = run
+	}
+	return runs, nil
+}
+
+// ListScenarioPassRates returns the pass rate of each test scenario of
+// application over the snapshots created in each of the last windows days
+// before now, with its last failure. It covers the scenarios with finished
+// runs in the largest window, by name; windows they did not run in have no
+// runs.
+func (d *DB) ListScenarioPassRates(ctx context.Context, application string, windows []int, now time.Time) ([]model.ScenarioPassRate, error) {
+	var rates []model.ScenarioPassRate
+	index := make(map[string]int)
+	for i := range windows {
+		// Widest window first, so that it lists every scenario.
+		w := len(windows) - 1 - i
+		rows, err := d.queries().ScenarioPassCounts(ctx, dbsqlc.ScenarioPassCountsParams{
+			Application: application,
+			CreatedAt:   now.UTC().AddDate(0, 0, -windows[w]).Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			j, ok := index[r.Name]
+			if !ok {
+				if i > 0 {
+					continue
+				}
+				j = len(rates)
+				index[r.Name] = j
+				rate := model.ScenarioPassRate{Scenario: r.Name, Windows: make([]model.PassRateWindow, len(windows))}
+				for k, days := range windows {
+					rate.Windows[k].Days = days
+				}
+				rates = append(rates, rate)
+			}
+			window := &rates[j].Windows[w]
+			window.Runs = int(r.Runs)
+			window.FailedRuns = int(r.FailedRuns)
+			if r.Executed > 0 {
+				pass := float64(r.Passed) / float64(r.Executed)
+				window.PassRate = &pass
+			}
+		}
+	}
+	if len(rates) == 0 {
+		return rates, nil
+	}
+
+	failures, err := d.queries().LastScenarioFailures(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range failures {
+		if j, ok := index[f.Scenario]; ok {
+			rates[j].LastFailure = &model.ScenarioFailure{
+				Snapshot:  f.Snapshot,
+				CreatedAt: parseTime(f.CreatedAt),
+				Failed:    int(f.Failed),
+			}
+		}
+	}
+	return rates, nil
+}
+
+// ListTestFailures returns the failed test cases in the snapshots of
+// application, or of every application if it is empty, created since
+// since. Newest snapshots come first.
+func (d *DB) ListTestFailures(ctx context.Context, application string, since time.Time) ([]model.TestFailure, error) {
+	createdAt := since.UTC().Format(time.RFC3339)
+	var rows []dbsqlc.ListAllTestFailuresSinceRow
+	if application != "" {
+		appRows, err := d.queries().ListTestFailuresSinceByApplication(ctx, dbsqlc.ListTestFailuresSinceByApplicationParams{
+			Application: application,
+			CreatedAt:   createdAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range appRows {
+			rows = append(rows, dbsqlc.ListAllTestFailuresSinceRow(r))
+		}
+	} else {
+		var err error
+		if rows, err = d.queries().ListAllTestFailuresSince(ctx, createdAt); err != nil {
+			return nil, err
+		}
+	}
+	failures := make([]model.TestFailure, len(rows))
+	for i, r := range rows {
+		failures[i] = model.TestFailure{
+			Application: r.Application,
+			Snapshot:    r.Name,
+			CreatedAt:   parseTime(r.CreatedAt),
+			Scenario:    r.Scenario,
+			Test:        r.TestName,
+			Message:     r.Message,
+		}
+	}
+	return failures, nil
+}
+
+// ListScenarioRequirements returns the scenarios of application marked
+// required or informational, by name.
+func (d *DB) ListScenarioRequirements(ctx context.Context, application string) ([]model.ScenarioRequirement, error) {
+	rows, err := d.queries().ListScenarioRequirements(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+	reqs := make([]model.ScenarioRequirement, len(rows))
+	for i, r := range rows {
+		reqs[i] = model.ScenarioRequirement{
+			Application: r.Application,
+			Scenario:    r.Scenario,
+			Required:    r.Required == 1,
+			UpdatedAt:   parseTime(r.UpdatedAt),
+		}
+	}
+	return reqs, nil
+}
+
+// SetScenarioRequirement marks a scenario of r.Application required or
+// informational, setting r.UpdatedAt, and recomputes tests_passed of the
+// application's stored snapshots to match.
+func (d *DB) SetScenarioRequirement(ctx context.Context, r *model.ScenarioRequirement) error {
+	r.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	return d.InTx(ctx, func(tx *DB) error {
+		if err := tx.queries().UpsertScenarioRequirement(ctx, dbsqlc.UpsertScenarioRequirementParams{
+			Application: r.Application,
+			Scenario:    r.Scenario,
+			Required:    boolToInt64(r.Required),
+			UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		return tx.queries().RecomputeTestsPassed(ctx, r.Application)
+	})
+}
+
+// ListScenarioTransitions returns the status changes of the test scenarios
+// of the snapshot with snapshotID found after it was ingested, oldest
+// first.
+func (d *DB) ListScenarioTransitions(ctx context.Context, snapshotID int64) ([]model.ScenarioTransition, error) {
+	rows, err := d.queries().ListScenarioTransitions(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	var transitions []model.ScenarioTransition
+	for _, r := range rows {
+		transitions = append(transitions, model.ScenarioTransition{
+			Scenario:  r.Scenario,
+			From:      r.FromStatus,
+			To:        r.ToStatus,
+			ChangedAt: parseTime(r.ChangedAt),
+		})
+	}
+	return transitions, nil
+}
@@ -2,7 +2,8 @@ CREATE TABLE IF NOT EXISTS components (
     id          INTEGER PRIMARY KEY AUTOINCREMENT,
     name        TEXT NOT NULL UNIQUE,
     description TEXT NOT NULL DEFAULT '',
-    created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+    created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    jira_components TEXT NOT NULL DEFAULT ''
 );
 
 CREATE TABLE IF NOT EXISTS snapshots (
@@ -10,11 +11,18 @@ CREATE TABLE IF NOT EXISTS snapshots (
     application  TEXT NOT NULL,
     name         TEXT NOT NULL UNIQUE,
     tests_passed INTEGER NOT NULL DEFAULT 0,
-    created_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+    created_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    -- source is the configured object store the snapshot was synced from;
+    -- empty for the one set by the -s3-* flags and for pushed snapshots.
+    source       TEXT NOT NULL DEFAULT '',
+    -- lifecycle is new, candidate, rejected, accepted or released; see
+    -- snapshot_lifecycle_transitions for how it got there.
+    lifecycle    TEXT NOT NULL DEFAULT 'new'
 );
 
-CREATE INDEX IF NOT EXISTS idx_snapshots_application ON snapshots(application);
 CREATE INDEX IF NOT EXISTS idx_snapshots_created ON snapshots(created_at DESC);
+DROP INDEX IF EXISTS idx_snapshots_application;
+CREATE INDEX IF NOT EXISTS idx_snapshots_application_created ON snapshots(application, created_at DESC);
 
 CREATE TABLE IF NOT EXISTS test_suites (
     id              INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -34,10 +42,62 @@ CREATE TABLE IF NOT EXISTS test_suites (
     start_time      INTEGER NOT NULL DEFAULT 0,
     stop_time       INTEGER NOT NULL DEFAULT 0,
     duration_ms     INTEGER NOT NULL DEFAULT 0,
-    created_at      TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+    created_at      TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    truncated       INTEGER NOT NULL DEFAULT 0
+);
+
+DROP INDEX IF EXISTS idx_test_suites_snapshot;
+CREATE INDEX IF NOT EXISTS idx_test_suites_snapshot_name ON test_suites(snapshot_id, name);
+
+-- Metadata configured for an S3 application. fix_versions lists the JIRA
+-- fixVersions mapped to it whatever their names. The retention columns
+-- replace the snapshot retention limits of the application when
+-- retention_set is 1.
+CREATE TABLE IF NOT EXISTS applications (
+    name                TEXT PRIMARY KEY,
+    display_name        TEXT NOT NULL DEFAULT '',
+    product             TEXT NOT NULL DEFAULT '',
+    fix_versions        TEXT NOT NULL DEFAULT '',
+    retention_set       INTEGER NOT NULL DEFAULT 0,
+    retention_max_count INTEGER NOT NULL DEFAULT 0,
+    retention_max_age   TEXT NOT NULL DEFAULT '',
+    updated_at          TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+
+-- Whether a test scenario (suite) of an application gates tests_passed.
+-- Scenarios without a row are required.
+CREATE TABLE IF NOT EXISTS scenario_requirements (
+    application TEXT NOT NULL,
+    scenario    TEXT NOT NULL,
+    required    INTEGER NOT NULL DEFAULT 1,
+    updated_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    PRIMARY KEY (application, scenario)
+);
+
+-- Changes of the status of a snapshot's test scenarios found after it was
+-- ingested, typically from pending once the scenario's tests completed.
+CREATE TABLE IF NOT EXISTS scenario_transitions (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    scenario    TEXT NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status   TEXT NOT NULL,
+    changed_at  TEXT NOT NULL
 );
+CREATE INDEX IF NOT EXISTS idx_scenario_transitions_snapshot ON scenario_transitions(snapshot_id);
 
-CREATE INDEX IF NOT EXISTS idx_test_suites_snapshot ON test_suites(snapshot_id);
+-- Changes of a snapshot's lifecycle state made by release managers, with
+-- why, e.g. the reason a release candidate was rejected.
+CREATE TABLE IF NOT EXISTS snapshot_lifecycle_transitions (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    from_state  TEXT NOT NULL,
+    to_state    TEXT NOT NULL,
+    reason      TEXT NOT NULL DEFAULT '',
+    changed_by  TEXT NOT NULL DEFAULT '',
+    changed_at  TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_snapshot_lifecycle_transitions_snapshot ON snapshot_lifecycle_transitions(snapshot_id);
 
 CREATE TABLE IF NOT EXISTS test_cases (
     id              INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -79,7 +139,15 @@ CREATE TABLE IF NOT EXISTS jira_issues (
     resolution  TEXT NOT NULL DEFAULT '',
     link        TEXT NOT NULL DEFAULT '',
     qa_contact  TEXT NOT NULL DEFAULT '',
-    updated_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+    updated_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    severity    TEXT NOT NULL DEFAULT '',
+    clones      TEXT NOT NULL DEFAULT '',
+    project     TEXT NOT NULL DEFAULT '',
+    cve_id      TEXT NOT NULL DEFAULT '',
+    cvss_score  REAL NOT NULL DEFAULT 0,
+    embargoed   INTEGER NOT NULL DEFAULT 0,
+    blocker     INTEGER NOT NULL DEFAULT 0,
+    components  TEXT NOT NULL DEFAULT ''
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_jira_issues_key_version ON jira_issues(key, fix_version);
@@ -114,7 +182,8 @@ CREATE TABLE IF NOT EXISTS vulnerabilities (
 );
 
 CREATE INDEX IF NOT EXISTS idx_vulns_report ON vulnerabilities(report_id);
-CREATE INDEX IF NOT EXISTS idx_jira_issues_fix_version ON jira_issues(fix_version);
+DROP INDEX IF EXISTS idx_jira_issues_fix_version;
+CREATE INDEX IF NOT EXISTS idx_jira_issues_fix_version_type ON jira_issues(fix_version, issue_type, key);
 
 CREATE TABLE IF NOT EXISTS release_versions (
     id                 INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -126,5 +195,335 @@ CREATE TABLE IF NOT EXISTS release_versions (
     release_ticket_key      TEXT NOT NULL DEFAULT '',
     release_ticket_assignee TEXT NOT NULL DEFAULT '',
     s3_application          TEXT NOT NULL DEFAULT '',
-    due_date                TEXT NOT NULL DEFAULT ''
+    due_date                TEXT NOT NULL DEFAULT '',
+    issues_archived_at      TEXT NOT NULL DEFAULT '',
+    hidden                  INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS release_audits (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    release       TEXT NOT NULL UNIQUE,
+    snapshot_name TEXT NOT NULL DEFAULT '',
+    audited_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+
+CREATE TABLE IF NOT EXISTS release_audit_findings (
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    audit_id  INTEGER NOT NULL REFERENCES release_audits(id) ON DELETE CASCADE,
+    component TEXT NOT NULL,
+    kind      TEXT NOT NULL,
+    expected  TEXT NOT NULL DEFAULT '',
+    actual    TEXT NOT NULL DEFAULT '',
+    message   TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_release_audit_findings_audit ON release_audit_findings(audit_id);
+
+-- git_ranges caches which commit ranges of GitHub repositories have been
+-- looked up; a range between two SHAs never changes once fetched.
+-- git_range_commits and git_range_pull_requests hold the commits of each
+-- range and the pull requests merged in it. total_commits counts the
+-- commits of the range, of which only the first are listed; it is -1 for
+-- ranges cached before commits were.
+CREATE TABLE IF NOT EXISTS git_ranges (
+    repo          TEXT NOT NULL,
+    from_sha      TEXT NOT NULL,
+    to_sha        TEXT NOT NULL,
+    fetched_at    TEXT NOT NULL,
+    total_commits INTEGER NOT NULL DEFAULT -1,
+    PRIMARY KEY (repo, from_sha, to_sha)
+);
+
+CREATE TABLE IF NOT EXISTS git_range_commits (
+    repo     TEXT NOT NULL,
+    from_sha TEXT NOT NULL,
+    to_sha   TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    sha      TEXT NOT NULL,
+    subject  TEXT NOT NULL DEFAULT '',
+    author   TEXT NOT NULL DEFAULT '',
+    url      TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (repo, from_sha, to_sha, position)
+);
+
+CREATE TABLE IF NOT EXISTS git_range_pull_requests (
+    repo      TEXT NOT NULL,
+    from_sha  TEXT NOT NULL,
+    to_sha    TEXT NOT NULL,
+    number    INTEGER NOT NULL,
+    title     TEXT NOT NULL DEFAULT '',
+    author    TEXT NOT NULL DEFAULT '',
+    url       TEXT NOT NULL DEFAULT '',
+    merged_at TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (repo, from_sha, to_sha, number)
+);
+
+CREATE TABLE IF NOT EXISTS component_sboms (
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    component   TEXT NOT NULL,
+    reference   TEXT NOT NULL DEFAULT '',
+    format      TEXT NOT NULL DEFAULT '',
+    packages    INTEGER NOT NULL DEFAULT 0,
+    licenses    TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (snapshot_id, component)
+);
+
+CREATE TABLE IF NOT EXISTS image_verifications (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    component   TEXT NOT NULL,
+    image_url   TEXT NOT NULL DEFAULT '',
+    tag         TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL,
+    message     TEXT NOT NULL DEFAULT '',
+    checked_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    UNIQUE(snapshot_id, component)
+);
+
+CREATE TABLE IF NOT EXISTS release_candidates (
+    release     TEXT NOT NULL,
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    state       TEXT NOT NULL,
+    changed_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    PRIMARY KEY (release, snapshot_id)
+);
+
+CREATE TABLE IF NOT EXISTS issue_buckets (
+    id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    name   TEXT NOT NULL UNIQUE,
+    labels TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS release_issue_archive (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    key         TEXT NOT NULL,
+    summary     TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL DEFAULT '',
+    priority    TEXT NOT NULL DEFAULT '',
+    labels      TEXT NOT NULL DEFAULT '',
+    fix_version TEXT NOT NULL,
+    assignee    TEXT NOT NULL DEFAULT '',
+    issue_type  TEXT NOT NULL DEFAULT '',
+    resolution  TEXT NOT NULL DEFAULT '',
+    link        TEXT NOT NULL DEFAULT '',
+    qa_contact  TEXT NOT NULL DEFAULT '',
+    updated_at  TEXT NOT NULL DEFAULT '',
+    severity    TEXT NOT NULL DEFAULT '',
+    clones      TEXT NOT NULL DEFAULT '',
+    project     TEXT NOT NULL DEFAULT '',
+    cve_id      TEXT NOT NULL DEFAULT '',
+    cvss_score  REAL NOT NULL DEFAULT 0,
+    embargoed   INTEGER NOT NULL DEFAULT 0,
+    blocker     INTEGER NOT NULL DEFAULT 0,
+    components  TEXT NOT NULL DEFAULT '',
+    UNIQUE(fix_version, key)
+);
+
+CREATE TABLE IF NOT EXISTS notification_states (
+    release         TEXT PRIMARY KEY,
+    signal          TEXT NOT NULL DEFAULT '',
+    blocking_cves   INTEGER NOT NULL DEFAULT 0,
+    failed_snapshot TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    email      TEXT NOT NULL,
+    release    TEXT NOT NULL,
+    frequency  TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    UNIQUE(email, release, frequency)
+);
+
+CREATE TABLE IF NOT EXISTS digest_states (
+    frequency        TEXT NOT NULL,
+    release          TEXT NOT NULL,
+    sent_at          TEXT NOT NULL,
+    blockers         TEXT NOT NULL DEFAULT '',
+    failed_scenarios TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (frequency, release)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_states (
+    release  TEXT PRIMARY KEY,
+    signal   TEXT NOT NULL DEFAULT '',
+    released INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS jira_sync_states (
+    fix_version   TEXT PRIMARY KEY,
+    synced_at     TEXT NOT NULL DEFAULT '',
+    reconciled_at TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS release_approvals (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    release    TEXT NOT NULL,
+    approver   TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    comment    TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+CREATE INDEX IF NOT EXISTS idx_release_approvals_release ON release_approvals(release);
+
+CREATE TABLE IF NOT EXISTS readiness_overrides (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    release    TEXT NOT NULL,
+    rule       TEXT NOT NULL,
+    reason     TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now')),
+    expires_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_readiness_overrides_release ON readiness_overrides(release);
+
+CREATE TABLE IF NOT EXISTS release_readiness_history (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    release       TEXT NOT NULL,
+    signal        TEXT NOT NULL,
+    total         INTEGER NOT NULL DEFAULT 0,
+    open          INTEGER NOT NULL DEFAULT 0,
+    verified      INTEGER NOT NULL DEFAULT 0,
+    cves          INTEGER NOT NULL DEFAULT 0,
+    bugs          INTEGER NOT NULL DEFAULT 0,
+    blocking_cves INTEGER NOT NULL DEFAULT 0,
+    recorded_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+CREATE INDEX IF NOT EXISTS idx_release_readiness_history_release ON release_readiness_history(release, id);
+
+CREATE TABLE IF NOT EXISTS release_audit_holds (
+    release    TEXT PRIMARY KEY,
+    reason     TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+
+CREATE TABLE IF NOT EXISTS release_advisories (
+    release     TEXT PRIMARY KEY,
+    advisory_id INTEGER NOT NULL,
+    name        TEXT NOT NULL DEFAULT '',
+    type        TEXT NOT NULL DEFAULT '',
+    state       TEXT NOT NULL DEFAULT '',
+    synopsis    TEXT NOT NULL DEFAULT '',
+    builds      TEXT NOT NULL DEFAULT '',
+    cves        TEXT NOT NULL DEFAULT '',
+    synced_at   TEXT NOT NULL DEFAULT '',
+    sync_error  TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS s3_sync_states (
+    key       TEXT PRIMARY KEY,
+    etag      TEXT NOT NULL,
+    synced_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+
+-- Snapshots whose ingestion failed, keyed by the source and key of their
+-- snapshot.json. Polls retry a snapshot until it is dead, after too many
+-- attempts; the row is removed once the snapshot is ingested.
+CREATE TABLE IF NOT EXISTS ingest_failures (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    source          TEXT NOT NULL DEFAULT '',
+    key             TEXT NOT NULL,
+    application     TEXT NOT NULL DEFAULT '',
+    snapshot        TEXT NOT NULL DEFAULT '',
+    attempts        INTEGER NOT NULL DEFAULT 1,
+    last_error      TEXT NOT NULL DEFAULT '',
+    dead            INTEGER NOT NULL DEFAULT 0,
+    first_failed_at TEXT NOT NULL,
+    last_failed_at  TEXT NOT NULL,
+    UNIQUE (source, key)
+);
+
+CREATE TABLE IF NOT EXISTS snapshot_releases (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    snapshot_id     INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    release_plan    TEXT NOT NULL DEFAULT '',
+    target          TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    message         TEXT NOT NULL DEFAULT '',
+    start_time      TEXT NOT NULL DEFAULT '',
+    completion_time TEXT NOT NULL DEFAULT '',
+    UNIQUE(snapshot_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS ec_results (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    snapshot_id INTEGER NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+    component   TEXT NOT NULL,
+    image_url   TEXT NOT NULL DEFAULT '',
+    success     INTEGER NOT NULL DEFAULT 0,
+    UNIQUE(snapshot_id, component)
+);
+
+CREATE TABLE IF NOT EXISTS ec_findings (
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    result_id INTEGER NOT NULL REFERENCES ec_results(id) ON DELETE CASCADE,
+    kind      TEXT NOT NULL,
+    code      TEXT NOT NULL DEFAULT '',
+    title     TEXT NOT NULL DEFAULT '',
+    message   TEXT NOT NULL DEFAULT '',
+    solution  TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_ec_findings_result ON ec_findings(result_id);
+
+CREATE TABLE IF NOT EXISTS pipeline_runs (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    test_suite_id   INTEGER NOT NULL UNIQUE REFERENCES test_suites(id) ON DELETE CASCADE,
+    namespace       TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    message         TEXT NOT NULL DEFAULT '',
+    start_time      TEXT NOT NULL DEFAULT '',
+    completion_time TEXT NOT NULL DEFAULT '',
+    resolved_at     TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ','now'))
+);
+
+CREATE TABLE IF NOT EXISTS pipeline_run_tasks (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    pipeline_run_id INTEGER NOT NULL REFERENCES pipeline_runs(id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    message         TEXT NOT NULL DEFAULT '',
+    start_time      TEXT NOT NULL DEFAULT '',
+    completion_time TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_pipeline_run_tasks_run ON pipeline_run_tasks(pipeline_run_id);
+
+-- jira_issue_blockers holds the "is blocked by" and "depends on" links of
+-- each synced issue, replaced whenever the issue is synced. The blocker's
+-- status and summary are as of that sync.
+CREATE TABLE IF NOT EXISTS jira_issue_blockers (
+    issue_key       TEXT NOT NULL,
+    blocker_key     TEXT NOT NULL,
+    blocker_summary TEXT NOT NULL DEFAULT '',
+    blocker_status  TEXT NOT NULL DEFAULT '',
+    blocker_link    TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (issue_key, blocker_key)
+);
+
+-- jira_subtasks holds the sub-tasks of each synced issue, replaced whenever
+-- the parent is synced. Summary and status are as of that sync.
+CREATE TABLE IF NOT EXISTS jira_subtasks (
+    parent_key  TEXT NOT NULL,
+    subtask_key TEXT NOT NULL,
+    summary     TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL DEFAULT '',
+    link        TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (parent_key, subtask_key)
+);
+
+-- issue_scope_changes records issues entering or leaving a fixVersion after
+-- its first sync, which sets the baseline scope.
+CREATE TABLE IF NOT EXISTS issue_scope_changes (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    fix_version TEXT NOT NULL,
+    issue_key   TEXT NOT NULL,
+    summary     TEXT NOT NULL DEFAULT '',
+    change      TEXT NOT NULL,
+    changed_at  TEXT NOT NULL
 );
+CREATE INDEX IF NOT EXISTS idx_issue_scope_changes_version ON issue_scope_changes(fix_version, id);
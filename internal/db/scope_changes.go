@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListScopeChanges returns the issues that entered or left fixVersion
+// after its first sync, oldest first.
+func (d *DB) ListScopeChanges(ctx context.Context, fixVersion string) ([]model.ScopeChange, error) {
+	rows, err := d.queries().ListScopeChanges(ctx, fixVersion)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]model.ScopeChange, len(rows))
+	for i, r := range rows {
+		changes[i] = model.ScopeChange{
+			IssueKey:  r.IssueKey,
+			Summary:   r.Summary,
+			Change:    r.Change,
+			ChangedAt: parseTime(r.ChangedAt),
+		}
+	}
+	return changes, nil
+}
+
+// recordScopeAddition records issue as added to its fixVersion if it is
+// not stored there yet and the fixVersion has been synced before.
+func (d *DB) recordScopeAddition(ctx context.Context, issue *model.JiraIssueRecord) error {
+	return d.queries().RecordScopeAddition(ctx, dbsqlc.RecordScopeAdditionParams{
+		IssueKey:   issue.Key,
+		Summary:    issue.Summary,
+		ChangedAt:  time.Now().UTC().Format(time.RFC3339),
+		FixVersion: issue.FixVersion,
+		Key:        issue.Key,
+	})
+}
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// searchMessageLen is the number of characters of a failure message kept
+// in a search result.
+const searchMessageLen = 200
+
+// searchSource is a type of search result: how its rows are matched and
+// read.
+type searchSource struct {
+	typ string
+	// id is the matched table's id column, looked up in the full-text
+	// index fts on SQLite. On PostgreSQL the columns fts indexes are
+	// matched with LIKE instead.
+	id      string
+	fts     string
+	columns []string
+	// query selects the matching rows in order, with %s for the match
+	// condition and a placeholder for the limit.
+	query string
+	scan  func(rows *sql.Rows) (model.SearchResult, error)
+}
+
+var searchSources = []searchSource{
+	{
+		typ: model.SearchIssue, id: "j.id", fts: "jira_issues_fts", columns: []string{"j.key", "j.summary"},
+		query: `SELECT j.key, j.summary, j.fix_version, j.status, j.link
+			FROM jira_issues j
+			WHERE %s
+			ORDER BY j.updated_at DESC, j.key, j.fix_version LIMIT ?`,
+		scan: func(rows *sql.Rows) (model.SearchResult, error) {
+			var r model.SearchResult
+			err := rows.Scan(&r.Name, &r.Summary, &r.FixVersion, &r.Status, &r.Link)
+			return r, err
+		},
+	},
+	{
+		typ: model.SearchSnapshot, id: "s.id", fts: "snapshots_fts", columns: []string{"s.name"},
+		query: `SELECT s.name, s.application, s.created_at
+			FROM snapshots s
+			WHERE %s
+			ORDER BY s.id DESC LIMIT ?`,
+		scan: func(rows *sql.Rows) (model.SearchResult, error) {
+			var r model.SearchResult
+			var createdAt string
+			err := rows.Scan(&r.Name, &r.Application, &createdAt)
+			r.CreatedAt = parseOptionalTime(createdAt)
+			return r, err
+		},
+	},
+	{
+		typ: model.SearchComponent, id: "c.id", fts: "components_fts", columns: []string{"c.name", "c.description"},
+		query: `SELECT c.name, c.description, COALESCE(s.name, ''), COALESCE(s.application, ''), COALESCE(s.created_at, '')
+			FROM components c
+			LEFT JOIN snapshots s ON s.id = (SELECT MAX(sc.snapshot_id) FROM snapshot_components sc WHERE sc.component = c.name)
+			WHERE %s
+			ORDER BY c.name LIMIT ?`,
+		scan: func(rows *sql.Rows) (model.SearchResult, error) {
+			var r model.SearchResult
+			var createdAt string
+			err := rows.Scan(&r.Name, &r.Summary, &r.Snapshot, &r.Application, &createdAt)
+			r.CreatedAt = parseOptionalTime(createdAt)
+			return r, err
+		},
+	},
+	{
+		typ: model.SearchFailure, id: "tc.id", fts: "test_failures_fts", columns: []string{"tc.name", "tc.message"},
+		query: `SELECT tc.name, tc.message, ts.name, s.name, s.application, s.created_at
+			FROM test_cases tc
+			JOIN test_suites ts ON ts.id = tc.test_suite_id
+			JOIN snapshots s ON s.id = ts.snapshot_id
+			WHERE tc.status = 'failed' AND %s
+			ORDER BY tc.id DESC LIMIT ?`,
+		scan: func(rows *sql.Rows) (model.SearchResult, error) {
+			var r model.SearchResult
+			var createdAt string
+			err := rows.Scan(&r.Name, &r.Summary, &r.Scenario, &r.Snapshot, &r.Application, &createdAt)
+			r.Summary = shortenMessage(r.Summary)
+			r.CreatedAt = parseOptionalTime(createdAt)
+			return r, err
+		},
+	},
+}
+
+// Search returns the issues, snapshots, components and failed test cases
+// matching every word of query, in that order and up to limit of each
+// type. Components are ordered by name, the rest newest first. On SQLite
+// each word matches the start of a word in the full-text indexes of
+// search.sql; on PostgreSQL, which has no such indexes, it matches
+// anywhere in the text, ignoring case.
+func (d *DB) Search(ctx context.Context, query string, limit int) ([]model.SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	var results []model.SearchResult
+	for _, src := range searchSources {
+		found, err := d.searchSource(ctx, src, terms, limit)
+		if err != nil {
+			return nil, fmt.Errorf("search %ss: %w", src.typ, err)
+		}
+		results = append(results, found...)
+	}
+	return results, nil
+}
+
+// searchSource returns up to limit results of src matching terms. Stays
+// hand-written due to the match condition differing by driver.
+func (d *DB) searchSource(ctx context.Context, src searchSource, terms []string, limit int) ([]model.SearchResult, error) {
+	var cond string
+	var args []interface{}
+	if d.driver == Postgres {
+		conds := make([]string, len(terms))
+		for i, t := range terms {
+			like := make([]string, len(src.columns))
+			for j, c := range src.columns {
+				like[j] = "LOWER(" + c + ") LIKE ?"
+				args = append(args, "%"+strings.ToLower(t)+"%")
+			}
+			conds[i] = "(" + strings.Join(like, " OR ") + ")"
+		}
+		cond = strings.Join(conds, " AND ")
+	} else {
+		cond = src.id + " IN (SELECT rowid FROM " + src.fts + " WHERE " + src.fts + " MATCH ?)"
+		args = append(args, ftsQuery(terms))
+	}
+
+	rows, err := d.dbtx.QueryContext(ctx, fmt.Sprintf(src.query, cond), append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []model.SearchResult
+	for rows.Next() {
+		r, err := src.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		r.Type = src.typ
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// searchTerms splits a search query into words, dropping those without a
+// letter or digit, which the full-text indexes do not hold.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, f := range strings.Fields(query) {
+		if strings.IndexFunc(f, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
+
+// ftsQuery returns the FTS5 query matching every term as a prefix. Each
+// term is quoted, so FTS5 operators in it match literally, and splits into
+// a phrase where the index splits words: quay-v3 matches "quay v3".
+func ftsQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"*`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shortenMessage returns the first searchMessageLen characters of a
+// failure message, marking a cut with an ellipsis.
+func shortenMessage(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if r := []rune(msg); len(r) > searchMessageLen {
+		return strings.TrimSpace(string(r[:searchMessageLen])) + "…"
+	}
+	return msg
+}
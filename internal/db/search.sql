@@ -0,0 +1,59 @@
+-- Full-text indexes for GET /api/v1/search, SQLite only. Each is an FTS5
+-- index over another table's rows, keyed by their id and kept current by
+-- the triggers below; createSearchIndexes fills one from the existing rows
+-- when it is first created.
+
+-- Issue keys and summaries.
+CREATE VIRTUAL TABLE IF NOT EXISTS jira_issues_fts USING fts5(key, summary, content='jira_issues', content_rowid='id');
+
+CREATE TRIGGER IF NOT EXISTS jira_issues_fts_insert AFTER INSERT ON jira_issues BEGIN
+    INSERT INTO jira_issues_fts (rowid, key, summary) VALUES (new.id, new.key, new.summary);
+END;
+
+CREATE TRIGGER IF NOT EXISTS jira_issues_fts_delete AFTER DELETE ON jira_issues BEGIN
+    INSERT INTO jira_issues_fts (jira_issues_fts, rowid, key, summary) VALUES ('delete', old.id, old.key, old.summary);
+END;
+
+CREATE TRIGGER IF NOT EXISTS jira_issues_fts_update AFTER UPDATE OF key, summary ON jira_issues BEGIN
+    INSERT INTO jira_issues_fts (jira_issues_fts, rowid, key, summary) VALUES ('delete', old.id, old.key, old.summary);
+    INSERT INTO jira_issues_fts (rowid, key, summary) VALUES (new.id, new.key, new.summary);
+END;
+
+-- Snapshot names, which are never changed.
+CREATE VIRTUAL TABLE IF NOT EXISTS snapshots_fts USING fts5(name, content='snapshots', content_rowid='id');
+
+CREATE TRIGGER IF NOT EXISTS snapshots_fts_insert AFTER INSERT ON snapshots BEGIN
+    INSERT INTO snapshots_fts (rowid, name) VALUES (new.id, new.name);
+END;
+
+CREATE TRIGGER IF NOT EXISTS snapshots_fts_delete AFTER DELETE ON snapshots BEGIN
+    INSERT INTO snapshots_fts (snapshots_fts, rowid, name) VALUES ('delete', old.id, old.name);
+END;
+
+-- Component names and descriptions.
+CREATE VIRTUAL TABLE IF NOT EXISTS components_fts USING fts5(name, description, content='components', content_rowid='id');
+
+CREATE TRIGGER IF NOT EXISTS components_fts_insert AFTER INSERT ON components BEGIN
+    INSERT INTO components_fts (rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS components_fts_delete AFTER DELETE ON components BEGIN
+    INSERT INTO components_fts (components_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS components_fts_update AFTER UPDATE OF name, description ON components BEGIN
+    INSERT INTO components_fts (components_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+    INSERT INTO components_fts (rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+
+-- Names and messages of failed test cases only; test cases are never
+-- changed once stored.
+CREATE VIRTUAL TABLE IF NOT EXISTS test_failures_fts USING fts5(name, message, content='test_cases', content_rowid='id');
+
+CREATE TRIGGER IF NOT EXISTS test_failures_fts_insert AFTER INSERT ON test_cases WHEN new.status = 'failed' BEGIN
+    INSERT INTO test_failures_fts (rowid, name, message) VALUES (new.id, new.name, new.message);
+END;
+
+CREATE TRIGGER IF NOT EXISTS test_failures_fts_delete AFTER DELETE ON test_cases WHEN old.status = 'failed' BEGIN
+    INSERT INTO test_failures_fts (test_failures_fts, rowid, name, message) VALUES ('delete', old.id, old.name, old.message);
+END;
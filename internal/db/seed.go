@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Seed populates an empty database with a small, fixed sample data set: one
+// active release with a snapshot, test results, and issues, plus one released
+// version. It is used for ephemeral databases so that demos and integration
+// tests have something to render. Seed is a no-op if any release exists.
+func (d *DB) Seed(ctx context.Context) error {
+	existing, err := d.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("list releases: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	due := now.Add(14 * 24 * time.Hour)
+	released := now.Add(-30 * 24 * time.Hour)
+
+	return d.InTx(ctx, func(tx *DB) error {
+		releases := []model.ReleaseVersion{
+			{
+				Name:                  "quay-v3.17.0",
+				Description:           "Sample minor release",
+				ReleaseTicketKey:      "PROJQUAY-1000",
+				ReleaseTicketAssignee: "Release Manager",
+				S3Application:         "quay-v3-17",
+				DueDate:               &due,
+			},
+			{
+				Name:          "quay-v3.16.0",
+				Description:   "Sample released version",
+				Released:      true,
+				ReleaseDate:   &released,
+				S3Application: "quay-v3-16",
+			},
+		}
+		for i := range releases {
+			if err := tx.UpsertReleaseVersion(ctx, &releases[i]); err != nil {
+				return fmt.Errorf("seed release %s: %w", releases[i].Name, err)
+			}
+		}
+
+		snap, err := tx.CreateSnapshot(ctx, "quay-v3-17", "quay-v3-17-sample", false, now.Add(-time.Hour))
+		if err != nil {
+			return fmt.Errorf("seed snapshot: %w", err)
+		}
+		components := []struct{ name, sha, image, git string }{
+			{"quay", "0123456789abcdef0123456789abcdef01234567", "quay.io/sample/quay@sha256:aaaa", "https://github.com/quay/quay"},
+			{"clair", "89abcdef0123456789abcdef0123456789abcdef", "quay.io/sample/clair@sha256:bbbb", "https://github.com/quay/clair"},
+		}
+		for _, c := range components {
+			if _, err := tx.EnsureComponent(ctx, c.name); err != nil {
+				return fmt.Errorf("seed component %s: %w", c.name, err)
+			}
+			if err := tx.CreateSnapshotComponent(ctx, snap.ID, c.name, c.sha, c.image, c.git); err != nil {
+				return fmt.Errorf("seed snapshot component %s: %w", c.name, err)
+			}
+		}
+
+		start := now.Add(-time.Hour).UnixMilli()
+		stop := start + 90_000
+		suiteID, err := tx.CreateTestSuite(ctx, snap.ID, "api-tests", "failed", "", "pytest", "8.0",
+			3, 2, 1, 0, 0, 0, 0, start, stop, stop-start, false)
+		if err != nil {
+			return fmt.Errorf("seed test suite: %w", err)
+		}
+		cases := []struct {
+			name, status, message string
+		}{
+			{"test_login", "passed", ""},
+			{"test_push_pull", "passed", ""},
+			{"test_mirror_sync", "failed", "AssertionError: mirror did not complete within 60s"},
+		}
+		for _, tc := range cases {
+			if err := tx.CreateTestCase(ctx, suiteID, tc.name, tc.status, 1000, tc.message, "", "", "api", 0, false); err != nil {
+				return fmt.Errorf("seed test case %s: %w", tc.name, err)
+			}
+		}
+
+		issues := []model.JiraIssueRecord{
+			{Key: "PROJQUAY-1001", Summary: "Mirror sync times out on large repositories", Status: "In Progress", Priority: "Major", IssueType: "Bug"},
+			{Key: "PROJQUAY-1002", Summary: "CVE-2026-0001 in bundled library", Status: "Verified", Priority: "Critical", IssueType: "Vulnerability", Labels: "CVE-2026-0001", Severity: "Important", CVEID: "CVE-2026-0001", CVSSScore: 7.5},
+			{Key: "PROJQUAY-1003", Summary: "Document new quota settings", Status: "Closed", Priority: "Minor", IssueType: "Story"},
+		}
+		for i := range issues {
+			issues[i].Project = "PROJQUAY"
+			issues[i].FixVersion = "quay-v3.17.0"
+			issues[i].Link = "https://redhat.atlassian.net/browse/" + issues[i].Key
+			issues[i].UpdatedAt = now
+			if err := tx.UpsertJiraIssue(ctx, &issues[i]); err != nil {
+				return fmt.Errorf("seed issue %s: %w", issues[i].Key, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// SaveSnapshotRelease stores a Konflux Release of the snapshot it names,
+// replacing what was stored for a release of the same name. It returns
+// ErrNotFound if the snapshot is not stored.
+func (d *DB) SaveSnapshotRelease(ctx context.Context, r *model.SnapshotRelease) error {
+	snap, err := d.queries().GetSnapshotRow(ctx, r.Snapshot)
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", r.Snapshot, classify(err))
+	}
+	return classify(d.queries().UpsertSnapshotRelease(ctx, dbsqlc.UpsertSnapshotReleaseParams{
+		SnapshotID:     snap.ID,
+		Name:           r.Name,
+		ReleasePlan:    r.ReleasePlan,
+		Target:         r.Target,
+		Status:         r.Status,
+		Reason:         r.Reason,
+		Message:        r.Message,
+		StartTime:      formatOptionalTime(r.StartTime),
+		CompletionTime: formatOptionalTime(r.CompletionTime),
+	}))
+}
+
+// ListSnapshotReleases returns the Konflux Releases of a snapshot, oldest
+// first.
+func (d *DB) ListSnapshotReleases(ctx context.Context, snapshotID int64, snapshot string) ([]model.SnapshotRelease, error) {
+	rows, err := d.queries().ListSnapshotReleases(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]model.SnapshotRelease, len(rows))
+	for i, r := range rows {
+		releases[i] = model.SnapshotRelease{
+			Name:           r.Name,
+			Snapshot:       snapshot,
+			ReleasePlan:    r.ReleasePlan,
+			Target:         r.Target,
+			Status:         r.Status,
+			Reason:         r.Reason,
+			Message:        r.Message,
+			StartTime:      parseOptionalTime(r.StartTime),
+			CompletionTime: parseOptionalTime(r.CompletionTime),
+		}
+	}
+	return releases, nil
+}
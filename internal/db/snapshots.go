@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/quay/release-readiness/internal/db/sqlc"
@@ -9,24 +10,214 @@ import (
 )
 
 func (d *DB) CreateSnapshot(ctx context.Context, application, name string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error) {
+	return d.createSnapshot(ctx, application, name, "", testsPassed, createdAt)
+}
+
+func (d *DB) createSnapshot(ctx context.Context, application, name, source string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error) {
 	id, err := d.queries().CreateSnapshot(ctx, dbsqlc.CreateSnapshotParams{
 		Application: application,
 		Name:        name,
 		TestsPassed: boolToInt64(testsPassed),
 		CreatedAt:   createdAt.UTC().Format(time.RFC3339),
+		Source:      source,
 	})
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return &model.SnapshotRecord{
 		ID:          id,
 		Application: application,
 		Name:        name,
+		Source:      source,
+		Lifecycle:   model.LifecycleNew,
 		TestsPassed: testsPassed,
 		CreatedAt:   createdAt.UTC(),
 	}, nil
 }
 
+// SaveSnapshot stores snap with its components, test suites and cases, and
+// vulnerability reports in one transaction, so that a snapshot is stored
+// either completely or not at all. Each distinct insert is prepared once.
+// The IDs of the new snapshot, suites and reports are set on snap.
+func (d *DB) SaveSnapshot(ctx context.Context, snap *model.SnapshotRecord) error {
+	return d.InTx(ctx, func(tx *DB) error {
+		prepared := newPreparedDBTX(tx.dbtx)
+		defer func() { _ = prepared.close() }()
+		tx.dbtx = prepared
+		return tx.saveSnapshot(ctx, snap)
+	})
+}
+
+func (d *DB) saveSnapshot(ctx context.Context, snap *model.SnapshotRecord) error {
+	rec, err := d.createSnapshot(ctx, snap.Application, snap.Name, snap.Source, snap.TestsPassed, snap.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create snapshot: %w", err)
+	}
+	snap.ID, snap.CreatedAt = rec.ID, rec.CreatedAt
+
+	for i := range snap.Components {
+		c := &snap.Components[i]
+		if _, err := d.EnsureComponent(ctx, c.Component); err != nil {
+			return fmt.Errorf("ensure component %s: %w", c.Component, err)
+		}
+		c.SnapshotID = snap.ID
+		if err := d.CreateSnapshotComponent(ctx, snap.ID, c.Component, c.GitSHA, c.ImageURL, c.GitURL); err != nil {
+			return fmt.Errorf("create snapshot component %s: %w", c.Component, err)
+		}
+		if c.SBOM != nil {
+			if err := d.createComponentSBOM(ctx, snap.ID, c.Component, c.SBOM); err != nil {
+				return fmt.Errorf("create sbom of %s: %w", c.Component, err)
+			}
+		}
+	}
+
+	if err := d.createTestSuites(ctx, snap.ID, snap.TestSuites); err != nil {
+		return err
+	}
+	snap.HasTests = len(snap.TestSuites) > 0
+
+	for i := range snap.VulnerabilityReports {
+		r := &snap.VulnerabilityReports[i]
+		id, err := d.CreateVulnerabilityReport(ctx, snap.ID, r.Component, r.Arch,
+			r.Total, r.Critical, r.High, r.Medium, r.Low, r.Unknown, r.Fixable)
+		if err != nil {
+			return fmt.Errorf("create vulnerability report %s/%s: %w", r.Component, r.Arch, err)
+		}
+		r.ID, r.SnapshotID = id, snap.ID
+		for j := range r.Vulnerabilities {
+			v := &r.Vulnerabilities[j]
+			v.ReportID = id
+			if err := d.CreateVulnerability(ctx, id,
+				v.Name, v.Severity, v.PackageName, v.PackageVersion,
+				v.FixedInVersion, v.Description, v.Link); err != nil {
+				return fmt.Errorf("create vulnerability %s: %w", v.Name, err)
+			}
+		}
+	}
+
+	for i := range snap.ECResults {
+		r := &snap.ECResults[i]
+		if err := d.createECResult(ctx, snap.ID, r); err != nil {
+			return fmt.Errorf("create EC result %s: %w", r.Component, err)
+		}
+	}
+	return nil
+}
+
+// createTestSuites stores suites and their cases under the snapshot with
+// snapshotID, setting their IDs.
+func (d *DB) createTestSuites(ctx context.Context, snapshotID int64, suites []model.TestSuite) error {
+	for i := range suites {
+		s := &suites[i]
+		id, err := d.CreateTestSuite(ctx, snapshotID,
+			s.Name, s.Status, s.PipelineRun, s.ToolName, s.ToolVersion,
+			s.Tests, s.Passed, s.Failed, s.Skipped, s.Pending, s.Other, s.Flaky,
+			s.StartTime, s.StopTime, s.DurationMs, s.Truncated)
+		if err != nil {
+			return fmt.Errorf("create test suite %s: %w", s.Name, err)
+		}
+		s.ID, s.SnapshotID = id, snapshotID
+		for j := range s.TestCases {
+			tc := &s.TestCases[j]
+			tc.TestSuiteID = id
+			if err := d.CreateTestCase(ctx, id,
+				tc.Name, tc.Status, tc.DurationMs,
+				tc.Message, tc.Trace, tc.FilePath, tc.Suite,
+				tc.Retries, tc.Flaky); err != nil {
+				return fmt.Errorf("create test case %s: %w", tc.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RefreshTestSuites brings the stored test suites of the snapshot with
+// snapshotID up to date with suites, re-read from its object store: new
+// suites are added and suites whose results changed are replaced, while
+// unchanged ones are kept with their resolved PipelineRuns. Stored suites
+// missing from suites are kept too. A replaced suite whose status changed
+// is recorded as a scenario transition. The snapshot's tests_passed is
+// then recomputed. It returns the names of the suites added or replaced.
+func (d *DB) RefreshTestSuites(ctx context.Context, snapshotID int64, suites []model.TestSuite) ([]string, error) {
+	var updated []string
+	err := d.InTx(ctx, func(tx *DB) error {
+		stored, err := tx.ListTestSuites(ctx, snapshotID)
+		if err != nil {
+			return err
+		}
+		byName := make(map[string]model.TestSuite, len(stored))
+		for _, s := range stored {
+			byName[s.Name] = s
+		}
+		var changed []model.TestSuite
+		for _, s := range suites {
+			old, ok := byName[s.Name]
+			if ok && sameResults(old, s) {
+				continue
+			}
+			if ok {
+				if err := tx.queries().DeleteTestSuite(ctx, old.ID); err != nil {
+					return fmt.Errorf("delete test suite %s: %w", s.Name, err)
+				}
+			}
+			if ok && old.Status != s.Status {
+				if err := tx.queries().CreateScenarioTransition(ctx, dbsqlc.CreateScenarioTransitionParams{
+					SnapshotID: snapshotID,
+					Scenario:   s.Name,
+					FromStatus: old.Status,
+					ToStatus:   s.Status,
+					ChangedAt:  time.Now().UTC().Format(time.RFC3339),
+				}); err != nil {
+					return fmt.Errorf("record transition of %s: %w", s.Name, err)
+				}
+			}
+			changed = append(changed, s)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		if err := tx.createTestSuites(ctx, snapshotID, changed); err != nil {
+			return err
+		}
+		if err := tx.queries().RecomputeSnapshotTestsPassed(ctx, snapshotID); err != nil {
+			return err
+		}
+		updated = make([]string, len(changed))
+		for i, s := range changed {
+			updated[i] = s.Name
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// sameResults reports whether two reports of a test suite have the same
+// results.
+func sameResults(a, b model.TestSuite) bool {
+	return a.Status == b.Status && a.PipelineRun == b.PipelineRun &&
+		a.Tests == b.Tests && a.Passed == b.Passed && a.Failed == b.Failed &&
+		a.Skipped == b.Skipped && a.Pending == b.Pending && a.Other == b.Other && a.Flaky == b.Flaky &&
+		a.StartTime == b.StartTime && a.StopTime == b.StopTime && a.Truncated == b.Truncated
+}
+
+// ListSnapshotsSince returns the snapshots synced from source that were
+// stored at or after since, newest first. Components, suites and reports
+// are not loaded.
+func (d *DB) ListSnapshotsSince(ctx context.Context, source string, since time.Time) ([]model.SnapshotRecord, error) {
+	rows, err := d.queries().ListSnapshotsSince(ctx, dbsqlc.ListSnapshotsSinceParams{
+		CreatedAt: since.UTC().Format(time.RFC3339),
+		Source:    source,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snaps := make([]model.SnapshotRecord, len(rows))
+	for i, r := range rows {
+		snaps[i] = toSnapshotRecord(r)
+	}
+	return snaps, nil
+}
+
 func (d *DB) SnapshotExistsByName(ctx context.Context, name string) (bool, error) {
 	count, err := d.queries().SnapshotExistsByName(ctx, name)
 	if err != nil {
@@ -35,10 +226,48 @@ func (d *DB) SnapshotExistsByName(ctx context.Context, name string) (bool, error
 	return count > 0, nil
 }
 
+// ListS3SyncStates returns the ETag of every snapshot.json the S3 syncer
+// has handled, keyed by object key.
+func (d *DB) ListS3SyncStates(ctx context.Context) (map[string]string, error) {
+	rows, err := d.queries().ListS3SyncStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	etags := make(map[string]string, len(rows))
+	for _, r := range rows {
+		etags[r.Key] = r.Etag
+	}
+	return etags, nil
+}
+
+// SaveS3SyncState records the ETag of the snapshot.json at key once the S3
+// syncer has handled it.
+func (d *DB) SaveS3SyncState(ctx context.Context, key, etag string) error {
+	return d.queries().UpsertS3SyncState(ctx, dbsqlc.UpsertS3SyncStateParams{
+		Key:      key,
+		Etag:     etag,
+		SyncedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
 func (d *DB) GetSnapshotByID(ctx context.Context, id int64) (*model.SnapshotRecord, error) {
 	row, err := d.queries().GetSnapshotByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
+	}
+	s := toSnapshotRecord(row)
+	return &s, nil
+}
+
+// LatestSnapshotBefore returns the newest snapshot of application created at
+// or before the given time.
+func (d *DB) LatestSnapshotBefore(ctx context.Context, application string, before time.Time) (*model.SnapshotRecord, error) {
+	row, err := d.queries().LatestSnapshotBefore(ctx, dbsqlc.LatestSnapshotBeforeParams{
+		Application: application,
+		CreatedAt:   before.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, classify(err)
 	}
 	s := toSnapshotRecord(row)
 	return &s, nil
@@ -47,7 +276,7 @@ func (d *DB) GetSnapshotByID(ctx context.Context, id int64) (*model.SnapshotReco
 func (d *DB) GetTestSuiteByID(ctx context.Context, id int64) (*model.TestSuiteMeta, error) {
 	row, err := d.queries().GetTestSuiteByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return &model.TestSuiteMeta{
 		ID:         row.ID,
@@ -59,14 +288,21 @@ func (d *DB) GetTestSuiteByID(ctx context.Context, id int64) (*model.TestSuiteMe
 func (d *DB) GetSnapshotByName(ctx context.Context, name string) (*model.SnapshotRecord, error) {
 	row, err := d.queries().GetSnapshotRow(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	s := toSnapshotRecord(row)
 
-	components, err := d.listSnapshotComponents(ctx, s.ID)
+	components, err := d.ListSnapshotComponents(ctx, s.ID)
 	if err != nil {
 		return nil, err
 	}
+	sboms, err := d.ListComponentSBOMs(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range components {
+		components[i].SBOM = sboms[components[i].Component]
+	}
 	s.Components = components
 
 	suites, err := d.ListTestSuites(ctx, s.ID)
@@ -80,9 +316,28 @@ func (d *DB) GetSnapshotByName(ctx context.Context, name string) (*model.Snapsho
 		}
 		suites[i].TestCases = cases
 	}
+	runs, err := d.listPipelineRuns(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i, suite := range suites {
+		suites[i].Run = runs[suite.ID]
+	}
 	s.TestSuites = suites
 	s.HasTests = len(suites) > 0
 
+	transitions, err := d.ListScenarioTransitions(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.ScenarioTransitions = transitions
+
+	lifecycle, err := d.ListLifecycleTransitions(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.LifecycleTransitions = lifecycle
+
 	vulnReports, err := d.ListVulnerabilityReports(ctx, s.ID)
 	if err != nil {
 		return nil, err
@@ -96,20 +351,32 @@ func (d *DB) GetSnapshotByName(ctx context.Context, name string) (*model.Snapsho
 	}
 	s.VulnerabilityReports = vulnReports
 
+	images, err := d.ListImageVerifications(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.ImageVerifications = images
+
+	releases, err := d.ListSnapshotReleases(ctx, s.ID, s.Name)
+	if err != nil {
+		return nil, err
+	}
+	s.Releases = releases
+
 	return &s, nil
 }
 
 func (d *DB) CreateSnapshotComponent(ctx context.Context, snapshotID int64, component, gitSHA, imageURL, gitURL string) error {
-	return d.queries().CreateSnapshotComponent(ctx, dbsqlc.CreateSnapshotComponentParams{
+	return classify(d.queries().CreateSnapshotComponent(ctx, dbsqlc.CreateSnapshotComponentParams{
 		SnapshotID: snapshotID,
 		Component:  component,
 		GitSha:     gitSHA,
 		ImageUrl:   imageURL,
 		GitUrl:     gitURL,
-	})
+	}))
 }
 
-func (d *DB) listSnapshotComponents(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error) {
+func (d *DB) ListSnapshotComponents(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error) {
 	rows, err := d.queries().ListSnapshotComponents(ctx, snapshotID)
 	if err != nil {
 		return nil, err
@@ -128,6 +395,19 @@ func (d *DB) listSnapshotComponents(ctx context.Context, snapshotID int64) ([]mo
 	return components, nil
 }
 
+// CountSnapshots returns the number of snapshots of application, or of all
+// applications if it is empty.
+func (d *DB) CountSnapshots(ctx context.Context, application string) (int, error) {
+	var n int64
+	var err error
+	if application != "" {
+		n, err = d.queries().CountSnapshotsByApplication(ctx, application)
+	} else {
+		n, err = d.queries().CountAllSnapshots(ctx)
+	}
+	return int(n), err
+}
+
 func (d *DB) ListSnapshots(ctx context.Context, application string, limit, offset int) ([]model.SnapshotRecord, error) {
 	var rows []dbsqlc.Snapshot
 	var err error
@@ -167,6 +447,12 @@ func (d *DB) LatestSnapshotPerApplication(ctx context.Context) ([]model.Applicat
 			TestsPassed: r.TestsPassed == 1,
 			HasTests:    r.TestCount > 0,
 			CreatedAt:   parseTime(r.CreatedAt),
+			ImageDigests: &model.ImageDigestSummary{
+				Components: int(r.ComponentCount),
+				Verified:   int(r.ImagesVerified),
+				Failed:     int(r.ImagesFailed),
+				Missing:    int(r.ImagesMissing),
+			},
 		}
 		summaries[i] = model.ApplicationSummary{
 			Application:    r.Application,
@@ -177,8 +463,8 @@ func (d *DB) LatestSnapshotPerApplication(ctx context.Context) ([]model.Applicat
 	return summaries, nil
 }
 
-func (d *DB) CreateTestSuite(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64) (int64, error) {
-	return d.queries().CreateTestSuite(ctx, dbsqlc.CreateTestSuiteParams{
+func (d *DB) CreateTestSuite(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64, truncated bool) (int64, error) {
+	id, err := d.queries().CreateTestSuite(ctx, dbsqlc.CreateTestSuiteParams{
 		SnapshotID:  snapshotID,
 		Name:        name,
 		Status:      status,
@@ -195,7 +481,9 @@ func (d *DB) CreateTestSuite(ctx context.Context, snapshotID int64, name, status
 		StartTime:   startTime,
 		StopTime:    stopTime,
 		DurationMs:  durationMs,
+		Truncated:   boolToInt64(truncated),
 	})
+	return id, classify(err)
 }
 
 func (d *DB) CreateTestCase(ctx context.Context, testSuiteID int64, name, status string, durationMs float64, message, trace, filePath, suite string, retries int, flaky bool) error {
@@ -239,6 +527,7 @@ func (d *DB) ListTestSuites(ctx context.Context, snapshotID int64) ([]model.Test
 			StopTime:    r.StopTime,
 			DurationMs:  r.DurationMs,
 			CreatedAt:   parseTime(r.CreatedAt),
+			Truncated:   r.Truncated == 1,
 		}
 	}
 	return suites, nil
@@ -269,7 +558,7 @@ func (d *DB) ListTestCases(ctx context.Context, testSuiteID int64) ([]model.Test
 }
 
 func (d *DB) CreateVulnerabilityReport(ctx context.Context, snapshotID int64, component, arch string, total, critical, high, medium, low, unknown, fixable int) (int64, error) {
-	return d.queries().CreateVulnerabilityReport(ctx, dbsqlc.CreateVulnerabilityReportParams{
+	id, err := d.queries().CreateVulnerabilityReport(ctx, dbsqlc.CreateVulnerabilityReportParams{
 		SnapshotID: snapshotID,
 		Component:  component,
 		Arch:       arch,
@@ -281,6 +570,7 @@ func (d *DB) CreateVulnerabilityReport(ctx context.Context, snapshotID int64, co
 		Unknown:    int64(unknown),
 		Fixable:    int64(fixable),
 	})
+	return id, classify(err)
 }
 
 func (d *DB) CreateVulnerability(ctx context.Context, reportID int64, name, severity, packageName, packageVersion, fixedInVersion, description, link string) error {
@@ -348,6 +638,8 @@ func toSnapshotRecord(r dbsqlc.Snapshot) model.SnapshotRecord {
 		ID:          r.ID,
 		Application: r.Application,
 		Name:        r.Name,
+		Source:      r.Source,
+		Lifecycle:   r.Lifecycle,
 		TestsPassed: r.TestsPassed == 1,
 		CreatedAt:   parseTime(r.CreatedAt),
 	}
@@ -9,8 +9,9 @@ import (
 	"context"
 )
 
-const createComponent = `-- name: CreateComponent :execlastid
+const createComponent = `-- name: CreateComponent :one
 INSERT INTO components (name, description) VALUES (?, ?)
+RETURNING id
 `
 
 type CreateComponentParams struct {
@@ -19,15 +20,14 @@ type CreateComponentParams struct {
 }
 
 func (q *Queries) CreateComponent(ctx context.Context, arg CreateComponentParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, createComponent, arg.Name, arg.Description)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	row := q.db.QueryRowContext(ctx, createComponent, arg.Name, arg.Description)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const getComponentByName = `-- name: GetComponentByName :one
-SELECT id, name, description, created_at FROM components WHERE name = ?
+SELECT id, name, description, created_at, jira_components FROM components WHERE name = ?
 `
 
 func (q *Queries) GetComponentByName(ctx context.Context, name string) (Component, error) {
@@ -38,12 +38,13 @@ func (q *Queries) GetComponentByName(ctx context.Context, name string) (Componen
 		&i.Name,
 		&i.Description,
 		&i.CreatedAt,
+		&i.JiraComponents,
 	)
 	return i, err
 }
 
 const listComponents = `-- name: ListComponents :many
-SELECT id, name, description, created_at FROM components ORDER BY name
+SELECT id, name, description, created_at, jira_components FROM components ORDER BY name
 `
 
 func (q *Queries) ListComponents(ctx context.Context) ([]Component, error) {
@@ -60,6 +61,7 @@ func (q *Queries) ListComponents(ctx context.Context) ([]Component, error) {
 			&i.Name,
 			&i.Description,
 			&i.CreatedAt,
+			&i.JiraComponents,
 		); err != nil {
 			return nil, err
 		}
@@ -73,3 +75,20 @@ func (q *Queries) ListComponents(ctx context.Context) ([]Component, error) {
 	}
 	return items, nil
 }
+
+const setComponentJiraComponents = `-- name: SetComponentJiraComponents :execrows
+UPDATE components SET jira_components = ? WHERE name = ?
+`
+
+type SetComponentJiraComponentsParams struct {
+	JiraComponents string
+	Name           string
+}
+
+func (q *Queries) SetComponentJiraComponents(ctx context.Context, arg SetComponentJiraComponentsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setComponentJiraComponents, arg.JiraComponents, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
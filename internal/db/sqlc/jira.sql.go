@@ -9,12 +9,65 @@ import (
 	"context"
 )
 
-const deleteAllJiraIssuesForVersion = `-- name: DeleteAllJiraIssuesForVersion :exec
-DELETE FROM jira_issues WHERE fix_version = ?
+const archiveReleaseIssues = `-- name: ArchiveReleaseIssues :exec
+INSERT INTO release_issue_archive (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components)
+SELECT key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM jira_issues WHERE fix_version = ?
 `
 
-func (q *Queries) DeleteAllJiraIssuesForVersion(ctx context.Context, fixVersion string) error {
-	_, err := q.db.ExecContext(ctx, deleteAllJiraIssuesForVersion, fixVersion)
+func (q *Queries) ArchiveReleaseIssues(ctx context.Context, fixVersion string) error {
+	_, err := q.db.ExecContext(ctx, archiveReleaseIssues, fixVersion)
+	return err
+}
+
+const countOpenCVEsBySeverity = `-- name: CountOpenCVEsBySeverity :many
+SELECT severity, CAST(COUNT(*) AS INTEGER) AS cnt
+FROM release_issues
+WHERE fix_version = ?
+  AND LOWER(status) NOT IN ('closed', 'verified', 'done')
+  AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+GROUP BY severity
+`
+
+type CountOpenCVEsBySeverityRow struct {
+	Severity string
+	Cnt      int64
+}
+
+func (q *Queries) CountOpenCVEsBySeverity(ctx context.Context, fixVersion string) ([]CountOpenCVEsBySeverityRow, error) {
+	rows, err := q.db.QueryContext(ctx, countOpenCVEsBySeverity, fixVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountOpenCVEsBySeverityRow
+	for rows.Next() {
+		var i CountOpenCVEsBySeverityRow
+		if err := rows.Scan(&i.Severity, &i.Cnt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteJiraIssue = `-- name: DeleteJiraIssue :exec
+DELETE FROM jira_issues WHERE key = ? AND fix_version = ?
+`
+
+type DeleteJiraIssueParams struct {
+	Key        string
+	FixVersion string
+}
+
+func (q *Queries) DeleteJiraIssue(ctx context.Context, arg DeleteJiraIssueParams) error {
+	_, err := q.db.ExecContext(ctx, deleteJiraIssue, arg.Key, arg.FixVersion)
 	return err
 }
 
@@ -24,17 +77,19 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS verified,
     CAST(COALESCE(SUM(CASE WHEN LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS open,
     CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%' THEN 1 ELSE 0 END), 0) AS INTEGER) AS cves,
-    CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END), 0) AS INTEGER) AS bugs
-FROM jira_issues
+    CAST(COALESCE(SUM(CASE WHEN LOWER(issue_type) = 'bug' THEN 1 ELSE 0 END), 0) AS INTEGER) AS bugs,
+    CAST(COALESCE(SUM(CASE WHEN blocker = 1 AND LOWER(status) NOT IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END), 0) AS INTEGER) AS open_blockers
+FROM release_issues
 WHERE fix_version = ?
 `
 
 type GetIssueSummaryRow struct {
-	Total    int64
-	Verified int64
-	Open     int64
-	Cves     int64
-	Bugs     int64
+	Total        int64
+	Verified     int64
+	Open         int64
+	Cves         int64
+	Bugs         int64
+	OpenBlockers int64
 }
 
 func (q *Queries) GetIssueSummary(ctx context.Context, fixVersion string) (GetIssueSummaryRow, error) {
@@ -46,12 +101,13 @@ func (q *Queries) GetIssueSummary(ctx context.Context, fixVersion string) (GetIs
 		&i.Open,
 		&i.Cves,
 		&i.Bugs,
+		&i.OpenBlockers,
 	)
 	return i, err
 }
 
 const getReleaseVersion = `-- name: GetReleaseVersion :one
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions WHERE name = ?
 `
 
@@ -65,6 +121,7 @@ type GetReleaseVersionRow struct {
 	ReleaseTicketAssignee string
 	S3Application         string
 	DueDate               string
+	Hidden                int64
 }
 
 func (q *Queries) GetReleaseVersion(ctx context.Context, name string) (GetReleaseVersionRow, error) {
@@ -80,14 +137,15 @@ func (q *Queries) GetReleaseVersion(ctx context.Context, name string) (GetReleas
 		&i.ReleaseTicketAssignee,
 		&i.S3Application,
 		&i.DueDate,
+		&i.Hidden,
 	)
 	return i, err
 }
 
 const listActiveReleaseVersions = `-- name: ListActiveReleaseVersions :many
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions
-WHERE released = 0 AND archived = 0
+WHERE released = 0 AND archived = 0 AND hidden = 0
 ORDER BY name
 `
 
@@ -101,6 +159,7 @@ type ListActiveReleaseVersionsRow struct {
 	ReleaseTicketAssignee string
 	S3Application         string
 	DueDate               string
+	Hidden                int64
 }
 
 func (q *Queries) ListActiveReleaseVersions(ctx context.Context) ([]ListActiveReleaseVersionsRow, error) {
@@ -122,6 +181,7 @@ func (q *Queries) ListActiveReleaseVersions(ctx context.Context) ([]ListActiveRe
 			&i.ReleaseTicketAssignee,
 			&i.S3Application,
 			&i.DueDate,
+			&i.Hidden,
 		); err != nil {
 			return nil, err
 		}
@@ -137,7 +197,7 @@ func (q *Queries) ListActiveReleaseVersions(ctx context.Context) ([]ListActiveRe
 }
 
 const listAllReleaseVersions = `-- name: ListAllReleaseVersions :many
-SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date
+SELECT name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date, hidden
 FROM release_versions
 ORDER BY name
 `
@@ -152,6 +212,7 @@ type ListAllReleaseVersionsRow struct {
 	ReleaseTicketAssignee string
 	S3Application         string
 	DueDate               string
+	Hidden                int64
 }
 
 func (q *Queries) ListAllReleaseVersions(ctx context.Context) ([]ListAllReleaseVersionsRow, error) {
@@ -173,6 +234,150 @@ func (q *Queries) ListAllReleaseVersions(ctx context.Context) ([]ListAllReleaseV
 			&i.ReleaseTicketAssignee,
 			&i.S3Application,
 			&i.DueDate,
+			&i.Hidden,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHiddenReleaseVersions = `-- name: ListHiddenReleaseVersions :many
+SELECT name FROM release_versions WHERE hidden = 1 ORDER BY name
+`
+
+func (q *Queries) ListHiddenReleaseVersions(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listHiddenReleaseVersions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJiraIssueVersions = `-- name: ListJiraIssueVersions :many
+SELECT fix_version FROM jira_issues WHERE key = ? ORDER BY fix_version
+`
+
+func (q *Queries) ListJiraIssueVersions(ctx context.Context, key string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listJiraIssueVersions, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fix_version string
+		if err := rows.Scan(&fix_version); err != nil {
+			return nil, err
+		}
+		items = append(items, fix_version)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJiraSyncStates = `-- name: ListJiraSyncStates :many
+SELECT fix_version, synced_at, reconciled_at FROM jira_sync_states
+`
+
+func (q *Queries) ListJiraSyncStates(ctx context.Context) ([]JiraSyncState, error) {
+	rows, err := q.db.QueryContext(ctx, listJiraSyncStates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JiraSyncState
+	for rows.Next() {
+		var i JiraSyncState
+		if err := rows.Scan(&i.FixVersion, &i.SyncedAt, &i.ReconciledAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleaseCVEs = `-- name: ListReleaseCVEs :many
+SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM release_issues
+WHERE fix_version = ?
+  AND (LOWER(issue_type) = 'vulnerability' OR LOWER(labels) LIKE '%cve%')
+ORDER BY
+    CASE severity
+        WHEN 'Critical' THEN 0
+        WHEN 'Important' THEN 1
+        WHEN 'Moderate' THEN 2
+        WHEN 'Low' THEN 3
+        ELSE 4
+    END,
+    cvss_score DESC,
+    key
+`
+
+func (q *Queries) ListReleaseCVEs(ctx context.Context, fixVersion string) ([]ReleaseIssue, error) {
+	rows, err := q.db.QueryContext(ctx, listReleaseCVEs, fixVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReleaseIssue
+	for rows.Next() {
+		var i ReleaseIssue
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.Summary,
+			&i.Status,
+			&i.Priority,
+			&i.Labels,
+			&i.FixVersion,
+			&i.Assignee,
+			&i.IssueType,
+			&i.Resolution,
+			&i.Link,
+			&i.QaContact,
+			&i.UpdatedAt,
+			&i.Severity,
+			&i.Clones,
+			&i.Project,
+			&i.CveID,
+			&i.CvssScore,
+			&i.Embargoed,
+			&i.Blocker,
+			&i.Components,
 		); err != nil {
 			return nil, err
 		}
@@ -187,9 +392,43 @@ func (q *Queries) ListAllReleaseVersions(ctx context.Context) ([]ListAllReleaseV
 	return items, nil
 }
 
+const markReleaseIssuesArchived = `-- name: MarkReleaseIssuesArchived :execrows
+UPDATE release_versions SET issues_archived_at = ? WHERE name = ? AND issues_archived_at = ''
+`
+
+type MarkReleaseIssuesArchivedParams struct {
+	IssuesArchivedAt string
+	Name             string
+}
+
+func (q *Queries) MarkReleaseIssuesArchived(ctx context.Context, arg MarkReleaseIssuesArchivedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markReleaseIssuesArchived, arg.IssuesArchivedAt, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setReleaseVersionHidden = `-- name: SetReleaseVersionHidden :execrows
+UPDATE release_versions SET hidden = ? WHERE name = ?
+`
+
+type SetReleaseVersionHiddenParams struct {
+	Hidden int64
+	Name   string
+}
+
+func (q *Queries) SetReleaseVersionHidden(ctx context.Context, arg SetReleaseVersionHiddenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setReleaseVersionHidden, arg.Hidden, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const upsertJiraIssue = `-- name: UpsertJiraIssue :exec
-INSERT INTO jira_issues (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO jira_issues (key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(key, fix_version) DO UPDATE SET
     summary=excluded.summary,
     status=excluded.status,
@@ -200,7 +439,15 @@ ON CONFLICT(key, fix_version) DO UPDATE SET
     resolution=excluded.resolution,
     link=excluded.link,
     qa_contact=excluded.qa_contact,
-    updated_at=excluded.updated_at
+    updated_at=excluded.updated_at,
+    severity=excluded.severity,
+    clones=excluded.clones,
+    project=excluded.project,
+    cve_id=excluded.cve_id,
+    cvss_score=excluded.cvss_score,
+    embargoed=excluded.embargoed,
+    blocker=excluded.blocker,
+    components=excluded.components
 `
 
 type UpsertJiraIssueParams struct {
@@ -216,6 +463,14 @@ type UpsertJiraIssueParams struct {
 	Link       string
 	QaContact  string
 	UpdatedAt  string
+	Severity   string
+	Clones     string
+	Project    string
+	CveID      string
+	CvssScore  float64
+	Embargoed  int64
+	Blocker    int64
+	Components string
 }
 
 func (q *Queries) UpsertJiraIssue(ctx context.Context, arg UpsertJiraIssueParams) error {
@@ -232,10 +487,37 @@ func (q *Queries) UpsertJiraIssue(ctx context.Context, arg UpsertJiraIssueParams
 		arg.Link,
 		arg.QaContact,
 		arg.UpdatedAt,
+		arg.Severity,
+		arg.Clones,
+		arg.Project,
+		arg.CveID,
+		arg.CvssScore,
+		arg.Embargoed,
+		arg.Blocker,
+		arg.Components,
 	)
 	return err
 }
 
+const upsertJiraSyncState = `-- name: UpsertJiraSyncState :exec
+INSERT INTO jira_sync_states (fix_version, synced_at, reconciled_at)
+VALUES (?, ?, ?)
+ON CONFLICT(fix_version) DO UPDATE SET
+    synced_at=excluded.synced_at,
+    reconciled_at=excluded.reconciled_at
+`
+
+type UpsertJiraSyncStateParams struct {
+	FixVersion   string
+	SyncedAt     string
+	ReconciledAt string
+}
+
+func (q *Queries) UpsertJiraSyncState(ctx context.Context, arg UpsertJiraSyncStateParams) error {
+	_, err := q.db.ExecContext(ctx, upsertJiraSyncState, arg.FixVersion, arg.SyncedAt, arg.ReconciledAt)
+	return err
+}
+
 const upsertReleaseVersion = `-- name: UpsertReleaseVersion :exec
 INSERT INTO release_versions (name, description, release_date, released, archived, release_ticket_key, release_ticket_assignee, s3_application, due_date)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
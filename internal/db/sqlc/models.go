@@ -4,11 +4,136 @@
 
 package dbsqlc
 
+type Application struct {
+	Name              string
+	DisplayName       string
+	Product           string
+	FixVersions       string
+	RetentionSet      int64
+	RetentionMaxCount int64
+	RetentionMaxAge   string
+	UpdatedAt         string
+}
+
 type Component struct {
-	ID          int64
-	Name        string
-	Description string
-	CreatedAt   string
+	ID             int64
+	Name           string
+	Description    string
+	CreatedAt      string
+	JiraComponents string
+}
+
+type ComponentSbom struct {
+	SnapshotID int64
+	Component  string
+	Reference  string
+	Format     string
+	Packages   int64
+	Licenses   string
+}
+
+type DigestState struct {
+	Frequency       string
+	Release         string
+	SentAt          string
+	Blockers        string
+	FailedScenarios string
+}
+
+type DigestSubscription struct {
+	ID        int64
+	Email     string
+	Release   string
+	Frequency string
+	CreatedBy string
+	CreatedAt string
+}
+
+type EcFinding struct {
+	ID       int64
+	ResultID int64
+	Kind     string
+	Code     string
+	Title    string
+	Message  string
+	Solution string
+}
+
+type EcResult struct {
+	ID         int64
+	SnapshotID int64
+	Component  string
+	ImageUrl   string
+	Success    int64
+}
+
+type GitRange struct {
+	Repo         string
+	FromSha      string
+	ToSha        string
+	FetchedAt    string
+	TotalCommits int64
+}
+
+type GitRangeCommit struct {
+	Repo     string
+	FromSha  string
+	ToSha    string
+	Position int64
+	Sha      string
+	Subject  string
+	Author   string
+	Url      string
+}
+
+type GitRangePullRequest struct {
+	Repo     string
+	FromSha  string
+	ToSha    string
+	Number   int64
+	Title    string
+	Author   string
+	Url      string
+	MergedAt string
+}
+
+type ImageVerification struct {
+	ID         int64
+	SnapshotID int64
+	Component  string
+	ImageUrl   string
+	Tag        string
+	Status     string
+	Message    string
+	CheckedAt  string
+}
+
+type IngestFailure struct {
+	ID            int64
+	Source        string
+	Key           string
+	Application   string
+	Snapshot      string
+	Attempts      int64
+	LastError     string
+	Dead          int64
+	FirstFailedAt string
+	LastFailedAt  string
+}
+
+type IssueBucket struct {
+	ID     int64
+	Name   string
+	Labels string
+}
+
+type IssueScopeChange struct {
+	ID         int64
+	FixVersion string
+	IssueKey   string
+	Summary    string
+	Change     string
+	ChangedAt  string
 }
 
 type JiraIssue struct {
@@ -25,6 +150,190 @@ type JiraIssue struct {
 	Link       string
 	QaContact  string
 	UpdatedAt  string
+	Severity   string
+	Clones     string
+	Project    string
+	CveID      string
+	CvssScore  float64
+	Embargoed  int64
+	Blocker    int64
+	Components string
+}
+
+type JiraIssueBlocker struct {
+	IssueKey       string
+	BlockerKey     string
+	BlockerSummary string
+	BlockerStatus  string
+	BlockerLink    string
+}
+
+type JiraSubtask struct {
+	ParentKey  string
+	SubtaskKey string
+	Summary    string
+	Status     string
+	Link       string
+}
+
+type JiraSyncState struct {
+	FixVersion   string
+	SyncedAt     string
+	ReconciledAt string
+}
+
+type NotificationState struct {
+	Release        string
+	Signal         string
+	BlockingCves   int64
+	FailedSnapshot string
+}
+
+type PipelineRun struct {
+	ID             int64
+	TestSuiteID    int64
+	Namespace      string
+	Name           string
+	Status         string
+	Reason         string
+	Message        string
+	StartTime      string
+	CompletionTime string
+	ResolvedAt     string
+}
+
+type PipelineRunTask struct {
+	ID             int64
+	PipelineRunID  int64
+	Name           string
+	Status         string
+	Reason         string
+	Message        string
+	StartTime      string
+	CompletionTime string
+}
+
+type ReadinessOverride struct {
+	ID        int64
+	Release   string
+	Rule      string
+	Reason    string
+	CreatedBy string
+	CreatedAt string
+	ExpiresAt string
+}
+
+type ReleaseAdvisory struct {
+	Release    string
+	AdvisoryID int64
+	Name       string
+	Type       string
+	State      string
+	Synopsis   string
+	Builds     string
+	Cves       string
+	SyncedAt   string
+	SyncError  string
+}
+
+type ReleaseApproval struct {
+	ID        int64
+	Release   string
+	Approver  string
+	Role      string
+	Comment   string
+	CreatedAt string
+}
+
+type ReleaseAudit struct {
+	ID           int64
+	Release      string
+	SnapshotName string
+	AuditedAt    string
+}
+
+type ReleaseAuditFinding struct {
+	ID        int64
+	AuditID   int64
+	Component string
+	Kind      string
+	Expected  string
+	Actual    string
+	Message   string
+}
+
+type ReleaseAuditHold struct {
+	Release   string
+	Reason    string
+	CreatedAt string
+}
+
+type ReleaseCandidate struct {
+	Release    string
+	SnapshotID int64
+	State      string
+	ChangedAt  string
+}
+
+type ReleaseIssue struct {
+	ID         int64
+	Key        string
+	Summary    string
+	Status     string
+	Priority   string
+	Labels     string
+	FixVersion string
+	Assignee   string
+	IssueType  string
+	Resolution string
+	Link       string
+	QaContact  string
+	UpdatedAt  string
+	Severity   string
+	Clones     string
+	Project    string
+	CveID      string
+	CvssScore  float64
+	Embargoed  int64
+	Blocker    int64
+	Components string
+}
+
+type ReleaseIssueArchive struct {
+	ID         int64
+	Key        string
+	Summary    string
+	Status     string
+	Priority   string
+	Labels     string
+	FixVersion string
+	Assignee   string
+	IssueType  string
+	Resolution string
+	Link       string
+	QaContact  string
+	UpdatedAt  string
+	Severity   string
+	Clones     string
+	Project    string
+	CveID      string
+	CvssScore  float64
+	Embargoed  int64
+	Blocker    int64
+	Components string
+}
+
+type ReleaseReadinessHistory struct {
+	ID           int64
+	Release      string
+	Signal       string
+	Total        int64
+	Open         int64
+	Verified     int64
+	Cves         int64
+	Bugs         int64
+	BlockingCves int64
+	RecordedAt   string
 }
 
 type ReleaseVersion struct {
@@ -38,6 +347,30 @@ type ReleaseVersion struct {
 	ReleaseTicketAssignee string
 	S3Application         string
 	DueDate               string
+	IssuesArchivedAt      string
+	Hidden                int64
+}
+
+type S3SyncState struct {
+	Key      string
+	Etag     string
+	SyncedAt string
+}
+
+type ScenarioRequirement struct {
+	Application string
+	Scenario    string
+	Required    int64
+	UpdatedAt   string
+}
+
+type ScenarioTransition struct {
+	ID         int64
+	SnapshotID int64
+	Scenario   string
+	FromStatus string
+	ToStatus   string
+	ChangedAt  string
 }
 
 type Snapshot struct {
@@ -46,6 +379,8 @@ type Snapshot struct {
 	Name        string
 	TestsPassed int64
 	CreatedAt   string
+	Source      string
+	Lifecycle   string
 }
 
 type SnapshotComponent struct {
@@ -57,6 +392,29 @@ type SnapshotComponent struct {
 	GitUrl     string
 }
 
+type SnapshotLifecycleTransition struct {
+	ID         int64
+	SnapshotID int64
+	FromState  string
+	ToState    string
+	Reason     string
+	ChangedBy  string
+	ChangedAt  string
+}
+
+type SnapshotRelease struct {
+	ID             int64
+	SnapshotID     int64
+	Name           string
+	ReleasePlan    string
+	Target         string
+	Status         string
+	Reason         string
+	Message        string
+	StartTime      string
+	CompletionTime string
+}
+
 type TestCase struct {
 	ID          int64
 	TestSuiteID int64
@@ -90,6 +448,7 @@ type TestSuite struct {
 	StopTime    int64
 	DurationMs  int64
 	CreatedAt   string
+	Truncated   int64
 }
 
 type Vulnerability struct {
@@ -118,3 +477,9 @@ type VulnerabilityReport struct {
 	Fixable    int64
 	CreatedAt  string
 }
+
+type WebhookState struct {
+	Release  string
+	Signal   string
+	Released int64
+}
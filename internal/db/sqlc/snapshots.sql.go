@@ -9,9 +9,32 @@ import (
 	"context"
 )
 
-const createSnapshot = `-- name: CreateSnapshot :execlastid
-INSERT INTO snapshots (application, name, tests_passed, created_at)
-VALUES (?, ?, ?, ?)
+const countAllSnapshots = `-- name: CountAllSnapshots :one
+SELECT COUNT(*) FROM snapshots
+`
+
+func (q *Queries) CountAllSnapshots(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAllSnapshots)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSnapshotsByApplication = `-- name: CountSnapshotsByApplication :one
+SELECT COUNT(*) FROM snapshots WHERE application = ?
+`
+
+func (q *Queries) CountSnapshotsByApplication(ctx context.Context, application string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSnapshotsByApplication, application)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createSnapshot = `-- name: CreateSnapshot :one
+INSERT INTO snapshots (application, name, tests_passed, created_at, source)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id
 `
 
 type CreateSnapshotParams struct {
@@ -19,19 +42,20 @@ type CreateSnapshotParams struct {
 	Name        string
 	TestsPassed int64
 	CreatedAt   string
+	Source      string
 }
 
 func (q *Queries) CreateSnapshot(ctx context.Context, arg CreateSnapshotParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, createSnapshot,
+	row := q.db.QueryRowContext(ctx, createSnapshot,
 		arg.Application,
 		arg.Name,
 		arg.TestsPassed,
 		arg.CreatedAt,
+		arg.Source,
 	)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const createSnapshotComponent = `-- name: CreateSnapshotComponent :exec
@@ -92,9 +116,10 @@ func (q *Queries) CreateTestCase(ctx context.Context, arg CreateTestCaseParams)
 	return err
 }
 
-const createTestSuite = `-- name: CreateTestSuite :execlastid
-INSERT INTO test_suites (snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+const createTestSuite = `-- name: CreateTestSuite :one
+INSERT INTO test_suites (snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, truncated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
 `
 
 type CreateTestSuiteParams struct {
@@ -114,10 +139,11 @@ type CreateTestSuiteParams struct {
 	StartTime   int64
 	StopTime    int64
 	DurationMs  int64
+	Truncated   int64
 }
 
 func (q *Queries) CreateTestSuite(ctx context.Context, arg CreateTestSuiteParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, createTestSuite,
+	row := q.db.QueryRowContext(ctx, createTestSuite,
 		arg.SnapshotID,
 		arg.Name,
 		arg.Status,
@@ -134,11 +160,11 @@ func (q *Queries) CreateTestSuite(ctx context.Context, arg CreateTestSuiteParams
 		arg.StartTime,
 		arg.StopTime,
 		arg.DurationMs,
+		arg.Truncated,
 	)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const createVulnerability = `-- name: CreateVulnerability :exec
@@ -171,9 +197,10 @@ func (q *Queries) CreateVulnerability(ctx context.Context, arg CreateVulnerabili
 	return err
 }
 
-const createVulnerabilityReport = `-- name: CreateVulnerabilityReport :execlastid
+const createVulnerabilityReport = `-- name: CreateVulnerabilityReport :one
 INSERT INTO vulnerability_reports (snapshot_id, component, arch, total, critical, high, medium, low, unknown, fixable)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
 `
 
 type CreateVulnerabilityReportParams struct {
@@ -190,7 +217,7 @@ type CreateVulnerabilityReportParams struct {
 }
 
 func (q *Queries) CreateVulnerabilityReport(ctx context.Context, arg CreateVulnerabilityReportParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, createVulnerabilityReport,
+	row := q.db.QueryRowContext(ctx, createVulnerabilityReport,
 		arg.SnapshotID,
 		arg.Component,
 		arg.Arch,
@@ -202,14 +229,22 @@ func (q *Queries) CreateVulnerabilityReport(ctx context.Context, arg CreateVulne
 		arg.Unknown,
 		arg.Fixable,
 	)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteTestSuite = `-- name: DeleteTestSuite :exec
+DELETE FROM test_suites WHERE id = ?
+`
+
+func (q *Queries) DeleteTestSuite(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTestSuite, id)
+	return err
 }
 
 const getSnapshotByID = `-- name: GetSnapshotByID :one
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots WHERE id = ?
 `
 
@@ -222,12 +257,14 @@ func (q *Queries) GetSnapshotByID(ctx context.Context, id int64) (Snapshot, erro
 		&i.Name,
 		&i.TestsPassed,
 		&i.CreatedAt,
+		&i.Source,
+		&i.Lifecycle,
 	)
 	return i, err
 }
 
 const getSnapshotRow = `-- name: GetSnapshotRow :one
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots WHERE name = ?
 `
 
@@ -240,6 +277,8 @@ func (q *Queries) GetSnapshotRow(ctx context.Context, name string) (Snapshot, er
 		&i.Name,
 		&i.TestsPassed,
 		&i.CreatedAt,
+		&i.Source,
+		&i.Lifecycle,
 	)
 	return i, err
 }
@@ -261,9 +300,41 @@ func (q *Queries) GetTestSuiteByID(ctx context.Context, id int64) (GetTestSuiteB
 	return i, err
 }
 
+const latestSnapshotBefore = `-- name: LatestSnapshotBefore :one
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
+FROM snapshots
+WHERE application = ? AND created_at <= ?
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type LatestSnapshotBeforeParams struct {
+	Application string
+	CreatedAt   string
+}
+
+func (q *Queries) LatestSnapshotBefore(ctx context.Context, arg LatestSnapshotBeforeParams) (Snapshot, error) {
+	row := q.db.QueryRowContext(ctx, latestSnapshotBefore, arg.Application, arg.CreatedAt)
+	var i Snapshot
+	err := row.Scan(
+		&i.ID,
+		&i.Application,
+		&i.Name,
+		&i.TestsPassed,
+		&i.CreatedAt,
+		&i.Source,
+		&i.Lifecycle,
+	)
+	return i, err
+}
+
 const latestSnapshotPerApplication = `-- name: LatestSnapshotPerApplication :many
 SELECT s.id, s.application, s.name, s.tests_passed, s.created_at, CAST(counts.cnt AS INTEGER) AS cnt,
-       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count
+       (SELECT COUNT(*) FROM test_suites WHERE snapshot_id = s.id) AS test_count,
+       (SELECT COUNT(*) FROM snapshot_components WHERE snapshot_id = s.id) AS component_count,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'ok') AS images_verified,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status IN ('missing', 'mismatch')) AS images_failed,
+       (SELECT COUNT(*) FROM image_verifications WHERE snapshot_id = s.id AND status = 'missing') AS images_missing
 FROM snapshots s
 JOIN (
     SELECT application, MAX(id) AS max_id, COUNT(*) AS cnt
@@ -274,13 +345,17 @@ ORDER BY s.application
 `
 
 type LatestSnapshotPerApplicationRow struct {
-	ID          int64
-	Application string
-	Name        string
-	TestsPassed int64
-	CreatedAt   string
-	Cnt         int64
-	TestCount   int64
+	ID             int64
+	Application    string
+	Name           string
+	TestsPassed    int64
+	CreatedAt      string
+	Cnt            int64
+	TestCount      int64
+	ComponentCount int64
+	ImagesVerified int64
+	ImagesFailed   int64
+	ImagesMissing  int64
 }
 
 func (q *Queries) LatestSnapshotPerApplication(ctx context.Context) ([]LatestSnapshotPerApplicationRow, error) {
@@ -300,6 +375,10 @@ func (q *Queries) LatestSnapshotPerApplication(ctx context.Context) ([]LatestSna
 			&i.CreatedAt,
 			&i.Cnt,
 			&i.TestCount,
+			&i.ComponentCount,
+			&i.ImagesVerified,
+			&i.ImagesFailed,
+			&i.ImagesMissing,
 		); err != nil {
 			return nil, err
 		}
@@ -315,7 +394,7 @@ func (q *Queries) LatestSnapshotPerApplication(ctx context.Context) ([]LatestSna
 }
 
 const listAllSnapshots = `-- name: ListAllSnapshots :many
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots
 ORDER BY id DESC LIMIT ? OFFSET ?
 `
@@ -340,6 +419,8 @@ func (q *Queries) ListAllSnapshots(ctx context.Context, arg ListAllSnapshotsPara
 			&i.Name,
 			&i.TestsPassed,
 			&i.CreatedAt,
+			&i.Source,
+			&i.Lifecycle,
 		); err != nil {
 			return nil, err
 		}
@@ -354,6 +435,38 @@ func (q *Queries) ListAllSnapshots(ctx context.Context, arg ListAllSnapshotsPara
 	return items, nil
 }
 
+const listS3SyncStates = `-- name: ListS3SyncStates :many
+SELECT key, etag FROM s3_sync_states
+`
+
+type ListS3SyncStatesRow struct {
+	Key  string
+	Etag string
+}
+
+func (q *Queries) ListS3SyncStates(ctx context.Context) ([]ListS3SyncStatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listS3SyncStates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListS3SyncStatesRow
+	for rows.Next() {
+		var i ListS3SyncStatesRow
+		if err := rows.Scan(&i.Key, &i.Etag); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSnapshotComponents = `-- name: ListSnapshotComponents :many
 SELECT id, snapshot_id, component, git_sha, image_url, git_url
 FROM snapshot_components
@@ -392,7 +505,7 @@ func (q *Queries) ListSnapshotComponents(ctx context.Context, snapshotID int64)
 }
 
 const listSnapshotsByApplication = `-- name: ListSnapshotsByApplication :many
-SELECT id, application, name, tests_passed, created_at
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
 FROM snapshots
 WHERE application = ?
 ORDER BY id DESC LIMIT ? OFFSET ?
@@ -419,6 +532,51 @@ func (q *Queries) ListSnapshotsByApplication(ctx context.Context, arg ListSnapsh
 			&i.Name,
 			&i.TestsPassed,
 			&i.CreatedAt,
+			&i.Source,
+			&i.Lifecycle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSnapshotsSince = `-- name: ListSnapshotsSince :many
+SELECT id, application, name, tests_passed, created_at, source, lifecycle
+FROM snapshots
+WHERE created_at >= ? AND source = ?
+ORDER BY created_at DESC
+`
+
+type ListSnapshotsSinceParams struct {
+	CreatedAt string
+	Source    string
+}
+
+func (q *Queries) ListSnapshotsSince(ctx context.Context, arg ListSnapshotsSinceParams) ([]Snapshot, error) {
+	rows, err := q.db.QueryContext(ctx, listSnapshotsSince, arg.CreatedAt, arg.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Snapshot
+	for rows.Next() {
+		var i Snapshot
+		if err := rows.Scan(
+			&i.ID,
+			&i.Application,
+			&i.Name,
+			&i.TestsPassed,
+			&i.CreatedAt,
+			&i.Source,
+			&i.Lifecycle,
 		); err != nil {
 			return nil, err
 		}
@@ -476,7 +634,7 @@ func (q *Queries) ListTestCasesBySuite(ctx context.Context, testSuiteID int64) (
 }
 
 const listTestSuitesBySnapshot = `-- name: ListTestSuitesBySnapshot :many
-SELECT id, snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, created_at
+SELECT id, snapshot_id, name, status, pipeline_run, tool_name, tool_version, tests, passed, failed, skipped, pending, other, flaky, start_time, stop_time, duration_ms, created_at, truncated
 FROM test_suites
 WHERE snapshot_id = ?
 ORDER BY name
@@ -510,6 +668,7 @@ func (q *Queries) ListTestSuitesBySnapshot(ctx context.Context, snapshotID int64
 			&i.StopTime,
 			&i.DurationMs,
 			&i.CreatedAt,
+			&i.Truncated,
 		); err != nil {
 			return nil, err
 		}
@@ -625,3 +784,22 @@ func (q *Queries) SnapshotExistsByName(ctx context.Context, name string) (int64,
 	err := row.Scan(&count)
 	return count, err
 }
+
+const upsertS3SyncState = `-- name: UpsertS3SyncState :exec
+INSERT INTO s3_sync_states (key, etag, synced_at)
+VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET
+    etag=excluded.etag,
+    synced_at=excluded.synced_at
+`
+
+type UpsertS3SyncStateParams struct {
+	Key      string
+	Etag     string
+	SyncedAt string
+}
+
+func (q *Queries) UpsertS3SyncState(ctx context.Context, arg UpsertS3SyncStateParams) error {
+	_, err := q.db.ExecContext(ctx, upsertS3SyncState, arg.Key, arg.Etag, arg.SyncedAt)
+	return err
+}
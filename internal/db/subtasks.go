@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// replaceSubtasks stores the sub-tasks of an issue in place of those
+// recorded at its previous sync.
+func (d *DB) replaceSubtasks(ctx context.Context, issue *model.JiraIssueRecord) error {
+	q := d.queries()
+	if err := q.DeleteJiraSubtasks(ctx, issue.Key); err != nil {
+		return err
+	}
+	for _, t := range issue.Subtasks {
+		if err := q.CreateJiraSubtask(ctx, dbsqlc.CreateJiraSubtaskParams{
+			ParentKey:  issue.Key,
+			SubtaskKey: t.Key,
+			Summary:    t.Summary,
+			Status:     t.Status,
+			Link:       t.Link,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachSubtasks fills in the sub-tasks and their completion ratio of the
+// issues of fixVersion.
+func (d *DB) attachSubtasks(ctx context.Context, fixVersion string, issues []model.JiraIssueRecord) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	rows, err := d.queries().ListReleaseSubtasks(ctx, fixVersion)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	byParent := make(map[string][]model.IssueSubtask)
+	for _, r := range rows {
+		byParent[r.ParentKey] = append(byParent[r.ParentKey], model.IssueSubtask{
+			Key:     r.SubtaskKey,
+			Summary: r.Summary,
+			Status:  r.Status,
+			Link:    r.Link,
+		})
+	}
+	for i := range issues {
+		subtasks := byParent[issues[i].Key]
+		if len(subtasks) == 0 {
+			continue
+		}
+		done := 0
+		for _, t := range subtasks {
+			if t.Done() {
+				done++
+			}
+		}
+		ratio := float64(done) / float64(len(subtasks))
+		issues[i].Subtasks = subtasks
+		issues[i].SubtaskCompletion = &ratio
+	}
+	return nil
+}
+
+// countSubtasks fills in the sub-task counts of summaries, which are keyed
+// by fixVersion.
+// Stays hand-written due to variable IN clause.
+func (d *DB) countSubtasks(ctx context.Context, summaries map[string]*model.IssueSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(summaries))
+	args := make([]interface{}, 0, len(summaries))
+	for fixVersion := range summaries {
+		placeholders = append(placeholders, "?")
+		args = append(args, fixVersion)
+	}
+
+	query := `
+		SELECT fix_version, COUNT(*) AS total,
+			SUM(CASE WHEN LOWER(status) IN ('closed', 'verified', 'done') THEN 1 ELSE 0 END) AS done
+		FROM (
+			SELECT ri.fix_version, COALESCE(
+				(SELECT ji.status FROM jira_issues ji WHERE ji.key = t.subtask_key ORDER BY ji.updated_at DESC LIMIT 1),
+				t.status) AS status
+			FROM release_issues ri
+			JOIN jira_subtasks t ON t.parent_key = ri.key
+			WHERE ri.fix_version IN (` + strings.Join(placeholders, ",") + `)
+		) s
+		GROUP BY fix_version`
+
+	rows, err := d.dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var fixVersion string
+		var total, done int
+		if err := rows.Scan(&fixVersion, &total, &done); err != nil {
+			return err
+		}
+		if s := summaries[fixVersion]; s != nil {
+			s.Subtasks = total
+			s.SubtasksDone = done
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/tracing"
+)
+
+// tracingDBTX records a span for each query. Spans are named after the
+// sqlc query ("db GetSnapshot"); a query's rows are read after its span
+// ends, so the span covers running the query, not scanning its results.
+type tracingDBTX struct {
+	dbsqlc.DBTX
+	driver string
+}
+
+func (t tracingDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := t.start(ctx, query)
+	res, err := t.DBTX.ExecContext(ctx, query, args...)
+	span.RecordError(err)
+	span.End()
+	return res, err
+}
+
+func (t tracingDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := t.start(ctx, query)
+	span.SetAttributes(slog.String("db.operation", "prepare"))
+	stmt, err := t.DBTX.PrepareContext(ctx, query)
+	span.RecordError(err)
+	span.End()
+	return stmt, err
+}
+
+func (t tracingDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := t.start(ctx, query)
+	rows, err := t.DBTX.QueryContext(ctx, query, args...)
+	span.RecordError(err)
+	span.End()
+	return rows, err
+}
+
+func (t tracingDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := t.start(ctx, query)
+	row := t.DBTX.QueryRowContext(ctx, query, args...)
+	span.RecordError(row.Err())
+	span.End()
+	return row
+}
+
+func (t tracingDBTX) start(ctx context.Context, query string) (context.Context, *tracing.Span) {
+	if !tracing.Enabled() {
+		return ctx, nil
+	}
+	return tracing.StartClient(ctx, "db "+queryName(query),
+		slog.String("db.system", t.driver),
+		slog.String("db.statement", query))
+}
+
+// queryName returns the name from a sqlc query's "-- name: X :kind"
+// header, or "query" for hand-written SQL.
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "query"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
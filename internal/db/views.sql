@@ -0,0 +1,13 @@
+-- Views are recreated on every start, after columnMigrations have run, so
+-- they can use columns added by those migrations.
+
+-- release_issues is the issue set shown for each version: the archive for
+-- released versions whose issues were archived, live JIRA data otherwise.
+DROP VIEW IF EXISTS release_issues;
+CREATE VIEW release_issues AS
+SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM jira_issues
+WHERE fix_version NOT IN (SELECT name FROM release_versions WHERE issues_archived_at != '')
+UNION ALL
+SELECT id, key, summary, status, priority, labels, fix_version, assignee, issue_type, resolution, link, qa_contact, updated_at, severity, clones, project, cve_id, cvss_score, embargoed, blocker, components
+FROM release_issue_archive;
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+
+	"github.com/quay/release-readiness/internal/db/sqlc"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ListWebhookStates returns what outbound webhooks were last told of each
+// release, keyed by release name.
+func (d *DB) ListWebhookStates(ctx context.Context) (map[string]model.WebhookState, error) {
+	rows, err := d.queries().ListWebhookStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]model.WebhookState, len(rows))
+	for _, r := range rows {
+		states[r.Release] = model.WebhookState{
+			Release:  r.Release,
+			Signal:   r.Signal,
+			Released: r.Released != 0,
+		}
+	}
+	return states, nil
+}
+
+// SaveWebhookState records what outbound webhooks were told of a release.
+func (d *DB) SaveWebhookState(ctx context.Context, state model.WebhookState) error {
+	return d.queries().UpsertWebhookState(ctx, dbsqlc.UpsertWebhookStateParams{
+		Release:  state.Release,
+		Signal:   state.Signal,
+		Released: boolToInt64(state.Released),
+	})
+}
@@ -0,0 +1,295 @@
+// Package demo generates synthetic releases, snapshots, test results, and
+// issues so the dashboard can run without S3 or JIRA.
+package demo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/jira"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Store is the subset of the database layer needed by the generator.
+type Store interface {
+	UpsertReleaseVersion(ctx context.Context, v *model.ReleaseVersion) error
+	UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord) error
+	CreateSnapshot(ctx context.Context, application, name string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error)
+	EnsureComponent(ctx context.Context, name string) (*model.Component, error)
+	CreateSnapshotComponent(ctx context.Context, snapshotID int64, component, gitSHA, imageURL, gitURL string) error
+	CreateTestSuite(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64, truncated bool) (int64, error)
+	CreateTestCase(ctx context.Context, testSuiteID int64, name, status string, durationMs float64, message, trace, filePath, suite string, retries int, flaky bool) error
+}
+
+// TxFunc wraps a function in a database transaction, passing a tx-scoped Store.
+type TxFunc func(ctx context.Context, fn func(Store) error) error
+
+type product struct {
+	name       string
+	components []string
+	releases   []string // fixVersions, oldest first; all but the last two are released
+}
+
+var products = []product{
+	{
+		name:       "quay",
+		components: []string{"quay", "clair", "quay-builder", "quay-operator"},
+		releases:   []string{"quay-v3.15.4", "quay-v3.16.2", "quay-v3.16.3", "quay-v3.17.0"},
+	},
+	{
+		name:       "omr",
+		components: []string{"mirror-registry"},
+		releases:   []string{"omr-v2.0.9", "omr-v2.0.10"},
+	},
+}
+
+var scenarios = []struct {
+	name  string
+	cases []string
+}{
+	{"api-tests", []string{"test_login", "test_push_pull", "test_delete_tag", "test_robot_accounts", "test_quota_enforcement"}},
+	{"ui-tests", []string{"renders repository list", "creates organization", "edits team permissions", "shows security scan"}},
+	{"upgrade-tests", []string{"upgrade from previous minor", "upgrade from previous z-stream"}},
+}
+
+var issueSummaries = []string{
+	"Image pull fails intermittently behind proxy",
+	"Garbage collection leaves orphaned blobs",
+	"Operator reconcile loop hot-spins on missing secret",
+	"UI shows stale tag list after delete",
+	"Mirror sync does not honour rate limits",
+	"Update base image for security fixes",
+	"Document new storage replication settings",
+	"Builder pod not cleaned up after timeout",
+}
+
+var (
+	statuses      = []string{"New", "ASSIGNED", "In Progress", "ON_QA", "Verified", "Closed"}
+	priorities    = []string{"Blocker", "Critical", "Major", "Normal", "Minor"}
+	issueTypes    = []string{"Bug", "Bug", "Bug", "Story", "Task", "Vulnerability"}
+	cveSeverities = []string{"Low", "Moderate", "Moderate", "Important", "Critical"}
+	assignees     = []string{"Alex Doe", "Sam Roe", "Jordan Poe", "Casey Loe"}
+)
+
+// Generator produces synthetic dashboard data.
+type Generator struct {
+	store  Store
+	withTx TxFunc
+	logger *slog.Logger
+	rng    *rand.Rand
+	seq    int
+}
+
+// NewGenerator creates a Generator. The same seed produces the same data set.
+func NewGenerator(store Store, withTx TxFunc, seed uint64, logger *slog.Logger) *Generator {
+	return &Generator{
+		store:  store,
+		withTx: withTx,
+		logger: logger,
+		rng:    rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// Seed writes releases, issues, and a short history of snapshots for every
+// demo product.
+func (g *Generator) Seed(ctx context.Context) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, p := range products {
+		for i, fixVersion := range p.releases {
+			g.seq++
+			released := i < len(p.releases)-2
+			rv := &model.ReleaseVersion{
+				Name:                  fixVersion,
+				Description:           fmt.Sprintf("Demo %s release", p.name),
+				Released:              released,
+				ReleaseTicketKey:      fmt.Sprintf("DEMO-%d", 1000+g.seq),
+				ReleaseTicketAssignee: assignees[g.rng.IntN(len(assignees))],
+				S3Application:         jira.FixVersionToS3App(fixVersion),
+			}
+			if released {
+				d := now.AddDate(0, 0, -30*(len(p.releases)-i))
+				rv.ReleaseDate = &d
+			} else {
+				d := now.AddDate(0, 0, 2+g.rng.IntN(20))
+				rv.DueDate = &d
+			}
+			if err := g.store.UpsertReleaseVersion(ctx, rv); err != nil {
+				return fmt.Errorf("upsert release %s: %w", fixVersion, err)
+			}
+			if err := g.seedIssues(ctx, p, fixVersion, released); err != nil {
+				return err
+			}
+		}
+
+		var apps []string
+		for _, fixVersion := range p.releases {
+			if app := jira.FixVersionToS3App(fixVersion); !slices.Contains(apps, app) {
+				apps = append(apps, app)
+			}
+		}
+		for _, app := range apps {
+			for n := 5; n > 0; n-- {
+				if err := g.snapshot(ctx, p, app, now.Add(-time.Duration(n)*6*time.Hour)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Run adds a new snapshot for a random active application every interval
+// until ctx is cancelled, so test results fluctuate over time. Call Seed first.
+func (g *Generator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			g.logger.Info("stopping")
+			return
+		case <-ticker.C:
+			p := products[g.rng.IntN(len(products))]
+			fixVersion := p.releases[len(p.releases)-1-g.rng.IntN(2)]
+			if err := g.snapshot(ctx, p, jira.FixVersionToS3App(fixVersion), time.Now().UTC()); err != nil {
+				g.logger.Error("generate snapshot", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Generator) seedIssues(ctx context.Context, p product, fixVersion string, released bool) error {
+	count := 4 + g.rng.IntN(8)
+	for i := 0; i < count; i++ {
+		g.seq++
+		status := statuses[g.rng.IntN(len(statuses))]
+		if released {
+			status = "Closed"
+		}
+		issueType := issueTypes[g.rng.IntN(len(issueTypes))]
+		labels := ""
+		if issueType == "Vulnerability" {
+			labels = fmt.Sprintf("CVE-2026-%04d,SecurityTracking", g.seq)
+		}
+		priority := priorities[g.rng.IntN(len(priorities))]
+		blocker := priority == "Blocker" && g.rng.IntN(3) == 0
+		if blocker {
+			labels = strings.TrimPrefix(labels+",release-blocker", ",")
+		}
+		key := fmt.Sprintf("DEMO-%d", 1000+g.seq)
+		issue := &model.JiraIssueRecord{
+			Key:        key,
+			Project:    "DEMO",
+			Summary:    issueSummaries[g.rng.IntN(len(issueSummaries))],
+			Status:     status,
+			Priority:   priority,
+			Labels:     labels,
+			FixVersion: fixVersion,
+			Assignee:   assignees[g.rng.IntN(len(assignees))],
+			IssueType:  issueType,
+			Link:       "https://issues.example.com/browse/" + key,
+			UpdatedAt:  time.Now().UTC().Add(-time.Duration(g.rng.IntN(240)) * time.Hour),
+			Components: p.components[g.rng.IntN(len(p.components))],
+			Blocker:    blocker,
+		}
+		if issueType == "Vulnerability" {
+			issue.CVEID = fmt.Sprintf("CVE-2026-%04d", g.seq)
+			issue.Severity = cveSeverities[g.rng.IntN(len(cveSeverities))]
+			issue.CVSSScore = float64(20+g.rng.IntN(81)) / 10
+			issue.Embargoed = !released && g.rng.IntN(5) == 0
+		}
+		if err := g.store.UpsertJiraIssue(ctx, issue); err != nil {
+			return fmt.Errorf("upsert issue %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// snapshot writes one snapshot with components and randomized test results.
+func (g *Generator) snapshot(ctx context.Context, p product, app string, createdAt time.Time) error {
+	g.seq++
+	name := fmt.Sprintf("%s-%s-%03d", app, createdAt.Format("20060102-1504"), g.seq%1000)
+
+	type caseResult struct {
+		name, status, message string
+		duration              float64
+	}
+	type suiteResult struct {
+		name  string
+		cases []caseResult
+	}
+	var suites []suiteResult
+	allPassed := true
+	for _, scenario := range scenarios {
+		sr := suiteResult{name: scenario.name}
+		for _, n := range scenario.cases {
+			cr := caseResult{name: n, status: "passed", duration: float64(200 + g.rng.IntN(20000))}
+			switch r := g.rng.Float64(); {
+			case r < 0.08:
+				cr.status = "failed"
+				cr.message = "AssertionError: expected 200, got 500"
+				allPassed = false
+			case r < 0.12:
+				cr.status = "skipped"
+			}
+			sr.cases = append(sr.cases, cr)
+		}
+		suites = append(suites, sr)
+	}
+
+	return g.withTx(ctx, func(tx Store) error {
+		snap, err := tx.CreateSnapshot(ctx, app, name, allPassed, createdAt)
+		if err != nil {
+			return fmt.Errorf("create snapshot %s: %w", name, err)
+		}
+		for _, c := range p.components {
+			if _, err := tx.EnsureComponent(ctx, c); err != nil {
+				return fmt.Errorf("ensure component %s: %w", c, err)
+			}
+			sha := fmt.Sprintf("%040x", g.rng.Uint64())
+			image := fmt.Sprintf("quay.io/demo/%s@sha256:%064x", c, g.rng.Uint64())
+			gitURL := "https://github.com/quay/" + c
+			if err := tx.CreateSnapshotComponent(ctx, snap.ID, c, sha, image, gitURL); err != nil {
+				return fmt.Errorf("create snapshot component %s: %w", c, err)
+			}
+		}
+
+		start := createdAt.Add(-time.Hour).UnixMilli()
+		for _, sr := range suites {
+			var passed, failed, skipped int
+			var total float64
+			for _, cr := range sr.cases {
+				total += cr.duration
+				switch cr.status {
+				case "passed":
+					passed++
+				case "failed":
+					failed++
+				case "skipped":
+					skipped++
+				}
+			}
+			status := "passed"
+			if failed > 0 {
+				status = "failed"
+			}
+			stop := start + int64(total)
+			suiteID, err := tx.CreateTestSuite(ctx, snap.ID, sr.name, status, "", "demo", "1.0",
+				len(sr.cases), passed, failed, skipped, 0, 0, 0, start, stop, stop-start, false)
+			if err != nil {
+				return fmt.Errorf("create test suite %s: %w", sr.name, err)
+			}
+			for _, cr := range sr.cases {
+				if err := tx.CreateTestCase(ctx, suiteID, cr.name, cr.status, cr.duration, cr.message, "", "", sr.name, 0, false); err != nil {
+					return fmt.Errorf("create test case %s: %w", cr.name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package demo
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+)
+
+func TestSeed(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	withTx := func(ctx context.Context, fn func(Store) error) error {
+		return database.InTx(ctx, func(txDB *db.DB) error { return fn(txDB) })
+	}
+	gen := NewGenerator(database, withTx, 1, slog.Default())
+	ctx := t.Context()
+	if err := gen.Seed(ctx); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	releases, err := database.ListAllReleaseVersions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 6 {
+		t.Errorf("releases: got %d, want 6", len(releases))
+	}
+
+	apps, err := database.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rel := range releases {
+		found := false
+		for _, app := range apps {
+			if app.Application == rel.S3Application && app.LatestSnapshot.HasTests {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("release %s: no snapshot with tests for %s", rel.Name, rel.S3Application)
+		}
+	}
+}
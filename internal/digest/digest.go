@@ -0,0 +1,325 @@
+// Package digest emails daily and weekly summaries of the releases each
+// recipient subscribed to.
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Store is the persistence contract the Digester depends on.
+type Store interface {
+	ListDigestSubscriptions(ctx context.Context) ([]model.DigestSubscription, error)
+	ListDigestStates(ctx context.Context, frequency string) (map[string]model.DigestState, error)
+	SaveDigestState(ctx context.Context, state model.DigestState) error
+	ListJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) ([]model.JiraIssueRecord, error)
+	ListTestSuites(ctx context.Context, snapshotID int64) ([]model.TestSuite, error)
+	ListSnapshots(ctx context.Context, application string, limit, offset int) ([]model.SnapshotRecord, error)
+}
+
+// Source reports the current readiness of every release.
+// *server.Server implements it.
+type Source interface {
+	ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error)
+}
+
+// Mailer delivers a plain-text email. *SMTP implements it.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// DefaultCheckInterval is how often SendDue should be called to check
+// whether a digest is due.
+const DefaultCheckInterval = 5 * time.Minute
+
+// maxSnapshots caps how many of an application's latest snapshots are
+// counted as ingested during a period.
+const maxSnapshots = 200
+
+// Config controls when digests are sent and how they link back.
+type Config struct {
+	// Hour is the hour of the day, in UTC, digests are sent at.
+	Hour int
+	// Weekday is the day weekly digests are sent on.
+	Weekday time.Weekday
+	// DashboardURL is the external base URL of the dashboard, used to link
+	// each release. Releases are not linked if it is empty.
+	DashboardURL string
+}
+
+// Digester sends each recipient a digest of the releases matching their
+// subscriptions of a frequency once per period: the readiness signal of
+// each, the blocker issues and failing scenarios that are new since the
+// previous digest, and the snapshots ingested meanwhile.
+type Digester struct {
+	store  Store
+	source Source
+	mailer Mailer
+	cfg    Config
+	logger *slog.Logger
+}
+
+// NewDigester creates a Digester.
+func NewDigester(store Store, source Source, mailer Mailer, cfg Config, logger *slog.Logger) *Digester {
+	return &Digester{store: store, source: source, mailer: mailer, cfg: cfg, logger: logger}
+}
+
+// SendDue sends the digests whose scheduled time has passed since they
+// were last sent.
+func (d *Digester) SendDue(ctx context.Context, now time.Time) {
+	ctx = requestid.Ensure(ctx)
+	for _, freq := range []string{model.DigestDaily, model.DigestWeekly} {
+		states, err := d.store.ListDigestStates(ctx, freq)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "list digest states", "frequency", freq, "error", err)
+			continue
+		}
+		scheduled := d.scheduled(freq, now)
+		if lastSent(states).Before(scheduled) {
+			d.send(ctx, freq, scheduled, states)
+		}
+	}
+}
+
+// scheduled returns the latest time at or before now that the digest of
+// freq is scheduled for.
+func (d *Digester) scheduled(freq string, now time.Time) time.Time {
+	now = now.UTC()
+	t := time.Date(now.Year(), now.Month(), now.Day(), d.cfg.Hour, 0, 0, 0, time.UTC)
+	if t.After(now) {
+		t = t.AddDate(0, 0, -1)
+	}
+	if freq == model.DigestWeekly {
+		t = t.AddDate(0, 0, -((int(t.Weekday()) - int(d.cfg.Weekday) + 7) % 7))
+	}
+	return t
+}
+
+// period returns how long a digest of freq covers.
+func period(freq string) time.Duration {
+	if freq == model.DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// lastSent returns when a digest was last sent, or the zero time if none
+// has been.
+func lastSent(states map[string]model.DigestState) time.Time {
+	var last time.Time
+	for _, s := range states {
+		if s.SentAt.After(last) {
+			last = s.SentAt
+		}
+	}
+	return last
+}
+
+// section is what a digest reports of one release.
+type section struct {
+	overview       model.ReleaseOverview
+	newBlockers    []model.JiraIssueRecord
+	newFailures    []string
+	snapshots      int
+	latestSnapshot string
+	state          model.DigestState
+	delivered      bool
+}
+
+// send emails the digest of freq scheduled at scheduled to each recipient
+// subscribed at that frequency. The state of a release is saved once a
+// digest reporting it was delivered, so a digest whose deliveries all
+// failed is retried on the next check.
+func (d *Digester) send(ctx context.Context, freq string, scheduled time.Time, states map[string]model.DigestState) {
+	subs, err := d.store.ListDigestSubscriptions(ctx)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "list digest subscriptions", "error", err)
+		return
+	}
+	recipients := make(map[string][]string) // email to release patterns
+	var emails []string
+	for _, sub := range subs {
+		if sub.Frequency != freq {
+			continue
+		}
+		if _, ok := recipients[sub.Email]; !ok {
+			emails = append(emails, sub.Email)
+		}
+		recipients[sub.Email] = append(recipients[sub.Email], sub.Release)
+	}
+	if len(emails) == 0 {
+		return
+	}
+
+	overviews, err := d.source.ReleasesOverview(ctx)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "compute readiness", "error", err)
+		return
+	}
+	sections := make(map[string]*section)
+	for _, email := range emails {
+		var mine []*section
+		for _, o := range overviews {
+			if o.Release.Released || o.Release.Archived || !matchesAny(recipients[email], o.Release.Name) {
+				continue
+			}
+			sec, ok := sections[o.Release.Name]
+			if !ok {
+				since := scheduled.Add(-period(freq))
+				prev, seen := states[o.Release.Name]
+				if seen && prev.SentAt.After(since) {
+					since = prev.SentAt
+				}
+				if sec, err = d.section(ctx, o, prev, since); err != nil {
+					d.logger.ErrorContext(ctx, "compose digest", "release", o.Release.Name, "error", err)
+					return
+				}
+				sec.state.Frequency, sec.state.SentAt = freq, scheduled
+				sections[o.Release.Name] = sec
+			}
+			mine = append(mine, sec)
+		}
+		if len(mine) == 0 {
+			continue
+		}
+		subject := fmt.Sprintf("Release readiness %s digest, %s", freq, scheduled.Format(time.DateOnly))
+		err := d.mailer.Send(ctx, email, subject, d.message(mine))
+		if errors.Is(err, breaker.ErrOpen) {
+			d.logger.WarnContext(ctx, "mail server unavailable, skipping remaining digests", "error", err)
+			break
+		}
+		if err != nil {
+			d.logger.ErrorContext(ctx, "send digest", "frequency", freq, "to", email, "error", err)
+			continue
+		}
+		for _, sec := range mine {
+			sec.delivered = true
+		}
+		d.logger.InfoContext(ctx, "sent digest", "frequency", freq, "to", email, "releases", len(mine))
+	}
+
+	for _, sec := range sections {
+		if !sec.delivered {
+			continue
+		}
+		if err := d.store.SaveDigestState(ctx, sec.state); err != nil {
+			d.logger.ErrorContext(ctx, "save digest state", "release", sec.state.Release, "error", err)
+		}
+	}
+}
+
+// section gathers what is new for a release since the previous digest,
+// whose state was prev, or since since.
+func (d *Digester) section(ctx context.Context, o model.ReleaseOverview, prev model.DigestState, since time.Time) (*section, error) {
+	sec := &section{overview: o, state: model.DigestState{Release: o.Release.Name, Blockers: []string{}, FailedScenarios: []string{}}}
+
+	issues, err := d.store.ListJiraIssues(ctx, o.Release.Name, model.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list issues: %w", err)
+	}
+	for _, i := range issues {
+		if !i.Blocker || i.Done() {
+			continue
+		}
+		sec.state.Blockers = append(sec.state.Blockers, i.Key)
+		if !slices.Contains(prev.Blockers, i.Key) {
+			sec.newBlockers = append(sec.newBlockers, i)
+		}
+	}
+
+	if snap := o.Snapshot; snap != nil {
+		suites, err := d.store.ListTestSuites(ctx, snap.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list test suites of %s: %w", snap.Name, err)
+		}
+		for _, s := range suites {
+			if s.Status != "failed" {
+				continue
+			}
+			sec.state.FailedScenarios = append(sec.state.FailedScenarios, s.Name)
+			if !slices.Contains(prev.FailedScenarios, s.Name) {
+				sec.newFailures = append(sec.newFailures, s.Name)
+			}
+		}
+	}
+
+	if app := o.Release.S3Application; app != "" {
+		snaps, err := d.store.ListSnapshots(ctx, app, maxSnapshots, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list snapshots of %s: %w", app, err)
+		}
+		var latest time.Time
+		for _, s := range snaps {
+			if !s.CreatedAt.After(since) {
+				continue
+			}
+			if s.CreatedAt.After(latest) {
+				latest, sec.latestSnapshot = s.CreatedAt, s.Name
+			}
+			sec.snapshots++
+		}
+	}
+	return sec, nil
+}
+
+// message formats the sections of a digest as plain text.
+func (d *Digester) message(sections []*section) string {
+	var b strings.Builder
+	for i, sec := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		rel := sec.overview.Release
+		b.WriteString(rel.Name)
+		if rel.DueDate != nil {
+			fmt.Fprintf(&b, " (due %s)", rel.DueDate.Format(time.DateOnly))
+		}
+		fmt.Fprintf(&b, "\nReadiness: %s, %s\n", sec.overview.Readiness.Signal, sec.overview.Readiness.Message)
+		if d.cfg.DashboardURL != "" {
+			fmt.Fprintf(&b, "%s/releases/%s\n", strings.TrimSuffix(d.cfg.DashboardURL, "/"), url.PathEscape(rel.Name))
+		}
+		if len(sec.newBlockers) > 0 {
+			b.WriteString("New blockers:\n")
+			for _, i := range sec.newBlockers {
+				fmt.Fprintf(&b, "  - %s %s (%s)\n", i.Key, i.Summary, i.Status)
+			}
+		}
+		if len(sec.newFailures) > 0 {
+			fmt.Fprintf(&b, "Newly failing scenarios on %s:\n", sec.overview.Snapshot.Name)
+			for _, name := range sec.newFailures {
+				fmt.Fprintf(&b, "  - %s\n", name)
+			}
+		}
+		switch sec.snapshots {
+		case 0:
+			b.WriteString("No snapshots ingested\n")
+		case 1:
+			fmt.Fprintf(&b, "1 snapshot ingested: %s\n", sec.latestSnapshot)
+		default:
+			fmt.Fprintf(&b, "%d snapshots ingested, latest %s\n", sec.snapshots, sec.latestSnapshot)
+		}
+	}
+	return b.String()
+}
+
+// matchesAny reports whether release matches one of patterns, release
+// names or globs in path.Match syntax.
+func matchesAny(patterns []string, release string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, release); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package digest
+
+import (
+	"context"
+	"log/slog"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakeSource []model.ReleaseOverview
+
+func (f *fakeSource) ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	return *f, nil
+}
+
+type message struct{ to, subject, body string }
+
+type fakeMailer []message
+
+func (f *fakeMailer) Send(ctx context.Context, to, subject, body string) error {
+	*f = append(*f, message{to, subject, body})
+	return nil
+}
+
+func TestSendDue(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	// Monday 2 March 2026, 09:30 UTC.
+	now := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	for _, sub := range []model.DigestSubscription{
+		{Email: "qe@example.com", Release: "quay-v3.16.*", Frequency: model.DigestDaily},
+		{Email: "pm@example.com", Release: "*", Frequency: model.DigestWeekly},
+		{Email: "omr@example.com", Release: "omr-*", Frequency: model.DigestDaily},
+	} {
+		if err := database.CreateDigestSubscription(ctx, &sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
+		Key: "PROJQUAY-1", Summary: "Mirror fails", Status: "In Progress", FixVersion: "quay-v3.16.3", Blocker: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-2", false, now.Add(-2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, now.Add(-3*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.CreateTestSuite(ctx, snap.ID, "ui-tests", "failed", "", "", "", 2, 1, 1, 0, 0, 0, 0, 0, 0, 0, false); err != nil {
+		t.Fatal(err)
+	}
+
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	source := &fakeSource{{
+		Release:   model.ReleaseVersion{Name: "quay-v3.16.3", S3Application: "quay-v3-16", DueDate: &due},
+		Readiness: model.ReadinessResponse{Signal: "red", Message: "1 open blocker"},
+		Snapshot:  snap,
+	}, {
+		Release:   model.ReleaseVersion{Name: "quay-v3.15.9", Released: true},
+		Readiness: model.ReadinessResponse{Signal: "green", Message: "Released"},
+	}}
+	mailer := &fakeMailer{}
+	d := NewDigester(database, source, mailer, Config{Hour: 8, Weekday: time.Monday, DashboardURL: "https://dashboard.example/"}, slog.Default())
+
+	d.SendDue(ctx, now)
+	if len(*mailer) != 2 {
+		t.Fatalf("sent %+v, want a daily and a weekly digest", *mailer)
+	}
+	daily := (*mailer)[0]
+	if daily.to != "qe@example.com" || daily.subject != "Release readiness daily digest, 2026-03-02" {
+		t.Errorf("daily digest: got %+v", daily)
+	}
+	want := "quay-v3.16.3 (due 2026-03-20)\n" +
+		"Readiness: red, 1 open blocker\n" +
+		"https://dashboard.example/releases/quay-v3.16.3\n" +
+		"New blockers:\n  - PROJQUAY-1 Mirror fails (In Progress)\n" +
+		"Newly failing scenarios on quay-v3-16-snap-2:\n  - ui-tests\n" +
+		"1 snapshot ingested: quay-v3-16-snap-2\n"
+	if daily.body != want {
+		t.Errorf("daily body:\ngot  %q\nwant %q", daily.body, want)
+	}
+	if weekly := (*mailer)[1]; weekly.to != "pm@example.com" || !strings.Contains(weekly.body, "2 snapshots ingested, latest quay-v3-16-snap-2\n") {
+		t.Errorf("weekly digest: got %+v", weekly)
+	}
+
+	// Nothing more is due until the next day.
+	*mailer = nil
+	d.SendDue(ctx, now.Add(time.Hour))
+	if len(*mailer) != 0 {
+		t.Fatalf("sent again: %+v", *mailer)
+	}
+
+	// The next daily digest only reports what is new.
+	d.SendDue(ctx, now.Add(24*time.Hour))
+	if len(*mailer) != 1 {
+		t.Fatalf("next day: sent %+v, want the daily digest", *mailer)
+	}
+	if body := (*mailer)[0].body; strings.Contains(body, "PROJQUAY-1") || strings.Contains(body, "ui-tests") || !strings.Contains(body, "No snapshots ingested\n") {
+		t.Errorf("next day body: %q", body)
+	}
+}
+
+func TestScheduled(t *testing.T) {
+	d := &Digester{cfg: Config{Hour: 8, Weekday: time.Friday}}
+	for _, tc := range []struct {
+		freq string
+		now  time.Time
+		want time.Time
+	}{
+		{model.DigestDaily, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)},
+		{model.DigestDaily, time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{model.DigestWeekly, time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC)},
+		{model.DigestWeekly, time.Date(2026, 3, 6, 7, 0, 0, 0, time.UTC), time.Date(2026, 2, 27, 8, 0, 0, 0, time.UTC)},
+		{model.DigestWeekly, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC)},
+	} {
+		if got := d.scheduled(tc.freq, tc.now); !got.Equal(tc.want) {
+			t.Errorf("%s at %v: got %v, want %v", tc.freq, tc.now, got, tc.want)
+		}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	s, err := NewSMTP(SMTPConfig{Addr: "mail.example.com:587", Username: "bot", Password: "pw", From: "Release Readiness <rr@example.com>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotFrom string
+	var gotTo []string
+	var msg string
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, m []byte) error {
+		if addr != "mail.example.com:587" || a == nil {
+			t.Errorf("addr %q, auth %v", addr, a)
+		}
+		gotFrom, gotTo, msg = from, to, string(m)
+		return nil
+	}
+	if err := s.Send(t.Context(), "QE <qe@example.com>", "Digest ✓", "line one\nline two\n"); err != nil {
+		t.Fatal(err)
+	}
+	if gotFrom != "rr@example.com" || len(gotTo) != 1 || gotTo[0] != "qe@example.com" {
+		t.Errorf("envelope: from %q to %q", gotFrom, gotTo)
+	}
+	for _, want := range []string{
+		"From: \"Release Readiness\" <rr@example.com>\r\n",
+		"To: \"QE\" <qe@example.com>\r\n",
+		"Subject: =?utf-8?q?Digest_=E2=9C=93?=\r\n",
+		"Content-Transfer-Encoding: quoted-printable\r\n\r\nline one\r\nline two\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+
+	if err := s.Send(t.Context(), "not an address", "s", "b"); err == nil {
+		t.Error("invalid recipient: got nil error")
+	}
+	if _, err := NewSMTP(SMTPConfig{Addr: "mail.example.com", From: "rr@example.com"}); err == nil {
+		t.Error("address without port: got nil error")
+	}
+}
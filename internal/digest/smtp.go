@@ -0,0 +1,115 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// SMTPConfig is the mail server digests are sent through.
+type SMTPConfig struct {
+	// Addr is the host:port of the server. Connections are upgraded with
+	// STARTTLS when the server offers it.
+	Addr string
+	// Username and Password authenticate with PLAIN auth, which requires
+	// TLS unless the server is localhost. No auth is used if Username is
+	// empty.
+	Username string
+	Password string
+	// From is the sender address.
+	From string
+}
+
+// SMTP sends email through an SMTP server.
+type SMTP struct {
+	cfg     SMTPConfig
+	from    *mail.Address
+	auth    smtp.Auth
+	breaker *breaker.Breaker
+	// sendMail is smtp.SendMail, replaced in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTP creates an SMTP mailer.
+func NewSMTP(cfg SMTPConfig) (*SMTP, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp address %q: %w", cfg.Addr, err)
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("sender %q: %w", cfg.From, err)
+	}
+	s := &SMTP{
+		cfg:      cfg,
+		from:     from,
+		breaker:  breaker.New("smtp", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+		sendMail: smtp.SendMail,
+	}
+	// Permanent failures, such as an unknown recipient, say nothing of
+	// the server's health.
+	s.breaker.SetFailurePredicate(func(err error) bool {
+		var te *textproto.Error
+		if errors.As(err, &te) {
+			return te.Code < 500
+		}
+		return err != nil
+	})
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return s, nil
+}
+
+// Breaker returns the circuit breaker guarding calls to the mail server.
+func (s *SMTP) Breaker() *breaker.Breaker {
+	return s.breaker
+}
+
+// Send emails a plain-text message to the address to.
+func (s *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rcpt, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("recipient %q: %w", to, err)
+	}
+	msg, err := s.message(rcpt, subject, body)
+	if err != nil {
+		return err
+	}
+	return s.breaker.Do(func() error {
+		return s.sendMail(s.cfg.Addr, s.auth, s.from.Address, []string{rcpt.Address}, msg)
+	})
+}
+
+// message formats an RFC 5322 message with a quoted-printable UTF-8 body.
+func (s *SMTP) message(to *mail.Address, subject, body string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write(bytes.ReplaceAll([]byte(body), []byte("\n"), []byte("\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
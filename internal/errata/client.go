@@ -0,0 +1,148 @@
+// Package errata syncs the state, builds and CVEs of release advisories
+// from the Errata Tool.
+package errata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ErrNotFound is returned when the Errata Tool has no such advisory.
+var ErrNotFound = errors.New("advisory not found")
+
+// Config holds the settings needed to reach an Errata Tool server.
+type Config struct {
+	URL   string // e.g. https://errata.example.com
+	Token string // bearer token
+}
+
+// Client reads advisories from the Errata Tool REST API.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// New creates an Errata Tool client.
+func New(cfg Config) *Client {
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	c := &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New("errata", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500
+		}
+		return err != nil && !errors.Is(err, ErrNotFound)
+	})
+	return c
+}
+
+// Breaker returns the circuit breaker guarding calls to the Errata Tool.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+// GetAdvisory returns the advisory with the given ID: its name, type,
+// state and synopsis, the NVRs of its attached builds and its CVEs. The
+// Release and SyncedAt fields are left for the caller.
+func (c *Client) GetAdvisory(ctx context.Context, id int64) (*model.Advisory, error) {
+	base := c.cfg.URL + "/api/v1/erratum/" + strconv.FormatInt(id, 10)
+
+	// The advisory is nested under its lower-cased type, e.g. "rhsa".
+	var erratum struct {
+		Errata map[string]struct {
+			FullAdvisory string `json:"fulladvisory"`
+			Status       string `json:"status"`
+			Synopsis     string `json:"synopsis"`
+		} `json:"errata"`
+		Content struct {
+			Content struct {
+				CVE string `json:"cve"` // space-separated
+			} `json:"content"`
+		} `json:"content"`
+	}
+	if err := c.breaker.Do(func() error { return c.get(ctx, base, &erratum) }); err != nil {
+		return nil, fmt.Errorf("advisory %d: %w", id, err)
+	}
+	if len(erratum.Errata) != 1 {
+		return nil, fmt.Errorf("advisory %d: unexpected response with %d advisory types", id, len(erratum.Errata))
+	}
+	a := &model.Advisory{AdvisoryID: id, Builds: []string{}, CVEs: strings.Fields(erratum.Content.Content.CVE)}
+	for typ, e := range erratum.Errata {
+		a.Type = strings.ToUpper(typ)
+		a.Name = e.FullAdvisory
+		a.State = e.Status
+		a.Synopsis = e.Synopsis
+	}
+
+	// Builds are grouped by product version, each keyed by its NVR.
+	var builds map[string]struct {
+		Builds []map[string]json.RawMessage `json:"builds"`
+	}
+	if err := c.breaker.Do(func() error { return c.get(ctx, base+"/builds", &builds) }); err != nil {
+		return nil, fmt.Errorf("advisory %d builds: %w", id, err)
+	}
+	nvrs := make(map[string]bool)
+	for _, pv := range builds {
+		for _, b := range pv.Builds {
+			for nvr := range b {
+				nvrs[nvr] = true
+			}
+		}
+	}
+	a.Builds = slices.Sorted(maps.Keys(nvrs))
+	if a.Builds == nil {
+		a.Builds = []string{}
+	}
+	return a, nil
+}
+
+func (c *Client) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return &statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+}
+
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("errata tool returned %d: %s", e.statusCode, e.body)
+}
@@ -0,0 +1,58 @@
+package errata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetAdvisory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/erratum/1234":
+			_, _ = w.Write([]byte(`{
+				"errata": {"rhsa": {"id": 1234, "fulladvisory": "RHSA-2026:1234-01", "status": "QE", "synopsis": "Moderate: Red Hat Quay v3.16.3 security update"}},
+				"content": {"content": {"cve": "CVE-2026-1111 CVE-2026-2222"}}
+			}`))
+		case "/api/v1/erratum/1234/builds":
+			_, _ = w.Write([]byte(`{
+				"Quay-3.16-RHEL-9": {"builds": [{"quay-registry-container-v3.16.3-4": {}}, {"quay-bundle-container-v3.16.3-2": {}}]},
+				"Quay-3.16-RHEL-8": {"builds": [{"quay-registry-container-v3.16.3-4": {}}]}
+			}`))
+		case "/api/v1/erratum/500":
+			http.Error(w, "boom", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c := New(Config{URL: srv.URL + "/", Token: "token"})
+
+	a, err := c.GetAdvisory(t.Context(), 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "RHSA-2026:1234-01" || a.Type != "RHSA" || a.State != "QE" || a.Synopsis == "" {
+		t.Errorf("advisory = %+v", a)
+	}
+	if !slices.Equal(a.CVEs, []string{"CVE-2026-1111", "CVE-2026-2222"}) {
+		t.Errorf("cves = %q", a.CVEs)
+	}
+	if !slices.Equal(a.Builds, []string{"quay-bundle-container-v3.16.3-2", "quay-registry-container-v3.16.3-4"}) {
+		t.Errorf("builds = %q", a.Builds)
+	}
+
+	if _, err := c.GetAdvisory(t.Context(), 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown advisory: err = %v, want ErrNotFound", err)
+	}
+	var se *statusError
+	if _, err := c.GetAdvisory(t.Context(), 500); !errors.As(err, &se) || se.statusCode != 500 {
+		t.Errorf("server error: err = %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package errata
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Store is the persistence contract the Syncer depends on.
+type Store interface {
+	ListReleaseAdvisories(ctx context.Context) ([]model.Advisory, error)
+	SaveAdvisorySync(ctx context.Context, a *model.Advisory) error
+	SaveAdvisorySyncError(ctx context.Context, release string, advisoryID int64, syncErr string) error
+}
+
+// Resolver looks up an advisory. *Client implements it.
+type Resolver interface {
+	GetAdvisory(ctx context.Context, id int64) (*model.Advisory, error)
+}
+
+// Syncer periodically refreshes the advisories configured for releases.
+type Syncer struct {
+	store    Store
+	resolver Resolver
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewSyncer creates a Syncer.
+func NewSyncer(store Store, resolver Resolver, logger *slog.Logger) *Syncer {
+	return &Syncer{store: store, resolver: resolver, logger: logger, now: time.Now}
+}
+
+// SyncOnce refreshes every configured advisory that has not reached a
+// final state. A failed lookup is recorded on the advisory, keeping the
+// state synced before.
+func (s *Syncer) SyncOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	advisories, err := s.store.ListReleaseAdvisories(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list release advisories", "error", err)
+		return
+	}
+	for _, cur := range advisories {
+		if cur.SyncedAt != nil && final(cur.State) {
+			continue
+		}
+		a, err := s.resolver.GetAdvisory(ctx, cur.AdvisoryID)
+		if errors.Is(err, breaker.ErrOpen) {
+			s.logger.WarnContext(ctx, "errata tool unavailable, skipping advisory sync", "error", err)
+			return
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "get advisory", "release", cur.Release, "advisory", cur.AdvisoryID, "error", err)
+			if err := s.store.SaveAdvisorySyncError(ctx, cur.Release, cur.AdvisoryID, err.Error()); err != nil {
+				s.logger.ErrorContext(ctx, "save advisory sync error", "release", cur.Release, "error", err)
+			}
+			continue
+		}
+		a.Release = cur.Release
+		now := s.now()
+		a.SyncedAt = &now
+		if err := s.store.SaveAdvisorySync(ctx, a); err != nil {
+			s.logger.ErrorContext(ctx, "save advisory", "release", cur.Release, "error", err)
+			continue
+		}
+		if a.State != cur.State {
+			s.logger.InfoContext(ctx, "advisory state changed", "release", a.Release, "advisory", a.Name,
+				"from", cur.State, "to", a.State)
+		}
+	}
+}
+
+// final reports whether an advisory in state will not change any more.
+func final(state string) bool {
+	return state == model.AdvisoryShippedLive || state == model.AdvisoryDropped
+}
@@ -0,0 +1,74 @@
+package errata
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// fakeResolver maps advisory IDs to advisories; calls counts lookups.
+type fakeResolver struct {
+	advisories map[int64]*model.Advisory
+	calls      int
+}
+
+func (f *fakeResolver) GetAdvisory(ctx context.Context, id int64) (*model.Advisory, error) {
+	f.calls++
+	a, ok := f.advisories[id]
+	if !ok {
+		return nil, fmt.Errorf("advisory %d: %w", id, ErrNotFound)
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func TestSyncOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	for release, id := range map[string]int64{"3.16.3": 1234, "3.15.9": 99} {
+		if _, err := database.SetReleaseAdvisory(ctx, release, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resolver := &fakeResolver{advisories: map[int64]*model.Advisory{
+		1234: {AdvisoryID: 1234, Name: "RHSA-2026:1234-01", Type: "RHSA", State: model.AdvisoryShippedLive,
+			Builds: []string{"quay-registry-container-v3.16.3-4"}, CVEs: []string{"CVE-2026-1111"}},
+	}}
+	syncer := NewSyncer(database, resolver, slog.Default())
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	syncer.now = func() time.Time { return now }
+	syncer.SyncOnce(ctx)
+
+	a, err := database.GetReleaseAdvisory(ctx, "3.16.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.State != model.AdvisoryShippedLive || a.SyncedAt == nil || !a.SyncedAt.Equal(now) ||
+		len(a.Builds) != 1 || len(a.CVEs) != 1 || !a.Ready() {
+		t.Errorf("synced advisory = %+v", a)
+	}
+	missing, err := database.GetReleaseAdvisory(ctx, "3.15.9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.SyncedAt != nil || missing.SyncError == "" {
+		t.Errorf("unknown advisory = %+v, want a sync error", missing)
+	}
+
+	// Shipped advisories are final and not looked up again.
+	resolver.calls = 0
+	syncer.SyncOnce(ctx)
+	if resolver.calls != 1 {
+		t.Errorf("second sync: %d lookups, want 1", resolver.calls)
+	}
+}
@@ -0,0 +1,85 @@
+// Package events fans out change notifications, such as newly ingested
+// snapshots and synced JIRA issues, to live UI subscribers.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Kind says what changed.
+type Kind string
+
+const (
+	// KindSnapshot is published when a snapshot is ingested or its test
+	// results are refreshed.
+	KindSnapshot Kind = "snapshot"
+	// KindIssues is published when a release's JIRA issues change.
+	KindIssues Kind = "issues"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it.
+const subscriberBuffer = 16
+
+// Event describes one change.
+type Event struct {
+	Kind Kind `json:"kind"`
+	// Application and Snapshot are set for snapshot events, and Refresh
+	// if the event is for refreshed test results rather than an ingest.
+	Application string `json:"application,omitempty"`
+	Snapshot    string `json:"snapshot,omitempty"`
+	Refresh     bool   `json:"refresh,omitempty"`
+	// Release is set for issue events.
+	Release string    `json:"release,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Broker delivers published events to every current subscriber. It is safe
+// for concurrent use, and a nil *Broker discards events.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+// New returns a Broker without subscribers.
+func New() *Broker {
+	return &Broker{subs: map[chan Event]struct{}{}}
+}
+
+// Publish sends e to every subscriber, setting its time if unset. It never
+// blocks: a subscriber whose buffer is full misses the event.
+func (b *Broker) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events published from now on and a
+// function that cancels the subscription and closes the channel.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
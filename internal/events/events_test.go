@@ -0,0 +1,32 @@
+package events
+
+import "testing"
+
+func TestBroker(t *testing.T) {
+	b := New()
+	b.Publish(Event{Kind: KindIssues, Release: "3.16.0"}) // no subscribers
+
+	ch, cancel := b.Subscribe()
+	b.Publish(Event{Kind: KindSnapshot, Application: "quay-v3-16", Snapshot: "quay-v3-16-abc"})
+	e := <-ch
+	if e.Kind != KindSnapshot || e.Snapshot != "quay-v3-16-abc" || e.Time.IsZero() {
+		t.Errorf("got %+v", e)
+	}
+
+	// A subscriber that falls behind misses events rather than blocking.
+	for range subscriberBuffer + 5 {
+		b.Publish(Event{Kind: KindIssues, Release: "3.16.0"})
+	}
+	if len(ch) != subscriberBuffer {
+		t.Errorf("buffered: got %d, want %d", len(ch), subscriberBuffer)
+	}
+
+	cancel()
+	cancel()
+	for range ch {
+	}
+	b.Publish(Event{Kind: KindIssues})
+
+	var nilBroker *Broker
+	nilBroker.Publish(Event{Kind: KindIssues})
+}
@@ -0,0 +1,262 @@
+// Package gitaudit verifies, after a release ships, that each component in
+// the released snapshot was built from the expected git tag (and optionally
+// is on the expected release branch), and records any discrepancies as
+// post-release audit findings.
+package gitaudit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Finding kinds.
+const (
+	KindNoSnapshot    = "no_snapshot"
+	KindUnverifiable  = "unverifiable"
+	KindTagMissing    = "tag_missing"
+	KindTagMismatch   = "tag_mismatch"
+	KindBranchMissing = "branch_missing"
+	KindNotOnBranch   = "not_on_branch"
+)
+
+// auditWindow limits audits to recent releases so that enabling the auditor
+// does not flag every historical release whose snapshots are long gone.
+const auditWindow = 30 * 24 * time.Hour
+
+// Store is the subset of the database layer needed by the auditor.
+type Store interface {
+	ListAllReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error)
+	ReleaseAuditExists(ctx context.Context, release string) (bool, error)
+	LatestSnapshotBefore(ctx context.Context, application string, before time.Time) (*model.SnapshotRecord, error)
+	ListSnapshotComponents(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error)
+	SaveReleaseAudit(ctx context.Context, audit *model.ReleaseAudit) error
+}
+
+// Resolver looks up refs in a git hosting service. *GitHub implements it.
+type Resolver interface {
+	ResolveRef(ctx context.Context, repo Repo, ref string) (string, error)
+	OnBranch(ctx context.Context, repo Repo, branch, sha string) (bool, error)
+}
+
+// Config controls which refs are expected for a release. Templates may use
+// {release} (the fixVersion, e.g. quay-v3.16.2), {version} (3.16.2), and
+// {minor} (3.16).
+type Config struct {
+	TagTemplate    string // e.g. "v{version}"
+	BranchTemplate string // e.g. "redhat-{minor}"; empty disables the branch check
+}
+
+// Repo identifies a GitHub repository.
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+var githubURL = regexp.MustCompile(`^(?:https?://|git@|ssh://git@)github\.com[/:]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// ParseRepo extracts the owner and name from a GitHub clone or web URL.
+func ParseRepo(gitURL string) (Repo, bool) {
+	m := githubURL.FindStringSubmatch(strings.TrimSpace(gitURL))
+	if m == nil {
+		return Repo{}, false
+	}
+	return Repo{Owner: m[1], Name: m[2]}, true
+}
+
+// Auditor periodically audits newly released versions.
+type Auditor struct {
+	store  Store
+	git    Resolver
+	cfg    Config
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewAuditor creates an Auditor.
+func NewAuditor(store Store, git Resolver, cfg Config, logger *slog.Logger) *Auditor {
+	return &Auditor{store: store, git: git, cfg: cfg, logger: logger, now: time.Now}
+}
+
+// AuditOnce audits every recently released version that has not been
+// audited yet. Releases whose audit fails (e.g. GitHub is unreachable) are
+// retried on the next cycle.
+func (a *Auditor) AuditOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	releases, err := a.store.ListAllReleaseVersions(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "list releases", "error", err)
+		return
+	}
+	for _, rel := range releases {
+		if !rel.Released || (rel.ReleaseDate != nil && a.now().Sub(*rel.ReleaseDate) > auditWindow) {
+			continue
+		}
+		exists, err := a.store.ReleaseAuditExists(ctx, rel.Name)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "check audit", "release", rel.Name, "error", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+		audit, err := a.Audit(ctx, rel)
+		if err != nil {
+			a.logger.WarnContext(ctx, "audit release", "release", rel.Name, "error", err)
+			continue
+		}
+		if err := a.store.SaveReleaseAudit(ctx, audit); err != nil {
+			a.logger.ErrorContext(ctx, "save audit", "release", rel.Name, "error", err)
+			continue
+		}
+		if len(audit.Findings) > 0 {
+			a.logger.WarnContext(ctx, "release audit found discrepancies", "release", rel.Name,
+				"snapshot", audit.SnapshotName, "findings", len(audit.Findings))
+		} else {
+			a.logger.InfoContext(ctx, "release audit passed", "release", rel.Name, "snapshot", audit.SnapshotName)
+		}
+	}
+}
+
+// Audit checks the snapshot released as rel. The released snapshot is the
+// latest one for the release's application created on or before the end of
+// its release date.
+func (a *Auditor) Audit(ctx context.Context, rel model.ReleaseVersion) (*model.ReleaseAudit, error) {
+	audit := &model.ReleaseAudit{Release: rel.Name, AuditedAt: a.now().UTC(), Findings: []model.AuditFinding{}}
+	if rel.S3Application == "" {
+		audit.Findings = append(audit.Findings, model.AuditFinding{
+			Kind:    KindNoSnapshot,
+			Message: "release has no S3 application mapped",
+		})
+		return audit, nil
+	}
+
+	before := a.now()
+	if rel.ReleaseDate != nil {
+		before = rel.ReleaseDate.Add(24 * time.Hour)
+	}
+	snap, err := a.store.LatestSnapshotBefore(ctx, rel.S3Application, before)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, fmt.Errorf("find released snapshot: %w", err)
+	}
+	if snap == nil {
+		audit.Findings = append(audit.Findings, model.AuditFinding{
+			Kind:    KindNoSnapshot,
+			Message: fmt.Sprintf("no snapshot of %s found before %s", rel.S3Application, before.Format(time.DateOnly)),
+		})
+		return audit, nil
+	}
+	audit.SnapshotName = snap.Name
+
+	components, err := a.store.ListSnapshotComponents(ctx, snap.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list components of %s: %w", snap.Name, err)
+	}
+
+	tag := expand(a.cfg.TagTemplate, rel.Name)
+	branch := expand(a.cfg.BranchTemplate, rel.Name)
+	for _, c := range components {
+		findings, err := a.checkComponent(ctx, c, tag, branch)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", c.Component, err)
+		}
+		audit.Findings = append(audit.Findings, findings...)
+	}
+	return audit, nil
+}
+
+func (a *Auditor) checkComponent(ctx context.Context, c model.ComponentRecord, tag, branch string) ([]model.AuditFinding, error) {
+	repo, ok := ParseRepo(c.GitURL)
+	if !ok || c.GitSHA == "" {
+		return []model.AuditFinding{{
+			Component: c.Component,
+			Kind:      KindUnverifiable,
+			Actual:    c.GitSHA,
+			Message:   fmt.Sprintf("cannot verify: git URL %q is not a GitHub repository or SHA is missing", c.GitURL),
+		}}, nil
+	}
+
+	var findings []model.AuditFinding
+	if tag != "" {
+		sha, err := a.git.ResolveRef(ctx, repo, tag)
+		switch {
+		case errors.Is(err, ErrRefNotFound):
+			findings = append(findings, model.AuditFinding{
+				Component: c.Component,
+				Kind:      KindTagMissing,
+				Expected:  tag,
+				Actual:    c.GitSHA,
+				Message:   fmt.Sprintf("tag %s does not exist in %s/%s", tag, repo.Owner, repo.Name),
+			})
+		case err != nil:
+			return nil, err
+		case !sameSHA(sha, c.GitSHA):
+			findings = append(findings, model.AuditFinding{
+				Component: c.Component,
+				Kind:      KindTagMismatch,
+				Expected:  sha,
+				Actual:    c.GitSHA,
+				Message:   fmt.Sprintf("tag %s points to %.12s, snapshot was built from %.12s", tag, sha, c.GitSHA),
+			})
+		}
+	}
+
+	if branch != "" {
+		onBranch, err := a.git.OnBranch(ctx, repo, branch, c.GitSHA)
+		switch {
+		case errors.Is(err, ErrRefNotFound):
+			findings = append(findings, model.AuditFinding{
+				Component: c.Component,
+				Kind:      KindBranchMissing,
+				Expected:  branch,
+				Actual:    c.GitSHA,
+				Message:   fmt.Sprintf("branch %s or commit %.12s not found in %s/%s", branch, c.GitSHA, repo.Owner, repo.Name),
+			})
+		case err != nil:
+			return nil, err
+		case !onBranch:
+			findings = append(findings, model.AuditFinding{
+				Component: c.Component,
+				Kind:      KindNotOnBranch,
+				Expected:  branch,
+				Actual:    c.GitSHA,
+				Message:   fmt.Sprintf("commit %.12s is not on branch %s", c.GitSHA, branch),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// sameSHA compares commit SHAs, allowing either side to be abbreviated.
+func sameSHA(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= 7 && strings.HasPrefix(b, a)
+}
+
+// expand fills a ref template for the given fixVersion.
+func expand(tmpl, release string) string {
+	if tmpl == "" {
+		return ""
+	}
+	version := release
+	if i := strings.Index(version, "-v"); i > 0 {
+		version = version[i+2:]
+	}
+	version = strings.TrimPrefix(version, "v")
+	minor := version
+	if parts := strings.SplitN(version, ".", 3); len(parts) >= 2 {
+		minor = parts[0] + "." + parts[1]
+	}
+	return strings.NewReplacer("{release}", release, "{version}", version, "{minor}", minor).Replace(tmpl)
+}
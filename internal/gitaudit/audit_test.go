@@ -0,0 +1,154 @@
+package gitaudit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakeResolver struct {
+	refs     map[string]string // "owner/repo@ref" -> sha
+	branches map[string]bool   // "owner/repo@branch@sha" -> on branch
+}
+
+func (f *fakeResolver) ResolveRef(ctx context.Context, repo Repo, ref string) (string, error) {
+	sha, ok := f.refs[repo.Owner+"/"+repo.Name+"@"+ref]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", ref, ErrRefNotFound)
+	}
+	return sha, nil
+}
+
+func (f *fakeResolver) OnBranch(ctx context.Context, repo Repo, branch, sha string) (bool, error) {
+	return f.branches[repo.Owner+"/"+repo.Name+"@"+branch+"@"+sha], nil
+}
+
+func TestAuditOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	releaseDate := now.Add(-48 * time.Hour).Truncate(24 * time.Hour)
+	for _, rv := range []model.ReleaseVersion{
+		{Name: "quay-v3.16.2", Released: true, ReleaseDate: &releaseDate, S3Application: "quay-v3-16"},
+		{Name: "quay-v3.17.0", S3Application: "quay-v3-17"}, // not released: skipped
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rv); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The released snapshot predates the release; a later one must be ignored.
+	released, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-released", true, releaseDate.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-later", true, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct{ name, sha, url string }{
+		{"quay", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "https://github.com/quay/quay"},
+		{"clair", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "https://github.com/quay/clair.git"},
+		{"quay-operator", "cccccccccccccccccccccccccccccccccccccccc", "git@github.com:quay/quay-operator.git"},
+		{"internal-tool", "dddddddddddddddddddddddddddddddddddddddd", "https://gitlab.example.com/quay/tool"},
+	} {
+		if err := database.CreateSnapshotComponent(ctx, released.ID, c.name, c.sha, "", c.url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.CreateSnapshotComponent(ctx, later.ID, "quay", "eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee", "", "https://github.com/quay/quay"); err != nil {
+		t.Fatal(err)
+	}
+
+	git := &fakeResolver{
+		refs: map[string]string{
+			"quay/quay@v3.16.2":  "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			"quay/clair@v3.16.2": "ffffffffffffffffffffffffffffffffffffffff",
+		},
+		branches: map[string]bool{
+			"quay/quay@redhat-3.16@aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa":          true,
+			"quay/clair@redhat-3.16@bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb":         true,
+			"quay/quay-operator@redhat-3.16@cccccccccccccccccccccccccccccccccccccccc": false,
+		},
+	}
+	auditor := NewAuditor(database, git, Config{TagTemplate: "v{version}", BranchTemplate: "redhat-{minor}"}, slog.Default())
+	auditor.AuditOnce(ctx)
+
+	audit, err := database.GetReleaseAudit(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("get audit: %v", err)
+	}
+	if audit.SnapshotName != "quay-v3-16-released" {
+		t.Errorf("snapshot: got %q, want quay-v3-16-released", audit.SnapshotName)
+	}
+
+	got := map[string]string{}
+	for _, f := range audit.Findings {
+		got[f.Component+"/"+f.Kind] = f.Expected
+	}
+	want := map[string]string{
+		"clair/tag_mismatch":          "ffffffffffffffffffffffffffffffffffffffff",
+		"internal-tool/unverifiable":  "",
+		"quay-operator/tag_missing":   "v3.16.2",
+		"quay-operator/not_on_branch": "redhat-3.16",
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings: got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if e, ok := got[k]; !ok || e != v {
+			t.Errorf("finding %s: got %q (present=%v), want %q", k, e, ok, v)
+		}
+	}
+
+	if exists, _ := database.ReleaseAuditExists(ctx, "quay-v3.17.0"); exists {
+		t.Error("unreleased version should not be audited")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		tmpl, release, want string
+	}{
+		{"v{version}", "quay-v3.16.2", "v3.16.2"},
+		{"v{version}", "3.16.2", "v3.16.2"},
+		{"redhat-{minor}", "omr-v2.0.10", "redhat-2.0"},
+		{"{release}", "quay-v3.16.2", "quay-v3.16.2"},
+		{"", "quay-v3.16.2", ""},
+	}
+	for _, tt := range tests {
+		if got := expand(tt.tmpl, tt.release); got != tt.want {
+			t.Errorf("expand(%q, %q) = %q, want %q", tt.tmpl, tt.release, got, tt.want)
+		}
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Repo
+		ok   bool
+	}{
+		{"https://github.com/quay/quay", Repo{"quay", "quay"}, true},
+		{"https://github.com/quay/clair.git", Repo{"quay", "clair"}, true},
+		{"git@github.com:quay/quay-operator.git", Repo{"quay", "quay-operator"}, true},
+		{"https://gitlab.com/quay/quay", Repo{}, false},
+		{"", Repo{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRepo(tt.url)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseRepo(%q) = %v, %v; want %v, %v", tt.url, got, ok, tt.want, tt.ok)
+		}
+	}
+}
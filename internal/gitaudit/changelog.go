@@ -0,0 +1,55 @@
+package gitaudit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ChangelogStore caches what changed between two commits of a repository.
+type ChangelogStore interface {
+	GetGitRange(ctx context.Context, repo, from, to string) (*model.GitRange, error)
+	SaveGitRange(ctx context.Context, repo, from, to string, r *model.GitRange) error
+}
+
+// ChangeLister lists what changed between two commits in a git hosting
+// service. *GitHub implements it.
+type ChangeLister interface {
+	Changes(ctx context.Context, repo Repo, from, to string) (*model.GitRange, error)
+}
+
+// Changelog lists the changes between component revisions, caching them
+// since the changes between two commits never change.
+type Changelog struct {
+	store ChangelogStore
+	git   ChangeLister
+}
+
+// NewChangelog creates a Changelog that looks up uncached ranges with git.
+func NewChangelog(store ChangelogStore, git ChangeLister) *Changelog {
+	return &Changelog{store: store, git: git}
+}
+
+// Changes returns the commits between revisions from and to of the
+// repository at gitURL and the pull requests merged in them. It returns nil
+// for repositories not on GitHub.
+func (c *Changelog) Changes(ctx context.Context, gitURL, from, to string) (*model.GitRange, error) {
+	repo, ok := ParseRepo(gitURL)
+	if !ok || from == "" || to == "" {
+		return nil, nil
+	}
+	key := repo.Owner + "/" + repo.Name
+	r, err := c.store.GetGitRange(ctx, key, from, to)
+	if !errors.Is(err, db.ErrNotFound) {
+		return r, err
+	}
+	if r, err = c.git.Changes(ctx, repo, from, to); err != nil {
+		return nil, err
+	}
+	if err := c.store.SaveGitRange(ctx, key, from, to, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
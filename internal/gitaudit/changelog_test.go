@@ -0,0 +1,81 @@
+package gitaudit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestChangelog(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	var compares int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/quay/quay/compare/{spec}", func(w http.ResponseWriter, r *http.Request) {
+		compares++
+		if r.PathValue("spec") != "aaa...ddd" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") != "1" {
+			t.Errorf("compare page: got %q", r.URL.Query().Get("page"))
+		}
+		_, _ = w.Write([]byte(`{"total_commits":3,"commits":[
+			{"sha":"bbb","html_url":"https://github.com/quay/quay/commit/bbb","commit":{"message":"Fix login\n\nDetails.","author":{"name":"Alice"}},"author":{"login":"alice"}},
+			{"sha":"ccc","html_url":"https://github.com/quay/quay/commit/ccc","commit":{"message":"Add test","author":{"name":"Alice"}},"author":{"login":"alice"}},
+			{"sha":"ddd","html_url":"https://github.com/quay/quay/commit/ddd","commit":{"message":"Bump deps","author":{"name":"Bob"}},"author":null}]}`))
+	})
+	mux.HandleFunc("GET /repos/quay/quay/commits/{sha}/pulls", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("sha") {
+		case "bbb", "ccc": // two commits of the same pull request
+			_, _ = w.Write([]byte(`[{"number":12,"title":"Fix login","html_url":"https://github.com/quay/quay/pull/12","user":{"login":"alice"},"merged_at":"2026-03-02T10:00:00Z"}]`))
+		case "ddd":
+			_, _ = w.Write([]byte(`[{"number":11,"title":"Bump deps","html_url":"https://github.com/quay/quay/pull/11","user":{"login":"bob"},"merged_at":"2026-03-01T10:00:00Z"},
+				{"number":13,"title":"Unmerged","user":{"login":"carol"},"merged_at":null}]`))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := NewChangelog(database, NewGitHub(srv.URL, "tok"))
+	ctx := t.Context()
+	for range 2 {
+		r, err := c.Changes(ctx, "https://github.com/quay/quay.git", "aaa", "ddd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantCommits := []model.Commit{
+			{SHA: "bbb", Subject: "Fix login", Author: "alice", URL: "https://github.com/quay/quay/commit/bbb"},
+			{SHA: "ccc", Subject: "Add test", Author: "alice", URL: "https://github.com/quay/quay/commit/ccc"},
+			{SHA: "ddd", Subject: "Bump deps", Author: "Bob", URL: "https://github.com/quay/quay/commit/ddd"},
+		}
+		if r.TotalCommits != 3 || !reflect.DeepEqual(r.Commits, wantCommits) {
+			t.Errorf("commits: got %d %+v", r.TotalCommits, r.Commits)
+		}
+		prs := r.PullRequests
+		if len(prs) != 2 || prs[0].Number != 11 || prs[1].Number != 12 || prs[1].Author != "alice" || prs[1].URL != "https://github.com/quay/quay/pull/12" {
+			t.Errorf("pull requests: got %+v", prs)
+		}
+	}
+	if compares != 1 {
+		t.Errorf("compares: got %d, want the range to be fetched once", compares)
+	}
+
+	if r, err := c.Changes(ctx, "https://gitlab.example.com/quay/tool", "aaa", "ddd"); err != nil || r != nil {
+		t.Errorf("non-GitHub repository: got %v, %v", r, err)
+	}
+	if _, err := c.Changes(ctx, "https://github.com/quay/quay", "aaa", "zzz"); err == nil {
+		t.Error("missing revision: expected error")
+	}
+	if _, err := database.GetGitRange(ctx, "quay/quay", "aaa", "zzz"); err == nil {
+		t.Error("failed lookups should not be cached")
+	}
+}
@@ -0,0 +1,237 @@
+package gitaudit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ErrRefNotFound is returned when a tag or branch does not exist.
+var ErrRefNotFound = errors.New("ref not found")
+
+// maxRangeCommits caps how many commits of a range are looked at, so a
+// diff between distant revisions does not exhaust the API rate limit.
+const maxRangeCommits = 250
+
+// maxResponseSize caps how much of a response is read; comparisons list
+// the changed files and can be large.
+const maxResponseSize = 8 << 20
+
+// GitHub is a minimal GitHub REST API client for resolving refs and listing
+// the changes between commits.
+type GitHub struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// NewGitHub creates a client for the GitHub API at baseURL (e.g.
+// https://api.github.com). token may be empty for unauthenticated access.
+func NewGitHub(baseURL, token string) *GitHub {
+	g := &GitHub{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New("github", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	g.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500
+		}
+		return err != nil && !errors.Is(err, ErrRefNotFound)
+	})
+	return g
+}
+
+// Breaker returns the circuit breaker guarding calls to GitHub.
+func (g *GitHub) Breaker() *breaker.Breaker {
+	return g.breaker
+}
+
+// ResolveRef returns the commit SHA that ref (a tag, branch, or SHA) points
+// to, peeling annotated tags.
+func (g *GitHub) ResolveRef(ctx context.Context, repo Repo, ref string) (string, error) {
+	body, err := g.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s", repo.Owner, repo.Name, url.PathEscape(ref)), "application/vnd.github.sha")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// OnBranch reports whether sha is reachable from branch.
+func (g *GitHub) OnBranch(ctx context.Context, repo Repo, branch, sha string) (bool, error) {
+	body, err := g.get(ctx, fmt.Sprintf("/repos/%s/%s/compare/%s...%s", repo.Owner, repo.Name, url.PathEscape(branch), url.PathEscape(sha)), "application/vnd.github+json")
+	if err != nil {
+		return false, err
+	}
+	var cmp struct {
+		Status string `json:"status"` // "identical", "behind", "ahead", "diverged"
+	}
+	if err := json.Unmarshal(body, &cmp); err != nil {
+		return false, fmt.Errorf("decode compare response: %w", err)
+	}
+	return cmp.Status == "identical" || cmp.Status == "behind", nil
+}
+
+// Changes returns the commits between from (exclusive) and to, oldest
+// first, and the pull requests merged into them. Only the first
+// maxRangeCommits commits of the range are listed and looked at.
+func (g *GitHub) Changes(ctx context.Context, repo Repo, from, to string) (*model.GitRange, error) {
+	commits, total, err := g.compareCommits(ctx, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := g.mergedPullRequests(ctx, repo, commits)
+	if err != nil {
+		return nil, err
+	}
+	return &model.GitRange{Commits: commits, TotalCommits: total, PullRequests: prs}, nil
+}
+
+// compareCommits returns up to maxRangeCommits commits between from
+// (exclusive) and to, oldest first, and how many commits the range has.
+func (g *GitHub) compareCommits(ctx context.Context, repo Repo, from, to string) ([]model.Commit, int, error) {
+	commits := []model.Commit{}
+	var total int
+	for page := 1; len(commits) < maxRangeCommits; page++ {
+		body, err := g.get(ctx, fmt.Sprintf("/repos/%s/%s/compare/%s...%s?per_page=100&page=%d", repo.Owner, repo.Name, url.PathEscape(from), url.PathEscape(to), page), "application/vnd.github+json")
+		if err != nil {
+			return nil, 0, err
+		}
+		var cmp struct {
+			TotalCommits int `json:"total_commits"`
+			Commits      []struct {
+				SHA     string `json:"sha"`
+				HTMLURL string `json:"html_url"`
+				Commit  struct {
+					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
+				} `json:"commit"`
+				// Author is the GitHub account of the commit author, if
+				// the author's email belongs to one.
+				Author *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+			} `json:"commits"`
+		}
+		if err := json.Unmarshal(body, &cmp); err != nil {
+			return nil, 0, fmt.Errorf("decode compare response: %w", err)
+		}
+		total = cmp.TotalCommits
+		for _, c := range cmp.Commits {
+			subject, _, _ := strings.Cut(c.Commit.Message, "\n")
+			author := c.Commit.Author.Name
+			if c.Author != nil && c.Author.Login != "" {
+				author = c.Author.Login
+			}
+			commits = append(commits, model.Commit{
+				SHA:     c.SHA,
+				Subject: strings.TrimSpace(subject),
+				Author:  author,
+				URL:     c.HTMLURL,
+			})
+		}
+		if len(cmp.Commits) == 0 || len(commits) >= total {
+			break
+		}
+	}
+	return commits[:min(len(commits), maxRangeCommits)], total, nil
+}
+
+// mergedPullRequests returns the pull requests merged into commits, in
+// the order they were merged.
+func (g *GitHub) mergedPullRequests(ctx context.Context, repo Repo, commits []model.Commit) ([]model.PullRequest, error) {
+	seen := make(map[int]bool)
+	prs := []model.PullRequest{}
+	for _, c := range commits {
+		body, err := g.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/pulls", repo.Owner, repo.Name, c.SHA), "application/vnd.github+json")
+		if err != nil {
+			return nil, err
+		}
+		var pulls []struct {
+			Number  int    `json:"number"`
+			Title   string `json:"title"`
+			HTMLURL string `json:"html_url"`
+			User    struct {
+				Login string `json:"login"`
+			} `json:"user"`
+			MergedAt *time.Time `json:"merged_at"`
+		}
+		if err := json.Unmarshal(body, &pulls); err != nil {
+			return nil, fmt.Errorf("decode pull requests of %s: %w", c.SHA, err)
+		}
+		for _, p := range pulls {
+			if p.MergedAt == nil || seen[p.Number] {
+				continue
+			}
+			seen[p.Number] = true
+			prs = append(prs, model.PullRequest{
+				Number:   p.Number,
+				Title:    p.Title,
+				Author:   p.User.Login,
+				URL:      p.HTMLURL,
+				MergedAt: *p.MergedAt,
+			})
+		}
+	}
+	slices.SortStableFunc(prs, func(a, b model.PullRequest) int {
+		return a.MergedAt.Compare(b.MergedAt)
+	})
+	return prs, nil
+}
+
+func (g *GitHub) get(ctx context.Context, path, accept string) ([]byte, error) {
+	var body []byte
+	err := g.breaker.Do(func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", accept)
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if g.token != "" {
+			req.Header.Set("Authorization", "Bearer "+g.token)
+		}
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+		switch {
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity:
+			return fmt.Errorf("%s: %w", path, ErrRefNotFound)
+		case resp.StatusCode != http.StatusOK:
+			return &statusError{statusCode: resp.StatusCode, body: string(body[:min(len(body), 200)])}
+		}
+		return nil
+	})
+	return body, err
+}
+
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("GitHub API returned %d: %s", e.statusCode, e.body)
+}
@@ -0,0 +1,54 @@
+package gitaudit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHub(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/quay/quay/commits/v3.16.2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.github.sha" {
+			t.Errorf("accept: got %q", r.Header.Get("Accept"))
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("authorization: got %q", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte("abc123\n"))
+	})
+	mux.HandleFunc("GET /repos/quay/quay/commits/v9.9.9", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"No commit found for SHA: v9.9.9"}`, http.StatusUnprocessableEntity)
+	})
+	mux.HandleFunc("GET /repos/quay/quay/compare/{spec}", func(w http.ResponseWriter, r *http.Request) {
+		status := "diverged"
+		if r.PathValue("spec") == "redhat-3.16...abc123" {
+			status = "behind"
+		}
+		_, _ = w.Write([]byte(`{"status":"` + status + `"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	gh := NewGitHub(srv.URL, "tok")
+	repo := Repo{Owner: "quay", Name: "quay"}
+	ctx := t.Context()
+
+	sha, err := gh.ResolveRef(ctx, repo, "v3.16.2")
+	if err != nil || sha != "abc123" {
+		t.Errorf("ResolveRef: got %q, %v; want abc123", sha, err)
+	}
+	if _, err := gh.ResolveRef(ctx, repo, "v9.9.9"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("ResolveRef missing tag: got %v, want ErrRefNotFound", err)
+	}
+	if on, err := gh.OnBranch(ctx, repo, "redhat-3.16", "abc123"); err != nil || !on {
+		t.Errorf("OnBranch: got %v, %v; want true", on, err)
+	}
+	if on, err := gh.OnBranch(ctx, repo, "redhat-3.15", "abc123"); err != nil || on {
+		t.Errorf("OnBranch other branch: got %v, %v; want false", on, err)
+	}
+	if st := gh.Breaker().Status(); st.State != "closed" {
+		t.Errorf("not-found responses should not trip the breaker: %+v", st)
+	}
+}
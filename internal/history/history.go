@@ -0,0 +1,93 @@
+// Package history records how the readiness of each release changes over
+// time, for burn-down charts.
+package history
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Store is the persistence contract the Recorder depends on.
+type Store interface {
+	LatestReadinessPoints(ctx context.Context) (map[string]model.ReadinessPoint, error)
+	CreateReadinessPoint(ctx context.Context, p *model.ReadinessPoint) error
+}
+
+// Source reports the current readiness of every release.
+// *server.Server implements it.
+type Source interface {
+	ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error)
+}
+
+// Recorder periodically appends the readiness signal and issue counts of
+// each active release to its history. A point is only recorded when it
+// differs from the release's previous one.
+type Recorder struct {
+	store  Store
+	source Source
+	logger *slog.Logger
+}
+
+// NewRecorder creates a Recorder.
+func NewRecorder(store Store, source Source, logger *slog.Logger) *Recorder {
+	return &Recorder{store: store, source: source, logger: logger}
+}
+
+// RecordOnce records the releases whose readiness changed since the last
+// cycle.
+func (r *Recorder) RecordOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	overviews, err := r.source.ReleasesOverview(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "compute readiness", "error", err)
+		return
+	}
+	latest, err := r.store.LatestReadinessPoints(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "list latest readiness", "error", err)
+		return
+	}
+
+	recorded := 0
+	for _, o := range overviews {
+		if o.Release.Released || o.Release.Archived {
+			continue
+		}
+		cur := point(o)
+		if prev, ok := latest[cur.Release]; ok && sameReadiness(prev, cur) {
+			continue
+		}
+		if err := r.store.CreateReadinessPoint(ctx, &cur); err != nil {
+			r.logger.ErrorContext(ctx, "record readiness", "release", cur.Release, "error", err)
+			continue
+		}
+		recorded++
+	}
+	if recorded > 0 {
+		r.logger.InfoContext(ctx, "recorded readiness", "releases", recorded)
+	}
+}
+
+// point takes the readiness of a release from its overview.
+func point(o model.ReleaseOverview) model.ReadinessPoint {
+	p := model.ReadinessPoint{
+		Release:      o.Release.Name,
+		Signal:       o.Readiness.Signal,
+		BlockingCVEs: o.Readiness.BlockingCVEs,
+	}
+	if s := o.IssueSummary; s != nil {
+		p.Total, p.Open, p.Verified, p.CVEs, p.Bugs = s.Total, s.Open, s.Verified, s.CVEs, s.Bugs
+	}
+	return p
+}
+
+// sameReadiness reports whether a and b differ only in when they were
+// recorded.
+func sameReadiness(a, b model.ReadinessPoint) bool {
+	a.RecordedAt, b.RecordedAt = time.Time{}, time.Time{}
+	return a == b
+}
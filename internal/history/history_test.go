@@ -0,0 +1,65 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakeSource []model.ReleaseOverview
+
+func (f *fakeSource) ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	return *f, nil
+}
+
+func TestRecordOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	overview := func(name, signal string, open int, released bool) model.ReleaseOverview {
+		return model.ReleaseOverview{
+			Release:      model.ReleaseVersion{Name: name, Released: released},
+			IssueSummary: &model.IssueSummary{Total: 10, Open: open, Verified: 10 - open, Bugs: 10},
+			Readiness:    model.ReadinessResponse{Signal: signal},
+		}
+	}
+	source := &fakeSource{
+		overview("quay-v3.16.3", "red", 6, false),
+		overview("quay-v3.15.9", "green", 0, true),
+	}
+	r := NewRecorder(database, source, slog.Default())
+
+	r.RecordOnce(ctx)
+	r.RecordOnce(ctx) // unchanged: nothing new
+	(*source)[0] = overview("quay-v3.16.3", "yellow", 2, false)
+	r.RecordOnce(ctx)
+
+	points, err := database.ListReadinessHistory(ctx, "quay-v3.16.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("points: got %+v, want 2", points)
+	}
+	if points[0].Signal != "red" || points[0].Open != 6 || points[1].Signal != "yellow" || points[1].Open != 2 || points[1].Verified != 8 {
+		t.Errorf("points: got %+v", points)
+	}
+	if points[1].RecordedAt.IsZero() {
+		t.Error("recorded_at not set")
+	}
+
+	released, err := database.ListReadinessHistory(ctx, "quay-v3.15.9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(released) != 0 {
+		t.Errorf("released release: got %+v, want no history", released)
+	}
+}
@@ -0,0 +1,47 @@
+package jira
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// adfNode is a node of an Atlassian Document Format document, in which
+// API v3 returns rich-text fields such as descriptions and multi-line
+// custom fields.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// adfText returns the plain text of an ADF document, with block nodes
+// separated by newlines. It reports false if raw is not an ADF document.
+func adfText(raw json.RawMessage) (string, bool) {
+	var doc adfNode
+	if json.Unmarshal(raw, &doc) != nil || doc.Type != "doc" {
+		return "", false
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimSpace(b.String()), true
+}
+
+func (n adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+		return
+	case "hardBreak":
+		b.WriteByte('\n')
+		return
+	}
+	for _, c := range n.Content {
+		c.writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "listItem", "codeBlock", "blockquote", "tableRow":
+		b.WriteByte('\n')
+	case "tableCell", "tableHeader":
+		b.WriteByte('\t')
+	}
+}
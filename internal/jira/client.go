@@ -4,15 +4,21 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/tracing"
 )
 
 // Config holds JIRA connection settings.
@@ -20,8 +26,52 @@ type Config struct {
 	BaseURL        string // e.g. https://redhat.atlassian.net
 	Email          string // JIRA Cloud account email for Basic Auth
 	Token          string // JIRA Cloud API token
-	Project        string // e.g. PROJQUAY
 	QAContactField string // custom field name for QA Contact (e.g. customfield_12315948)
+	SeverityField  string // custom field name for CVE severity (e.g. customfield_12316142)
+	// CVEIDField, CVSSField and EmbargoField name the security custom
+	// fields read from CVE issues. Each is optional; without CVEIDField the
+	// CVE ID is taken from the issue's labels or summary.
+	CVEIDField   string
+	CVSSField    string
+	EmbargoField string
+	// Projects are the project keys to sync, e.g. PROJQUAY. Releases are
+	// discovered and their issues searched in every project; a version's
+	// metadata comes from the first project that has it.
+	Projects []string
+	// TargetVersionField is the custom field name for Target Version (e.g.
+	// customfield_12319940). Searches match Target Version by name; the ID
+	// is needed to read it from webhook payloads.
+	TargetVersionField string
+	// Templates are the project's JQL and summary conventions. Nil means
+	// DefaultTemplates.
+	Templates *Templates
+	// Mapping maps fixVersions to S3 applications. Nil means
+	// DefaultMapping.
+	Mapping *Mapping
+	// RequestsPerSecond and Burst size the token bucket every request
+	// draws from. Zero values mean DefaultRequestsPerSecond and
+	// DefaultBurst; a negative RequestsPerSecond disables rate limiting.
+	RequestsPerSecond float64
+	Burst             int
+	// APIVersion selects the REST API: "3" (JIRA Cloud, searching through
+	// /rest/api/3/search/jql with page tokens) or "2" (JIRA Server and Data
+	// Center, searching through /rest/api/2/search with offsets). Empty
+	// means AutoAPIVersion(BaseURL).
+	APIVersion string
+}
+
+// AutoAPIVersion returns the REST API version to use with a JIRA at
+// baseURL: "3" for Atlassian Cloud sites and "2" for everything else.
+func AutoAPIVersion(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "3"
+	}
+	host := strings.ToLower(u.Hostname())
+	if strings.HasSuffix(host, ".atlassian.net") || strings.HasSuffix(host, ".jira.com") {
+		return "3"
+	}
+	return "2"
 }
 
 // Client is a JIRA REST API client.
@@ -29,25 +79,86 @@ type Client struct {
 	baseURL        string
 	email          string
 	token          string
-	project        string
+	projects       []string
 	qaContactField string
+	severityField  string
+	cveIDField     string
+	cvssField      string
+	embargoField   string
+	targetField    string
+	templates      *Templates
+	mapping        *Mapping
+	apiVersion     string
 	httpClient     *http.Client
-	minDelay       time.Duration // minimum delay between requests
+	limiter        *Limiter
+	breaker        *breaker.Breaker
 }
 
 // New creates a new JIRA client.
 func New(cfg Config) *Client {
+	templates := cfg.Templates
+	if templates == nil {
+		templates = DefaultTemplates
+	}
+	mapping := cfg.Mapping
+	if mapping == nil {
+		mapping = DefaultMapping
+	}
+	rps, burst := cfg.RequestsPerSecond, cfg.Burst
+	if rps == 0 {
+		rps = DefaultRequestsPerSecond
+	}
+	if burst == 0 {
+		burst = DefaultBurst
+	}
+	apiVersion := cfg.APIVersion
+	if apiVersion == "" {
+		apiVersion = AutoAPIVersion(cfg.BaseURL)
+	}
 	return &Client{
 		baseURL:        strings.TrimRight(cfg.BaseURL, "/"),
 		email:          cfg.Email,
 		token:          cfg.Token,
-		project:        cfg.Project,
+		projects:       cfg.Projects,
 		qaContactField: cfg.QAContactField,
+		severityField:  cfg.SeverityField,
+		cveIDField:     cfg.CVEIDField,
+		cvssField:      cfg.CVSSField,
+		embargoField:   cfg.EmbargoField,
+		targetField:    cfg.TargetVersionField,
+		templates:      templates,
+		mapping:        mapping,
+		apiVersion:     apiVersion,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		minDelay: 1 * time.Second,
+		limiter: NewLimiter(rps, burst),
+		breaker: newBreaker(),
+	}
+}
+
+func newBreaker() *breaker.Breaker {
+	b := breaker.New("jira", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown)
+	b.SetFailurePredicate(isUnavailable)
+	return b
+}
+
+// Breaker returns the circuit breaker guarding calls to JIRA.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+// isUnavailable reports whether err means JIRA is unreachable or failing.
+// Rate limiting and other 4xx responses mean JIRA is up and answering.
+func isUnavailable(err error) bool {
+	if err == nil || isRateLimitError(err) {
+		return false
 	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.statusCode >= 500
+	}
+	return true
 }
 
 // Issue represents a JIRA issue from the REST API.
@@ -55,6 +166,10 @@ type Issue struct {
 	Key       string      `json:"key"`
 	Fields    IssueFields `json:"fields"`
 	QAContact string      `json:"-"`
+	Severity  string      `json:"-"`
+	CVEID     string      `json:"-"`
+	CVSSScore float64     `json:"-"`
+	Embargoed bool        `json:"-"`
 }
 
 // IssueFields holds the fields we care about from a JIRA issue.
@@ -70,6 +185,8 @@ type IssueFields struct {
 	Updated     string           `json:"updated"`
 	DueDate     string           `json:"duedate"`
 	Components  []ComponentField `json:"components"`
+	IssueLinks  []IssueLink      `json:"issuelinks"`
+	Subtasks    []LinkedKey      `json:"subtasks"`
 
 	Raw map[string]json.RawMessage `json:"-"`
 }
@@ -116,8 +233,99 @@ type ComponentField struct {
 	Name string `json:"name"`
 }
 
+// IssueLink is a link to another issue. Exactly one of InwardIssue and
+// OutwardIssue is set.
+type IssueLink struct {
+	Type         LinkType   `json:"type"`
+	InwardIssue  *LinkedKey `json:"inwardIssue"`
+	OutwardIssue *LinkedKey `json:"outwardIssue"`
+}
+
+// LinkType names a link type and how it reads from either end, e.g.
+// "Blocks" with inward "is blocked by" and outward "blocks".
+type LinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+type LinkedKey struct {
+	Key    string       `json:"key"`
+	Fields LinkedFields `json:"fields"`
+}
+
+// LinkedFields are the fields JIRA includes for a linked issue.
+type LinkedFields struct {
+	Summary string      `json:"summary"`
+	Status  StatusField `json:"status"`
+}
+
+// CloneKeys returns the keys of the issues linked to i by a clone or
+// backport link, in either direction. JIRA's built-in clone link type is
+// named "Cloners".
+func (i Issue) CloneKeys() []string {
+	var keys []string
+	for _, l := range i.Fields.IssueLinks {
+		name := strings.ToLower(l.Type.Name)
+		if !strings.Contains(name, "clone") && !strings.Contains(name, "backport") {
+			continue
+		}
+		switch {
+		case l.InwardIssue != nil:
+			keys = append(keys, l.InwardIssue.Key)
+		case l.OutwardIssue != nil:
+			keys = append(keys, l.OutwardIssue.Key)
+		}
+	}
+	return keys
+}
+
+// Blockers returns the issues i is blocked by or depends on, read from
+// "Blocks" and dependency links. A link read from i's side as "is blocked
+// by" or "depends on" names a blocker; without link descriptions the link
+// type's name and direction decide.
+func (i Issue) Blockers() []LinkedKey {
+	var blockers []LinkedKey
+	for _, l := range i.Fields.IssueLinks {
+		var other *LinkedKey
+		var desc string
+		var wantName string
+		switch {
+		case l.InwardIssue != nil:
+			other, desc, wantName = l.InwardIssue, l.Type.Inward, "block"
+		case l.OutwardIssue != nil:
+			other, desc, wantName = l.OutwardIssue, l.Type.Outward, "depend"
+		default:
+			continue
+		}
+		desc = strings.ToLower(desc)
+		if desc != "" {
+			if !strings.Contains(desc, "blocked by") && !strings.HasPrefix(desc, "depends on") {
+				continue
+			}
+		} else if !strings.Contains(strings.ToLower(l.Type.Name), wantName) {
+			continue
+		}
+		blockers = append(blockers, *other)
+	}
+	return blockers
+}
+
+// ComponentNames returns the names of the issue's JIRA components.
+func (i Issue) ComponentNames() []string {
+	names := make([]string, len(i.Fields.Components))
+	for j, c := range i.Fields.Components {
+		names[j] = c.Name
+	}
+	return names
+}
+
+// searchResponse is a page of search results. API v3 pages by token; API
+// v2 by offset, with StartAt and Total.
 type searchResponse struct {
 	NextPageToken string  `json:"nextPageToken,omitempty"`
+	StartAt       int     `json:"startAt"`
+	Total         int     `json:"total"`
 	MaxResults    int     `json:"maxResults"`
 	Issues        []Issue `json:"issues"`
 }
@@ -128,7 +336,7 @@ type ActiveRelease struct {
 	DueDate          *time.Time // from the release ticket's dueDate field
 	ReleaseTicketKey string     // e.g. "PROJQUAY-10276"
 	Assignee         string     // display name of the release ticket assignee
-	S3Application    string     // e.g. "quay-v3-16" (derived from fixVersion)
+	S3Application    string     // e.g. "quay-v3-16" (mapped from fixVersion)
 }
 
 // BaseURL returns the configured JIRA base URL.
@@ -136,70 +344,35 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
-// versionRe matches version patterns like "v3.16.2", "v2.0.10", "3.16.2" in release ticket summaries.
-// Examples:
-//   - "Release Quay v3.16.2"       → product="quay", version="3.16.2"
-//   - "Release OMR v2.0.10"        → product="omr", version="2.0.10"
-//   - "⦗konflux⦘ Quay v3.15.3"    → product="quay", version="3.15.3"
-var versionRe = regexp.MustCompile(`(?i)(?:(\w+)\s+)?v?(\d+\.\d+(?:\.\d+)?)`)
-
-// ParseVersionFromSummary extracts the product and version from a release ticket summary.
-// Returns product (lowercased), version string, and whether a match was found.
+// ParseVersionFromSummary extracts the product and version from a release
+// ticket summary using DefaultTemplates. Returns product (lowercased), version
+// string, and whether a match was found.
 func ParseVersionFromSummary(summary string) (product, version string, ok bool) {
-	m := versionRe.FindStringSubmatch(summary)
-	if m == nil {
-		return "", "", false
-	}
-	product = strings.ToLower(m[1])
-	version = m[2]
-	return product, version, true
+	return DefaultTemplates.parseSummary(summary)
 }
 
-// DiscoverActiveReleases queries JIRA for active release tickets using the -area/release component.
-// Returns releases that are not Closed/Done, each with their fixVersion (parsed from
-// the ticket summary), dueDate, and ticket key.
+// DiscoverActiveReleases queries each project for active release tickets
+// using the discovery JQL (by default, open tickets with the -area/release
+// component). Returns each with its fixVersion (parsed from the ticket
+// summary), dueDate, and ticket key. A fixVersion with tickets in several
+// projects is returned once, for the first project.
 func (c *Client) DiscoverActiveReleases(ctx context.Context) ([]ActiveRelease, error) {
-	jql := fmt.Sprintf(
-		`project=%s AND component="-area/release" AND status NOT IN (Closed, Done)`,
-		c.project,
-	)
 	fields := "summary,status,fixVersions,duedate,components,assignee"
 
 	var allIssues []Issue
-	nextPageToken := ""
-
-	for {
-		params := url.Values{
-			"jql":        {jql},
-			"fields":     {fields},
-			"maxResults": {"100"},
-		}
-		if nextPageToken != "" {
-			params.Set("nextPageToken", nextPageToken)
-		}
-
-		reqURL := fmt.Sprintf("%s/rest/api/3/search/jql?%s", c.baseURL, params.Encode())
-		body, err := c.doGetWithRetry(ctx, reqURL)
+	for _, project := range c.projects {
+		err := c.searchPages(ctx, c.templates.discoveryJQL(project), fields, func(page []Issue) {
+			allIssues = append(allIssues, page...)
+		})
 		if err != nil {
-			return nil, fmt.Errorf("discover releases: %w", err)
-		}
-
-		var resp searchResponse
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("decode search response: %w", err)
-		}
-
-		allIssues = append(allIssues, resp.Issues...)
-
-		if resp.NextPageToken == "" {
-			break
+			return nil, fmt.Errorf("discover releases in %s: %w", project, err)
 		}
-		nextPageToken = resp.NextPageToken
 	}
 
 	var releases []ActiveRelease
+	seen := make(map[string]bool)
 	for _, issue := range allIssues {
-		product, version, ok := ParseVersionFromSummary(issue.Fields.Summary)
+		product, version, ok := c.templates.parseSummary(issue.Fields.Summary)
 		if !ok {
 			continue
 		}
@@ -210,10 +383,11 @@ func (c *Client) DiscoverActiveReleases(ctx context.Context) ([]ActiveRelease, e
 			fixVersion = product + "-v" + version
 		}
 
-		s3App := FixVersionToS3App(fixVersion)
-		if s3App == "" {
+		s3App := c.mapping.Application(fixVersion)
+		if s3App == "" || seen[fixVersion] {
 			continue
 		}
+		seen[fixVersion] = true
 
 		assignee := ""
 		if issue.Fields.Assignee != nil {
@@ -240,128 +414,254 @@ func (c *Client) DiscoverActiveReleases(ctx context.Context) ([]ActiveRelease, e
 	return releases, nil
 }
 
-// buildSearchJQL constructs the JQL for searching issues by Target Version.
-func (c *Client) buildSearchJQL(version string) string {
-	return fmt.Sprintf(`project=%s AND "Target Version"="%s"`,
-		c.project, version)
+// buildSearchJQL constructs the JQL for searching the issues of a version
+// in a project.
+func (c *Client) buildSearchJQL(project, version string) string {
+	return c.templates.searchJQL(project, version)
 }
 
-// SearchIssues queries JIRA for issues matching a Target Version.
-// It handles pagination automatically and respects rate limits.
+// SearchIssues queries every project for issues matching a Target Version.
+// It handles pagination automatically and respects rate limits. It fails if
+// any project's search fails, so callers never mistake a partial result for
+// the version's full issue set.
 func (c *Client) SearchIssues(ctx context.Context, fixVersion string) ([]Issue, error) {
-	jql := c.buildSearchJQL(fixVersion)
-	fields := "summary,status,priority,labels,assignee,issuetype,resolution,updated"
+	return c.searchProjects(ctx, func(project string) string {
+		return c.buildSearchJQL(project, fixVersion)
+	})
+}
+
+// SearchUpdatedIssues is like SearchIssues but only returns issues updated
+// within the last window. The window is sent as a relative JQL date, which
+// JIRA evaluates itself, so neither clock skew nor the account's time zone
+// setting can shift it. It is rounded up to whole minutes.
+func (c *Client) SearchUpdatedIssues(ctx context.Context, fixVersion string, window time.Duration) ([]Issue, error) {
+	minutes := int64((window + time.Minute - 1) / time.Minute)
+	return c.searchProjects(ctx, func(project string) string {
+		return fmt.Sprintf(`%s AND updated >= "-%dm"`, c.buildSearchJQL(project, fixVersion), minutes)
+	})
+}
+
+// searchProjects runs the JQL built by jql for each project and concatenates
+// the results.
+func (c *Client) searchProjects(ctx context.Context, jql func(project string) string) ([]Issue, error) {
+	var issues []Issue
+	for _, project := range c.projects {
+		found, err := c.search(ctx, jql(project))
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+func (c *Client) search(ctx context.Context, jql string) ([]Issue, error) {
+	fields := "summary,status,priority,labels,assignee,issuetype,resolution,updated,issuelinks,subtasks,components"
 	if c.qaContactField != "" {
 		fields += "," + c.qaContactField
 	}
+	for _, f := range []string{c.severityField, c.cveIDField, c.cvssField, c.embargoField} {
+		if f != "" {
+			fields += "," + f
+		}
+	}
+
+	ctx, span := tracing.StartClient(ctx, "jira search", slog.String("jira.jql", jql))
+	defer span.End()
 
 	var allIssues []Issue
-	nextPageToken := ""
+	pages := 0
+	err := c.searchPages(ctx, jql, fields, func(page []Issue) {
+		pages++
+		for i := range page {
+			c.readCustomFields(&page[i])
+		}
+		allIssues = append(allIssues, page...)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("search issues: %w", err)
+	}
 
+	span.SetAttributes(slog.Int("jira.pages", pages), slog.Int("jira.issues", len(allIssues)))
+	return allIssues, nil
+}
+
+// searchPages runs a JQL search and passes each page of issues to fn, in
+// order. API v3 follows nextPageToken; API v2 advances startAt until total
+// is reached.
+func (c *Client) searchPages(ctx context.Context, jql, fields string, fn func([]Issue)) error {
+	const pageSize = 100
+	nextPageToken := ""
+	startAt := 0
 	for {
 		params := url.Values{
 			"jql":        {jql},
 			"fields":     {fields},
-			"maxResults": {"100"},
+			"maxResults": {strconv.Itoa(pageSize)},
 		}
-		if nextPageToken != "" {
+		path := "/rest/api/3/search/jql"
+		if c.apiVersion == "2" {
+			path = "/rest/api/2/search"
+			params.Set("startAt", strconv.Itoa(startAt))
+		} else if nextPageToken != "" {
 			params.Set("nextPageToken", nextPageToken)
 		}
 
-		reqURL := fmt.Sprintf("%s/rest/api/3/search/jql?%s", c.baseURL, params.Encode())
-		body, err := c.doGetWithRetry(ctx, reqURL)
+		body, err := c.doGetWithRetry(ctx, c.baseURL+path+"?"+params.Encode())
 		if err != nil {
-			return nil, fmt.Errorf("search issues: %w", err)
+			return err
 		}
-
 		var resp searchResponse
 		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("decode search response: %w", err)
+			return fmt.Errorf("decode search response: %w", err)
 		}
+		fn(resp.Issues)
 
-		if c.qaContactField != "" {
-			for i := range resp.Issues {
-				if v, ok := resp.Issues[i].Fields.Raw[c.qaContactField]; ok {
-					var u *UserField
-					if json.Unmarshal(v, &u) == nil && u != nil {
-						resp.Issues[i].QAContact = u.DisplayName
-					}
-				}
+		if c.apiVersion == "2" {
+			startAt = resp.StartAt + len(resp.Issues)
+			if len(resp.Issues) == 0 || startAt >= resp.Total {
+				return nil
 			}
+			continue
 		}
-
-		allIssues = append(allIssues, resp.Issues...)
-
 		if resp.NextPageToken == "" {
-			break
+			return nil
 		}
 		nextPageToken = resp.NextPageToken
 	}
+}
 
-	return allIssues, nil
+// readCustomFields sets the issue fields read from configured custom fields.
+func (c *Client) readCustomFields(issue *Issue) {
+	if v, ok := issue.Fields.Raw[c.qaContactField]; ok && c.qaContactField != "" {
+		var u *UserField
+		if json.Unmarshal(v, &u) == nil && u != nil {
+			issue.QAContact = u.DisplayName
+		}
+	}
+	if v, ok := issue.Fields.Raw[c.severityField]; ok && c.severityField != "" {
+		issue.Severity = optionValue(v)
+	}
+	if !issue.IsCVE() {
+		return
+	}
+	if v, ok := issue.Fields.Raw[c.cveIDField]; ok && c.cveIDField != "" {
+		issue.CVEID = cvePattern.FindString(optionValue(v))
+	}
+	if issue.CVEID == "" {
+		issue.CVEID = cvePattern.FindString(strings.Join(issue.Fields.Labels, " "))
+	}
+	if issue.CVEID == "" {
+		issue.CVEID = cvePattern.FindString(issue.Fields.Summary)
+	}
+	if v, ok := issue.Fields.Raw[c.cvssField]; ok && c.cvssField != "" {
+		issue.CVSSScore = numberValue(v)
+	}
+	if v, ok := issue.Fields.Raw[c.embargoField]; ok && c.embargoField != "" {
+		issue.Embargoed = flagValue(v)
+	}
 }
 
-// GetVersion fetches version metadata from JIRA for the given project and version name.
-func (c *Client) GetVersion(ctx context.Context, versionName string) (*VersionField, error) {
-	reqURL := fmt.Sprintf("%s/rest/api/3/project/%s/versions", c.baseURL, url.PathEscape(c.project))
-	body, err := c.doGetWithRetry(ctx, reqURL)
-	if err != nil {
-		return nil, fmt.Errorf("get versions: %w", err)
+// cvePattern matches a CVE ID such as CVE-2026-1234.
+var cvePattern = regexp.MustCompile(`CVE-\d{4}-\d{4,}`)
+
+// IsCVE reports whether the issue tracks a CVE: it is of type
+// Vulnerability or has a label mentioning CVE.
+func (i Issue) IsCVE() bool {
+	if strings.EqualFold(i.Fields.IssueType.Name, "vulnerability") {
+		return true
 	}
+	return slices.ContainsFunc(i.Fields.Labels, func(l string) bool {
+		return strings.Contains(strings.ToLower(l), "cve")
+	})
+}
 
+// targetVersions returns the names in the issue's Target Version field. It
+// reports false if the field is not configured or not in the payload, in
+// which case the issue's versions are unknown.
+func (c *Client) targetVersions(issue Issue) ([]string, bool) {
+	raw, ok := issue.Fields.Raw[c.targetField]
+	if !ok || c.targetField == "" {
+		return nil, false
+	}
 	var versions []VersionField
-	if err := json.Unmarshal(body, &versions); err != nil {
-		return nil, fmt.Errorf("decode versions: %w", err)
+	if err := json.Unmarshal(raw, &versions); err != nil {
+		// Single-version pickers hold one object rather than a list.
+		var v *VersionField
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, false
+		}
+		if v != nil {
+			versions = append(versions, *v)
+		}
 	}
-
+	names := make([]string, 0, len(versions))
 	for _, v := range versions {
-		if v.Name == versionName {
-			return &v, nil
+		names = append(names, v.Name)
+	}
+	return names, true
+}
+
+// GetVersion fetches version metadata from JIRA for the given version name,
+// from the first project that has it.
+func (c *Client) GetVersion(ctx context.Context, versionName string) (*VersionField, error) {
+	for _, project := range c.projects {
+		reqURL := fmt.Sprintf("%s/rest/api/%s/project/%s/versions", c.baseURL, c.apiVersion, url.PathEscape(project))
+		body, err := c.doGetWithRetry(ctx, reqURL)
+		if err != nil {
+			return nil, fmt.Errorf("get versions of %s: %w", project, err)
+		}
+
+		var versions []VersionField
+		if err := json.Unmarshal(body, &versions); err != nil {
+			return nil, fmt.Errorf("decode versions: %w", err)
+		}
+
+		for _, v := range versions {
+			if v.Name == versionName {
+				return &v, nil
+			}
 		}
 	}
-	return nil, fmt.Errorf("version %q not found in project %s", versionName, c.project)
+	return nil, fmt.Errorf("version %q not found in %s", versionName, strings.Join(c.projects, ", "))
 }
 
-// doGetWithRetry performs an HTTP GET with rate limiting and retry on 429 responses.
+// doGetWithRetry performs an HTTP GET within the client's rate limit,
+// retrying 429 responses with backoff. A 429 pauses the shared limiter, so
+// concurrent calls hold off too.
 func (c *Client) doGetWithRetry(ctx context.Context, reqURL string) ([]byte, error) {
 	const maxRetries = 3
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 || c.minDelay > 0 {
-			delay := c.minDelay
-			if attempt > 0 {
-				// Exponential backoff: 2s, 4s, 8s
-				delay = time.Duration(math.Pow(2, float64(attempt))) * time.Second
-			}
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
 		}
 
-		body, err := c.doGet(ctx, reqURL)
+		var body []byte
+		err := c.breaker.Do(func() error {
+			var err error
+			body, err = c.doGet(ctx, reqURL)
+			return err
+		})
 		if err == nil {
 			return body, nil
 		}
 
-		// Check if it's a rate limit error
-		if isRateLimitError(err) && attempt < maxRetries {
-			retryAfter := parseRetryAfter(err)
-			if retryAfter > 0 {
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(retryAfter):
-				}
-			}
-			continue
+		var rle *rateLimitError
+		if !errors.As(err, &rle) || attempt == maxRetries {
+			return nil, err
+		}
+		delay := backoff(rle, attempt+1, time.Now())
+		c.limiter.PauseUntil(time.Now().Add(delay))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
 		}
-
-		return nil, err
 	}
-
-	return nil, fmt.Errorf("max retries exceeded for %s", reqURL)
 }
 
 func (c *Client) doGet(ctx context.Context, reqURL string) ([]byte, error) {
@@ -370,6 +670,10 @@ func (c *Client) doGet(ctx context.Context, reqURL string) ([]byte, error) {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	tracing.Inject(ctx, req.Header)
 	if c.token != "" {
 		req.SetBasicAuth(c.email, c.token)
 	}
@@ -379,6 +683,7 @@ func (c *Client) doGet(ctx context.Context, reqURL string) ([]byte, error) {
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.limiter.Observe(resp.Header)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -386,16 +691,17 @@ func (c *Client) doGet(ctx context.Context, reqURL string) ([]byte, error) {
 	}
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		retryAfter := resp.Header.Get("Retry-After")
+		reset, _ := rateLimitReset(resp.Header, time.Now())
 		return nil, &rateLimitError{
 			statusCode: resp.StatusCode,
-			retryAfter: retryAfter,
+			retryAfter: resp.Header.Get("Retry-After"),
+			reset:      reset,
 			body:       string(body[:min(len(body), 200)]),
 		}
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("JIRA API returned %d: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+		return nil, &statusError{statusCode: resp.StatusCode, body: string(body[:min(len(body), 200)])}
 	}
 
 	return body, nil
@@ -404,7 +710,8 @@ func (c *Client) doGet(ctx context.Context, reqURL string) ([]byte, error) {
 // rateLimitError represents a 429 Too Many Requests response.
 type rateLimitError struct {
 	statusCode int
-	retryAfter string
+	retryAfter string    // Retry-After header, if any
+	reset      time.Time // X-RateLimit-Reset, if any
 	body       string
 }
 
@@ -412,43 +719,75 @@ func (e *rateLimitError) Error() string {
 	return fmt.Sprintf("JIRA API returned %d: %s", e.statusCode, e.body)
 }
 
+// statusError represents any other non-200 response.
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("JIRA API returned %d: %s", e.statusCode, e.body)
+}
+
 func isRateLimitError(err error) bool {
 	_, ok := err.(*rateLimitError)
 	return ok
 }
 
-func parseRetryAfter(err error) time.Duration {
-	rle, ok := err.(*rateLimitError)
-	if !ok || rle.retryAfter == "" {
-		return 0
+// FixVersionToS3App maps a JIRA fixVersion to an S3 application prefix
+// with DefaultMapping:
+//   - Plain semver: "3.16.3" → "quay-v3-16" (defaults to "quay" product)
+//   - Prefixed:     "omr-v2.0.10" → "omr-v2-0" (product parsed from prefix)
+func FixVersionToS3App(fixVersion string) string {
+	return DefaultMapping.Application(fixVersion)
+}
+
+// optionValue decodes a select-list custom field, which JIRA returns as an
+// object like {"value": "Important"}. Plain strings are accepted as well,
+// and so are rich-text fields, which API v3 returns as ADF documents.
+func optionValue(raw json.RawMessage) string {
+	if text, ok := adfText(raw); ok {
+		return text
 	}
-	seconds, err := strconv.Atoi(rle.retryAfter)
-	if err != nil {
-		return 0
+	var opt *struct {
+		Value string `json:"value"`
+	}
+	if json.Unmarshal(raw, &opt) == nil && opt != nil {
+		return opt.Value
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
 	}
-	return time.Duration(seconds) * time.Second
+	return ""
 }
 
-// FixVersionToS3App maps a JIRA fixVersion to an S3 application prefix.
-// It handles two formats:
-//   - Plain semver: "3.16.3" → "quay-v3-16" (defaults to "quay" product)
-//   - Prefixed:     "omr-v2.0.10" → "omr-v2-0" (product parsed from prefix)
-func FixVersionToS3App(fixVersion string) string {
-	// Check for "{product}-v{version}" format (e.g. "omr-v2.0.10")
-	if idx := strings.Index(fixVersion, "-v"); idx > 0 {
-		product := fixVersion[:idx]
-		version := fixVersion[idx+2:] // skip "-v"
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			return fmt.Sprintf("%s-v%s-%s", product, parts[0], parts[1])
-		}
-		return ""
+// numberValue reads a number field, which some JIRA instances hold as text
+// or as a select-list option. It returns 0 if the value is not a number.
+func numberValue(raw json.RawMessage) float64 {
+	var f float64
+	if json.Unmarshal(raw, &f) == nil {
+		return f
 	}
+	f, _ = strconv.ParseFloat(strings.TrimSpace(optionValue(raw)), 64)
+	return f
+}
 
-	// Plain semver: "3.16.3" → "quay-v3-16"
-	parts := strings.Split(fixVersion, ".")
-	if len(parts) >= 2 {
-		return fmt.Sprintf("quay-v%s-%s", parts[0], parts[1])
+// flagValue reads a yes/no field: a boolean, a select-list option such as
+// "Yes" or "True", or a checkbox list, which is set if any box is checked
+// with such a value.
+func flagValue(raw json.RawMessage) bool {
+	var b bool
+	if json.Unmarshal(raw, &b) == nil {
+		return b
 	}
-	return ""
+	var opts []json.RawMessage
+	if json.Unmarshal(raw, &opts) == nil {
+		return slices.ContainsFunc(opts, flagValue)
+	}
+	switch strings.ToLower(strings.TrimSpace(optionValue(raw))) {
+	case "yes", "true", "embargoed":
+		return true
+	}
+	return false
 }
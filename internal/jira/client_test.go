@@ -2,61 +2,46 @@ package jira
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
-	"net/http/httptest"
+	"slices"
 	"testing"
-)
-
-func TestSearchIssues(t *testing.T) {
-	issues := []Issue{
-		{
-			Key: "PROJQUAY-100",
-			Fields: IssueFields{
-				Summary:     "Fix auth bug",
-				Status:      StatusField{Name: "Closed"},
-				Priority:    PriorityField{Name: "Major"},
-				Labels:      []string{"qe-approved"},
-				FixVersions: []VersionField{{Name: "3.16.2"}},
-				Assignee:    &UserField{DisplayName: "Jane Doe"},
-				IssueType:   TypeField{Name: "Bug"},
-				Resolution:  &ResField{Name: "Done"},
-				Updated:     "2026-01-15T10:00:00.000+0000",
-			},
-		},
-	}
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/rest/api/3/search/jql" {
-			t.Errorf("unexpected path: %s", r.URL.Path)
-			http.Error(w, "not found", 404)
-			return
-		}
-
-		// Check Basic Auth header
-		wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("test@example.com:test-token"))
-		if r.Header.Get("Authorization") != wantAuth {
-			t.Errorf("unexpected auth: %s", r.Header.Get("Authorization"))
-		}
 
-		resp := searchResponse{
-			MaxResults: 100,
-			Issues:     issues,
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer srv.Close()
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/jiratest"
+)
 
+func newTestClient(srv *jiratest.Server, projects ...string) *Client {
 	client := New(Config{
-		BaseURL: srv.URL,
-		Email:   "test@example.com",
-		Token:   "test-token",
-		Project: "PROJQUAY",
+		BaseURL:    srv.URL,
+		Email:      "test@example.com",
+		Token:      "test-token",
+		Projects:   projects,
+		APIVersion: "3",
+	})
+	client.limiter = nil // no rate limiting in tests
+	return client
+}
+
+func TestSearchIssues(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.RequireAuth("test@example.com", "test-token")
+	srv.AddIssues(jiratest.Issue{
+		Key:            "PROJQUAY-100",
+		Summary:        "Fix auth bug",
+		Status:         "Closed",
+		Priority:       "Major",
+		Labels:         []string{"qe-approved"},
+		FixVersions:    []string{"3.16.2"},
+		TargetVersions: []string{"3.16.2"},
+		Assignee:       "Jane Doe",
+		IssueType:      "Bug",
+		Resolution:     "Done",
+		Updated:        "2026-01-15T10:00:00.000+0000",
 	})
-	client.minDelay = 0 // disable delay for tests
 
+	client := newTestClient(srv, "PROJQUAY")
 	result, err := client.SearchIssues(context.Background(), "3.16.2")
 	if err != nil {
 		t.Fatalf("SearchIssues: %v", err)
@@ -73,28 +58,13 @@ func TestSearchIssues(t *testing.T) {
 }
 
 func TestGetVersion(t *testing.T) {
-	versions := []VersionField{
-		{Name: "3.16.1", Released: true},
-		{Name: "3.16.2", Description: "z-stream", ReleaseDate: "2026-02-20", Released: false},
-	}
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/rest/api/3/project/PROJQUAY/versions" {
-			t.Errorf("unexpected path: %s", r.URL.Path)
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(versions)
-	}))
-	defer srv.Close()
-
-	client := New(Config{
-		BaseURL: srv.URL,
-		Email:   "test@example.com",
-		Token:   "test-token",
-		Project: "PROJQUAY",
-	})
-	client.minDelay = 0
+	srv := jiratest.New(t)
+	srv.AddVersions("PROJQUAY",
+		jiratest.Version{Name: "3.16.1", Released: true},
+		jiratest.Version{Name: "3.16.2", Description: "z-stream", ReleaseDate: "2026-02-20", Released: false},
+	)
 
+	client := newTestClient(srv, "PROJQUAY")
 	v, err := client.GetVersion(context.Background(), "3.16.2")
 	if err != nil {
 		t.Fatalf("GetVersion: %v", err)
@@ -114,36 +84,13 @@ func TestGetVersion(t *testing.T) {
 }
 
 func TestSearchIssuesPagination(t *testing.T) {
-	callCount := 0
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		token := r.URL.Query().Get("nextPageToken")
-
-		var resp searchResponse
-		if token == "" {
-			resp = searchResponse{
-				MaxResults:    2,
-				NextPageToken: "page2",
-				Issues: []Issue{
-					{Key: "PROJ-1"},
-					{Key: "PROJ-2"},
-				},
-			}
-		} else {
-			resp = searchResponse{
-				MaxResults: 2,
-				Issues: []Issue{
-					{Key: "PROJ-3"},
-				},
-			}
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer srv.Close()
+	srv := jiratest.New(t)
+	srv.SetPageSize(2)
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		srv.AddIssues(jiratest.Issue{Key: key, TargetVersions: []string{"1.0"}})
+	}
 
-	client := New(Config{BaseURL: srv.URL, Project: "PROJ"})
-	client.minDelay = 0
+	client := newTestClient(srv, "PROJ")
 	result, err := client.SearchIssues(context.Background(), "1.0")
 	if err != nil {
 		t.Fatalf("SearchIssues: %v", err)
@@ -151,73 +98,24 @@ func TestSearchIssuesPagination(t *testing.T) {
 	if len(result) != 3 {
 		t.Fatalf("got %d issues, want 3", len(result))
 	}
-	if callCount != 2 {
-		t.Errorf("expected 2 API calls for pagination, got %d", callCount)
+	if calls := len(srv.SearchRequests()); calls != 2 {
+		t.Errorf("expected 2 API calls for pagination, got %d", calls)
 	}
 }
 
 func TestDiscoverActiveReleases(t *testing.T) {
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/rest/api/3/search/jql" {
-			t.Errorf("unexpected path: %s", r.URL.Path)
-		}
-
-		jql := r.URL.Query().Get("jql")
-		if jql == "" {
-			t.Error("expected JQL parameter")
-		}
-
-		resp := searchResponse{
-			MaxResults: 100,
-			Issues: []Issue{
-				{
-					Key: "PROJQUAY-10276",
-					Fields: IssueFields{
-						Summary: "Release Quay v3.16.2",
-						Status:  StatusField{Name: "In Progress"},
-						DueDate: "2026-02-28",
-						Components: []ComponentField{
-							{Name: "-area/release"},
-						},
-					},
-				},
-				{
-					Key: "PROJQUAY-10170",
-					Fields: IssueFields{
-						Summary: "Release Quay v3.17.0",
-						Status:  StatusField{Name: "New"},
-						DueDate: "2026-03-15",
-						Components: []ComponentField{
-							{Name: "-area/release"},
-						},
-					},
-				},
-				{
-					Key: "PROJQUAY-10278",
-					Fields: IssueFields{
-						Summary: "Release OMR v2.0.10",
-						Status:  StatusField{Name: "Testing"},
-						DueDate: "2026-02-20",
-						Components: []ComponentField{
-							{Name: "-area/release"},
-						},
-					},
-				},
-			},
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer srv.Close()
-
-	client := New(Config{
-		BaseURL: srv.URL,
-		Email:   "test@example.com",
-		Token:   "test-token",
-		Project: "PROJQUAY",
-	})
-	client.minDelay = 0
-
+	srv := jiratest.New(t)
+	release := []string{"-area/release"}
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-10276", Summary: "Release Quay v3.16.2", Status: "In Progress", DueDate: "2026-02-28", Components: release},
+		jiratest.Issue{Key: "PROJQUAY-10170", Summary: "Release Quay v3.17.0", Status: "New", DueDate: "2026-03-15", Components: release},
+		jiratest.Issue{Key: "PROJQUAY-10278", Summary: "Release OMR v2.0.10", Status: "Testing", DueDate: "2026-02-20", Components: release},
+		// Excluded: closed release ticket and a non-release issue.
+		jiratest.Issue{Key: "PROJQUAY-9000", Summary: "Release Quay v3.15.0", Status: "Closed", Components: release},
+		jiratest.Issue{Key: "PROJQUAY-9001", Summary: "Bump Quay v3.16.2 deps", Status: "New"},
+	)
+
+	client := newTestClient(srv, "PROJQUAY")
 	releases, err := client.DiscoverActiveReleases(context.Background())
 	if err != nil {
 		t.Fatalf("DiscoverActiveReleases: %v", err)
@@ -318,8 +216,8 @@ func TestFixVersionToS3App(t *testing.T) {
 }
 
 func TestBuildSearchJQL(t *testing.T) {
-	client := New(Config{Project: "PROJQUAY"})
-	got := client.buildSearchJQL("quay-v3.16.2")
+	client := New(Config{Projects: []string{"PROJQUAY"}})
+	got := client.buildSearchJQL("PROJQUAY", "quay-v3.16.2")
 	want := `project=PROJQUAY AND "Target Version"="quay-v3.16.2"`
 	if got != want {
 		t.Errorf("buildSearchJQL:\n got %q\nwant %q", got, want)
@@ -327,24 +225,10 @@ func TestBuildSearchJQL(t *testing.T) {
 }
 
 func TestSearchIssuesTargetVersion(t *testing.T) {
-	var capturedJQL string
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		capturedJQL = r.URL.Query().Get("jql")
-		resp := searchResponse{
-			MaxResults: 100,
-			Issues:     []Issue{{Key: "PROJQUAY-10157"}},
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer srv.Close()
-
-	client := New(Config{
-		BaseURL: srv.URL,
-		Project: "PROJQUAY",
-	})
-	client.minDelay = 0
+	srv := jiratest.New(t)
+	srv.AddIssues(jiratest.Issue{Key: "PROJQUAY-10157", TargetVersions: []string{"quay-v3.17.0"}})
 
+	client := newTestClient(srv, "PROJQUAY")
 	result, err := client.SearchIssues(context.Background(), "quay-v3.17.0")
 	if err != nil {
 		t.Fatalf("SearchIssues: %v", err)
@@ -354,33 +238,96 @@ func TestSearchIssuesTargetVersion(t *testing.T) {
 	}
 
 	wantJQL := `project=PROJQUAY AND "Target Version"="quay-v3.17.0"`
-	if capturedJQL != wantJQL {
-		t.Errorf("JQL:\n got %q\nwant %q", capturedJQL, wantJQL)
+	if got := srv.SearchRequests()[0].JQL; got != wantJQL {
+		t.Errorf("JQL:\n got %q\nwant %q", got, wantJQL)
 	}
 }
 
-func TestRateLimitRetry(t *testing.T) {
-	callCount := 0
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		if callCount <= 2 {
-			w.Header().Set("Retry-After", "1")
-			w.WriteHeader(http.StatusTooManyRequests)
-			_, _ = w.Write([]byte("rate limited"))
-			return
+func TestSearchIssuesAPIv2(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.SetPageSize(2)
+	for i := range 5 {
+		srv.AddIssues(jiratest.Issue{Key: fmt.Sprintf("PROJ-%d", i), TargetVersions: []string{"1.0"}})
+	}
+	srv.AddVersions("PROJ", jiratest.Version{Name: "1.0", Released: true})
+
+	client := newTestClient(srv, "PROJ")
+	client.apiVersion = "2"
+	result, err := client.SearchIssues(context.Background(), "1.0")
+	if err != nil {
+		t.Fatalf("SearchIssues: %v", err)
+	}
+	if len(result) != 5 {
+		t.Fatalf("got %d issues, want 5", len(result))
+	}
+	var offsets []string
+	for _, r := range srv.SearchRequests() {
+		if r.Path != "/rest/api/2/search" {
+			t.Errorf("search path: got %s", r.Path)
 		}
-		resp := searchResponse{
-			MaxResults: 100,
-			Issues:     []Issue{{Key: "PROJ-1"}},
+		offsets = append(offsets, r.StartAt)
+	}
+	if want := []string{"0", "2", "4"}; !slices.Equal(offsets, want) {
+		t.Errorf("startAt: got %v, want %v", offsets, want)
+	}
+
+	v, err := client.GetVersion(context.Background(), "1.0")
+	if err != nil || !v.Released {
+		t.Errorf("GetVersion: got %+v, %v", v, err)
+	}
+	if last := srv.Requests()[len(srv.Requests())-1].Path; last != "/rest/api/2/project/PROJ/versions" {
+		t.Errorf("versions path: got %s", last)
+	}
+}
+
+func TestAutoAPIVersion(t *testing.T) {
+	tests := map[string]string{
+		"https://redhat.atlassian.net":     "3",
+		"https://example.jira.com/":        "3",
+		"https://issues.redhat.com":        "2",
+		"http://localhost:8080/jira":       "2",
+		"https://REDHAT.ATLASSIAN.NET:443": "3",
+	}
+	for baseURL, want := range tests {
+		if got := AutoAPIVersion(baseURL); got != want {
+			t.Errorf("AutoAPIVersion(%q): got %q, want %q", baseURL, got, want)
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer srv.Close()
+	}
+}
 
-	client := New(Config{BaseURL: srv.URL, Project: "PROJ"})
-	client.minDelay = 0
+func TestADFCustomField(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(jiratest.Issue{
+		Key:            "PROJ-1",
+		IssueType:      "Vulnerability",
+		TargetVersions: []string{"1.0"},
+		Fields: map[string]any{
+			"customfield_1": map[string]any{
+				"type": "doc", "version": 1,
+				"content": []any{map[string]any{
+					"type":    "paragraph",
+					"content": []any{map[string]any{"type": "text", "text": "Tracks CVE-2026-31337 in quay"}},
+				}},
+			},
+		},
+	})
+	client := New(Config{BaseURL: srv.URL, Projects: []string{"PROJ"}, CVEIDField: "customfield_1", APIVersion: "3"})
+	client.limiter = nil
+	result, err := client.SearchIssues(context.Background(), "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0].CVEID != "CVE-2026-31337" {
+		t.Errorf("CVE ID from ADF field: got %+v", result)
+	}
+}
+
+func TestRateLimitRetry(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.RateLimit(2, "1")
+	srv.AddIssues(jiratest.Issue{Key: "PROJ-1", TargetVersions: []string{"1.0"}})
 
+	client := newTestClient(srv, "PROJ")
 	result, err := client.SearchIssues(context.Background(), "1.0")
 	if err != nil {
 		t.Fatalf("SearchIssues after retries: %v", err)
@@ -388,7 +335,40 @@ func TestRateLimitRetry(t *testing.T) {
 	if len(result) != 1 {
 		t.Fatalf("got %d issues, want 1", len(result))
 	}
-	if callCount != 3 {
-		t.Errorf("expected 3 calls (2 retries + 1 success), got %d", callCount)
+	if calls := len(srv.Requests()); calls != 3 {
+		t.Errorf("expected 3 calls (2 retries + 1 success), got %d", calls)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.Fail(100, http.StatusServiceUnavailable)
+
+	client := newTestClient(srv, "PROJ")
+	for i := 0; i < breaker.DefaultThreshold; i++ {
+		if _, err := client.SearchIssues(context.Background(), "1.0"); err == nil || errors.Is(err, breaker.ErrOpen) {
+			t.Fatalf("call %d: got %v, want upstream error", i, err)
+		}
+	}
+	if _, err := client.SearchIssues(context.Background(), "1.0"); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("after %d failures: got %v, want ErrOpen", breaker.DefaultThreshold, err)
+	}
+	if calls := len(srv.Requests()); calls != breaker.DefaultThreshold {
+		t.Errorf("open breaker should not reach JIRA: got %d calls, want %d", calls, breaker.DefaultThreshold)
+	}
+	if st := client.Breaker().Status(); st.State != "open" || st.RetryAt == nil {
+		t.Errorf("status: got %+v", st)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.Fail(100, http.StatusBadRequest)
+
+	client := newTestClient(srv, "PROJ")
+	for i := 0; i < breaker.DefaultThreshold+1; i++ {
+		if _, err := client.SearchIssues(context.Background(), "1.0"); errors.Is(err, breaker.ErrOpen) {
+			t.Fatalf("call %d: 4xx responses tripped the breaker", i)
+		}
 	}
 }
@@ -0,0 +1,151 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// MappingRule maps the fixVersions matching a regular expression to an S3
+// application prefix.
+type MappingRule struct {
+	// FixVersion is a regular expression matched against fixVersions.
+	FixVersion string `json:"fix_version"`
+	// Application is the application prefix of a matching fixVersion,
+	// expanded with the match's groups as by regexp.Expand: "$name" or
+	// "${name}" for a named group, "$1" for a numbered one.
+	Application string `json:"application"`
+
+	re *regexp.Regexp
+}
+
+// Mapping is an ordered list of rules mapping fixVersions to S3
+// applications; the first rule that matches a fixVersion wins.
+type Mapping struct {
+	rules []MappingRule
+}
+
+// DefaultMapping is the PROJQUAY scheme: "{product}-v{major}.{minor}.{patch}"
+// fixVersions belong to the "{product}-v{major}-{minor}" application, and
+// plain "{major}.{minor}.{patch}" versions are Quay's.
+var DefaultMapping = mustNewMapping([]MappingRule{
+	{FixVersion: `^(?P<product>[\w-]+?)-v(?P<major>\d+)\.(?P<minor>\d+)`, Application: "${product}-v${major}-${minor}"},
+	{FixVersion: `^(?P<major>\d+)\.(?P<minor>\d+)`, Application: "quay-v${major}-${minor}"},
+})
+
+// NewMapping compiles rules into a Mapping.
+func NewMapping(rules []MappingRule) (*Mapping, error) {
+	if len(rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+	m := &Mapping{rules: make([]MappingRule, len(rules))}
+	for i, r := range rules {
+		if err := r.compile(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		m.rules[i] = r
+	}
+	return m, nil
+}
+
+func mustNewMapping(rules []MappingRule) *Mapping {
+	m, err := NewMapping(rules)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// LoadMapping reads a JSON array of rules from the file at path. The rules
+// replace DefaultMapping entirely.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []MappingRule
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	m, err := NewMapping(rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// templateRef matches the references of an application template: "$$",
+// "${name}" or "$name".
+var templateRef = regexp.MustCompile(`\$(?:\$|\{(\w+)\}|(\w+))`)
+
+func (r *MappingRule) compile() error {
+	re, err := regexp.Compile(r.FixVersion)
+	if err != nil {
+		return fmt.Errorf("fix_version: %w", err)
+	}
+	if r.Application == "" {
+		return errors.New("application is required")
+	}
+	// regexp.Expand silently expands unknown groups to nothing, which
+	// would map every fixVersion to the same application.
+	for _, m := range templateRef.FindAllStringSubmatch(r.Application, -1) {
+		name := m[1] + m[2]
+		if name == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(name); err == nil {
+			if n > re.NumSubexp() {
+				return fmt.Errorf("application refers to group %d, but fix_version has %d", n, re.NumSubexp())
+			}
+		} else if re.SubexpIndex(name) < 0 {
+			return fmt.Errorf("application refers to group %q, which fix_version lacks", name)
+		}
+	}
+	r.re = re
+	return nil
+}
+
+// Rules returns the rules of m, in order.
+func (m *Mapping) Rules() []MappingRule {
+	return m.rules
+}
+
+// Match returns the application of fixVersion and the index of the rule
+// that mapped it. rule is -1, and application empty, if no rule matches.
+func (m *Mapping) Match(fixVersion string) (application string, rule int) {
+	for i, r := range m.rules {
+		match := r.re.FindStringSubmatchIndex(fixVersion)
+		if match == nil {
+			continue
+		}
+		if app := string(r.re.ExpandString(nil, r.Application, fixVersion, match)); app != "" {
+			return app, i
+		}
+	}
+	return "", -1
+}
+
+// Application returns the S3 application prefix of fixVersion, or "" if no
+// rule matches it.
+func (m *Mapping) Application(fixVersion string) string {
+	app, _ := m.Match(fixVersion)
+	return app
+}
+
+// FixVersions maps back from application: it returns those of fixVersions
+// that map to it, in order.
+func (m *Mapping) FixVersions(application string, fixVersions []string) []string {
+	var matched []string
+	for _, fv := range fixVersions {
+		if m.Application(fv) == application {
+			matched = append(matched, fv)
+		}
+	}
+	return matched
+}
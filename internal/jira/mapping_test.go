@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMapping(t *testing.T) {
+	m, err := NewMapping([]MappingRule{
+		{FixVersion: `^widget-(\d+)\.(\d+)`, Application: "widget-v$1-$2"},
+		{FixVersion: `^(?P<product>\w+)-v(?P<major>\d+)\.\d+`, Application: "${product}-v${major}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		fixVersion, want string
+		rule             int
+	}{
+		{"widget-1.4.0", "widget-v1-4", 0},
+		{"gadget-v2.0.1", "gadget-v2", 1},
+		{"3.16.3", "", -1},
+	}
+	for _, tc := range tests {
+		if app, rule := m.Match(tc.fixVersion); app != tc.want || rule != tc.rule {
+			t.Errorf("Match(%q): got %q, %d, want %q, %d", tc.fixVersion, app, rule, tc.want, tc.rule)
+		}
+	}
+	got := m.FixVersions("widget-v1-4", []string{"widget-1.4.0", "widget-1.5.0", "widget-1.4.1"})
+	if want := []string{"widget-1.4.0", "widget-1.4.1"}; !slices.Equal(got, want) {
+		t.Errorf("FixVersions: got %v, want %v", got, want)
+	}
+
+	for name, rules := range map[string][]MappingRule{
+		"no rules":      nil,
+		"bad pattern":   {{FixVersion: "(", Application: "x"}},
+		"no template":   {{FixVersion: "x"}},
+		"unknown name":  {{FixVersion: `(?P<major>\d+)`, Application: "app-v${minor}"}},
+		"unknown index": {{FixVersion: `(\d+)`, Application: "app-v$2"}},
+	} {
+		if _, err := NewMapping(rules); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, []byte(`[{"fix_version": "^(\\d+)\\.(\\d+)", "application": "widget-v$1-$2"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadMapping(path)
+	if err != nil {
+		t.Fatalf("LoadMapping: %v", err)
+	}
+	if got := m.Application("1.4.0"); got != "widget-v1-4" {
+		t.Errorf("Application: got %q, want widget-v1-4", got)
+	}
+	if err := os.WriteFile(path, []byte(`[{"fixversion": "x", "application": "x"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMapping(path); err == nil {
+		t.Error("unknown field: expected an error")
+	}
+}
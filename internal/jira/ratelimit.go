@@ -0,0 +1,172 @@
+package jira
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default request budget of a Client.
+const (
+	DefaultRequestsPerSecond = 1.0
+	DefaultBurst             = 3
+)
+
+// Limiter is a token bucket shared by every request a Client makes, so
+// release discovery and the per-version searches of a sync cycle draw on
+// one budget. When JIRA reports the budget exhausted, through a 429 or its
+// X-RateLimit-* headers, the limiter pauses all callers until the reset.
+// A nil *Limiter does not limit.
+type Limiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewLimiter returns a limiter allowing rps requests per second on average
+// and bursts of up to burst requests. A non-positive rps means no limit
+// and returns nil.
+func NewLimiter(rps float64, burst int) *Limiter {
+	if rps <= 0 {
+		return nil
+	}
+	b := float64(max(burst, 1))
+	return &Limiter{rate: rps, burst: b, tokens: b, last: time.Now()}
+}
+
+// Wait blocks until a request may be made or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+		var wait time.Duration
+		switch {
+		case now.Before(l.pausedUntil):
+			wait = l.pausedUntil.Sub(now)
+		case l.tokens >= 1:
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// PauseUntil holds back every request until t, and lets the bucket refill
+// only from then on so that requests resume at the steady rate.
+func (l *Limiter) PauseUntil(t time.Time) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+		l.tokens = 0
+		l.last = t
+	}
+}
+
+// Observe adapts to the rate limit headers of a JIRA response: an
+// exhausted budget (X-RateLimit-Remaining: 0) pauses requests until
+// X-RateLimit-Reset, and X-RateLimit-NearLimit empties the bucket so no
+// burst follows.
+func (l *Limiter) Observe(h http.Header) {
+	if l == nil {
+		return
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := rateLimitReset(h, time.Now()); ok {
+			l.PauseUntil(reset)
+			return
+		}
+	}
+	if h.Get("X-RateLimit-NearLimit") == "true" {
+		l.mu.Lock()
+		l.refill(time.Now())
+		l.tokens = 0
+		l.mu.Unlock()
+	}
+}
+
+// rateLimitReset reads X-RateLimit-Reset, which Atlassian sends as an
+// ISO 8601 timestamp; a number of seconds from now is accepted as well.
+func rateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	v := h.Get("X-RateLimit-Reset")
+	if v == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return now.Add(time.Duration(secs) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// backoff returns how long to wait before retry attempt n (from 1) after a
+// 429. JIRA's Retry-After or X-RateLimit-Reset is honored with up to 10%
+// jitter added; without either, the delay doubles from 2s with full
+// jitter over its upper half, so clients rate limited together spread out.
+func backoff(err *rateLimitError, attempt int, now time.Time) time.Duration {
+	if d, ok := retryAfter(err.retryAfter, now); ok {
+		return d + jitter(d/10)
+	}
+	if !err.reset.IsZero() && err.reset.After(now) {
+		d := err.reset.Sub(now)
+		return d + jitter(d/10)
+	}
+	d := time.Duration(1<<min(attempt, 6)) * time.Second
+	return d/2 + jitter(d/2)
+}
+
+// jitter returns a random duration in [0, d].
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
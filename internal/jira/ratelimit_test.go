@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLimiterBurstThenRate(t *testing.T) {
+	l := NewLimiter(50, 2)
+	ctx := context.Background()
+	start := time.Now()
+	for range 4 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two requests use the burst; the other two wait 20ms each.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("4 requests at 50/s with burst 2 took %v, want at least 40ms", elapsed)
+	}
+
+	if NewLimiter(0, 5) != nil {
+		t.Error("NewLimiter(0, ...) should not limit")
+	}
+	var unlimited *Limiter
+	if err := unlimited.Wait(ctx); err != nil {
+		t.Errorf("nil limiter: %v", err)
+	}
+}
+
+func TestLimiterObserve(t *testing.T) {
+	l := NewLimiter(1000, 10)
+	reset := time.Now().Add(80 * time.Millisecond)
+	l.Observe(http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {reset.UTC().Format(time.RFC3339Nano)},
+	})
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("exhausted budget: waited %v, want until the reset", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	l.PauseUntil(time.Now().Add(time.Hour))
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("paused limiter: got %v, want the context's error", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		err      rateLimitError
+		attempt  int
+		min, max time.Duration
+	}{
+		{"Retry-After seconds", rateLimitError{retryAfter: "10"}, 1, 10 * time.Second, 11 * time.Second},
+		{"Retry-After date", rateLimitError{retryAfter: now.Add(20 * time.Second).Format(http.TimeFormat)}, 1, 20 * time.Second, 22 * time.Second},
+		{"X-RateLimit-Reset", rateLimitError{reset: now.Add(30 * time.Second)}, 1, 30 * time.Second, 33 * time.Second},
+		{"first retry", rateLimitError{}, 1, time.Second, 2 * time.Second},
+		{"third retry", rateLimitError{}, 3, 4 * time.Second, 8 * time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if d := backoff(&tt.err, tt.attempt, now); d < tt.min || d > tt.max {
+				t.Errorf("%s: got %v, want between %v and %v", tt.name, d, tt.min, tt.max)
+				break
+			}
+		}
+	}
+}
@@ -1,13 +1,21 @@
 package jira
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/events"
 	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/runstatus"
+	"github.com/quay/release-readiness/internal/tracing"
 )
 
 // Store is the subset of the database layer needed by the JIRA syncer.
@@ -16,66 +24,137 @@ type Store interface {
 	UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord) error
 	DeleteJiraIssuesNotIn(ctx context.Context, fixVersion string, keys []string) error
 	ListActiveReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error)
+	ListHiddenReleaseVersions(ctx context.Context) ([]string, error)
+	ArchiveReleaseIssues(ctx context.Context, fixVersion string) (bool, error)
+	ListJiraSyncStates(ctx context.Context) (map[string]model.JiraSyncState, error)
+	SaveJiraSyncState(ctx context.Context, state model.JiraSyncState) error
+	ListJiraIssueVersions(ctx context.Context, key string) ([]string, error)
+	DeleteJiraIssue(ctx context.Context, key, fixVersion string) error
+	ListApplicationConfigs(ctx context.Context) ([]model.ApplicationConfig, error)
 }
 
+// DefaultFullSyncInterval is how often each fixVersion gets a full sync by
+// default. Syncs in between only fetch issues updated since the last one.
+const DefaultFullSyncInterval = time.Hour
+
+// syncOverlap widens incremental sync windows to cover issues JIRA had not
+// yet indexed when the previous search ran.
+const syncOverlap = 2 * time.Minute
+
 // TxFunc wraps a function in a database transaction, passing a tx-scoped Store.
 type TxFunc func(ctx context.Context, fn func(Store) error) error
 
 // Syncer orchestrates periodic JIRA synchronisation into a Store.
 type Syncer struct {
-	client *Client
-	store  Store
-	withTx TxFunc
-	logger *slog.Logger
+	client           *Client
+	store            Store
+	withTx           TxFunc
+	logger           *slog.Logger
+	fullSyncInterval time.Duration
+	status           *runstatus.Tracker
+	events           *events.Broker
 }
 
 // NewSyncer creates a Syncer that uses client to fetch data and store to persist it.
 func NewSyncer(client *Client, store Store, withTx TxFunc, logger *slog.Logger) *Syncer {
-	return &Syncer{client: client, store: store, withTx: withTx, logger: logger}
+	return &Syncer{client: client, store: store, withTx: withTx, logger: logger, fullSyncInterval: DefaultFullSyncInterval, status: runstatus.New("jira")}
 }
 
-// Run performs an immediate sync and then repeats every interval until ctx is cancelled.
-func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
-	s.SyncOnce(ctx)
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			s.logger.Info("stopping")
-			return
-		case <-ticker.C:
-			s.SyncOnce(ctx)
-		}
-	}
+// RunStatus returns the tracker of the syncer's polls, for the sync status
+// API.
+func (s *Syncer) RunStatus() *runstatus.Tracker {
+	return s.status
+}
+
+// SetFullSyncInterval sets how often each fixVersion is fully re-synced.
+// Only full syncs remove issues that left the version. Zero makes every
+// sync a full one.
+func (s *Syncer) SetFullSyncInterval(d time.Duration) {
+	s.fullSyncInterval = d
+}
+
+// SetEvents makes the syncer announce each release whose issues changed on
+// b.
+func (s *Syncer) SetEvents(b *events.Broker) {
+	s.events = b
 }
 
-// SyncOnce discovers active releases and syncs their issues.
+// SyncOnce discovers active releases and syncs their issues. Each
+// cycle is tagged with a request ID (unless ctx already carries one) so its
+// log lines can be correlated.
 func (s *Syncer) SyncOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	ctx, span := tracing.Start(ctx, "jira sync", slog.String("request_id", requestid.FromContext(ctx)))
+	defer span.End()
+	run := s.status.Start()
+	defer run.Finish()
+
 	releases, err := s.client.DiscoverActiveReleases(ctx)
+	if errors.Is(err, breaker.ErrOpen) {
+		s.logger.WarnContext(ctx, "skipping sync, upstream unavailable", "error", err)
+		run.Fail(err)
+		span.RecordError(err)
+		return
+	}
 	if err != nil {
-		s.logger.Error("discover releases", "error", err)
+		s.logger.ErrorContext(ctx, "discover releases", "error", err)
+		run.Fail(err)
+		span.RecordError(err)
 		return
 	}
+	span.SetAttributes(slog.Int("releases", len(releases)))
+
+	s.logger.InfoContext(ctx, "discovered active releases", "count", len(releases))
 
-	s.logger.Info("discovered active releases", "count", len(releases))
+	states, err := s.store.ListJiraSyncStates(ctx)
+	if err != nil {
+		// Without sync states every version gets a full sync.
+		s.logger.ErrorContext(ctx, "list sync states", "error", err)
+	}
+
+	// Applications configured with fixVersions take those releases over
+	// from the application derived from their names.
+	apps := make(map[string]string)
+	configs, err := s.store.ListApplicationConfigs(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list application configs", "error", err)
+	}
+	for _, c := range configs {
+		for _, fv := range c.FixVersions {
+			apps[fv] = c.Name
+		}
+	}
+
+	// Releases hidden by an admin are not synced.
+	hidden := make(map[string]bool)
+	names, err := s.store.ListHiddenReleaseVersions(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list hidden releases", "error", err)
+	}
+	for _, name := range names {
+		hidden[name] = true
+	}
 
 	activeSet := make(map[string]bool, len(releases))
 
 	for _, rel := range releases {
 		activeSet[rel.FixVersion] = true
+		if hidden[rel.FixVersion] {
+			s.logger.DebugContext(ctx, "skipping hidden release", "version", rel.FixVersion)
+			continue
+		}
 
 		rv := &model.ReleaseVersion{
 			Name:                  rel.FixVersion,
 			ReleaseTicketKey:      rel.ReleaseTicketKey,
 			ReleaseTicketAssignee: rel.Assignee,
-			S3Application:         rel.S3Application,
+			S3Application:         cmp.Or(apps[rel.FixVersion], rel.S3Application),
 			DueDate:               rel.DueDate,
 		}
 
 		versionInfo, err := s.client.GetVersion(ctx, rel.FixVersion)
 		if err != nil {
-			s.logger.Warn("get version metadata", "version", rel.FixVersion, "error", err)
+			s.logger.WarnContext(ctx, "get version metadata", "version", rel.FixVersion, "error", err)
 		} else {
 			rv.Description = versionInfo.Description
 			rv.Released = versionInfo.Released
@@ -89,10 +168,19 @@ func (s *Syncer) SyncOnce(ctx context.Context) {
 		}
 
 		if err := s.store.UpsertReleaseVersion(ctx, rv); err != nil {
-			s.logger.Error("upsert version", "version", rel.FixVersion, "error", err)
+			s.logger.ErrorContext(ctx, "upsert version", "version", rel.FixVersion, "error", err)
+			run.Fail(fmt.Errorf("upsert version %s: %w", rel.FixVersion, err))
 		}
 
-		s.syncVersion(ctx, rel.FixVersion)
+		// A released version gets a full sync, since its issue set is
+		// about to be archived.
+		full := rv.Released || s.fullSyncDue(states[rel.FixVersion])
+		n, err := s.syncVersion(ctx, rel.FixVersion, states[rel.FixVersion], full)
+		run.Add(n)
+		run.Fail(err)
+		if err == nil && rv.Released {
+			s.archiveIssues(ctx, rel.FixVersion)
+		}
 	}
 
 	// Reconcile unreleased versions in DB that may have been released in
@@ -100,7 +188,7 @@ func (s *Syncer) SyncOnce(ctx context.Context) {
 	// DiscoverActiveReleases).
 	dbVersions, err := s.store.ListActiveReleaseVersions(ctx)
 	if err != nil {
-		s.logger.Error("list active db versions", "error", err)
+		s.logger.ErrorContext(ctx, "list active db versions", "error", err)
 	} else {
 		for _, dbv := range dbVersions {
 			if activeSet[dbv.Name] {
@@ -120,73 +208,151 @@ func (s *Syncer) SyncOnce(ctx context.Context) {
 					}
 				}
 				if err := s.store.UpsertReleaseVersion(ctx, &dbv); err != nil {
-					s.logger.Error("upsert version", "version", dbv.Name, "error", err)
+					s.logger.ErrorContext(ctx, "upsert version", "version", dbv.Name, "error", err)
+					run.Fail(fmt.Errorf("upsert version %s: %w", dbv.Name, err))
+				}
+				n, err := s.syncVersion(ctx, dbv.Name, states[dbv.Name], true)
+				run.Add(n)
+				run.Fail(err)
+				if err == nil && versionInfo.Released {
+					s.archiveIssues(ctx, dbv.Name)
 				}
-				s.syncVersion(ctx, dbv.Name)
-				s.logger.Info("reconciled version", "version", dbv.Name, "released", versionInfo.Released)
+				s.logger.InfoContext(ctx, "reconciled version", "version", dbv.Name, "released", versionInfo.Released)
 			}
 		}
 	}
 }
 
-// syncVersion fetches all issues for a single fixVersion and upserts them.
-func (s *Syncer) syncVersion(ctx context.Context, fixVersion string) {
-	issues, err := s.client.SearchIssues(ctx, fixVersion)
+// fullSyncDue reports whether a fixVersion with the given state needs a full
+// sync rather than an incremental one.
+func (s *Syncer) fullSyncDue(state model.JiraSyncState) bool {
+	return s.fullSyncInterval <= 0 || state.SyncedAt == nil || state.ReconciledAt == nil ||
+		time.Since(*state.ReconciledAt) >= s.fullSyncInterval
+}
+
+// syncVersion fetches the issues of a single fixVersion and upserts them.
+// A full sync fetches every issue and removes the ones no longer in the
+// version; an incremental sync only fetches issues updated since the last
+// sync in state. It returns the number of issues stored; failures are
+// logged and returned.
+func (s *Syncer) syncVersion(ctx context.Context, fixVersion string, state model.JiraSyncState, full bool) (int, error) {
+	started := time.Now().UTC()
+	var issues []Issue
+	var err error
+	if full {
+		issues, err = s.client.SearchIssues(ctx, fixVersion)
+	} else {
+		issues, err = s.client.SearchUpdatedIssues(ctx, fixVersion, started.Sub(*state.SyncedAt)+syncOverlap)
+	}
 	if err != nil {
-		s.logger.Error("search issues", "version", fixVersion, "error", err)
-		return
+		s.logger.ErrorContext(ctx, "search issues", "version", fixVersion, "error", err)
+		return 0, fmt.Errorf("search issues of %s: %w", fixVersion, err)
 	}
 
 	if err := s.withTx(ctx, func(txStore Store) error {
 		var keys []string
 		for _, issue := range issues {
 			keys = append(keys, issue.Key)
-
-			labels := strings.Join(issue.Fields.Labels, ",")
-			assignee := ""
-			if issue.Fields.Assignee != nil {
-				assignee = issue.Fields.Assignee.DisplayName
-			}
-			resolution := ""
-			if issue.Fields.Resolution != nil {
-				resolution = issue.Fields.Resolution.Name
-			}
-
-			updatedAt, _ := time.Parse("2006-01-02T15:04:05.000-0700", issue.Fields.Updated)
-			if updatedAt.IsZero() {
-				updatedAt = time.Now().UTC()
-			}
-
-			jiraURL := fmt.Sprintf("%s/browse/%s", s.client.BaseURL(), issue.Key)
-
-			record := &model.JiraIssueRecord{
-				Key:        issue.Key,
-				Summary:    issue.Fields.Summary,
-				Status:     issue.Fields.Status.Name,
-				Priority:   issue.Fields.Priority.Name,
-				Labels:     labels,
-				FixVersion: fixVersion,
-				Assignee:   assignee,
-				IssueType:  issue.Fields.IssueType.Name,
-				Resolution: resolution,
-				Link:       jiraURL,
-				QAContact:  issue.QAContact,
-				UpdatedAt:  updatedAt,
-			}
-
-			if err := txStore.UpsertJiraIssue(ctx, record); err != nil {
+			if err := txStore.UpsertJiraIssue(ctx, s.issueRecord(issue, fixVersion)); err != nil {
 				return fmt.Errorf("upsert issue %s: %w", issue.Key, err)
 			}
 		}
 
-		if err := txStore.DeleteJiraIssuesNotIn(ctx, fixVersion, keys); err != nil {
-			return fmt.Errorf("cleanup issues: %w", err)
+		next := model.JiraSyncState{FixVersion: fixVersion, SyncedAt: &started, ReconciledAt: state.ReconciledAt}
+		if full {
+			if err := txStore.DeleteJiraIssuesNotIn(ctx, fixVersion, keys); err != nil {
+				return fmt.Errorf("cleanup issues: %w", err)
+			}
+			next.ReconciledAt = &started
+		}
+		if err := txStore.SaveJiraSyncState(ctx, next); err != nil {
+			return fmt.Errorf("save sync state: %w", err)
 		}
 		return nil
 	}); err != nil {
-		s.logger.Error("sync version", "version", fixVersion, "error", err)
-		return
+		s.logger.ErrorContext(ctx, "sync version", "version", fixVersion, "error", err)
+		return 0, fmt.Errorf("sync version %s: %w", fixVersion, err)
+	}
+
+	s.logger.InfoContext(ctx, "synced issues", "count", len(issues), "version", fixVersion, "full", full)
+	if len(issues) > 0 {
+		s.events.Publish(events.Event{Kind: events.KindIssues, Release: fixVersion})
+	}
+	return len(issues), nil
+}
+
+// issueRecord converts an issue to the row stored under fixVersion.
+func (s *Syncer) issueRecord(issue Issue, fixVersion string) *model.JiraIssueRecord {
+	assignee := ""
+	if issue.Fields.Assignee != nil {
+		assignee = issue.Fields.Assignee.DisplayName
+	}
+	resolution := ""
+	if issue.Fields.Resolution != nil {
+		resolution = issue.Fields.Resolution.Name
+	}
+
+	updatedAt, _ := time.Parse("2006-01-02T15:04:05.000-0700", issue.Fields.Updated)
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+
+	var blockedBy []model.IssueBlocker
+	for _, b := range issue.Blockers() {
+		blockedBy = append(blockedBy, model.IssueBlocker{
+			Key:     b.Key,
+			Summary: b.Fields.Summary,
+			Status:  b.Fields.Status.Name,
+			Link:    fmt.Sprintf("%s/browse/%s", s.client.BaseURL(), b.Key),
+		})
+	}
+
+	var subtasks []model.IssueSubtask
+	for _, t := range issue.Fields.Subtasks {
+		subtasks = append(subtasks, model.IssueSubtask{
+			Key:     t.Key,
+			Summary: t.Fields.Summary,
+			Status:  t.Fields.Status.Name,
+			Link:    fmt.Sprintf("%s/browse/%s", s.client.BaseURL(), t.Key),
+		})
 	}
 
-	s.logger.Info("synced issues", "count", len(issues), "version", fixVersion)
+	return &model.JiraIssueRecord{
+		Key:        issue.Key,
+		Project:    model.IssueProject(issue.Key),
+		Summary:    issue.Fields.Summary,
+		Status:     issue.Fields.Status.Name,
+		Priority:   issue.Fields.Priority.Name,
+		Labels:     strings.Join(issue.Fields.Labels, ","),
+		FixVersion: fixVersion,
+		Assignee:   assignee,
+		IssueType:  issue.Fields.IssueType.Name,
+		Resolution: resolution,
+		Link:       fmt.Sprintf("%s/browse/%s", s.client.BaseURL(), issue.Key),
+		QAContact:  issue.QAContact,
+		Severity:   issue.Severity,
+		Clones:     strings.Join(issue.CloneKeys(), ","),
+		Components: strings.Join(issue.ComponentNames(), ","),
+		UpdatedAt:  updatedAt,
+		CVEID:      issue.CVEID,
+		CVSSScore:  issue.CVSSScore,
+		Embargoed:  issue.Embargoed,
+		Blocker:    slices.ContainsFunc(issue.Fields.Labels, model.IsBlockerLabel),
+		BlockedBy:  blockedBy,
+		Subtasks:   subtasks,
+	}
+}
+
+// archiveIssues freezes the issue set of a released version, so later JIRA
+// edits and fixVersion moves don't rewrite what shipped. Versions already
+// archived are left alone.
+func (s *Syncer) archiveIssues(ctx context.Context, fixVersion string) {
+	archived, err := s.store.ArchiveReleaseIssues(ctx, fixVersion)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "archive release issues", "version", fixVersion, "error", err)
+		return
+	}
+	if archived {
+		s.logger.InfoContext(ctx, "archived release issues", "version", fixVersion)
+	}
 }
@@ -0,0 +1,692 @@
+package jira
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/jiratest"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+func newTestSyncer(t *testing.T, srv *jiratest.Server) (*Syncer, *db.DB) {
+	t.Helper()
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	withTx := func(ctx context.Context, fn func(Store) error) error {
+		return database.InTx(ctx, func(txDB *db.DB) error { return fn(txDB) })
+	}
+	return NewSyncer(newTestClient(srv, "PROJQUAY"), database, withTx, slog.Default()), database
+}
+
+func TestSyncOnce(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", DueDate: "2026-02-28", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Open", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}, Components: []string{"quay", "clair"}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "verify fix", Status: "Verified", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2", Description: "z-stream"})
+
+	syncer, database := newTestSyncer(t, srv)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	rel, err := database.GetReleaseVersion(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("get release: %v", err)
+	}
+	if rel.Description != "z-stream" || rel.ReleaseTicketKey != "PROJQUAY-1" || rel.S3Application != "quay-v3-16" {
+		t.Errorf("release: got %+v", rel)
+	}
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("issue summary: %v", err)
+	}
+	if summary.Total != 2 || summary.Open != 1 || summary.Verified != 1 {
+		t.Errorf("summary: got %+v, want total=2 open=1 verified=1", summary)
+	}
+	if st := syncer.RunStatus().Status(); !st.LastRunOK || st.ItemsProcessed != 2 || st.LastFinishedAt == nil {
+		t.Errorf("run status: got %+v", st)
+	}
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Components != "quay,clair" || issues[1].Components != "" {
+		t.Errorf("issue components: got %+v", issues)
+	}
+
+	// Issues removed from the version in JIRA are removed locally by the
+	// next full sync.
+	syncer.SetFullSyncInterval(0)
+	srv.SetIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Open", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	syncer.SyncOnce(ctx)
+
+	summary, err = database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("issue summary: %v", err)
+	}
+	if summary.Total != 1 {
+		t.Errorf("total after removal: got %d, want 1", summary.Total)
+	}
+}
+
+func TestSyncOnceMultipleProjects(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Open", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "SECURITY-7", Summary: "CVE-2026-0001", Status: "Verified", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "SECURITY-8", Summary: "CVE-2026-0002", Status: "New", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "OTHER-1", Summary: "unrelated", Status: "New", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.17.0"})
+	srv.AddVersions("SECURITY", jiratest.Version{Name: "quay-v3.16.2", Description: "security"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.client = newTestClient(srv, "PROJQUAY", "SECURITY")
+	syncer.SetFullSyncInterval(0)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	// The version is found in the second project.
+	rel, err := database.GetReleaseVersion(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("get release: %v", err)
+	}
+	if rel.Description != "security" {
+		t.Errorf("release description: got %q, want security", rel.Description)
+	}
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatalf("issue summary: %v", err)
+	}
+	if summary.Total != 3 || summary.Open != 2 || summary.CVEs != 2 {
+		t.Errorf("summary: got %+v, want total=3 open=2 cves=2", summary)
+	}
+	want := []model.ProjectCount{{Project: "PROJQUAY", Total: 1, Open: 1}, {Project: "SECURITY", Total: 2, Open: 1}}
+	if !slices.Equal(summary.Projects, want) {
+		t.Errorf("projects: got %+v, want %+v", summary.Projects, want)
+	}
+
+	// A full sync of one project does not drop the other project's issues.
+	syncer.SyncOnce(ctx)
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, i := range issues {
+		keys = append(keys, i.Project+":"+i.Key)
+	}
+	if want := []string{"PROJQUAY:PROJQUAY-2", "SECURITY:SECURITY-7", "SECURITY:SECURITY-8"}; !slices.Equal(keys, want) {
+		t.Errorf("issues: got %v, want %v", keys, want)
+	}
+}
+
+func TestSyncOnceIncremental(t *testing.T) {
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000-0700")
+	old := time.Now().Add(-48 * time.Hour).UTC().Format("2006-01-02T15:04:05.000-0700")
+	release := jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}}
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		release,
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Open", Updated: old, TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "moved away", Status: "Open", Updated: old, TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	ctx := t.Context()
+
+	// versionSearches returns the JQL of issue searches for the version
+	// since the last call.
+	seen := 0
+	versionSearches := func() []string {
+		var jql []string
+		reqs := srv.SearchRequests()
+		for _, r := range reqs[seen:] {
+			if strings.Contains(r.JQL, `"Target Version"`) && r.NextPageToken == "" {
+				jql = append(jql, r.JQL)
+			}
+		}
+		seen = len(reqs)
+		return jql
+	}
+	total := func() int {
+		t.Helper()
+		summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return summary.Total
+	}
+
+	syncer.SyncOnce(ctx)
+	if jql := versionSearches(); len(jql) != 1 || strings.Contains(jql[0], "updated") {
+		t.Errorf("first sync: got searches %q, want one full search", jql)
+	}
+	if n := total(); n != 2 {
+		t.Fatalf("after full sync: got %d issues, want 2", n)
+	}
+	states, err := database.ListJiraSyncStates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := states["quay-v3.16.2"]; st.SyncedAt == nil || st.ReconciledAt == nil {
+		t.Errorf("sync state after full sync: got %+v", st)
+	}
+
+	// PROJQUAY-2 is updated, PROJQUAY-3 leaves the version, PROJQUAY-4 joins.
+	srv.SetIssues(
+		release,
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Verified", Updated: now, TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-4", Summary: "new bug", Status: "Open", Updated: now, TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	syncer.SyncOnce(ctx)
+	if jql := versionSearches(); len(jql) != 1 || !strings.HasSuffix(jql[0], ` AND updated >= "-3m"`) {
+		t.Errorf("second sync: got searches %q, want one incremental search", jql)
+	}
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 3 || summary.Verified != 1 {
+		t.Errorf("after incremental sync: got %+v, want 3 issues with 1 verified", summary)
+	}
+
+	syncer.SetFullSyncInterval(0)
+	syncer.SyncOnce(ctx)
+	if jql := versionSearches(); len(jql) != 1 || strings.Contains(jql[0], "updated") {
+		t.Errorf("reconciliation: got searches %q, want one full search", jql)
+	}
+	if n := total(); n != 2 {
+		t.Errorf("after reconciliation: got %d issues, want 2", n)
+	}
+}
+
+func TestSyncOnceSeverity(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "CVE-2026-1", Status: "Open", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"customfield_1": map[string]any{"value": "Important", "id": "1"}}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "CVE-2026-2", Status: "Closed", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"customfield_1": map[string]any{"value": "Critical", "id": "2"}}},
+		jiratest.Issue{Key: "PROJQUAY-4", Summary: "CVE-2026-3", Status: "New", Labels: []string{"CVE-2026-3"}, TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.client.severityField = "customfield_1"
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 || issues[0].Severity != "Important" || issues[1].Severity != "Critical" {
+		t.Errorf("severities: got %+v", issues)
+	}
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Important": 1, "": 1}
+	if len(summary.OpenCVESeverities) != len(want) {
+		t.Errorf("open CVE severities: got %v, want %v", summary.OpenCVESeverities, want)
+	}
+	for k, v := range want {
+		if summary.OpenCVESeverities[k] != v {
+			t.Errorf("open CVE severities[%q]: got %d, want %d", k, summary.OpenCVESeverities[k], v)
+		}
+	}
+
+	batch, err := database.GetIssueSummariesBatch(ctx, []string{"quay-v3.16.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := batch["quay-v3.16.2"].OpenCVESeverities["Important"]; got != 1 {
+		t.Errorf("batch open Important CVEs: got %d, want 1", got)
+	}
+}
+
+func TestSyncOnceBlockers(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "Crash on push", Status: "Open", Labels: []string{"Release-Blocker"}, TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "Crash on pull", Status: "Verified", Labels: []string{"blocker"}, TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-4", Summary: "Slow UI", Status: "Open", Labels: []string{"blocker-candidate"}, TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockers := map[string]bool{}
+	for _, i := range issues {
+		blockers[i.Key] = i.Blocker
+	}
+	want := map[string]bool{"PROJQUAY-2": true, "PROJQUAY-3": true, "PROJQUAY-4": false}
+	for key, b := range want {
+		if blockers[key] != b {
+			t.Errorf("%s blocker: got %v, want %v", key, blockers[key], b)
+		}
+	}
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.OpenBlockers != 1 {
+		t.Errorf("open blockers: got %d, want 1", summary.OpenBlockers)
+	}
+	batch, err := database.GetIssueSummariesBatch(ctx, []string{"quay-v3.16.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := batch["quay-v3.16.2"].OpenBlockers; got != 1 {
+		t.Errorf("batch open blockers: got %d, want 1", got)
+	}
+}
+
+func TestSyncOnceCVEFields(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "openssl: buffer overflow", Status: "Open", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{
+				"customfield_1": map[string]any{"value": "Critical"},
+				"customfield_2": "CVE-2026-1111",
+				"customfield_3": 9.8,
+				"customfield_4": []map[string]any{{"value": "Yes"}},
+			}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "CVE-2026-2222 quay: XSS", Status: "Closed", IssueType: "Vulnerability", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{
+				"customfield_1": map[string]any{"value": "Critical"},
+				"customfield_3": "6.1",
+				"customfield_4": map[string]any{"value": "No"},
+			}},
+		jiratest.Issue{Key: "PROJQUAY-4", Summary: "golang: DoS", Status: "New", Labels: []string{"CVE-2026-3333", "SecurityTracking"}, TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-5", Summary: "mentions CVE-2026-4444 in passing", Status: "New", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"customfield_3": 5.0}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.client.severityField = "customfield_1"
+	syncer.client.cveIDField = "customfield_2"
+	syncer.client.cvssField = "customfield_3"
+	syncer.client.embargoField = "customfield_4"
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	cves, err := database.ListReleaseCVEs(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type cve struct {
+		key, id   string
+		score     float64
+		embargoed bool
+	}
+	var got []cve
+	for _, i := range cves {
+		got = append(got, cve{i.Key, i.CVEID, i.CVSSScore, i.Embargoed})
+	}
+	want := []cve{
+		{"PROJQUAY-2", "CVE-2026-1111", 9.8, true},
+		{"PROJQUAY-3", "CVE-2026-2222", 6.1, false},
+		{"PROJQUAY-4", "CVE-2026-3333", 0, false},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("CVEs: got %+v, want %+v", got, want)
+	}
+
+	// Security fields are only read from CVE issues.
+	bugs, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{Type: "Bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bugs) != 1 || bugs[0].CVEID != "" || bugs[0].CVSSScore != 0 {
+		t.Errorf("bug: got %+v", bugs)
+	}
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSeverities := []model.CVESeverityCount{
+		{Severity: "Critical", Total: 2, Open: 1, Embargoed: 1, MaxCVSS: 9.8},
+		{Severity: "", Total: 1, Open: 1},
+	}
+	if !slices.Equal(summary.CVESeverities, wantSeverities) {
+		t.Errorf("CVE severities: got %+v, want %+v", summary.CVESeverities, wantSeverities)
+	}
+	batch, err := database.GetIssueSummariesBatch(ctx, []string{"quay-v3.16.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := batch["quay-v3.16.2"].CVESeverities; !slices.Equal(got, wantSeverities) {
+		t.Errorf("batch CVE severities: got %+v, want %+v", got, wantSeverities)
+	}
+}
+
+func TestSyncOnceClones(t *testing.T) {
+	link := func(typ, dir, key string) map[string]any {
+		return map[string]any{"type": map[string]any{"name": typ}, dir: map[string]any{"key": key}}
+	}
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "CLONE - fix bug", Status: "New", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"issuelinks": []any{
+				link("Cloners", "outwardIssue", "PROJQUAY-10"),
+				link("Blocks", "outwardIssue", "PROJQUAY-11"),
+				link("Backport", "inwardIssue", "PROJQUAY-12"),
+			}}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.SyncOnce(t.Context())
+
+	issues, err := database.ListJiraIssues(t.Context(), "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Clones != "PROJQUAY-10,PROJQUAY-12" {
+		t.Errorf("clones: got %+v", issues)
+	}
+}
+
+func TestSyncOnceBlockedBy(t *testing.T) {
+	blocks := map[string]any{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}
+	depends := map[string]any{"name": "Dependency", "inward": "is depended on by", "outward": "depends on"}
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix api", Status: "New", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "fix ui", Status: "Verified", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"issuelinks": []any{
+				map[string]any{"type": blocks, "inwardIssue": map[string]any{"key": "PROJQUAY-20",
+					"fields": map[string]any{"summary": "fix schema", "status": map[string]any{"name": "Closed"}}}},
+				map[string]any{"type": depends, "outwardIssue": map[string]any{"key": "PROJQUAY-2",
+					"fields": map[string]any{"summary": "fix api", "status": map[string]any{"name": "Verified"}}}},
+				map[string]any{"type": blocks, "outwardIssue": map[string]any{"key": "PROJQUAY-21"}},
+			}}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.SyncOnce(t.Context())
+
+	issues, err := database.ListJiraIssues(t.Context(), "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2", len(issues))
+	}
+	// PROJQUAY-2 is synced, so its own status wins over the stale one in
+	// the link; PROJQUAY-20 is not, so the link's status is kept.
+	want := []model.IssueBlocker{
+		{Key: "PROJQUAY-2", Summary: "fix api", Status: "New", Link: srv.URL + "/browse/PROJQUAY-2"},
+		{Key: "PROJQUAY-20", Summary: "fix schema", Status: "Closed", Link: srv.URL + "/browse/PROJQUAY-20"},
+	}
+	if got := issues[1].BlockedBy; !slices.Equal(got, want) {
+		t.Errorf("blocked by: got %+v, want %+v", got, want)
+	}
+	if issues[0].BlockedBy != nil {
+		t.Errorf("PROJQUAY-2 blocked by %+v", issues[0].BlockedBy)
+	}
+
+	summary, err := database.GetIssueSummary(t.Context(), "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Blocked != 1 || summary.Verified != 0 || summary.Open != 2 {
+		t.Errorf("summary: got blocked=%d verified=%d open=%d, want 1, 0, 2", summary.Blocked, summary.Verified, summary.Open)
+	}
+	batch, err := database.GetIssueSummariesBatch(t.Context(), []string{"quay-v3.16.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := batch["quay-v3.16.2"]; s.Blocked != 1 || s.Verified != 0 || s.Open != 2 {
+		t.Errorf("batch summary: got blocked=%d verified=%d open=%d, want 1, 0, 2", s.Blocked, s.Verified, s.Open)
+	}
+}
+
+func TestSyncOnceSubtasks(t *testing.T) {
+	subtask := func(key, status string) map[string]any {
+		return map[string]any{"key": key, "fields": map[string]any{"summary": "step " + key, "status": map[string]any{"name": status}}}
+	}
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "epic-sized story", Status: "In Progress", TargetVersions: []string{"quay-v3.16.2"},
+			Fields: map[string]any{"subtasks": []any{
+				subtask("PROJQUAY-20", "Closed"),
+				subtask("PROJQUAY-21", "New"),
+				subtask("PROJQUAY-22", "Done"),
+				subtask("PROJQUAY-23", "In Progress"),
+			}}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "small fix", Status: "New", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.SyncOnce(t.Context())
+
+	issues, err := database.ListJiraIssues(t.Context(), "quay-v3.16.2", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2", len(issues))
+	}
+	parent := issues[0]
+	if len(parent.Subtasks) != 4 || parent.Subtasks[0].Key != "PROJQUAY-20" || parent.Subtasks[0].Link != srv.URL+"/browse/PROJQUAY-20" {
+		t.Errorf("sub-tasks: got %+v", parent.Subtasks)
+	}
+	if parent.SubtaskCompletion == nil || *parent.SubtaskCompletion != 0.5 {
+		t.Errorf("completion: got %v, want 0.5", parent.SubtaskCompletion)
+	}
+	if issues[1].Subtasks != nil || issues[1].SubtaskCompletion != nil {
+		t.Errorf("PROJQUAY-3 sub-tasks: got %+v", issues[1].Subtasks)
+	}
+
+	summary, err := database.GetIssueSummary(t.Context(), "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Subtasks != 4 || summary.SubtasksDone != 2 || summary.Open != 2 {
+		t.Errorf("summary: got subtasks=%d done=%d open=%d, want 4, 2, 2", summary.Subtasks, summary.SubtasksDone, summary.Open)
+	}
+	batch, err := database.GetIssueSummariesBatch(t.Context(), []string{"quay-v3.16.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := batch["quay-v3.16.2"]; s.Subtasks != 4 || s.SubtasksDone != 2 {
+		t.Errorf("batch summary: got subtasks=%d done=%d, want 4, 2", s.Subtasks, s.SubtasksDone)
+	}
+}
+
+func TestSyncOnceScopeChanges(t *testing.T) {
+	ticket := jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}}
+	issue := func(key, summary string) jiratest.Issue {
+		return jiratest.Issue{Key: key, Summary: summary, Status: "New", TargetVersions: []string{"quay-v3.16.2"}}
+	}
+	srv := jiratest.New(t)
+	srv.AddIssues(ticket, issue("PROJQUAY-2", "fix api"), issue("PROJQUAY-3", "fix ui"))
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.SyncOnce(t.Context())
+
+	// The first sync sets the baseline.
+	changes, err := database.ListScopeChanges(t.Context(), "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("baseline changes: got %+v, want none", changes)
+	}
+
+	syncer.SetFullSyncInterval(0)
+	srv.SetIssues(ticket, issue("PROJQUAY-2", "fix api"), issue("PROJQUAY-4", "fix docs"))
+	syncer.SyncOnce(t.Context())
+
+	changes, err = database.ListScopeChanges(t.Context(), "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(changes))
+	for i, c := range changes {
+		got[i] = c.Change + " " + c.IssueKey + " " + c.Summary
+	}
+	want := []string{"added PROJQUAY-4 fix docs", "removed PROJQUAY-3 fix ui"}
+	if !slices.Equal(got, want) {
+		t.Errorf("changes: got %q, want %q", got, want)
+	}
+}
+
+func TestSyncOnceArchivesReleasedIssues(t *testing.T) {
+	srv := jiratest.New(t)
+	ticket := jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}}
+	srv.AddIssues(ticket,
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Verified", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-3", Summary: "another fix", Status: "Closed", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	ctx := t.Context()
+	keys := func() []string {
+		t.Helper()
+		issues, err := database.ListJiraIssues(ctx, "quay-v3.16.2", model.IssueFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		for _, i := range issues {
+			keys = append(keys, i.Key+"="+i.Status)
+		}
+		return keys
+	}
+
+	// Unreleased versions track JIRA, including removals on full syncs.
+	syncer.SetFullSyncInterval(0)
+	syncer.SyncOnce(ctx)
+	srv.SetIssues(ticket, jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Verified", TargetVersions: []string{"quay-v3.16.2"}})
+	syncer.SyncOnce(ctx)
+	if got := keys(); !slices.Equal(got, []string{"PROJQUAY-2=Verified"}) {
+		t.Fatalf("unreleased issues: got %v", got)
+	}
+
+	// Releasing archives the issue set as it stood.
+	srv.SetVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2", Released: true, ReleaseDate: "2026-03-01"})
+	syncer.SyncOnce(ctx)
+
+	// Later edits and fixVersion moves no longer show.
+	srv.SetIssues(ticket,
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Reopened", TargetVersions: []string{"quay-v3.16.2"}},
+		jiratest.Issue{Key: "PROJQUAY-4", Summary: "moved in", Status: "New", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	syncer.SyncOnce(ctx)
+	if got := keys(); !slices.Equal(got, []string{"PROJQUAY-2=Verified"}) {
+		t.Errorf("released issues: got %v, want the archived set", got)
+	}
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 1 || summary.Open != 0 {
+		t.Errorf("released summary: got %+v, want total=1 open=0", summary)
+	}
+}
+
+func TestSyncOnceRequestID(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}})
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, _ := newTestSyncer(t, srv)
+	syncer.SyncOnce(t.Context())
+	syncer.SyncOnce(requestid.NewContext(t.Context(), "caller-id"))
+
+	reqs := srv.Requests()
+	if len(reqs) < 4 {
+		t.Fatalf("requests: got %d, want at least 4", len(reqs))
+	}
+	first := reqs[0].RequestID
+	if first == "" || first == "caller-id" {
+		t.Fatalf("first cycle request ID: got %q", first)
+	}
+	for _, r := range reqs {
+		if r.RequestID != first && r.RequestID != "caller-id" {
+			t.Errorf("%s: request ID %q, want %q or caller-id", r.Path, r.RequestID, first)
+		}
+	}
+	if last := reqs[len(reqs)-1].RequestID; last != "caller-id" {
+		t.Errorf("second cycle request ID: got %q, want caller-id", last)
+	}
+}
+
+func TestSyncOnceSkipsHiddenRelease(t *testing.T) {
+	srv := jiratest.New(t)
+	srv.AddIssues(
+		jiratest.Issue{Key: "PROJQUAY-1", Summary: "Release Quay v3.16.2", Status: "In Progress", Components: []string{"-area/release"}},
+		jiratest.Issue{Key: "PROJQUAY-2", Summary: "fix bug", Status: "Open", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}},
+	)
+	srv.AddVersions("PROJQUAY", jiratest.Version{Name: "quay-v3.16.2"})
+
+	syncer, database := newTestSyncer(t, srv)
+	syncer.SetFullSyncInterval(0)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	if err := database.SetReleaseVersionHidden(ctx, "quay-v3.16.2", true); err != nil {
+		t.Fatal(err)
+	}
+	srv.AddIssues(jiratest.Issue{Key: "PROJQUAY-3", Summary: "another bug", Status: "Open", IssueType: "Bug", TargetVersions: []string{"quay-v3.16.2"}})
+	syncer.SyncOnce(ctx)
+
+	summary, err := database.GetIssueSummary(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 1 {
+		t.Errorf("hidden release synced: got %d issues, want 1", summary.Total)
+	}
+	rel, err := database.GetReleaseVersion(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rel.Hidden || rel.Archived {
+		t.Errorf("release: got %+v, want hidden and not archived", rel)
+	}
+}
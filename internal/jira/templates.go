@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Templates describe a project's JIRA conventions: how release tickets are
+// found, how a release's issues are found, and how the version is read from
+// a release ticket's summary. The zero value of each field means the
+// PROJQUAY default.
+type Templates struct {
+	// DiscoveryJQL finds the open release tickets. {project} is replaced
+	// with the configured project key.
+	DiscoveryJQL string `json:"discovery_jql"`
+	// SearchJQL finds the issues of one release. {project} and {version}
+	// are replaced with the project key and the release's fix version.
+	// Incremental syncs append an "AND updated >= ..." clause, so an OR at
+	// the top level must be parenthesised.
+	SearchJQL string `json:"search_jql"`
+	// SummaryPattern is a regular expression matched against release ticket
+	// summaries. It must have a group named "version" and may have one named
+	// "product"; fix versions are "{product}-v{version}" when the product
+	// matches and is not "release", otherwise just the version.
+	SummaryPattern string `json:"summary_pattern"`
+
+	summaryRe *regexp.Regexp
+}
+
+// DefaultTemplates are the PROJQUAY conventions: release tickets carry the
+// -area/release component and a summary such as "Release Quay v3.16.2", and
+// issues are assigned to a release by Target Version.
+var DefaultTemplates = mustCompileTemplates(Templates{
+	DiscoveryJQL: `project={project} AND component="-area/release" AND status NOT IN (Closed, Done)`,
+	SearchJQL:    `project={project} AND "Target Version"="{version}"`,
+	// Examples:
+	//   - "Release Quay v3.16.2"       → product="quay", version="3.16.2"
+	//   - "Release OMR v2.0.10"        → product="omr", version="2.0.10"
+	//   - "⦗konflux⦘ Quay v3.15.3"    → product="quay", version="3.15.3"
+	SummaryPattern: `(?i)(?:(?P<product>\w+)\s+)?v?(?P<version>\d+\.\d+(?:\.\d+)?)`,
+})
+
+// LoadTemplates reads a JSON object of templates from the file at path.
+// Fields the file leaves out keep their DefaultTemplates value.
+func LoadTemplates(path string) (*Templates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Templates
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if t.DiscoveryJQL == "" {
+		t.DiscoveryJQL = DefaultTemplates.DiscoveryJQL
+	}
+	if t.SearchJQL == "" {
+		t.SearchJQL = DefaultTemplates.SearchJQL
+	}
+	if t.SummaryPattern == "" {
+		t.SummaryPattern = DefaultTemplates.SummaryPattern
+	}
+	if err := t.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &t, nil
+}
+
+func mustCompileTemplates(t Templates) *Templates {
+	if err := t.compile(); err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func (t *Templates) compile() error {
+	if !strings.Contains(t.SearchJQL, "{version}") {
+		return errors.New("search_jql must contain {version}")
+	}
+	re, err := regexp.Compile(t.SummaryPattern)
+	if err != nil {
+		return fmt.Errorf("summary_pattern: %w", err)
+	}
+	if re.SubexpIndex("version") < 0 {
+		return errors.New(`summary_pattern must have a group named "version"`)
+	}
+	t.summaryRe = re
+	return nil
+}
+
+// discoveryJQL returns the release discovery JQL for project.
+func (t *Templates) discoveryJQL(project string) string {
+	return strings.ReplaceAll(t.DiscoveryJQL, "{project}", project)
+}
+
+// searchJQL returns the JQL matching the issues of version in project.
+func (t *Templates) searchJQL(project, version string) string {
+	return strings.NewReplacer("{project}", project, "{version}", version).Replace(t.SearchJQL)
+}
+
+// parseSummary extracts the product (lowercased) and version from a release
+// ticket summary.
+func (t *Templates) parseSummary(summary string) (product, version string, ok bool) {
+	m := t.summaryRe.FindStringSubmatch(summary)
+	if m == nil {
+		return "", "", false
+	}
+	if i := t.summaryRe.SubexpIndex("product"); i >= 0 {
+		product = strings.ToLower(m[i])
+	}
+	version = m[t.summaryRe.SubexpIndex("version")]
+	if version == "" {
+		return "", "", false
+	}
+	return product, version, true
+}
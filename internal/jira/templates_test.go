@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/jiratest"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "templates.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tmpl, err := LoadTemplates(write(`{"search_jql": "project={project} AND fixVersion=\"{version}\""}`))
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	if tmpl.DiscoveryJQL != DefaultTemplates.DiscoveryJQL || tmpl.SummaryPattern != DefaultTemplates.SummaryPattern {
+		t.Errorf("omitted fields should keep their defaults, got %+v", tmpl)
+	}
+	if got, want := tmpl.searchJQL("WIDGET", "1.4.0"), `project=WIDGET AND fixVersion="1.4.0"`; got != want {
+		t.Errorf("searchJQL: got %q, want %q", got, want)
+	}
+
+	for name, content := range map[string]string{
+		"no version placeholder": `{"search_jql": "project={project}"}`,
+		"bad pattern":            `{"summary_pattern": "("}`,
+		"no version group":       `{"summary_pattern": "(\\d+\\.\\d+)"}`,
+		"unknown field":          `{"serch_jql": "project={project} AND fixVersion={version}"}`,
+	} {
+		if _, err := LoadTemplates(write(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDiscoverActiveReleasesTemplates(t *testing.T) {
+	srv := jiratest.New(t)
+	tracker := []string{"release-tracker"}
+	srv.AddIssues(
+		jiratest.Issue{Key: "WIDGET-1", Summary: "widget 1.4.0 release", Status: "New", Components: tracker},
+		jiratest.Issue{Key: "WIDGET-2", Summary: "Release Widget v1.5.0", Status: "New", Components: tracker},
+		jiratest.Issue{Key: "WIDGET-3", Summary: "widget 1.3.0 release", Status: "Closed", Components: tracker},
+		jiratest.Issue{Key: "WIDGET-10", FixVersions: []string{"widget-v1.4.0"}},
+	)
+
+	path := filepath.Join(t.TempDir(), "templates.json")
+	if err := os.WriteFile(path, []byte(`{
+		"discovery_jql": "project={project} AND component=\"release-tracker\" AND status IN (New)",
+		"search_jql": "project={project} AND fixVersion=\"{version}\"",
+		"summary_pattern": "^(?P<product>\\w+) (?P<version>\\d+\\.\\d+\\.\\d+) release$"
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := LoadTemplates(path)
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	client := New(Config{BaseURL: srv.URL, Projects: []string{"WIDGET"}, Templates: tmpl})
+	client.limiter = nil
+
+	releases, err := client.DiscoverActiveReleases(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverActiveReleases: %v", err)
+	}
+	if len(releases) != 1 {
+		t.Fatalf("got %d releases, want 1: %+v", len(releases), releases)
+	}
+	if r := releases[0]; r.FixVersion != "widget-v1.4.0" || r.ReleaseTicketKey != "WIDGET-1" || r.S3Application != "widget-v1-4" {
+		t.Errorf("release: got %+v", r)
+	}
+
+	issues, err := client.SearchIssues(context.Background(), releases[0].FixVersion)
+	if err != nil {
+		t.Fatalf("SearchIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "WIDGET-10" {
+		t.Errorf("issues: got %+v", issues)
+	}
+	if jql := srv.SearchRequests()[0].JQL; !strings.Contains(jql, "release-tracker") {
+		t.Errorf("discovery JQL: got %q", jql)
+	}
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Webhook events for issue changes. Other events are ignored.
+const (
+	EventIssueCreated = "jira:issue_created"
+	EventIssueUpdated = "jira:issue_updated"
+	EventIssueDeleted = "jira:issue_deleted"
+)
+
+// WebhookEvent is the payload JIRA posts to a webhook.
+type WebhookEvent struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        Issue  `json:"issue"`
+}
+
+// ApplyWebhook applies an issue event to the issues of unreleased versions,
+// without waiting for the next poll.
+//
+// A created or updated issue is stored under the versions in its Target
+// Version field and removed from the others. If the field is not configured
+// or not in the payload, the issue is only refreshed under the versions it
+// is already stored under; the next poll picks up new issues and moves. A
+// deleted issue is removed from every version.
+func (s *Syncer) ApplyWebhook(ctx context.Context, event WebhookEvent) error {
+	if event.WebhookEvent != EventIssueCreated && event.WebhookEvent != EventIssueUpdated && event.WebhookEvent != EventIssueDeleted {
+		return nil
+	}
+	issue := event.Issue
+	s.client.readCustomFields(&issue)
+
+	active, err := s.store.ListActiveReleaseVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("list active versions: %w", err)
+	}
+	isActive := func(version string) bool {
+		return slices.ContainsFunc(active, func(v model.ReleaseVersion) bool { return v.Name == version })
+	}
+	stored, err := s.store.ListJiraIssueVersions(ctx, issue.Key)
+	if err != nil {
+		return fmt.Errorf("list issue versions: %w", err)
+	}
+	stored = slices.DeleteFunc(stored, func(v string) bool { return !isActive(v) })
+
+	var upsert, remove []string
+	switch target, known := s.client.targetVersions(issue); {
+	case event.WebhookEvent == EventIssueDeleted:
+		remove = stored
+	case known:
+		upsert = slices.DeleteFunc(target, func(v string) bool { return !isActive(v) })
+		remove = slices.DeleteFunc(stored, func(v string) bool { return slices.Contains(upsert, v) })
+	default:
+		upsert = stored
+	}
+	if len(upsert) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	if err := s.withTx(ctx, func(txStore Store) error {
+		for _, version := range upsert {
+			if err := txStore.UpsertJiraIssue(ctx, s.issueRecord(issue, version)); err != nil {
+				return fmt.Errorf("upsert issue %s: %w", issue.Key, err)
+			}
+		}
+		for _, version := range remove {
+			if err := txStore.DeleteJiraIssue(ctx, issue.Key, version); err != nil {
+				return fmt.Errorf("delete issue %s: %w", issue.Key, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, version := range slices.Concat(upsert, remove) {
+		s.events.Publish(events.Event{Kind: events.KindIssues, Release: version})
+	}
+	s.logger.InfoContext(ctx, "applied webhook", "event", event.WebhookEvent, "issue", issue.Key, "stored", upsert, "removed", remove)
+	return nil
+}
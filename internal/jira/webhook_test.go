@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/jiratest"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestApplyWebhook(t *testing.T) {
+	srv := jiratest.New(t)
+	syncer, database := newTestSyncer(t, srv)
+	syncer.client.targetField = "customfield_1"
+	ctx := t.Context()
+
+	for _, v := range []model.ReleaseVersion{{Name: "quay-v3.16.2"}, {Name: "quay-v3.17.0"}, {Name: "quay-v3.15.9", Released: true}} {
+		if err := database.UpsertReleaseVersion(ctx, &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event := func(name, payload string) WebhookEvent {
+		t.Helper()
+		var e WebhookEvent
+		if err := json.Unmarshal([]byte(`{"webhookEvent":"`+name+`","issue":`+payload+`}`), &e); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	versions := func(key string) []string {
+		t.Helper()
+		v, err := database.ListJiraIssueVersions(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	apply := func(e WebhookEvent) {
+		t.Helper()
+		if err := syncer.ApplyWebhook(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A new issue is stored under its unreleased target versions.
+	apply(event(EventIssueCreated, `{"key":"PROJQUAY-7","fields":{"summary":"crash","status":{"name":"New"},"issuetype":{"name":"Bug"},
+		"customfield_1":[{"name":"quay-v3.16.2"},{"name":"quay-v3.15.9"},{"name":"quay-v9.9.9"}]}}`))
+	if got := versions("PROJQUAY-7"); !slices.Equal(got, []string{"quay-v3.16.2"}) {
+		t.Errorf("created: got %v", got)
+	}
+
+	// Retargeting moves it.
+	apply(event(EventIssueUpdated, `{"key":"PROJQUAY-7","fields":{"summary":"crash","status":{"name":"Verified"},"issuetype":{"name":"Bug"},
+		"customfield_1":[{"name":"quay-v3.17.0"}]}}`))
+	if got := versions("PROJQUAY-7"); !slices.Equal(got, []string{"quay-v3.17.0"}) {
+		t.Errorf("retargeted: got %v", got)
+	}
+	issues, err := database.ListJiraIssues(ctx, "quay-v3.17.0", model.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Status != "Verified" || issues[0].Link != srv.URL+"/browse/PROJQUAY-7" {
+		t.Errorf("stored issue: got %+v", issues)
+	}
+
+	// Without the target field, known issues are refreshed in place and
+	// unknown ones are left to the next poll.
+	apply(event(EventIssueUpdated, `{"key":"PROJQUAY-7","fields":{"summary":"crash on push","status":{"name":"Verified"}}}`))
+	apply(event(EventIssueUpdated, `{"key":"PROJQUAY-8","fields":{"summary":"other"}}`))
+	if got := versions("PROJQUAY-7"); !slices.Equal(got, []string{"quay-v3.17.0"}) {
+		t.Errorf("refreshed: got %v", got)
+	}
+	if got := versions("PROJQUAY-8"); len(got) != 0 {
+		t.Errorf("unknown issue: got %v", got)
+	}
+	if issues, _ := database.ListJiraIssues(ctx, "quay-v3.17.0", model.IssueFilter{}); len(issues) != 1 || issues[0].Summary != "crash on push" {
+		t.Errorf("refreshed issue: got %+v", issues)
+	}
+
+	apply(event(EventIssueDeleted, `{"key":"PROJQUAY-7","fields":{}}`))
+	if got := versions("PROJQUAY-7"); len(got) != 0 {
+		t.Errorf("deleted: got %v", got)
+	}
+}
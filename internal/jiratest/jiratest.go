@@ -0,0 +1,419 @@
+// Package jiratest provides an in-process fake of the JIRA Cloud REST API for
+// tests. It implements the subset of endpoints used by internal/jira: JQL
+// search and project versions, in both REST API v3 (search paged by token)
+// and v2 (search paged by offset), plus optional Basic Auth checking and
+// injectable 429 rate limiting.
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Issue is a fake JIRA issue. Only the fields the dashboard reads are modelled;
+// anything else (e.g. custom fields) can be supplied through Fields.
+type Issue struct {
+	Key            string
+	Summary        string
+	Status         string
+	Priority       string
+	IssueType      string
+	Resolution     string
+	Assignee       string
+	Updated        string // JIRA timestamp, e.g. 2026-01-15T10:00:00.000+0000; matched by `updated >= "-Nm"` clauses
+	DueDate        string // YYYY-MM-DD
+	Labels         []string
+	FixVersions    []string
+	Components     []string
+	TargetVersions []string       // matched by `"Target Version"="..."` clauses
+	Fields         map[string]any // extra raw fields merged into the response
+}
+
+// Project returns the project key, taken from the issue key prefix.
+func (i Issue) Project() string {
+	project, _, _ := strings.Cut(i.Key, "-")
+	return project
+}
+
+// Version is a fake project version as returned by /project/{key}/versions.
+type Version struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived"`
+}
+
+// Request records a request received by the fake server.
+type Request struct {
+	Method        string
+	Path          string
+	JQL           string
+	Fields        string
+	NextPageToken string
+	StartAt       string // startAt of v2 searches
+	RequestID     string // X-Request-ID header
+}
+
+// Server is a fake JIRA server backed by httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	issues      []Issue
+	versions    map[string][]Version
+	pageSize    int
+	email       string
+	token       string
+	rateLimited int
+	retryAfter  string
+	failing     int
+	failStatus  int
+	requests    []Request
+}
+
+// New starts a fake JIRA server that is closed when the test finishes.
+func New(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{versions: map[string][]Version{}, pageSize: 100}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/3/search/jql", s.handleSearch)
+	mux.HandleFunc("GET /rest/api/2/search", s.handleSearch)
+	mux.HandleFunc("GET /rest/api/{version}/project/{key}/versions", s.handleVersions)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("jiratest: unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	})
+	s.Server = httptest.NewServer(s.middleware(mux))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// AddIssues adds issues to the fake's data set.
+func (s *Server) AddIssues(issues ...Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues = append(s.issues, issues...)
+}
+
+// SetIssues replaces the fake's data set.
+func (s *Server) SetIssues(issues ...Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues = slices.Clone(issues)
+}
+
+// AddVersions adds versions to a project.
+func (s *Server) AddVersions(project string, versions ...Version) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions[project] = append(s.versions[project], versions...)
+}
+
+// SetVersions replaces a project's versions.
+func (s *Server) SetVersions(project string, versions ...Version) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions[project] = slices.Clone(versions)
+}
+
+// SetPageSize caps the number of issues returned per search page.
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// RequireAuth makes the fake reject requests without matching Basic Auth credentials.
+func (s *Server) RequireAuth(email, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.email, s.token = email, token
+}
+
+// RateLimit makes the next n requests fail with 429 Too Many Requests and the
+// given Retry-After header value (omitted if empty).
+func (s *Server) RateLimit(n int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimited, s.retryAfter = n, retryAfter
+}
+
+// Fail makes the next n requests fail with the given HTTP status, e.g. 503
+// to simulate an outage.
+func (s *Server) Fail(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing, s.failStatus = n, status
+}
+
+// Requests returns all requests received so far, including rate-limited and failed ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// SearchRequests returns the JQL search requests received so far.
+func (s *Server) SearchRequests() []Request {
+	var out []Request
+	for _, r := range s.Requests() {
+		if r.Path == "/rest/api/3/search/jql" || r.Path == "/rest/api/2/search" {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			JQL:           q.Get("jql"),
+			Fields:        q.Get("fields"),
+			NextPageToken: q.Get("nextPageToken"),
+			StartAt:       q.Get("startAt"),
+			RequestID:     r.Header.Get("X-Request-ID"),
+		})
+		limited := s.rateLimited > 0
+		if limited {
+			s.rateLimited--
+		}
+		retryAfter := s.retryAfter
+		failStatus := 0
+		if !limited && s.failing > 0 {
+			s.failing--
+			failStatus = s.failStatus
+		}
+		email, token := s.email, s.token
+		s.mu.Unlock()
+
+		if limited {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		if failStatus != 0 {
+			http.Error(w, http.StatusText(failStatus), failStatus)
+			return
+		}
+		if token != "" {
+			if u, p, ok := r.BasicAuth(); !ok || u != email || p != token {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type searchResponse struct {
+	NextPageToken string           `json:"nextPageToken,omitempty"`
+	StartAt       *int             `json:"startAt,omitempty"`
+	Total         *int             `json:"total,omitempty"`
+	MaxResults    int              `json:"maxResults"`
+	Issues        []map[string]any `json:"issues"`
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	match, err := compileJQL(q.Get("jql"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	var matched []Issue
+	for _, issue := range s.issues {
+		if match(issue) {
+			matched = append(matched, issue)
+		}
+	}
+	pageSize := s.pageSize
+	s.mu.Unlock()
+
+	if n, err := strconv.Atoi(q.Get("maxResults")); err == nil && n > 0 && n < pageSize {
+		pageSize = n
+	}
+	// API v2 pages by offset; v3 by an opaque token, which here is the
+	// offset too.
+	v2 := r.URL.Path == "/rest/api/2/search"
+	param := "nextPageToken"
+	if v2 {
+		param = "startAt"
+	}
+	start := 0
+	if tok := q.Get(param); tok != "" {
+		start, err = strconv.Atoi(tok)
+		if err != nil || start < 0 || start > len(matched) {
+			http.Error(w, "invalid "+param, http.StatusBadRequest)
+			return
+		}
+	}
+	end := min(start+pageSize, len(matched))
+
+	resp := searchResponse{MaxResults: pageSize, Issues: []map[string]any{}}
+	for _, issue := range matched[start:end] {
+		resp.Issues = append(resp.Issues, issueJSON(issue))
+	}
+	if v2 {
+		total := len(matched)
+		resp.StartAt, resp.Total = &start, &total
+	} else if end < len(matched) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleVersions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	versions, ok := s.versions[r.PathValue("key")]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, versions)
+}
+
+func issueJSON(i Issue) map[string]any {
+	named := func(name string) any {
+		if name == "" {
+			return nil
+		}
+		return map[string]string{"name": name}
+	}
+	namedList := func(names []string) []map[string]string {
+		out := []map[string]string{}
+		for _, n := range names {
+			out = append(out, map[string]string{"name": n})
+		}
+		return out
+	}
+
+	fields := map[string]any{
+		"summary":     i.Summary,
+		"status":      map[string]string{"name": i.Status},
+		"priority":    map[string]string{"name": i.Priority},
+		"issuetype":   map[string]string{"name": i.IssueType},
+		"resolution":  named(i.Resolution),
+		"labels":      append([]string{}, i.Labels...),
+		"fixVersions": namedList(i.FixVersions),
+		"components":  namedList(i.Components),
+		"updated":     i.Updated,
+	}
+	if i.DueDate != "" {
+		fields["duedate"] = i.DueDate
+	}
+	if i.Assignee != "" {
+		fields["assignee"] = map[string]string{"displayName": i.Assignee}
+	} else {
+		fields["assignee"] = nil
+	}
+	for k, v := range i.Fields {
+		fields[k] = v
+	}
+	return map[string]any{"key": i.Key, "fields": fields}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// clause matches a single JQL condition against an issue.
+type clause struct {
+	re    *regexp.Regexp
+	match func(i Issue, m []string) bool
+}
+
+// clauses lists the JQL conditions the fake understands. Conditions are
+// joined with AND; anything else is rejected with 400 so that tests notice
+// when the client starts sending JQL the fake does not model.
+var clauses = []clause{
+	{regexp.MustCompile(`(?i)^project\s*=\s*"?([^"\s]+)"?$`), func(i Issue, m []string) bool {
+		return strings.EqualFold(i.Project(), m[1])
+	}},
+	{regexp.MustCompile(`(?i)^component\s*=\s*"([^"]+)"$`), func(i Issue, m []string) bool {
+		return slices.Contains(i.Components, m[1])
+	}},
+	{regexp.MustCompile(`(?i)^status\s+NOT\s+IN\s*\(([^)]*)\)$`), func(i Issue, m []string) bool {
+		return !slices.ContainsFunc(splitList(m[1]), func(s string) bool { return strings.EqualFold(s, i.Status) })
+	}},
+	{regexp.MustCompile(`(?i)^status\s+IN\s*\(([^)]*)\)$`), func(i Issue, m []string) bool {
+		return slices.ContainsFunc(splitList(m[1]), func(s string) bool { return strings.EqualFold(s, i.Status) })
+	}},
+	{regexp.MustCompile(`(?i)^"Target Version"\s*=\s*"([^"]+)"$`), func(i Issue, m []string) bool {
+		return slices.Contains(i.TargetVersions, m[1])
+	}},
+	{regexp.MustCompile(`(?i)^fixVersion\s*=\s*"?([^"]+?)"?$`), func(i Issue, m []string) bool {
+		return slices.Contains(i.FixVersions, m[1])
+	}},
+	{regexp.MustCompile(`(?i)^updated\s*>=\s*"-(\d+)m"$`), func(i Issue, m []string) bool {
+		updated, err := time.Parse(timestampLayout, i.Updated)
+		if err != nil {
+			return false
+		}
+		minutes, _ := strconv.Atoi(m[1])
+		return !updated.Before(time.Now().Add(-time.Duration(minutes) * time.Minute))
+	}},
+}
+
+// timestampLayout is the format of JIRA issue timestamps such as Updated.
+const timestampLayout = "2006-01-02T15:04:05.000-0700"
+
+func compileJQL(jql string) (func(Issue) bool, error) {
+	type bound struct {
+		c clause
+		m []string
+	}
+	var conds []bound
+	for _, part := range regexp.MustCompile(`(?i)\s+AND\s+`).Split(strings.TrimSpace(jql), -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		found := false
+		for _, c := range clauses {
+			if m := c.re.FindStringSubmatch(part); m != nil {
+				conds = append(conds, bound{c, m})
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("jiratest: unsupported JQL clause %q", part)
+		}
+	}
+	return func(i Issue) bool {
+		for _, b := range conds {
+			if !b.c.match(i, b.m) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		out = append(out, strings.Trim(strings.TrimSpace(v), `"`))
+	}
+	return out
+}
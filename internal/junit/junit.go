@@ -0,0 +1,113 @@
+// Package junit reads JUnit XML test reports, as written by pytest, Go's
+// gotestsum, Cypress and most other runners, into the CTRF model the
+// dashboard stores.
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+)
+
+// TestSuites is the <testsuites> root element. Reports with a single
+// <testsuite> root are read into it too.
+type TestSuites struct {
+	Suites []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite is a <testsuite> element. Suites may nest.
+type TestSuite struct {
+	Name   string      `xml:"name,attr"`
+	Cases  []TestCase  `xml:"testcase"`
+	Suites []TestSuite `xml:"testsuite"`
+}
+
+// TestCase is a <testcase> element.
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	File      string   `xml:"file,attr"`
+	Time      float64  `xml:"time,attr"`
+	Failure   *Failure `xml:"failure"`
+	Error     *Failure `xml:"error"`
+	Skipped   *Failure `xml:"skipped"`
+}
+
+// Failure is a <failure>, <error> or <skipped> element: a short message
+// attribute and the full output, usually a stack trace, as its text.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Parse decodes a JUnit XML report with either a <testsuites> or a
+// <testsuite> root.
+func Parse(data []byte) (*TestSuites, error) {
+	var root struct {
+		XMLName xml.Name
+		TestSuite
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	switch root.XMLName.Local {
+	case "testsuites":
+		return &TestSuites{Suites: root.Suites}, nil
+	case "testsuite":
+		return &TestSuites{Suites: []TestSuite{root.TestSuite}}, nil
+	}
+	return nil, fmt.Errorf("unexpected root element <%s>", root.XMLName.Local)
+}
+
+// Append adds the test cases of r to report, updating its summary. Failed
+// and errored cases keep the failure message as Message and the failure
+// output as Trace.
+func (r *TestSuites) Append(report *ctrf.Report) {
+	for _, s := range r.Suites {
+		appendSuite(report, s)
+	}
+}
+
+func appendSuite(report *ctrf.Report, s TestSuite) {
+	for _, tc := range s.Cases {
+		t := ctrf.Test{
+			Name:     tc.Name,
+			Status:   "passed",
+			Duration: tc.Time * 1000,
+			FilePath: tc.File,
+			Suite:    tc.Classname,
+		}
+		if t.Suite == "" {
+			t.Suite = s.Name
+		}
+		sum := &report.Results.Summary
+		sum.Tests++
+		f := tc.Failure
+		if f == nil {
+			f = tc.Error
+		}
+		switch {
+		case f != nil:
+			t.Status = "failed"
+			t.Message = strings.TrimSpace(f.Message)
+			t.Trace = strings.TrimSpace(f.Text)
+			if t.Message == "" {
+				t.Message = f.Type
+			}
+			sum.Failed++
+		case tc.Skipped != nil:
+			t.Status = "skipped"
+			t.Message = strings.TrimSpace(tc.Skipped.Message)
+			sum.Skipped++
+		default:
+			sum.Passed++
+		}
+		report.Results.Tests = append(report.Results.Tests, t)
+	}
+	for _, nested := range s.Suites {
+		appendSuite(report, nested)
+	}
+}
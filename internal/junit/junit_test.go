@@ -0,0 +1,77 @@
+package junit
+
+import (
+	"testing"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+)
+
+const pytestReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites>
+  <testsuite name="pytest" tests="4" failures="1" errors="1" skipped="1" time="3.5">
+    <testcase classname="test.test_api" name="test_login" file="test/test_api.py" time="0.25"/>
+    <testcase classname="test.test_api" name="test_push" time="1.5">
+      <failure message="AssertionError: expected 201, got 500">Traceback (most recent call last):
+  File "test/test_api.py", line 42, in test_push
+AssertionError: expected 201, got 500</failure>
+    </testcase>
+    <testcase classname="test.test_api" name="test_pull" time="0">
+      <error type="TimeoutError">connection timed out</error>
+    </testcase>
+    <testcase classname="test.test_api" name="test_quota" time="0">
+      <skipped message="quota disabled"/>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func TestParse(t *testing.T) {
+	r, err := Parse([]byte(pytestReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report ctrf.Report
+	r.Append(&report)
+
+	sum := report.Results.Summary
+	if sum.Tests != 4 || sum.Passed != 1 || sum.Failed != 2 || sum.Skipped != 1 {
+		t.Errorf("summary: got %+v", sum)
+	}
+	if len(report.Results.Tests) != 4 {
+		t.Fatalf("tests: got %d, want 4", len(report.Results.Tests))
+	}
+
+	login := report.Results.Tests[0]
+	if login.Status != "passed" || login.Duration != 250 || login.FilePath != "test/test_api.py" || login.Suite != "test.test_api" {
+		t.Errorf("passed case: got %+v", login)
+	}
+	push := report.Results.Tests[1]
+	if push.Status != "failed" || push.Message != "AssertionError: expected 201, got 500" {
+		t.Errorf("failed case: got %+v", push)
+	}
+	if want := "AssertionError: expected 201, got 500"; len(push.Trace) < len(want) || push.Trace[len(push.Trace)-len(want):] != want {
+		t.Errorf("failure output: got %q", push.Trace)
+	}
+	pull := report.Results.Tests[2]
+	if pull.Status != "failed" || pull.Message != "TimeoutError" || pull.Trace != "connection timed out" {
+		t.Errorf("errored case: got %+v", pull)
+	}
+	if quota := report.Results.Tests[3]; quota.Status != "skipped" || quota.Message != "quota disabled" {
+		t.Errorf("skipped case: got %+v", quota)
+	}
+}
+
+func TestParseSingleSuite(t *testing.T) {
+	r, err := Parse([]byte(`<testsuite name="e2e"><testcase name="creates org"/><testsuite name="nested"><testcase name="deletes org"/></testsuite></testsuite>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report ctrf.Report
+	r.Append(&report)
+	if len(report.Results.Tests) != 2 || report.Results.Tests[0].Suite != "e2e" || report.Results.Tests[1].Suite != "nested" {
+		t.Errorf("tests: got %+v", report.Results.Tests)
+	}
+
+	if _, err := Parse([]byte(`<results/>`)); err == nil {
+		t.Error("unexpected root: expected error")
+	}
+}
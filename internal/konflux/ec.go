@@ -0,0 +1,60 @@
+package konflux
+
+import (
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ECReport is the JSON output of `ec validate image`, as written by the
+// Enterprise Contract integration test next to the JUnit results.
+type ECReport struct {
+	Success    bool          `json:"success"`
+	Components []ECComponent `json:"components"`
+}
+
+// ECComponent is the verification result of one component image.
+type ECComponent struct {
+	Name           string     `json:"name"`
+	ContainerImage string     `json:"containerImage"`
+	Success        bool       `json:"success"`
+	Violations     []ECResult `json:"violations"`
+	Warnings       []ECResult `json:"warnings"`
+}
+
+// ECResult is a policy rule outcome.
+type ECResult struct {
+	Msg      string `json:"msg"`
+	Metadata struct {
+		Code     string `json:"code"`
+		Title    string `json:"title"`
+		Solution string `json:"solution"`
+	} `json:"metadata"`
+}
+
+// ConvertECReport transforms an EC report into per-component results. A
+// component that reports violations is failed even if it claims success.
+func ConvertECReport(r ECReport) []model.ECResult {
+	results := make([]model.ECResult, len(r.Components))
+	for i, c := range r.Components {
+		results[i] = model.ECResult{
+			Component:  c.Name,
+			ImageURL:   c.ContainerImage,
+			Success:    c.Success && len(c.Violations) == 0,
+			Violations: convertECFindings(c.Violations),
+			Warnings:   convertECFindings(c.Warnings),
+		}
+	}
+	return results
+}
+
+func convertECFindings(results []ECResult) []model.ECFinding {
+	findings := make([]model.ECFinding, len(results))
+	for i, r := range results {
+		findings[i] = model.ECFinding{
+			Code:     r.Metadata.Code,
+			Title:    r.Metadata.Title,
+			Message:  r.Msg,
+			Solution: r.Metadata.Solution,
+		}
+	}
+	return findings
+}
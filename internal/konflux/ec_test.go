@@ -0,0 +1,44 @@
+package konflux
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertECReport(t *testing.T) {
+	var r ECReport
+	err := json.Unmarshal([]byte(`{
+		"success": false,
+		"ec-version": "v0.6",
+		"components": [
+			{
+				"name": "quay-server",
+				"containerImage": "quay.io/redhat-user-workloads/quay/quay-server@sha256:abc",
+				"success": false,
+				"violations": [{
+					"msg": "Required CVE check not run",
+					"metadata": {"code": "cve.cve_results_found", "title": "CVE scan results found", "solution": "Run the clair-scan task"}
+				}],
+				"warnings": [{"msg": "Base image is from an unapproved registry", "metadata": {"code": "base_image_registries.base_image_permitted"}}]
+			},
+			{"name": "quay-builder", "containerImage": "quay.io/redhat-user-workloads/quay/quay-builder@sha256:def", "success": true}
+		]
+	}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := ConvertECReport(r)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	server := results[0]
+	if server.Component != "quay-server" || server.Success || len(server.Violations) != 1 || len(server.Warnings) != 1 {
+		t.Errorf("quay-server = %+v", server)
+	}
+	if v := server.Violations[0]; v.Code != "cve.cve_results_found" || v.Message != "Required CVE check not run" || v.Solution == "" {
+		t.Errorf("violation = %+v", v)
+	}
+	if builder := results[1]; !builder.Success || len(builder.Violations) != 0 || builder.ImageURL == "" {
+		t.Errorf("quay-builder = %+v", builder)
+	}
+}
@@ -0,0 +1,62 @@
+package konflux
+
+import (
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Release is a Konflux Release custom resource, as exported to S3 by the
+// release pipeline.
+type Release struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Snapshot    string `json:"snapshot"`
+		ReleasePlan string `json:"releasePlan"`
+	} `json:"spec"`
+	Status struct {
+		Target         string      `json:"target"`
+		StartTime      *time.Time  `json:"startTime"`
+		CompletionTime *time.Time  `json:"completionTime"`
+		Conditions     []Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// Condition is a Kubernetes status condition.
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"` // "True", "False" or "Unknown"
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// ConvertRelease transforms a Release CR into a model.SnapshotRelease. The
+// state is taken from the Released condition: true means succeeded, false
+// with reason Failed means failed, and anything else, including a missing
+// condition, means the release is still progressing.
+func ConvertRelease(r Release) model.SnapshotRelease {
+	rel := model.SnapshotRelease{
+		Name:           r.Metadata.Name,
+		Snapshot:       r.Spec.Snapshot,
+		ReleasePlan:    r.Spec.ReleasePlan,
+		Target:         r.Status.Target,
+		Status:         model.ReleaseProgressing,
+		StartTime:      r.Status.StartTime,
+		CompletionTime: r.Status.CompletionTime,
+	}
+	for _, c := range r.Status.Conditions {
+		if c.Type != "Released" {
+			continue
+		}
+		rel.Reason, rel.Message = c.Reason, c.Message
+		switch {
+		case c.Status == "True":
+			rel.Status = model.ReleaseSucceeded
+		case c.Status == "False" && c.Reason == "Failed":
+			rel.Status = model.ReleaseFailed
+		}
+	}
+	return rel
+}
@@ -0,0 +1,55 @@
+package konflux
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestConvertRelease(t *testing.T) {
+	var r Release
+	err := json.Unmarshal([]byte(`{
+		"apiVersion": "appstudio.redhat.com/v1alpha1",
+		"kind": "Release",
+		"metadata": {"name": "quay-v3-17-snap-1-prod"},
+		"spec": {"snapshot": "quay-v3-17-snap-1", "releasePlan": "quay-v3-17-prod"},
+		"status": {
+			"target": "rhtap-releng-tenant",
+			"startTime": "2026-03-02T10:00:00Z",
+			"completionTime": "2026-03-02T10:20:00Z",
+			"conditions": [
+				{"type": "Validated", "status": "True", "reason": "Succeeded"},
+				{"type": "Released", "status": "False", "reason": "Failed", "message": "Release processing failed on managed pipelineRun"}
+			]
+		}
+	}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel := ConvertRelease(r)
+	if rel.Name != "quay-v3-17-snap-1-prod" || rel.Snapshot != "quay-v3-17-snap-1" || rel.ReleasePlan != "quay-v3-17-prod" || rel.Target != "rhtap-releng-tenant" {
+		t.Errorf("release = %+v", rel)
+	}
+	if rel.Status != model.ReleaseFailed || rel.Reason != "Failed" || rel.Message == "" {
+		t.Errorf("status = %q, reason = %q, message = %q", rel.Status, rel.Reason, rel.Message)
+	}
+	if rel.StartTime == nil || rel.CompletionTime == nil || rel.CompletionTime.Sub(*rel.StartTime) != 20*time.Minute {
+		t.Errorf("times = %v, %v", rel.StartTime, rel.CompletionTime)
+	}
+
+	for _, tc := range []struct {
+		conditions []Condition
+		want       string
+	}{
+		{nil, model.ReleaseProgressing},
+		{[]Condition{{Type: "Released", Status: "False", Reason: "Progressing"}}, model.ReleaseProgressing},
+		{[]Condition{{Type: "Released", Status: "True", Reason: "Succeeded"}}, model.ReleaseSucceeded},
+	} {
+		r.Status.Conditions = tc.conditions
+		if got := ConvertRelease(r).Status; got != tc.want {
+			t.Errorf("conditions %+v: status = %q, want %q", tc.conditions, got, tc.want)
+		}
+	}
+}
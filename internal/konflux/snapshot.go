@@ -20,6 +20,32 @@ type SnapshotSpec struct {
 	} `json:"components"`
 }
 
+// Snapshot is a Konflux Snapshot custom resource, as pushed by a Tekton
+// pipeline. Unlike the S3 copy it names itself.
+type Snapshot struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec SnapshotSpec `json:"spec"`
+}
+
+// SnapshotPush is the body of a snapshot push: a Snapshot CR and, optionally,
+// the test results of the snapshot, so that pipelines need not upload them
+// to S3 first.
+type SnapshotPush struct {
+	Snapshot
+	TestResults []TestResult `json:"testResults,omitempty"`
+}
+
+// TestResult is a JUnit XML report of one test scenario. A scenario may
+// have several reports, which are merged as the files of its S3 directory
+// are.
+type TestResult struct {
+	Scenario string `json:"scenario"`
+	JUnit    string `json:"junit"`
+}
+
 // Convert transforms a SnapshotSpec into a model.Snapshot.
 // The name parameter is the snapshot directory name from S3 (since
 // the spec does not include the snapshot name).
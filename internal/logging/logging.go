@@ -0,0 +1,98 @@
+// Package logging provides a slog.Handler whose minimum level can be changed
+// at runtime, globally or for a single component (the value of the
+// "component" attribute, e.g. s3-sync or jira-sync).
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+)
+
+// ComponentKey is the attribute key used to select per-component levels.
+const ComponentKey = "component"
+
+// Levels holds the default minimum level and any per-component overrides.
+// It is safe for concurrent use.
+type Levels struct {
+	mu         sync.RWMutex
+	base       slog.Level
+	components map[string]slog.Level
+}
+
+// NewLevels returns Levels with the given default level and no overrides.
+func NewLevels(base slog.Level) *Levels {
+	return &Levels{base: base, components: map[string]slog.Level{}}
+}
+
+// Level returns the effective level for component ("" for the default).
+func (l *Levels) Level(component string) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if lvl, ok := l.components[component]; ok && component != "" {
+		return lvl
+	}
+	return l.base
+}
+
+// Set changes the default level, or the override for component if non-empty.
+func (l *Levels) Set(component string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if component == "" {
+		l.base = level
+		return
+	}
+	l.components[component] = level
+}
+
+// Reset removes the override for component so it follows the default again.
+func (l *Levels) Reset(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.components, component)
+}
+
+// Snapshot returns the default level and a copy of the overrides.
+func (l *Levels) Snapshot() (slog.Level, map[string]slog.Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.base, maps.Clone(l.components)
+}
+
+// Handler filters records by the level configured for its component. The
+// wrapped handler should be created with the lowest level that may ever be
+// enabled (slog.LevelDebug) so that it does not filter on its own.
+type Handler struct {
+	inner     slog.Handler
+	levels    *Levels
+	component string
+}
+
+// NewHandler wraps h with level filtering driven by levels.
+func NewHandler(h slog.Handler, levels *Levels) *Handler {
+	return &Handler{inner: h, levels: levels}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.levels.Level(h.component) && h.inner.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+	for _, a := range attrs {
+		if a.Key == ComponentKey {
+			component = a.Value.String()
+		}
+	}
+	return &Handler{inner: h.inner.WithAttrs(attrs), levels: h.levels, component: component}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{inner: h.inner.WithGroup(name), levels: h.levels, component: h.component}
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestHandlerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(slog.LevelInfo)
+	logger := slog.New(NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), levels))
+	s3Log := logger.With(ComponentKey, "s3-sync")
+	jiraLog := logger.With(ComponentKey, "jira-sync")
+
+	logDebug := func() string {
+		buf.Reset()
+		logger.Debug("root")
+		s3Log.Debug("s3")
+		jiraLog.Debug("jira")
+		return buf.String()
+	}
+
+	if out := logDebug(); out != "" {
+		t.Errorf("debug logged at info level:\n%s", out)
+	}
+
+	levels.Set("s3-sync", slog.LevelDebug)
+	out := logDebug()
+	if !strings.Contains(out, "msg=s3") || strings.Contains(out, "msg=jira") || strings.Contains(out, "msg=root") {
+		t.Errorf("component override: got\n%s", out)
+	}
+
+	levels.Reset("s3-sync")
+	levels.Set("", slog.LevelDebug)
+	out = logDebug()
+	for _, msg := range []string{"msg=root", "msg=s3", "msg=jira"} {
+		if !strings.Contains(out, msg) {
+			t.Errorf("default debug: missing %s in\n%s", msg, out)
+		}
+	}
+
+	levels.Set("jira-sync", slog.LevelError)
+	if out := logDebug(); strings.Contains(out, "msg=jira") {
+		t.Errorf("component override above default: got\n%s", out)
+	}
+}
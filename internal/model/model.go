@@ -1,55 +1,349 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Component struct {
 	ID          int64     `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	// JIRAComponents are the JIRA components the component's issues are
+	// filed under. If empty, the JIRA component of the same name is used.
+	JIRAComponents []string `json:"jira_components"`
+}
+
+// IssueComponents returns the JIRA components c's issues are filed under.
+func (c Component) IssueComponents() []string {
+	if len(c.JIRAComponents) == 0 {
+		return []string{c.Name}
+	}
+	return c.JIRAComponents
 }
 
 type ComponentRecord struct {
-	ID         int64  `json:"id"`
-	SnapshotID int64  `json:"snapshot_id"`
-	Component  string `json:"component"`
-	GitSHA     string `json:"git_sha"`
-	ImageURL   string `json:"image_url"`
-	GitURL     string `json:"git_url"`
+	ID         int64          `json:"id"`
+	SnapshotID int64          `json:"snapshot_id"`
+	Component  string         `json:"component"`
+	GitSHA     string         `json:"git_sha"`
+	ImageURL   string         `json:"image_url"`
+	GitURL     string         `json:"git_url"`
+	SBOM       *ComponentSBOM `json:"sbom,omitempty"`
+}
+
+// ComponentSBOM is the SBOM of a snapshot component's image: where its
+// attestation is stored and, if the document was uploaded with the
+// snapshot, a summary of it.
+type ComponentSBOM struct {
+	Reference string   `json:"reference,omitempty"` // e.g. quay.io/org/repo:sha256-abc.sbom
+	Format    string   `json:"format,omitempty"`    // "spdx" or "cyclonedx"; empty without a summary
+	Packages  int      `json:"packages"`
+	Licenses  []string `json:"licenses,omitempty"` // most common first
 }
 
 type SnapshotRecord struct {
-	ID                   int64                 `json:"id"`
-	Application          string                `json:"application"`
-	Name                 string                `json:"name"`
-	TestsPassed          bool                  `json:"tests_passed"`
-	HasTests             bool                  `json:"has_tests"`
-	CreatedAt            time.Time             `json:"created_at"`
-	Components           []ComponentRecord     `json:"components,omitempty"`
-	TestSuites           []TestSuite           `json:"test_suites,omitempty"`
-	VulnerabilityReports []VulnerabilityReport `json:"vulnerability_reports,omitempty"`
+	ID                   int64                   `json:"id"`
+	Application          string                  `json:"application"`
+	Name                 string                  `json:"name"`
+	Source               string                  `json:"source,omitempty"`    // object store synced from; empty for the default one
+	Lifecycle            string                  `json:"lifecycle,omitempty"` // one of the Lifecycle states
+	TestsPassed          bool                    `json:"tests_passed"`
+	HasTests             bool                    `json:"has_tests"`
+	CreatedAt            time.Time               `json:"created_at"`
+	Components           []ComponentRecord       `json:"components,omitempty"`
+	TestSuites           []TestSuite             `json:"test_suites,omitempty"`
+	VulnerabilityReports []VulnerabilityReport   `json:"vulnerability_reports,omitempty"`
+	ImageVerifications   []ImageVerification     `json:"image_verifications,omitempty"`
+	ImageDigests         *ImageDigestSummary     `json:"image_digests,omitempty"`
+	Releases             []SnapshotRelease       `json:"releases,omitempty"`
+	ScenarioTransitions  []ScenarioTransition    `json:"scenario_transitions,omitempty"`
+	LifecycleTransitions []LifecycleTransition   `json:"lifecycle_transitions,omitempty"`
+	ReleasePipelines     *ReleasePipelineSummary `json:"release_pipelines,omitempty"`
+	ECResults            []ECResult              `json:"ec_results,omitempty"`
+	EC                   *ECSummary              `json:"ec,omitempty"`
 }
 
 type TestSuite struct {
-	ID          int64      `json:"id"`
-	SnapshotID  int64      `json:"snapshot_id"`
-	Name        string     `json:"name"`
-	Status      string     `json:"status"`
-	PipelineRun string     `json:"pipeline_run"`
-	ToolName    string     `json:"tool_name"`
-	ToolVersion string     `json:"tool_version"`
-	Tests       int        `json:"tests"`
-	Passed      int        `json:"passed"`
-	Failed      int        `json:"failed"`
-	Skipped     int        `json:"skipped"`
-	Pending     int        `json:"pending"`
-	Other       int        `json:"other"`
-	Flaky       int        `json:"flaky"`
-	StartTime   int64      `json:"start_time"`
-	StopTime    int64      `json:"stop_time"`
-	DurationMs  int64      `json:"duration_ms"`
-	CreatedAt   time.Time  `json:"created_at"`
-	TestCases   []TestCase `json:"test_cases,omitempty"`
+	ID          int64        `json:"id"`
+	SnapshotID  int64        `json:"snapshot_id"`
+	Name        string       `json:"name"`
+	Status      string       `json:"status"` // "passed", "failed", or "pending" while tests are still to run
+	PipelineRun string       `json:"pipeline_run"`
+	ToolName    string       `json:"tool_name"`
+	ToolVersion string       `json:"tool_version"`
+	Tests       int          `json:"tests"`
+	Passed      int          `json:"passed"`
+	Failed      int          `json:"failed"`
+	Skipped     int          `json:"skipped"`
+	Pending     int          `json:"pending"`
+	Other       int          `json:"other"`
+	Flaky       int          `json:"flaky"`
+	StartTime   int64        `json:"start_time"`
+	StopTime    int64        `json:"stop_time"`
+	DurationMs  int64        `json:"duration_ms"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Truncated   bool         `json:"truncated"` // cases or failure text were capped at ingest, or the report was rejected
+	TestCases   []TestCase   `json:"test_cases,omitempty"`
+	Run         *PipelineRun `json:"run,omitempty"` // resolved from PipelineRun by Tekton Results
+}
+
+// ScenarioTransition is a change of the status of a test scenario of a
+// snapshot found after the snapshot was ingested, typically from pending
+// once the scenario's tests completed.
+type ScenarioTransition struct {
+	Scenario  string    `json:"scenario"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// PipelineRun states, derived from the Succeeded condition of a Tekton
+// PipelineRun or TaskRun.
+const (
+	PipelineRunRunning   = "running"
+	PipelineRunSucceeded = "succeeded"
+	PipelineRunFailed    = "failed"
+	PipelineRunUnknown   = "unknown" // the run could not be found
+)
+
+// PipelineRun is the Tekton PipelineRun that ran a test suite, as recorded
+// by Tekton Results.
+type PipelineRun struct {
+	Namespace      string         `json:"namespace"`
+	Name           string         `json:"name"`
+	Status         string         `json:"status"`
+	Reason         string         `json:"reason,omitempty"`
+	Message        string         `json:"message,omitempty"` // failure reason of a failed run
+	StartTime      *time.Time     `json:"start_time,omitempty"`
+	CompletionTime *time.Time     `json:"completion_time,omitempty"`
+	DurationMs     int64          `json:"duration_ms"`
+	Tasks          []PipelineTask `json:"tasks"`
+	ResolvedAt     time.Time      `json:"resolved_at"`
+}
+
+// PipelineTask is one TaskRun of a PipelineRun.
+type PipelineTask struct {
+	Name           string     `json:"name"` // pipeline task name
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+	DurationMs     int64      `json:"duration_ms"`
+}
+
+// PipelineRunRef is a test suite whose PipelineRun has not been resolved
+// to a finished run yet.
+type PipelineRunRef struct {
+	TestSuiteID int64
+	Snapshot    string
+	Suite       string
+	PipelineRun string // run name or Konflux UI URL
+}
+
+// Component change kinds in a SnapshotDiff.
+const (
+	ComponentAdded   = "added"
+	ComponentRemoved = "removed"
+	ComponentChanged = "changed"
+)
+
+// Scenario change kinds in a SnapshotDiff.
+const (
+	ScenarioRegressed = "regressed"
+	ScenarioRecovered = "recovered"
+	ScenarioAdded     = "added"
+	ScenarioRemoved   = "removed"
+)
+
+// SnapshotDiff compares two snapshots of the same application, from the
+// older (From) to the newer (To). Only components and scenarios that
+// differ are listed.
+type SnapshotDiff struct {
+	From       SnapshotRecord    `json:"from"`
+	To         SnapshotRecord    `json:"to"`
+	Components []ComponentChange `json:"components"`
+	Scenarios  []ScenarioChange  `json:"scenarios"`
+	Tests      TestCountDiff     `json:"tests"`
+}
+
+// ComponentChange is a component whose image or source revision differs
+// between two snapshots.
+type ComponentChange struct {
+	Component    string `json:"component"`
+	Change       string `json:"change"` // ComponentAdded, ComponentRemoved or ComponentChanged
+	FromImage    string `json:"from_image,omitempty"`
+	ToImage      string `json:"to_image,omitempty"`
+	FromRevision string `json:"from_revision,omitempty"`
+	ToRevision   string `json:"to_revision,omitempty"`
+	GitURL       string `json:"git_url,omitempty"`
+	CompareURL   string `json:"compare_url,omitempty"` // commit range; GitHub repositories only
+
+	// Commits are the commits between the two revisions, oldest first, and
+	// PullRequests the pull requests merged in them; both are listed when a
+	// GitHub token is configured. TotalCommits counts the commits of the
+	// range, which may be more than are listed.
+	Commits      []Commit      `json:"commits,omitempty"`
+	TotalCommits int           `json:"total_commits,omitempty"`
+	PullRequests []PullRequest `json:"pull_requests,omitempty"`
+}
+
+// Commit is a commit between two component revisions.
+type Commit struct {
+	SHA     string `json:"sha"`
+	Subject string `json:"subject"`
+	Author  string `json:"author"`
+	URL     string `json:"url"`
+}
+
+// PullRequest is a merged GitHub pull request.
+type PullRequest struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Author   string    `json:"author"`
+	URL      string    `json:"url"`
+	MergedAt time.Time `json:"merged_at"`
+}
+
+// GitRange is what changed between two commits of a repository: its
+// commits, oldest first and possibly capped, and the pull requests merged
+// in them.
+type GitRange struct {
+	Commits      []Commit
+	TotalCommits int
+	PullRequests []PullRequest
+}
+
+// ScenarioChange is a test scenario that was added, removed, or changed
+// outcome between two snapshots.
+type ScenarioChange struct {
+	Name       string     `json:"name"`
+	Change     string     `json:"change"` // one of the Scenario* kinds
+	FromStatus string     `json:"from_status,omitempty"`
+	ToStatus   string     `json:"to_status,omitempty"`
+	Delta      TestCounts `json:"delta"`
+}
+
+// TestCounts totals test case outcomes.
+type TestCounts struct {
+	Tests   int `json:"tests"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// TestCountDiff holds the test totals of both snapshots in a SnapshotDiff
+// and their difference (To minus From).
+type TestCountDiff struct {
+	From  TestCounts `json:"from"`
+	To    TestCounts `json:"to"`
+	Delta TestCounts `json:"delta"`
+}
+
+// ScenarioRun is one snapshot's result for a test scenario (suite).
+type ScenarioRun struct {
+	Snapshot   string    `json:"snapshot"`
+	CreatedAt  time.Time `json:"created_at"`
+	Status     string    `json:"status"`
+	Tests      int       `json:"tests"`
+	Passed     int       `json:"passed"`
+	Failed     int       `json:"failed"`
+	Skipped    int       `json:"skipped"`
+	DurationMs int64     `json:"duration_ms"`
+	// PassRate is passed over executed (not skipped) tests; nil if none ran.
+	PassRate *float64 `json:"pass_rate"`
+}
+
+// ScenarioTrend is a scenario's pass rate and duration over an
+// application's recent snapshots.
+type ScenarioTrend struct {
+	Application string        `json:"application"`
+	Scenario    string        `json:"scenario"`
+	Runs        []ScenarioRun `json:"runs"` // oldest first
+	// PassRate is passed over executed tests across all runs.
+	PassRate       *float64 `json:"pass_rate"`
+	MeanDurationMs int64    `json:"mean_duration_ms"`
+	// DurationChange is the relative change of the mean duration of the
+	// newer half of the runs over the older half, e.g. 0.2 for 20%
+	// slower; nil with fewer than two runs.
+	DurationChange *float64 `json:"duration_change"`
+}
+
+// ScenarioPassRate is a scenario's pass rate over an application's
+// snapshots of the last days, for several windows of days, and its most
+// recent failure.
+type ScenarioPassRate struct {
+	Scenario string           `json:"scenario"`
+	Required bool             `json:"required"`
+	Windows  []PassRateWindow `json:"windows"` // shortest first
+	// LastFailure is the newest snapshot the scenario failed in, however
+	// old; nil if it never failed.
+	LastFailure *ScenarioFailure `json:"last_failure"`
+}
+
+// PassRateWindow aggregates a scenario's finished runs in the snapshots
+// created in the last Days days.
+type PassRateWindow struct {
+	Days       int `json:"days"`
+	Runs       int `json:"runs"`
+	FailedRuns int `json:"failed_runs"`
+	// PassRate is passed over executed (not skipped) tests across the
+	// runs; nil if none ran.
+	PassRate *float64 `json:"pass_rate"`
+}
+
+// ScenarioFailure is a snapshot a scenario failed in.
+type ScenarioFailure struct {
+	Snapshot  string    `json:"snapshot"`
+	CreatedAt time.Time `json:"created_at"`
+	Failed    int       `json:"failed"`
+}
+
+// TestFailure is a failed test case and the snapshot it failed in.
+type TestFailure struct {
+	Application string    `json:"application"`
+	Snapshot    string    `json:"snapshot"`
+	CreatedAt   time.Time `json:"created_at"` // of the snapshot
+	Scenario    string    `json:"scenario"`
+	Test        string    `json:"test"`
+	Message     string    `json:"message"`
+}
+
+// FailureGroup is the recent failures of test cases that failed with the
+// same message, once the parts that differ between runs, such as
+// timestamps and UUIDs, are masked.
+type FailureGroup struct {
+	Message  string `json:"message"`  // the masked message; empty for failures without one
+	Failures int    `json:"failures"` // failed test cases
+	// Snapshots and Applications are those the failures happened in,
+	// snapshots newest first and applications by name.
+	Snapshots    []string     `json:"snapshots"`
+	Applications []string     `json:"applications"`
+	Tests        []FailedTest `json:"tests"` // by scenario, then name
+	FirstSeen    time.Time    `json:"first_seen"`
+	LastSeen     time.Time    `json:"last_seen"`
+	// Latest is the newest failure, with its message as reported.
+	Latest TestFailure `json:"latest"`
+}
+
+// FailedTest is a test case of a scenario.
+type FailedTest struct {
+	Scenario string `json:"scenario"`
+	Test     string `json:"test"`
+}
+
+// ScenarioRequirement marks whether an application's test scenario
+// (suite) is required, so that its failures fail the snapshot's
+// tests_passed and withhold readiness, or only informational. Scenarios
+// without a requirement are required.
+type ScenarioRequirement struct {
+	Application string    `json:"application"`
+	Scenario    string    `json:"scenario"`
+	Required    bool      `json:"required"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type TestSuiteMeta struct {
@@ -100,16 +394,284 @@ type Vulnerability struct {
 	Link           string `json:"link"`
 }
 
+// ImageVerification records whether a snapshot component's image digest
+// still resolves in its registry.
+type ImageVerification struct {
+	Component string    `json:"component"`
+	ImageURL  string    `json:"image_url"`
+	Tag       string    `json:"tag,omitempty"` // tag the image was published under, if found
+	Status    string    `json:"status"`        // "ok", "missing", "mismatch", "error"
+	Message   string    `json:"message,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ImageDigestSummary counts the image verification results of a snapshot.
+type ImageDigestSummary struct {
+	Components int `json:"components"`
+	Verified   int `json:"verified"`
+	Failed     int `json:"failed"`  // digest missing or tag moved
+	Missing    int `json:"missing"` // digest garbage-collected; counted in Failed too
+}
+
+// Konflux Release states, derived from a Release CR's Released condition.
+const (
+	ReleaseProgressing = "progressing"
+	ReleaseSucceeded   = "succeeded"
+	ReleaseFailed      = "failed"
+)
+
+// SnapshotRelease is a Konflux Release of a snapshot: one run of the
+// release pipeline of a release plan, shipping the snapshot to a target.
+type SnapshotRelease struct {
+	Name           string     `json:"name"`
+	Snapshot       string     `json:"snapshot"`
+	ReleasePlan    string     `json:"release_plan"`
+	Target         string     `json:"target"`
+	Status         string     `json:"status"` // "progressing", "succeeded", "failed"
+	Reason         string     `json:"reason,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+}
+
+// IngestFailure is a snapshot found in an object store whose ingestion
+// failed. Polls retry it until it has failed the configured number of
+// times; it is then dead, and skipped until re-ingested through the API.
+type IngestFailure struct {
+	ID            int64     `json:"id"`
+	Source        string    `json:"source,omitempty"`
+	Key           string    `json:"key"` // of its snapshot.json
+	Application   string    `json:"application"`
+	Snapshot      string    `json:"snapshot"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	Dead          bool      `json:"dead"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+}
+
+// ReleasePipelineSummary counts the Konflux Releases of a snapshot by
+// state. A failed release is not counted once a later release through the
+// same plan has succeeded.
+type ReleasePipelineSummary struct {
+	Succeeded   int `json:"succeeded"`
+	Failed      int `json:"failed"`
+	Progressing int `json:"progressing"`
+}
+
+// ECFinding is an Enterprise Contract policy violation or warning.
+type ECFinding struct {
+	Code     string `json:"code,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Message  string `json:"message"`
+	Solution string `json:"solution,omitempty"`
+}
+
+// ECResult is the Enterprise Contract verification result of one component
+// image of a snapshot.
+type ECResult struct {
+	Component  string      `json:"component"`
+	ImageURL   string      `json:"image_url"`
+	Success    bool        `json:"success"`
+	Violations []ECFinding `json:"violations"`
+	Warnings   []ECFinding `json:"warnings"`
+}
+
+// ECReport is the Enterprise Contract verification of a snapshot.
+type ECReport struct {
+	Snapshot   string     `json:"snapshot"`
+	Success    bool       `json:"success"`
+	Components []ECResult `json:"components"`
+}
+
+// ECSummary counts the Enterprise Contract results of a snapshot.
+type ECSummary struct {
+	Components int `json:"components"`
+	Failed     int `json:"failed"`
+}
+
+// Release candidate states. A snapshot without an explicit state is a plain
+// candidate.
+const (
+	CandidateDefault  = "candidate"
+	CandidatePromoted = "promoted"
+	CandidateDemoted  = "demoted"
+)
+
+// ReleaseCandidate is a snapshot considered for a release. Selected marks
+// the candidate that feeds readiness: the promoted snapshot if there is one,
+// otherwise the newest snapshot that has not been demoted.
+type ReleaseCandidate struct {
+	Snapshot  SnapshotRecord `json:"snapshot"`
+	State     string         `json:"state"`
+	ChangedAt *time.Time     `json:"changed_at,omitempty"`
+	Selected  bool           `json:"selected"`
+}
+
+// Snapshot lifecycle states, recording which snapshots were considered as
+// release candidates and what became of them. Every snapshot starts new.
+const (
+	LifecycleNew       = "new"
+	LifecycleCandidate = "candidate"
+	LifecycleRejected  = "rejected"
+	LifecycleAccepted  = "accepted"
+	LifecycleReleased  = "released"
+)
+
+// LifecycleNext lists the states a snapshot may move to from each lifecycle
+// state. A rejected snapshot may be reconsidered; a released one is final.
+var LifecycleNext = map[string][]string{
+	LifecycleNew:       {LifecycleCandidate, LifecycleRejected},
+	LifecycleCandidate: {LifecycleAccepted, LifecycleRejected},
+	LifecycleAccepted:  {LifecycleReleased, LifecycleRejected},
+	LifecycleRejected:  {LifecycleCandidate},
+	LifecycleReleased:  nil,
+}
+
+// LifecycleTransition is a change of a snapshot's lifecycle state, with who
+// made it and why.
+type LifecycleTransition struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedBy string    `json:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// SnapshotLifecycle is a snapshot's lifecycle state and how it got there.
+type SnapshotLifecycle struct {
+	Snapshot    string                `json:"snapshot"`
+	State       string                `json:"state"`
+	Transitions []LifecycleTransition `json:"transitions"`
+}
+
+// Readiness matrix cell statuses. A cell is untested when every test case
+// of its scenario belongs to other components.
+const (
+	MatrixPassed   = "passed"
+	MatrixFailed   = "failed"
+	MatrixPending  = "pending"
+	MatrixSkipped  = "skipped"
+	MatrixUntested = "untested"
+)
+
+// ReadinessMatrix breaks a release's selected candidate snapshot down by
+// component (rows) and test scenario (columns).
+type ReadinessMatrix struct {
+	Release   string           `json:"release"`
+	Snapshot  string           `json:"snapshot"`
+	Scenarios []MatrixScenario `json:"scenarios"`
+	Rows      []MatrixRow      `json:"rows"`
+}
+
+// MatrixScenario is a column of a readiness matrix.
+type MatrixScenario struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`   // the scenario's own status
+	Required bool   `json:"required"` // false for informational scenarios
+}
+
+// MatrixRow is a component of a readiness matrix, with one cell per
+// scenario in the order of ReadinessMatrix.Scenarios.
+type MatrixRow struct {
+	Component string       `json:"component"`
+	Cells     []MatrixCell `json:"cells"`
+}
+
+// MatrixCell is the result of a scenario for a component. Scoped cells
+// count test cases whose suite is named after the component; the others
+// show the result of the test cases that name no component.
+type MatrixCell struct {
+	Status  string `json:"status"` // one of the Matrix statuses
+	Tests   int    `json:"tests"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+	Scoped  bool   `json:"scoped"`
+}
+
+// Sign-off roles. A release is fully signed off once each of ApprovalRoles
+// has approved it.
+const (
+	RoleQE  = "QE"
+	RoleDev = "Dev"
+	RolePM  = "PM"
+)
+
+// ApprovalRoles lists the roles whose approval a release needs.
+var ApprovalRoles = []string{RoleQE, RoleDev, RolePM}
+
+// Approval is a recorded "approved for release" decision.
+type Approval struct {
+	ID        int64     `json:"id"`
+	Release   string    `json:"release"`
+	Approver  string    `json:"approver"`
+	Role      string    `json:"role"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SignOff splits ApprovalRoles by whether they have approved a release.
+type SignOff struct {
+	Approved    []string `json:"approved"`
+	Outstanding []string `json:"outstanding"`
+}
+
 type ApplicationSummary struct {
 	Application    string          `json:"application"`
 	LatestSnapshot *SnapshotRecord `json:"latest_snapshot,omitempty"`
 	SnapshotCount  int             `json:"snapshot_count"`
 }
 
+// ApplicationConfig is the metadata configured for an S3 application.
+type ApplicationConfig struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	Product     string `json:"product,omitempty"`
+	// FixVersions are JIRA fixVersions mapped to the application, in place
+	// of the application derived from their names.
+	FixVersions []string `json:"fix_versions,omitempty"`
+	// Retention, if set, replaces the snapshot retention limits of the
+	// application.
+	Retention *RetentionLimits `json:"retention,omitempty"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+}
+
+// RetentionLimits caps how many snapshots of an application are kept, and
+// for how long. A zero limit means none.
+type RetentionLimits struct {
+	MaxCount int `json:"max_count"`
+	// MaxAge is a Go duration such as "2160h".
+	MaxAge string `json:"max_age,omitempty"`
+}
+
+// Application is an S3 application, discovered from its snapshots or
+// configured ahead of them, with its metadata.
+type Application struct {
+	ApplicationConfig
+	// Configured reports whether any metadata has been configured.
+	Configured     bool            `json:"configured"`
+	SnapshotCount  int             `json:"snapshot_count"`
+	LatestSnapshot *SnapshotRecord `json:"latest_snapshot,omitempty"`
+	// Releases are the fixVersions whose snapshots come from the
+	// application.
+	Releases  []string              `json:"releases"`
+	Scenarios []ScenarioRequirement `json:"scenarios"`
+}
+
+// JiraSyncState records when a fixVersion's issues were last synced from
+// JIRA, incrementally or in full.
+type JiraSyncState struct {
+	FixVersion   string     `json:"fix_version"`
+	SyncedAt     *time.Time `json:"synced_at,omitempty"`
+	ReconciledAt *time.Time `json:"reconciled_at,omitempty"` // last full sync
+}
+
 // JiraIssueRecord represents a JIRA issue cached in the database.
 type JiraIssueRecord struct {
 	ID         int64     `json:"id"`
 	Key        string    `json:"key"`
+	Project    string    `json:"project"`
 	Summary    string    `json:"summary"`
 	Status     string    `json:"status"`
 	Priority   string    `json:"priority"`
@@ -120,7 +682,112 @@ type JiraIssueRecord struct {
 	Resolution string    `json:"resolution"`
 	Link       string    `json:"link"`
 	QAContact  string    `json:"qa_contact"`
+	Severity   string    `json:"severity,omitempty"`   // CVE severity, e.g. "Important"
+	Clones     string    `json:"clones,omitempty"`     // comma-separated keys of clone/backport-linked issues
+	Components string    `json:"components,omitempty"` // comma-separated JIRA component names
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Security fields, set on CVE issues only.
+	CVEID     string  `json:"cve_id,omitempty"`     // e.g. "CVE-2026-1234"
+	CVSSScore float64 `json:"cvss_score,omitempty"` // 0 if unknown
+	Embargoed bool    `json:"embargoed,omitempty"`
+
+	// Blocker is set when the issue carries one of the BlockerLabels.
+	Blocker bool `json:"blocker,omitempty"`
+
+	// BlockedBy lists the issues this one is blocked by or depends on in
+	// JIRA.
+	BlockedBy []IssueBlocker `json:"blocked_by,omitempty"`
+
+	// Subtasks lists the issue's JIRA sub-tasks, and SubtaskCompletion the
+	// fraction of them that are done, from 0 to 1. SubtaskCompletion is nil
+	// for issues without sub-tasks.
+	Subtasks          []IssueSubtask `json:"subtasks,omitempty"`
+	SubtaskCompletion *float64       `json:"subtask_completion,omitempty"`
+}
+
+// IssueSubtask is a JIRA sub-task of an issue. Status is the sub-task's
+// synced status if it is synced under any fixVersion, else its status when
+// the parent was last synced.
+type IssueSubtask struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Link    string `json:"link"`
+}
+
+// Done reports whether the sub-task is closed, verified, or done.
+func (t IssueSubtask) Done() bool {
+	return isDoneStatus(t.Status)
+}
+
+// IssueBlocker is an issue that blocks another through a JIRA "Blocks" or
+// dependency link. Status is the blocker's synced status if it is synced
+// under any fixVersion, else its status when the blocked issue was last
+// synced.
+type IssueBlocker struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Link    string `json:"link"`
+}
+
+// Resolved reports whether the blocker is closed, verified, or done.
+func (b IssueBlocker) Resolved() bool {
+	return isDoneStatus(b.Status)
+}
+
+// OpenBlockers returns the blockers of the issue that are not resolved.
+func (i JiraIssueRecord) OpenBlockers() []IssueBlocker {
+	var open []IssueBlocker
+	for _, b := range i.BlockedBy {
+		if !b.Resolved() {
+			open = append(open, b)
+		}
+	}
+	return open
+}
+
+// IssueFilter narrows a release's issue list. Empty fields match any
+// value. Offset applies only when Limit is set; a zero Limit returns every
+// match.
+type IssueFilter struct {
+	Type       string
+	Status     string
+	Label      string // substring of the labels, case-insensitive
+	Assignee   string
+	Resolution string
+	Limit      int
+	Offset     int
+}
+
+// Done reports whether the issue is closed, verified, or done; the same
+// rule IssueSummary counts as verified.
+func (i JiraIssueRecord) Done() bool {
+	return isDoneStatus(i.Status)
+}
+
+func isDoneStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "closed", "verified", "done":
+		return true
+	}
+	return false
+}
+
+// Backport match kinds.
+const (
+	BackportClone   = "clone"   // the issues are linked as clones or backports in JIRA
+	BackportSummary = "summary" // the issues have the same summary, ignoring clone prefixes
+)
+
+// Backport pairs an issue of a release with its counterpart in a newer
+// stream of the same product. It is pending while Issue is not done.
+type Backport struct {
+	Issue   JiraIssueRecord `json:"issue"`
+	Source  JiraIssueRecord `json:"source"`
+	Match   string          `json:"match"`
+	Pending bool            `json:"pending"`
 }
 
 // IssueSummary provides aggregate counts of JIRA issues for a release.
@@ -130,6 +797,122 @@ type IssueSummary struct {
 	Open     int `json:"open"`
 	CVEs     int `json:"cves"`
 	Bugs     int `json:"bugs"`
+
+	// OpenBlockers counts open issues labelled as release blockers.
+	OpenBlockers int `json:"open_blockers"`
+
+	// Blocked counts issues that are done in JIRA but blocked by or
+	// depending on an unresolved issue. They are counted as open, not
+	// verified, until their blockers are resolved.
+	Blocked int `json:"blocked"`
+
+	// Subtasks counts the sub-tasks of the issues, and SubtasksDone those
+	// of them that are done, so progress shows within issues that are
+	// still open.
+	Subtasks     int `json:"subtasks"`
+	SubtasksDone int `json:"subtasks_done"`
+
+	// OpenCVESeverities counts open CVE issues by severity. Issues without
+	// a severity are counted under "".
+	OpenCVESeverities map[string]int `json:"open_cve_severities,omitempty"`
+
+	// CVESeverities breaks the CVE issues down by severity, most severe
+	// first. Issues without a severity are counted under "".
+	CVESeverities []CVESeverityCount `json:"cve_severities,omitempty"`
+
+	// Buckets breaks the issues down by the configured IssueBuckets, in
+	// their configured order.
+	Buckets []BucketCount `json:"buckets,omitempty"`
+
+	// Components breaks the issues down by dashboard component, ordered
+	// by component name. Components without issues are left out.
+	Components []ComponentCount `json:"components,omitempty"`
+
+	// Projects breaks the issues down by JIRA project, ordered by project
+	// key.
+	Projects []ProjectCount `json:"projects,omitempty"`
+
+	// AsOf is when the release's issues were last synced from JIRA; nil if
+	// they never were. Stale is set once that is longer ago than the
+	// staleness threshold, e.g. while JIRA is down, so the counts are those
+	// of AsOf.
+	Stale bool       `json:"stale,omitempty"`
+	AsOf  *time.Time `json:"as_of,omitempty"`
+}
+
+// IssueBucket is an admin-defined group of issues, such as "doc-required".
+// An issue belongs to the bucket if it carries any of its labels.
+type IssueBucket struct {
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+// BucketCount counts a release's issues in one IssueBucket.
+type BucketCount struct {
+	Name  string `json:"name"`
+	Total int    `json:"total"`
+	Open  int    `json:"open"`
+}
+
+// CVESeverityCount counts a release's CVE issues of one severity.
+type CVESeverityCount struct {
+	Severity  string  `json:"severity"`
+	Total     int     `json:"total"`
+	Open      int     `json:"open"`
+	Embargoed int     `json:"embargoed"`
+	MaxCVSS   float64 `json:"max_cvss,omitempty"`
+}
+
+// ComponentCount counts a release's issues filed under the JIRA
+// components of one dashboard component.
+type ComponentCount struct {
+	Component    string `json:"component"`
+	Total        int    `json:"total"`
+	Open         int    `json:"open"`
+	OpenBlockers int    `json:"open_blockers"`
+}
+
+// ProjectCount counts a release's issues in one JIRA project.
+type ProjectCount struct {
+	Project string `json:"project"`
+	Total   int    `json:"total"`
+	Open    int    `json:"open"`
+}
+
+// IssueProject returns the project key of an issue key, e.g. "PROJQUAY" for
+// "PROJQUAY-123", or "" if key has no project prefix.
+func IssueProject(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		return key[:i]
+	}
+	return ""
+}
+
+// BugzillaProject is the project of the Bugzilla bugs synced alongside
+// JIRA issues. Their keys are "BZ-" followed by the bug ID.
+const BugzillaProject = "BZ"
+
+// BlockerLabels are the JIRA labels that mark an issue as blocking its
+// release.
+var BlockerLabels = []string{"blocker", "release-blocker"}
+
+// IsBlockerLabel reports whether label is one of the BlockerLabels,
+// ignoring case.
+func IsBlockerLabel(label string) bool {
+	for _, l := range BlockerLabels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddOpenCVE adds n open CVE issues of the given severity.
+func (s *IssueSummary) AddOpenCVE(severity string, n int) {
+	if s.OpenCVESeverities == nil {
+		s.OpenCVESeverities = make(map[string]int)
+	}
+	s.OpenCVESeverities[severity] += n
 }
 
 // ReleaseOverview is a combined view of a release with its issue summary,
@@ -139,12 +922,190 @@ type ReleaseOverview struct {
 	IssueSummary *IssueSummary     `json:"issue_summary,omitempty"`
 	Readiness    ReadinessResponse `json:"readiness"`
 	Snapshot     *SnapshotRecord   `json:"snapshot,omitempty"`
+	SignOff      *SignOff          `json:"sign_off,omitempty"` // unset once released
 }
 
 // ReadinessResponse represents the computed readiness signal for a release.
 type ReadinessResponse struct {
 	Signal  string `json:"signal"`  // "green", "yellow", "red"
 	Message string `json:"message"` // human-readable reason
+
+	// BlockingCVEs counts the open CVEs at or above the readiness CVE
+	// severity gate; always zero when the gate is disabled.
+	BlockingCVEs int `json:"blocking_cves,omitempty"`
+
+	// OpenBlockers counts the open issues labelled as release blockers.
+	OpenBlockers int `json:"open_blockers,omitempty"`
+
+	// OutstandingApprovals lists the sign-off roles that have yet to
+	// approve an unreleased release.
+	OutstandingApprovals []string `json:"outstanding_approvals,omitempty"`
+
+	// AdvisoryState is the Errata Tool state of the release's advisory,
+	// if one is configured and has been synced.
+	AdvisoryState string `json:"advisory_state,omitempty"`
+
+	// Rule names the readiness rule that set Signal; it is empty when the
+	// release is green.
+	Rule string `json:"rule,omitempty"`
+
+	// Waivers lists the active overrides waiving rules the release
+	// currently fails.
+	Waivers []ReadinessOverride `json:"waivers,omitempty"`
+
+	// Schedule projects when an unreleased release with a due date will
+	// have no open issues left.
+	Schedule *ScheduleRisk `json:"schedule,omitempty"`
+}
+
+// BurnWindow is how far back the open-issue burn rate of a release is
+// measured.
+const BurnWindow = 14 * 24 * time.Hour
+
+// ScheduleRisk compares how fast a release's open issues are closing with
+// the time left until its due date.
+type ScheduleRisk struct {
+	// DaysToDue is negative once the due date has passed.
+	DaysToDue  int `json:"days_to_due"`
+	OpenIssues int `json:"open_issues"`
+	// BurnRate is the net number of open issues closed per day over the
+	// last BurnWindow, or over the readiness history if it is shorter; it
+	// is negative when issues were added faster than they were closed.
+	BurnRate float64 `json:"burn_rate"`
+	// BurnDays is how many days of history BurnRate was measured over.
+	BurnDays float64 `json:"burn_days"`
+	// ProjectedCompletion is when the open issues will be closed at
+	// BurnRate; unset when they are not burning down.
+	ProjectedCompletion *time.Time `json:"projected_completion,omitempty"`
+	// AtRisk is set when the projection exceeds the due date, or when
+	// open issues are not burning down after a day of history.
+	AtRisk bool `json:"at_risk"`
+}
+
+// Readiness rules, in the order they are checked. A release manager can
+// waive any of them for a release with a ReadinessOverride.
+const (
+	RulePastDue          = "past_due"
+	RuleOpenBlockers     = "open_blockers"
+	RuleSevereCVEs       = "severe_cves"
+	RuleImageMismatch    = "image_mismatch"
+	RuleReleasePipelines = "release_pipelines"
+	RuleECFailed         = "ec_failed"
+	RuleTestsFailing     = "tests_failing"
+	RuleOpenIssues       = "open_issues"
+	RuleImagesUnverified = "images_unverified"
+	RuleECMissing        = "ec_missing"
+	RuleDueSoon          = "due_soon"
+	RuleAdvisoryDropped  = "advisory_dropped"
+	RuleAdvisoryNotReady = "advisory_not_ready"
+)
+
+// ReadinessRules lists every readiness rule.
+var ReadinessRules = []string{
+	RulePastDue, RuleOpenBlockers, RuleSevereCVEs, RuleImageMismatch,
+	RuleReleasePipelines, RuleECFailed, RuleTestsFailing, RuleOpenIssues,
+	RuleImagesUnverified, RuleECMissing, RuleDueSoon,
+	RuleAdvisoryDropped, RuleAdvisoryNotReady,
+}
+
+// ReadinessOverride waives a readiness rule for a release until it
+// expires, such as a known flaky scenario the release ships despite.
+type ReadinessOverride struct {
+	ID        int64     `json:"id"`
+	Release   string    `json:"release"`
+	Rule      string    `json:"rule"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NotificationState is what was last seen of a release by the notifier, so
+// that only transitions are announced.
+type NotificationState struct {
+	Release        string
+	Signal         string
+	BlockingCVEs   int
+	FailedSnapshot string // selected candidate whose test failures were announced
+}
+
+// Digest frequencies.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestSubscription subscribes an email recipient to the daily or weekly
+// digest of the releases whose name matches Release, a release name or a
+// glob in path.Match syntax.
+type DigestSubscription struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Release   string    `json:"release"`
+	Frequency string    `json:"frequency"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DigestState is what the last digest of a frequency reported of a
+// release, so that the next one only reports what is new.
+type DigestState struct {
+	Frequency       string
+	Release         string
+	SentAt          time.Time
+	Blockers        []string // keys of the open blocker issues
+	FailedScenarios []string // failing scenarios of the selected candidate
+}
+
+// WebhookState is what outbound webhooks were last told of a release, so
+// that only transitions fire.
+type WebhookState struct {
+	Release  string
+	Signal   string
+	Released bool
+}
+
+// ReadinessPoint is a release's readiness and issue counts at one time.
+// Points are only recorded when something changed, so each holds until the
+// next.
+type ReadinessPoint struct {
+	Release      string    `json:"release"`
+	Signal       string    `json:"signal"`
+	Total        int       `json:"total"`
+	Open         int       `json:"open"`
+	Verified     int       `json:"verified"`
+	CVEs         int       `json:"cves"`
+	Bugs         int       `json:"bugs"`
+	BlockingCVEs int       `json:"blocking_cves"`
+	RecordedAt   time.Time `json:"recorded_at"`
+}
+
+// Scope change kinds.
+const (
+	ScopeAdded   = "added"   // the issue was moved into the fixVersion
+	ScopeRemoved = "removed" // the issue was moved out of the fixVersion
+)
+
+// ScopeChange records an issue entering or leaving a release after its
+// first sync.
+type ScopeChange struct {
+	IssueKey  string    `json:"issue_key"`
+	Summary   string    `json:"summary"`
+	Change    string    `json:"change"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// ScopeChanges summarizes how a release's scope changed, oldest change
+// first. Additions from LateSince on, the start of the window before the
+// due date, count as late; without a due date none do.
+type ScopeChanges struct {
+	Release   string        `json:"release"`
+	DueDate   *time.Time    `json:"due_date,omitempty"`
+	LateSince *time.Time    `json:"late_since,omitempty"`
+	Added     int           `json:"added"`
+	Removed   int           `json:"removed"`
+	LateAdded int           `json:"late_added"`
+	Changes   []ScopeChange `json:"changes"`
 }
 
 // ReleaseVersion represents a JIRA fixVersion with release metadata.
@@ -158,4 +1119,192 @@ type ReleaseVersion struct {
 	ReleaseTicketAssignee string     `json:"release_ticket_assignee,omitempty"`
 	S3Application         string     `json:"s3_application,omitempty"`
 	DueDate               *time.Time `json:"due_date,omitempty"`
+	// Hidden is set by an admin, independently of JIRA's Released and
+	// Archived flags, to drop a release from the overview and from syncs.
+	Hidden bool `json:"hidden"`
+}
+
+// Product returns the product a release belongs to, taken from the
+// "{product}-v{version}" fixVersion format. Plain versions are Quay's.
+func (r ReleaseVersion) Product() string {
+	if i := strings.Index(r.Name, "-v"); i > 0 {
+		return strings.ToLower(r.Name[:i])
+	}
+	return "quay"
+}
+
+// Timeline event kinds.
+const (
+	TimelineFreeze   = "freeze"   // code freeze window leading up to the due date
+	TimelineDue      = "due"      // scheduled release date
+	TimelineSnapshot = "snapshot" // a snapshot was built
+	TimelineReleased = "released" // actual release date
+)
+
+// Timeline lays out the releases of a product on a shared time axis for
+// Gantt-style rendering.
+type Timeline struct {
+	Product string         `json:"product"`
+	Lanes   []TimelineLane `json:"lanes"`
+}
+
+// TimelineLane is one release's row in a Timeline. Start and End span all
+// of its events.
+type TimelineLane struct {
+	Release     string          `json:"release"`
+	Application string          `json:"application,omitempty"`
+	Released    bool            `json:"released"`
+	Start       *time.Time      `json:"start,omitempty"`
+	End         *time.Time      `json:"end,omitempty"`
+	Events      []TimelineEvent `json:"events"`
+}
+
+// TimelineEvent is a point in time or, when End is set, a window.
+type TimelineEvent struct {
+	Kind   string     `json:"kind"`
+	Start  time.Time  `json:"start"`
+	End    *time.Time `json:"end,omitempty"`
+	Label  string     `json:"label"`
+	Status string     `json:"status,omitempty"` // snapshots: "passed" or "failed"
+}
+
+// Calendar event kinds.
+const (
+	CalendarFreeze  = "freeze"  // code freeze window leading up to the due date
+	CalendarDue     = "due"     // due date
+	CalendarRelease = "release" // scheduled release (GA) date
+)
+
+// CalendarEvent is an all-day event of the release calendar, covering the
+// days from Start up to, but not including, End.
+type CalendarEvent struct {
+	Release     string    `json:"release"`
+	Kind        string    `json:"kind"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+}
+
+// ReleaseAudit is the post-release check that the components shipped in a
+// release's snapshot were built from the expected git tags/branches.
+type ReleaseAudit struct {
+	Release      string         `json:"release"`
+	SnapshotName string         `json:"snapshot_name,omitempty"`
+	AuditedAt    time.Time      `json:"audited_at"`
+	Findings     []AuditFinding `json:"findings"`
+}
+
+// AuditFinding is a single discrepancy found by a ReleaseAudit.
+type AuditFinding struct {
+	Component string `json:"component"`
+	Kind      string `json:"kind"` // e.g. "tag_missing", "tag_mismatch", "not_on_branch"
+	Expected  string `json:"expected,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+	Message   string `json:"message"`
+}
+
+// AuditHold exempts a release's snapshots from retention, e.g. while the
+// release is under audit.
+type AuditHold struct {
+	Release   string    `json:"release"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Errata Tool advisory states, in the order an advisory moves through
+// them.
+const (
+	AdvisoryNewFiles    = "NEW_FILES"
+	AdvisoryQE          = "QE"
+	AdvisoryRelPrep     = "REL_PREP"
+	AdvisoryPushReady   = "PUSH_READY"
+	AdvisoryInPush      = "IN_PUSH"
+	AdvisoryShippedLive = "SHIPPED_LIVE"
+	AdvisoryDropped     = "DROPPED_NO_SHIP"
+)
+
+// Advisory is the Errata Tool advisory configured for a release and the
+// state last synced from it. Name, State and the rest are empty until the
+// first sync.
+type Advisory struct {
+	Release    string     `json:"release"`
+	AdvisoryID int64      `json:"advisory_id"`
+	Name       string     `json:"name,omitempty"`     // e.g. RHSA-2026:1234-01
+	Type       string     `json:"type,omitempty"`     // RHSA, RHBA or RHEA
+	State      string     `json:"state,omitempty"`    // e.g. QE, REL_PREP, SHIPPED_LIVE
+	Synopsis   string     `json:"synopsis,omitempty"` // advisory title
+	Builds     []string   `json:"builds"`             // NVRs of the attached builds
+	CVEs       []string   `json:"cves"`
+	SyncedAt   *time.Time `json:"synced_at,omitempty"`
+	SyncError  string     `json:"sync_error,omitempty"` // why the last sync failed
+}
+
+// Ready reports whether the advisory has passed QE: it is being prepared
+// for release, pushed, or shipped.
+func (a *Advisory) Ready() bool {
+	switch a.State {
+	case AdvisoryRelPrep, AdvisoryPushReady, AdvisoryInPush, AdvisoryShippedLive:
+		return true
+	}
+	return false
+}
+
+// Retention protection reasons: why a snapshot is kept regardless of the
+// retention limits.
+const (
+	RetainUnreleased      = "unreleased"       // belongs to a release not yet shipped
+	RetainAuditHold       = "audit_hold"       // belongs to a release on audit hold
+	RetainReleased        = "released"         // the snapshot a release shipped
+	RetainRecentCandidate = "recent_candidate" // one of a released release's newest other candidates
+	RetainLifecycle       = "lifecycle"        // accepted or released in its lifecycle
+)
+
+// RetentionPlan is what a retention run deletes and keeps.
+type RetentionPlan struct {
+	Delete []RetentionDeletion `json:"delete"`
+	Kept   int                 `json:"kept"`
+	// Protected counts the kept snapshots by the Retain* reason that
+	// protects them.
+	Protected map[string]int `json:"protected,omitempty"`
+}
+
+// RetentionDeletion is a snapshot a retention run deletes, and why.
+type RetentionDeletion struct {
+	SnapshotID  int64     `json:"snapshot_id"`
+	Snapshot    string    `json:"snapshot"`
+	Application string    `json:"application"`
+	Release     string    `json:"release,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Reason      string    `json:"reason"` // "max_count" or "max_age"
+}
+
+// Search result types: what a SearchResult matched.
+const (
+	SearchIssue     = "issue"
+	SearchSnapshot  = "snapshot"
+	SearchComponent = "component"
+	SearchFailure   = "failure" // a failed test case, by its name or message
+)
+
+// SearchResult is a match of a full-text search. Type is one of the
+// Search* types and tells which of the other fields are set.
+type SearchResult struct {
+	Type string `json:"type"`
+	// Name is the issue key, or the name of the snapshot, component or
+	// failed test case.
+	Name string `json:"name"`
+	// Summary is the issue summary, the component description or the
+	// start of the failure message.
+	Summary    string `json:"summary,omitempty"`
+	FixVersion string `json:"fix_version,omitempty"` // issue
+	Status     string `json:"status,omitempty"`      // issue
+	Link       string `json:"link,omitempty"`        // issue in JIRA
+	// Snapshot is the snapshot of a failure, or the newest snapshot
+	// including a component. Application and CreatedAt are those of the
+	// matched snapshot, or else of Snapshot.
+	Snapshot    string     `json:"snapshot,omitempty"`
+	Application string     `json:"application,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	Scenario    string     `json:"scenario,omitempty"` // failure's test suite
 }
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// GoogleChat posts cards to Google Chat incoming webhooks.
+type GoogleChat struct {
+	poster poster
+}
+
+// NewGoogleChat creates a Google Chat webhook client.
+func NewGoogleChat() *GoogleChat {
+	return &GoogleChat{poster: newPoster("Google Chat", "google-chat")}
+}
+
+// Breaker returns the circuit breaker guarding calls to Google Chat.
+func (g *GoogleChat) Breaker() *breaker.Breaker {
+	return g.poster.breaker
+}
+
+// Send posts msg, as a card, to the incoming webhook at webhookURL.
+func (g *GoogleChat) Send(ctx context.Context, webhookURL string, msg Message) error {
+	return g.poster.post(ctx, webhookURL, googleChatCard(msg))
+}
+
+// Google Chat card messages; see
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/cards.
+type (
+	chatMessage struct {
+		CardsV2 []chatCardWithID `json:"cardsV2"`
+	}
+	chatCardWithID struct {
+		CardID string   `json:"cardId"`
+		Card   chatCard `json:"card"`
+	}
+	chatCard struct {
+		Header   chatHeader    `json:"header"`
+		Sections []chatSection `json:"sections"`
+	}
+	chatHeader struct {
+		Title    string `json:"title"`
+		Subtitle string `json:"subtitle,omitempty"`
+	}
+	chatSection struct {
+		Widgets []chatWidget `json:"widgets"`
+	}
+	chatWidget struct {
+		TextParagraph *chatText       `json:"textParagraph,omitempty"`
+		ButtonList    *chatButtonList `json:"buttonList,omitempty"`
+	}
+	chatText struct {
+		Text string `json:"text"`
+	}
+	chatButtonList struct {
+		Buttons []chatButton `json:"buttons"`
+	}
+	chatButton struct {
+		Text    string      `json:"text"`
+		OnClick chatOnClick `json:"onClick"`
+	}
+	chatOnClick struct {
+		OpenLink struct {
+			URL string `json:"url"`
+		} `json:"openLink"`
+	}
+)
+
+// googleChatMarkup is the HTML subset card text paragraphs support.
+var googleChatMarkup = markup{
+	escape: html.EscapeString,
+	strong: func(s string) string { return "<b>" + s + "</b>" },
+	code:   func(s string) string { return "<i>" + s + "</i>" },
+}
+
+// googleChatCard formats msg as a card headed by the release, listing the
+// events and linking to the release page.
+func googleChatCard(msg Message) chatMessage {
+	card := chatCard{Header: chatHeader{Title: msg.Release}}
+	if msg.DueDate != nil {
+		card.Header.Subtitle = "Due " + msg.DueDate.Format(time.DateOnly)
+	}
+	lines := make([]string, len(msg.Events))
+	for i, e := range msg.Events {
+		lines[i] = "• " + e.text(googleChatMarkup)
+	}
+	widgets := []chatWidget{{TextParagraph: &chatText{Text: strings.Join(lines, "<br>")}}}
+	if msg.URL != "" {
+		button := chatButton{Text: "Open release"}
+		button.OnClick.OpenLink.URL = msg.URL
+		widgets = append(widgets, chatWidget{ButtonList: &chatButtonList{Buttons: []chatButton{button}}})
+	}
+	card.Sections = []chatSection{{Widgets: widgets}}
+	return chatMessage{CardsV2: []chatCardWithID{{CardID: "readiness", Card: card}}}
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGoogleChatSend(t *testing.T) {
+	var got chatMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	msg := Message{
+		Release: "quay-v3.16.3",
+		URL:     "https://dashboard.example/releases/quay-v3.16.3",
+		DueDate: &due,
+		Events: []Event{
+			{Kind: EventSignal, From: "green", To: "red", Reason: "Open issues & CVEs"},
+			{Kind: EventCVEs, NewCVEs: 1, OpenCVEs: 3},
+		},
+	}
+	g := NewGoogleChat()
+	if err := g.Send(t.Context(), srv.URL+"/hook", msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.CardsV2) != 1 {
+		t.Fatalf("cards: got %+v", got.CardsV2)
+	}
+	card := got.CardsV2[0].Card
+	if card.Header.Title != "quay-v3.16.3" || card.Header.Subtitle != "Due 2026-03-20" {
+		t.Errorf("header: got %+v", card.Header)
+	}
+	widgets := card.Sections[0].Widgets
+	want := "• Readiness changed from green to <b>red</b>: Open issues &amp; CVEs<br>• 1 new blocking CVEs (3 open)"
+	if len(widgets) != 2 || widgets[0].TextParagraph == nil || widgets[0].TextParagraph.Text != want {
+		t.Fatalf("widgets: got %+v", widgets)
+	}
+	if b := widgets[1].ButtonList; b == nil || b.Buttons[0].OnClick.OpenLink.URL != msg.URL {
+		t.Errorf("button: got %+v", b)
+	}
+
+	if err := g.Send(t.Context(), srv.URL+"/broken", msg); err == nil {
+		t.Error("503 response: got nil error")
+	}
+	if st := g.Breaker().Status(); st.ConsecutiveFailures != 1 {
+		t.Errorf("5xx not counted as breaker failure: %+v", st)
+	}
+}
@@ -0,0 +1,315 @@
+// Package notify announces release readiness transitions to Slack, Google
+// Chat and Microsoft Teams.
+package notify
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Store is the persistence contract the Notifier depends on.
+type Store interface {
+	ListNotificationStates(ctx context.Context) (map[string]model.NotificationState, error)
+	SaveNotificationState(ctx context.Context, state model.NotificationState) error
+}
+
+// Source reports the current readiness of every release.
+// *server.Server implements it.
+type Source interface {
+	ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error)
+}
+
+// Provider names, as given in routes.
+const (
+	ProviderSlack      = "slack"
+	ProviderGoogleChat = "google-chat"
+	ProviderTeams      = "teams"
+)
+
+// Provider delivers a notification to an incoming webhook of one chat
+// service, in that service's message format. *Slack, *GoogleChat and *Teams
+// implement it.
+type Provider interface {
+	Send(ctx context.Context, webhookURL string, msg Message) error
+}
+
+// Message is a notification about one release.
+type Message struct {
+	Release string
+	// URL is the release's page on the dashboard, or "" if
+	// Config.DashboardURL is not set.
+	URL     string
+	DueDate *time.Time
+	Events  []Event
+}
+
+// Event kinds.
+const (
+	EventSignal      = "signal"
+	EventCVEs        = "cves"
+	EventTestsFailed = "tests-failed"
+)
+
+// Event is one transition worth announcing.
+type Event struct {
+	Kind string
+	// From and To are the previous and current signals of an EventSignal,
+	// and Reason the readiness message.
+	From, To, Reason string
+	// NewCVEs and OpenCVEs count the blocking CVEs of an EventCVEs.
+	NewCVEs, OpenCVEs int
+	// Snapshot is the candidate failing its tests in an EventTestsFailed.
+	Snapshot string
+}
+
+// markup is how a message format escapes text and marks it up.
+type markup struct {
+	escape func(string) string
+	strong func(string) string
+	code   func(string) string
+}
+
+// text formats e as a sentence in the markup m.
+func (e Event) text(m markup) string {
+	switch e.Kind {
+	case EventSignal:
+		return fmt.Sprintf("Readiness changed from %s to %s: %s", m.escape(e.From), m.strong(m.escape(e.To)), m.escape(e.Reason))
+	case EventCVEs:
+		return fmt.Sprintf("%d new blocking CVEs (%d open)", e.NewCVEs, e.OpenCVEs)
+	case EventTestsFailed:
+		return fmt.Sprintf("Integration tests failing on snapshot %s", m.code(m.escape(e.Snapshot)))
+	}
+	return m.escape(e.Kind)
+}
+
+// Route sends notifications for releases whose name matches the Release
+// glob (path.Match syntax) to Webhook, a webhook of Provider (Slack if
+// empty).
+type Route struct {
+	Release  string `json:"release"`
+	Webhook  string `json:"webhook"`
+	Provider string `json:"provider,omitempty"`
+}
+
+// Config controls where notifications go and how they link back.
+type Config struct {
+	// Routes are tried in order; the first match wins.
+	Routes []Route
+	// DefaultWebhook is a Slack webhook receiving notifications for releases
+	// no route matches. Those releases are not announced if it is empty.
+	DefaultWebhook string
+	// DashboardURL is the external base URL of the dashboard, used to link
+	// each release. Releases are not linked if it is empty.
+	DashboardURL string
+}
+
+// LoadRoutes reads a JSON array of routes from the file at path.
+func LoadRoutes(path string) ([]Route, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var routes []Route
+	if err := json.Unmarshal(data, &routes); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i, r := range routes {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("%s: route %d: %w", path, i+1, err)
+		}
+	}
+	return routes, nil
+}
+
+func (r Route) validate() error {
+	if r.Release == "" {
+		return errors.New("release pattern is required")
+	}
+	if _, err := path.Match(r.Release, ""); err != nil {
+		return fmt.Errorf("release pattern %q: %w", r.Release, err)
+	}
+	if r.Webhook == "" {
+		return errors.New("webhook is required")
+	}
+	switch r.Provider {
+	case "", ProviderSlack, ProviderGoogleChat, ProviderTeams:
+	default:
+		return fmt.Errorf("unknown provider %q (want %s, %s or %s)", r.Provider, ProviderSlack, ProviderGoogleChat, ProviderTeams)
+	}
+	return nil
+}
+
+// route returns where notifications for release go. Its Webhook is "" if
+// the release has none.
+func (c Config) route(release string) Route {
+	r := Route{Release: release, Webhook: c.DefaultWebhook}
+	for _, cr := range c.Routes {
+		if ok, _ := path.Match(cr.Release, release); ok {
+			r = cr
+			break
+		}
+	}
+	if r.Provider == "" {
+		r.Provider = ProviderSlack
+	}
+	return r
+}
+
+// NewProviders creates a client for each provider cfg sends to, and returns
+// them with the circuit breakers guarding them.
+func NewProviders(cfg Config) (map[string]Provider, []*breaker.Breaker) {
+	providers := make(map[string]Provider)
+	var breakers []*breaker.Breaker
+	add := func(name string) {
+		if _, ok := providers[name]; ok {
+			return
+		}
+		var b *breaker.Breaker
+		switch name {
+		case ProviderSlack:
+			p := NewSlack()
+			providers[name], b = p, p.Breaker()
+		case ProviderGoogleChat:
+			p := NewGoogleChat()
+			providers[name], b = p, p.Breaker()
+		case ProviderTeams:
+			p := NewTeams()
+			providers[name], b = p, p.Breaker()
+		default:
+			return
+		}
+		breakers = append(breakers, b)
+	}
+	if cfg.DefaultWebhook != "" {
+		add(ProviderSlack)
+	}
+	for _, r := range cfg.Routes {
+		add(cmp.Or(r.Provider, ProviderSlack))
+	}
+	return providers, breakers
+}
+
+// Notifier periodically compares the readiness of each active release with
+// what it last saw and announces:
+//   - any change of the readiness signal;
+//   - an increase in open CVEs that block readiness;
+//   - integration test failures on a newly selected candidate snapshot.
+//
+// The first time a release is seen its state is recorded without notifying,
+// so enabling notifications does not announce every release at once.
+type Notifier struct {
+	store     Store
+	source    Source
+	providers map[string]Provider
+	cfg       Config
+	logger    *slog.Logger
+}
+
+// NewNotifier creates a Notifier sending through providers, keyed by
+// provider name.
+func NewNotifier(store Store, source Source, providers map[string]Provider, cfg Config, logger *slog.Logger) *Notifier {
+	return &Notifier{store: store, source: source, providers: providers, cfg: cfg, logger: logger}
+}
+
+// NotifyOnce announces the transitions since the last check. A release's
+// state is only saved once its notification was delivered, so failed
+// deliveries are retried on the next cycle. While a provider's breaker is
+// open, the releases routed to it are skipped.
+func (n *Notifier) NotifyOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	overviews, err := n.source.ReleasesOverview(ctx)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "compute readiness", "error", err)
+		return
+	}
+	states, err := n.store.ListNotificationStates(ctx)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "list notification states", "error", err)
+		return
+	}
+
+	unavailable := make(map[string]bool)
+	for _, o := range overviews {
+		if o.Release.Released || o.Release.Archived {
+			continue
+		}
+		prev, seen := states[o.Release.Name]
+		cur := model.NotificationState{
+			Release:      o.Release.Name,
+			Signal:       o.Readiness.Signal,
+			BlockingCVEs: o.Readiness.BlockingCVEs,
+		}
+		if snap := o.Snapshot; snap != nil && snap.HasTests && !snap.TestsPassed {
+			cur.FailedSnapshot = snap.Name
+		}
+		if cur == prev {
+			continue
+		}
+
+		if events := transitions(prev, cur, o); seen && len(events) > 0 {
+			route := n.cfg.route(o.Release.Name)
+			provider := n.providers[route.Provider]
+			switch {
+			case route.Webhook == "":
+				n.logger.DebugContext(ctx, "no webhook for release, not notifying", "release", o.Release.Name)
+			case provider == nil:
+				n.logger.ErrorContext(ctx, "no client for provider, not notifying", "release", o.Release.Name, "provider", route.Provider)
+				continue
+			case unavailable[route.Provider]:
+				continue
+			default:
+				err := provider.Send(ctx, route.Webhook, n.message(o, events))
+				if errors.Is(err, breaker.ErrOpen) {
+					n.logger.WarnContext(ctx, "provider unavailable, skipping its notifications", "provider", route.Provider, "error", err)
+					unavailable[route.Provider] = true
+					continue
+				}
+				if err != nil {
+					n.logger.ErrorContext(ctx, "send notification", "release", o.Release.Name, "provider", route.Provider, "error", err)
+					continue
+				}
+				n.logger.InfoContext(ctx, "sent notification", "release", o.Release.Name, "provider", route.Provider, "events", len(events))
+			}
+		}
+		if err := n.store.SaveNotificationState(ctx, cur); err != nil {
+			n.logger.ErrorContext(ctx, "save notification state", "release", o.Release.Name, "error", err)
+		}
+	}
+}
+
+// transitions describes what changed between prev and cur worth announcing.
+func transitions(prev, cur model.NotificationState, o model.ReleaseOverview) []Event {
+	var events []Event
+	if cur.Signal != prev.Signal {
+		events = append(events, Event{Kind: EventSignal, From: prev.Signal, To: cur.Signal, Reason: o.Readiness.Message})
+	}
+	if n := cur.BlockingCVEs - prev.BlockingCVEs; n > 0 {
+		events = append(events, Event{Kind: EventCVEs, NewCVEs: n, OpenCVEs: cur.BlockingCVEs})
+	}
+	if cur.FailedSnapshot != "" && cur.FailedSnapshot != prev.FailedSnapshot {
+		events = append(events, Event{Kind: EventTestsFailed, Snapshot: cur.FailedSnapshot})
+	}
+	return events
+}
+
+// message builds the notification of events for a release.
+func (n *Notifier) message(o model.ReleaseOverview, events []Event) Message {
+	msg := Message{Release: o.Release.Name, DueDate: o.Release.DueDate, Events: events}
+	if n.cfg.DashboardURL != "" {
+		msg.URL = strings.TrimSuffix(n.cfg.DashboardURL, "/") + "/releases/" + url.PathEscape(o.Release.Name)
+	}
+	return msg
+}
@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakeSource []model.ReleaseOverview
+
+func (f *fakeSource) ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	return *f, nil
+}
+
+type sent struct {
+	provider, webhook string
+	msg               Message
+}
+
+type fakeProvider struct {
+	name string
+	sent *[]sent
+}
+
+func (f fakeProvider) Send(ctx context.Context, webhookURL string, msg Message) error {
+	*f.sent = append(*f.sent, sent{f.name, webhookURL, msg})
+	return nil
+}
+
+func TestNotifyOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	overview := func(name, signal string, cves int, snap *model.SnapshotRecord) model.ReleaseOverview {
+		return model.ReleaseOverview{
+			Release:   model.ReleaseVersion{Name: name, DueDate: &due},
+			Readiness: model.ReadinessResponse{Signal: signal, Message: "Open issues & CVEs", BlockingCVEs: cves},
+			Snapshot:  snap,
+		}
+	}
+	failing := &model.SnapshotRecord{Name: "quay-v3-16-snap-2", HasTests: true}
+
+	source := &fakeSource{
+		overview("quay-v3.16.3", "yellow", 0, nil),
+		overview("omr-v2.1.0", "green", 0, nil),
+		overview("quay-v3.15.9", "green", 0, nil),
+	}
+	sender := &[]sent{}
+	providers := map[string]Provider{
+		ProviderSlack: fakeProvider{ProviderSlack, sender},
+		ProviderTeams: fakeProvider{ProviderTeams, sender},
+	}
+	n := NewNotifier(database, source, providers, Config{
+		Routes:       []Route{{Release: "omr-*", Webhook: "https://hooks.example/omr", Provider: ProviderTeams}},
+		DashboardURL: "https://dashboard.example/",
+	}, slog.Default())
+
+	// First sight records a baseline without notifying.
+	n.NotifyOnce(ctx)
+	if len(*sender) != 0 {
+		t.Fatalf("baseline: sent %+v, want nothing", *sender)
+	}
+
+	*source = fakeSource{
+		overview("quay-v3.16.3", "red", 2, failing),
+		overview("omr-v2.1.0", "red", 0, nil),
+		overview("quay-v3.15.9", "green", 0, nil),
+	}
+	(*source)[2].Release.Released = true
+	n.NotifyOnce(ctx)
+
+	// quay-v3.16.3 matches no route and there is no default webhook.
+	if len(*sender) != 1 {
+		t.Fatalf("sent %+v, want one message", *sender)
+	}
+	got := (*sender)[0]
+	if got.provider != ProviderTeams || got.webhook != "https://hooks.example/omr" {
+		t.Errorf("route: got %s %q", got.provider, got.webhook)
+	}
+	if got.msg.URL != "https://dashboard.example/releases/omr-v2.1.0" || len(got.msg.Events) != 1 {
+		t.Errorf("message: got %+v", got.msg)
+	}
+	want := "*<https://dashboard.example/releases/omr-v2.1.0|omr-v2.1.0>* (due 2026-03-20)\n• Readiness changed from green to *red*: Open issues &amp; CVEs"
+	if text := slackText(got.msg); text != want {
+		t.Errorf("text:\ngot  %q\nwant %q", text, want)
+	}
+
+	// Unrouted releases still have their state recorded.
+	states, err := database.ListNotificationStates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := states["quay-v3.16.3"]; s.Signal != "red" || s.BlockingCVEs != 2 || s.FailedSnapshot != failing.Name {
+		t.Errorf("quay-v3.16.3 state: got %+v", s)
+	}
+
+	*sender = nil
+	n.cfg.DefaultWebhook = "https://hooks.example/default"
+	*source = fakeSource{overview("quay-v3.16.3", "red", 3, failing)}
+	n.NotifyOnce(ctx)
+	if len(*sender) != 1 || (*sender)[0].provider != ProviderSlack || !strings.HasSuffix(slackText((*sender)[0].msg), "\n• 1 new blocking CVEs (3 open)") {
+		t.Errorf("new CVE: sent %+v", *sender)
+	}
+
+	*sender = nil
+	n.NotifyOnce(ctx)
+	if len(*sender) != 0 {
+		t.Errorf("unchanged: sent %+v, want nothing", *sender)
+	}
+}
+
+func TestLoadRoutes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		p := filepath.Join(dir, "routes.json")
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	routes, err := LoadRoutes(write(`[
+		{"release":"quay-v3.16.*","webhook":"https://hooks.example/a"},
+		{"release":"omr-*","webhook":"https://chat.example/b","provider":"google-chat"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Routes: routes, DefaultWebhook: "https://hooks.example/default"}
+	if got := cfg.route("quay-v3.16.3"); got.Webhook != "https://hooks.example/a" || got.Provider != ProviderSlack {
+		t.Errorf("routed webhook: got %+v", got)
+	}
+	if got := cfg.route("omr-v2.1.0"); got.Webhook != "https://chat.example/b" || got.Provider != ProviderGoogleChat {
+		t.Errorf("google chat route: got %+v", got)
+	}
+	if got := cfg.route("quay-v3.17.0"); got.Webhook != "https://hooks.example/default" || got.Provider != ProviderSlack {
+		t.Errorf("default webhook: got %+v", got)
+	}
+	providers, breakers := NewProviders(cfg)
+	if len(providers) != 2 || len(breakers) != 2 || providers[ProviderTeams] != nil {
+		t.Errorf("providers: got %v", providers)
+	}
+
+	for _, bad := range []string{
+		`{}`,
+		`[{"release":"[","webhook":"https://hooks.example/a"}]`,
+		`[{"release":"quay-*"}]`,
+		`[{"release":"quay-*","webhook":"https://hooks.example/a","provider":"irc"}]`,
+	} {
+		if _, err := LoadRoutes(write(bad)); err == nil {
+			t.Errorf("LoadRoutes(%s): got nil error", bad)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// Slack posts messages to Slack incoming webhooks.
+type Slack struct {
+	poster poster
+}
+
+// NewSlack creates a Slack webhook client.
+func NewSlack() *Slack {
+	return &Slack{poster: newPoster("Slack", "slack")}
+}
+
+// Breaker returns the circuit breaker guarding calls to Slack.
+func (s *Slack) Breaker() *breaker.Breaker {
+	return s.poster.breaker
+}
+
+// Send posts msg, as Slack mrkdwn text, to the incoming webhook at
+// webhookURL.
+func (s *Slack) Send(ctx context.Context, webhookURL string, msg Message) error {
+	return s.poster.post(ctx, webhookURL, struct {
+		Text string `json:"text"`
+	}{slackText(msg)})
+}
+
+var slackMarkup = markup{
+	escape: escape,
+	strong: func(s string) string { return "*" + s + "*" },
+	code:   func(s string) string { return "`" + s + "`" },
+}
+
+// slackText formats msg as Slack mrkdwn.
+func slackText(msg Message) string {
+	var b strings.Builder
+	name := escape(msg.Release)
+	if msg.URL != "" {
+		fmt.Fprintf(&b, "*<%s|%s>*", msg.URL, name)
+	} else {
+		fmt.Fprintf(&b, "*%s*", name)
+	}
+	if msg.DueDate != nil {
+		fmt.Fprintf(&b, " (due %s)", msg.DueDate.Format(time.DateOnly))
+	}
+	for _, e := range msg.Events {
+		b.WriteString("\n• " + e.text(slackMarkup))
+	}
+	return b.String()
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escape escapes the characters that Slack treats as control sequences in
+// message text.
+func escape(s string) string {
+	return slackEscaper.Replace(s)
+}
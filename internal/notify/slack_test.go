@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSlackSend(t *testing.T) {
+	var got struct {
+		Text string `json:"text"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			http.Error(w, "no_service", http.StatusNotFound)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("content type: got %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	s := NewSlack()
+	msg := Message{Release: "quay-v3.16.3", Events: []Event{{Kind: EventTestsFailed, Snapshot: "quay-v3-16-snap-2"}}}
+	if err := s.Send(t.Context(), srv.URL+"/hook", msg); err != nil {
+		t.Fatal(err)
+	}
+	if want := "*quay-v3.16.3*\n• Integration tests failing on snapshot `quay-v3-16-snap-2`"; got.Text != want {
+		t.Errorf("text: got %q, want %q", got.Text, want)
+	}
+	if err := s.Send(t.Context(), srv.URL+"/broken", msg); err == nil {
+		t.Error("404 response: got nil error")
+	}
+	if st := s.Breaker().Status(); st.ConsecutiveFailures != 0 {
+		t.Errorf("4xx counted as breaker failure: %+v", st)
+	}
+}
@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// Teams posts Adaptive Cards to Microsoft Teams incoming webhooks, either
+// Workflows webhooks or legacy Office 365 connectors.
+type Teams struct {
+	poster poster
+}
+
+// NewTeams creates a Microsoft Teams webhook client.
+func NewTeams() *Teams {
+	return &Teams{poster: newPoster("Teams", "teams")}
+}
+
+// Breaker returns the circuit breaker guarding calls to Teams.
+func (t *Teams) Breaker() *breaker.Breaker {
+	return t.poster.breaker
+}
+
+// Send posts msg, as an Adaptive Card, to the incoming webhook at
+// webhookURL.
+func (t *Teams) Send(ctx context.Context, webhookURL string, msg Message) error {
+	return t.poster.post(ctx, webhookURL, teamsCard(msg))
+}
+
+// Teams messages carrying an Adaptive Card; see
+// https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using.
+type (
+	teamsMessage struct {
+		Type        string            `json:"type"`
+		Attachments []teamsAttachment `json:"attachments"`
+	}
+	teamsAttachment struct {
+		ContentType string       `json:"contentType"`
+		Content     adaptiveCard `json:"content"`
+	}
+	adaptiveCard struct {
+		Schema  string           `json:"$schema"`
+		Type    string           `json:"type"`
+		Version string           `json:"version"`
+		Body    []adaptiveBlock  `json:"body"`
+		Actions []adaptiveAction `json:"actions,omitempty"`
+	}
+	adaptiveBlock struct {
+		Type     string `json:"type"`
+		Text     string `json:"text"`
+		Weight   string `json:"weight,omitempty"`
+		Size     string `json:"size,omitempty"`
+		IsSubtle bool   `json:"isSubtle,omitempty"`
+		Wrap     bool   `json:"wrap,omitempty"`
+	}
+	adaptiveAction struct {
+		Type  string `json:"type"`
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	}
+)
+
+// teamsMarkup is the Markdown subset Adaptive Card text blocks support,
+// which has no code spans.
+var teamsMarkup = markup{
+	escape: teamsEscaper.Replace,
+	strong: func(s string) string { return "**" + s + "**" },
+	code:   func(s string) string { return s },
+}
+
+var teamsEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`)
+
+// teamsCard formats msg as an Adaptive Card headed by the release, listing
+// the events and linking to the release page.
+func teamsCard(msg Message) teamsMessage {
+	body := []adaptiveBlock{{Type: "TextBlock", Text: teamsEscaper.Replace(msg.Release), Weight: "Bolder", Size: "Medium", Wrap: true}}
+	if msg.DueDate != nil {
+		body = append(body, adaptiveBlock{Type: "TextBlock", Text: "Due " + msg.DueDate.Format(time.DateOnly), IsSubtle: true})
+	}
+	for _, e := range msg.Events {
+		body = append(body, adaptiveBlock{Type: "TextBlock", Text: "- " + e.text(teamsMarkup), Wrap: true})
+	}
+	card := adaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    body,
+	}
+	if msg.URL != "" {
+		card.Actions = []adaptiveAction{{Type: "Action.OpenUrl", Title: "Open release", URL: msg.URL}}
+	}
+	return teamsMessage{
+		Type:        "message",
+		Attachments: []teamsAttachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: card}},
+	}
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTeamsSend(t *testing.T) {
+	var got teamsMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		// Workflows webhooks accept messages asynchronously.
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	msg := Message{
+		Release: "quay-v3.16.3",
+		URL:     "https://dashboard.example/releases/quay-v3.16.3",
+		Events: []Event{
+			{Kind: EventSignal, From: "yellow", To: "red", Reason: "2 open *blockers*"},
+			{Kind: EventTestsFailed, Snapshot: "quay_v3_16_snap"},
+		},
+	}
+	if err := NewTeams().Send(t.Context(), srv.URL, msg); err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "message" || len(got.Attachments) != 1 || got.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Fatalf("message: got %+v", got)
+	}
+	card := got.Attachments[0].Content
+	var texts []string
+	for _, b := range card.Body {
+		texts = append(texts, b.Text)
+	}
+	want := []string{
+		"quay-v3.16.3",
+		`- Readiness changed from yellow to **red**: 2 open \*blockers\*`,
+		`- Integration tests failing on snapshot quay\_v3\_16\_snap`,
+	}
+	if len(texts) != len(want) {
+		t.Fatalf("body: got %q", texts)
+	}
+	for i := range want {
+		if texts[i] != want[i] {
+			t.Errorf("block %d: got %q, want %q", i, texts[i], want[i])
+		}
+	}
+	if len(card.Actions) != 1 || card.Actions[0].URL != msg.URL {
+		t.Errorf("actions: got %+v", card.Actions)
+	}
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// poster posts JSON payloads to the incoming webhooks of one chat service.
+type poster struct {
+	service    string
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// newPoster creates a poster whose breaker is named name. Only server
+// errors and rate limiting count as breaker failures; a 4xx response means
+// the webhook or payload is at fault, not the service.
+func newPoster(service, name string) poster {
+	p := poster{
+		service:    service,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New(name, breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	p.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500 || se.statusCode == http.StatusTooManyRequests
+		}
+		return err != nil
+	})
+	return p
+}
+
+// post sends payload as JSON to webhookURL. Any 2xx response is success.
+func (p poster) post(ctx context.Context, webhookURL string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return p.breaker.Do(func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+			return &statusError{service: p.service, statusCode: resp.StatusCode, body: string(body)}
+		}
+		return nil
+	})
+}
+
+type statusError struct {
+	service    string
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s webhook returned %d: %s", e.service, e.statusCode, e.body)
+}
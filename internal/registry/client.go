@@ -0,0 +1,318 @@
+// Package registry verifies that snapshot component images still resolve in
+// their container registry.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// ErrNotFound is returned when a manifest (by digest or tag) does not exist.
+var ErrNotFound = errors.New("manifest not found")
+
+// manifestTypes are the media types accepted when resolving a manifest.
+// Listing the index types first makes registries return the digest of the
+// multi-arch index, which is what Konflux records for each component.
+var manifestTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Reference is a parsed image reference such as
+// quay.io/org/repo:tag@sha256:abc.
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// ParseReference parses an image reference. The registry host is required;
+// Docker Hub short names are not supported.
+func ParseReference(image string) (Reference, error) {
+	s := strings.TrimSpace(image)
+	s = strings.TrimPrefix(s, "docker://")
+	var ref Reference
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		ref.Digest = s[i+1:]
+		s = s[:i]
+		if !strings.Contains(ref.Digest, ":") {
+			return Reference{}, fmt.Errorf("invalid digest in %q", image)
+		}
+	}
+	host, path, ok := strings.Cut(s, "/")
+	if !ok || path == "" || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return Reference{}, fmt.Errorf("%q has no registry host", image)
+	}
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		ref.Tag = path[i+1:]
+		path = path[:i]
+	}
+	ref.Registry = host
+	ref.Repository = path
+	return ref, nil
+}
+
+// Config holds optional registry credentials. They are used for every
+// registry the images point to.
+type Config struct {
+	Username string
+	Password string
+}
+
+// Client resolves manifests using the OCI distribution API.
+type Client struct {
+	cfg        Config
+	scheme     string
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// New creates a registry client.
+func New(cfg Config) *Client {
+	c := &Client{
+		cfg:        cfg,
+		scheme:     "https",
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New("registry", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500
+		}
+		return err != nil && !errors.Is(err, ErrNotFound)
+	})
+	return c
+}
+
+// Breaker returns the circuit breaker guarding calls to registries.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+// Resolve returns the digest of the manifest that reference (a tag or
+// digest) points to in the repository of ref.
+func (c *Client) Resolve(ctx context.Context, ref Reference, reference string) (string, error) {
+	var digest string
+	err := c.breaker.Do(func() error {
+		u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", c.scheme, ref.Registry, ref.Repository, reference)
+		resp, err := c.authorized(ctx, http.MethodHead, u)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusOK:
+			digest = resp.Header.Get("Docker-Content-Digest")
+			if digest == "" && strings.Contains(reference, ":") {
+				// Pulling by digest is content-addressed; a 200 is a match.
+				digest = reference
+			}
+			return nil
+		case http.StatusNotFound:
+			return fmt.Errorf("%s: %w", ref.Registry+"/"+ref.Repository+"@"+reference, ErrNotFound)
+		default:
+			return &statusError{statusCode: resp.StatusCode}
+		}
+	})
+	return digest, err
+}
+
+// maxTagPages caps how many pages of a repository's tag list Tags reads.
+const maxTagPages = 10
+
+// Tags lists the tags of the repository of ref, following the registry's
+// pagination up to maxTagPages pages.
+func (c *Client) Tags(ctx context.Context, ref Reference) ([]string, error) {
+	var tags []string
+	u := fmt.Sprintf("%s://%s/v2/%s/tags/list?n=1000", c.scheme, ref.Registry, ref.Repository)
+	for page := 0; u != "" && page < maxTagPages; page++ {
+		err := c.breaker.Do(func() error {
+			resp, err := c.authorized(ctx, http.MethodGet, u)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = resp.Body.Close() }()
+			switch resp.StatusCode {
+			case http.StatusOK:
+			case http.StatusNotFound:
+				return fmt.Errorf("%s: %w", ref.Registry+"/"+ref.Repository, ErrNotFound)
+			default:
+				return &statusError{statusCode: resp.StatusCode}
+			}
+			var body struct {
+				Tags []string `json:"tags"`
+			}
+			if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&body); err != nil {
+				return fmt.Errorf("decode tag list: %w", err)
+			}
+			tags = append(tags, body.Tags...)
+			u = nextPage(resp, u)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// nextPage returns the URL of the next page named by the rel="next" Link
+// header of resp, resolved against the URL u it was fetched from, or "" on
+// the last page.
+func nextPage(resp *http.Response, u string) string {
+	for _, link := range resp.Header.Values("Link") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		base, err := url.Parse(u)
+		if err != nil {
+			return ""
+		}
+		next, err := base.Parse(target)
+		if err != nil {
+			return ""
+		}
+		return next.String()
+	}
+	return ""
+}
+
+// authorized sends a request, answering a Bearer challenge once if the
+// registry asks for one. The caller closes the response body.
+func (c *Client) authorized(ctx context.Context, method, u string) (*http.Response, error) {
+	resp, err := c.send(ctx, method, u, "")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+	token, err := c.token(ctx, resp.Header.Get("WWW-Authenticate"))
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, method, u, token)
+}
+
+func (c *Client) send(ctx context.Context, method, u, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodHead {
+		req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	}
+	switch {
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.cfg.Username != "":
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	return c.httpClient.Do(req)
+}
+
+// token answers a Bearer challenge by fetching a token from the realm it
+// names, authenticating with the configured credentials if any.
+func (c *Client) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", &statusError{statusCode: http.StatusUnauthorized}
+	}
+	p := parseChallenge(params)
+	if p["realm"] == "" {
+		return "", fmt.Errorf("auth challenge without realm: %q", challenge)
+	}
+	q := url.Values{}
+	for _, k := range []string{"service", "scope"} {
+		if v := p[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	u := p["realm"]
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{statusCode: resp.StatusCode}
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of a
+// WWW-Authenticate header.
+func parseChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		s = strings.TrimLeft(s, ", ")
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		var val string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+			val, s = rest[1:end+1], rest[end+2:]
+		} else {
+			val, s, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = val
+	}
+	return params
+}
+
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("registry returned %d", e.statusCode)
+}
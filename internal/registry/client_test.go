@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  Reference
+	}{
+		{"quay.io/org/repo@sha256:abc", Reference{"quay.io", "org/repo", "", "sha256:abc"}},
+		{"quay.io/org/team/repo:v1@sha256:abc", Reference{"quay.io", "org/team/repo", "v1", "sha256:abc"}},
+		{"localhost:5000/repo:latest", Reference{"localhost:5000", "repo", "latest", ""}},
+		{"docker://registry.example.com/repo@sha256:abc", Reference{"registry.example.com", "repo", "", "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseReference(tt.image)
+		if err != nil {
+			t.Errorf("ParseReference(%q): %v", tt.image, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseReference(%q) = %+v, want %+v", tt.image, got, tt.want)
+		}
+		if !strings.HasSuffix(tt.image, got.String()) {
+			t.Errorf("String() = %q, not a suffix of %q", got.String(), tt.image)
+		}
+	}
+	for _, image := range []string{"", "ubuntu:22.04", "quay.io/org/repo@abc"} {
+		if _, err := ParseReference(image); err == nil {
+			t.Errorf("ParseReference(%q): expected error", image)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /token", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("scope"); got != "repository:org/repo:pull" {
+			t.Errorf("scope: got %q", got)
+		}
+		if user, pass, _ := r.BasicAuth(); user != "robot" || pass != "secret" {
+			t.Errorf("token basic auth: got %q/%q", user, pass)
+		}
+		_, _ = w.Write([]byte(`{"token":"tok"}`))
+	})
+	mux.HandleFunc("HEAD /v2/org/repo/manifests/{ref}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test",scope="repository:org/repo:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.PathValue("ref") {
+		case "sha256:abc", "v1":
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := New(Config{Username: "robot", Password: "secret"})
+	c.scheme = "http"
+	ref := Reference{Registry: strings.TrimPrefix(srv.URL, "http://"), Repository: "org/repo"}
+	ctx := t.Context()
+
+	for _, reference := range []string{"sha256:abc", "v1"} {
+		digest, err := c.Resolve(ctx, ref, reference)
+		if err != nil || digest != "sha256:abc" {
+			t.Errorf("Resolve(%s): got %q, %v; want sha256:abc", reference, digest, err)
+		}
+	}
+	if _, err := c.Resolve(ctx, ref, "sha256:gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve missing digest: got %v, want ErrNotFound", err)
+	}
+	if st := c.Breaker().Status(); st.State != "closed" {
+		t.Errorf("not-found responses should not trip the breaker: %+v", st)
+	}
+}
+
+func TestTags(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/org/repo/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/org/repo/tags/list?n=1000&last=b>; rel="next"`)
+			_, _ = w.Write([]byte(`{"name":"org/repo","tags":["a","b"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"org/repo","tags":["c"]}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := New(Config{})
+	c.scheme = "http"
+	host := strings.TrimPrefix(srv.URL, "http://")
+	tags, err := c.Tags(t.Context(), Reference{Registry: host, Repository: "org/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tags, ",") != "a,b,c" {
+		t.Errorf("tags: got %q, want a,b,c", tags)
+	}
+	if _, err := c.Tags(t.Context(), Reference{Registry: host, Repository: "org/gone"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown repository: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	got := parseChallenge(`realm="https://quay.io/v2/auth",service="quay.io",scope="repository:a/b:pull,push"`)
+	want := map[string]string{
+		"realm":   "https://quay.io/v2/auth",
+		"service": "quay.io",
+		"scope":   "repository:a/b:pull,push",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %q, want %q", k, got[k], v)
+		}
+	}
+}
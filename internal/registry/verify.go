@@ -0,0 +1,226 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Verification statuses.
+const (
+	StatusOK       = "ok"
+	StatusMissing  = "missing"  // the digest no longer resolves
+	StatusMismatch = "mismatch" // the tag was removed or moved to another digest
+	StatusError    = "error"    // the image could not be checked
+)
+
+// Store is the persistence contract the Verifier depends on.
+type Store interface {
+	ListSelectedCandidates(ctx context.Context) (map[string]*model.SnapshotRecord, error)
+	ListSnapshotComponents(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error)
+	SaveImageVerifications(ctx context.Context, snapshotID int64, results []model.ImageVerification) error
+}
+
+// Resolver resolves a tag or digest to a manifest digest and lists a
+// repository's tags. *Client implements it.
+type Resolver interface {
+	Resolve(ctx context.Context, ref Reference, reference string) (string, error)
+	Tags(ctx context.Context, ref Reference) ([]string, error)
+}
+
+// maxTagCandidates caps how many tags are resolved when looking for the
+// tag a digest-only image was published under.
+const maxTagCandidates = 5
+
+// Verifier periodically checks the component images of each release's
+// selected candidate snapshot against their registries.
+type Verifier struct {
+	store    Store
+	resolver Resolver
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewVerifier creates a Verifier.
+func NewVerifier(store Store, resolver Resolver, logger *slog.Logger) *Verifier {
+	return &Verifier{store: store, resolver: resolver, logger: logger, now: time.Now}
+}
+
+// VerifyOnce re-verifies the selected candidate of every release. Images
+// are checked on every cycle because they can be garbage-collected or
+// retagged at any time.
+func (v *Verifier) VerifyOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	selected, err := v.store.ListSelectedCandidates(ctx)
+	if err != nil {
+		v.logger.ErrorContext(ctx, "list selected candidates", "error", err)
+		return
+	}
+	verified := make(map[int64]bool)
+	for _, snap := range selected {
+		// Releases of the same application usually share a candidate.
+		if verified[snap.ID] {
+			continue
+		}
+		verified[snap.ID] = true
+		results, err := v.Verify(ctx, snap.ID)
+		if errors.Is(err, breaker.ErrOpen) {
+			v.logger.WarnContext(ctx, "registry unavailable, skipping verification", "error", err)
+			return
+		}
+		if err != nil {
+			v.logger.ErrorContext(ctx, "verify snapshot", "snapshot", snap.Name, "error", err)
+			continue
+		}
+		if err := v.store.SaveImageVerifications(ctx, snap.ID, results); err != nil {
+			v.logger.ErrorContext(ctx, "save image verifications", "snapshot", snap.Name, "error", err)
+			continue
+		}
+		failed, missing := 0, 0
+		for _, r := range results {
+			switch r.Status {
+			case StatusMissing:
+				missing++
+				failed++
+			case StatusMismatch:
+				failed++
+			}
+		}
+		if failed > 0 {
+			// Selected candidates are not yet shipped, so a missing digest
+			// was garbage-collected before release.
+			v.logger.WarnContext(ctx, "snapshot images failed verification", "snapshot", snap.Name, "failed", failed, "garbage_collected", missing)
+		} else {
+			v.logger.DebugContext(ctx, "snapshot images verified", "snapshot", snap.Name, "components", len(results))
+		}
+	}
+}
+
+// Verify checks every component image of a snapshot. It returns
+// breaker.ErrOpen if the registry breaker opens partway through, since the
+// remaining results would all be errors.
+func (v *Verifier) Verify(ctx context.Context, snapshotID int64) ([]model.ImageVerification, error) {
+	components, err := v.store.ListSnapshotComponents(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.ImageVerification, 0, len(components))
+	for _, c := range components {
+		status, msg, err := v.check(ctx, c.ImageURL)
+		if errors.Is(err, breaker.ErrOpen) {
+			return nil, err
+		}
+		var tag string
+		if status == StatusOK {
+			if tag, err = v.publishedTag(ctx, c); errors.Is(err, breaker.ErrOpen) {
+				return nil, err
+			}
+		}
+		results = append(results, model.ImageVerification{
+			Component: c.Component,
+			ImageURL:  c.ImageURL,
+			Tag:       tag,
+			Status:    status,
+			Message:   msg,
+			CheckedAt: v.now(),
+		})
+	}
+	return results, nil
+}
+
+// check verifies one image reference. The returned error is only non-nil
+// when the breaker is open; other failures are reported as StatusError.
+func (v *Verifier) check(ctx context.Context, image string) (status, msg string, err error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return StatusError, err.Error(), nil
+	}
+	if ref.Digest == "" {
+		return StatusError, "image reference has no digest", nil
+	}
+
+	digest, err := v.resolver.Resolve(ctx, ref, ref.Digest)
+	switch {
+	case errors.Is(err, breaker.ErrOpen):
+		return "", "", err
+	case errors.Is(err, ErrNotFound):
+		return StatusMissing, "digest no longer resolves in the registry", nil
+	case err != nil:
+		return StatusError, err.Error(), nil
+	case digest != ref.Digest:
+		return StatusMismatch, fmt.Sprintf("registry returned digest %s", digest), nil
+	}
+
+	if ref.Tag == "" {
+		return StatusOK, "", nil
+	}
+	digest, err = v.resolver.Resolve(ctx, ref, ref.Tag)
+	switch {
+	case errors.Is(err, breaker.ErrOpen):
+		return "", "", err
+	case errors.Is(err, ErrNotFound):
+		return StatusMismatch, fmt.Sprintf("tag %s no longer exists", ref.Tag), nil
+	case err != nil:
+		return StatusError, err.Error(), nil
+	case digest != ref.Digest:
+		return StatusMismatch, fmt.Sprintf("tag %s now points to %s", ref.Tag, digest), nil
+	}
+	return StatusOK, "", nil
+}
+
+// publishedTag returns the tag a verified component image was published
+// under. A tagged reference names it; for a digest-only reference the
+// repository's tags containing the component's git revision, which is how
+// Konflux tags its builds, are resolved until one points to the digest.
+// It returns "" if no tag is found; only breaker.ErrOpen is returned as an
+// error.
+func (v *Verifier) publishedTag(ctx context.Context, c model.ComponentRecord) (string, error) {
+	ref, err := ParseReference(c.ImageURL)
+	if err != nil {
+		return "", nil
+	}
+	if ref.Tag != "" {
+		return ref.Tag, nil
+	}
+	if c.GitSHA == "" {
+		return "", nil
+	}
+	tags, err := v.resolver.Tags(ctx, ref)
+	if err != nil {
+		if errors.Is(err, breaker.ErrOpen) {
+			return "", err
+		}
+		v.logger.DebugContext(ctx, "list tags", "image", c.ImageURL, "error", err)
+		return "", nil
+	}
+	// Prefer the bare revision over tags that merely contain it.
+	var candidates []string
+	for _, t := range tags {
+		switch {
+		case t == c.GitSHA:
+			candidates = append([]string{t}, candidates...)
+		case strings.Contains(t, c.GitSHA):
+			candidates = append(candidates, t)
+		}
+	}
+	for i, t := range candidates {
+		if i == maxTagCandidates {
+			break
+		}
+		digest, err := v.resolver.Resolve(ctx, ref, t)
+		if errors.Is(err, breaker.ErrOpen) {
+			return "", err
+		}
+		if err == nil && digest == ref.Digest {
+			return t, nil
+		}
+	}
+	return "", nil
+}
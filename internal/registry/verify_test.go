@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// fakeResolver maps "repository@reference" to a digest.
+type fakeResolver map[string]string
+
+func (f fakeResolver) Resolve(ctx context.Context, ref Reference, reference string) (string, error) {
+	digest, ok := f[ref.Repository+"@"+reference]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", reference, ErrNotFound)
+	}
+	return digest, nil
+}
+
+// Tags lists the references of ref's repository that are not digests.
+func (f fakeResolver) Tags(ctx context.Context, ref Reference) ([]string, error) {
+	var tags []string
+	for k := range f {
+		repo, reference, _ := strings.Cut(k, "@")
+		if repo == ref.Repository && !strings.Contains(reference, ":") {
+			tags = append(tags, reference)
+		}
+	}
+	slices.Sort(tags)
+	return tags, nil
+}
+
+func TestVerifyOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.2", S3Application: "quay-v3-16"}); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct{ name, sha, image string }{
+		{"quay", "1a2b3c", "quay.io/org/quay@sha256:aaa"},
+		{"clair", "", "quay.io/org/clair@sha256:bbb"},
+		{"builder", "", "quay.io/org/builder:v3.16@sha256:ccc"},
+		{"short", "", "builder:latest"},
+	} {
+		if err := database.CreateSnapshotComponent(ctx, snap.ID, c.name, c.sha, c.image, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resolver := fakeResolver{
+		"org/quay@sha256:aaa":    "sha256:aaa",
+		"org/quay@1a2b3c":        "sha256:aaa",
+		"org/quay@on-pr-1a2b3c":  "sha256:999",
+		"org/quay@latest":        "sha256:aaa",
+		"org/builder@sha256:ccc": "sha256:ccc",
+		"org/builder@v3.16":      "sha256:ddd",
+	}
+	NewVerifier(database, resolver, slog.Default()).VerifyOnce(ctx)
+
+	results, err := database.ListImageVerifications(ctx, snap.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, r := range results {
+		got[r.Component] = r.Status
+		if r.Component == "quay" && r.Tag != "1a2b3c" {
+			t.Errorf("quay published tag: got %q, want 1a2b3c", r.Tag)
+		}
+	}
+	want := map[string]string{
+		"quay":    StatusOK,
+		"clair":   StatusMissing,
+		"builder": StatusMismatch,
+		"short":   StatusError,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %q, want %q", k, got[k], v)
+		}
+	}
+
+	selected, err := database.GetSelectedCandidate(ctx, "quay-v3.16.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := selected.ImageDigests; d == nil || d.Components != 4 || d.Verified != 1 || d.Failed != 2 || d.Missing != 1 {
+		t.Errorf("image digest summary: got %+v, want 4 components, 1 verified, 2 failed, 1 missing", d)
+	}
+}
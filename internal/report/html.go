@@ -0,0 +1,22 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"time"
+)
+
+//go:embed report.html
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"day":  func(t *time.Time) string { return t.Format("2006-01-02") },
+}).Parse(htmlSource))
+
+// HTML writes r as a self-contained HTML page, with inline styles and no
+// external resources.
+func HTML(w io.Writer, r *Report) error {
+	return htmlTemplate.Execute(w, r)
+}
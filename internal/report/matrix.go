@@ -0,0 +1,20 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+//go:embed matrix.html
+var matrixSource string
+
+var matrixTemplate = template.Must(template.New("matrix").Parse(matrixSource))
+
+// MatrixHTML writes m as a self-contained HTML page, with inline styles and
+// no external resources.
+func MatrixHTML(w io.Writer, m *model.ReadinessMatrix) error {
+	return matrixTemplate.Execute(w, m)
+}
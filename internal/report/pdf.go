@@ -0,0 +1,303 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Page geometry in points: A4 with 50pt margins.
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	contentWidth = pageWidth - 2*margin
+)
+
+// The two standard PDF fonts used; neither needs embedding.
+const (
+	regular = "F1" // Helvetica
+	bold    = "F2" // Helvetica-Bold
+)
+
+// PDF writes r as a PDF document. It uses the standard Helvetica fonts and
+// approximates their glyph widths, so long table cells are cut short.
+func PDF(w io.Writer, r *Report) error {
+	d := &pdfDoc{}
+	d.newPage()
+
+	d.paragraph(bold, 18, r.Release.Name+" go/no-go report")
+	meta := "Generated " + r.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC")
+	if r.Release.DueDate != nil {
+		meta += " - Due " + r.Release.DueDate.Format("2006-01-02")
+	}
+	if r.Release.ReleaseTicketKey != "" {
+		meta += " - Release ticket " + r.Release.ReleaseTicketKey
+	}
+	if r.Release.Released {
+		meta += " - Released"
+	}
+	d.paragraph(regular, 9, meta)
+	d.space(6)
+	d.paragraph(bold, 12, fmt.Sprintf("Readiness: %s - %s", strings.ToUpper(r.Readiness.Signal), r.Readiness.Message))
+
+	d.heading("Readiness rules")
+	rules := make([][]string, len(r.Checks))
+	for i, c := range r.Checks {
+		result := "Pass"
+		if !c.Passed {
+			result = "Fail"
+		}
+		rules[i] = []string{c.Name, result, c.Detail}
+	}
+	d.table([]float64{150, 45, 300}, []string{"Rule", "Result", "Detail"}, rules)
+
+	if len(r.Readiness.Waivers) > 0 {
+		d.heading("Waivers")
+		waivers := make([][]string, len(r.Readiness.Waivers))
+		for i, o := range r.Readiness.Waivers {
+			waivers[i] = []string{o.Rule, o.Reason, o.CreatedBy, o.ExpiresAt.UTC().Format("2006-01-02 15:04")}
+		}
+		d.table([]float64{100, 215, 90, 90}, []string{"Rule", "Reason", "By", "Expires"}, waivers)
+	}
+
+	d.heading("Sign-offs")
+	var signOffs [][]string
+	for _, role := range r.Roles() {
+		if a := r.Approver(role); a != nil {
+			signOffs = append(signOffs, []string{a.Role, a.Approver, a.CreatedAt.UTC().Format("2006-01-02 15:04"), a.Comment})
+		} else {
+			signOffs = append(signOffs, []string{role, "Outstanding", "", ""})
+		}
+	}
+	d.table([]float64{110, 120, 90, 175}, []string{"Role", "Approver", "When", "Comment"}, signOffs)
+
+	d.heading("Issues")
+	if s := r.IssueSummary; s != nil {
+		d.paragraph(regular, 10, fmt.Sprintf("%d issues: %d done, %d open, %d open release blockers, %d waiting on unresolved blockers.",
+			s.Total, s.Verified, s.Open, s.OpenBlockers, s.Blocked))
+	}
+	issueColumns := []float64{75, 60, 55, 70, 85, 150}
+	issueHeader := []string{"Key", "Type", "Priority", "Status", "Assignee", "Summary"}
+	if open := r.OpenIssues(); len(open) > 0 {
+		d.subheading(fmt.Sprintf("Open (%d)", len(open)))
+		d.table(issueColumns, issueHeader, issueRows(open))
+	}
+	if done := r.DoneIssues(); len(done) > 0 {
+		d.subheading(fmt.Sprintf("Done (%d)", len(done)))
+		d.table(issueColumns, issueHeader, issueRows(done))
+	}
+
+	d.heading("Snapshot")
+	if s := r.Snapshot; s != nil {
+		tests := "none"
+		if s.HasTests && s.TestsPassed {
+			tests = "passed"
+		} else if s.HasTests {
+			tests = "failed"
+		}
+		d.paragraph(regular, 10, fmt.Sprintf("%s (%s), built %s. Tests: %s.", s.Name, s.Application, s.CreatedAt.UTC().Format("2006-01-02 15:04 UTC"), tests))
+		widths := []float64{120, 90, 80, 205}
+		d.row(bold, widths, []string{"Component", "Git SHA", "Registry", "Image"})
+		for _, c := range r.Components() {
+			verification := c.Verification
+			if verification == "" {
+				verification = "not verified"
+			}
+			d.row(regular, widths, []string{c.Name, c.GitSHA, verification, c.Image})
+			if c.Digest != "" {
+				d.ensure(10)
+				d.text(margin+10, 7, regular, c.Digest)
+				d.y -= 10
+			}
+		}
+	} else {
+		d.paragraph(regular, 10, "No snapshot has been selected for this release.")
+	}
+
+	return d.writeTo(w, r.GeneratedAt)
+}
+
+func issueRows(issues []model.JiraIssueRecord) [][]string {
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		key := issue.Key
+		if issue.Blocker {
+			key += " (blocker)"
+		}
+		rows[i] = []string{key, issue.IssueType, issue.Priority, issue.Status, issue.Assignee, issue.Summary}
+	}
+	return rows
+}
+
+// pdfDoc lays out text top to bottom over as many pages as it needs.
+type pdfDoc struct {
+	pages []*bytes.Buffer
+	y     float64 // baseline of the next line on the current page
+}
+
+func (d *pdfDoc) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// ensure starts a new page unless height points fit above the margin.
+func (d *pdfDoc) ensure(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+func (d *pdfDoc) space(height float64) {
+	d.y -= height
+}
+
+// text draws s with its baseline at the current line.
+func (d *pdfDoc) text(x, size float64, font, s string) {
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", font, size, x, d.y, pdfString(s))
+}
+
+// paragraph draws s wrapped to the content width.
+func (d *pdfDoc) paragraph(font string, size float64, s string) {
+	for _, line := range wrap(s, maxChars(contentWidth, font, size)) {
+		d.ensure(size * 1.3)
+		d.y -= size
+		d.text(margin, size, font, line)
+		d.y -= size * 0.3
+	}
+}
+
+func (d *pdfDoc) heading(s string) {
+	d.space(12)
+	d.ensure(40) // keep headings with what follows
+	d.paragraph(bold, 14, s)
+	d.space(4)
+}
+
+func (d *pdfDoc) subheading(s string) {
+	d.space(6)
+	d.ensure(30)
+	d.paragraph(bold, 11, s)
+	d.space(2)
+}
+
+// table draws a header row and rows, cutting cells to their column width.
+func (d *pdfDoc) table(widths []float64, header []string, rows [][]string) {
+	d.row(bold, widths, header)
+	for _, r := range rows {
+		d.row(regular, widths, r)
+	}
+}
+
+func (d *pdfDoc) row(font string, widths []float64, cells []string) {
+	const size = 8
+	d.ensure(size * 1.5)
+	d.y -= size
+	x := float64(margin)
+	for i, cell := range cells {
+		d.text(x, size, font, truncate(cell, maxChars(widths[i]-4, font, size)))
+		x += widths[i]
+	}
+	d.y -= size * 0.5
+}
+
+// writeTo writes the PDF file: catalog, page tree, fonts, then a page and
+// content stream object per page, followed by the cross-reference table.
+func (d *pdfDoc) writeTo(w io.Writer, created time.Time) error {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Producer (release-readiness) /CreationDate (D:%s) >>", created.UTC().Format("20060102150405Z")))
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, regular, bold, 7+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// maxChars estimates how many characters of font at size fit in width,
+// from Helvetica's average glyph width.
+func maxChars(width float64, font string, size float64) int {
+	avg := 0.5
+	if font == bold {
+		avg = 0.55
+	}
+	return int(width / (size * avg))
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
+
+// wrap breaks s into lines of at most n characters at spaces.
+func wrap(s string, n int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= n:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return append(lines, line)
+}
+
+// pdfString encodes s for a literal string in WinAnsiEncoding, escaping
+// delimiters and replacing characters the encoding lacks.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '—' || r == '–':
+			b.WriteByte('-')
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x7f || (r >= 0xa0 && r <= 0xff):
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+// Package report renders a release's go/no-go report as a self-contained
+// HTML page or a PDF, for attaching to the release ticket, and its
+// readiness matrix as an HTML page.
+package report
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Report is everything a go/no-go report shows about a release.
+type Report struct {
+	Release      model.ReleaseVersion
+	Readiness    model.ReadinessResponse
+	Checks       []Check
+	IssueSummary *model.IssueSummary
+	Issues       []model.JiraIssueRecord
+	// Snapshot is the selected candidate; nil if the release has none.
+	Snapshot    *model.SnapshotRecord
+	Approvals   []model.Approval
+	SignOff     model.SignOff
+	GeneratedAt time.Time
+}
+
+// Check is one readiness rule and whether the release meets it.
+type Check struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// Component is a snapshot component with the digest of its image and the
+// result of its registry verification, if any.
+type Component struct {
+	Name         string
+	GitSHA       string
+	Image        string
+	Digest       string
+	Verification string
+}
+
+// OpenIssues returns the issues that are not done, blockers first.
+func (r *Report) OpenIssues() []model.JiraIssueRecord {
+	var open []model.JiraIssueRecord
+	for _, i := range r.Issues {
+		if !i.Done() || len(i.OpenBlockers()) > 0 {
+			open = append(open, i)
+		}
+	}
+	slices.SortStableFunc(open, func(a, b model.JiraIssueRecord) int {
+		if a.Blocker != b.Blocker {
+			if a.Blocker {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return open
+}
+
+// DoneIssues returns the issues that are done and not blocked.
+func (r *Report) DoneIssues() []model.JiraIssueRecord {
+	var done []model.JiraIssueRecord
+	for _, i := range r.Issues {
+		if i.Done() && len(i.OpenBlockers()) == 0 {
+			done = append(done, i)
+		}
+	}
+	return done
+}
+
+// Components lists the snapshot's components with their image digests.
+func (r *Report) Components() []Component {
+	if r.Snapshot == nil {
+		return nil
+	}
+	verified := make(map[string]model.ImageVerification, len(r.Snapshot.ImageVerifications))
+	for _, v := range r.Snapshot.ImageVerifications {
+		verified[v.Component] = v
+	}
+	components := make([]Component, len(r.Snapshot.Components))
+	for i, c := range r.Snapshot.Components {
+		image, digest, _ := strings.Cut(c.ImageURL, "@")
+		v := verified[c.Component]
+		// Name the tag a digest-only image was published under.
+		if v.Tag != "" && !strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+			image += ":" + v.Tag
+		}
+		components[i] = Component{
+			Name:         c.Component,
+			GitSHA:       c.GitSHA,
+			Image:        image,
+			Digest:       digest,
+			Verification: v.Status,
+		}
+	}
+	return components
+}
+
+// Approver returns the approval of role, or nil if it is outstanding.
+func (r *Report) Approver(role string) *model.Approval {
+	for i := range r.Approvals {
+		if strings.EqualFold(r.Approvals[i].Role, role) {
+			return &r.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// Roles returns every sign-off role, approved ones first.
+func (r *Report) Roles() []string {
+	return slices.Concat(r.SignOff.Approved, r.SignOff.Outstanding)
+}
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func testReport(issues int) *Report {
+	due := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	r := &Report{
+		Release:   model.ReleaseVersion{Name: "3.16.3", DueDate: &due, ReleaseTicketKey: "PROJQUAY-1"},
+		Readiness: model.ReadinessResponse{Signal: "yellow", Message: "Open issues remain"},
+		Checks: []Check{
+			{Name: "Release blockers", Passed: true, Detail: "None open"},
+			{Name: "Open issues", Passed: false, Detail: "1 open"},
+		},
+		IssueSummary: &model.IssueSummary{Total: issues, Open: 1, Verified: issues - 1},
+		Snapshot: &model.SnapshotRecord{
+			Name: "quay-v3-16-abc", Application: "quay-v3-16", HasTests: true, TestsPassed: true,
+			Components:         []model.ComponentRecord{{Component: "quay", GitSHA: "abc123", ImageURL: "quay.io/quay/quay@sha256:0123"}},
+			ImageVerifications: []model.ImageVerification{{Component: "quay", Status: "ok"}},
+		},
+		Approvals:   []model.Approval{{Role: "QE", Approver: "qe-lead", CreatedAt: due.AddDate(0, 0, -1)}},
+		SignOff:     model.SignOff{Approved: []string{"QE"}, Outstanding: []string{"Docs"}},
+		GeneratedAt: due.AddDate(0, 0, -2),
+	}
+	r.Issues = append(r.Issues, model.JiraIssueRecord{Key: "PROJQUAY-2", Summary: "Fix <script> escaping", Status: "In Progress", Blocker: true})
+	for i := 1; i < issues; i++ {
+		r.Issues = append(r.Issues, model.JiraIssueRecord{Key: fmt.Sprintf("PROJQUAY-%d", 100+i), Summary: "Done (really)", Status: "Closed"})
+	}
+	return r
+}
+
+func TestHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := HTML(&buf, testReport(3)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"3.16.3 go/no-go report",
+		"Due 2026-11-02",
+		`<span class="signal yellow">yellow</span> Open issues remain`,
+		"Fix &lt;script&gt; escaping",
+		"<h3>Open (1)</h3>",
+		"<h3>Done (2)</h3>",
+		"<td>qe-lead</td>",
+		`<td>Docs</td><td colspan="3" class="fail">Outstanding</td>`,
+		"<code>sha256:0123</code>",
+		"<td>ok</td>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script>") || strings.Contains(out, "<link") {
+		t.Error("report is not self-contained or unescaped")
+	}
+}
+
+func TestPDF(t *testing.T) {
+	var buf bytes.Buffer
+	// Enough issues to need several pages.
+	if err := PDF(&buf, testReport(200)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatal("not a PDF file")
+	}
+	count := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(out)
+	if count == nil || count[1] == "1" {
+		t.Errorf("pages: got %v, want several", count)
+	}
+	if !strings.Contains(out, `(Done \(really\))`) || !strings.Contains(out, "(sha256:0123)") {
+		t.Error("missing escaped issue summary or digest")
+	}
+
+	// Every cross-reference entry must point at its object.
+	start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(out)[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[start:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		if want := fmt.Sprintf("%d 0 obj", i+1); !strings.HasPrefix(out[off:], want) {
+			t.Errorf("xref entry %d: points at %q", i+1, out[off:off+10])
+		}
+	}
+}
+
+func TestMatrixHTML(t *testing.T) {
+	var buf bytes.Buffer
+	m := &model.ReadinessMatrix{
+		Release:  "3.16.3",
+		Snapshot: "quay-v3-16-abc",
+		Scenarios: []model.MatrixScenario{
+			{Name: "api-tests", Status: "failed", Required: true},
+			{Name: "perf-tests", Status: "passed"},
+		},
+		Rows: []model.MatrixRow{{Component: "quay<br>", Cells: []model.MatrixCell{
+			{Status: "failed", Tests: 3, Passed: 2, Failed: 1, Scoped: true},
+			{Status: "passed", Tests: 1, Passed: 1},
+		}}},
+	}
+	if err := MatrixHTML(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"3.16.3 readiness matrix",
+		`<th class="failed">api-tests</th>`,
+		`perf-tests<br><span class="info">informational</span>`,
+		"<th class=\"component\">quay&lt;br&gt;</th>",
+		`<td class="failed"><span class="status">failed</span><br><span class="counts">2/3 passed, 1 failed</span></td>`,
+		`<span class="status">passed*</span>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package requestid propagates a per-request (or per-sync-cycle) correlation
+// ID through contexts, outgoing HTTP calls, and slog records.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// Header is the HTTP header used to receive and return request IDs.
+const Header = "X-Request-ID"
+
+// maxLen bounds client-supplied IDs so they cannot bloat log lines.
+const maxLen = 64
+
+type ctxKey struct{}
+
+// New returns a random 16-character hex ID.
+func New() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Valid reports whether id is acceptable as a client-supplied request ID:
+// non-empty, at most 64 characters, and limited to [A-Za-z0-9._-].
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the ID stored in ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Ensure returns ctx unchanged if it already carries an ID, and otherwise a
+// copy carrying a freshly generated one.
+func Ensure(ctx context.Context) context.Context {
+	if FromContext(ctx) != "" {
+		return ctx
+	}
+	return NewContext(ctx, New())
+}
+
+// Handler is a slog.Handler that adds a request_id attribute to every record
+// logged with a context carrying an ID (e.g. logger.InfoContext(ctx, ...)).
+type Handler struct {
+	slog.Handler
+}
+
+// NewHandler wraps h so that records include the request ID from their context.
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{Handler: h}
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if id := FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{Handler: h.Handler.WithGroup(name)}
+}
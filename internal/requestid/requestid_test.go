@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"", false},
+		{"abc-123_DEF.4", true},
+		{New(), true},
+		{strings.Repeat("a", 65), false},
+		{"has space", false},
+		{"new\nline", false},
+	}
+	for _, tt := range tests {
+		if got := Valid(tt.id); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	ctx := Ensure(context.Background())
+	id := FromContext(ctx)
+	if id == "" {
+		t.Fatal("Ensure did not set an ID")
+	}
+	if got := FromContext(Ensure(ctx)); got != id {
+		t.Errorf("Ensure replaced existing ID: got %q, want %q", got, id)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewTextHandler(&buf, nil))).With("component", "test")
+
+	logger.InfoContext(NewContext(context.Background(), "req-1"), "with id")
+	logger.Info("without id")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "request_id=req-1") || !strings.Contains(lines[0], "component=test") {
+		t.Errorf("first line missing attrs: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("second line should not have request_id: %s", lines[1])
+	}
+}
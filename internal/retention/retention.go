@@ -0,0 +1,352 @@
+// Package retention prunes old snapshots, with their components, test
+// results and scans, so that the database does not grow without bound.
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Store is the persistence contract the Pruner depends on.
+type Store interface {
+	ListRetentionSnapshots(ctx context.Context) ([]model.SnapshotRecord, error)
+	ListAllReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error)
+	ListCandidateStates(ctx context.Context) (map[string]map[int64]string, error)
+	ListAuditHolds(ctx context.Context) ([]model.AuditHold, error)
+	DeleteSnapshots(ctx context.Context, ids []int64) error
+	ListApplicationConfigs(ctx context.Context) ([]model.ApplicationConfig, error)
+}
+
+// Rule sets the retention limits of the applications whose name matches the
+// Application glob (path.Match syntax). A matching rule replaces both
+// default limits; a zero limit means none.
+type Rule struct {
+	Application string `json:"application"`
+	MaxCount    int    `json:"max_count"`
+	// MaxAge is a Go duration such as "2160h".
+	MaxAge string `json:"max_age"`
+
+	maxAge time.Duration
+}
+
+// Policy decides which snapshots are kept.
+//
+// Snapshots are first assigned to a release of their application: the
+// earliest released one that shipped after the snapshot was built, or at
+// most a day before, or else an unreleased one. A snapshot is always kept
+// if its release is unreleased or on audit hold. Of a released release's
+// snapshots, the one it shipped (the promoted candidate, or else the
+// newest one not demoted) and the KeepCandidates newest others not demoted
+// are kept. So is every snapshot accepted or released in its lifecycle,
+// whatever release it belongs to. Any other snapshot is deleted once MaxCount newer snapshots of
+// its application exist, protected ones included, or once it is older than
+// MaxAge. The retention configured for an application replaces both.
+type Policy struct {
+	// MaxCount and MaxAge are the limits of applications no rule matches.
+	// Zero means no limit.
+	MaxCount int
+	MaxAge   time.Duration
+	// KeepCandidates is how many candidates of a released release are kept
+	// besides the one it shipped.
+	KeepCandidates int
+	// Rules are tried in order; the first match wins.
+	Rules []Rule
+}
+
+// LoadRules reads a JSON array of rules from the file at path.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: %w", path, i+1, err)
+		}
+	}
+	return rules, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Application == "" {
+		return errors.New("application pattern is required")
+	}
+	if _, err := path.Match(r.Application, ""); err != nil {
+		return fmt.Errorf("application pattern %q: %w", r.Application, err)
+	}
+	if r.MaxCount < 0 {
+		return errors.New("max_count must not be negative")
+	}
+	if r.MaxAge != "" {
+		d, err := time.ParseDuration(r.MaxAge)
+		if err != nil {
+			return fmt.Errorf("max_age: %w", err)
+		}
+		if d < 0 {
+			return errors.New("max_age must not be negative")
+		}
+		r.maxAge = d
+	}
+	return nil
+}
+
+// limits returns the retention limits of application.
+func (p Policy) limits(application string) (maxCount int, maxAge time.Duration) {
+	for _, r := range p.Rules {
+		if ok, _ := path.Match(r.Application, application); ok {
+			return r.MaxCount, r.maxAge
+		}
+	}
+	return p.MaxCount, p.MaxAge
+}
+
+// Limited reports whether the policy can delete anything at all.
+func (p Policy) Limited() bool {
+	if p.MaxCount > 0 || p.MaxAge > 0 {
+		return true
+	}
+	for _, r := range p.Rules {
+		if r.MaxCount > 0 || r.maxAge > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Pruner periodically deletes the snapshots its policy does not keep.
+type Pruner struct {
+	store  Store
+	policy Policy
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewPruner creates a Pruner.
+func NewPruner(store Store, policy Policy, logger *slog.Logger) *Pruner {
+	return &Pruner{store: store, policy: policy, logger: logger, now: time.Now}
+}
+
+// PruneOnce deletes the snapshots that the policy currently does not keep.
+func (p *Pruner) PruneOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	plan, err := p.Plan(ctx)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "plan retention", "error", err)
+		return
+	}
+	if len(plan.Delete) == 0 {
+		return
+	}
+	ids := make([]int64, len(plan.Delete))
+	for i, d := range plan.Delete {
+		ids[i] = d.SnapshotID
+	}
+	if err := p.store.DeleteSnapshots(ctx, ids); err != nil {
+		p.logger.ErrorContext(ctx, "delete snapshots", "count", len(ids), "error", err)
+		return
+	}
+	p.logger.InfoContext(ctx, "pruned snapshots", "deleted", len(ids), "kept", plan.Kept)
+}
+
+// Plan returns what pruning would delete now, without deleting anything.
+func (p *Pruner) Plan(ctx context.Context) (*model.RetentionPlan, error) {
+	snapshots, err := p.store.ListRetentionSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+	versions, err := p.store.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+	states, err := p.store.ListCandidateStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list candidate states: %w", err)
+	}
+	holds, err := p.store.ListAuditHolds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list audit holds: %w", err)
+	}
+	held := make(map[string]bool, len(holds))
+	for _, h := range holds {
+		held[h.Release] = true
+	}
+	configs, err := p.store.ListApplicationConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list application configs: %w", err)
+	}
+	overrides := make(map[string]Rule)
+	for _, c := range configs {
+		if c.Retention == nil {
+			continue
+		}
+		r := Rule{Application: c.Name, MaxCount: c.Retention.MaxCount, MaxAge: c.Retention.MaxAge}
+		if err := r.validate(); err != nil {
+			p.logger.WarnContext(ctx, "ignoring retention of application", "application", c.Name, "error", err)
+			continue
+		}
+		overrides[c.Name] = r
+	}
+
+	releases := make(map[string][]model.ReleaseVersion)
+	for _, v := range versions {
+		if v.S3Application != "" {
+			releases[v.S3Application] = append(releases[v.S3Application], v)
+		}
+	}
+
+	plan := &model.RetentionPlan{
+		Delete:    []model.RetentionDeletion{},
+		Protected: make(map[string]int),
+	}
+	now := p.now()
+	for _, app := range groupByApplication(snapshots) {
+		protected := p.protect(app, releases[app[0].Application], states, held)
+		maxCount, maxAge := p.policy.limits(app[0].Application)
+		if r, ok := overrides[app[0].Application]; ok {
+			maxCount, maxAge = r.MaxCount, r.maxAge
+		}
+		for rank, s := range app {
+			if reason, ok := protected[s.ID]; ok {
+				plan.Protected[reason]++
+				plan.Kept++
+				continue
+			}
+			reason := ""
+			switch {
+			case maxCount > 0 && rank >= maxCount:
+				reason = "max_count"
+			case maxAge > 0 && now.Sub(s.CreatedAt) > maxAge:
+				reason = "max_age"
+			}
+			if reason == "" {
+				plan.Kept++
+				continue
+			}
+			plan.Delete = append(plan.Delete, model.RetentionDeletion{
+				SnapshotID:  s.ID,
+				Snapshot:    s.Name,
+				Application: s.Application,
+				Release:     owner(s, releases[s.Application]).Name,
+				CreatedAt:   s.CreatedAt,
+				Reason:      reason,
+			})
+		}
+	}
+	return plan, nil
+}
+
+// protect returns the snapshots of one application (newest first) that the
+// state rules keep, with the reason for each.
+func (p *Pruner) protect(snapshots []model.SnapshotRecord, releases []model.ReleaseVersion, states map[string]map[int64]string, held map[string]bool) map[int64]string {
+	protected := make(map[int64]string)
+	byRelease := make(map[string][]model.SnapshotRecord)
+	for _, s := range snapshots {
+		r := owner(s, releases)
+		switch {
+		case r.Name == "":
+		case held[r.Name]:
+			protected[s.ID] = model.RetainAuditHold
+		case !r.Released:
+			protected[s.ID] = model.RetainUnreleased
+		default:
+			byRelease[r.Name] = append(byRelease[r.Name], s)
+		}
+	}
+
+	for _, r := range releases {
+		if !r.Released {
+			continue
+		}
+		// A promoted candidate is what the release shipped, even if it
+		// was built outside the release's window. Should there be more
+		// than one, the newest is.
+		shipped := int64(0)
+		for id, state := range states[r.Name] {
+			if state == model.CandidatePromoted && id > shipped {
+				shipped = id
+			}
+		}
+		kept := 0
+		for _, s := range byRelease[r.Name] {
+			state := states[r.Name][s.ID]
+			switch {
+			case state == model.CandidateDemoted:
+			case shipped == 0:
+				shipped = s.ID
+			case s.ID != shipped && kept < p.policy.KeepCandidates:
+				if _, ok := protected[s.ID]; !ok {
+					protected[s.ID] = model.RetainRecentCandidate
+				}
+				kept++
+			}
+		}
+		if reason, ok := protected[shipped]; shipped != 0 && (!ok || reason == model.RetainRecentCandidate) {
+			protected[shipped] = model.RetainReleased
+		}
+	}
+
+	// Deleting an accepted or released snapshot would also delete the
+	// record of its lifecycle transitions.
+	for _, s := range snapshots {
+		if s.Lifecycle != model.LifecycleAccepted && s.Lifecycle != model.LifecycleReleased {
+			continue
+		}
+		if reason, ok := protected[s.ID]; !ok || reason == model.RetainRecentCandidate {
+			protected[s.ID] = model.RetainLifecycle
+		}
+	}
+	return protected
+}
+
+// owner returns the release a snapshot was built for: the earliest release
+// shipped after the snapshot, or at most a day before, or else an
+// unreleased one.
+// It returns the zero ReleaseVersion if there is none.
+func owner(s model.SnapshotRecord, releases []model.ReleaseVersion) model.ReleaseVersion {
+	var found, unreleased model.ReleaseVersion
+	for _, r := range releases {
+		switch {
+		case r.Released && r.ReleaseDate != nil:
+			if s.CreatedAt.After(r.ReleaseDate.Add(24 * time.Hour)) {
+				continue
+			}
+			if found.Name == "" || r.ReleaseDate.Before(*found.ReleaseDate) {
+				found = r
+			}
+		case !r.Released && !r.Archived && unreleased.Name == "":
+			unreleased = r
+		}
+	}
+	if found.Name != "" {
+		return found
+	}
+	return unreleased
+}
+
+// groupByApplication splits snapshots ordered by application into one
+// slice per application.
+func groupByApplication(snapshots []model.SnapshotRecord) [][]model.SnapshotRecord {
+	var groups [][]model.SnapshotRecord
+	for i := 0; i < len(snapshots); {
+		j := i + 1
+		for j < len(snapshots) && snapshots[j].Application == snapshots[i].Application {
+			j++
+		}
+		groups = append(groups, snapshots[i:j])
+		i = j
+	}
+	return groups
+}
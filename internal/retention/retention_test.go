@@ -0,0 +1,279 @@
+package retention
+
+import (
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestPlanAndPrune(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	jan10, mar10 := day(time.January, 10), day(time.March, 10)
+	for _, v := range []model.ReleaseVersion{
+		{Name: "quay-v3.15.0", Released: true, ReleaseDate: &jan10, S3Application: "quay-v3-15"},
+		{Name: "quay-v3.15.1", Released: true, ReleaseDate: &mar10, S3Application: "quay-v3-15"},
+		{Name: "quay-v3.15.2", S3Application: "quay-v3-15"},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ids := make(map[string]int64)
+	for _, s := range []struct {
+		app, name string
+		at        time.Time
+	}{
+		{"quay-v3-15", "s1", day(time.January, 1)},
+		{"quay-v3-15", "s2", day(time.January, 5)},
+		{"quay-v3-15", "s3", day(time.January, 8)},
+		{"quay-v3-15", "s4", day(time.January, 9)},
+		{"quay-v3-15", "s5", day(time.February, 1)},
+		{"quay-v3-15", "s6", day(time.March, 1)},
+		{"quay-v3-15", "s7", day(time.April, 1)},
+		{"quay-v3-15", "s8", day(time.April, 2)},
+		{"other", "o1", day(time.January, 1)},
+		{"other", "o2", day(time.April, 1)},
+	} {
+		rec, err := database.CreateSnapshot(ctx, s.app, s.name, true, s.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[s.name] = rec.ID
+	}
+	if err := database.CreateSnapshotComponent(ctx, ids["s1"], "quay", "abc", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetCandidateState(ctx, "quay-v3.15.0", ids["s2"], model.CandidatePromoted, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetCandidateState(ctx, "quay-v3.15.0", ids["s4"], model.CandidateDemoted, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPruner(database, Policy{
+		MaxAge:         30 * 24 * time.Hour,
+		KeepCandidates: 1,
+		Rules:          []Rule{{Application: "quay-*", MaxCount: 2}},
+	}, slog.Default())
+	p.now = func() time.Time { return day(time.April, 3) }
+
+	deleted := func(plan *model.RetentionPlan) []string {
+		var names []string
+		for _, d := range plan.Delete {
+			names = append(names, d.Snapshot+":"+d.Reason)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	// s4 is demoted and s1 is an old candidate of a released release, both
+	// beyond the rule's max count; o1 is older than the default max age.
+	plan, err := p.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := deleted(plan), []string{"o1:max_age", "s1:max_count", "s4:max_count"}; !slices.Equal(got, want) {
+		t.Errorf("delete: got %v, want %v", got, want)
+	}
+	if plan.Kept != 7 {
+		t.Errorf("kept: got %d, want 7", plan.Kept)
+	}
+	if want := map[string]int{
+		model.RetainUnreleased:      2, // s7, s8
+		model.RetainReleased:        2, // s2 (promoted), s6 (newest)
+		model.RetainRecentCandidate: 2, // s3, s5
+	}; !maps.Equal(plan.Protected, want) {
+		t.Errorf("protected: got %v, want %v", plan.Protected, want)
+	}
+
+	// An audit hold keeps everything of quay-v3.15.0.
+	if _, err := database.SetAuditHold(ctx, "quay-v3.15.0", "CVE review"); err != nil {
+		t.Fatal(err)
+	}
+	plan, err = p.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := deleted(plan), []string{"o1:max_age"}; !slices.Equal(got, want) {
+		t.Errorf("delete with audit hold: got %v, want %v", got, want)
+	}
+	if err := database.DeleteAuditHold(ctx, "quay-v3.15.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	p.PruneOnce(ctx)
+	left, err := database.ListRetentionSnapshots(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range left {
+		names = append(names, s.Name)
+	}
+	if want := []string{"o2", "s8", "s7", "s6", "s5", "s3", "s2"}; !slices.Equal(names, want) {
+		t.Errorf("left: got %v, want %v", names, want)
+	}
+	components, err := database.ListSnapshotComponents(ctx, ids["s1"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 0 {
+		t.Errorf("components of pruned snapshot: got %+v", components)
+	}
+}
+
+func TestProtectTwoPromoted(t *testing.T) {
+	jan10 := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	releases := []model.ReleaseVersion{{Name: "quay-v3.15.0", Released: true, ReleaseDate: &jan10, S3Application: "quay-v3-15"}}
+	var snapshots []model.SnapshotRecord
+	for id := int64(4); id >= 1; id-- {
+		snapshots = append(snapshots, model.SnapshotRecord{ID: id, Application: "quay-v3-15", CreatedAt: jan10.AddDate(0, 0, -int(id))})
+	}
+	// Only one candidate can be promoted through the API, but the older
+	// promotion of a release may have been left behind.
+	states := map[string]map[int64]string{"quay-v3.15.0": {
+		1: model.CandidatePromoted,
+		2: model.CandidatePromoted,
+		3: model.CandidatePromoted,
+	}}
+
+	p := &Pruner{policy: Policy{}}
+	want := map[int64]string{3: model.RetainReleased}
+	// Map iteration order varies, so one pick could be right by chance.
+	for range 20 {
+		if got := p.protect(snapshots, releases, states, nil); !maps.Equal(got, want) {
+			t.Fatalf("protected: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPlanKeepsLifecycle(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	var ids []int64
+	for i, name := range []string{"s1", "s2", "s3", "s4"} {
+		rec, err := database.CreateSnapshot(ctx, "other", name, true, start.AddDate(0, 0, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, rec.ID)
+	}
+	// s1 shipped and s2 was accepted, though no release points at the
+	// application; s3 only got as far as candidate.
+	for id, path := range map[int64][]string{
+		ids[0]: {model.LifecycleCandidate, model.LifecycleAccepted, model.LifecycleReleased},
+		ids[1]: {model.LifecycleCandidate, model.LifecycleAccepted},
+		ids[2]: {model.LifecycleCandidate},
+	} {
+		from := model.LifecycleNew
+		for _, to := range path {
+			if err := database.TransitionSnapshot(ctx, id, &model.LifecycleTransition{From: from, To: to}); err != nil {
+				t.Fatal(err)
+			}
+			from = to
+		}
+	}
+
+	plan, err := NewPruner(database, Policy{MaxCount: 1}, slog.Default()).Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].Snapshot != "s3" || plan.Delete[0].Reason != "max_count" {
+		t.Errorf("plan: got %+v, want only s3 deleted", plan.Delete)
+	}
+	if want := map[string]int{model.RetainLifecycle: 2}; !maps.Equal(plan.Protected, want) {
+		t.Errorf("protected: got %v, want %v", plan.Protected, want)
+	}
+}
+
+func TestPlanWithoutLimits(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-15", "s1", true, time.Now().AddDate(-2, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := Policy{KeepCandidates: 3}
+	if policy.Limited() {
+		t.Error("Limited: got true for a policy without limits")
+	}
+	plan, err := NewPruner(database, policy, slog.Default()).Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 0 || plan.Kept != 1 {
+		t.Errorf("plan: got %+v, want nothing deleted", plan)
+	}
+
+	// Retention configured for the application applies all the same.
+	if err := database.SaveApplicationConfig(ctx, &model.ApplicationConfig{Name: "quay-v3-15",
+		Retention: &model.RetentionLimits{MaxAge: "8760h"}}); err != nil {
+		t.Fatal(err)
+	}
+	if plan, err = NewPruner(database, policy, slog.Default()).Plan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].Reason != "max_age" {
+		t.Errorf("plan with application retention: got %+v, want s1 deleted", plan)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "rules.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	rules, err := LoadRules(write(`[{"application": "quay-*", "max_count": 50, "max_age": "2160h"}, {"application": "*"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := Policy{MaxCount: 10, Rules: rules}
+	if n, age := policy.limits("quay-v3-15"); n != 50 || age != 2160*time.Hour {
+		t.Errorf("quay limits: got %d, %s", n, age)
+	}
+	if n, age := policy.limits("omr-v2"); n != 0 || age != 0 {
+		t.Errorf("catch-all limits: got %d, %s, want none", n, age)
+	}
+
+	for _, bad := range []string{
+		`[{"max_count": 5}]`,
+		`[{"application": "[", "max_count": 5}]`,
+		`[{"application": "quay-*", "max_age": "90 days"}]`,
+		`[{"application": "quay-*", "max_count": -1}]`,
+	} {
+		if _, err := LoadRules(write(bad)); err == nil {
+			t.Errorf("LoadRules(%s): got nil error", bad)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package runstatus tracks the runs of periodic background jobs, such as the
+// S3 and JIRA syncs, for the sync status API. Together with the circuit
+// breakers it tells whether a stale dashboard is an ingestion problem or an
+// upstream one.
+package runstatus
+
+import (
+	"sync"
+	"time"
+)
+
+// Tracker records the runs of one job. It is safe for concurrent use.
+type Tracker struct {
+	mu     sync.Mutex
+	status Status
+	// prevSuccess is LastSuccessAt before the last run finished, restored
+	// if Fail turns that run into a failure.
+	prevSuccess *time.Time
+}
+
+// Status is a snapshot of a job's runs.
+type Status struct {
+	Name           string     `json:"name"`
+	Running        bool       `json:"running"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	// ItemsProcessed counts what the last finished run stored, e.g. new
+	// snapshots or synced issues.
+	ItemsProcessed int  `json:"items_processed"`
+	LastRunOK      bool `json:"last_run_ok"`
+	// LastSuccessAt is when the last run that finished without error did.
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	// LastError is the most recent error of any run; it is kept after later
+	// runs succeed, with the time it happened.
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	NextRunAt   *time.Time `json:"next_run_at,omitempty"`
+}
+
+// New returns a tracker for the job called name.
+func New(name string) *Tracker {
+	return &Tracker{status: Status{Name: name}}
+}
+
+// Run is one run of a job, begun by Tracker.Start.
+type Run struct {
+	t       *Tracker
+	started time.Time
+	items   int
+	err     error
+}
+
+// Start records that a run has begun. The caller must call Finish.
+func (t *Tracker) Start() *Run {
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Running = true
+	t.status.LastStartedAt = &now
+	return &Run{t: t, started: now}
+}
+
+// Add counts n more items processed by the run.
+func (r *Run) Add(n int) {
+	r.items += n
+}
+
+// Fail records an error of the run. The run continues; if it fails more
+// than once, the last error is reported.
+func (r *Run) Fail(err error) {
+	if err != nil {
+		r.err = err
+	}
+}
+
+// Finish records that the run has ended.
+func (r *Run) Finish() {
+	now := time.Now().UTC()
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+	s := &r.t.status
+	s.Running = false
+	s.LastFinishedAt = &now
+	s.LastDurationMs = now.Sub(r.started).Milliseconds()
+	s.ItemsProcessed = r.items
+	s.LastRunOK = r.err == nil
+	r.t.prevSuccess = s.LastSuccessAt
+	if r.err == nil {
+		s.LastSuccessAt = &now
+	} else {
+		s.LastError = r.err.Error()
+		s.LastErrorAt = &now
+	}
+}
+
+// Fail records err as the outcome of the last run, after it finished; for
+// a job that records its own runs but ended abnormally, e.g. by panicking.
+func (t *Tracker) Fail(err error) {
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &t.status
+	if s.LastRunOK {
+		s.LastSuccessAt = t.prevSuccess
+	}
+	s.Running = false
+	s.LastRunOK = false
+	s.LastError = err.Error()
+	s.LastErrorAt = &now
+}
+
+// Schedule records when the next run is due.
+func (t *Tracker) Schedule(next time.Time) {
+	next = next.UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.NextRunAt = &next
+}
+
+// Status returns the job's current status.
+func (t *Tracker) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
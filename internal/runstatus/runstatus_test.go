@@ -0,0 +1,58 @@
+package runstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	tr := New("jira")
+	if s := tr.Status(); s.Name != "jira" || s.LastStartedAt != nil || s.Running {
+		t.Fatalf("new tracker: got %+v", s)
+	}
+
+	run := tr.Start()
+	if !tr.Status().Running {
+		t.Error("started run: want running")
+	}
+	run.Add(3)
+	run.Fail(errors.New("first"))
+	run.Fail(nil)
+	run.Fail(errors.New("search issues: 502"))
+	run.Finish()
+
+	s := tr.Status()
+	if s.Running || s.LastRunOK || s.ItemsProcessed != 3 || s.LastError != "search issues: 502" || s.LastErrorAt == nil || s.LastSuccessAt != nil {
+		t.Errorf("failed run: got %+v", s)
+	}
+
+	// A later successful run keeps the last error for context.
+	run = tr.Start()
+	run.Add(5)
+	run.Finish()
+	next := time.Now().Add(time.Minute)
+	tr.Schedule(next)
+
+	s = tr.Status()
+	if !s.LastRunOK || s.ItemsProcessed != 5 || s.LastError != "search issues: 502" || s.LastSuccessAt == nil {
+		t.Errorf("successful run: got %+v", s)
+	}
+	if s.NextRunAt == nil || !s.NextRunAt.Equal(next) {
+		t.Errorf("next run: got %v, want %v", s.NextRunAt, next)
+	}
+}
+
+func TestTrackerFail(t *testing.T) {
+	tr := New("s3")
+	tr.Start().Finish()
+	first := tr.Status().LastSuccessAt
+
+	// A run that panics after finishing fails, keeping the previous success.
+	tr.Start().Finish()
+	tr.Fail(errors.New("panic: nil map"))
+	s := tr.Status()
+	if s.Running || s.LastRunOK || s.LastError != "panic: nil map" || s.LastErrorAt == nil || s.LastSuccessAt != first {
+		t.Errorf("got %+v, want last success %v", s, first)
+	}
+}
@@ -0,0 +1,196 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// azureAPIVersion is the Blob service REST API version requested.
+const azureAPIVersion = "2021-08-06"
+
+// AzureConfig holds the settings needed to read an Azure Blob Storage
+// container.
+type AzureConfig struct {
+	Endpoint  string // custom endpoint URL (e.g. http://127.0.0.1:10000/devstoreaccount1 for Azurite); defaults to https://{account}.blob.core.windows.net
+	Account   string
+	Container string
+	// SASToken is a shared access signature granting read and list on the
+	// container, with or without the leading "?". If empty, requests are
+	// not authenticated, which works for public containers only.
+	SASToken string
+	Prefix   string // directory the layout is rooted at; empty for the container root
+	Source   string // names the breaker of one of several sources; see Source
+}
+
+// AzureClient reads an Azure Blob Storage container laid out like the S3
+// bucket, through the Blob service REST API.
+type AzureClient struct {
+	layout
+	container  string
+	sas        url.Values
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// NewAzure creates an AzureClient from the given AzureConfig.
+func NewAzure(cfg AzureConfig) (*AzureClient, error) {
+	if cfg.Container == "" {
+		return nil, fmt.Errorf("azure: container is required")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		if cfg.Account == "" {
+			return nil, fmt.Errorf("azure: account or endpoint is required")
+		}
+		endpoint = "https://" + cfg.Account + ".blob.core.windows.net"
+	}
+	sas, err := url.ParseQuery(strings.TrimPrefix(cfg.SASToken, "?"))
+	if err != nil {
+		return nil, fmt.Errorf("azure: invalid SAS token: %w", err)
+	}
+	c := &AzureClient{
+		container:  strings.TrimSuffix(endpoint, "/") + "/" + url.PathEscape(cfg.Container),
+		sas:        sas,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+		breaker:    breaker.New(breakerName("azure", cfg.Source), breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(isUnavailable)
+	c.layout = newLayout(c, cfg.Prefix)
+	return c, nil
+}
+
+// Breaker returns the circuit breaker guarding calls to the container.
+func (c *AzureClient) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+func (c *AzureClient) getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	resp, err := c.getBlob(ctx, key, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+func (c *AzureClient) listObjects(ctx context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	q := url.Values{"restype": {"container"}, "comp": {"list"}}
+	if prefix != "" {
+		q.Set("prefix", prefix)
+	}
+	if delimiter != "" {
+		q.Set("delimiter", delimiter)
+	}
+	for {
+		var page struct {
+			Blobs []struct {
+				Name string `xml:"Name"`
+			} `xml:"Blobs>Blob"`
+			Prefixes []struct {
+				Name string `xml:"Name"`
+			} `xml:"Blobs>BlobPrefix"`
+			NextMarker string `xml:"NextMarker"`
+		}
+		u := c.container + "?" + c.query(q)
+		err := c.breaker.Do(func() error {
+			resp, err := c.do(ctx, u, "")
+			if err != nil {
+				return fmt.Errorf("list %s: %w", prefix, err)
+			}
+			if err := checkResponse("list "+prefix, resp); err != nil {
+				return err
+			}
+			defer func() { _ = resp.Body.Close() }()
+			return xml.NewDecoder(resp.Body).Decode(&page)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, b := range page.Blobs {
+			keys = append(keys, b.Name)
+		}
+		for _, p := range page.Prefixes {
+			prefixes = append(prefixes, p.Name)
+		}
+		if page.NextMarker == "" {
+			return keys, prefixes, nil
+		}
+		q.Set("marker", page.NextMarker)
+	}
+}
+
+func (c *AzureClient) getObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	resp, err := c.getBlob(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return readObject(key, resp.Body, resp.ContentLength, maxBytes)
+}
+
+func (c *AzureClient) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	resp, err := c.getBlob(ctx, key, etag)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// getBlob downloads key. With ifNoneMatch set, it returns ErrNotModified if
+// the blob's ETag still matches.
+func (c *AzureClient) getBlob(ctx context.Context, key, ifNoneMatch string) (*http.Response, error) {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	u := c.container + "/" + strings.Join(segments, "/")
+	if len(c.sas) > 0 {
+		u += "?" + c.query(nil)
+	}
+	var resp *http.Response
+	err := c.breaker.Do(func() error {
+		var err error
+		resp, err = c.do(ctx, u, ifNoneMatch)
+		if err != nil {
+			return fmt.Errorf("get %s: %w", key, err)
+		}
+		return checkResponse("get "+key, resp)
+	})
+	if err != nil {
+		return nil, notModified(err)
+	}
+	return resp, nil
+}
+
+// query encodes q together with the SAS token.
+func (c *AzureClient) query(q url.Values) string {
+	all := maps.Clone(c.sas)
+	maps.Copy(all, q)
+	return all.Encode()
+}
+
+// do sends a GET request for u.
+func (c *AzureClient) do(ctx context.Context, u, ifNoneMatch string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	return c.httpClient.Do(req)
+}
@@ -0,0 +1,229 @@
+package s3
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// pageSize is small so the fake servers exercise pagination.
+const pageSize = 2
+
+// page returns the slice of items starting at the position encoded in
+// token, along with the token of the next page.
+func page(items []string, token string) ([]string, string) {
+	start, _ := strconv.Atoi(token)
+	end := min(start+pageSize, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[start:end], next
+}
+
+// fakeGCS serves the subset of the GCS JSON API GCSClient uses from store.
+func fakeGCS(store *MemoryStore) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		const listPath = "/storage/v1/b/bucket/o"
+		if r.URL.Path == listPath {
+			keys, prefixes, _ := store.listObjects(r.Context(), q.Get("prefix"), q.Get("delimiter"))
+			// Page through keys and prefixes together.
+			all := append(slices.Clone(prefixes), keys...)
+			items, next := page(all, q.Get("pageToken"))
+			var out struct {
+				Items []struct {
+					Name string `json:"name"`
+				} `json:"items"`
+				Prefixes      []string `json:"prefixes"`
+				NextPageToken string   `json:"nextPageToken,omitempty"`
+			}
+			for _, item := range items {
+				if slices.Contains(prefixes, item) {
+					out.Prefixes = append(out.Prefixes, item)
+				} else {
+					out.Items = append(out.Items, struct {
+						Name string `json:"name"`
+					}{item})
+				}
+			}
+			out.NextPageToken = next
+			_ = json.NewEncoder(w).Encode(out)
+			return
+		}
+		key, ok := strings.CutPrefix(r.URL.EscapedPath(), listPath+"/")
+		if !ok || q.Get("alt") != "media" || strings.Contains(key, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		key, _ = url.PathUnescape(key)
+		data, err := store.getObject(r.Context(), key, 0)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		generation := strconv.Itoa(len(data))
+		if q.Get("ifGenerationNotMatch") == generation {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("X-Goog-Generation", generation)
+		_, _ = w.Write(data)
+	}))
+}
+
+// fakeAzure serves the subset of the Blob service API AzureClient uses
+// from store.
+func fakeAzure(store *MemoryStore) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sig") != "secret" || r.Header.Get("x-ms-version") == "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/container" && q.Get("comp") == "list" {
+			keys, prefixes, _ := store.listObjects(r.Context(), q.Get("prefix"), q.Get("delimiter"))
+			all := append(slices.Clone(prefixes), keys...)
+			items, next := page(all, q.Get("marker"))
+			type name struct {
+				Name string `xml:"Name"`
+			}
+			var out struct {
+				XMLName    xml.Name `xml:"EnumerationResults"`
+				Blobs      []name   `xml:"Blobs>Blob"`
+				Prefixes   []name   `xml:"Blobs>BlobPrefix"`
+				NextMarker string   `xml:"NextMarker"`
+			}
+			for _, item := range items {
+				if slices.Contains(prefixes, item) {
+					out.Prefixes = append(out.Prefixes, name{item})
+				} else {
+					out.Blobs = append(out.Blobs, name{item})
+				}
+			}
+			out.NextMarker = next
+			_ = xml.NewEncoder(w).Encode(out)
+			return
+		}
+		key, ok := strings.CutPrefix(r.URL.Path, "/container/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := store.getObject(r.Context(), key, 0)
+		if err != nil {
+			http.Error(w, "BlobNotFound", http.StatusNotFound)
+			return
+		}
+		sum := md5.Sum(data)
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write(data)
+	}))
+}
+
+func TestBackends(t *testing.T) {
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-16", "quay-v3-16-snap-1", 0)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 1)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-2", 0)
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/junit/ui tests/results.xml",
+		[]byte(`<testsuite name="cypress"><testcase name="logs in"/></testsuite>`))
+
+	gcsServer := fakeGCS(store)
+	t.Cleanup(gcsServer.Close)
+	gcs, err := NewGCS(GCSConfig{Endpoint: gcsServer.URL, Bucket: "bucket", Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	azureServer := fakeAzure(store)
+	t.Cleanup(azureServer.Close)
+	azure, err := NewAzure(AzureConfig{Endpoint: azureServer.URL, Container: "container", SASToken: "?sv=2021-08-06&sig=secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	for key, data := range store.objects {
+		p := filepath.Join(root, filepath.FromSlash(key))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	local := NewDirStore(root)
+
+	for name, backend := range map[string]ObjectStore{"gcs": gcs, "azure": azure, "dir": local} {
+		ctx := t.Context()
+		apps, err := backend.ListApplications(ctx)
+		if err != nil || !slices.Equal(apps, []string{"quay-v3-16", "quay-v3-17"}) {
+			t.Errorf("%s applications: got %q, %v", name, apps, err)
+		}
+		keys, err := backend.ListSnapshots(ctx, "quay-v3-17")
+		want := []string{"quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json", "quay-v3-17/snapshots/quay-v3-17-snap-2/snapshot.json"}
+		if err != nil || !slices.Equal(keys, want) {
+			t.Errorf("%s snapshots: got %q, %v", name, keys, err)
+			continue
+		}
+
+		snap, etag, err := backend.GetSnapshotIfChanged(ctx, keys[0], "")
+		if err != nil || snap.Snapshot != "quay-v3-17-snap-1" || etag == "" {
+			t.Errorf("%s snapshot: got %+v, %q, %v", name, snap, etag, err)
+		}
+		if _, _, err := backend.GetSnapshotIfChanged(ctx, keys[0], etag); !errors.Is(err, ErrNotModified) {
+			t.Errorf("%s unchanged snapshot: got %v, want ErrNotModified", name, err)
+		}
+
+		dir := "quay-v3-17/snapshots/quay-v3-17-snap-1/"
+		if suites, err := backend.ListTestSuites(ctx, dir); err != nil || !slices.Equal(suites, []string{"api-tests"}) {
+			t.Errorf("%s test suites: got %q, %v", name, suites, err)
+		}
+		report, err := backend.GetCTRFReport(ctx, dir+"api-tests/results/ctrf-report.json", 0)
+		if err != nil || report.Results.Summary.Failed != 1 {
+			t.Errorf("%s ctrf report: got %+v, %v", name, report, err)
+		}
+		if _, err := backend.GetCTRFReport(ctx, dir+"api-tests/results/ctrf-report.json", 10); err == nil {
+			t.Errorf("%s oversized ctrf report: expected error", name)
+		}
+		junitKeys, err := backend.ListJUnitReports(ctx, dir)
+		if err != nil || len(junitKeys["ui tests"]) != 1 {
+			t.Fatalf("%s junit reports: got %q, %v", name, junitKeys, err)
+		}
+		if report, err := backend.GetJUnitReport(ctx, junitKeys["ui tests"], 0, 1); err != nil || report.Results.Summary.Passed != 1 {
+			t.Errorf("%s junit report: got %+v, %v", name, report, err)
+		}
+		if _, err := backend.GetJUnitReport(ctx, append(junitKeys["ui tests"], junitKeys["ui tests"]...), 0, 1); err == nil {
+			t.Errorf("%s junit report of too many files: expected error", name)
+		}
+
+		if _, err := backend.GetCTRFReport(ctx, dir+"missing.json", 0); err == nil {
+			t.Errorf("%s missing object: expected error", name)
+		}
+		if b, ok := backend.(interface{ Breaker() *breaker.Breaker }); ok && b.Breaker().Status().ConsecutiveFailures != 0 {
+			t.Errorf("%s breaker: got %+v after client errors", name, b.Breaker().Status())
+		}
+	}
+}
@@ -2,22 +2,18 @@ package s3
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
-	"path"
-	"strings"
+	"net/http"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 
-	"github.com/quay/release-readiness/internal/clair"
-	"github.com/quay/release-readiness/internal/ctrf"
-	"github.com/quay/release-readiness/internal/konflux"
-	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/breaker"
 )
 
 // Config holds the settings needed to connect to an S3-compatible store.
@@ -27,13 +23,17 @@ type Config struct {
 	Bucket    string // "quay-release-readiness"
 	AccessKey string
 	SecretKey string
+	Prefix    string // directory the layout is rooted at; empty for the bucket root
+	Source    string // names the breakers of one of several sources; see Source
 }
 
 // Client wraps an S3 client scoped to a single bucket.
 type Client struct {
-	s3     *s3.Client
-	bucket string
-	logger *slog.Logger
+	layout
+	s3      *s3.Client
+	bucket  string
+	logger  *slog.Logger
+	breaker *breaker.Breaker
 }
 
 // New creates an S3 Client from the given Config.
@@ -56,211 +56,116 @@ func New(ctx context.Context, cfg Config, logger *slog.Logger) (*Client, error)
 		})
 	}
 
-	return &Client{
-		s3:     s3.NewFromConfig(awsCfg, opts...),
-		bucket: cfg.Bucket,
-		logger: logger,
-	}, nil
-}
-
-// ListApplications returns the top-level application prefixes in the bucket
-// (e.g. "quay-v3-17", "quay-v3-16").
-func (c *Client) ListApplications(ctx context.Context) ([]string, error) {
-	out, err := c.s3.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-		Bucket:    &c.bucket,
-		Delimiter: aws.String("/"),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("list applications: %w", err)
+	c := &Client{
+		s3:      s3.NewFromConfig(awsCfg, opts...),
+		bucket:  cfg.Bucket,
+		logger:  logger,
+		breaker: breaker.New(breakerName("s3", cfg.Source), breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
 	}
-
-	apps := make([]string, 0, len(out.CommonPrefixes))
-	for _, p := range out.CommonPrefixes {
-		apps = append(apps, strings.TrimSuffix(*p.Prefix, "/"))
-	}
-	return apps, nil
+	c.breaker.SetFailurePredicate(isUnavailable)
+	c.layout = newLayout(c, cfg.Prefix)
+	return c, nil
 }
 
-// ListSnapshots lists snapshot subdirectory names under {application}/snapshots/
-// and returns the S3 key for each snapshot.json file.
-func (c *Client) ListSnapshots(ctx context.Context, application string) ([]string, error) {
-	prefix := application + "/snapshots/"
-	delimiter := "/"
-	paginator := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
-		Bucket:    &c.bucket,
-		Prefix:    &prefix,
-		Delimiter: &delimiter,
-	})
-
-	var keys []string
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("list snapshots: %w", err)
-		}
-		for _, p := range page.CommonPrefixes {
-			// Each prefix is {app}/snapshots/{snapshot-name}/
-			// The snapshot.json is at {app}/snapshots/{snapshot-name}/snapshot.json
-			keys = append(keys, *p.Prefix+"snapshot.json")
-		}
-	}
-	return keys, nil
+// Breaker returns the circuit breaker guarding calls to the bucket.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
 }
 
-// GetSnapshot fetches a Snapshot spec JSON by its full S3 key,
-// parses it, and converts to model.Snapshot. The snapshot name is
-// derived from the S3 directory name.
-func (c *Client) GetSnapshot(ctx context.Context, key string) (*model.Snapshot, error) {
-	data, err := c.getObject(ctx, key)
-	if err != nil {
-		return nil, err
+// isUnavailable reports whether err means the store is unreachable or
+// failing, as opposed to answering with a client error such as NoSuchKey.
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
 	}
-	var spec konflux.SnapshotSpec
-	if err := json.Unmarshal(data, &spec); err != nil {
-		return nil, fmt.Errorf("decode snapshot %s: %w", key, err)
+	var re interface{ HTTPStatusCode() int }
+	if errors.As(err, &re) && re.HTTPStatusCode() < 500 {
+		return false
 	}
-	// Extract snapshot name from S3 key.
-	// key is "{app}/snapshots/{snapshot-name}/snapshot.json"
-	name := path.Base(path.Dir(key))
-	snap := konflux.Convert(spec, name)
-	return &snap, nil
+	return true
 }
 
-// ListTestSuites discovers test suite subdirectories under snapshotDir
-// by looking for keys matching {snapshotDir}{suite}/results/ctrf-report.json.
-// Returns the suite directory names (e.g. "api-tests", "ui-tests").
-func (c *Client) ListTestSuites(ctx context.Context, snapshotDir string) ([]string, error) {
-	paginator := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
+// getObjectOutput fetches key. With ifNoneMatch set, it returns
+// ErrNotModified if the object's ETag still matches.
+func (c *Client) getObjectOutput(ctx context.Context, key, ifNoneMatch string) (*s3.GetObjectOutput, error) {
+	input := &s3.GetObjectInput{
 		Bucket: &c.bucket,
-		Prefix: aws.String(snapshotDir),
-	})
-
-	suffix := "/results/ctrf-report.json"
-	var suites []string
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("list test suites: %w", err)
-		}
-		for _, obj := range page.Contents {
-			key := *obj.Key
-			// Match keys like {snapshotDir}{suite}/results/ctrf-report.json
-			rel := strings.TrimPrefix(key, snapshotDir)
-			if strings.HasSuffix(rel, suffix) {
-				suite := strings.TrimSuffix(rel, suffix)
-				if suite != "" && !strings.Contains(suite, "/") {
-					suites = append(suites, suite)
-				}
-			}
-		}
+		Key:    &key,
 	}
-	return suites, nil
-}
-
-// GetCTRFReport fetches and parses a single CTRF JSON report from S3.
-func (c *Client) GetCTRFReport(ctx context.Context, key string) (*ctrf.Report, error) {
-	data, err := c.getObject(ctx, key)
-	if err != nil {
-		return nil, err
+	if ifNoneMatch != "" {
+		input.IfNoneMatch = aws.String(ifNoneMatch)
 	}
-
-	var report ctrf.Report
-	if err := json.Unmarshal(data, &report); err != nil {
-		return nil, fmt.Errorf("decode ctrf report %s: %w", key, err)
+	var out *s3.GetObjectOutput
+	err := c.breaker.Do(func() error {
+		var err error
+		out, err = c.s3.GetObject(ctx, input)
+		return err
+	})
+	var re interface{ HTTPStatusCode() int }
+	if errors.As(err, &re) && re.HTTPStatusCode() == http.StatusNotModified {
+		return nil, ErrNotModified
 	}
-	return &report, nil
-}
-
-// GetScanSummary fetches and parses the scans/summary.json file from a snapshot directory.
-func (c *Client) GetScanSummary(ctx context.Context, snapshotDir string) ([]clair.ScanSummaryEntry, error) {
-	key := snapshotDir + "scans/summary.json"
-	data, err := c.getObject(ctx, key)
 	if err != nil {
-		return nil, err
-	}
-	var entries []clair.ScanSummaryEntry
-	if err := json.Unmarshal(data, &entries); err != nil {
-		return nil, fmt.Errorf("decode scan summary %s: %w", key, err)
+		return nil, fmt.Errorf("get %s: %w", key, err)
 	}
-	return entries, nil
+	return out, nil
 }
 
-// ListClairReports returns the architecture suffixes for clair reports under
-// {snapshotDir}scans/{component}/ by matching clair-report-*.json keys.
-func (c *Client) ListClairReports(ctx context.Context, snapshotDir, component string) ([]string, error) {
-	prefix := snapshotDir + "scans/" + component + "/clair-report-"
-	paginator := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
-		Bucket: &c.bucket,
-		Prefix: aws.String(prefix),
-	})
-
-	var keys []string
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("list clair reports: %w", err)
-		}
-		for _, obj := range page.Contents {
-			keys = append(keys, *obj.Key)
-		}
+func (c *Client) getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	out, err := c.getObjectOutput(ctx, key, "")
+	if err != nil {
+		return nil, 0, err
 	}
-	return keys, nil
+	return out.Body, aws.ToInt64(out.ContentLength), nil
 }
 
-// GetClairReport fetches and parses a single Clair vulnerability report from S3.
-func (c *Client) GetClairReport(ctx context.Context, key string) (*clair.Report, error) {
-	data, err := c.getObject(ctx, key)
-	if err != nil {
-		return nil, err
+func (c *Client) listObjects(ctx context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	input := &s3.ListObjectsV2Input{Bucket: &c.bucket}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
 	}
-	var report clair.Report
-	if err := json.Unmarshal(data, &report); err != nil {
-		return nil, fmt.Errorf("decode clair report %s: %w", key, err)
+	if delimiter != "" {
+		input.Delimiter = aws.String(delimiter)
 	}
-	return &report, nil
-}
-
-// ListObjects returns all object keys under the given prefix.
-func (c *Client) ListObjects(ctx context.Context, prefix string) ([]string, error) {
-	paginator := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
-		Bucket: &c.bucket,
-		Prefix: aws.String(prefix),
-	})
-
-	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(c.s3, input)
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
+		var page *s3.ListObjectsV2Output
+		err := c.breaker.Do(func() error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
 		if err != nil {
-			return nil, fmt.Errorf("list objects: %w", err)
+			return nil, nil, err
 		}
 		for _, obj := range page.Contents {
 			keys = append(keys, *obj.Key)
 		}
+		for _, p := range page.CommonPrefixes {
+			prefixes = append(prefixes, *p.Prefix)
+		}
 	}
-	return keys, nil
+	return keys, prefixes, nil
 }
 
-// GetObjectStream returns a reader for the given S3 key along with the content length.
-// The caller must close the returned ReadCloser.
-func (c *Client) GetObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
-	out, err := c.s3.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &c.bucket,
-		Key:    &key,
-	})
+func (c *Client) getObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	out, err := c.getObjectOutput(ctx, key, "")
 	if err != nil {
-		return nil, 0, fmt.Errorf("get %s: %w", key, err)
+		return nil, err
 	}
-	return out.Body, aws.ToInt64(out.ContentLength), nil
+	defer func() { _ = out.Body.Close() }()
+	return readObject(key, out.Body, aws.ToInt64(out.ContentLength), maxBytes)
 }
 
-func (c *Client) getObject(ctx context.Context, key string) ([]byte, error) {
-	out, err := c.s3.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &c.bucket,
-		Key:    &key,
-	})
+func (c *Client) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	out, err := c.getObjectOutput(ctx, key, etag)
 	if err != nil {
-		return nil, fmt.Errorf("get %s: %w", key, err)
+		return nil, "", err
 	}
 	defer func() { _ = out.Body.Close() }()
-	return io.ReadAll(out.Body)
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, aws.ToString(out.ETag), nil
 }
@@ -0,0 +1,147 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DirStore is an ObjectStore reading a local directory laid out like the
+// S3 bucket, with each file's slash-separated path as its key. It lets
+// pipeline output be checked and ingested without uploading it.
+type DirStore struct {
+	layout
+	fsys fs.FS
+}
+
+// NewDirStore returns a DirStore reading the directory root.
+func NewDirStore(root string) *DirStore {
+	d := &DirStore{fsys: os.DirFS(root)}
+	d.layout = layout{raw: d}
+	return d
+}
+
+// keys returns the paths of the regular files in the directory.
+func (d *DirStore) keys() ([]string, error) {
+	var keys []string
+	err := fs.WalkDir(d.fsys, ".", func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if e.Type().IsRegular() {
+			keys = append(keys, p)
+		}
+		return nil
+	})
+	return keys, err
+}
+
+func (d *DirStore) listObjects(_ context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	all, err := d.keys()
+	if err != nil {
+		return nil, nil, err
+	}
+	keys, prefixes = listKeys(slices.Values(all), prefix, delimiter)
+	return keys, prefixes, nil
+}
+
+func (d *DirStore) getObject(_ context.Context, key string, maxBytes int64) ([]byte, error) {
+	if maxBytes > 0 {
+		info, err := fs.Stat(d.fsys, key)
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() > maxBytes {
+			return nil, fmt.Errorf("get %s: object is %d bytes, limit is %d", key, info.Size(), maxBytes)
+		}
+	}
+	return fs.ReadFile(d.fsys, key)
+}
+
+func (d *DirStore) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	data, err := d.getObject(ctx, key, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	current := contentETag(data)
+	if etag == current {
+		return nil, "", ErrNotModified
+	}
+	return data, current, nil
+}
+
+func (d *DirStore) getObjectStream(_ context.Context, key string) (io.ReadCloser, int64, error) {
+	f, err := d.fsys.Open(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// Check reports the files of the directory that the syncer would ignore
+// because they do not fit the bucket layout, and the snapshot directories
+// without a snapshot.json. Scenario directories may hold anything besides
+// their CTRF report, as their files are served as artifacts.
+func (d *DirStore) Check() ([]string, error) {
+	keys, err := d.keys()
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	snapshots := make(map[string]bool) // snapshot directory to whether it has a snapshot.json
+	for _, key := range keys {
+		parts := strings.Split(key, "/")
+		switch {
+		case len(parts) == 3 && parts[1] == "releases" && path.Ext(key) == ".json":
+		case len(parts) >= 4 && parts[1] == "snapshots":
+			dir := strings.Join(parts[:3], "/")
+			if rest := parts[3:]; len(rest) == 1 && rest[0] == "snapshot.json" {
+				snapshots[dir] = true
+			} else {
+				if _, ok := snapshots[dir]; !ok {
+					snapshots[dir] = false
+				}
+				if !snapshotFile(rest) {
+					problems = append(problems, key+": not part of the bucket layout")
+				}
+			}
+		default:
+			problems = append(problems, key+": not part of the bucket layout")
+		}
+	}
+	for _, dir := range slices.Sorted(maps.Keys(snapshots)) {
+		if !snapshots[dir] {
+			problems = append(problems, dir+": no snapshot.json")
+		}
+	}
+	return problems, nil
+}
+
+// snapshotFile reports whether rest, the path of a file within a snapshot
+// directory split at slashes, fits the bucket layout.
+func snapshotFile(rest []string) bool {
+	switch rest[0] {
+	case "junit":
+		return len(rest) == 3 && path.Ext(rest[2]) == ".xml"
+	case "ec":
+		return len(rest) == 2 && rest[1] == "ec-report.json"
+	case "sbom":
+		return len(rest) == 2 && path.Ext(rest[1]) == ".json"
+	case "scans":
+		return (len(rest) == 2 && rest[1] == "summary.json") ||
+			(len(rest) == 3 && strings.HasPrefix(rest[2], "clair-report-") && path.Ext(rest[2]) == ".json")
+	}
+	// Anything in a scenario directory.
+	return len(rest) >= 2
+}
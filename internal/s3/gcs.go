@@ -0,0 +1,224 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// gcsMetadataTokenURL serves access tokens for the service account of a
+// GCE VM or GKE workload.
+const gcsMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GCSConfig holds the settings needed to read a Google Cloud Storage
+// bucket.
+type GCSConfig struct {
+	Endpoint string // custom endpoint URL (e.g. a fake-gcs-server); defaults to https://storage.googleapis.com
+	Bucket   string
+	// Token is an OAuth2 access token. If empty, tokens are fetched from
+	// the GCE metadata server, unless Endpoint is set, in which case
+	// requests are not authenticated.
+	Token  string
+	Prefix string // directory the layout is rooted at; empty for the bucket root
+	Source string // names the breaker of one of several sources; see Source
+}
+
+// GCSClient reads a Google Cloud Storage bucket laid out like the S3 one,
+// through the JSON API.
+type GCSClient struct {
+	layout
+	endpoint   string
+	bucket     string
+	token      string
+	metadata   bool
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+
+	mu       sync.Mutex
+	cached   string
+	cachedTo time.Time
+}
+
+// NewGCS creates a GCSClient from the given GCSConfig.
+func NewGCS(cfg GCSConfig) (*GCSClient, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("gcs: bucket is required")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	c := &GCSClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		bucket:     cfg.Bucket,
+		token:      cfg.Token,
+		metadata:   cfg.Token == "" && cfg.Endpoint == "",
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+		breaker:    breaker.New(breakerName("gcs", cfg.Source), breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(isUnavailable)
+	c.layout = newLayout(c, cfg.Prefix)
+	return c, nil
+}
+
+// Breaker returns the circuit breaker guarding calls to the bucket.
+func (c *GCSClient) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+func (c *GCSClient) getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	resp, err := c.getObjectResponse(ctx, key, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+func (c *GCSClient) listObjects(ctx context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	q := url.Values{"fields": {"items(name),prefixes,nextPageToken"}}
+	if prefix != "" {
+		q.Set("prefix", prefix)
+	}
+	if delimiter != "" {
+		q.Set("delimiter", delimiter)
+	}
+	for {
+		var page struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			Prefixes      []string `json:"prefixes"`
+			NextPageToken string   `json:"nextPageToken"`
+		}
+		u := c.endpoint + "/storage/v1/b/" + url.PathEscape(c.bucket) + "/o?" + q.Encode()
+		err := c.breaker.Do(func() error {
+			resp, err := c.do(ctx, u)
+			if err != nil {
+				return fmt.Errorf("list %s: %w", prefix, err)
+			}
+			if err := checkResponse("list "+prefix, resp); err != nil {
+				return err
+			}
+			defer func() { _ = resp.Body.Close() }()
+			return json.NewDecoder(resp.Body).Decode(&page)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, item := range page.Items {
+			keys = append(keys, item.Name)
+		}
+		prefixes = append(prefixes, page.Prefixes...)
+		if page.NextPageToken == "" {
+			return keys, prefixes, nil
+		}
+		q.Set("pageToken", page.NextPageToken)
+	}
+}
+
+func (c *GCSClient) getObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	resp, err := c.getObjectResponse(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return readObject(key, resp.Body, resp.ContentLength, maxBytes)
+}
+
+// getObjectIfNoneMatch uses the object's generation as its ETag: GCS
+// answers conditional reads on the generation, not on the HTTP ETag.
+func (c *GCSClient) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	resp, err := c.getObjectResponse(ctx, key, etag)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("X-Goog-Generation"), nil
+}
+
+// getObjectResponse downloads key. With generation set, it returns
+// ErrNotModified if the object still has that generation.
+func (c *GCSClient) getObjectResponse(ctx context.Context, key, generation string) (*http.Response, error) {
+	q := url.Values{"alt": {"media"}}
+	if generation != "" {
+		q.Set("ifGenerationNotMatch", generation)
+	}
+	u := c.endpoint + "/storage/v1/b/" + url.PathEscape(c.bucket) + "/o/" + url.PathEscape(key) + "?" + q.Encode()
+	var resp *http.Response
+	err := c.breaker.Do(func() error {
+		var err error
+		resp, err = c.do(ctx, u)
+		if err != nil {
+			return fmt.Errorf("get %s: %w", key, err)
+		}
+		return checkResponse("get "+key, resp)
+	})
+	if err != nil {
+		return nil, notModified(err)
+	}
+	return resp, nil
+}
+
+// do sends an authenticated GET request for u.
+func (c *GCSClient) do(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.httpClient.Do(req)
+}
+
+// accessToken returns the configured token, or a metadata server token
+// cached until shortly before it expires.
+func (c *GCSClient) accessToken(ctx context.Context) (string, error) {
+	if !c.metadata {
+		return c.token, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached != "" && time.Now().Before(c.cachedTo) {
+		return c.cached, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcsMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gcs metadata token: %w", err)
+	}
+	if err := checkResponse("gcs metadata token", resp); err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("gcs metadata token: %w", err)
+	}
+	c.cached = tok.AccessToken
+	c.cachedTo = time.Now().Add(time.Duration(tok.ExpiresIn)*time.Second - time.Minute)
+	return c.cached, nil
+}
@@ -0,0 +1,375 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/clair"
+	"github.com/quay/release-readiness/internal/ctrf"
+	"github.com/quay/release-readiness/internal/junit"
+	"github.com/quay/release-readiness/internal/konflux"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/sbom"
+)
+
+// ObjectStore is the set of bucket operations used by the syncer and the
+// HTTP server. Client implements it against S3; MemoryStore is an in-memory
+// implementation for tests and local development.
+type ObjectStore interface {
+	ListApplications(ctx context.Context) ([]string, error)
+	ListSnapshots(ctx context.Context, application string) ([]string, error)
+	GetSnapshotIfChanged(ctx context.Context, key, etag string) (*model.Snapshot, string, error)
+	ListReleases(ctx context.Context, application string) ([]string, error)
+	GetReleaseIfChanged(ctx context.Context, key, etag string) (*model.SnapshotRelease, string, error)
+	ListTestSuites(ctx context.Context, snapshotDir string) ([]string, error)
+	GetCTRFReport(ctx context.Context, key string, maxBytes int64) (*ctrf.Report, error)
+	ListJUnitReports(ctx context.Context, snapshotDir string) (map[string][]string, error)
+	GetJUnitReport(ctx context.Context, keys []string, maxBytes int64, maxFiles int) (*ctrf.Report, error)
+	GetECReport(ctx context.Context, snapshotDir string, maxBytes int64) ([]model.ECResult, error)
+	GetScanSummary(ctx context.Context, snapshotDir string) ([]clair.ScanSummaryEntry, error)
+	GetSBOM(ctx context.Context, snapshotDir, component string, maxBytes int64) (*model.ComponentSBOM, error)
+	ListClairReports(ctx context.Context, snapshotDir, component string) ([]string, error)
+	GetClairReport(ctx context.Context, key string) (*clair.Report, error)
+	ListObjects(ctx context.Context, prefix string) ([]string, error)
+	GetObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error)
+}
+
+// rawBucket is the raw object access the bucket layout is built on.
+type rawBucket interface {
+	// listObjects returns keys under prefix and, when delimiter is non-empty,
+	// the common prefixes rolled up at the delimiter.
+	listObjects(ctx context.Context, prefix, delimiter string) (keys, prefixes []string, err error)
+	// getObject reads an object fully, failing if it is larger than maxBytes.
+	// A maxBytes of zero means no limit.
+	getObject(ctx context.Context, key string, maxBytes int64) ([]byte, error)
+	// getObjectIfNoneMatch reads an object fully along with its ETag. It
+	// returns ErrNotModified if etag is non-empty and still matches.
+	getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error)
+	// getObjectStream returns a reader for an object along with its length.
+	getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error)
+}
+
+// ErrNotModified is returned by conditional reads when the object still
+// has the ETag the caller already saw.
+var ErrNotModified = errors.New("not modified")
+
+// layout implements the dashboard's bucket layout (see README) on top of a
+// rawBucket. It is embedded by each ObjectStore implementation.
+type layout struct {
+	raw rawBucket
+}
+
+// newLayout returns the layout of raw, rooted at prefix if it is not empty.
+func newLayout(raw rawBucket, prefix string) layout {
+	if prefix = normalizePrefix(prefix); prefix != "" {
+		raw = prefixed{raw: raw, prefix: prefix}
+	}
+	return layout{raw: raw}
+}
+
+// normalizePrefix returns prefix as a directory: without a leading slash
+// and with a trailing one, or empty.
+func normalizePrefix(prefix string) string {
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// prefixed roots a rawBucket at a directory, so that a bucket can hold the
+// layout of several products side by side. Keys are relative to prefix on
+// the way in and out.
+type prefixed struct {
+	raw    rawBucket
+	prefix string
+}
+
+func (p prefixed) listObjects(ctx context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	keys, prefixes, err = p.raw.listObjects(ctx, p.prefix+prefix, delimiter)
+	for i := range keys {
+		keys[i] = strings.TrimPrefix(keys[i], p.prefix)
+	}
+	for i := range prefixes {
+		prefixes[i] = strings.TrimPrefix(prefixes[i], p.prefix)
+	}
+	return keys, prefixes, err
+}
+
+func (p prefixed) getObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	return p.raw.getObject(ctx, p.prefix+key, maxBytes)
+}
+
+func (p prefixed) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	return p.raw.getObjectIfNoneMatch(ctx, p.prefix+key, etag)
+}
+
+func (p prefixed) getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	return p.raw.getObjectStream(ctx, p.prefix+key)
+}
+
+// ListApplications returns the top-level application prefixes in the bucket
+// (e.g. "quay-v3-17", "quay-v3-16").
+func (l layout) ListApplications(ctx context.Context) ([]string, error) {
+	_, prefixes, err := l.raw.listObjects(ctx, "", "/")
+	if err != nil {
+		return nil, fmt.Errorf("list applications: %w", err)
+	}
+	apps := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		apps = append(apps, strings.TrimSuffix(p, "/"))
+	}
+	return apps, nil
+}
+
+// ListSnapshots lists snapshot subdirectory names under {application}/snapshots/
+// and returns the S3 key for each snapshot.json file.
+func (l layout) ListSnapshots(ctx context.Context, application string) ([]string, error) {
+	_, prefixes, err := l.raw.listObjects(ctx, application+"/snapshots/", "/")
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+	keys := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		// Each prefix is {app}/snapshots/{snapshot-name}/
+		// The snapshot.json is at {app}/snapshots/{snapshot-name}/snapshot.json
+		keys = append(keys, p+"snapshot.json")
+	}
+	return keys, nil
+}
+
+// GetSnapshotIfChanged fetches a Snapshot spec JSON by its full S3 key,
+// parses it, and converts to model.Snapshot along with the object's ETag.
+// The snapshot name is derived from the S3 directory name. If etag is set
+// and the object still has it, nothing is downloaded and ErrNotModified is
+// returned.
+func (l layout) GetSnapshotIfChanged(ctx context.Context, key, etag string) (*model.Snapshot, string, error) {
+	data, current, err := l.raw.getObjectIfNoneMatch(ctx, key, etag)
+	if err != nil {
+		return nil, "", err
+	}
+	snap, err := decodeSnapshot(key, data)
+	if err != nil {
+		return nil, "", err
+	}
+	return snap, current, nil
+}
+
+func decodeSnapshot(key string, data []byte) (*model.Snapshot, error) {
+	var spec konflux.SnapshotSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("decode snapshot %s: %w", key, err)
+	}
+	// Extract snapshot name from S3 key.
+	// key is "{app}/snapshots/{snapshot-name}/snapshot.json"
+	name := path.Base(path.Dir(key))
+	snap := konflux.Convert(spec, name)
+	return &snap, nil
+}
+
+// ListReleases returns the keys of the Konflux Release CRs exported under
+// {application}/releases/, one {release-name}.json per Release.
+func (l layout) ListReleases(ctx context.Context, application string) ([]string, error) {
+	keys, _, err := l.raw.listObjects(ctx, application+"/releases/", "/")
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+	var releases []string
+	for _, key := range keys {
+		if path.Ext(key) == ".json" {
+			releases = append(releases, key)
+		}
+	}
+	return releases, nil
+}
+
+// GetReleaseIfChanged fetches a Konflux Release CR by its full key and
+// converts it to a model.SnapshotRelease, along with the object's ETag. If
+// etag is set and the object still has it, nothing is downloaded and
+// ErrNotModified is returned.
+func (l layout) GetReleaseIfChanged(ctx context.Context, key, etag string) (*model.SnapshotRelease, string, error) {
+	data, current, err := l.raw.getObjectIfNoneMatch(ctx, key, etag)
+	if err != nil {
+		return nil, "", err
+	}
+	var cr konflux.Release
+	if err := json.Unmarshal(data, &cr); err != nil {
+		return nil, "", fmt.Errorf("decode release %s: %w", key, err)
+	}
+	if cr.Metadata.Name == "" || cr.Spec.Snapshot == "" {
+		return nil, "", fmt.Errorf("decode release %s: metadata.name and spec.snapshot are required", key)
+	}
+	rel := konflux.ConvertRelease(cr)
+	return &rel, current, nil
+}
+
+// ListTestSuites discovers test suite subdirectories under snapshotDir
+// by looking for keys matching {snapshotDir}{suite}/results/ctrf-report.json.
+// Returns the suite directory names (e.g. "api-tests", "ui-tests").
+func (l layout) ListTestSuites(ctx context.Context, snapshotDir string) ([]string, error) {
+	keys, _, err := l.raw.listObjects(ctx, snapshotDir, "")
+	if err != nil {
+		return nil, fmt.Errorf("list test suites: %w", err)
+	}
+
+	suffix := "/results/ctrf-report.json"
+	var suites []string
+	for _, key := range keys {
+		// Match keys like {snapshotDir}{suite}/results/ctrf-report.json
+		rel := strings.TrimPrefix(key, snapshotDir)
+		if strings.HasSuffix(rel, suffix) {
+			suite := strings.TrimSuffix(rel, suffix)
+			if suite != "" && !strings.Contains(suite, "/") {
+				suites = append(suites, suite)
+			}
+		}
+	}
+	return suites, nil
+}
+
+// GetCTRFReport fetches and parses a single CTRF JSON report from S3.
+// Reports larger than maxBytes are rejected without being decoded;
+// a maxBytes of zero disables the check.
+func (l layout) GetCTRFReport(ctx context.Context, key string, maxBytes int64) (*ctrf.Report, error) {
+	data, err := l.raw.getObject(ctx, key, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var report ctrf.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("decode ctrf report %s: %w", key, err)
+	}
+	return &report, nil
+}
+
+// ListJUnitReports discovers JUnit XML files under
+// {snapshotDir}junit/{scenario}/ and returns their keys grouped by scenario
+// directory name.
+func (l layout) ListJUnitReports(ctx context.Context, snapshotDir string) (map[string][]string, error) {
+	prefix := snapshotDir + "junit/"
+	keys, _, err := l.raw.listObjects(ctx, prefix, "")
+	if err != nil {
+		return nil, fmt.Errorf("list junit reports: %w", err)
+	}
+	reports := map[string][]string{}
+	for _, key := range keys {
+		// Match keys like {snapshotDir}junit/{scenario}/{file}.xml
+		scenario, file, ok := strings.Cut(strings.TrimPrefix(key, prefix), "/")
+		if ok && scenario != "" && path.Ext(file) == ".xml" && !strings.Contains(file, "/") {
+			reports[scenario] = append(reports[scenario], key)
+		}
+	}
+	return reports, nil
+}
+
+// GetJUnitReport fetches the JUnit XML files of one scenario and merges
+// them into a single CTRF report. Files larger than maxBytes are rejected
+// without being decoded, and scenarios of more than maxFiles files without
+// fetching any; a zero limit disables its check.
+func (l layout) GetJUnitReport(ctx context.Context, keys []string, maxBytes int64, maxFiles int) (*ctrf.Report, error) {
+	if maxFiles > 0 && len(keys) > maxFiles {
+		return nil, fmt.Errorf("junit report has %d files, limit is %d", len(keys), maxFiles)
+	}
+	report := &ctrf.Report{Results: ctrf.Results{Tool: ctrf.Tool{Name: "junit"}}}
+	for _, key := range keys {
+		data, err := l.raw.getObject(ctx, key, maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		suites, err := junit.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode junit report %s: %w", key, err)
+		}
+		suites.Append(report)
+	}
+	return report, nil
+}
+
+// GetECReport fetches the Enterprise Contract verification output written
+// to {snapshotDir}ec/ec-report.json, next to the JUnit results, and
+// converts it to per-component results. Reports larger than maxBytes are
+// rejected without being decoded; a maxBytes of zero disables the check.
+func (l layout) GetECReport(ctx context.Context, snapshotDir string, maxBytes int64) ([]model.ECResult, error) {
+	key := snapshotDir + "ec/ec-report.json"
+	data, err := l.raw.getObject(ctx, key, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var report konflux.ECReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("decode ec report %s: %w", key, err)
+	}
+	return konflux.ConvertECReport(report), nil
+}
+
+// GetSBOM fetches and summarises the SPDX or CycloneDX document uploaded
+// for component at {snapshotDir}sbom/{component}.json.
+func (l layout) GetSBOM(ctx context.Context, snapshotDir, component string, maxBytes int64) (*model.ComponentSBOM, error) {
+	key := snapshotDir + "sbom/" + component + ".json"
+	data, err := l.raw.getObject(ctx, key, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sbom.Summarize(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return s, nil
+}
+
+// GetScanSummary fetches and parses the scans/summary.json file from a snapshot directory.
+func (l layout) GetScanSummary(ctx context.Context, snapshotDir string) ([]clair.ScanSummaryEntry, error) {
+	key := snapshotDir + "scans/summary.json"
+	data, err := l.raw.getObject(ctx, key, 0)
+	if err != nil {
+		return nil, err
+	}
+	var entries []clair.ScanSummaryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("decode scan summary %s: %w", key, err)
+	}
+	return entries, nil
+}
+
+// ListClairReports returns the architecture suffixes for clair reports under
+// {snapshotDir}scans/{component}/ by matching clair-report-*.json keys.
+func (l layout) ListClairReports(ctx context.Context, snapshotDir, component string) ([]string, error) {
+	keys, _, err := l.raw.listObjects(ctx, snapshotDir+"scans/"+component+"/clair-report-", "")
+	if err != nil {
+		return nil, fmt.Errorf("list clair reports: %w", err)
+	}
+	return keys, nil
+}
+
+// GetClairReport fetches and parses a single Clair vulnerability report from S3.
+func (l layout) GetClairReport(ctx context.Context, key string) (*clair.Report, error) {
+	data, err := l.raw.getObject(ctx, key, 0)
+	if err != nil {
+		return nil, err
+	}
+	var report clair.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("decode clair report %s: %w", key, err)
+	}
+	return &report, nil
+}
+
+// GetObjectStream returns a reader for the given key along with the content
+// length. The caller must close the returned ReadCloser.
+func (l layout) GetObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	return l.raw.getObjectStream(ctx, key)
+}
+
+// ListObjects returns all object keys under the given prefix.
+func (l layout) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	keys, _, err := l.raw.listObjects(ctx, prefix, "")
+	if err != nil {
+		return nil, fmt.Errorf("list objects: %w", err)
+	}
+	return keys, nil
+}
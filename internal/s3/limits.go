@@ -0,0 +1,72 @@
+package s3
+
+import (
+	"strings"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+)
+
+// Limits caps how much of a single scenario's test report is retained at
+// ingest, protecting the syncer (and the database) from pathological reports.
+// A zero value for any field disables that limit.
+type Limits struct {
+	MaxReportBytes  int64 // reports larger than this are rejected
+	MaxReportFiles  int   // scenarios with more JUnit files than this are rejected
+	MaxCases        int   // test cases retained per scenario
+	MaxMessageBytes int   // failure message and trace are cut to this length
+}
+
+// DefaultLimits are the limits used when none are configured.
+var DefaultLimits = Limits{
+	MaxReportBytes:  32 << 20,
+	MaxReportFiles:  500,
+	MaxCases:        5000,
+	MaxMessageBytes: 16 << 10,
+}
+
+// applyLimits trims the test cases of report in place according to l and
+// reports whether anything was dropped or shortened. Non-passing cases are
+// retained in preference to passing ones so failures are never hidden by a
+// large number of successes.
+func applyLimits(report *ctrf.Report, l Limits) bool {
+	truncated := false
+	tests := report.Results.Tests
+
+	if l.MaxCases > 0 && len(tests) > l.MaxCases {
+		kept := make([]ctrf.Test, 0, l.MaxCases)
+		for _, tc := range tests {
+			if tc.Status != "passed" && len(kept) < l.MaxCases {
+				kept = append(kept, tc)
+			}
+		}
+		for _, tc := range tests {
+			if tc.Status == "passed" && len(kept) < l.MaxCases {
+				kept = append(kept, tc)
+			}
+		}
+		tests = kept
+		truncated = true
+	}
+
+	if l.MaxMessageBytes > 0 {
+		for i := range tests {
+			var cut bool
+			tests[i].Message, cut = truncateText(tests[i].Message, l.MaxMessageBytes)
+			truncated = truncated || cut
+			tests[i].Trace, cut = truncateText(tests[i].Trace, l.MaxMessageBytes)
+			truncated = truncated || cut
+		}
+	}
+
+	report.Results.Tests = tests
+	return truncated
+}
+
+// truncateText shortens s to at most n bytes, dropping any UTF-8 sequence
+// split by the cut.
+func truncateText(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	return strings.ToValidUTF8(s[:n], ""), true
+}
@@ -0,0 +1,57 @@
+package s3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+)
+
+func TestApplyLimits(t *testing.T) {
+	report := &ctrf.Report{}
+	for i := 0; i < 5; i++ {
+		report.Results.Tests = append(report.Results.Tests, ctrf.Test{Name: "pass", Status: "passed"})
+	}
+	report.Results.Tests = append(report.Results.Tests, ctrf.Test{
+		Name:    "fail",
+		Status:  "failed",
+		Message: strings.Repeat("x", 100),
+	})
+
+	truncated := applyLimits(report, Limits{MaxCases: 3, MaxMessageBytes: 10})
+	if !truncated {
+		t.Fatal("truncated: got false, want true")
+	}
+	tests := report.Results.Tests
+	if len(tests) != 3 {
+		t.Fatalf("cases: got %d, want 3", len(tests))
+	}
+	if tests[0].Status != "failed" {
+		t.Errorf("first case: got %q, want failed case retained first", tests[0].Status)
+	}
+	if len(tests[0].Message) != 10 {
+		t.Errorf("message length: got %d, want 10", len(tests[0].Message))
+	}
+}
+
+func TestApplyLimitsWithinBounds(t *testing.T) {
+	report := &ctrf.Report{}
+	report.Results.Tests = []ctrf.Test{{Name: "a", Status: "failed", Message: "boom"}}
+
+	if applyLimits(report, DefaultLimits) {
+		t.Error("truncated: got true, want false")
+	}
+	if report.Results.Tests[0].Message != "boom" {
+		t.Errorf("message: got %q, want boom", report.Results.Tests[0].Message)
+	}
+}
+
+func TestTruncateTextUTF8(t *testing.T) {
+	got, cut := truncateText("héllo", 2)
+	if !cut {
+		t.Fatal("cut: got false, want true")
+	}
+	if got != "h" {
+		t.Errorf("got %q, want %q", got, "h")
+	}
+}
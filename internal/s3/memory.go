@@ -0,0 +1,128 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an in-memory ObjectStore. It follows the same key layout as
+// the S3 bucket so tests can exercise the syncer and artifact downloads
+// without an S3-compatible server.
+type MemoryStore struct {
+	layout
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	m := &MemoryStore{objects: map[string][]byte{}}
+	m.layout = layout{raw: m}
+	return m
+}
+
+// Put stores data under key, replacing any existing object.
+func (m *MemoryStore) Put(key string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = slices.Clone(data)
+}
+
+// PutJSON stores the JSON encoding of v under key.
+func (m *MemoryStore) PutJSON(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	m.Put(key, data)
+	return nil
+}
+
+// Delete removes the object stored under key, if any.
+func (m *MemoryStore) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+}
+
+func (m *MemoryStore) getObjectStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	data, err := m.getObject(ctx, key, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func (m *MemoryStore) listObjects(_ context.Context, prefix, delimiter string) (keys, prefixes []string, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys, prefixes = listKeys(maps.Keys(m.objects), prefix, delimiter)
+	return keys, prefixes, nil
+}
+
+// listKeys returns the keys of all under prefix and, when delimiter is
+// non-empty, the common prefixes rolled up at the delimiter, both sorted.
+func listKeys(all iter.Seq[string], prefix, delimiter string) (keys, prefixes []string) {
+	for key := range all {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(rest, delimiter); i >= 0 {
+				p := prefix + rest[:i+len(delimiter)]
+				if !slices.Contains(prefixes, p) {
+					prefixes = append(prefixes, p)
+				}
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	slices.Sort(prefixes)
+	return keys, prefixes
+}
+
+func (m *MemoryStore) getObject(_ context.Context, key string, maxBytes int64) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("get %s: no such key", key)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("get %s: object is %d bytes, limit is %d", key, len(data), maxBytes)
+	}
+	return slices.Clone(data), nil
+}
+
+// getObjectIfNoneMatch serves ETags the way S3 does for single-part
+// uploads: the quoted hex MD5 of the content.
+func (m *MemoryStore) getObjectIfNoneMatch(ctx context.Context, key, etag string) ([]byte, string, error) {
+	data, err := m.getObject(ctx, key, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	current := contentETag(data)
+	if etag == current {
+		return nil, "", ErrNotModified
+	}
+	return data, current, nil
+}
+
+// contentETag returns the ETag S3 gives a single-part upload of data.
+func contentETag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
@@ -0,0 +1,119 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// queueRetryDelay is how long Consume waits after a failed receive.
+const queueRetryDelay = 10 * time.Second
+
+// Consume ingests snapshots as soon as their snapshot.json is written, from
+// the S3 event notifications delivered to queue, until ctx is cancelled.
+// It complements Run rather than replacing it: anything the consumer misses
+// or fails to ingest is picked up by the next poll.
+func (s *Syncer) Consume(ctx context.Context, queue Queue) {
+	for {
+		if err := s.ConsumeOnce(ctx, queue); err != nil && ctx.Err() == nil {
+			if errors.Is(err, breaker.ErrOpen) {
+				s.logger.WarnContext(ctx, "skipping queue receive, upstream unavailable", "error", err)
+			} else {
+				s.logger.ErrorContext(ctx, "receive queue messages", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(queueRetryDelay):
+			}
+		}
+		if ctx.Err() != nil {
+			s.logger.InfoContext(ctx, "stopping queue consumer")
+			return
+		}
+	}
+}
+
+// ConsumeOnce receives one batch of messages from queue and ingests the
+// snapshots they announce. Every received message is deleted once handled,
+// including ones that announce nothing or fail to ingest.
+func (s *Syncer) ConsumeOnce(ctx context.Context, queue Queue) error {
+	ctx = requestid.Ensure(ctx)
+	msgs, err := queue.Receive(ctx)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		keys, err := snapshotKeys(msg.Body, s.prefix)
+		if err != nil {
+			s.logger.WarnContext(ctx, "ignoring unrecognised queue message", "error", err)
+		}
+		for _, key := range keys {
+			s.logger.DebugContext(ctx, "snapshot notification", "key", key)
+			_, _ = s.syncSnapshot(ctx, key, "")
+		}
+		if err := queue.Delete(ctx, msg.ReceiptHandle); err != nil {
+			s.logger.ErrorContext(ctx, "delete queue message", "error", err)
+		}
+	}
+	return nil
+}
+
+// s3Notification is an S3 event notification, or an SNS notification
+// carrying one in Message when the bucket publishes through a topic.
+type s3Notification struct {
+	Records []struct {
+		EventName string `json:"eventName"`
+		S3        struct {
+			Object struct {
+				Key string `json:"key"`
+			} `json:"object"`
+		} `json:"s3"`
+	} `json:"Records"`
+
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// snapshotKeys returns the snapshot.json keys created under prefix
+// according to an S3 event notification body, relative to prefix. Other
+// objects, other event types, and S3's test event yield no keys.
+func snapshotKeys(body, prefix string) ([]string, error) {
+	var n s3Notification
+	if err := json.Unmarshal([]byte(body), &n); err != nil {
+		return nil, fmt.Errorf("decode notification: %w", err)
+	}
+	if n.Type == "Notification" {
+		return snapshotKeys(n.Message, prefix)
+	}
+
+	var keys []string
+	for _, r := range n.Records {
+		if !strings.HasPrefix(r.EventName, "ObjectCreated:") {
+			continue
+		}
+		// Keys in event notifications are URL-encoded, with spaces as '+'.
+		key, err := url.QueryUnescape(r.S3.Object.Key)
+		if err != nil {
+			return keys, fmt.Errorf("decode key %q: %w", r.S3.Object.Key, err)
+		}
+		key, ok := strings.CutPrefix(key, prefix)
+		if ok && isSnapshotKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// isSnapshotKey reports whether key is a {app}/snapshots/{name}/snapshot.json
+// key, as returned by ListSnapshots.
+func isSnapshotKey(key string) bool {
+	parts := strings.Split(key, "/")
+	return len(parts) == 4 && parts[0] != "" && parts[1] == "snapshots" && parts[2] != "" && parts[3] == "snapshot.json"
+}
@@ -0,0 +1,183 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+)
+
+func s3Event(eventName string, keys ...string) string {
+	type record struct {
+		EventName string         `json:"eventName"`
+		S3        map[string]any `json:"s3"`
+	}
+	var records []record
+	for _, key := range keys {
+		records = append(records, record{EventName: eventName, S3: map[string]any{
+			"bucket": map[string]string{"name": "quay-release-readiness"},
+			"object": map[string]string{"key": key},
+		}})
+	}
+	b, _ := json.Marshal(map[string]any{"Records": records})
+	return string(b)
+}
+
+func TestSnapshotKeys(t *testing.T) {
+	sns, _ := json.Marshal(map[string]string{
+		"Type":    "Notification",
+		"Message": s3Event("ObjectCreated:Put", "quay-v3-17/snapshots/snap-2/snapshot.json"),
+	})
+	for _, tc := range []struct {
+		name, body, prefix string
+		want               []string
+	}{
+		{"put", s3Event("ObjectCreated:Put",
+			"quay-v3-17/snapshots/snap-1/snapshot.json",
+			"quay-v3-17/snapshots/snap-1/api-tests/results/ctrf-report.json",
+			"quay-v3-17/snapshot.json",
+		), "", []string{"quay-v3-17/snapshots/snap-1/snapshot.json"}},
+		{"encoded key", s3Event("ObjectCreated:CompleteMultipartUpload", "quay%2Bv3/snapshots/snap+1/snapshot.json"), "",
+			[]string{"quay+v3/snapshots/snap 1/snapshot.json"}},
+		{"removed", s3Event("ObjectRemoved:Delete", "quay-v3-17/snapshots/snap-1/snapshot.json"), "", nil},
+		{"sns", string(sns), "", []string{"quay-v3-17/snapshots/snap-2/snapshot.json"}},
+		{"test event", `{"Service":"Amazon S3","Event":"s3:TestEvent"}`, "", nil},
+		{"prefix", s3Event("ObjectCreated:Put",
+			"omr/omr-v2-0/snapshots/snap-1/snapshot.json",
+			"quay-v3-17/snapshots/snap-2/snapshot.json",
+		), "omr/", []string{"omr-v2-0/snapshots/snap-1/snapshot.json"}},
+	} {
+		got, err := snapshotKeys(tc.body, tc.prefix)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if _, err := snapshotKeys("not json", ""); err == nil {
+		t.Error("invalid body: want error")
+	}
+}
+
+type fakeQueue struct {
+	messages []QueueMessage
+	deleted  []string
+}
+
+func (q *fakeQueue) Receive(context.Context) ([]QueueMessage, error) {
+	msgs := q.messages
+	q.messages = nil
+	return msgs, nil
+}
+
+func (q *fakeQueue) Delete(_ context.Context, receiptHandle string) error {
+	q.deleted = append(q.deleted, receiptHandle)
+	return nil
+}
+
+func TestConsumeOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-2", 1)
+
+	syncer := NewSyncer(store, database, slog.Default())
+	queue := &fakeQueue{messages: []QueueMessage{
+		{ReceiptHandle: "1", Body: s3Event("ObjectCreated:Put", "quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json")},
+		{ReceiptHandle: "2", Body: `{"Event":"s3:TestEvent"}`},
+		{ReceiptHandle: "3", Body: s3Event("ObjectCreated:Put", "quay-v3-17/snapshots/missing/snapshot.json")},
+		{ReceiptHandle: "4", Body: s3Event("ObjectCreated:Put", "quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json")},
+	}}
+	ctx := t.Context()
+	if err := syncer.ConsumeOnce(ctx, queue); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(queue.deleted, []string{"1", "2", "3", "4"}) {
+		t.Errorf("deleted: got %v", queue.deleted)
+	}
+
+	if _, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1"); err != nil {
+		t.Errorf("notified snapshot: %v", err)
+	}
+	// Snapshots without a notification wait for the next poll.
+	if _, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-2"); err == nil {
+		t.Error("unnotified snapshot was ingested")
+	}
+	apps, err := database.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].SnapshotCount != 1 {
+		t.Errorf("applications: got %+v, want one snapshot", apps)
+	}
+}
+
+func TestSQS(t *testing.T) {
+	var targets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targets = append(targets, r.Header.Get("X-Amz-Target"))
+		if auth := r.Header.Get("Authorization"); !strings.Contains(auth, "/eu-west-1/sqs/aws4_request") {
+			t.Errorf("authorization: got %q", auth)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var in map[string]any
+		if err := json.Unmarshal(body, &in); err != nil || in["QueueUrl"] == nil {
+			t.Errorf("request body: %s", body)
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSQS.ReceiveMessage":
+			_, _ = w.Write([]byte(`{"Messages":[{"MessageId":"m1","ReceiptHandle":"rh1","Body":"{}"}]}`))
+		case "AmazonSQS.DeleteMessage":
+			if in["ReceiptHandle"] != "rh1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.sqs#ReceiptHandleIsInvalid","message":"bad handle"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	q, err := NewSQS(srv.URL+"/123456789012/snapshots", Config{Region: "eu-west-1", AccessKey: "AKID", SecretKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+	msgs, err := q.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].ReceiptHandle != "rh1" || msgs[0].Body != "{}" {
+		t.Errorf("messages: got %+v", msgs)
+	}
+	if err := q.Delete(ctx, "rh1"); err != nil {
+		t.Fatal(err)
+	}
+	err = q.Delete(ctx, "other")
+	if err == nil || !strings.Contains(err.Error(), "ReceiptHandleIsInvalid") {
+		t.Errorf("invalid delete: got %v", err)
+	}
+	if q.Breaker().Status().ConsecutiveFailures != 0 {
+		t.Error("client error counted against the breaker")
+	}
+	if want := []string{"AmazonSQS.ReceiveMessage", "AmazonSQS.DeleteMessage", "AmazonSQS.DeleteMessage"}; !slices.Equal(targets, want) {
+		t.Errorf("targets: got %v, want %v", targets, want)
+	}
+
+	if q, _ := NewSQS("https://sqs.us-east-2.amazonaws.com/123/q", Config{Region: "garage"}); q.region != "us-east-2" {
+		t.Errorf("region from AWS queue URL: got %q", q.region)
+	}
+}
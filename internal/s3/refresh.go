@@ -0,0 +1,129 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// DefaultLateResultsWindow is how long after a snapshot is stored polls
+// look for test results uploaded after it, unless overridden with
+// SetLateResultsWindow.
+const DefaultLateResultsWindow = time.Hour
+
+// SetLateResultsWindow sets how long after a snapshot is stored polls look
+// for test scenarios whose results were uploaded after it was ingested. Zero
+// disables the check; Refresh still picks them up.
+func (s *Syncer) SetLateResultsWindow(d time.Duration) {
+	s.lateResults = d
+}
+
+// Refresh re-reads the test results uploaded for snap, an ingested
+// snapshot of the syncer's source, and stores those that are new or
+// changed, recomputing whether its tests passed. It returns the names of
+// the scenarios updated; refreshing a snapshot whose results are unchanged
+// updates nothing.
+func (s *Syncer) Refresh(ctx context.Context, snap *model.SnapshotRecord) ([]string, error) {
+	if s.client == nil {
+		return nil, errors.New("no object store to refresh from")
+	}
+	dir := snapshotDir(snap.Application, snap.Name)
+	defer s.locks.lock(dir + "snapshot.json")()
+
+	suites, _ := s.collectTestSuites(ctx, dir, snap.Name, nil)
+	if len(suites) == 0 {
+		return nil, nil
+	}
+	updated, err := s.store.RefreshTestSuites(ctx, snap.ID, suites)
+	if err != nil {
+		return nil, fmt.Errorf("refresh test suites of %s: %w", snap.Name, err)
+	}
+	if len(updated) > 0 {
+		s.logger.InfoContext(ctx, "refreshed test results", "snapshot", snap.Name, "scenarios", updated)
+		s.events.Publish(events.Event{Kind: events.KindSnapshot, Application: snap.Application, Snapshot: snap.Name, Refresh: true})
+	}
+	return updated, nil
+}
+
+// refreshLate refreshes the snapshots stored within the late results
+// window that have pending test scenarios, or scenarios in the object
+// store but not in the database, and returns how many it updated.
+func (s *Syncer) refreshLate(ctx context.Context) (int, error) {
+	if s.lateResults <= 0 || s.client == nil {
+		return 0, nil
+	}
+	snaps, err := s.store.ListSnapshotsSince(ctx, s.source, time.Now().Add(-s.lateResults))
+	if err != nil {
+		return 0, fmt.Errorf("list recent snapshots: %w", err)
+	}
+	refreshed := 0
+	var lastErr error
+	for i := range snaps {
+		snap := &snaps[i]
+		late, err := s.hasLateResults(ctx, snap)
+		if err != nil {
+			s.logger.DebugContext(ctx, "skipping late results check", "snapshot", snap.Name, "error", err)
+			continue
+		}
+		if !late {
+			continue
+		}
+		updated, err := s.Refresh(ctx, snap)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "refresh snapshot", "snapshot", snap.Name, "error", err)
+			lastErr = err
+			continue
+		}
+		if len(updated) > 0 {
+			refreshed++
+		}
+	}
+	return refreshed, lastErr
+}
+
+// hasLateResults reports whether a test scenario of snap is pending, or
+// the object store holds results of one that is not stored. Only listings
+// are fetched.
+func (s *Syncer) hasLateResults(ctx context.Context, snap *model.SnapshotRecord) (bool, error) {
+	stored, err := s.store.ListTestSuites(ctx, snap.ID)
+	if err != nil {
+		return false, err
+	}
+	known := make(map[string]bool, len(stored))
+	for _, suite := range stored {
+		if suite.Status == "pending" {
+			return true, nil
+		}
+		known[suite.Name] = true
+	}
+	dir := snapshotDir(snap.Application, snap.Name)
+	suites, err := s.client.ListTestSuites(ctx, dir)
+	if err != nil {
+		return false, err
+	}
+	junitReports, err := s.client.ListJUnitReports(ctx, dir)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range suites {
+		if !known[name] {
+			return true, nil
+		}
+	}
+	for name := range junitReports {
+		if !known[name] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// snapshotDir returns the directory of the snapshot called name of app.
+func snapshotDir(app, name string) string {
+	return path.Join(app, "snapshots", name) + "/"
+}
@@ -0,0 +1,140 @@
+package s3
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+	"github.com/quay/release-readiness/internal/db"
+)
+
+func TestRefresh(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	syncer := NewSyncer(store, database, slog.Default())
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	get := func() ([]string, bool) {
+		t.Helper()
+		snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, s := range snap.TestSuites {
+			names = append(names, s.Name)
+		}
+		return names, snap.TestsPassed
+	}
+	if names, passed := get(); len(names) != 1 || !passed {
+		t.Fatalf("after ingest: suites %v, passed %v", names, passed)
+	}
+
+	// A scenario whose results land after the snapshot is ingested is
+	// picked up by the next poll.
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/junit/ui-tests/results.xml",
+		[]byte(`<testsuite name="cypress"><testcase name="logs in"><failure message="timeout"/></testcase></testsuite>`))
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); !st.LastRunOK {
+		t.Errorf("run status: got %+v", st)
+	}
+	if names, passed := get(); !slices.Equal(names, []string{"api-tests", "ui-tests"}) || passed {
+		t.Fatalf("after late results: suites %v, passed %v", names, passed)
+	}
+
+	// Rewritten results of a known scenario need an explicit refresh.
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 1)
+	syncer.SyncOnce(ctx)
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.TestSuites[0].Failed != 0 {
+		t.Errorf("poll replaced known scenario: got %+v", snap.TestSuites[0])
+	}
+	updated, err := syncer.Refresh(ctx, snap)
+	if err != nil || !slices.Equal(updated, []string{"api-tests"}) {
+		t.Fatalf("refresh: updated %v, err %v", updated, err)
+	}
+	snap, err = database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.TestSuites) != 2 || snap.TestSuites[0].Failed != 1 || len(snap.TestSuites[0].TestCases) != 2 {
+		t.Errorf("refreshed suites: got %+v", snap.TestSuites)
+	}
+
+	if updated, err := syncer.Refresh(ctx, snap); err != nil || len(updated) != 0 {
+		t.Errorf("repeat refresh: updated %v, err %v", updated, err)
+	}
+
+	// Outside the window, late results are left to Refresh.
+	syncer.SetLateResultsWindow(0)
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/junit/e2e/results.xml",
+		[]byte(`<testsuite name="e2e"><testcase name="pulls"/></testsuite>`))
+	syncer.SyncOnce(ctx)
+	if names, _ := get(); len(names) != 2 {
+		t.Errorf("late results outside the window: suites %v", names)
+	}
+}
+
+func TestRefreshPending(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	const key = "quay-v3-17/snapshots/quay-v3-17-snap-1/api-tests/results/ctrf-report.json"
+	running := ctrf.Report{Results: ctrf.Results{
+		Summary: ctrf.Summary{Tests: 2, Passed: 1, Pending: 1},
+		Tests:   []ctrf.Test{{Name: "test_a", Status: "passed"}, {Name: "test_b", Status: "pending"}},
+	}}
+	if err := store.PutJSON(key, running); err != nil {
+		t.Fatal(err)
+	}
+	syncer := NewSyncer(store, database, slog.Default())
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.TestsPassed || len(snap.TestSuites) != 1 || snap.TestSuites[0].Status != "pending" {
+		t.Fatalf("while running: passed %v, suites %+v", snap.TestsPassed, snap.TestSuites)
+	}
+
+	// Unchanged pending results are re-read without recording anything.
+	syncer.SyncOnce(ctx)
+	if snap, _ := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1"); len(snap.ScenarioTransitions) != 0 {
+		t.Errorf("transitions while running: got %+v", snap.ScenarioTransitions)
+	}
+
+	running.Results.Summary = ctrf.Summary{Tests: 2, Passed: 2}
+	running.Results.Tests[1].Status = "passed"
+	if err := store.PutJSON(key, running); err != nil {
+		t.Fatal(err)
+	}
+	syncer.SyncOnce(ctx)
+	snap, err = database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snap.TestsPassed || snap.TestSuites[0].Status != "passed" || snap.TestSuites[0].Passed != 2 {
+		t.Errorf("once completed: passed %v, suites %+v", snap.TestsPassed, snap.TestSuites)
+	}
+	if tr := snap.ScenarioTransitions; len(tr) != 1 || tr[0].Scenario != "api-tests" || tr[0].From != "pending" || tr[0].To != "passed" || tr[0].ChangedAt.IsZero() {
+		t.Errorf("transitions: got %+v", tr)
+	}
+}
@@ -0,0 +1,68 @@
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// statusError is an error response from a storage service's REST API. It
+// satisfies the interface isUnavailable checks, so only 5xx responses count
+// against the breaker.
+type statusError struct {
+	op         string
+	statusCode int
+	message    string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: returned %d: %s", e.op, e.statusCode, e.message)
+}
+
+func (e *statusError) HTTPStatusCode() int {
+	return e.statusCode
+}
+
+// checkResponse returns a statusError for any response other than 200 OK
+// and closes the body of anything it rejects.
+func checkResponse(op string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &statusError{op: op, statusCode: resp.StatusCode, message: strings.TrimSpace(string(body))}
+}
+
+// notModified turns the statusError of a 304 response into ErrNotModified.
+// Call it on the error returned by breaker.Do, so that unchanged objects do
+// not count against the breaker.
+func notModified(err error) error {
+	var se *statusError
+	if errors.As(err, &se) && se.statusCode == http.StatusNotModified {
+		return ErrNotModified
+	}
+	return err
+}
+
+// readObject reads the body of key, failing if it is larger than maxBytes.
+// size is the advertised length, or -1 if unknown. A maxBytes of zero means
+// no limit.
+func readObject(key string, body io.Reader, size, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		return io.ReadAll(body)
+	}
+	if size > maxBytes {
+		return nil, fmt.Errorf("get %s: object is %d bytes, limit is %d", key, size, maxBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("get %s: object exceeds %d byte limit", key, maxBytes)
+	}
+	return data, nil
+}
@@ -0,0 +1,154 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// Source is one object store snapshots are synced from, when a dashboard
+// serves products that publish to different buckets. Snapshots are tagged
+// with the Name of the source they were synced from.
+type Source struct {
+	Name string `json:"name"`
+	// Backend is s3 (the default), gcs or azure.
+	Backend  string `json:"backend,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Region   string `json:"region,omitempty"`
+	// Bucket is the S3 or GCS bucket, or the Azure blob container.
+	Bucket string `json:"bucket"`
+	// Prefix is the directory of the bucket holding the layout; empty for
+	// the bucket root.
+	Prefix string `json:"prefix,omitempty"`
+	// Credentials. Each may refer to environment variables as ${VAR}, so
+	// that the file need not hold secrets.
+	AccessKey     string `json:"access_key,omitempty"`
+	SecretKey     string `json:"secret_key,omitempty"`
+	GCSToken      string `json:"gcs_token,omitempty"`
+	AzureAccount  string `json:"azure_account,omitempty"`
+	AzureSASToken string `json:"azure_sas_token,omitempty"`
+	// SQSQueue receives the bucket's ObjectCreated notifications, for
+	// immediate ingestion. It requires the s3 backend.
+	SQSQueue string `json:"sqs_queue,omitempty"`
+}
+
+// SourceStore is an ObjectStore whose calls are guarded by a circuit
+// breaker, as every backend's are.
+type SourceStore interface {
+	ObjectStore
+	Breaker() *breaker.Breaker
+}
+
+var sourceName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// LoadSources reads a JSON array of sources from the file at path.
+func LoadSources(path string) ([]Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sources []Source
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sources); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	seen := make(map[string]bool, len(sources))
+	for i := range sources {
+		src := &sources[i]
+		if err := src.validate(); err != nil {
+			return nil, fmt.Errorf("%s: source %d: %w", path, i+1, err)
+		}
+		if seen[src.Name] {
+			return nil, fmt.Errorf("%s: source %d: name %q duplicates an earlier source", path, i+1, src.Name)
+		}
+		seen[src.Name] = true
+		src.AccessKey = os.ExpandEnv(src.AccessKey)
+		src.SecretKey = os.ExpandEnv(src.SecretKey)
+		src.GCSToken = os.ExpandEnv(src.GCSToken)
+		src.AzureSASToken = os.ExpandEnv(src.AzureSASToken)
+	}
+	return sources, nil
+}
+
+func (src *Source) validate() error {
+	if !sourceName.MatchString(src.Name) {
+		return fmt.Errorf("name %q must be lowercase letters, digits, - and _", src.Name)
+	}
+	if src.Bucket == "" {
+		return errors.New("bucket is required")
+	}
+	switch src.Backend {
+	case "":
+		src.Backend = "s3"
+	case "s3", "gcs", "azure":
+	default:
+		return fmt.Errorf("backend %q must be one of s3, gcs, azure", src.Backend)
+	}
+	if src.SQSQueue != "" && src.Backend != "s3" {
+		return fmt.Errorf("sqs_queue requires the s3 backend, not %s", src.Backend)
+	}
+	if src.Region == "" {
+		src.Region = "us-east-1"
+	}
+	return nil
+}
+
+// Open creates the client of the source's object store.
+func (src Source) Open(ctx context.Context, logger *slog.Logger) (SourceStore, error) {
+	switch src.Backend {
+	case "s3", "":
+		return New(ctx, src.s3Config(), logger)
+	case "gcs":
+		return NewGCS(GCSConfig{
+			Endpoint: src.Endpoint,
+			Bucket:   src.Bucket,
+			Token:    src.GCSToken,
+			Prefix:   src.Prefix,
+			Source:   src.Name,
+		})
+	case "azure":
+		return NewAzure(AzureConfig{
+			Endpoint:  src.Endpoint,
+			Account:   src.AzureAccount,
+			Container: src.Bucket,
+			SASToken:  src.AzureSASToken,
+			Prefix:    src.Prefix,
+			Source:    src.Name,
+		})
+	}
+	return nil, fmt.Errorf("unknown backend %q", src.Backend)
+}
+
+// OpenQueue creates the client of the source's SQS queue.
+func (src Source) OpenQueue() (*SQS, error) {
+	return NewSQS(src.SQSQueue, src.s3Config())
+}
+
+func (src Source) s3Config() Config {
+	return Config{
+		Endpoint:  src.Endpoint,
+		Region:    src.Region,
+		Bucket:    src.Bucket,
+		AccessKey: src.AccessKey,
+		SecretKey: src.SecretKey,
+		Prefix:    src.Prefix,
+		Source:    src.Name,
+	}
+}
+
+// breakerName names the breaker of a backend for source, which is empty
+// for the default one.
+func breakerName(backend, source string) string {
+	if source == "" {
+		return backend
+	}
+	return backend + "/" + source
+}
@@ -0,0 +1,97 @@
+package s3
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+)
+
+func TestLoadSources(t *testing.T) {
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "sources.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Setenv("OMR_SECRET_KEY", "s3cret")
+	sources, err := LoadSources(write(`[
+		{"name": "quay", "bucket": "quay-readiness"},
+		{"name": "omr", "bucket": "products", "prefix": "omr", "access_key": "omr", "secret_key": "${OMR_SECRET_KEY}"}
+	]`))
+	if err != nil {
+		t.Fatalf("LoadSources: %v", err)
+	}
+	if len(sources) != 2 || sources[0].Backend != "s3" || sources[0].Region != "us-east-1" || sources[1].SecretKey != "s3cret" {
+		t.Errorf("sources: got %+v", sources)
+	}
+
+	for name, content := range map[string]string{
+		"no name":       `[{"bucket": "b"}]`,
+		"bad name":      `[{"name": "Quay Main", "bucket": "b"}]`,
+		"no bucket":     `[{"name": "quay"}]`,
+		"duplicate":     `[{"name": "quay", "bucket": "a"}, {"name": "quay", "bucket": "b"}]`,
+		"bad backend":   `[{"name": "quay", "bucket": "b", "backend": "ftp"}]`,
+		"sqs on gcs":    `[{"name": "quay", "bucket": "b", "backend": "gcs", "sqs_queue": "https://sqs.us-east-1.amazonaws.com/1/q"}]`,
+		"unknown field": `[{"name": "quay", "bucket": "b", "buckt": "c"}]`,
+		"not an array":  `{"name": "quay", "bucket": "b"}`,
+	} {
+		if _, err := LoadSources(write(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSyncOnceSources(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	quay := NewMemoryStore()
+	putTestSnapshot(t, quay, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	// The OMR bucket holds its layout under a prefix.
+	plain := NewMemoryStore()
+	putTestSnapshot(t, plain, "omr-v2-0", "omr-v2-0-snap-1", 0)
+	omr := NewMemoryStore()
+	for key, data := range plain.objects {
+		omr.Put("products/omr/"+key, data)
+	}
+	omr.Put("products/other/app/snapshots/other-snap-1/snapshot.json", []byte(`{"application":"app"}`))
+	omr.layout = newLayout(omr, "/products/omr")
+
+	ctx := t.Context()
+	NewSyncer(quay, database, slog.Default()).SyncOnce(ctx)
+	syncer := NewSyncer(omr, database, slog.Default())
+	syncer.SetSource("omr", "products/omr")
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); st.Name != "s3/omr" || !st.LastRunOK || st.ItemsProcessed != 1 {
+		t.Errorf("omr run status: got %+v", st)
+	}
+
+	for name, want := range map[string]string{"quay-v3-17-snap-1": "", "omr-v2-0-snap-1": "omr"} {
+		snap, err := database.GetSnapshotByName(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snap.Source != want || len(snap.TestSuites) != 1 {
+			t.Errorf("%s: source %q, %d suites; want source %q, 1 suite", name, snap.Source, len(snap.TestSuites), want)
+		}
+	}
+	states, err := database.ListS3SyncStates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := states["omr:omr-v2-0/snapshots/omr-v2-0-snap-1/snapshot.json"]; !ok {
+		t.Errorf("sync states: got %v, want the omr snapshot's qualified by its source", states)
+	}
+	if _, ok := states["quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json"]; !ok {
+		t.Errorf("sync states: got %v, want the default source's unqualified", states)
+	}
+}
@@ -0,0 +1,171 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// sqsWaitSeconds is how long a ReceiveMessage call long-polls for messages.
+const sqsWaitSeconds = 20
+
+// QueueMessage is a message received from a Queue.
+type QueueMessage struct {
+	ReceiptHandle string
+	Body          string
+}
+
+// Queue is the message queue the Syncer consumes bucket notifications from.
+type Queue interface {
+	// Receive waits briefly for messages, returning none if nothing arrived.
+	Receive(ctx context.Context) ([]QueueMessage, error)
+	// Delete acknowledges a received message so it is not redelivered.
+	Delete(ctx context.Context, receiptHandle string) error
+}
+
+// SQS is a minimal Amazon SQS client speaking the JSON protocol, covering
+// the two calls the Syncer needs.
+type SQS struct {
+	queueURL   string
+	endpoint   string
+	region     string
+	creds      aws.CredentialsProvider
+	signer     *v4.Signer
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// NewSQS creates a client for the queue at queueURL (e.g.
+// https://sqs.us-east-1.amazonaws.com/123456789012/snapshots), signing
+// requests with the credentials in cfg. The region is taken from the queue
+// URL when it is an AWS one, and from cfg otherwise.
+func NewSQS(queueURL string, cfg Config) (*SQS, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid queue URL %q", queueURL)
+	}
+	region := cfg.Region
+	if parts := strings.Split(u.Hostname(), "."); len(parts) >= 4 && parts[0] == "sqs" && parts[len(parts)-2] == "amazonaws" {
+		region = parts[1]
+	}
+	q := &SQS{
+		queueURL: queueURL,
+		endpoint: u.Scheme + "://" + u.Host + "/",
+		region:   region,
+		creds:    credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
+		signer:   v4.NewSigner(),
+		// Leave room for the long poll on top of the usual request time.
+		httpClient: &http.Client{Timeout: (sqsWaitSeconds + 30) * time.Second},
+		breaker:    breaker.New(breakerName("sqs", cfg.Source), breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	q.breaker.SetFailurePredicate(isUnavailable)
+	return q, nil
+}
+
+// Breaker returns the circuit breaker guarding calls to the queue.
+func (q *SQS) Breaker() *breaker.Breaker {
+	return q.breaker
+}
+
+// Receive long-polls the queue for up to ten messages.
+func (q *SQS) Receive(ctx context.Context) ([]QueueMessage, error) {
+	var out struct {
+		Messages []QueueMessage `json:"Messages"`
+	}
+	err := q.call(ctx, "ReceiveMessage", map[string]any{
+		"QueueUrl":            q.queueURL,
+		"MaxNumberOfMessages": 10,
+		"WaitTimeSeconds":     sqsWaitSeconds,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Messages, nil
+}
+
+// Delete removes a received message from the queue.
+func (q *SQS) Delete(ctx context.Context, receiptHandle string) error {
+	return q.call(ctx, "DeleteMessage", map[string]any{
+		"QueueUrl":      q.queueURL,
+		"ReceiptHandle": receiptHandle,
+	}, nil)
+}
+
+// call invokes an SQS action with a SigV4-signed JSON request, decoding the
+// response into out unless it is nil.
+func (q *SQS) call(ctx context.Context, action string, in, out any) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return q.breaker.Do(func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+		req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+
+		creds, err := q.creds.Retrieve(ctx)
+		if err != nil {
+			return fmt.Errorf("sqs credentials: %w", err)
+		}
+		sum := sha256.Sum256(payload)
+		if err := q.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), "sqs", q.region, time.Now()); err != nil {
+			return fmt.Errorf("sign %s: %w", action, err)
+		}
+
+		resp, err := q.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("%s: %w", action, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			e := &sqsError{action: action, statusCode: resp.StatusCode}
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			if json.Unmarshal(body, e) != nil || e.Message == "" {
+				e.Message = string(body)
+			}
+			return e
+		}
+		if out == nil {
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("%s: decode response: %w", action, err)
+		}
+		return nil
+	})
+}
+
+// sqsError is an error response from SQS. It satisfies the interface
+// isUnavailable checks, so only 5xx responses count against the breaker.
+type sqsError struct {
+	action     string
+	statusCode int
+	Type       string `json:"__type"`
+	Message    string `json:"message"`
+}
+
+func (e *sqsError) Error() string {
+	return fmt.Sprintf("%s: sqs returned %d: %s %s", e.action, e.statusCode, e.Type, e.Message)
+}
+
+func (e *sqsError) HTTPStatusCode() int {
+	return e.statusCode
+}
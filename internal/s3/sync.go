@@ -2,206 +2,657 @@ package s3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"path"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/quay/release-readiness/internal/breaker"
 	"github.com/quay/release-readiness/internal/clair"
 	"github.com/quay/release-readiness/internal/ctrf"
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/events"
 	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/runstatus"
+	"github.com/quay/release-readiness/internal/sbom"
+	"github.com/quay/release-readiness/internal/tracing"
 )
 
 // Store is the subset of the database layer needed by the S3 syncer.
 type Store interface {
 	SnapshotExistsByName(ctx context.Context, name string) (bool, error)
-	CreateSnapshot(ctx context.Context, application, name string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error)
-	EnsureComponent(ctx context.Context, name string) (*model.Component, error)
-	CreateSnapshotComponent(ctx context.Context, snapshotID int64, component, gitSHA, imageURL, gitURL string) error
-	CreateTestSuite(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64) (int64, error)
-	CreateTestCase(ctx context.Context, testSuiteID int64, name, status string, durationMs float64, message, trace, filePath, suite string, retries int, flaky bool) error
-	CreateVulnerabilityReport(ctx context.Context, snapshotID int64, component, arch string, total, critical, high, medium, low, unknown, fixable int) (int64, error)
-	CreateVulnerability(ctx context.Context, reportID int64, name, severity, packageName, packageVersion, fixedInVersion, description, link string) error
+	SaveSnapshot(ctx context.Context, snap *model.SnapshotRecord) error
+	ListS3SyncStates(ctx context.Context) (map[string]string, error)
+	SaveS3SyncState(ctx context.Context, key, etag string) error
+	SaveSnapshotRelease(ctx context.Context, r *model.SnapshotRelease) error
+	ListScenarioRequirements(ctx context.Context, application string) ([]model.ScenarioRequirement, error)
+	ListIngestFailures(ctx context.Context) ([]model.IngestFailure, error)
+	RecordIngestFailure(ctx context.Context, f *model.IngestFailure, maxAttempts int) error
+	ClearIngestFailure(ctx context.Context, source, key string) error
+	ListSnapshotsSince(ctx context.Context, source string, since time.Time) ([]model.SnapshotRecord, error)
+	ListTestSuites(ctx context.Context, snapshotID int64) ([]model.TestSuite, error)
+	RefreshTestSuites(ctx context.Context, snapshotID int64, suites []model.TestSuite) ([]string, error)
 }
 
-// TxFunc wraps a function in a database transaction, passing a tx-scoped Store.
-type TxFunc func(ctx context.Context, fn func(Store) error) error
+// DefaultConcurrency is the number of applications synced in parallel
+// unless overridden with SetConcurrency.
+const DefaultConcurrency = 4
+
+// DefaultMaxAttempts is the number of times polls try to ingest a snapshot
+// before giving up on it, unless overridden with SetMaxAttempts.
+const DefaultMaxAttempts = 5
 
 // Syncer orchestrates periodic S3 snapshot synchronisation into a Store.
 type Syncer struct {
-	client *Client
-	store  Store
-	withTx TxFunc
-	logger *slog.Logger
+	client      ObjectStore
+	store       Store
+	logger      *slog.Logger
+	limits      Limits
+	concurrency int
+	maxAttempts int
+	lateResults time.Duration
+	status      *runstatus.Tracker
+	locks       keyedMutex // serialises ingestion of each snapshot
+	events      *events.Broker
+	sboms       bool
+	// source names the object store synced from, and prefix is the
+	// directory its layout is rooted at; see SetSource.
+	source string
+	prefix string
 }
 
 // NewSyncer creates a Syncer that uses client to fetch data and store to persist it.
-func NewSyncer(client *Client, store Store, withTx TxFunc, logger *slog.Logger) *Syncer {
-	return &Syncer{client: client, store: store, withTx: withTx, logger: logger}
-}
-
-// Run performs an immediate sync and then repeats every interval until ctx is cancelled.
-func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
-	s.SyncOnce(ctx)
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			s.logger.Info("stopping")
-			return
-		case <-ticker.C:
-			s.SyncOnce(ctx)
-		}
+// client may be nil if only pushed snapshots (IngestSnapshot) are ingested;
+// they are then stored without test results or scans.
+func NewSyncer(client ObjectStore, store Store, logger *slog.Logger) *Syncer {
+	return &Syncer{
+		client:      client,
+		store:       store,
+		logger:      logger,
+		limits:      DefaultLimits,
+		concurrency: DefaultConcurrency,
+		maxAttempts: DefaultMaxAttempts,
+		lateResults: DefaultLateResultsWindow,
+		status:      runstatus.New("s3"),
+	}
+}
+
+// RunStatus returns the tracker of the syncer's polls, for the sync status
+// API.
+func (s *Syncer) RunStatus() *runstatus.Tracker {
+	return s.status
+}
+
+// SetLimits overrides the per-scenario report limits applied at ingest.
+func (s *Syncer) SetLimits(l Limits) {
+	s.limits = l
+}
+
+// SetConcurrency sets how many applications SyncOnce syncs in parallel.
+// Values below 1 are treated as 1.
+func (s *Syncer) SetConcurrency(n int) {
+	s.concurrency = max(n, 1)
+}
+
+// SetMaxAttempts sets how many times polls try to ingest a snapshot before
+// it is dead: skipped until re-ingested. Values below 1 are treated as 1.
+func (s *Syncer) SetMaxAttempts(n int) {
+	s.maxAttempts = max(n, 1)
+}
+
+// SetSBOMs makes ingestion record each component's SBOM: the reference of
+// its SBOM attestation, derived from the image digest, and a summary of the
+// SBOM document if one was uploaded with the snapshot.
+func (s *Syncer) SetSBOMs(enabled bool) {
+	s.sboms = enabled
+}
+
+// SetSource tags the snapshots the syncer ingests with the name of the
+// source it syncs from, and names its status and sync state after it, so
+// that several syncers can share a database. prefix is the directory the
+// source's layout is rooted at, which queue notifications include.
+func (s *Syncer) SetSource(name, prefix string) {
+	s.source = name
+	s.prefix = normalizePrefix(prefix)
+	s.status = runstatus.New(breakerName("s3", name))
+}
+
+// stateKey returns the key the sync state of the object at key is saved
+// under: key itself for the default source, or else key qualified by the
+// source, as different buckets may hold the same keys.
+func (s *Syncer) stateKey(key string) string {
+	if s.source == "" {
+		return key
 	}
+	return s.source + ":" + key
 }
 
-// SyncOnce discovers all applications and ingests any new snapshots.
+// SetEvents makes the syncer announce each ingested snapshot on b.
+func (s *Syncer) SetEvents(b *events.Broker) {
+	s.events = b
+}
+
+// SyncOnce discovers all applications and ingests any new snapshots. Each
+// cycle is tagged with a request ID (unless ctx already carries one) so its
+// log lines can be correlated.
 func (s *Syncer) SyncOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	ctx, span := tracing.Start(ctx, "s3 sync", slog.String("request_id", requestid.FromContext(ctx)))
+	defer span.End()
+	run := s.status.Start()
+	defer run.Finish()
+
 	apps, err := s.client.ListApplications(ctx)
+	if errors.Is(err, breaker.ErrOpen) {
+		s.logger.WarnContext(ctx, "skipping sync, upstream unavailable", "error", err)
+		run.Fail(err)
+		span.RecordError(err)
+		return
+	}
 	if err != nil {
-		s.logger.Error("list applications", "error", err)
+		s.logger.ErrorContext(ctx, "list applications", "error", err)
+		run.Fail(fmt.Errorf("list applications: %w", err))
+		span.RecordError(err)
 		return
 	}
+	span.SetAttributes(slog.Int("applications", len(apps)))
 
-	for _, app := range apps {
-		keys, err := s.client.ListSnapshots(ctx, app)
-		if err != nil {
-			s.logger.Error("list snapshots", "application", app, "error", err)
-			continue
-		}
+	// ETags of the snapshot.json files already handled; unchanged ones are
+	// not downloaded again.
+	etags, err := s.store.ListS3SyncStates(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "load sync state, downloading every snapshot", "error", err)
+		run.Fail(fmt.Errorf("load sync state: %w", err))
+	}
+	dead, err := s.deadKeys(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "load ingest failures", "error", err)
+		run.Fail(fmt.Errorf("load ingest failures: %w", err))
+	}
 
-		for _, key := range keys {
-			snap, err := s.client.GetSnapshot(ctx, key)
-			if err != nil {
-				s.logger.Debug("skipping snapshot", "key", key, "error", err)
-				continue
+	// Applications are synced in parallel; each application's snapshots
+	// are ingested in order by a single worker.
+	results := make([]appResult, len(apps))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(s.concurrency, len(apps)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = s.syncApplication(ctx, apps[i], etags, dead)
 			}
+		}()
+	}
+	for i := range apps {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	ingested := 0
+	for _, r := range results {
+		ingested += r.ingested
+		run.Add(r.ingested)
+		run.Fail(r.err)
+		span.RecordError(r.err)
+	}
+	span.SetAttributes(slog.Int("snapshots.ingested", ingested))
 
-			exists, err := s.store.SnapshotExistsByName(ctx, snap.Snapshot)
-			if err != nil {
-				s.logger.Error("check snapshot", "snapshot", snap.Snapshot, "error", err)
-				continue
-			}
-			if exists {
-				continue
-			}
+	refreshed, err := s.refreshLate(ctx)
+	run.Fail(err)
+	span.RecordError(err)
+	span.SetAttributes(slog.Int("snapshots.refreshed", refreshed))
+}
 
-			s.logger.Info("new snapshot", "snapshot", snap.Snapshot, "application", app)
+// appResult is the outcome of syncing one application.
+type appResult struct {
+	ingested int
+	err      error // last failure, if any
+}
+
+// syncApplication ingests the new snapshots of app, in the order S3 lists
+// them. etags holds the ETag last seen for each snapshot.json key, and dead
+// the keys of the snapshots that are no longer retried.
+func (s *Syncer) syncApplication(ctx context.Context, app string, etags map[string]string, dead map[string]bool) (r appResult) {
+	ctx, span := tracing.Start(ctx, "s3 sync application", slog.String("application", app))
+	defer func() {
+		span.SetAttributes(slog.Int("snapshots.ingested", r.ingested))
+		span.RecordError(r.err)
+		span.End()
+	}()
+	keys, err := s.client.ListSnapshots(ctx, app)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list snapshots", "application", app, "error", err)
+		r.err = fmt.Errorf("list snapshots of %s: %w", app, err)
+		return r
+	}
+	for _, key := range keys {
+		if dead[key] {
+			s.logger.DebugContext(ctx, "skipping dead snapshot", "key", key)
+			continue
+		}
+		ingested, err := s.syncSnapshot(ctx, key, etags[s.stateKey(key)])
+		if ingested {
+			r.ingested++
+		}
+		if err != nil {
+			r.err = err
+		}
+	}
+	if err := s.syncReleases(ctx, app, etags); err != nil {
+		r.err = err
+	}
+	return r
+}
 
-			if err := s.withTx(ctx, func(txStore Store) error {
-				txSyncer := &Syncer{client: s.client, store: txStore, withTx: s.withTx, logger: s.logger}
-				return txSyncer.ingest(ctx, key, snap)
-			}); err != nil {
-				s.logger.Error("ingest snapshot", "snapshot", snap.Snapshot, "error", err)
+// syncReleases stores the Konflux Releases exported for app whose Release
+// CR changed since the last poll. A release of a snapshot that is not
+// stored yet is retried on the next poll.
+func (s *Syncer) syncReleases(ctx context.Context, app string, etags map[string]string) error {
+	keys, err := s.client.ListReleases(ctx, app)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "list releases", "application", app, "error", err)
+		return fmt.Errorf("list releases of %s: %w", app, err)
+	}
+	var lastErr error
+	for _, key := range keys {
+		rel, current, err := s.client.GetReleaseIfChanged(ctx, key, etags[s.stateKey(key)])
+		if errors.Is(err, ErrNotModified) {
+			continue
+		}
+		if err != nil {
+			s.logger.DebugContext(ctx, "skipping release", "key", key, "error", err)
+			continue
+		}
+		err = s.store.SaveSnapshotRelease(ctx, rel)
+		if errors.Is(err, db.ErrNotFound) {
+			s.logger.DebugContext(ctx, "release of unknown snapshot", "release", rel.Name, "snapshot", rel.Snapshot)
+			continue
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "save release", "release", rel.Name, "error", err)
+			lastErr = fmt.Errorf("save release %s: %w", rel.Name, err)
+			continue
+		}
+		s.logger.InfoContext(ctx, "synced release", "release", rel.Name, "snapshot", rel.Snapshot, "status", rel.Status)
+		if current != "" {
+			if err := s.store.SaveS3SyncState(ctx, s.stateKey(key), current); err != nil {
+				s.logger.WarnContext(ctx, "save sync state", "key", key, "error", err)
 			}
 		}
 	}
+	return lastErr
+}
+
+// deadKeys returns the keys of the snapshots of the syncer's source that
+// failed to ingest too often to be retried by polls.
+func (s *Syncer) deadKeys(ctx context.Context) (map[string]bool, error) {
+	failures, err := s.store.ListIngestFailures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dead := make(map[string]bool)
+	for _, f := range failures {
+		if f.Dead && f.Source == s.source {
+			dead[f.Key] = true
+		}
+	}
+	return dead, nil
+}
+
+// syncSnapshot ingests the snapshot whose snapshot.json is at key, unless it
+// is already stored, and reports whether it did. If etag is set and the
+// object still has it, the snapshot is skipped without downloading it.
+// A snapshot.json that cannot be read is skipped. Ingestion failures are
+// logged, recorded and returned; the next poll retries them, until they
+// have failed too often.
+func (s *Syncer) syncSnapshot(ctx context.Context, key, etag string) (bool, error) {
+	// The poller and the queue consumer may see the same snapshot at once.
+	defer s.locks.lock(key)()
+
+	snap, current, err := s.client.GetSnapshotIfChanged(ctx, key, etag)
+	if errors.Is(err, ErrNotModified) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.DebugContext(ctx, "skipping snapshot", "key", key, "error", err)
+		return false, nil
+	}
+	ingested, err := s.ingestNew(ctx, key, snap, nil)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ingest snapshot", "snapshot", snap.Snapshot, "error", err)
+		s.recordFailure(ctx, key, snap, err)
+		return false, fmt.Errorf("ingest snapshot %s: %w", snap.Snapshot, err)
+	}
+	if err := s.store.ClearIngestFailure(ctx, s.source, key); err != nil {
+		s.logger.WarnContext(ctx, "clear ingest failure", "key", key, "error", err)
+	}
+	if current != "" {
+		if err := s.store.SaveS3SyncState(ctx, s.stateKey(key), current); err != nil {
+			// Only costs a download on the next poll.
+			s.logger.WarnContext(ctx, "save sync state", "key", key, "error", err)
+		}
+	}
+	return ingested, nil
 }
 
-type suiteData struct {
-	name   string
-	report *ctrf.Report
+// recordFailure records a failed attempt to ingest snap, and warns when it
+// will no longer be retried.
+func (s *Syncer) recordFailure(ctx context.Context, key string, snap *model.Snapshot, cause error) {
+	f := &model.IngestFailure{
+		Source:      s.source,
+		Key:         key,
+		Application: snap.Application,
+		Snapshot:    snap.Snapshot,
+		LastError:   cause.Error(),
+	}
+	if err := s.store.RecordIngestFailure(ctx, f, s.maxAttempts); err != nil {
+		s.logger.WarnContext(ctx, "record ingest failure", "key", key, "error", err)
+		return
+	}
+	if f.Dead {
+		s.logger.WarnContext(ctx, "giving up on snapshot", "snapshot", snap.Snapshot, "attempts", f.Attempts, "failure", f.ID)
+	}
+}
+
+// Reingest ingests the snapshot whose snapshot.json is at key, even if it
+// failed too often to be retried by polls, and reports whether it did. Its
+// past failures are forgotten first, so a failure starts a new series of
+// attempts.
+func (s *Syncer) Reingest(ctx context.Context, key string) (bool, error) {
+	if s.client == nil {
+		return false, errors.New("no object store to re-ingest from")
+	}
+	if err := s.store.ClearIngestFailure(ctx, s.source, key); err != nil {
+		return false, fmt.Errorf("clear ingest failure: %w", err)
+	}
+	defer s.locks.lock(key)()
+	snap, _, err := s.client.GetSnapshotIfChanged(ctx, key, "")
+	if err != nil {
+		return false, fmt.Errorf("get %s: %w", key, err)
+	}
+	ingested, err := s.ingestNew(ctx, key, snap, nil)
+	if err != nil {
+		s.recordFailure(ctx, key, snap, err)
+		return false, fmt.Errorf("ingest snapshot %s: %w", snap.Snapshot, err)
+	}
+	return ingested, nil
+}
+
+// IngestSnapshot stores a snapshot pushed to the dashboard rather than
+// found by polling, along with any test results and scans already uploaded
+// under its S3 prefix. reports holds test results pushed with it, by
+// scenario; they replace the uploaded results of the same scenarios. It
+// reports false if the snapshot was already stored.
+func (s *Syncer) IngestSnapshot(ctx context.Context, snap *model.Snapshot, reports map[string]*ctrf.Report) (bool, error) {
+	key := path.Join(snap.Application, "snapshots", snap.Snapshot, "snapshot.json")
+	defer s.locks.lock(key)()
+	return s.ingestNew(ctx, key, snap, reports)
+}
+
+// ingestNew ingests snap unless a snapshot of the same name is already
+// stored. Everything is fetched from S3 first and then stored in one
+// transaction, so a failure leaves nothing behind to be skipped on the next
+// poll. pushed holds test results pushed with snap, by scenario. Callers
+// must hold the lock of key.
+func (s *Syncer) ingestNew(ctx context.Context, key string, snap *model.Snapshot, pushed map[string]*ctrf.Report) (bool, error) {
+	exists, err := s.store.SnapshotExistsByName(ctx, snap.Snapshot)
+	if err != nil {
+		return false, fmt.Errorf("check snapshot: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	s.logger.InfoContext(ctx, "new snapshot", "snapshot", snap.Snapshot, "application", snap.Application)
+
+	reqs, err := s.store.ListScenarioRequirements(ctx, snap.Application)
+	if err != nil {
+		return false, fmt.Errorf("list scenario requirements: %w", err)
+	}
+	informational := make(map[string]bool)
+	for _, r := range reqs {
+		informational[r.Scenario] = !r.Required
+	}
+
+	record := s.collect(ctx, key, snap, informational, pushed)
+	if err := s.store.SaveSnapshot(ctx, record); err != nil {
+		return false, err
+	}
+	s.events.Publish(events.Event{Kind: events.KindSnapshot, Application: snap.Application, Snapshot: snap.Snapshot})
+	for _, r := range record.VulnerabilityReports {
+		s.logger.InfoContext(ctx, "ingested clair report",
+			"component", r.Component, "arch", r.Arch,
+			"vulnerabilities", r.Total)
+	}
+	return true, nil
 }
 
-// ingest persists a single snapshot and its components/test results into the store.
-func (s *Syncer) ingest(ctx context.Context, key string, snap *model.Snapshot) error {
+// collect fetches the test results and scans uploaded under snap's S3
+// prefix and assembles the snapshot record to store. Scans and other
+// reports that cannot be fetched are skipped; test reports are not, see
+// collectTestSuites. Failures of informational scenarios do not fail the
+// record's TestsPassed.
+func (s *Syncer) collect(ctx context.Context, key string, snap *model.Snapshot, informational map[string]bool, pushed map[string]*ctrf.Report) *model.SnapshotRecord {
 	// Derive the snapshot directory prefix from the key.
 	// key is like "{app}/snapshots/{snapshot-name}/snapshot.json"
 	snapshotDir := path.Dir(key) + "/"
 
+	record := &model.SnapshotRecord{
+		Source:      s.source,
+		Application: snap.Application,
+		Name:        snap.Snapshot,
+		CreatedAt:   time.Now().UTC(),
+	}
+	for _, comp := range snap.Components {
+		record.Components = append(record.Components, model.ComponentRecord{
+			Component: comp.Name,
+			GitSHA:    comp.GitRevision,
+			ImageURL:  comp.ContainerImage,
+			GitURL:    comp.GitURL,
+		})
+	}
+	if s.client != nil {
+		record.TestSuites, _ = s.collectTestSuites(ctx, snapshotDir, snap.Snapshot, informational)
+	}
+	record.TestSuites = s.addPushedTestSuites(ctx, snap.Snapshot, record.TestSuites, pushed)
+	record.TestsPassed = testsPassed(record.TestSuites, informational)
+	if s.client == nil {
+		return record
+	}
+
+	// Ingest Clair vulnerability scans.
+	record.VulnerabilityReports = s.collectScans(ctx, snapshotDir)
+
+	// Ingest the Enterprise Contract verification, if the snapshot has one.
+	ecResults, err := s.client.GetECReport(ctx, snapshotDir, s.limits.MaxReportBytes)
+	if err != nil {
+		s.logger.DebugContext(ctx, "no ec report found", "snapshot", snap.Snapshot, "error", err)
+	}
+	record.ECResults = ecResults
+
+	if s.sboms {
+		s.collectSBOMs(ctx, snapshotDir, record)
+	}
+	return record
+}
+
+// collectTestSuites fetches the test results of the snapshot called name
+// uploaded under snapshotDir, and reports whether they passed: whether
+// there are any, and no scenario but informational ones failed or is
+// still pending. A scenario whose report cannot be fetched, or is rejected
+// by the limits, is recorded as failed rather than left out, so that it
+// cannot pass unseen.
+func (s *Syncer) collectTestSuites(ctx context.Context, snapshotDir, name string, informational map[string]bool) ([]model.TestSuite, bool) {
 	// Discover test suites from S3 and fetch CTRF reports to determine testsPassed.
 	suiteNames, err := s.client.ListTestSuites(ctx, snapshotDir)
 	if err != nil {
-		s.logger.Debug("no test suites found", "snapshot", snap.Snapshot, "error", err)
+		s.logger.DebugContext(ctx, "no test suites found", "snapshot", name, "error", err)
 	}
-
-	var suites []suiteData
-	testsPassed := len(suiteNames) > 0
-	for _, name := range suiteNames {
-		ctrfPath := snapshotDir + name + "/results/ctrf-report.json"
-		report, err := s.client.GetCTRFReport(ctx, ctrfPath)
+	var suites []model.TestSuite
+	for _, suite := range suiteNames {
+		ctrfPath := snapshotDir + suite + "/results/ctrf-report.json"
+		report, err := s.client.GetCTRFReport(ctx, ctrfPath, s.limits.MaxReportBytes)
 		if err != nil {
-			s.logger.Debug("failed to fetch ctrf report", "suite", name, "error", err)
+			s.logger.WarnContext(ctx, "skipped ctrf report", "suite", suite, "snapshot", name, "error", err)
+			suites = append(suites, unreadTestSuite(suite))
 			continue
 		}
-		suites = append(suites, suiteData{name: name, report: report})
-		if report.Results.Summary.Failed > 0 {
-			testsPassed = false
+		truncated := applyLimits(report, s.limits)
+		if truncated {
+			s.logger.WarnContext(ctx, "truncated ctrf report", "suite", suite, "snapshot", name,
+				"cases", report.Results.Summary.Tests, "retained", len(report.Results.Tests))
 		}
-	}
-	if len(suites) == 0 {
-		testsPassed = false
+		suites = append(suites, testSuite(suite, report, truncated))
 	}
 
-	snapshotRecord, err := s.store.CreateSnapshot(
-		ctx,
-		snap.Application,
-		snap.Snapshot,
-		testsPassed,
-		time.Now().UTC(),
-	)
+	// Scenarios that publish JUnit XML rather than a CTRF report.
+	junitReports, err := s.client.ListJUnitReports(ctx, snapshotDir)
 	if err != nil {
-		return fmt.Errorf("create snapshot: %w", err)
+		s.logger.DebugContext(ctx, "no junit reports found", "snapshot", name, "error", err)
 	}
-
-	for _, comp := range snap.Components {
-		if _, err := s.store.EnsureComponent(ctx, comp.Name); err != nil {
-			return fmt.Errorf("ensure component %s: %w", comp.Name, err)
+	for _, suite := range slices.Sorted(maps.Keys(junitReports)) {
+		if slices.Contains(suiteNames, suite) {
+			continue
+		}
+		report, err := s.client.GetJUnitReport(ctx, junitReports[suite], s.limits.MaxReportBytes, s.limits.MaxReportFiles)
+		if err != nil {
+			s.logger.WarnContext(ctx, "skipped junit report", "suite", suite, "snapshot", name, "error", err)
+			suites = append(suites, unreadTestSuite(suite))
+			continue
+		}
+		truncated := applyLimits(report, s.limits)
+		if truncated {
+			s.logger.WarnContext(ctx, "truncated junit report", "suite", suite, "snapshot", name,
+				"cases", report.Results.Summary.Tests, "retained", len(report.Results.Tests))
 		}
+		suites = append(suites, testSuite(suite, report, truncated))
+	}
+	return suites, testsPassed(suites, informational)
+}
 
-		if err := s.store.CreateSnapshotComponent(ctx, snapshotRecord.ID, comp.Name, comp.GitRevision, comp.ContainerImage, comp.GitURL); err != nil {
-			return fmt.Errorf("create snapshot component %s: %w", comp.Name, err)
+// addPushedTestSuites adds the test results pushed with the snapshot called
+// name to suites, replacing those of the same scenarios.
+func (s *Syncer) addPushedTestSuites(ctx context.Context, name string, suites []model.TestSuite, pushed map[string]*ctrf.Report) []model.TestSuite {
+	for _, scenario := range slices.Sorted(maps.Keys(pushed)) {
+		report := pushed[scenario]
+		truncated := applyLimits(report, s.limits)
+		if truncated {
+			s.logger.WarnContext(ctx, "truncated pushed report", "suite", scenario, "snapshot", name,
+				"cases", report.Results.Summary.Tests, "retained", len(report.Results.Tests))
+		}
+		suite := testSuite(scenario, report, truncated)
+		if i := slices.IndexFunc(suites, func(t model.TestSuite) bool { return t.Name == scenario }); i >= 0 {
+			suites[i] = suite
+		} else {
+			suites = append(suites, suite)
 		}
 	}
+	return suites
+}
 
-	for _, sd := range suites {
-		status := "passed"
-		if sd.report.Results.Summary.Failed > 0 {
-			status = "failed"
+// testsPassed reports whether suites passed: whether there are any, and no
+// scenario but informational ones failed or is still pending.
+func testsPassed(suites []model.TestSuite, informational map[string]bool) bool {
+	for _, suite := range suites {
+		if !informational[suite.Name] && suite.Status != "passed" {
+			return false
 		}
+	}
+	return len(suites) > 0
+}
 
-		sum := sd.report.Results.Summary
-		suiteID, err := s.store.CreateTestSuite(
-			ctx, snapshotRecord.ID,
-			sd.name, status, "",
-			sd.report.Results.Tool.Name, sd.report.Results.Tool.Version,
-			sum.Tests, sum.Passed, sum.Failed, sum.Skipped,
-			sum.Pending, sum.Other, sum.Flaky,
-			sum.Start, sum.Stop, sum.Stop-sum.Start,
-		)
+// collectSBOMs sets the SBOM of each component of record whose image has a
+// digest or whose SBOM document was uploaded under snapshotDir.
+func (s *Syncer) collectSBOMs(ctx context.Context, snapshotDir string, record *model.SnapshotRecord) {
+	for i := range record.Components {
+		c := &record.Components[i]
+		summary, err := s.client.GetSBOM(ctx, snapshotDir, c.Component, s.limits.MaxReportBytes)
 		if err != nil {
-			return fmt.Errorf("create test suite %s: %w", sd.name, err)
+			s.logger.DebugContext(ctx, "no sbom found", "component", c.Component, "snapshot", record.Name, "error", err)
+			summary = &model.ComponentSBOM{}
 		}
-
-		for _, tc := range sd.report.Results.Tests {
-			if err := s.store.CreateTestCase(
-				ctx, suiteID,
-				tc.Name, tc.Status, tc.Duration,
-				tc.Message, tc.Trace, tc.FilePath, tc.Suite,
-				tc.Retries, tc.Flaky,
-			); err != nil {
-				return fmt.Errorf("create test case %s: %w", tc.Name, err)
-			}
+		summary.Reference = sbom.Reference(c.ImageURL)
+		if summary.Reference == "" && summary.Format == "" {
+			continue
 		}
+		c.SBOM = summary
 	}
+}
 
-	// Ingest Clair vulnerability scans.
-	if err := s.ingestScans(ctx, snapshotDir, snapshotRecord.ID); err != nil {
-		s.logger.Error("ingest scans", "snapshot", snap.Snapshot, "error", err)
-	}
+// unreadTestSuite is the test suite of a scenario called name whose report
+// could not be read: failed, since its results are unknown, and truncated,
+// since none of them were kept.
+func unreadTestSuite(name string) model.TestSuite {
+	return model.TestSuite{Name: name, Status: "failed", Truncated: true}
+}
 
-	return nil
+// testSuite converts the CTRF report of the suite called name.
+func testSuite(name string, report *ctrf.Report, truncated bool) model.TestSuite {
+	status := "passed"
+	switch {
+	case report.Results.Summary.Failed > 0:
+		status = "failed"
+	case report.Results.Summary.Pending > 0:
+		// The scenario is still running; polls re-read it until it
+		// completes.
+		status = "pending"
+	}
+	sum := report.Results.Summary
+	pipelineRun := report.Results.Environment.BuildURL
+	if pipelineRun == "" {
+		pipelineRun = report.Results.Environment.BuildName
+	}
+	suite := model.TestSuite{
+		Name:        name,
+		Status:      status,
+		PipelineRun: pipelineRun,
+		ToolName:    report.Results.Tool.Name,
+		ToolVersion: report.Results.Tool.Version,
+		Tests:       sum.Tests,
+		Passed:      sum.Passed,
+		Failed:      sum.Failed,
+		Skipped:     sum.Skipped,
+		Pending:     sum.Pending,
+		Other:       sum.Other,
+		Flaky:       sum.Flaky,
+		StartTime:   sum.Start,
+		StopTime:    sum.Stop,
+		DurationMs:  sum.Stop - sum.Start,
+		Truncated:   truncated,
+	}
+	for _, tc := range report.Results.Tests {
+		suite.TestCases = append(suite.TestCases, model.TestCase{
+			Name:       tc.Name,
+			Status:     tc.Status,
+			DurationMs: tc.Duration,
+			Message:    tc.Message,
+			Trace:      tc.Trace,
+			FilePath:   tc.FilePath,
+			Suite:      tc.Suite,
+			Retries:    tc.Retries,
+			Flaky:      tc.Flaky,
+		})
+	}
+	return suite
 }
 
-// ingestScans fetches scan summary and clair reports from S3, persisting vulnerability data.
-func (s *Syncer) ingestScans(ctx context.Context, snapshotDir string, snapshotID int64) error {
+// collectScans fetches the scan summary and Clair reports under
+// snapshotDir.
+func (s *Syncer) collectScans(ctx context.Context, snapshotDir string) []model.VulnerabilityReport {
 	summary, err := s.client.GetScanSummary(ctx, snapshotDir)
 	if err != nil {
 		return nil // scans directory may not exist
 	}
 
+	var reports []model.VulnerabilityReport
 	for _, entry := range summary {
 		if entry.Status != "ok" {
 			continue
@@ -209,46 +660,43 @@ func (s *Syncer) ingestScans(ctx context.Context, snapshotDir string, snapshotID
 
 		reportKeys, err := s.client.ListClairReports(ctx, snapshotDir, entry.Component)
 		if err != nil {
-			s.logger.Debug("list clair reports", "component", entry.Component, "error", err)
+			s.logger.DebugContext(ctx, "list clair reports", "component", entry.Component, "error", err)
 			continue
 		}
 
 		for _, key := range reportKeys {
-			arch := archFromKey(key)
 			report, err := s.client.GetClairReport(ctx, key)
 			if err != nil {
-				s.logger.Debug("fetch clair report", "key", key, "error", err)
+				s.logger.DebugContext(ctx, "fetch clair report", "key", key, "error", err)
 				continue
 			}
 
 			counts := countSeverities(report)
-			reportID, err := s.store.CreateVulnerabilityReport(
-				ctx, snapshotID, entry.Component, arch,
-				counts.total, counts.critical, counts.high,
-				counts.medium, counts.low, counts.unknown, counts.fixable,
-			)
-			if err != nil {
-				return fmt.Errorf("create vulnerability report %s/%s: %w", entry.Component, arch, err)
+			r := model.VulnerabilityReport{
+				Component: entry.Component,
+				Arch:      archFromKey(key),
+				Total:     counts.total,
+				Critical:  counts.critical,
+				High:      counts.high,
+				Medium:    counts.medium,
+				Low:       counts.low,
+				Unknown:   counts.unknown,
+				Fixable:   counts.fixable,
 			}
-
 			for _, v := range report.Vulnerabilities {
-				link := firstLink(v.Links)
-				if err := s.store.CreateVulnerability(
-					ctx, reportID,
-					v.Name, v.NormalizedSeverity,
-					v.Package.Name, "",
-					v.FixedInVersion, v.Description, link,
-				); err != nil {
-					return fmt.Errorf("create vulnerability %s: %w", v.Name, err)
-				}
+				r.Vulnerabilities = append(r.Vulnerabilities, model.Vulnerability{
+					Name:           v.Name,
+					Severity:       v.NormalizedSeverity,
+					PackageName:    v.Package.Name,
+					FixedInVersion: v.FixedInVersion,
+					Description:    v.Description,
+					Link:           firstLink(v.Links),
+				})
 			}
-
-			s.logger.Info("ingested clair report",
-				"component", entry.Component, "arch", arch,
-				"vulnerabilities", counts.total)
+			reports = append(reports, r)
 		}
 	}
-	return nil
+	return reports
 }
 
 type severityCounts struct {
@@ -293,3 +741,41 @@ func firstLink(links string) string {
 	}
 	return links
 }
+
+// keyedMutex serialises work per key while letting different keys proceed
+// in parallel. The zero value is ready to use.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// lock locks key and returns the function that unlocks it.
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyLock)
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyLock{}
+		k.locks[key] = l
+	}
+	l.waiters++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
@@ -0,0 +1,670 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func putTestSnapshot(t *testing.T, store *MemoryStore, app, name string, failed int) {
+	t.Helper()
+	dir := app + "/snapshots/" + name + "/"
+	snapshot := map[string]any{
+		"application": app,
+		"components": []map[string]any{{
+			"name":           "quay",
+			"containerImage": "quay.io/quay/quay@sha256:abc",
+			"source":         map[string]any{"git": map[string]string{"url": "https://github.com/quay/quay", "revision": "abc123"}},
+		}},
+	}
+	if err := store.PutJSON(dir+"snapshot.json", snapshot); err != nil {
+		t.Fatal(err)
+	}
+	statusB := "passed"
+	if failed > 0 {
+		statusB = "failed"
+	}
+	report := ctrf.Report{Results: ctrf.Results{
+		Tool:    ctrf.Tool{Name: "pytest"},
+		Summary: ctrf.Summary{Tests: 2, Passed: 2 - failed, Failed: failed},
+		Tests: []ctrf.Test{
+			{Name: "test_a", Status: "passed"},
+			{Name: "test_b", Status: statusB},
+		},
+	}}
+	if err := store.PutJSON(dir+"api-tests/results/ctrf-report.json", report); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-16", "quay-v3-16-snap-1", 0)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 1)
+
+	syncer := NewSyncer(store, database, slog.Default())
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); !st.LastRunOK || st.ItemsProcessed != 2 {
+		t.Errorf("first run status: got %+v", st)
+	}
+	syncer.SyncOnce(ctx) // second pass must not duplicate snapshots
+	if st := syncer.RunStatus().Status(); st.ItemsProcessed != 0 {
+		t.Errorf("second run status: got %+v", st)
+	}
+
+	apps, err := database.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("applications: got %d, want 2", len(apps))
+	}
+	for _, app := range apps {
+		if app.SnapshotCount != 1 {
+			t.Errorf("%s snapshot count: got %d, want 1", app.Application, app.SnapshotCount)
+		}
+		wantPassed := app.Application == "quay-v3-16"
+		if app.LatestSnapshot.TestsPassed != wantPassed {
+			t.Errorf("%s tests passed: got %v, want %v", app.Application, app.LatestSnapshot.TestsPassed, wantPassed)
+		}
+	}
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Components) != 1 || snap.Components[0].GitSHA != "abc123" {
+		t.Errorf("components: got %+v", snap.Components)
+	}
+	if len(snap.TestSuites) != 1 || len(snap.TestSuites[0].TestCases) != 2 {
+		t.Errorf("test suites: got %+v", snap.TestSuites)
+	}
+}
+
+func TestInformationalScenario(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+	if err := database.SetScenarioRequirement(ctx, &model.ScenarioRequirement{Application: "quay-v3-17", Scenario: "api-tests"}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 1)
+	NewSyncer(store, database, slog.Default()).SyncOnce(ctx)
+
+	record, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !record.TestsPassed || len(record.TestSuites) != 1 || record.TestSuites[0].Status != "failed" {
+		t.Errorf("failing informational scenario: passed %v, suites %+v", record.TestsPassed, record.TestSuites)
+	}
+}
+
+func TestIngestSnapshot(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	// The pipeline uploaded some test results but pushes the snapshot
+	// itself, with the results of another scenario.
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+
+	syncer := NewSyncer(store, database, slog.Default())
+	broker := events.New()
+	published, cancel := broker.Subscribe()
+	defer cancel()
+	syncer.SetEvents(broker)
+	ctx := t.Context()
+	snap := &model.Snapshot{
+		Application: "quay-v3-17",
+		Snapshot:    "quay-v3-17-snap-1",
+		Components:  []model.SnapshotComponent{{Name: "quay", GitRevision: "abc123"}},
+	}
+	pushed := map[string]*ctrf.Report{"ui-tests": {Results: ctrf.Results{
+		Tool:    ctrf.Tool{Name: "junit"},
+		Summary: ctrf.Summary{Tests: 1, Passed: 1},
+		Tests:   []ctrf.Test{{Name: "test_login", Status: "passed"}},
+	}}}
+	created, err := syncer.IngestSnapshot(ctx, snap, pushed)
+	if err != nil || !created {
+		t.Fatalf("ingest: created %v, err %v", created, err)
+	}
+	if created, err := syncer.IngestSnapshot(ctx, snap, nil); err != nil || created {
+		t.Errorf("repeat ingest: created %v, err %v", created, err)
+	}
+	if len(published) != 1 {
+		t.Errorf("events: got %d, want 1 for the new snapshot", len(published))
+	} else if e := <-published; e.Kind != events.KindSnapshot || e.Snapshot != "quay-v3-17-snap-1" {
+		t.Errorf("event: got %+v", e)
+	}
+
+	record, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !record.TestsPassed || len(record.TestSuites) != 2 || record.TestSuites[0].Name != "api-tests" || record.TestSuites[1].Name != "ui-tests" {
+		t.Errorf("test results from S3 and pushed: passed %v, suites %+v", record.TestsPassed, record.TestSuites)
+	}
+
+	// The poller must not ingest it again.
+	syncer.SyncOnce(ctx)
+	apps, err := database.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].SnapshotCount != 1 {
+		t.Errorf("applications: got %+v, want one snapshot", apps)
+	}
+}
+
+// failingStore fails to save snapshots while fail is set.
+type failingStore struct {
+	*db.DB
+	fail bool
+}
+
+func (f *failingStore) SaveSnapshot(ctx context.Context, snap *model.SnapshotRecord) error {
+	if f.fail {
+		return fmt.Errorf("database is locked")
+	}
+	return f.DB.SaveSnapshot(ctx, snap)
+}
+
+func TestIngestFailures(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	failing := &failingStore{DB: database, fail: true}
+	syncer := NewSyncer(store, failing, slog.Default())
+	syncer.SetMaxAttempts(2)
+	ctx := t.Context()
+	failures := func() []model.IngestFailure {
+		t.Helper()
+		failures, err := database.ListIngestFailures(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return failures
+	}
+
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); st.LastRunOK {
+		t.Errorf("failed run status: got %+v", st)
+	}
+	f := failures()
+	if len(f) != 1 || f[0].Attempts != 1 || f[0].Dead || f[0].Snapshot != "quay-v3-17-snap-1" ||
+		f[0].Key != "quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json" || !strings.Contains(f[0].LastError, "database is locked") {
+		t.Fatalf("after first failure: got %+v", f)
+	}
+
+	syncer.SyncOnce(ctx)
+	if f := failures(); len(f) != 1 || f[0].Attempts != 2 || !f[0].Dead {
+		t.Fatalf("after second failure: got %+v, want dead", f)
+	}
+
+	// Polls skip the dead snapshot, even once it would ingest.
+	failing.fail = false
+	syncer.SyncOnce(ctx)
+	if f := failures(); len(f) != 1 || f[0].Attempts != 2 {
+		t.Errorf("dead snapshot retried: got %+v", f)
+	}
+	if exists, _ := database.SnapshotExistsByName(ctx, "quay-v3-17-snap-1"); exists {
+		t.Fatal("dead snapshot ingested by a poll")
+	}
+
+	ingested, err := syncer.Reingest(ctx, "quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json")
+	if err != nil || !ingested {
+		t.Fatalf("reingest: ingested %v, err %v", ingested, err)
+	}
+	if f := failures(); len(f) != 0 {
+		t.Errorf("failures after reingest: got %+v", f)
+	}
+	record, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !record.TestsPassed || len(record.TestSuites) != 1 {
+		t.Errorf("reingested snapshot: passed %v, suites %+v", record.TestsPassed, record.TestSuites)
+	}
+}
+
+func TestSyncOnceJUnit(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	dir := "quay-v3-17/snapshots/quay-v3-17-snap-1/junit/"
+	store.Put(dir+"ui-tests/results-1.xml", []byte(`<testsuite name="cypress"><testcase name="logs in" time="1.5"/></testsuite>`))
+	store.Put(dir+"ui-tests/results-2.xml", []byte(`<testsuites><testsuite name="cypress">
+  <testcase name="creates a repository" classname="repositories">
+    <failure message="Timed out retrying after 4000ms">AssertionError: Timed out retrying after 4000ms
+    at Context.eval (cypress/e2e/repositories.cy.ts:12:8)</failure>
+  </testcase>
+</testsuite></testsuites>`))
+	store.Put(dir+"ui-tests/screenshot.png", []byte("not xml"))
+	// A scenario with a CTRF report keeps it; its JUnit files are ignored.
+	store.Put(dir+"api-tests/results.xml", []byte(`<testsuite name="pytest"><testcase name="test_c"/></testsuite>`))
+
+	syncer := NewSyncer(store, database, slog.Default())
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.TestsPassed {
+		t.Error("tests passed: got true with a failing JUnit case")
+	}
+	if len(snap.TestSuites) != 2 || snap.TestSuites[0].Name != "api-tests" || len(snap.TestSuites[0].TestCases) != 2 {
+		t.Fatalf("test suites: got %+v", snap.TestSuites)
+	}
+	ui := snap.TestSuites[1]
+	if ui.Name != "ui-tests" || ui.Status != "failed" || ui.ToolName != "junit" || ui.Tests != 2 || ui.Failed != 1 {
+		t.Errorf("junit suite: got %+v", ui)
+	}
+	var failed *model.TestCase
+	for i := range ui.TestCases {
+		if ui.TestCases[i].Status == "failed" {
+			failed = &ui.TestCases[i]
+		}
+	}
+	if failed == nil {
+		t.Fatalf("junit cases: got %+v", ui.TestCases)
+	}
+	if failed.Name != "creates a repository" || failed.Suite != "repositories" || failed.Message != "Timed out retrying after 4000ms" {
+		t.Errorf("failed case: got %+v", failed)
+	}
+	if !strings.Contains(failed.Trace, "repositories.cy.ts:12:8") {
+		t.Errorf("failure output: got %q", failed.Trace)
+	}
+}
+
+func TestSyncOnceRejectedReports(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	dir := "quay-v3-17/snapshots/quay-v3-17-snap-1/"
+	for i := range 3 {
+		store.Put(fmt.Sprintf("%sjunit/ui-tests/results-%d.xml", dir, i), []byte(`<testsuite name="cypress"><testcase name="logs in"/></testsuite>`))
+	}
+	store.Put(dir+"upgrade-tests/results/ctrf-report.json", []byte(`{"results": {`))
+
+	syncer := NewSyncer(store, database, slog.Default())
+	syncer.SetLimits(Limits{MaxReportFiles: 2})
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The passing api-tests must not make the snapshot pass without the
+	// scenarios whose reports were rejected.
+	if snap.TestsPassed {
+		t.Error("tests passed: got true with rejected reports")
+	}
+	status := make(map[string]string)
+	for _, suite := range snap.TestSuites {
+		status[suite.Name] = suite.Status
+		if suite.Name != "api-tests" && (!suite.Truncated || len(suite.TestCases) != 0) {
+			t.Errorf("rejected suite %s: got %+v", suite.Name, suite)
+		}
+	}
+	if want := map[string]string{"api-tests": "passed", "ui-tests": "failed", "upgrade-tests": "failed"}; !maps.Equal(status, want) {
+		t.Errorf("suite statuses: got %v, want %v", status, want)
+	}
+}
+
+func TestSyncOnceEC(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-2", 0)
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/ec/ec-report.json", []byte(`{
+		"success": false,
+		"components": [
+			{"name": "quay-server", "containerImage": "quay.io/quay/quay-server@sha256:abc", "success": false,
+			 "violations": [{"msg": "No CVE scan results", "metadata": {"code": "cve.cve_results_found", "title": "CVE scan results found"}}],
+			 "warnings": [{"msg": "Base image registry not allowed", "metadata": {"code": "base_image_registries.base_image_permitted"}}]},
+			{"name": "quay-builder", "containerImage": "quay.io/quay/quay-builder@sha256:def", "success": true}
+		]
+	}`))
+
+	syncer := NewSyncer(store, database, slog.Default())
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	report, err := database.GetECReport(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Success || len(report.Components) != 2 {
+		t.Fatalf("ec report: got %+v", report)
+	}
+	builder, server := report.Components[0], report.Components[1]
+	if builder.Component != "quay-builder" || !builder.Success || len(builder.Violations) != 0 {
+		t.Errorf("quay-builder: got %+v", builder)
+	}
+	if server.Success || len(server.Violations) != 1 || server.Violations[0].Code != "cve.cve_results_found" ||
+		len(server.Warnings) != 1 || server.Warnings[0].Message != "Base image registry not allowed" {
+		t.Errorf("quay-server: got %+v", server)
+	}
+
+	// A snapshot without an EC report is stored with no results.
+	report, err = database.GetECReport(ctx, "quay-v3-17-snap-2")
+	if err != nil || report.Success || len(report.Components) != 0 {
+		t.Errorf("snapshot without ec report: got %+v, %v", report, err)
+	}
+}
+
+func TestSyncOnceSBOMs(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-2", 0)
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/sbom/quay.json", []byte(`{
+		"spdxVersion": "SPDX-2.3",
+		"packages": [{"licenseConcluded": "Apache-2.0"}, {"licenseDeclared": "MIT"}, {"licenseConcluded": "Apache-2.0"}]
+	}`))
+
+	syncer := NewSyncer(store, database, slog.Default())
+	syncer.SetSBOMs(true)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := snap.Components[0].SBOM
+	if got == nil || got.Reference != "quay.io/quay/quay:sha256-abc.sbom" || got.Format != "spdx" ||
+		got.Packages != 3 || strings.Join(got.Licenses, ",") != "Apache-2.0,MIT" {
+		t.Errorf("sbom: got %+v", got)
+	}
+
+	// Without an uploaded document only the reference is recorded.
+	if snap, err = database.GetSnapshotByName(ctx, "quay-v3-17-snap-2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := snap.Components[0].SBOM; got == nil || got.Reference == "" || got.Format != "" {
+		t.Errorf("sbom without document: got %+v", got)
+	}
+}
+
+func TestSyncOncePipelineRun(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	dir := "quay-v3-17/snapshots/quay-v3-17-snap-1/"
+	for suite, env := range map[string]ctrf.Environment{
+		"e2e-tests":     {BuildName: "quay-e2e-abc12", BuildURL: "https://konflux-ui.example.com/ns/quay-tenant/applications/quay-v3-17/pipelineruns/quay-e2e-abc12"},
+		"upgrade-tests": {BuildName: "quay-upgrade-def34"},
+	} {
+		report := ctrf.Report{Results: ctrf.Results{
+			Tool:        ctrf.Tool{Name: "pytest"},
+			Summary:     ctrf.Summary{Tests: 1, Passed: 1},
+			Tests:       []ctrf.Test{{Name: "test_a", Status: "passed"}},
+			Environment: env,
+		}}
+		if err := store.PutJSON(dir+suite+"/results/ctrf-report.json", report); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := t.Context()
+	NewSyncer(store, database, slog.Default()).SyncOnce(ctx)
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, s := range snap.TestSuites {
+		got[s.Name] = s.PipelineRun
+	}
+	want := map[string]string{
+		"api-tests":     "",
+		"e2e-tests":     "https://konflux-ui.example.com/ns/quay-tenant/applications/quay-v3-17/pipelineruns/quay-e2e-abc12",
+		"upgrade-tests": "quay-upgrade-def34",
+	}
+	for suite, run := range want {
+		if got[suite] != run {
+			t.Errorf("%s pipeline run: got %q, want %q", suite, got[suite], run)
+		}
+	}
+}
+
+func putTestRelease(t *testing.T, store *MemoryStore, app, name, snapshot, plan, released, reason string) {
+	t.Helper()
+	release := map[string]any{
+		"kind":     "Release",
+		"metadata": map[string]string{"name": name},
+		"spec":     map[string]string{"snapshot": snapshot, "releasePlan": plan},
+		"status": map[string]any{
+			"target":     "rhtap-releng-tenant",
+			"startTime":  "2026-03-02T10:00:00Z",
+			"conditions": []map[string]string{{"type": "Released", "status": released, "reason": reason}},
+		},
+	}
+	if err := store.PutJSON(app+"/releases/"+name+".json", release); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncOnceReleases(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.17.0", S3Application: "quay-v3-17"}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMemoryStore()
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	putTestRelease(t, store, "quay-v3-17", "snap-1-stage", "quay-v3-17-snap-1", "quay-v3-17-stage", "True", "Succeeded")
+	putTestRelease(t, store, "quay-v3-17", "snap-1-prod", "quay-v3-17-snap-1", "quay-v3-17-prod", "False", "Failed")
+	putTestRelease(t, store, "quay-v3-17", "snap-2-prod", "quay-v3-17-snap-2", "quay-v3-17-prod", "False", "Progressing")
+
+	syncer := NewSyncer(store, database, slog.Default())
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); !st.LastRunOK {
+		t.Errorf("run status: got %+v", st)
+	}
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Releases) != 2 {
+		t.Fatalf("releases: got %+v", snap.Releases)
+	}
+	for _, r := range snap.Releases {
+		if r.Target != "rhtap-releng-tenant" || r.StartTime == nil {
+			t.Errorf("release %s: got %+v", r.Name, r)
+		}
+	}
+	pipelines := func() model.ReleasePipelineSummary {
+		t.Helper()
+		selected, err := database.ListSelectedCandidates(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		candidate := selected["quay-v3.17.0"]
+		if candidate == nil || candidate.ReleasePipelines == nil {
+			t.Fatalf("selected candidate: got %+v", candidate)
+		}
+		return *candidate.ReleasePipelines
+	}
+	if got, want := pipelines(), (model.ReleasePipelineSummary{Succeeded: 1, Failed: 1}); got != want {
+		t.Errorf("pipelines: got %+v, want %+v", got, want)
+	}
+
+	// The release of snap-2 is stored once its snapshot is, and a retry of
+	// the failed release through the same plan resolves the failure.
+	putTestSnapshot(t, store, "quay-v3-17", "quay-v3-17-snap-2", 0)
+	putTestRelease(t, store, "quay-v3-17", "snap-1-prod-retry", "quay-v3-17-snap-1", "quay-v3-17-prod", "True", "Succeeded")
+	syncer.SyncOnce(ctx)
+
+	snap2, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap2.Releases) != 1 || snap2.Releases[0].Status != model.ReleaseProgressing {
+		t.Errorf("snap-2 releases: got %+v", snap2.Releases)
+	}
+	if err := database.SetCandidateState(ctx, "quay-v3.17.0", snap.ID, model.CandidatePromoted, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pipelines(), (model.ReleasePipelineSummary{Succeeded: 2}); got != want {
+		t.Errorf("pipelines after retry: got %+v, want %+v", got, want)
+	}
+}
+
+func TestSyncOnceConcurrent(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := NewMemoryStore()
+	for a := range 6 {
+		app := fmt.Sprintf("quay-v3-%d", 10+a)
+		for n := 1; n <= 3; n++ {
+			putTestSnapshot(t, store, app, fmt.Sprintf("%s-snap-%d", app, n), 0)
+		}
+	}
+
+	syncer := NewSyncer(store, database, slog.Default())
+	syncer.SetConcurrency(3)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+	if st := syncer.RunStatus().Status(); !st.LastRunOK || st.ItemsProcessed != 18 {
+		t.Fatalf("run status: got %+v", st)
+	}
+
+	// Each application's snapshots are stored in the order they are listed.
+	for a := range 6 {
+		app := fmt.Sprintf("quay-v3-%d", 10+a)
+		var last int64
+		for n := 1; n <= 3; n++ {
+			snap, err := database.GetSnapshotByName(ctx, fmt.Sprintf("%s-snap-%d", app, n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if snap.ID <= last {
+				t.Errorf("%s: snapshot %d stored out of order", app, n)
+			}
+			last = snap.ID
+		}
+	}
+}
+
+// countingStore counts the snapshot.json files actually downloaded.
+type countingStore struct {
+	*MemoryStore
+	downloads int
+}
+
+func (c *countingStore) GetSnapshotIfChanged(ctx context.Context, key, etag string) (*model.Snapshot, string, error) {
+	snap, current, err := c.MemoryStore.GetSnapshotIfChanged(ctx, key, etag)
+	if err == nil {
+		c.downloads++
+	}
+	return snap, current, err
+}
+
+func TestSyncOnceSkipsUnchanged(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	mem := NewMemoryStore()
+	putTestSnapshot(t, mem, "quay-v3-17", "quay-v3-17-snap-1", 0)
+	putTestSnapshot(t, mem, "quay-v3-17", "quay-v3-17-snap-2", 0)
+	store := &countingStore{MemoryStore: mem}
+
+	syncer := NewSyncer(store, database, slog.Default())
+	syncer.SetConcurrency(1)
+	ctx := t.Context()
+	syncer.SyncOnce(ctx)
+	if store.downloads != 2 {
+		t.Fatalf("first run: %d downloads, want 2", store.downloads)
+	}
+
+	store.downloads = 0
+	syncer.SyncOnce(ctx)
+	if store.downloads != 0 {
+		t.Errorf("unchanged run: %d downloads, want 0", store.downloads)
+	}
+
+	// A rewritten snapshot.json is fetched again but not stored twice.
+	if err := mem.PutJSON("quay-v3-17/snapshots/quay-v3-17-snap-2/snapshot.json", map[string]any{"application": "quay-v3-17"}); err != nil {
+		t.Fatal(err)
+	}
+	syncer.SyncOnce(ctx)
+	if store.downloads != 1 {
+		t.Errorf("after rewrite: %d downloads, want 1", store.downloads)
+	}
+	if st := syncer.RunStatus().Status(); !st.LastRunOK || st.ItemsProcessed != 0 {
+		t.Errorf("after rewrite: run status %+v", st)
+	}
+}
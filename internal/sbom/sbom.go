@@ -0,0 +1,114 @@
+// Package sbom summarises the SBOM documents Konflux publishes for each
+// component image and derives where the SBOM attestation is stored.
+package sbom
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Document formats.
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// MaxLicenses caps how many licenses a summary lists.
+const MaxLicenses = 5
+
+// Reference returns the reference of the SBOM attached to image by the
+// cosign convention Konflux follows: a tag named after the image digest
+// with an .sbom suffix, e.g. quay.io/org/repo:sha256-abc.sbom. It returns
+// "" for images without a digest.
+func Reference(image string) string {
+	repo, digest, ok := strings.Cut(image, "@")
+	if !ok {
+		return ""
+	}
+	alg, hex, ok := strings.Cut(digest, ":")
+	if !ok || hex == "" {
+		return ""
+	}
+	// Drop any tag; the SBOM tag replaces it.
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	return repo + ":" + alg + "-" + hex + ".sbom"
+}
+
+// Summarize counts the packages of an SPDX or CycloneDX JSON document and
+// lists its most common licenses, most used first.
+func Summarize(data []byte) (*model.ComponentSBOM, error) {
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+		} `json:"packages"`
+
+		BOMFormat  string `json:"bomFormat"`
+		Components []struct {
+			Licenses []struct {
+				License struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"license"`
+				Expression string `json:"expression"`
+			} `json:"licenses"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode sbom: %w", err)
+	}
+
+	counts := make(map[string]int)
+	s := &model.ComponentSBOM{}
+	switch {
+	case doc.SPDXVersion != "":
+		s.Format = FormatSPDX
+		s.Packages = len(doc.Packages)
+		for _, p := range doc.Packages {
+			license := p.LicenseConcluded
+			if !known(license) {
+				license = p.LicenseDeclared
+			}
+			if known(license) {
+				counts[license]++
+			}
+		}
+	case strings.EqualFold(doc.BOMFormat, "CycloneDX"):
+		s.Format = FormatCycloneDX
+		s.Packages = len(doc.Components)
+		for _, c := range doc.Components {
+			for _, l := range c.Licenses {
+				license := cmp.Or(l.Expression, l.License.ID, l.License.Name)
+				if known(license) {
+					counts[license]++
+				}
+			}
+		}
+	default:
+		return nil, errors.New("not an SPDX or CycloneDX document")
+	}
+
+	licenses := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		return cmp.Or(counts[b]-counts[a], strings.Compare(a, b))
+	})
+	if len(licenses) > MaxLicenses {
+		licenses = licenses[:MaxLicenses]
+	}
+	s.Licenses = licenses
+	return s, nil
+}
+
+// known reports whether an SPDX license field names a license.
+func known(license string) bool {
+	return license != "" && license != "NOASSERTION" && license != "NONE"
+}
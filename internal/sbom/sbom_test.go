@@ -0,0 +1,51 @@
+package sbom
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReference(t *testing.T) {
+	tests := []struct{ image, want string }{
+		{"quay.io/org/repo@sha256:abc", "quay.io/org/repo:sha256-abc.sbom"},
+		{"quay.io/org/repo:v1@sha256:abc", "quay.io/org/repo:sha256-abc.sbom"},
+		{"localhost:5000/repo@sha256:abc", "localhost:5000/repo:sha256-abc.sbom"},
+		{"quay.io/org/repo:v1", ""},
+	}
+	for _, tt := range tests {
+		if got := Reference(tt.image); got != tt.want {
+			t.Errorf("Reference(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	spdx := `{"spdxVersion":"SPDX-2.3","packages":[
+		{"licenseConcluded":"Apache-2.0"},
+		{"licenseConcluded":"NOASSERTION","licenseDeclared":"MIT"},
+		{"licenseConcluded":"Apache-2.0"},
+		{"licenseConcluded":"NOASSERTION","licenseDeclared":"NOASSERTION"}]}`
+	s, err := Summarize([]byte(spdx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Format != FormatSPDX || s.Packages != 4 || !slices.Equal(s.Licenses, []string{"Apache-2.0", "MIT"}) {
+		t.Errorf("spdx: got %+v", s)
+	}
+
+	cdx := `{"bomFormat":"CycloneDX","components":[
+		{"licenses":[{"license":{"id":"MIT"}}]},
+		{"licenses":[{"expression":"BSD-3-Clause OR MIT"}]},
+		{"licenses":[{"license":{"name":"Custom"}}]},
+		{}]}`
+	if s, err = Summarize([]byte(cdx)); err != nil {
+		t.Fatal(err)
+	}
+	if s.Format != FormatCycloneDX || s.Packages != 4 || !slices.Equal(s.Licenses, []string{"BSD-3-Clause OR MIT", "Custom", "MIT"}) {
+		t.Errorf("cyclonedx: got %+v", s)
+	}
+
+	if _, err := Summarize([]byte(`{"foo":1}`)); err == nil {
+		t.Error("unknown format: expected error")
+	}
+}
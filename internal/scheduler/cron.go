@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cron is a schedule given as the five fields of a crontab line: minute,
+// hour, day of month, month and day of week, in UTC.
+type cron struct {
+	minute, hour, dom, month, dow uint64 // bit sets of matching values
+	// domAny and dowAny are set for day fields starting with *. If both
+	// day fields are restricted, a day matching either matches, as in
+	// crontab.
+	domAny, dowAny bool
+}
+
+// cronFields are the bounds of each field.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron parses a crontab schedule such as "0 3 * * 1-5", evaluated in
+// UTC. Each field is *, a value, a range a-b, or either with a step /n, or
+// a comma-separated list of those. Day of week 0 and 7 are both Sunday;
+// names and the @ shorthands are not supported.
+func ParseCron(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron schedule %q: want 5 fields, got %d", spec, len(fields))
+	}
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("cron schedule %q: %s: %w", spec, cronFields[i].name, err)
+		}
+		sets[i] = set
+	}
+	c := &cron{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// parseCronField returns the set of values between lo and hi matched by
+// field.
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], n
+		}
+		from, to := lo, hi
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var errA, errB error
+			from, errA = strconv.Atoi(a)
+			to, errB = strconv.Atoi(b)
+			if errA != nil || errB != nil || from > to {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rng)
+			}
+			from = n
+			if step == 1 {
+				to = n
+			}
+		}
+		if from < lo || to > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first minute after t that the schedule matches, or the
+// zero time if none does within five years, as for February 30.
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day of month and day
+// of week fields.
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
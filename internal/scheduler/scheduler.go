@@ -0,0 +1,172 @@
+// Package scheduler runs the periodic background jobs, such as the S3 and
+// JIRA syncs, retention and digests, on an interval or a cron schedule. It
+// spreads runs with jitter, skips a run while the job's previous one is
+// still going, recovers from panics, and tracks every job's runs so that
+// the sync status API and the metrics report them alike.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quay/release-readiness/internal/runstatus"
+)
+
+// Schedule tells when a job runs next.
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time if the
+	// job does not run again.
+	Next(t time.Time) time.Time
+}
+
+// every is a fixed interval.
+type every time.Duration
+
+// Every returns a schedule repeating every d.
+func Every(d time.Duration) Schedule {
+	return every(d)
+}
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// Job is a periodic background job.
+type Job struct {
+	// Name names the job in the logs and the sync status API; it defaults
+	// to the name of Status.
+	Name     string
+	Schedule Schedule
+	// Jitter delays each scheduled run by a random duration up to it, so
+	// that jobs on the same schedule do not all start at once. The run
+	// when the scheduler starts is not delayed.
+	Jitter time.Duration
+	Run    func(ctx context.Context)
+	// Status is set for jobs that record their own runs, like the syncers
+	// do to count what they store and report their errors. For other jobs
+	// the scheduler records each run, failed only if it panics.
+	Status *runstatus.Tracker
+}
+
+// Scheduler runs jobs. Jobs are added before Run is called.
+type Scheduler struct {
+	logger *slog.Logger
+	jobs   []*job
+}
+
+type job struct {
+	Job
+	status    *runstatus.Tracker
+	ownStatus bool
+	running   atomic.Bool
+}
+
+// New creates a Scheduler.
+func New(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers j and returns the tracker of its runs.
+func (s *Scheduler) Add(j Job) *runstatus.Tracker {
+	entry := &job{Job: j, status: j.Status, ownStatus: j.Status != nil}
+	if entry.status == nil {
+		entry.status = runstatus.New(j.Name)
+	} else if entry.Name == "" {
+		entry.Name = entry.status.Status().Name
+	}
+	s.jobs = append(s.jobs, entry)
+	return entry.status
+}
+
+// Trackers returns the trackers of the jobs' runs, in the order they were
+// added.
+func (s *Scheduler) Trackers() []*runstatus.Tracker {
+	trackers := make([]*runstatus.Tracker, len(s.jobs))
+	for i, j := range s.jobs {
+		trackers[i] = j.status
+	}
+	return trackers
+}
+
+// Run runs every job immediately and then on its schedule until ctx is
+// cancelled, and returns once the runs in progress have ended.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, j := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, j, &wg)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop starts the runs of j until ctx is cancelled.
+func (s *Scheduler) loop(ctx context.Context, j *job, runs *sync.WaitGroup) {
+	s.start(ctx, j, runs)
+	for {
+		next := j.Schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.WarnContext(ctx, "no further runs scheduled", "job", j.Name)
+			return
+		}
+		if j.Jitter > 0 {
+			next = next.Add(rand.N(j.Jitter))
+		}
+		j.status.Schedule(next)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.logger.InfoContext(ctx, "stopping", "job", j.Name)
+			return
+		case <-timer.C:
+			s.start(ctx, j, runs)
+		}
+	}
+}
+
+// start runs j in the background, unless its previous run has not ended.
+func (s *Scheduler) start(ctx context.Context, j *job, runs *sync.WaitGroup) {
+	if !j.running.CompareAndSwap(false, true) {
+		s.logger.WarnContext(ctx, "skipping run, previous one still running", "job", j.Name)
+		return
+	}
+	runs.Add(1)
+	go func() {
+		defer runs.Done()
+		defer j.running.Store(false)
+		s.runOnce(ctx, j)
+	}()
+}
+
+// runOnce runs j, recording the run unless j records its own, and
+// recovers from a panic of the job as a failed run.
+func (s *Scheduler) runOnce(ctx context.Context, j *job) {
+	var run *runstatus.Run
+	if !j.ownStatus {
+		run = j.status.Start()
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err := fmt.Errorf("panic: %v", v)
+			s.logger.ErrorContext(ctx, "job panicked", "job", j.Name, "error", err, "stack", string(debug.Stack()))
+			if run == nil {
+				j.status.Fail(err)
+			} else {
+				run.Fail(err)
+			}
+		}
+		if run != nil {
+			run.Finish()
+		}
+	}()
+	j.Run(ctx)
+}
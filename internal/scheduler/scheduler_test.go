@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/runstatus"
+)
+
+func TestParseCron(t *testing.T) {
+	// A Wednesday.
+	from := time.Date(2026, 3, 18, 10, 30, 0, 0, time.UTC)
+	for spec, want := range map[string]time.Time{
+		"* * * * *":        time.Date(2026, 3, 18, 10, 31, 0, 0, time.UTC),
+		"0 3 * * *":        time.Date(2026, 3, 19, 3, 0, 0, 0, time.UTC),
+		"*/20 * * * *":     time.Date(2026, 3, 18, 10, 40, 0, 0, time.UTC),
+		"15,45 9-17 * * *": time.Date(2026, 3, 18, 10, 45, 0, 0, time.UTC),
+		"0 9 * * 1-5":      time.Date(2026, 3, 19, 9, 0, 0, 0, time.UTC),
+		"0 0 * * 7":        time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":        time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":       time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		// Either day field matches when both are restricted.
+		"0 0 25 * 5": time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *": {},
+	} {
+		sched, err := ParseCron(spec)
+		if err != nil {
+			t.Errorf("%q: %v", spec, err)
+			continue
+		}
+		if got := sched.Next(from); !got.Equal(want) {
+			t.Errorf("%q: got %v, want %v", spec, got, want)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("%q: want error", spec)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// A slow job is not started again while it runs.
+	var slowRuns atomic.Int32
+	release := make(chan struct{})
+	s.Add(Job{Name: "slow", Schedule: Every(time.Millisecond), Run: func(ctx context.Context) {
+		if slowRuns.Add(1) == 1 {
+			<-release
+		}
+	}})
+
+	// A panicking job keeps being scheduled, its runs failed.
+	var panics atomic.Int32
+	panicking := s.Add(Job{Name: "panicking", Schedule: Every(time.Millisecond), Jitter: time.Millisecond, Run: func(ctx context.Context) {
+		panics.Add(1)
+		panic("nil map")
+	}})
+
+	// A job recording its own runs is not recorded again.
+	own := runstatus.New("own")
+	var ownRuns atomic.Int32
+	s.Add(Job{Name: "own", Schedule: Every(time.Hour), Status: own, Run: func(ctx context.Context) {
+		run := own.Start()
+		run.Add(2)
+		run.Finish()
+		ownRuns.Add(1)
+	}})
+
+	if got := s.Trackers(); len(got) != 3 || got[1] != panicking || got[2] != own {
+		t.Fatalf("trackers: got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for panics.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := slowRuns.Load(); n != 1 {
+		t.Errorf("slow job: got %d runs while the first was running, want 1", n)
+	}
+	close(release)
+	cancel()
+	<-done
+
+	if st := panicking.Status(); panics.Load() < 3 || st.LastRunOK || st.LastError != "panic: nil map" || st.NextRunAt == nil {
+		t.Errorf("panicking job: got %d runs, status %+v", panics.Load(), st)
+	}
+	if st := own.Status(); ownRuns.Load() != 1 || !st.LastRunOK || st.ItemsProcessed != 2 || st.NextRunAt == nil {
+		t.Errorf("own status: got %d runs, status %+v", ownRuns.Load(), st)
+	}
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Roles, weakest first. Each role includes the ones before it: a
+// release manager can also report results, and an admin can do anything.
+const (
+	RoleViewer         = "viewer"          // read endpoints, when reads are private
+	RoleReporter       = "reporter"        // pushing snapshots
+	RoleReleaseManager = "release-manager" // candidates, sign-offs, advisories
+	RoleAdmin          = "admin"           // mappings, audit holds, admin API
+)
+
+var roles = []string{RoleViewer, RoleReporter, RoleReleaseManager, RoleAdmin}
+
+// legacyScopes maps the scopes tokens were issued with before roles to the
+// role each now grants.
+var legacyScopes = map[string]string{
+	"read":  RoleViewer,
+	"write": RoleReleaseManager,
+	"admin": RoleAdmin,
+}
+
+// APIToken is a static bearer token and the role it grants.
+type APIToken struct {
+	Name  string `json:"name"` // identifies the holder in logs
+	Token string `json:"token"`
+	Role  string `json:"role,omitempty"`
+	// Scope is the read, write or admin scope of tokens issued before
+	// roles; it is used if Role is empty.
+	Scope string `json:"scope,omitempty"`
+}
+
+func (t APIToken) validate() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if t.Token == "" {
+		return errors.New("token is required")
+	}
+	if t.Role != "" && t.Scope != "" {
+		return errors.New("set role or scope, not both")
+	}
+	if t.Scope != "" && legacyScopes[t.Scope] == "" {
+		return fmt.Errorf("scope %q must be one of read, write, admin", t.Scope)
+	}
+	if t.Scope == "" && !slices.Contains(roles, t.Role) {
+		return fmt.Errorf("role %q must be one of %s", t.Role, strings.Join(roles, ", "))
+	}
+	return nil
+}
+
+// role returns the role the token grants.
+func (t APIToken) role() string {
+	if t.Role == "" {
+		return legacyScopes[t.Scope]
+	}
+	return t.Role
+}
+
+// includes reports whether granted includes role.
+func includes(granted, role string) bool {
+	return granted != "" && slices.Index(roles, granted) >= slices.Index(roles, role)
+}
+
+// LoadAPITokens reads API tokens from a JSON file holding an array of
+// {"name", "token", "role"} objects.
+func LoadAPITokens(path string) ([]APIToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []APIToken
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	seen := make(map[string]bool, len(tokens))
+	for i, t := range tokens {
+		if err := t.validate(); err != nil {
+			return nil, fmt.Errorf("%s: token %d: %w", path, i+1, err)
+		}
+		if seen[t.Token] {
+			return nil, fmt.Errorf("%s: token %d (%s) duplicates an earlier token", path, i+1, t.Name)
+		}
+		seen[t.Token] = true
+	}
+	return tokens, nil
+}
+
+// ParseGroupRoles parses a comma-separated list of group=role pairs.
+func ParseGroupRoles(s string) (map[string]string, error) {
+	groupRoles := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		if !ok || group == "" {
+			return nil, fmt.Errorf("%q is not group=role", pair)
+		}
+		if !slices.Contains(roles, role) {
+			return nil, fmt.Errorf("group %s: role %q must be one of %s", group, role, strings.Join(roles, ", "))
+		}
+		groupRoles[group] = role
+	}
+	return groupRoles, nil
+}
+
+// SetAPITokens adds tokens to those accepted by the API.
+func (s *Server) SetAPITokens(tokens []APIToken) {
+	s.tokens = append(s.tokens, tokens...)
+}
+
+// SetGroupRoles grants the roles of groupRoles to requests whose header
+// lists the group, as a comma-separated list. The header must be set by an
+// authenticating proxy in front of the server, such as oauth2-proxy's
+// X-Forwarded-Groups for OIDC groups; requests that reach the server
+// otherwise could forge it. An empty header disables group roles.
+func (s *Server) SetGroupRoles(header string, groupRoles map[string]string) {
+	s.groupsHeader = header
+	s.groupRoles = groupRoles
+}
+
+// SetPublicReads sets whether read endpoints may be called without a token.
+// They are public by default; otherwise they require the viewer role.
+func (s *Server) SetPublicReads(public bool) {
+	s.privateReads = !public
+}
+
+// token returns the configured token matching the request's
+// "Authorization: Bearer <token>" header.
+func (s *Server) token(r *http.Request) (APIToken, bool) {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return APIToken{}, false
+	}
+	var match APIToken
+	found := false
+	// Compare against every token so timing does not reveal which matched.
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(t.Token)) == 1 {
+			match, found = t, true
+		}
+	}
+	return match, found
+}
+
+// principal returns who made the request and the role they have: the
+// holder of its bearer token, or else the proxy-authenticated user whose
+// groups map to the strongest role. ok is false for anonymous requests.
+func (s *Server) principal(r *http.Request) (name, role string, ok bool) {
+	if token, ok := s.token(r); ok {
+		return token.Name, token.role(), true
+	}
+	if s.groupsHeader == "" {
+		return "", "", false
+	}
+	for group := range strings.SplitSeq(r.Header.Get(s.groupsHeader), ",") {
+		if gr := s.groupRoles[strings.TrimSpace(group)]; gr != "" && !includes(role, gr) {
+			role = gr
+		}
+	}
+	if role == "" {
+		return "", "", false
+	}
+	name = r.Header.Get("X-Forwarded-User")
+	if name == "" {
+		name = "group member"
+	}
+	return name, role, true
+}
+
+// roleEnabled reports whether any token or group grants role.
+func (s *Server) roleEnabled(role string) bool {
+	if slices.ContainsFunc(s.tokens, func(t APIToken) bool { return includes(t.role(), role) }) {
+		return true
+	}
+	if s.groupsHeader == "" {
+		return false
+	}
+	for _, gr := range s.groupRoles {
+		if includes(gr, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// requireRole rejects requests whose token or groups do not grant role.
+// Endpoints needing a role that no token or group grants are disabled.
+func (s *Server) requireRole(role string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.roleEnabled(role) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("no API token or group has the %s role; this endpoint is disabled", role))
+			return
+		}
+		name, granted, ok := s.principal(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="release-readiness"`)
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("invalid or missing API token"))
+			return
+		}
+		if !includes(granted, role) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("%s has the %s role, not %s", name, granted, role))
+			return
+		}
+		if role != RoleViewer {
+			s.logger.DebugContext(r.Context(), "authorized", "principal", name, "role", role)
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireReporter guards endpoints that feed results in.
+func (s *Server) requireReporter(next http.HandlerFunc) http.Handler {
+	return s.requireRole(RoleReporter, next)
+}
+
+// requireReleaseManager guards release decisions: candidates, sign-offs
+// and advisories.
+func (s *Server) requireReleaseManager(next http.HandlerFunc) http.Handler {
+	return s.requireRole(RoleReleaseManager, next)
+}
+
+// requireAdmin guards configuration and operational endpoints.
+func (s *Server) requireAdmin(next http.HandlerFunc) http.Handler {
+	return s.requireRole(RoleAdmin, next)
+}
+
+// read guards read endpoints, which are public unless SetPublicReads(false)
+// was called, and lets clients revalidate their responses by ETag.
+func (s *Server) read(next http.HandlerFunc) http.Handler {
+	protected := s.requireRole(RoleViewer, next)
+	return etagMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.privateReads {
+			next(w, r)
+			return
+		}
+		protected.ServeHTTP(w, r)
+	}))
+}
+
+type whoAmI struct {
+	Name string `json:"name,omitempty"`
+	Role string `json:"role,omitempty"`
+}
+
+// handleWhoAmI returns who the request is authenticated as and their role,
+// both empty for anonymous requests, so the web UI can offer the actions
+// a proxy-authenticated user may take.
+func (s *Server) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	name, role, _ := s.principal(r)
+	writeJSON(w, http.StatusOK, whoAmI{Name: name, Role: role})
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestAPITokenRoles(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) int {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+	approve := func(token string) int {
+		return do("POST", "/api/v1/releases/3.16.3/approvals", `{"approver":"jane","role":"QE"}`, token)
+	}
+	setBuckets := func(token string) int {
+		return do("PUT", "/api/v1/issue-buckets", `[]`, token)
+	}
+
+	// With no tokens, reads are public and writes are disabled.
+	if code := do("GET", "/api/v1/releases/overview", "", ""); code != http.StatusOK {
+		t.Errorf("public read: got %d", code)
+	}
+	if code := approve(""); code != http.StatusForbidden {
+		t.Errorf("write without tokens: got %d, want 403", code)
+	}
+
+	srv.SetAPITokens([]APIToken{
+		{Name: "dashboard", Token: "r", Role: RoleViewer},
+		{Name: "ci", Token: "p", Role: RoleReporter},
+		{Name: "release-lead", Token: "w", Role: RoleReleaseManager},
+		{Name: "legacy", Token: "l", Scope: "write"},
+	})
+	srv.SetAdmin("a", nil)
+
+	for _, tc := range []struct {
+		name string
+		code int
+		want int
+	}{
+		{"approve, no token", approve(""), http.StatusUnauthorized},
+		{"approve, unknown token", approve("x"), http.StatusUnauthorized},
+		{"approve, viewer", approve("r"), http.StatusForbidden},
+		{"approve, reporter", approve("p"), http.StatusForbidden},
+		{"approve, release manager", approve("w"), http.StatusCreated},
+		{"approve, legacy write scope", approve("l"), http.StatusCreated},
+		{"approve, admin", approve("a"), http.StatusCreated},
+		{"buckets, release manager", setBuckets("w"), http.StatusForbidden},
+		{"buckets, admin", setBuckets("a"), http.StatusOK},
+	} {
+		if tc.code != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, tc.code, tc.want)
+		}
+	}
+
+	srv.SetPublicReads(false)
+	if code := do("GET", "/api/v1/releases/overview", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("private read without token: got %d, want 401", code)
+	}
+	if code := do("GET", "/api/v1/releases/overview", "", "r"); code != http.StatusOK {
+		t.Errorf("private read with viewer token: got %d", code)
+	}
+	if code := do("GET", "/api/v1/releases/overview", "", "w"); code != http.StatusOK {
+		t.Errorf("private read with release manager token: got %d", code)
+	}
+	if code := do("GET", "/api/v1/health", "", ""); code != http.StatusOK {
+		t.Errorf("health with private reads: got %d", code)
+	}
+}
+
+func TestLoadAPITokens(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "tokens.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tokens, err := LoadAPITokens(write(`[{"name":"ci","token":"t1","role":"reporter"},{"name":"grafana","token":"t2","scope":"read"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[0].Name != "ci" || tokens[0].role() != RoleReporter || tokens[1].role() != RoleViewer {
+		t.Errorf("tokens: got %+v", tokens)
+	}
+
+	for name, content := range map[string]string{
+		"bad scope": `[{"name":"ci","token":"t1","scope":"owner"}]`,
+		"bad role":  `[{"name":"ci","token":"t1","role":"owner"}]`,
+		"no role":   `[{"name":"ci","token":"t1"}]`,
+		"both":      `[{"name":"ci","token":"t1","role":"admin","scope":"read"}]`,
+		"no token":  `[{"name":"ci","role":"viewer"}]`,
+		"no name":   `[{"token":"t1","role":"viewer"}]`,
+		"duplicate": `[{"name":"a","token":"t1","scope":"read"},{"name":"b","token":"t1","scope":"admin"}]`,
+		"not json":  `{`,
+	} {
+		if _, err := LoadAPITokens(write(content)); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
+
+func TestGroupRoles(t *testing.T) {
+	srv, database := setupTestServer(t)
+	if err := database.UpsertReleaseVersion(t.Context(), &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	roles, err := ParseGroupRoles("quay-release-managers=release-manager, quay-admins=admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetGroupRoles("X-Forwarded-Groups", roles)
+
+	do := func(method, path, body, groups string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Forwarded-User", "jane")
+		if groups != "" {
+			req.Header.Set("X-Forwarded-Groups", groups)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	approve := func(groups string) int {
+		return do("POST", "/api/v1/releases/3.16.3/approvals", `{"approver":"jane","role":"QE"}`, groups).Code
+	}
+
+	for _, tc := range []struct {
+		name string
+		code int
+		want int
+	}{
+		{"approve, no groups", approve(""), http.StatusUnauthorized},
+		{"approve, unmapped group", approve("devs"), http.StatusUnauthorized},
+		{"approve, release managers", approve("devs,quay-release-managers"), http.StatusCreated},
+		{"buckets, release managers", do("PUT", "/api/v1/issue-buckets", `[]`, "quay-release-managers").Code, http.StatusForbidden},
+		{"buckets, admins", do("PUT", "/api/v1/issue-buckets", `[]`, "quay-release-managers,quay-admins").Code, http.StatusOK},
+		// No group is mapped to reporter, but stronger roles include it;
+		// the request is authorized and fails for want of an ingester.
+		{"ingest, release managers", do("POST", "/api/v1/ingest/snapshot", `{`, "quay-release-managers").Code, http.StatusNotImplemented},
+	} {
+		if tc.code != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, tc.code, tc.want)
+		}
+	}
+
+	w := do("GET", "/api/v1/whoami", "", "quay-release-managers")
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `"name":"jane"`) || !strings.Contains(body, `"role":"release-manager"`) {
+		t.Errorf("whoami: got %d %s", w.Code, body)
+	}
+	if w := do("GET", "/api/v1/whoami", "", ""); strings.TrimSpace(w.Body.String()) != "{}" {
+		t.Errorf("anonymous whoami: got %s", w.Body.String())
+	}
+
+	for _, bad := range []string{"quay-admins", "=admin", "quay-admins=owner"} {
+		if _, err := ParseGroupRoles(bad); err == nil {
+			t.Errorf("ParseGroupRoles(%q): want error", bad)
+		}
+	}
+}
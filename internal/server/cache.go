@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cacheTTL matches the Cache-Control max-age sent on JSON responses.
+const cacheTTL = 30 * time.Second
+
+// ttlCache memoizes a single expensive read for a short TTL. Loads are
+// serialized so a burst of concurrent requests against a cold cache results
+// in one database query rather than a stampede.
+type ttlCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	value   T
+	expires time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{ttl: ttl}
+}
+
+// get returns the cached value, calling load to refresh it if it has expired.
+func (c *ttlCache[T]) get(ctx context.Context, load func(context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Now().Before(c.expires) {
+		return c.value, nil
+	}
+	v, err := load(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.value = v
+	c.expires = time.Now().Add(c.ttl)
+	return v, nil
+}
+
+// invalidate drops the cached value so the next get reloads it.
+func (c *ttlCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires = time.Time{}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type logLevelResponse struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+type logLevelRequest struct {
+	// Component limits the change to one component (e.g. "s3-sync");
+	// empty changes the default level.
+	Component string `json:"component"`
+	// Level is a slog level name such as "debug" or "warn". An empty level
+	// with a component removes that component's override.
+	Level string `json:"level"`
+}
+
+func (s *Server) handleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if s.logLevels == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("runtime log levels not configured"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.logLevelResponse())
+}
+
+func (s *Server) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if s.logLevels == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("runtime log levels not configured"))
+		return
+	}
+	var req logLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if req.Level == "" {
+		if req.Component == "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("level is required"))
+			return
+		}
+		s.logLevels.Reset(req.Component)
+	} else {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(req.Level)); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		s.logLevels.Set(req.Component, level)
+	}
+	s.logger.InfoContext(r.Context(), "log level changed", "target", req.Component, "level", req.Level)
+	writeJSON(w, http.StatusOK, s.logLevelResponse())
+}
+
+func (s *Server) logLevelResponse() logLevelResponse {
+	base, overrides := s.logLevels.Snapshot()
+	resp := logLevelResponse{Level: base.String(), Components: map[string]string{}}
+	for c, l := range overrides {
+		resp.Components[c] = l.String()
+	}
+	return resp
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/logging"
+)
+
+func TestAdminLogLevel(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	do := func(method, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/admin/log-level", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("GET", "", "secret"); w.Code != http.StatusForbidden {
+		t.Errorf("admin disabled: got %d, want 403", w.Code)
+	}
+
+	levels := logging.NewLevels(slog.LevelInfo)
+	srv.SetAdmin("secret", levels)
+
+	if w := do("GET", "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("missing token: got %d, want 401", w.Code)
+	}
+	if w := do("GET", "", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: got %d, want 401", w.Code)
+	}
+
+	w := do("PUT", `{"component":"s3-sync","level":"debug"}`, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("set component level: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("cache-control: got %q, want no-store", got)
+	}
+	var resp logLevelResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Level != "INFO" || resp.Components["s3-sync"] != "DEBUG" {
+		t.Errorf("response: got %+v", resp)
+	}
+	if levels.Level("s3-sync") != slog.LevelDebug || levels.Level("jira-sync") != slog.LevelInfo {
+		t.Errorf("levels not applied: s3-sync=%v jira-sync=%v", levels.Level("s3-sync"), levels.Level("jira-sync"))
+	}
+
+	if w := do("PUT", `{"level":"warn"}`, "secret"); w.Code != http.StatusOK {
+		t.Errorf("set default level: got %d", w.Code)
+	}
+	if w := do("PUT", `{"component":"s3-sync"}`, "secret"); w.Code != http.StatusOK {
+		t.Errorf("reset component level: got %d", w.Code)
+	}
+	if got := levels.Level("s3-sync"); got != slog.LevelWarn {
+		t.Errorf("s3-sync after reset: got %v, want WARN", got)
+	}
+
+	if w := do("PUT", `{"level":"loud"}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid level: got %d, want 400", w.Code)
+	}
+	if w := do("PUT", `{}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("empty request: got %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/report"
+)
+
+// handleGetReleaseAdvisory returns the Errata Tool advisory configured for
+// a release and the state last synced from it.
+func (s *Server) handleGetReleaseAdvisory(w http.ResponseWriter, r *http.Request) {
+	version := r.PathValue("version")
+	adv, err := s.db.GetReleaseAdvisory(r.Context(), version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("advisory for release %q", version))
+		return
+	}
+	writeJSON(w, http.StatusOK, adv)
+}
+
+type releaseAdvisoryRequest struct {
+	AdvisoryID int64 `json:"advisory_id"`
+}
+
+// handleSetReleaseAdvisory configures the advisory tracked for a release.
+// The advisory is synced from the Errata Tool on the next sync cycle. It
+// is a release-manager endpoint.
+func (s *Server) handleSetReleaseAdvisory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	var req releaseAdvisoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.AdvisoryID <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("advisory_id must be a positive advisory number"))
+		return
+	}
+	adv, err := s.db.SetReleaseAdvisory(ctx, version, req.AdvisoryID)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("advisory for release %q", version))
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "release advisory set", "release", version, "advisory", adv.AdvisoryID)
+	writeJSON(w, http.StatusOK, adv)
+}
+
+// handleDeleteReleaseAdvisory stops tracking a release's advisory. It is
+// a release-manager endpoint.
+func (s *Server) handleDeleteReleaseAdvisory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if err := s.db.DeleteReleaseAdvisory(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("advisory for release %q", version))
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "release advisory removed", "release", version)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// releaseAdvisory returns the advisory configured for release, or nil if
+// there is none.
+func (s *Server) releaseAdvisory(ctx context.Context, release string) (*model.Advisory, error) {
+	adv, err := s.db.GetReleaseAdvisory(ctx, release)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	return adv, err
+}
+
+// applyAdvisory factors the advisory of an unreleased release into its
+// readiness: a dropped advisory forces red, and an advisory that has not
+// passed QE, or has not been synced yet, withholds green, unless one of
+// overrides waives the rule.
+func applyAdvisory(readiness *model.ReadinessResponse, adv *model.Advisory, overrides []model.ReadinessOverride) {
+	if adv == nil {
+		return
+	}
+	readiness.AdvisoryState = adv.State
+	if adv.State == model.AdvisoryDropped {
+		if o, ok := waiver(overrides, model.RuleAdvisoryDropped); ok {
+			waive(readiness, o)
+		} else if readiness.Signal != "red" {
+			readiness.Signal = "red"
+			readiness.Message = fmt.Sprintf("Advisory %s dropped", adv.Name)
+			readiness.Rule = model.RuleAdvisoryDropped
+		}
+		return
+	}
+	if adv.Ready() {
+		return
+	}
+	if o, ok := waiver(overrides, model.RuleAdvisoryNotReady); ok {
+		waive(readiness, o)
+		return
+	}
+	if readiness.Signal != "green" {
+		return
+	}
+	readiness.Signal = "yellow"
+	readiness.Rule = model.RuleAdvisoryNotReady
+	readiness.Message = fmt.Sprintf("Advisory %s in %s", adv.Name, adv.State)
+	if adv.SyncedAt == nil {
+		readiness.Message = fmt.Sprintf("Advisory %d not yet synced", adv.AdvisoryID)
+	}
+}
+
+// advisoryCheck reports whether the advisory of a release is ready, for
+// the go/no-go report.
+func advisoryCheck(release *model.ReleaseVersion, adv *model.Advisory) report.Check {
+	c := report.Check{Name: "Advisory", Passed: release.Released || adv.Ready(), Detail: adv.Name + " in " + adv.State}
+	if adv.SyncedAt == nil {
+		c.Detail = fmt.Sprintf("Advisory %d not yet synced", adv.AdvisoryID)
+	}
+	return c
+}
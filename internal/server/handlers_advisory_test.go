@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleaseAdvisory(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	readiness := func() model.ReadinessResponse {
+		t.Helper()
+		w := do("GET", "/api/v1/releases/quay-v3.16.3/readiness", "", "")
+		var resp model.ReadinessResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	for _, tc := range []struct {
+		name, method, path, body, token string
+		want                            int
+	}{
+		{"no advisory", "GET", "/api/v1/releases/quay-v3.16.3/advisory", "", "", http.StatusNotFound},
+		{"no token", "PUT", "/api/v1/releases/quay-v3.16.3/advisory", `{"advisory_id":1234}`, "", http.StatusUnauthorized},
+		{"unknown release", "PUT", "/api/v1/releases/quay-v9.9.9/advisory", `{"advisory_id":1234}`, "secret", http.StatusNotFound},
+		{"missing id", "PUT", "/api/v1/releases/quay-v3.16.3/advisory", `{}`, "secret", http.StatusBadRequest},
+		{"delete missing", "DELETE", "/api/v1/releases/quay-v3.16.3/advisory", "", "secret", http.StatusNotFound},
+	} {
+		if w := do(tc.method, tc.path, tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	if r := readiness(); r.Signal != "green" || r.AdvisoryState != "" {
+		t.Fatalf("readiness without advisory: got %+v", r)
+	}
+
+	if w := do("PUT", "/api/v1/releases/quay-v3.16.3/advisory", `{"advisory_id":1234}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("set advisory: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if r := readiness(); r.Signal != "yellow" || r.Message != "Advisory 1234 not yet synced" {
+		t.Errorf("readiness before sync: got %+v", r)
+	}
+
+	synced := time.Now()
+	adv := &model.Advisory{Release: "quay-v3.16.3", AdvisoryID: 1234, Name: "RHSA-2026:1234-01", Type: "RHSA",
+		State: model.AdvisoryQE, Builds: []string{"quay-registry-container-v3.16.3-4"}, CVEs: []string{"CVE-2026-1111"}, SyncedAt: &synced}
+	if err := database.SaveAdvisorySync(ctx, adv); err != nil {
+		t.Fatal(err)
+	}
+	if r := readiness(); r.Signal != "yellow" || r.AdvisoryState != model.AdvisoryQE || r.Message != "Advisory RHSA-2026:1234-01 in QE" {
+		t.Errorf("readiness in QE: got %+v", r)
+	}
+
+	adv.State = model.AdvisoryRelPrep
+	if err := database.SaveAdvisorySync(ctx, adv); err != nil {
+		t.Fatal(err)
+	}
+	if r := readiness(); r.Signal != "green" || r.AdvisoryState != model.AdvisoryRelPrep {
+		t.Errorf("readiness in REL_PREP: got %+v", r)
+	}
+
+	adv.State = model.AdvisoryDropped
+	if err := database.SaveAdvisorySync(ctx, adv); err != nil {
+		t.Fatal(err)
+	}
+	if r := readiness(); r.Signal != "red" {
+		t.Errorf("readiness when dropped: got %+v", r)
+	}
+
+	w := do("GET", "/api/v1/releases/quay-v3.16.3/advisory", "", "")
+	var got model.Advisory
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != adv.Name || len(got.Builds) != 1 || len(got.CVEs) != 1 || got.SyncedAt == nil {
+		t.Errorf("advisory: got %+v", got)
+	}
+
+	if w := do("DELETE", "/api/v1/releases/quay-v3.16.3/advisory", "", "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("delete advisory: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if r := readiness(); r.Signal != "green" {
+		t.Errorf("readiness after removing advisory: got %+v", r)
+	}
+}
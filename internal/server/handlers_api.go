@@ -3,16 +3,24 @@ package server
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/db"
 	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/runstatus"
+	"github.com/quay/release-readiness/internal/version"
 )
 
 func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
@@ -22,6 +30,10 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, version.Get())
+}
+
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if err := s.db.Ping(); err != nil {
 		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unhealthy", "error": err.Error()})
@@ -32,6 +44,8 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 // --- Snapshots ---
 
+// handleListSnapshots lists snapshots, newest first, a page of limit
+// (default 50) at a time with the pagination headers.
 func (s *Server) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	limit, _ := strconv.Atoi(q.Get("limit"))
@@ -39,21 +53,84 @@ func (s *Server) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
 	if limit <= 0 {
 		limit = 50
 	}
-	snapshots, err := s.db.ListSnapshots(r.Context(), q.Get("application"), limit, offset)
+	offset = max(offset, 0)
+	application := q.Get("application")
+	snapshots, err := s.db.ListSnapshots(r.Context(), application, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	total, err := s.db.CountSnapshots(r.Context(), application)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	setPageHeaders(w, r, total, limit, offset)
 	writeJSON(w, http.StatusOK, snapshots)
 }
 
+// handleGetSnapshot returns a snapshot with its components, test results,
+// releases and vulnerability reports, or with format=csv its test results.
+func (s *Server) handleGetSnapshot(w http.ResponseWriter, r *http.Request) {
+	asCSV, err := wantCSV(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	name := r.PathValue("name")
+	snap, err := s.db.GetSnapshotByName(r.Context(), name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	writeSnapshot(w, snap, asCSV)
+}
+
+// writeSnapshot writes snap as JSON, or its test results as CSV.
+func writeSnapshot(w http.ResponseWriter, snap *model.SnapshotRecord, asCSV bool) {
+	if asCSV {
+		writeCSV(w, snap.Name+"-tests.csv", testResultCSVHeader, testResultCSVRows(snap))
+		return
+	}
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// handleGetSnapshotEC returns the Enterprise Contract results of a snapshot.
+func (s *Server) handleGetSnapshotEC(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	report, err := s.db.GetECReport(r.Context(), name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// handleGetSnapshotComponent returns one component of a snapshot with its
+// SBOM.
+func (s *Server) handleGetSnapshotComponent(w http.ResponseWriter, r *http.Request) {
+	name, component := r.PathValue("name"), r.PathValue("component")
+	snap, err := s.db.GetSnapshotByName(r.Context(), name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	for _, c := range snap.Components {
+		if c.Component == component {
+			writeJSON(w, http.StatusOK, c)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("component %q not found in snapshot %q", component, name))
+}
+
 // --- Releases (version-centric) ---
 
 func (s *Server) handleGetRelease(w http.ResponseWriter, r *http.Request) {
 	version := r.PathValue("version")
 	release, err := s.db.GetReleaseVersion(r.Context(), version)
 	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Errorf("release %q not found", version))
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
 		return
 	}
 	writeJSON(w, http.StatusOK, release)
@@ -61,10 +138,15 @@ func (s *Server) handleGetRelease(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleGetReleaseSnapshot(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	asCSV, err := wantCSV(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
 	version := r.PathValue("version")
 	release, err := s.db.GetReleaseVersion(ctx, version)
 	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Errorf("release %q not found", version))
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
 		return
 	}
 
@@ -73,37 +155,102 @@ func (s *Server) handleGetReleaseSnapshot(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Get the latest snapshot for this release's S3 application
-	apps, err := s.db.LatestSnapshotPerApplication(ctx)
+	// Get the selected candidate snapshot for this release
+	selected, err := s.selectedCandidates(ctx)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
-
-	for _, app := range apps {
-		if app.Application == release.S3Application {
-			if app.LatestSnapshot == nil {
-				writeError(w, http.StatusNotFound, fmt.Errorf("no snapshots found for %s", release.S3Application))
-				return
-			}
-			// Get full snapshot with components and test results
-			snap, err := s.db.GetSnapshotByName(ctx, app.LatestSnapshot.Name)
-			if err != nil {
-				writeError(w, http.StatusInternalServerError, err)
-				return
-			}
-			writeJSON(w, http.StatusOK, snap)
-			return
-		}
+	candidate := selected[release.Name]
+	if candidate == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no snapshots found for application %s", release.S3Application))
+		return
 	}
 
-	writeError(w, http.StatusNotFound, fmt.Errorf("no snapshots found for application %s", release.S3Application))
+	// Get full snapshot with components and test results
+	snap, err := s.db.GetSnapshotByName(ctx, candidate.Name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", candidate.Name))
+		return
+	}
+	writeSnapshot(w, snap, asCSV)
 }
 
+// defaultIssuePageSize is the page size of handleListReleaseIssues when
+// only an offset is given.
+const defaultIssuePageSize = 100
+
+// handleListReleaseIssues lists a release's cached JIRA issues, ordered by
+// key, as JSON or with format=csv as CSV. Without limit or offset every
+// matching issue is returned; either way the pagination headers give the
+// number of matching issues.
 func (s *Server) handleListReleaseIssues(w http.ResponseWriter, r *http.Request) {
+	asCSV, err := wantCSV(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
 	version := r.PathValue("version")
 	q := r.URL.Query()
-	issues, err := s.db.ListJiraIssues(r.Context(), version, q.Get("type"), q.Get("status"), q.Get("label"))
+	filter := model.IssueFilter{
+		Type:       q.Get("type"),
+		Status:     q.Get("status"),
+		Label:      q.Get("label"),
+		Assignee:   q.Get("assignee"),
+		Resolution: q.Get("resolution"),
+	}
+	if filter.Limit, err = queryInt(q, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filter.Offset, err = queryInt(q, "offset"); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filter.Limit == 0 && filter.Offset > 0 {
+		filter.Limit = defaultIssuePageSize
+	}
+	issues, err := s.db.ListJiraIssues(r.Context(), version, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	total := len(issues)
+	if filter.Limit > 0 {
+		if total, err = s.db.CountJiraIssues(r.Context(), version, filter); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	setPageHeaders(w, r, total, filter.Limit, filter.Offset)
+	if asCSV {
+		writeCSV(w, version+"-issues.csv", issueCSVHeader, issueCSVRows(issues))
+		return
+	}
+	if issues == nil {
+		issues = []model.JiraIssueRecord{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+// queryInt parses the non-negative integer query parameter name, returning
+// 0 if it is absent.
+func queryInt(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", name, v)
+	}
+	return n, nil
+}
+
+// handleListReleaseCVEs lists a release's CVE issues with their security
+// fields, most severe first.
+func (s *Server) handleListReleaseCVEs(w http.ResponseWriter, r *http.Request) {
+	issues, err := s.db.ListReleaseCVEs(r.Context(), r.PathValue("version"))
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
@@ -115,67 +262,200 @@ func (s *Server) handleListReleaseIssues(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleGetReleaseIssueSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	version := r.PathValue("version")
-	summary, err := s.db.GetIssueSummary(r.Context(), version)
+	summary, err := s.db.GetIssueSummary(ctx, version)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if release != nil {
+		states, err := s.db.ListJiraSyncStates(ctx)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		s.markStale(summary, release, states[version], time.Now())
+	}
 	writeJSON(w, http.StatusOK, summary)
 }
 
+// markStale records on summary when the issues of release were last synced
+// from JIRA, given its sync state, and marks it stale once that is longer
+// ago than issueStaleAfter. Released, archived and hidden releases are no
+// longer synced, so they do not go stale.
+func (s *Server) markStale(summary *model.IssueSummary, release *model.ReleaseVersion, state model.JiraSyncState, now time.Time) {
+	if summary == nil || state.SyncedAt == nil {
+		return
+	}
+	summary.AsOf = state.SyncedAt
+	synced := !release.Released && !release.Archived && !release.Hidden
+	summary.Stale = synced && s.issueStaleAfter > 0 && now.Sub(*state.SyncedAt) > s.issueStaleAfter
+}
+
 func (s *Server) handleGetReleaseReadiness(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	version := r.PathValue("version")
 
 	release, err := s.db.GetReleaseVersion(ctx, version)
 	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Errorf("release %q not found", version))
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
 		return
 	}
 
 	issueSummary, _ := s.db.GetIssueSummary(ctx, version)
 
-	testsPassed := false
-	hasTests := false
-	if release.S3Application != "" {
-		apps, err := s.db.LatestSnapshotPerApplication(ctx)
-		if err == nil {
-			for _, app := range apps {
-				if app.Application == release.S3Application && app.LatestSnapshot != nil {
-					testsPassed = app.LatestSnapshot.TestsPassed
-					hasTests = app.LatestSnapshot.HasTests
-					break
-				}
+	var snap *model.SnapshotRecord
+	if selected, err := s.selectedCandidates(ctx); err == nil {
+		snap = selected[release.Name]
+	}
+
+	var overrides []model.ReadinessOverride
+	if !release.Released {
+		if overrides, err = s.db.ListReadinessOverrides(ctx, release.Name); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	readiness := s.policy.computeReadiness(release, issueSummary, snap, overrides)
+	if !release.Released {
+		approvals, err := s.db.ListReleaseApprovals(ctx, release.Name)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		roles := make([]string, len(approvals))
+		for i, a := range approvals {
+			roles[i] = a.Role
+		}
+		readiness.OutstandingApprovals = signOff(roles).Outstanding
+
+		adv, err := s.releaseAdvisory(ctx, release.Name)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		applyAdvisory(&readiness, adv, overrides)
+
+		if release.DueDate != nil {
+			history, err := s.db.ListReadinessHistory(ctx, release.Name)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			open := 0
+			if issueSummary != nil {
+				open = issueSummary.Open
 			}
+			readiness.Schedule = scheduleRisk(release, open, history, time.Now())
 		}
 	}
+	writeJSON(w, http.StatusOK, readiness)
+}
 
-	writeJSON(w, http.StatusOK, computeReadiness(release, issueSummary, testsPassed, hasTests))
+func (s *Server) handleGetReleaseAudit(w http.ResponseWriter, r *http.Request) {
+	version := r.PathValue("version")
+	audit, err := s.db.GetReleaseAudit(r.Context(), version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("audit for release %q", version))
+		return
+	}
+	writeJSON(w, http.StatusOK, audit)
 }
 
-func (s *Server) handleReleasesOverview(w http.ResponseWriter, r *http.Request) {
+// handleGetReleaseHistory returns how the readiness and issue counts of a
+// release changed over time, oldest first, as JSON or with format=csv as
+// CSV.
+func (s *Server) handleGetReleaseHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	releases, err := s.db.ListAllReleaseVersions(ctx)
+	asCSV, err := wantCSV(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	points, err := s.db.ListReadinessHistory(ctx, version)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
-	if releases == nil {
-		releases = []model.ReleaseVersion{}
+	if asCSV {
+		writeCSV(w, version+"-history.csv", historyCSVHeader, historyCSVRows(points))
+		return
 	}
+	writeJSON(w, http.StatusOK, points)
+}
 
-	apps, err := s.db.LatestSnapshotPerApplication(ctx)
+// handleReleasesOverview serves the overview of every release. Releases
+// hidden by an admin are left out unless ?hidden=true is given.
+func (s *Server) handleReleasesOverview(w http.ResponseWriter, r *http.Request) {
+	overviews, err := s.releasesOverview(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
-	snapshotMap := make(map[string]*model.SnapshotRecord, len(apps))
-	for i := range apps {
-		if apps[i].LatestSnapshot != nil {
-			snapshotMap[apps[i].Application] = apps[i].LatestSnapshot
+	if r.URL.Query().Get("hidden") != "true" {
+		overviews = visibleOverviews(overviews)
+	}
+	writeJSON(w, http.StatusOK, overviews)
+}
+
+// selectedCandidates returns the selected candidate snapshot per release,
+// cached for cacheTTL.
+func (s *Server) selectedCandidates(ctx context.Context) (map[string]*model.SnapshotRecord, error) {
+	return s.candidateCache.get(ctx, s.db.ListSelectedCandidates)
+}
+
+// ReleasesOverview returns the readiness of every release as served by the
+// overview endpoint, without hidden releases. It lets background consumers
+// such as notifiers share the server's readiness policy and cache.
+func (s *Server) ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	overviews, err := s.releasesOverview(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return visibleOverviews(overviews), nil
+}
+
+// visibleOverviews returns the overviews of the releases not hidden by an
+// admin, leaving the cached slice untouched.
+func visibleOverviews(overviews []model.ReleaseOverview) []model.ReleaseOverview {
+	visible := make([]model.ReleaseOverview, 0, len(overviews))
+	for _, o := range overviews {
+		if !o.Release.Hidden {
+			visible = append(visible, o)
 		}
 	}
+	return visible
+}
+
+// releasesOverview returns the combined overview of all releases, cached for cacheTTL.
+func (s *Server) releasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	return s.overviewCache.get(ctx, s.buildReleasesOverview)
+}
+
+func (s *Server) buildReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if releases == nil {
+		releases = []model.ReleaseVersion{}
+	}
+
+	selected, err := s.selectedCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	fixVersions := make([]string, len(releases))
 	for i, rel := range releases {
@@ -183,80 +463,276 @@ func (s *Server) handleReleasesOverview(w http.ResponseWriter, r *http.Request)
 	}
 	issueSummaries, err := s.db.GetIssueSummariesBatch(ctx, fixVersions)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err)
-		return
+		return nil, err
+	}
+	syncStates, err := s.db.ListJiraSyncStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	approved, err := s.db.ListApprovedRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	advisoryList, err := s.db.ListReleaseAdvisories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	advisories := make(map[string]*model.Advisory, len(advisoryList))
+	for i := range advisoryList {
+		advisories[advisoryList[i].Release] = &advisoryList[i]
+	}
+
+	overrides, err := s.db.ListActiveReadinessOverrides(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	overviews := make([]model.ReleaseOverview, len(releases))
 	for i, rel := range releases {
 		summary := issueSummaries[rel.Name]
-		var snap *model.SnapshotRecord
-		testsPassed := false
-		hasTests := false
-		if rel.S3Application != "" {
-			if s := snapshotMap[rel.S3Application]; s != nil {
-				// Return snapshot metadata only (no components/test_results)
-				snapCopy := *s
-				snapCopy.Components = nil
-				snapCopy.TestSuites = nil
-				snap = &snapCopy
-				testsPassed = s.TestsPassed
-				hasTests = s.HasTests
-			}
+		s.markStale(summary, &rel, syncStates[rel.Name], now)
+
+		snap := selected[rel.Name]
+		if snap != nil {
+			// Return snapshot metadata only (no components/test_results)
+			snapCopy := *snap
+			snapCopy.Components = nil
+			snapCopy.TestSuites = nil
+			snap = &snapCopy
 		}
 
 		overviews[i] = model.ReleaseOverview{
 			Release:      rel,
 			IssueSummary: summary,
-			Readiness:    computeReadiness(&rel, summary, testsPassed, hasTests),
+			Readiness:    s.policy.computeReadiness(&rel, summary, snap, overrides[rel.Name]),
 			Snapshot:     snap,
 		}
+		if !rel.Released {
+			overviews[i].SignOff = signOff(approved[rel.Name])
+			overviews[i].Readiness.OutstandingApprovals = overviews[i].SignOff.Outstanding
+			applyAdvisory(&overviews[i].Readiness, advisories[rel.Name], overrides[rel.Name])
+		}
 	}
+	return overviews, nil
+}
 
-	writeJSON(w, http.StatusOK, overviews)
+// readinessPolicy holds the optional gates applied by computeReadiness.
+type readinessPolicy struct {
+	// requireImageDigests withholds green until every component image of
+	// the latest snapshot has been verified in its registry.
+	requireImageDigests bool
+
+	// requireEC withholds green until every component of the latest
+	// snapshot has passed Enterprise Contract, and forces red while any
+	// component fails it.
+	requireEC bool
+
+	// cveSeverity, if set, forces red while the release has open CVE issues
+	// of at least this severity. It is one of cveSeverities.
+	cveSeverity string
+}
+
+// cveSeverities is the Red Hat impact scale, lowest first.
+var cveSeverities = []string{"Low", "Moderate", "Important", "Critical"}
+
+// severityRank returns the position of severity on the impact scale,
+// starting at 1, or 0 if it is not on the scale.
+func severityRank(severity string) int {
+	for i, s := range cveSeverities {
+		if strings.EqualFold(s, severity) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// openCVEsAtOrAbove counts the open CVE issues in summary whose severity is
+// at least min. Issues without a recognised severity are not counted.
+func openCVEsAtOrAbove(summary *model.IssueSummary, min string) int {
+	if summary == nil {
+		return 0
+	}
+	minRank := severityRank(min)
+	n := 0
+	for severity, count := range summary.OpenCVESeverities {
+		if rank := severityRank(severity); rank > 0 && rank >= minRank {
+			n += count
+		}
+	}
+	return n
 }
 
 // computeReadiness derives a readiness signal from release metadata,
-// issue summary, and test status.
-func computeReadiness(release *model.ReleaseVersion, issueSummary *model.IssueSummary, testsPassed, hasTests bool) model.ReadinessResponse {
+// issue summary, and the latest snapshot (nil if there is none). The
+// first failing rule, in the order of model.ReadinessRules, sets the
+// signal; rules waived by one of overrides are skipped and listed in the
+// response's Waivers.
+func (p readinessPolicy) computeReadiness(release *model.ReleaseVersion, issueSummary *model.IssueSummary, snap *model.SnapshotRecord, overrides []model.ReadinessOverride) model.ReadinessResponse {
 	if release.Released {
 		return model.ReadinessResponse{Signal: "green", Message: "Released"}
 	}
 
 	now := time.Now()
-	signal := "green"
-	message := "All checks passing"
 
 	openIssues := issueSummary != nil && issueSummary.Open > 0
-	testsFailing := hasTests && !testsPassed
+	openBlockers := 0
+	if issueSummary != nil {
+		openBlockers = issueSummary.OpenBlockers
+	}
+	testsFailing := snap != nil && snap.HasTests && !snap.TestsPassed
+
+	var images model.ImageDigestSummary
+	if p.requireImageDigests && snap != nil && snap.ImageDigests != nil {
+		images = *snap.ImageDigests
+	}
+	imagesUnverified := p.requireImageDigests && snap != nil && images.Verified < images.Components
+
+	var pipelines model.ReleasePipelineSummary
+	if snap != nil && snap.ReleasePipelines != nil {
+		pipelines = *snap.ReleasePipelines
+	}
+
+	var ec model.ECSummary
+	if p.requireEC && snap != nil && snap.EC != nil {
+		ec = *snap.EC
+	}
+	ecUnverified := p.requireEC && snap != nil && ec.Components == 0
+
+	severeCVEs := 0
+	if p.cveSeverity != "" {
+		severeCVEs = openCVEsAtOrAbove(issueSummary, p.cveSeverity)
+	}
+
+	readiness := model.ReadinessResponse{Signal: "green", Message: "All checks passing", BlockingCVEs: severeCVEs, OpenBlockers: openBlockers}
+	fail := func(rule, signal, message string) {
+		if o, ok := waiver(overrides, rule); ok {
+			waive(&readiness, o)
+			return
+		}
+		if readiness.Rule == "" {
+			readiness.Signal, readiness.Message, readiness.Rule = signal, message, rule
+		}
+	}
 
 	if release.DueDate != nil && now.After(*release.DueDate) {
-		signal = "red"
-		message = "Past due date"
-	} else if testsFailing && openIssues {
-		signal = "red"
-		message = "Tests failing and open issues remain"
-	} else if testsFailing {
-		signal = "yellow"
-		message = "Integration tests failing"
-	} else if openIssues {
-		signal = "yellow"
-		message = "Open issues remain"
-	} else if release.DueDate != nil {
-		daysUntil := int(release.DueDate.Sub(now).Hours() / 24)
-		if daysUntil <= 3 {
-			signal = "yellow"
-			message = fmt.Sprintf("Due date in %d days", daysUntil)
+		fail(model.RulePastDue, "red", "Past due date")
+	}
+	if openBlockers > 0 {
+		fail(model.RuleOpenBlockers, "red", fmt.Sprintf("%d open release blockers", openBlockers))
+	}
+	if severeCVEs > 0 {
+		fail(model.RuleSevereCVEs, "red", fmt.Sprintf("%d open CVEs rated %s or higher", severeCVEs, p.cveSeverity))
+	}
+	if images.Failed > 0 {
+		fail(model.RuleImageMismatch, "red", fmt.Sprintf("%d component images no longer match the registry", images.Failed))
+	}
+	if pipelines.Failed > 0 {
+		fail(model.RuleReleasePipelines, "red", fmt.Sprintf("%d Konflux release pipelines failed", pipelines.Failed))
+	}
+	if ec.Failed > 0 {
+		fail(model.RuleECFailed, "red", fmt.Sprintf("%d components fail Enterprise Contract", ec.Failed))
+	}
+	if _, issuesWaived := waiver(overrides, model.RuleOpenIssues); testsFailing && openIssues && !issuesWaived {
+		fail(model.RuleTestsFailing, "red", "Tests failing and open issues remain")
+	}
+	if testsFailing {
+		fail(model.RuleTestsFailing, "yellow", "Integration tests failing")
+	}
+	if openIssues {
+		message := "Open issues remain"
+		if issueSummary.Blocked == issueSummary.Open {
+			message = fmt.Sprintf("%d done issues wait on unresolved blockers", issueSummary.Blocked)
+		}
+		fail(model.RuleOpenIssues, "yellow", message)
+	}
+	if imagesUnverified {
+		fail(model.RuleImagesUnverified, "yellow", "Image digests not yet verified")
+	}
+	if ecUnverified {
+		fail(model.RuleECMissing, "yellow", "No Enterprise Contract results")
+	}
+	if release.DueDate != nil && !now.After(*release.DueDate) {
+		if daysUntil := int(release.DueDate.Sub(now).Hours() / 24); daysUntil <= 3 {
+			fail(model.RuleDueSoon, "yellow", fmt.Sprintf("Due date in %d days", daysUntil))
+		}
+	}
+	return readiness
+}
+
+// scheduleRisk projects when the open issues of an unreleased release with
+// a due date will be closed, from how fast they burned down over the last
+// model.BurnWindow of its readiness history, oldest first. It returns nil
+// for releases without a due date.
+func scheduleRisk(release *model.ReleaseVersion, open int, history []model.ReadinessPoint, now time.Time) *model.ScheduleRisk {
+	if release.Released || release.DueDate == nil {
+		return nil
+	}
+	risk := &model.ScheduleRisk{
+		DaysToDue:  int(math.Floor(release.DueDate.Sub(now).Hours() / 24)),
+		OpenIssues: open,
+	}
+
+	// The open count at the start of the window is that of the last point
+	// recorded by then, as each point holds until the next; a history
+	// that starts later is measured from its first point.
+	start := now.Add(-model.BurnWindow)
+	var from *model.ReadinessPoint
+	for i := range history {
+		if history[i].RecordedAt.After(start) && from != nil {
+			break
 		}
+		from = &history[i]
 	}
+	var rate float64
+	if from != nil {
+		since := from.RecordedAt
+		if since.Before(start) {
+			since = start
+		}
+		if days := now.Sub(since).Hours() / 24; days > 0 {
+			rate = float64(from.Open-open) / days
+			risk.BurnRate = math.Round(rate*100) / 100
+			risk.BurnDays = math.Round(days*100) / 100
+		}
+	}
+
+	switch {
+	case open == 0:
+		risk.ProjectedCompletion = &now
+	case rate > 0:
+		done := now.Add(time.Duration(float64(open) / rate * float64(24*time.Hour)))
+		risk.ProjectedCompletion = &done
+		risk.AtRisk = done.After(*release.DueDate)
+	default:
+		risk.AtRisk = risk.BurnDays >= 1
+	}
+	return risk
+}
+
+// --- Sync ---
 
-	return model.ReadinessResponse{Signal: signal, Message: message}
+type syncStatusResponse struct {
+	Syncers  []runstatus.Status `json:"syncers"`
+	Breakers []breaker.Status   `json:"breakers"`
+}
+
+func (s *Server) handleSyncStatus(w http.ResponseWriter, r *http.Request) {
+	resp := syncStatusResponse{Syncers: []runstatus.Status{}, Breakers: []breaker.Status{}}
+	for _, t := range s.syncers {
+		resp.Syncers = append(resp.Syncers, t.Status())
+	}
+	for _, b := range s.breakers {
+		resp.Breakers = append(resp.Breakers, b.Status())
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, resp)
 }
 
 // --- Artifacts ---
 
 func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Request) {
-	if s.s3 == nil {
+	if s.s3 == nil && len(s.sources) == 0 {
 		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("S3 not configured"))
 		return
 	}
@@ -276,13 +752,13 @@ func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Req
 
 	snap, err := s.db.GetSnapshotByID(ctx, snapshotID)
 	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Errorf("snapshot %d not found", snapshotID))
+		writeStoreError(w, err, fmt.Sprintf("snapshot %d", snapshotID))
 		return
 	}
 
 	suite, err := s.db.GetTestSuiteByID(ctx, suiteID)
 	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Errorf("test suite %d not found", suiteID))
+		writeStoreError(w, err, fmt.Sprintf("test suite %d", suiteID))
 		return
 	}
 	if suite.SnapshotID != snapshotID {
@@ -290,8 +766,21 @@ func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	store := s.s3
+	if snap.Source != "" {
+		store = s.sources[snap.Source]
+	}
+	if store == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("object store of source %q not configured", snap.Source))
+		return
+	}
+
 	prefix := snap.Application + "/snapshots/" + snap.Name + "/" + suite.Name + "/"
-	keys, err := s.s3.ListObjects(ctx, prefix)
+	keys, err := store.ListObjects(ctx, prefix)
+	if errors.Is(err, breaker.ErrOpen) {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("S3 temporarily unavailable: %w", err))
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Errorf("listing artifacts: %w", err))
 		return
@@ -310,9 +799,9 @@ func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Req
 	defer func() { _ = tw.Close() }()
 
 	for _, key := range keys {
-		body, size, err := s.s3.GetObjectStream(ctx, key)
+		body, size, err := store.GetObjectStream(ctx, key)
 		if err != nil {
-			s.logger.Error("fetch artifact", "key", key, "error", err)
+			s.logger.ErrorContext(ctx, "fetch artifact", "key", key, "error", err)
 			continue
 		}
 
@@ -323,12 +812,12 @@ func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Req
 			Mode: 0o644,
 		}); err != nil {
 			_ = body.Close()
-			s.logger.Error("write tar header", "key", key, "error", err)
+			s.logger.ErrorContext(ctx, "write tar header", "key", key, "error", err)
 			return
 		}
 		if _, err := io.Copy(tw, body); err != nil {
 			_ = body.Close()
-			s.logger.Error("write tar body", "key", key, "error", err)
+			s.logger.ErrorContext(ctx, "write tar body", "key", key, "error", err)
 			return
 		}
 		_ = body.Close()
@@ -339,7 +828,7 @@ func (s *Server) handleDownloadSuiteArtifacts(w http.ResponseWriter, r *http.Req
 
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	if status == http.StatusOK {
+	if status == http.StatusOK && w.Header().Get("Cache-Control") == "" {
 		w.Header().Set("Cache-Control", "max-age=30")
 	}
 	w.WriteHeader(status)
@@ -351,3 +840,16 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 func writeError(w http.ResponseWriter, status int, err error) {
 	writeJSON(w, status, map[string]string{"error": err.Error()})
 }
+
+// writeStoreError maps db.ErrNotFound to 404 and db.ErrConflict to 409 using
+// what to name the resource; any other error is an internal failure.
+func writeStoreError(w http.ResponseWriter, err error, what string) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s not found", what))
+	case errors.Is(err, db.ErrConflict):
+		writeError(w, http.StatusConflict, fmt.Errorf("%s already exists", what))
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
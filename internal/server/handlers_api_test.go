@@ -1,35 +1,43 @@
 package server
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/quay/release-readiness/internal/breaker"
 	"github.com/quay/release-readiness/internal/db"
 	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/runstatus"
+	s3client "github.com/quay/release-readiness/internal/s3"
+	"github.com/quay/release-readiness/internal/storetest"
+	"github.com/quay/release-readiness/internal/version"
 )
 
-func setupTestServer(t *testing.T) *Server {
+func setupTestServer(t *testing.T) (*Server, *db.DB) {
 	t.Helper()
-	dbPath := filepath.Join(t.TempDir(), "test.db")
-	database, err := db.Open(dbPath)
+	database, err := db.Open(db.MemoryPath)
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Cleanup(func() {
-		_ = database.Close()
-		_ = os.Remove(dbPath)
-	})
-	return New(database, nil, ":0", "https://redhat.atlassian.net", "PROJQUAY", slog.Default())
+	t.Cleanup(func() { _ = database.Close() })
+	return New(database, nil, ":0", "https://redhat.atlassian.net", "PROJQUAY", slog.Default()), database
 }
 
 func TestHealthEndpoint(t *testing.T) {
-	srv := setupTestServer(t)
+	srv, _ := setupTestServer(t)
 	req := httptest.NewRequest("GET", "/api/v1/health", nil)
 	w := httptest.NewRecorder()
 	srv.http.Handler.ServeHTTP(w, req)
@@ -48,10 +56,10 @@ func TestHealthEndpoint(t *testing.T) {
 }
 
 func TestListSnapshots(t *testing.T) {
-	srv := setupTestServer(t)
+	srv, database := setupTestServer(t)
 	ctx := t.Context()
 
-	_, err := srv.db.CreateSnapshot(ctx, "quay-v3-17", "quay-v3-17-20260213-000", true, time.Now())
+	_, err := database.CreateSnapshot(ctx, "quay-v3-17", "quay-v3-17-20260213-000", true, time.Now())
 	if err != nil {
 		t.Fatalf("create snapshot: %v", err)
 	}
@@ -74,20 +82,39 @@ func TestListSnapshots(t *testing.T) {
 	if snapshots[0].Application != "quay-v3-17" {
 		t.Errorf("application: got %q, want %q", snapshots[0].Application, "quay-v3-17")
 	}
+
+	// Pages report the total of the application's snapshots.
+	for _, name := range []string{"quay-v3-17-20260214-000", "quay-v3-17-20260215-000"} {
+		if _, err := database.CreateSnapshot(ctx, "quay-v3-17", name, true, time.Now()); err != nil {
+			t.Fatalf("create snapshot: %v", err)
+		}
+	}
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-20260215-000", true, time.Now()); err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots?application=quay-v3-17&limit=2", nil))
+	snapshots = nil
+	if err := json.NewDecoder(w.Body).Decode(&snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || w.Header().Get("X-Total-Count") != "3" || w.Header().Get("Link") == "" {
+		t.Errorf("page: got %d snapshots, headers %v", len(snapshots), w.Header())
+	}
 }
 
 func TestGetReleaseSnapshot(t *testing.T) {
-	srv := setupTestServer(t)
+	srv, database := setupTestServer(t)
 	ctx := t.Context()
 
 	// Create a snapshot for the S3 application
-	_, err := srv.db.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
+	_, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
 	if err != nil {
 		t.Fatalf("create snapshot: %v", err)
 	}
 
 	// Create the release version pointing to this S3 app
-	err = srv.db.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
+	err = database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
 		Name:          "3.16.3",
 		S3Application: "quay-v3-16",
 	})
@@ -112,12 +139,88 @@ func TestGetReleaseSnapshot(t *testing.T) {
 	}
 }
 
+func TestGetReleaseAudit(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	req := httptest.NewRequest("GET", "/api/v1/releases/3.16.2/audit", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unaudited release: got %d, want 404", w.Code)
+	}
+
+	err := database.SaveReleaseAudit(ctx, &model.ReleaseAudit{
+		Release:      "3.16.2",
+		SnapshotName: "quay-v3-16-snap-1",
+		AuditedAt:    time.Now(),
+		Findings: []model.AuditFinding{
+			{Component: "clair", Kind: "tag_mismatch", Expected: "aaa", Actual: "bbb"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("save audit: %v", err)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/releases/3.16.2/audit", nil)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get audit: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var audit model.ReleaseAudit
+	if err := json.NewDecoder(w.Body).Decode(&audit); err != nil {
+		t.Fatal(err)
+	}
+	if audit.SnapshotName != "quay-v3-16-snap-1" || len(audit.Findings) != 1 || audit.Findings[0].Kind != "tag_mismatch" {
+		t.Errorf("audit: got %+v", audit)
+	}
+}
+
+func TestGetReleaseHistory(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/releases/3.16.3/history", nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	if w := get(); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown release: got %d, want 404", w.Code)
+	}
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, open := range []int{8, 5, 1} {
+		p := model.ReadinessPoint{Release: "3.16.3", Signal: "yellow", Total: 10, Open: open, RecordedAt: start.AddDate(0, 0, i)}
+		if err := database.CreateReadinessPoint(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := get()
+	if w.Code != http.StatusOK {
+		t.Fatalf("history: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var points []model.ReadinessPoint
+	if err := json.NewDecoder(w.Body).Decode(&points); err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 3 || points[0].Open != 8 || points[2].Open != 1 || !points[2].RecordedAt.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("history: got %+v", points)
+	}
+}
+
 func TestReleasesOverview(t *testing.T) {
-	srv := setupTestServer(t)
+	srv, database := setupTestServer(t)
 	ctx := t.Context()
 
 	dueDate := time.Now().Add(10 * 24 * time.Hour)
-	err := srv.db.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
+	err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
 		Name:          "3.16.3",
 		S3Application: "quay-v3-16",
 		DueDate:       &dueDate,
@@ -126,12 +229,12 @@ func TestReleasesOverview(t *testing.T) {
 		t.Fatalf("upsert release: %v", err)
 	}
 
-	_, err = srv.db.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
+	_, err = database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
 	if err != nil {
 		t.Fatalf("create snapshot: %v", err)
 	}
 
-	err = srv.db.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
+	err = database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
 		Key: "PROJQUAY-1", Summary: "fix bug", Status: "Open",
 		Priority: "Major", FixVersion: "3.16.3", IssueType: "Bug",
 		Link: "https://redhat.atlassian.net/browse/PROJQUAY-1", UpdatedAt: time.Now(),
@@ -185,8 +288,59 @@ func TestReleasesOverview(t *testing.T) {
 	}
 }
 
+func TestIssueSummaryStaleness(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetIssueStaleAfter(time.Hour)
+	ctx := t.Context()
+
+	// 3.16.3 last synced 2h ago, 3.17.0 just now; 3.15.0 is released, so
+	// no longer synced.
+	now := time.Now().UTC().Truncate(time.Second)
+	for name, age := range map[string]time.Duration{"3.16.3": 2 * time.Hour, "3.17.0": time.Minute, "3.15.0": 2 * time.Hour} {
+		if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: name, Released: name == "3.15.0"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
+			Key: "PROJQUAY-" + name, Status: "Open", FixVersion: name, IssueType: "Bug", UpdatedAt: now,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		synced := now.Add(-age)
+		if err := database.SaveJiraSyncState(ctx, model.JiraSyncState{FixVersion: name, SyncedAt: &synced}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/overview", nil))
+	var overviews []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overviews); err != nil {
+		t.Fatal(err)
+	}
+	stale := make(map[string]bool)
+	for _, ov := range overviews {
+		if ov.IssueSummary == nil || ov.IssueSummary.AsOf == nil {
+			t.Fatalf("%s: got summary %+v, want as_of", ov.Release.Name, ov.IssueSummary)
+		}
+		stale[ov.Release.Name] = ov.IssueSummary.Stale
+	}
+	if len(stale) != 3 || !stale["3.16.3"] || stale["3.17.0"] || stale["3.15.0"] {
+		t.Errorf("overview: got stale %v, want only 3.16.3", stale)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/3.16.3/issues/summary", nil))
+	var summary model.IssueSummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if !summary.Stale || summary.AsOf == nil || !summary.AsOf.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("summary: got stale %v as of %v", summary.Stale, summary.AsOf)
+	}
+}
+
 func TestGetIssueSummariesBatch(t *testing.T) {
-	srv := setupTestServer(t)
+	_, database := setupTestServer(t)
 	ctx := t.Context()
 
 	issues := []model.JiraIssueRecord{
@@ -195,12 +349,12 @@ func TestGetIssueSummariesBatch(t *testing.T) {
 		{Key: "Q-3", Summary: "task1", Status: "Verified", Priority: "Minor", FixVersion: "3.17.0", IssueType: "Story", UpdatedAt: time.Now()},
 	}
 	for _, issue := range issues {
-		if err := srv.db.UpsertJiraIssue(ctx, &issue); err != nil {
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
 			t.Fatalf("upsert issue %s: %v", issue.Key, err)
 		}
 	}
 
-	summaries, err := srv.db.GetIssueSummariesBatch(ctx, []string{"3.16.3", "3.17.0", "nonexistent"})
+	summaries, err := database.GetIssueSummariesBatch(ctx, []string{"3.16.3", "3.17.0", "nonexistent"})
 	if err != nil {
 		t.Fatalf("batch: %v", err)
 	}
@@ -233,12 +387,12 @@ func TestGetIssueSummariesBatch(t *testing.T) {
 }
 
 func TestGetReleaseReadiness(t *testing.T) {
-	srv := setupTestServer(t)
+	srv, database := setupTestServer(t)
 	ctx := t.Context()
 
 	// Create a release with a future due date
 	dueDate := time.Now().Add(10 * 24 * time.Hour)
-	err := srv.db.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
+	err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
 		Name:          "3.16.3",
 		S3Application: "quay-v3-16",
 		DueDate:       &dueDate,
@@ -248,7 +402,7 @@ func TestGetReleaseReadiness(t *testing.T) {
 	}
 
 	// Create a passing snapshot
-	_, err = srv.db.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
+	_, err = database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now())
 	if err != nil {
 		t.Fatalf("create snapshot: %v", err)
 	}
@@ -268,4 +422,742 @@ func TestGetReleaseReadiness(t *testing.T) {
 	if readiness.Signal != "green" {
 		t.Errorf("signal: got %q, want green", readiness.Signal)
 	}
+	if sr := readiness.Schedule; sr == nil || sr.DaysToDue != 9 || sr.AtRisk {
+		t.Errorf("schedule: got %+v", sr)
+	}
+}
+
+func TestReadinessImageDigestGate(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	tests := []struct {
+		name    string
+		require bool
+		digests *model.ImageDigestSummary
+		want    string
+	}{
+		{"gate disabled", false, &model.ImageDigestSummary{Components: 3, Failed: 1}, "green"},
+		{"all verified", true, &model.ImageDigestSummary{Components: 3, Verified: 3}, "green"},
+		{"not yet verified", true, &model.ImageDigestSummary{Components: 3}, "yellow"},
+		{"digest missing", true, &model.ImageDigestSummary{Components: 3, Verified: 2, Failed: 1}, "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := readinessPolicy{requireImageDigests: tt.require}
+			snap := &model.SnapshotRecord{TestsPassed: true, ImageDigests: tt.digests}
+			if got := p.computeReadiness(release, nil, snap, nil); got.Signal != tt.want {
+				t.Errorf("signal: got %q (%s), want %s", got.Signal, got.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadinessCVESeverityGate(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	if err := srv.SetCVESeverityGate("urgent"); err == nil {
+		t.Error("SetCVESeverityGate(urgent): expected error")
+	}
+	if err := srv.SetCVESeverityGate("important"); err != nil {
+		t.Fatal(err)
+	}
+
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	tests := []struct {
+		name       string
+		severities map[string]int
+		want       string
+		blocking   int
+	}{
+		{"no open CVEs", nil, "green", 0},
+		{"below threshold", map[string]int{"Moderate": 2, "": 1}, "yellow", 0},
+		{"at threshold", map[string]int{"Important": 1}, "red", 1},
+		{"above threshold", map[string]int{"Critical": 1, "Low": 3}, "red", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := &model.IssueSummary{OpenCVESeverities: tt.severities}
+			for _, n := range tt.severities {
+				summary.Open += n
+			}
+			got := srv.policy.computeReadiness(release, summary, nil, nil)
+			if got.Signal != tt.want {
+				t.Errorf("signal: got %q (%s), want %s", got.Signal, got.Message, tt.want)
+			}
+			if got.BlockingCVEs != tt.blocking {
+				t.Errorf("blocking CVEs: got %d, want %d", got.BlockingCVEs, tt.blocking)
+			}
+		})
+	}
+}
+
+func TestReadinessBlockerGate(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	snap := &model.SnapshotRecord{HasTests: true, TestsPassed: true}
+	summary := &model.IssueSummary{Total: 2, Open: 1, OpenBlockers: 1}
+
+	got := readinessPolicy{}.computeReadiness(release, summary, snap, nil)
+	if got.Signal != "red" || got.OpenBlockers != 1 {
+		t.Errorf("open blocker: got %+v, want red with 1 blocker", got)
+	}
+
+	summary.OpenBlockers = 0
+	if got := (readinessPolicy{}).computeReadiness(release, summary, snap, nil); got.Signal != "yellow" {
+		t.Errorf("no blockers: got %q (%s), want yellow", got.Signal, got.Message)
+	}
+}
+
+func TestReadinessBlockedIssues(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	snap := &model.SnapshotRecord{HasTests: true, TestsPassed: true}
+	summary := &model.IssueSummary{Total: 2, Verified: 0, Open: 2, Blocked: 2}
+
+	got := readinessPolicy{}.computeReadiness(release, summary, snap, nil)
+	if got.Signal != "yellow" || got.Message != "2 done issues wait on unresolved blockers" {
+		t.Errorf("blocked issues: got %+v", got)
+	}
+}
+
+func TestReadinessReleasePipelineGate(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	snap := &model.SnapshotRecord{HasTests: true, TestsPassed: true,
+		ReleasePipelines: &model.ReleasePipelineSummary{Succeeded: 1, Failed: 1}}
+
+	got := readinessPolicy{}.computeReadiness(release, nil, snap, nil)
+	if got.Signal != "red" {
+		t.Errorf("failed release: got %q (%s), want red", got.Signal, got.Message)
+	}
+
+	snap.ReleasePipelines.Failed = 0
+	if got := (readinessPolicy{}).computeReadiness(release, nil, snap, nil); got.Signal != "green" {
+		t.Errorf("no failed releases: got %q (%s), want green", got.Signal, got.Message)
+	}
+}
+
+func TestReadinessECGate(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	snap := &model.SnapshotRecord{HasTests: true, TestsPassed: true,
+		EC: &model.ECSummary{Components: 2, Failed: 1}}
+
+	if got := (readinessPolicy{}).computeReadiness(release, nil, snap, nil); got.Signal != "green" {
+		t.Errorf("gate off: got %q (%s), want green", got.Signal, got.Message)
+	}
+	policy := readinessPolicy{requireEC: true}
+	if got := policy.computeReadiness(release, nil, snap, nil); got.Signal != "red" {
+		t.Errorf("failing component: got %q (%s), want red", got.Signal, got.Message)
+	}
+	snap.EC = &model.ECSummary{}
+	if got := policy.computeReadiness(release, nil, snap, nil); got.Signal != "yellow" {
+		t.Errorf("no results: got %q (%s), want yellow", got.Signal, got.Message)
+	}
+	snap.EC = &model.ECSummary{Components: 2}
+	if got := policy.computeReadiness(release, nil, snap, nil); got.Signal != "green" {
+		t.Errorf("all passing: got %q (%s), want green", got.Signal, got.Message)
+	}
+}
+
+func TestScheduleRisk(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	due := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	point := func(daysAgo, open int) model.ReadinessPoint {
+		return model.ReadinessPoint{Open: open, RecordedAt: now.Add(-time.Duration(daysAgo) * day)}
+	}
+	tests := []struct {
+		name    string
+		open    int
+		history []model.ReadinessPoint
+		rate    float64
+		days    float64
+		done    time.Duration // from now; -1 for no projection
+		atRisk  bool
+	}{
+		// 20 days ago 40 were open, 28 by the start of the window.
+		{"behind schedule", 14, []model.ReadinessPoint{point(20, 40), point(15, 28), point(3, 20)}, 1, 14, 14 * day, true},
+		{"on schedule", 4, []model.ReadinessPoint{point(20, 40), point(15, 32)}, 2, 14, 2 * day, false},
+		{"short history", 6, []model.ReadinessPoint{point(2, 10)}, 2, 2, 3 * day, false},
+		{"not burning down", 12, []model.ReadinessPoint{point(5, 10)}, -0.4, 5, -1, true},
+		{"too little history", 12, []model.ReadinessPoint{{Open: 12, RecordedAt: now.Add(-time.Hour)}}, 0, 0.04, -1, false},
+		{"no history", 12, nil, 0, 0, -1, false},
+		{"done", 0, []model.ReadinessPoint{point(5, 10)}, 2, 5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := scheduleRisk(&model.ReleaseVersion{Name: "3.16.3", DueDate: &due}, tt.open, tt.history, now)
+			if risk.DaysToDue != 9 || risk.OpenIssues != tt.open {
+				t.Errorf("countdown: got %+v", risk)
+			}
+			if risk.BurnRate != tt.rate || risk.BurnDays != tt.days || risk.AtRisk != tt.atRisk {
+				t.Errorf("got rate %v over %v days, at risk %v; want %v over %v, %v", risk.BurnRate, risk.BurnDays, risk.AtRisk, tt.rate, tt.days, tt.atRisk)
+			}
+			switch {
+			case tt.done < 0 && risk.ProjectedCompletion != nil:
+				t.Errorf("projection: got %v, want none", risk.ProjectedCompletion)
+			case tt.done >= 0 && (risk.ProjectedCompletion == nil || !risk.ProjectedCompletion.Equal(now.Add(tt.done))):
+				t.Errorf("projection: got %v, want %v", risk.ProjectedCompletion, now.Add(tt.done))
+			}
+		})
+	}
+
+	if risk := scheduleRisk(&model.ReleaseVersion{Name: "3.16.3"}, 3, nil, now); risk != nil {
+		t.Errorf("without due date: got %+v", risk)
+	}
+	if risk := scheduleRisk(&model.ReleaseVersion{Name: "3.16.3", DueDate: &due}, 3, nil, due.Add(12*time.Hour)); risk.DaysToDue != -1 {
+		t.Errorf("past due: got %d days to due", risk.DaysToDue)
+	}
+}
+
+func TestGetSnapshot(t *testing.T) {
+	srv, database := setupTestServer(t)
+	err := database.SaveSnapshot(t.Context(), &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		CreatedAt:   time.Now(),
+		Components: []model.ComponentRecord{{
+			Component: "quay-server",
+			GitSHA:    "abc123",
+			ImageURL:  "quay.io/quay/quay-server@sha256:abc",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots/quay-v3-17-snap-1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var snap model.SnapshotRecord
+	if err := json.NewDecoder(w.Body).Decode(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Name != "quay-v3-17-snap-1" || len(snap.Components) != 1 || snap.Components[0].Component != "quay-server" {
+		t.Errorf("got %+v", snap)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown snapshot: got %d, want 404", w.Code)
+	}
+}
+
+func TestGetSnapshotComponent(t *testing.T) {
+	srv, database := setupTestServer(t)
+	err := database.SaveSnapshot(t.Context(), &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		CreatedAt:   time.Now(),
+		Components: []model.ComponentRecord{{
+			Component: "quay-server",
+			ImageURL:  "quay.io/quay/quay-server@sha256:abc",
+			SBOM: &model.ComponentSBOM{
+				Reference: "quay.io/quay/quay-server:sha256-abc.sbom",
+				Format:    "spdx",
+				Packages:  120,
+				Licenses:  []string{"Apache-2.0", "MIT"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots/quay-v3-17-snap-1/components/quay-server", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var c model.ComponentRecord
+	if err := json.NewDecoder(w.Body).Decode(&c); err != nil {
+		t.Fatal(err)
+	}
+	if c.SBOM == nil || c.SBOM.Packages != 120 || len(c.SBOM.Licenses) != 2 || c.SBOM.Reference != "quay.io/quay/quay-server:sha256-abc.sbom" {
+		t.Errorf("sbom: got %+v", c.SBOM)
+	}
+
+	for _, url := range []string{
+		"/api/v1/snapshots/quay-v3-17-snap-1/components/clair",
+		"/api/v1/snapshots/missing/components/quay-server",
+	} {
+		w = httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: got %d, want 404", url, w.Code)
+		}
+	}
+}
+
+func TestGetSnapshotEC(t *testing.T) {
+	srv, database := setupTestServer(t)
+	err := database.SaveSnapshot(t.Context(), &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		CreatedAt:   time.Now(),
+		ECResults: []model.ECResult{{
+			Component:  "quay-server",
+			ImageURL:   "quay.io/quay/quay-server@sha256:abc",
+			Violations: []model.ECFinding{{Code: "cve.cve_results_found", Message: "No CVE scan results"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots/quay-v3-17-snap-1/ec", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var report model.ECReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Success || len(report.Components) != 1 || len(report.Components[0].Violations) != 1 {
+		t.Errorf("got %+v", report)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/snapshots/missing/ec", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing snapshot: got %d, want 404", w.Code)
+	}
+}
+
+func TestListReleaseIssues(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	for _, issue := range []model.JiraIssueRecord{
+		{Key: "PROJQUAY-1", FixVersion: "quay-v3.16.3", IssueType: "Bug", Status: "Open", Assignee: "Alex Doe"},
+		{Key: "PROJQUAY-2", FixVersion: "quay-v3.16.3", IssueType: "Bug", Status: "Closed", Assignee: "Alex Doe", Resolution: "Done"},
+		{Key: "PROJQUAY-3", FixVersion: "quay-v3.16.3", IssueType: "Story", Status: "Closed", Assignee: "Sam Roe", Resolution: "Won't Do"},
+		{Key: "PROJQUAY-4", FixVersion: "quay-v3.16.3", IssueType: "Bug", Status: "Open", Assignee: "Sam Roe"},
+	} {
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+		total string
+	}{
+		{"", []string{"PROJQUAY-1", "PROJQUAY-2", "PROJQUAY-3", "PROJQUAY-4"}, "4"},
+		{"?assignee=Alex+Doe", []string{"PROJQUAY-1", "PROJQUAY-2"}, "2"},
+		{"?resolution=Done", []string{"PROJQUAY-2"}, "1"},
+		{"?type=Bug&assignee=Sam+Roe", []string{"PROJQUAY-4"}, "1"},
+		{"?limit=2", []string{"PROJQUAY-1", "PROJQUAY-2"}, "4"},
+		{"?limit=2&offset=2", []string{"PROJQUAY-3", "PROJQUAY-4"}, "4"},
+		{"?offset=3", []string{"PROJQUAY-4"}, "4"},
+		{"?type=Bug&limit=1", []string{"PROJQUAY-1"}, "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/quay-v3.16.3/issues"+tt.query, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+			}
+			var issues []model.JiraIssueRecord
+			if err := json.NewDecoder(w.Body).Decode(&issues); err != nil {
+				t.Fatal(err)
+			}
+			var keys []string
+			for _, i := range issues {
+				keys = append(keys, i.Key)
+			}
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("got %v, want %v", keys, tt.want)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != tt.total {
+				t.Errorf("X-Total-Count: got %q, want %q", got, tt.total)
+			}
+		})
+	}
+
+	for _, query := range []string{"?limit=-1", "?offset=x"} {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/quay-v3.16.3/issues"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want 400", query, w.Code)
+		}
+	}
+}
+
+func TestListReleaseCVEs(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	for _, issue := range []model.JiraIssueRecord{
+		{Key: "PROJQUAY-1", FixVersion: "quay-v3.16.3", IssueType: "Bug", Status: "Open"},
+		{Key: "PROJQUAY-2", FixVersion: "quay-v3.16.3", IssueType: "Vulnerability", Status: "Open", Severity: "Moderate", CVEID: "CVE-2026-2", CVSSScore: 5.3},
+		{Key: "PROJQUAY-3", FixVersion: "quay-v3.16.3", IssueType: "Vulnerability", Status: "Open", Severity: "Critical", CVEID: "CVE-2026-3", CVSSScore: 9.1, Embargoed: true},
+	} {
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/quay-v3.16.3/cves", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var cves []model.JiraIssueRecord
+	if err := json.NewDecoder(w.Body).Decode(&cves); err != nil {
+		t.Fatal(err)
+	}
+	if len(cves) != 2 || cves[0].CVEID != "CVE-2026-3" || !cves[0].Embargoed || cves[1].CVSSScore != 5.3 {
+		t.Errorf("CVEs: got %+v", cves)
+	}
+}
+
+func TestWarmCaches(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+	srv.warmCaches(ctx)
+
+	// Written after warm-up; should not be visible until the cache expires.
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.17.0"}); err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/releases/overview", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+
+	var overviews []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overviews); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(overviews) != 1 {
+		t.Errorf("overviews: got %d, want 1 (served from warmed cache)", len(overviews))
+	}
+}
+
+func TestDownloadSuiteArtifacts(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	store := s3client.NewMemoryStore()
+	store.Put("quay-v3-16/snapshots/snap-1/api-tests/results/ctrf-report.json", []byte(`{}`))
+	store.Put("quay-v3-16/snapshots/snap-1/api-tests/logs/run.log", []byte("log output"))
+	srv.s3 = store
+
+	snap, err := database.CreateSnapshot(ctx, "quay-v3-16", "snap-1", true, time.Now())
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	suiteID, err := database.CreateTestSuite(ctx, snap.ID, "api-tests", "passed", "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, false)
+	if err != nil {
+		t.Fatalf("create suite: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/snapshots/%d/suites/%d/artifacts", snap.ID, suiteID), nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("download: got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	want := []string{"logs/run.log", "results/ctrf-report.json"}
+	if !slices.Equal(names, want) {
+		t.Errorf("archive entries: got %v, want %v", names, want)
+	}
+
+	// Artifacts of a snapshot synced from a configured source are read
+	// from that source's store.
+	omr := &model.SnapshotRecord{Application: "omr-v2-0", Name: "omr-snap-1", Source: "omr", TestSuites: []model.TestSuite{{Name: "api-tests"}}}
+	if err := database.SaveSnapshot(ctx, omr); err != nil {
+		t.Fatal(err)
+	}
+	path := fmt.Sprintf("/api/v1/snapshots/%d/suites/%d/artifacts", omr.ID, omr.TestSuites[0].ID)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("unconfigured source: got %d, want 503", w.Code)
+	}
+	omrStore := s3client.NewMemoryStore()
+	omrStore.Put("omr-v2-0/snapshots/omr-snap-1/api-tests/logs/run.log", []byte("log output"))
+	srv.AddSource("omr", omrStore)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("source download: got %d, body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandlersWithMockStore(t *testing.T) {
+	store := &storetest.Store{
+		PingFunc: func() error { return errors.New("database is locked") },
+		GetReleaseVersionFunc: func(ctx context.Context, name string) (*model.ReleaseVersion, error) {
+			if name == "broken" {
+				return nil, errors.New("disk I/O error")
+			}
+			return nil, db.ErrNotFound
+		},
+	}
+	srv := New(store, nil, ":0", "https://redhat.atlassian.net", "PROJQUAY", slog.Default())
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/v1/health", http.StatusServiceUnavailable},
+		{"/api/v1/releases/quay-v9.9.9", http.StatusNotFound},
+		{"/api/v1/releases/quay-v9.9.9/readiness", http.StatusNotFound},
+		{"/api/v1/releases/broken", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("GET %s: got %d, want %d", tt.path, w.Code, tt.want)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if id := w.Header().Get("X-Request-ID"); len(id) != 16 {
+		t.Errorf("generated request ID: got %q", id)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/health", nil)
+	req.Header.Set("X-Request-ID", "client-abc.123")
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if id := w.Header().Get("X-Request-ID"); id != "client-abc.123" {
+		t.Errorf("propagated request ID: got %q, want client-abc.123", id)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/health", nil)
+	req.Header.Set("X-Request-ID", "bad id\twith tabs")
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if id := w.Header().Get("X-Request-ID"); id == "bad id\twith tabs" || id == "" {
+		t.Errorf("invalid request ID should be replaced, got %q", id)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	var buf bytes.Buffer
+	logger := slog.New(requestid.NewHandler(slog.NewJSONHandler(&buf, nil)))
+	srv := New(database, nil, ":0", "", "", logger)
+
+	req := httptest.NewRequest("GET", "/api/v1/health", nil)
+	req.Header.Set("X-Request-ID", "access-log-1")
+	req.RemoteAddr = "192.0.2.7:41234"
+	srv.http.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry struct {
+		Level      string `json:"level"`
+		Msg        string `json:"msg"`
+		Method     string `json:"method"`
+		Path       string `json:"path"`
+		Status     int    `json:"status"`
+		Bytes      int64  `json:"bytes"`
+		RemoteAddr string `json:"remote_addr"`
+		RequestID  string `json:"request_id"`
+		Latency    *int64 `json:"latency"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	if entry.Msg != "http request" || entry.Level != "INFO" || entry.Method != "GET" ||
+		entry.Path != "/api/v1/health" || entry.Status != http.StatusOK || entry.Bytes == 0 ||
+		entry.RemoteAddr != "192.0.2.7:41234" || entry.RequestID != "access-log-1" || entry.Latency == nil {
+		t.Errorf("access log: got %s", buf.String())
+	}
+}
+
+func TestETag(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/releases/overview", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	tag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || tag == "" || w.Body.Len() == 0 {
+		t.Fatalf("first request: got %d, ETag %q, %d bytes", w.Code, tag, w.Body.Len())
+	}
+
+	for _, header := range []string{tag, "W/" + tag, `"other", ` + tag, "*"} {
+		w = get(header)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: got %d with %d bytes, want 304 without a body", header, w.Code, w.Body.Len())
+		}
+		if got := w.Header().Get("ETag"); got != tag {
+			t.Errorf("If-None-Match %s: ETag got %q, want %q", header, got, tag)
+		}
+	}
+	if w = get(`"other"`); w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("stale tag: got %d with %d bytes, want the body", w.Code, w.Body.Len())
+	}
+
+	// A change produces a new tag once the overview is rebuilt.
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.4"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.overviewCache.invalidate()
+	if w = get(tag); w.Code != http.StatusOK || w.Header().Get("ETag") == tag {
+		t.Errorf("after a change: got %d, ETag %q, want 200 with a new tag", w.Code, w.Header().Get("ETag"))
+	}
+
+	// Errors are not tagged.
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/9.9.9", nil))
+	if w.Code != http.StatusNotFound || w.Header().Get("ETag") != "" {
+		t.Errorf("not found: got %d, ETag %q", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestVersionEndpoint(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	old := version.Version
+	version.Version = "v1.2.3"
+	t.Cleanup(func() { version.Version = old })
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/version", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusOK)
+	}
+	var info version.Info
+	if err := json.NewDecoder(w.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "v1.2.3" || info.GoVersion == "" {
+		t.Errorf("version info: got %+v", info)
+	}
+}
+
+func TestSyncStatus(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	b := breaker.New("s3", 1, time.Minute, time.Minute)
+	_ = b.Do(func() error { return errors.New("connection refused") })
+	srv.SetBreakers(b)
+	tracker := runstatus.New("s3")
+	run := tracker.Start()
+	run.Add(2)
+	run.Fail(errors.New("list applications: timeout"))
+	run.Finish()
+	srv.SetSyncers(tracker)
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/sync/status", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp syncStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Breakers) != 1 || resp.Breakers[0].Name != "s3" || resp.Breakers[0].State != "open" {
+		t.Errorf("breakers: got %+v", resp.Breakers)
+	}
+	if len(resp.Syncers) != 1 {
+		t.Fatalf("syncers: got %+v", resp.Syncers)
+	}
+	if s := resp.Syncers[0]; s.Name != "s3" || s.ItemsProcessed != 2 || s.LastRunOK || s.LastError != "list applications: timeout" || s.LastFinishedAt == nil {
+		t.Errorf("syncer: got %+v", s)
+	}
+}
+
+func TestGzip(t *testing.T) {
+	srv, database := setupTestServer(t)
+	if err := database.UpsertReleaseVersion(t.Context(), &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/releases/overview", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	plain := get("")
+	if plain.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("no Accept-Encoding: got Content-Encoding %q", plain.Header().Get("Content-Encoding"))
+	}
+	if got := plain.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Vary: got %q", got)
+	}
+
+	w := get("br, gzip;q=0.8")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("gzip: got %d, Content-Encoding %q", w.Code, w.Header().Get("Content-Encoding"))
+	}
+	if got, want := w.Header().Get("ETag"), "W/"+plain.Header().Get("ETag"); got != want {
+		t.Errorf("ETag: got %q, want %q", got, want)
+	}
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != plain.Body.String() {
+		t.Errorf("decompressed body: got %q, want %q", body, plain.Body.String())
+	}
+
+	// The weakened tag still matches the uncompressed body.
+	req := httptest.NewRequest("GET", "/api/v1/releases/overview", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified || w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("If-None-Match: got %d, Content-Encoding %q", w.Code, w.Header().Get("Content-Encoding"))
+	}
+
+	if w = get("gzip;q=0"); w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("gzip;q=0: got Content-Encoding %q", w.Header().Get("Content-Encoding"))
+	}
 }
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleListApplications lists the S3 applications that have snapshots or
+// configured metadata, by name.
+func (s *Server) handleListApplications(w http.ResponseWriter, r *http.Request) {
+	apps, err := s.applications(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, apps)
+}
+
+type applicationRequest struct {
+	model.ApplicationConfig
+	// Scenarios, if given, mark scenarios of the application required or
+	// informational; scenarios not listed are left as they are.
+	Scenarios []model.ScenarioRequirement `json:"scenarios"`
+}
+
+// handleSaveApplication configures the metadata of an application,
+// replacing what was configured before. It is an admin endpoint.
+func (s *Server) handleSaveApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req applicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	cfg := model.ApplicationConfig{
+		Name:        strings.TrimSpace(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		Product:     strings.TrimSpace(req.Product),
+		Retention:   req.Retention,
+	}
+	if cfg.Name == "" || strings.Contains(cfg.Name, "/") {
+		writeError(w, http.StatusBadRequest, errors.New("name must be an S3 application prefix without slashes"))
+		return
+	}
+	for _, fv := range req.FixVersions {
+		fv = strings.TrimSpace(fv)
+		if fv == "" || strings.Contains(fv, ",") {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid fixVersion %q", fv))
+			return
+		}
+		if !slices.Contains(cfg.FixVersions, fv) {
+			cfg.FixVersions = append(cfg.FixVersions, fv)
+		}
+	}
+	if err := validateRetention(cfg.Retention); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("retention: %w", err))
+		return
+	}
+	for _, sc := range req.Scenarios {
+		if strings.TrimSpace(sc.Scenario) == "" {
+			writeError(w, http.StatusBadRequest, errors.New("scenario names are required"))
+			return
+		}
+	}
+
+	configs, err := s.db.ListApplicationConfigs(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	for _, c := range configs {
+		if c.Name == cfg.Name {
+			continue
+		}
+		for _, fv := range cfg.FixVersions {
+			if slices.Contains(c.FixVersions, fv) {
+				writeError(w, http.StatusConflict, fmt.Errorf("fixVersion %s is already mapped to application %s", fv, c.Name))
+				return
+			}
+		}
+	}
+
+	if err := s.db.SaveApplicationConfig(ctx, &cfg); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	for _, sc := range req.Scenarios {
+		sr := model.ScenarioRequirement{Application: cfg.Name, Scenario: strings.TrimSpace(sc.Scenario), Required: sc.Required}
+		if err := s.db.SetScenarioRequirement(ctx, &sr); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	s.candidateCache.invalidate()
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "application configured", "application", cfg.Name, "fix_versions", cfg.FixVersions)
+
+	apps, err := s.applications(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	i := slices.IndexFunc(apps, func(a model.Application) bool { return a.Name == cfg.Name })
+	writeJSON(w, http.StatusOK, apps[i])
+}
+
+// validateRetention checks retention limits as the pruner reads them.
+func validateRetention(r *model.RetentionLimits) error {
+	if r == nil {
+		return nil
+	}
+	if r.MaxCount < 0 {
+		return errors.New("max_count must not be negative")
+	}
+	if r.MaxAge != "" {
+		d, err := time.ParseDuration(r.MaxAge)
+		if err != nil {
+			return fmt.Errorf("max_age: %w", err)
+		}
+		if d < 0 {
+			return errors.New("max_age must not be negative")
+		}
+	}
+	return nil
+}
+
+// applications merges the applications found in snapshots with the
+// configured ones, and adds the releases and scenario requirements of each.
+func (s *Server) applications(ctx context.Context) ([]model.Application, error) {
+	summaries, err := s.db.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := s.db.ListApplicationConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*model.Application)
+	get := func(name string) *model.Application {
+		if byName[name] == nil {
+			byName[name] = &model.Application{ApplicationConfig: model.ApplicationConfig{Name: name}}
+		}
+		return byName[name]
+	}
+	for _, sum := range summaries {
+		app := get(sum.Application)
+		app.SnapshotCount = sum.SnapshotCount
+		app.LatestSnapshot = sum.LatestSnapshot
+	}
+	for _, c := range configs {
+		app := get(c.Name)
+		app.ApplicationConfig = c
+		app.Configured = true
+	}
+	for _, rel := range releases {
+		if rel.S3Application != "" {
+			app := get(rel.S3Application)
+			app.Releases = append(app.Releases, rel.Name)
+		}
+	}
+
+	apps := make([]model.Application, 0, len(byName))
+	for _, app := range byName {
+		if app.Scenarios, err = s.db.ListScenarioRequirements(ctx, app.Name); err != nil {
+			return nil, err
+		}
+		if app.Scenarios == nil {
+			app.Scenarios = []model.ScenarioRequirement{}
+		}
+		if app.Releases == nil {
+			app.Releases = []string{}
+		}
+		apps = append(apps, *app)
+	}
+	slices.SortFunc(apps, func(a, b model.Application) int { return cmp.Compare(a.Name, b.Name) })
+	return apps, nil
+}
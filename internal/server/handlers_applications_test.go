@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestApplications(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	if err := database.SaveSnapshot(ctx, &model.SnapshotRecord{Application: "quay-v3-16", Name: "quay-v3-16-snap-1"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []model.ReleaseVersion{
+		{Name: "quay-v3.16.3", S3Application: "quay-v3-16"},
+		{Name: "omr-v2.0.10", S3Application: "omr-v2-0"},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	list := func() []model.Application {
+		t.Helper()
+		w := do("GET", "/api/v1/applications", "", "")
+		var apps []model.Application
+		if err := json.NewDecoder(w.Body).Decode(&apps); err != nil {
+			t.Fatal(err)
+		}
+		return apps
+	}
+
+	apps := list()
+	if len(apps) != 2 || apps[0].Name != "omr-v2-0" || apps[1].Name != "quay-v3-16" {
+		t.Fatalf("discovered applications: got %+v", apps)
+	}
+	if a := apps[1]; a.Configured || a.SnapshotCount != 1 || a.LatestSnapshot == nil || !slices.Equal(a.Releases, []string{"quay-v3.16.3"}) {
+		t.Errorf("quay-v3-16: got %+v", a)
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", `{"name":"quay-next"}`, "", http.StatusUnauthorized},
+		{"missing name", `{"display_name":"Quay"}`, "secret", http.StatusBadRequest},
+		{"bad fixVersion", `{"name":"quay-next","fix_versions":["a,b"]}`, "secret", http.StatusBadRequest},
+		{"bad retention", `{"name":"quay-next","retention":{"max_age":"forever"}}`, "secret", http.StatusBadRequest},
+	} {
+		if w := do("POST", "/api/v1/applications", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	body := `{"name":"quay-next","display_name":"Quay (next)","product":"quay","fix_versions":["quay-v3.16.3"],
+		"retention":{"max_count":20},"scenarios":[{"scenario":"perf","required":false}]}`
+	w := do("POST", "/api/v1/applications", body, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("save application: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var app model.Application
+	if err := json.NewDecoder(w.Body).Decode(&app); err != nil {
+		t.Fatal(err)
+	}
+	if !app.Configured || app.DisplayName != "Quay (next)" || app.Retention == nil || app.Retention.MaxCount != 20 ||
+		!slices.Equal(app.Releases, []string{"quay-v3.16.3"}) || len(app.Scenarios) != 1 || app.Scenarios[0].Required {
+		t.Errorf("saved application: got %+v", app)
+	}
+	rel, err := database.GetReleaseVersion(ctx, "quay-v3.16.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.S3Application != "quay-next" {
+		t.Errorf("release application: got %q, want quay-next", rel.S3Application)
+	}
+
+	if w := do("POST", "/api/v1/applications", `{"name":"other","fix_versions":["quay-v3.16.3"]}`, "secret"); w.Code != http.StatusConflict {
+		t.Errorf("fixVersion of another application: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if apps := list(); len(apps) != 3 || len(apps[1].Releases) != 1 || len(apps[2].Releases) != 0 {
+		t.Errorf("applications after mapping: got %+v", apps)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListReleaseApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	approvals, err := s.db.ListReleaseApprovals(ctx, version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, approvals)
+}
+
+type approvalRequest struct {
+	Approver string `json:"approver"`
+	Role     string `json:"role"` // one of model.ApprovalRoles, case-insensitive
+	Comment  string `json:"comment"`
+}
+
+// handleCreateReleaseApproval records a sign-off on an unreleased release.
+func (s *Server) handleCreateReleaseApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+
+	var req approvalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	approval := model.Approval{
+		Release:  version,
+		Approver: strings.TrimSpace(req.Approver),
+		Comment:  strings.TrimSpace(req.Comment),
+	}
+	if approval.Approver == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("approver is required"))
+		return
+	}
+	for _, role := range model.ApprovalRoles {
+		if strings.EqualFold(role, strings.TrimSpace(req.Role)) {
+			approval.Role = role
+		}
+	}
+	if approval.Role == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("role must be one of %s", strings.Join(model.ApprovalRoles, ", ")))
+		return
+	}
+
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	if release.Released {
+		writeError(w, http.StatusConflict, fmt.Errorf("release %q has already been released", version))
+		return
+	}
+
+	if err := s.db.CreateReleaseApproval(ctx, &approval); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "release approved", "release", version, "approver", approval.Approver, "role", approval.Role)
+	writeJSON(w, http.StatusCreated, approval)
+}
+
+// signOff splits model.ApprovalRoles by whether they appear in approved.
+func signOff(approved []string) *model.SignOff {
+	so := &model.SignOff{Approved: []string{}, Outstanding: []string{}}
+	for _, role := range model.ApprovalRoles {
+		if slices.Contains(approved, role) {
+			so.Approved = append(so.Approved, role)
+		} else {
+			so.Outstanding = append(so.Outstanding, role)
+		}
+	}
+	return so
+}
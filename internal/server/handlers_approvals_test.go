@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleaseApprovals(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+
+	for _, rel := range []model.ReleaseVersion{
+		{Name: "3.16.3"},
+		{Name: "3.16.2", Released: true},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tc := range []struct {
+		name, path, body, token string
+		want                    int
+	}{
+		{"no token", "/api/v1/releases/3.16.3/approvals", `{"approver":"jane","role":"QE"}`, "", http.StatusUnauthorized},
+		{"no approver", "/api/v1/releases/3.16.3/approvals", `{"approver":" ","role":"QE"}`, "secret", http.StatusBadRequest},
+		{"unknown role", "/api/v1/releases/3.16.3/approvals", `{"approver":"jane","role":"Docs"}`, "secret", http.StatusBadRequest},
+		{"unknown release", "/api/v1/releases/9.9.9/approvals", `{"approver":"jane","role":"QE"}`, "secret", http.StatusNotFound},
+		{"released", "/api/v1/releases/3.16.2/approvals", `{"approver":"jane","role":"QE"}`, "secret", http.StatusConflict},
+	} {
+		if w := do("POST", tc.path, tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	w := do("POST", "/api/v1/releases/3.16.3/approvals", `{"approver":"Jane Doe","role":"qe","comment":"Regression run clean"}`, "secret")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("approve: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var created model.Approval
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 || created.Role != model.RoleQE || created.CreatedAt.IsZero() {
+		t.Errorf("created approval: got %+v", created)
+	}
+
+	w = do("GET", "/api/v1/releases/3.16.3/approvals", "", "")
+	var approvals []model.Approval
+	if err := json.NewDecoder(w.Body).Decode(&approvals); err != nil {
+		t.Fatal(err)
+	}
+	if len(approvals) != 1 || approvals[0].Approver != "Jane Doe" || approvals[0].Comment != "Regression run clean" {
+		t.Errorf("approvals: got %+v", approvals)
+	}
+
+	w = do("GET", "/api/v1/releases/3.16.3/readiness", "", "")
+	var readiness model.ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&readiness); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{model.RoleDev, model.RolePM}; !slices.Equal(readiness.OutstandingApprovals, want) {
+		t.Errorf("outstanding approvals: got %v, want %v", readiness.OutstandingApprovals, want)
+	}
+
+	w = do("GET", "/api/v1/releases/overview", "", "")
+	var overviews []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overviews); err != nil {
+		t.Fatal(err)
+	}
+	for _, ov := range overviews {
+		switch ov.Release.Name {
+		case "3.16.3":
+			if ov.SignOff == nil || !slices.Equal(ov.SignOff.Approved, []string{model.RoleQE}) || len(ov.SignOff.Outstanding) != 2 {
+				t.Errorf("3.16.3 sign-off: got %+v", ov.SignOff)
+			}
+		case "3.16.2":
+			if ov.SignOff != nil || ov.Readiness.OutstandingApprovals != nil {
+				t.Errorf("released sign-off: got %+v, %v", ov.SignOff, ov.Readiness.OutstandingApprovals)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// handleArchiveRelease hides a release from the overview and excludes it
+// from JIRA sync, whatever its released and archived flags in JIRA. It is
+// an admin endpoint.
+func (s *Server) handleArchiveRelease(w http.ResponseWriter, r *http.Request) {
+	s.setReleaseHidden(w, r, true)
+}
+
+// handleUnarchiveRelease undoes handleArchiveRelease. It is an admin
+// endpoint.
+func (s *Server) handleUnarchiveRelease(w http.ResponseWriter, r *http.Request) {
+	s.setReleaseHidden(w, r, false)
+}
+
+// setReleaseHidden sets whether the release in the path is hidden and
+// responds with the updated release.
+func (s *Server) setReleaseHidden(w http.ResponseWriter, r *http.Request, hidden bool) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if err := s.db.SetReleaseVersionHidden(ctx, version, hidden); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	s.overviewCache.invalidate()
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	s.logger.InfoContext(ctx, "release visibility changed", "release", version, "hidden", hidden)
+	writeJSON(w, http.StatusOK, release)
+}
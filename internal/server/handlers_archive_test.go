@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestArchiveRelease(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	for _, name := range []string{"quay-v3.16.0", "quay-v3.17.0"} {
+		if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	overview := func(path string) []string {
+		t.Helper()
+		var overviews []model.ReleaseOverview
+		if err := json.NewDecoder(do("GET", path, "").Body).Decode(&overviews); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, o := range overviews {
+			names = append(names, o.Release.Name)
+		}
+		return names
+	}
+
+	if w := do("POST", "/api/v1/releases/quay-v3.16.0/archive", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("no token: got %d", w.Code)
+	}
+	if w := do("POST", "/api/v1/releases/quay-v9.9.9/archive", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown release: got %d", w.Code)
+	}
+	// Cache the overview so that archiving must invalidate it.
+	if got := overview("/api/v1/releases/overview"); len(got) != 2 {
+		t.Fatalf("overview: got %v", got)
+	}
+
+	w := do("POST", "/api/v1/releases/quay-v3.16.0/archive", "secret")
+	var rel model.ReleaseVersion
+	if err := json.NewDecoder(w.Body).Decode(&rel); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("archive: got %d, %v", w.Code, err)
+	}
+	if !rel.Hidden || rel.Archived {
+		t.Errorf("archived release: got %+v", rel)
+	}
+	if got := overview("/api/v1/releases/overview"); strings.Join(got, ",") != "quay-v3.17.0" {
+		t.Errorf("overview after archive: got %v", got)
+	}
+	if got := overview("/api/v1/releases/overview?hidden=true"); len(got) != 2 {
+		t.Errorf("overview with hidden: got %v", got)
+	}
+	overviews, err := srv.ReleasesOverview(ctx)
+	if err != nil || len(overviews) != 1 {
+		t.Errorf("ReleasesOverview: got %d releases, %v", len(overviews), err)
+	}
+
+	if w := do("POST", "/api/v1/releases/quay-v3.16.0/unarchive", "secret"); w.Code != http.StatusOK {
+		t.Fatalf("unarchive: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if got := overview("/api/v1/releases/overview"); len(got) != 2 {
+		t.Errorf("overview after unarchive: got %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListReleaseBackports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	backports, err := s.releaseBackports(ctx, release)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if r.URL.Query().Get("pending") == "true" {
+		backports = slices.DeleteFunc(backports, func(b model.Backport) bool { return !b.Pending })
+	}
+	writeJSON(w, http.StatusOK, backports)
+}
+
+// releaseBackports pairs the issues of release with their counterparts in
+// newer streams of the same product, pending ones first.
+func (s *Server) releaseBackports(ctx context.Context, release *model.ReleaseVersion) ([]model.Backport, error) {
+	stream, ok := parseStream(release.Name)
+	if !ok {
+		return []model.Backport{}, nil
+	}
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.db.ListJiraIssues(ctx, release.Name, model.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var newer []model.JiraIssueRecord
+	for _, rel := range releases {
+		if rel.Archived || rel.Hidden || rel.Product() != release.Product() {
+			continue
+		}
+		if st, ok := parseStream(rel.Name); !ok || slices.Compare(st, stream) <= 0 {
+			continue
+		}
+		relIssues, err := s.db.ListJiraIssues(ctx, rel.Name, model.IssueFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("list issues for %s: %w", rel.Name, err)
+		}
+		newer = append(newer, relIssues...)
+	}
+
+	backports := matchBackports(issues, newer)
+	slices.SortStableFunc(backports, func(a, b model.Backport) int {
+		if a.Pending != b.Pending {
+			if a.Pending {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(a.Issue.Key, b.Issue.Key); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Source.FixVersion, b.Source.FixVersion)
+	})
+	return backports, nil
+}
+
+// matchBackports pairs each issue with the newer-stream issues it is linked
+// to as a clone or backport or, failing that, shares a summary with. An
+// issue that targets several versions under one key is not a backport.
+func matchBackports(issues, newer []model.JiraIssueRecord) []model.Backport {
+	backports := []model.Backport{}
+	for _, issue := range issues {
+		clones := splitKeys(issue.Clones)
+		summary := normalizeSummary(issue.Summary)
+		for _, src := range newer {
+			if src.Key == issue.Key {
+				continue
+			}
+			var match string
+			switch {
+			case slices.Contains(clones, src.Key) || slices.Contains(splitKeys(src.Clones), issue.Key):
+				match = model.BackportClone
+			case summary != "" && summary == normalizeSummary(src.Summary):
+				match = model.BackportSummary
+			default:
+				continue
+			}
+			backports = append(backports, model.Backport{
+				Issue:   issue,
+				Source:  src,
+				Match:   match,
+				Pending: !issue.Done(),
+			})
+		}
+	}
+	return backports
+}
+
+// parseStream returns the major and minor version of a fixVersion such as
+// "quay-v3.16.2" or "3.16.2".
+func parseStream(fixVersion string) ([]int, bool) {
+	v := fixVersion
+	if i := strings.Index(v, "-v"); i > 0 {
+		v = v[i+2:]
+	}
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return nil, false
+	}
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return nil, false
+	}
+	return []int{major, minor}, true
+}
+
+// summaryPrefix matches what JIRA and backporters put in front of a cloned
+// summary: "CLONE - ", "[3.16]", "(backport 3.16.z)", "Backport:".
+var summaryPrefix = regexp.MustCompile(`(?i)^\s*(clone\s*-\s*|\[[^\]]*\]\s*|\([^)]*\)\s*|backport\s*:\s*)`)
+
+func normalizeSummary(s string) string {
+	for {
+		t := summaryPrefix.ReplaceAllString(s, "")
+		if t == s {
+			break
+		}
+		s = t
+	}
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func splitKeys(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
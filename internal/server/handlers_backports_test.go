@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleaseBackports(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	for _, name := range []string{"quay-v3.15.9", "quay-v3.16.3", "quay-v3.17.0", "omr-v3.17.0"} {
+		if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, issue := range []model.JiraIssueRecord{
+		// 3.17 fixes that were cloned back to 3.16.
+		{Key: "PROJQUAY-10", FixVersion: "quay-v3.17.0", Summary: "Fix GC race", Status: "Verified"},
+		{Key: "PROJQUAY-11", FixVersion: "quay-v3.16.3", Summary: "CLONE - Fix GC race (something else)", Status: "New", Clones: "PROJQUAY-10"},
+		{Key: "PROJQUAY-20", FixVersion: "quay-v3.17.0", Summary: "Robot token leak", Status: "Closed"},
+		{Key: "PROJQUAY-21", FixVersion: "quay-v3.16.3", Summary: "[3.16] Robot  token leak", Status: "Verified"},
+		// Same key in both streams is not a backport; nor is an OMR issue.
+		{Key: "PROJQUAY-30", FixVersion: "quay-v3.17.0", Summary: "Shared fix", Status: "New"},
+		{Key: "PROJQUAY-30", FixVersion: "quay-v3.16.3", Summary: "Shared fix", Status: "New"},
+		{Key: "PROJQUAY-40", FixVersion: "omr-v3.17.0", Summary: "Fix GC race", Status: "New"},
+		// An older stream is not a source.
+		{Key: "PROJQUAY-50", FixVersion: "quay-v3.15.9", Summary: "Robot token leak", Status: "New"},
+	} {
+		issue.UpdatedAt = time.Now()
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get := func(path string) []model.Backport {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d, body: %s", path, w.Code, w.Body.String())
+		}
+		var backports []model.Backport
+		if err := json.NewDecoder(w.Body).Decode(&backports); err != nil {
+			t.Fatal(err)
+		}
+		return backports
+	}
+
+	backports := get("/api/v1/releases/quay-v3.16.3/backports")
+	if len(backports) != 2 {
+		t.Fatalf("backports: got %+v, want 2", backports)
+	}
+	if b := backports[0]; b.Issue.Key != "PROJQUAY-11" || b.Source.Key != "PROJQUAY-10" || b.Match != model.BackportClone || !b.Pending {
+		t.Errorf("first backport: got %+v", b)
+	}
+	if b := backports[1]; b.Issue.Key != "PROJQUAY-21" || b.Source.Key != "PROJQUAY-20" || b.Match != model.BackportSummary || b.Pending {
+		t.Errorf("second backport: got %+v", b)
+	}
+
+	if pending := get("/api/v1/releases/quay-v3.16.3/backports?pending=true"); len(pending) != 1 || pending[0].Issue.Key != "PROJQUAY-11" {
+		t.Errorf("pending backports: got %+v", pending)
+	}
+	if newest := get("/api/v1/releases/quay-v3.17.0/backports"); len(newest) != 0 {
+		t.Errorf("newest stream backports: got %+v, want none", newest)
+	}
+}
+
+func TestNormalizeSummary(t *testing.T) {
+	for in, want := range map[string]string{
+		"CLONE - Fix GC race":             "fix gc race",
+		"[3.16] [backport] Fix  GC race":  "fix gc race",
+		"(3.16.z) Backport: Fix GC race ": "fix gc race",
+		"Fix GC race (in builder)":        "fix gc race (in builder)",
+	} {
+		if got := normalizeSummary(in); got != want {
+			t.Errorf("normalizeSummary(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
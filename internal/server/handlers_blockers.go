@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleListBlockedIssues lists the issues of a release that are blocked by
+// or depend on an unresolved issue. Issues already done in JIRA, which the
+// readiness signal counts as open until their blockers are resolved, come
+// first.
+func (s *Server) handleListBlockedIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	issues, err := s.db.ListJiraIssues(ctx, version, model.IssueFilter{})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	doneOnly := r.URL.Query().Get("done") == "true"
+	blocked := slices.DeleteFunc(issues, func(i model.JiraIssueRecord) bool {
+		return len(i.OpenBlockers()) == 0 || doneOnly && !i.Done()
+	})
+	if blocked == nil {
+		blocked = []model.JiraIssueRecord{}
+	}
+	slices.SortStableFunc(blocked, func(a, b model.JiraIssueRecord) int {
+		if a.Done() != b.Done() {
+			if a.Done() {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	writeJSON(w, http.StatusOK, blocked)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestListBlockedIssues(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range []model.JiraIssueRecord{
+		{Key: "PROJQUAY-1", FixVersion: "quay-v3.16.3", Summary: "Fix schema", Status: "New"},
+		// Verified, but blocked by the open PROJQUAY-1.
+		{Key: "PROJQUAY-2", FixVersion: "quay-v3.16.3", Summary: "Fix UI", Status: "Verified",
+			BlockedBy: []model.IssueBlocker{{Key: "PROJQUAY-1", Summary: "Fix schema", Status: "Verified"}}},
+		// Open and blocked by an issue outside the release.
+		{Key: "PROJQUAY-3", FixVersion: "quay-v3.16.3", Summary: "Fix API", Status: "New",
+			BlockedBy: []model.IssueBlocker{{Key: "CLAIR-9", Summary: "Fix matcher", Status: "In Progress"}}},
+		// Its only blocker is resolved.
+		{Key: "PROJQUAY-4", FixVersion: "quay-v3.16.3", Summary: "Fix docs", Status: "Closed",
+			BlockedBy: []model.IssueBlocker{{Key: "CLAIR-10", Summary: "Fix docs", Status: "Done"}}},
+	} {
+		issue.UpdatedAt = time.Now()
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get := func(path string) []model.JiraIssueRecord {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d, body: %s", path, w.Code, w.Body.String())
+		}
+		var issues []model.JiraIssueRecord
+		if err := json.NewDecoder(w.Body).Decode(&issues); err != nil {
+			t.Fatal(err)
+		}
+		return issues
+	}
+
+	blocked := get("/api/v1/releases/quay-v3.16.3/blocked-issues")
+	if len(blocked) != 2 || blocked[0].Key != "PROJQUAY-2" || blocked[1].Key != "PROJQUAY-3" {
+		t.Fatalf("blocked issues: got %+v", blocked)
+	}
+	// PROJQUAY-1 is synced, so its own status overrides the one recorded
+	// with the link.
+	if b := blocked[0].BlockedBy; len(b) != 1 || b[0].Status != "New" {
+		t.Errorf("PROJQUAY-2 blockers: got %+v", b)
+	}
+	if done := get("/api/v1/releases/quay-v3.16.3/blocked-issues?done=true"); len(done) != 1 || done[0].Key != "PROJQUAY-2" {
+		t.Errorf("done blocked issues: got %+v", done)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/releases/quay-v9.9.9/blocked-issues", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown release: got %d, want 404", w.Code)
+	}
+}
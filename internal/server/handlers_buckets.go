@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListIssueBuckets(w http.ResponseWriter, r *http.Request) {
+	buckets, err := s.db.ListIssueBuckets(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if buckets == nil {
+		buckets = []model.IssueBucket{}
+	}
+	writeJSON(w, http.StatusOK, buckets)
+}
+
+// handleSetIssueBuckets replaces the label buckets that issue summaries break
+// out. It is an admin endpoint; an empty list removes all buckets.
+func (s *Server) handleSetIssueBuckets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var buckets []model.IssueBucket
+	if err := json.NewDecoder(r.Body).Decode(&buckets); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	seen := make(map[string]bool, len(buckets))
+	for i := range buckets {
+		b := &buckets[i]
+		b.Name = strings.TrimSpace(b.Name)
+		if b.Name == "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("bucket %d: name is required", i))
+			return
+		}
+		if seen[strings.ToLower(b.Name)] {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("bucket %q is defined twice", b.Name))
+			return
+		}
+		seen[strings.ToLower(b.Name)] = true
+
+		var labels []string
+		for _, l := range b.Labels {
+			l = strings.TrimSpace(l)
+			if l == "" {
+				continue
+			}
+			// Labels are stored comma-separated, as JIRA issue labels are.
+			if strings.Contains(l, ",") {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("bucket %q: label %q contains a comma", b.Name, l))
+				return
+			}
+			labels = append(labels, l)
+		}
+		if len(labels) == 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("bucket %q: at least one label is required", b.Name))
+			return
+		}
+		b.Labels = labels
+	}
+
+	if err := s.db.ReplaceIssueBuckets(ctx, buckets); err != nil {
+		writeStoreError(w, err, "issue buckets")
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "issue buckets changed", "buckets", len(buckets))
+	s.handleListIssueBuckets(w, r)
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestIssueBuckets(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range []model.JiraIssueRecord{
+		{Key: "PROJQUAY-1", Status: "New", Labels: "doc-required,needs-backport"},
+		{Key: "PROJQUAY-2", Status: "Verified", Labels: "Doc-Required"},
+		{Key: "PROJQUAY-3", Status: "New", Labels: "escalation"},
+		{Key: "PROJQUAY-4", Status: "New", Labels: "doc-required-later"},
+	} {
+		issue.FixVersion = "3.16.3"
+		issue.UpdatedAt = time.Now()
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// No buckets configured: summaries carry none.
+	w := do("GET", "/api/v1/releases/3.16.3/issues/summary", "", "")
+	var summary model.IssueSummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Buckets != nil {
+		t.Errorf("buckets before configuration: got %+v, want none", summary.Buckets)
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", `[]`, "", http.StatusUnauthorized},
+		{"missing name", `[{"labels":["a"]}]`, "secret", http.StatusBadRequest},
+		{"no labels", `[{"name":"docs","labels":[" "]}]`, "secret", http.StatusBadRequest},
+		{"comma in label", `[{"name":"docs","labels":["a,b"]}]`, "secret", http.StatusBadRequest},
+		{"duplicate", `[{"name":"docs","labels":["a"]},{"name":"Docs","labels":["b"]}]`, "secret", http.StatusBadRequest},
+	} {
+		if w := do("PUT", "/api/v1/issue-buckets", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	w = do("PUT", "/api/v1/issue-buckets",
+		`[{"name":"doc-required","labels":["doc-required"]},{"name":"escalations","labels":["escalation","customer-escalation"]},{"name":"unused","labels":["nothing"]}]`,
+		"secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("set buckets: got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	w = do("GET", "/api/v1/issue-buckets", "", "")
+	var buckets []model.IssueBucket
+	if err := json.NewDecoder(w.Body).Decode(&buckets); err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 3 || buckets[0].Name != "doc-required" || !slices.Equal(buckets[1].Labels, []string{"escalation", "customer-escalation"}) {
+		t.Errorf("buckets: got %+v", buckets)
+	}
+
+	want := []model.BucketCount{
+		{Name: "doc-required", Total: 2, Open: 1},
+		{Name: "escalations", Total: 1, Open: 1},
+		{Name: "unused"},
+	}
+	w = do("GET", "/api/v1/releases/3.16.3/issues/summary", "", "")
+	summary = model.IssueSummary{}
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(summary.Buckets, want) {
+		t.Errorf("summary buckets: got %+v, want %+v", summary.Buckets, want)
+	}
+
+	w = do("GET", "/api/v1/releases/overview", "", "")
+	var overviews []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overviews); err != nil {
+		t.Fatal(err)
+	}
+	if len(overviews) != 1 || overviews[0].IssueSummary == nil || !slices.Equal(overviews[0].IssueSummary.Buckets, want) {
+		t.Errorf("overview buckets: got %+v", overviews)
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListReleaseCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50
+	}
+
+	candidates := []model.ReleaseCandidate{}
+	if release.S3Application != "" {
+		candidates, err = s.db.ListReleaseCandidates(ctx, release.Name, release.S3Application, limit)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, candidates)
+}
+
+type candidateStateRequest struct {
+	State string `json:"state"` // "promoted", "demoted", or "candidate"
+}
+
+// handleSetCandidateState promotes or demotes one of a release's candidate
+// snapshots. Promoting a new or rejected snapshot also makes it a lifecycle
+// candidate. It is a release-manager endpoint.
+func (s *Server) handleSetCandidateState(w http.ResponseWriter, r *http.Request) {
+	var req candidateStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	switch req.State {
+	case model.CandidateDefault, model.CandidatePromoted, model.CandidateDemoted:
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("state must be %q, %q or %q",
+			model.CandidatePromoted, model.CandidateDemoted, model.CandidateDefault))
+		return
+	}
+	s.setCandidateState(w, r, r.PathValue("snapshot"), req.State)
+}
+
+type pinCandidateRequest struct {
+	Snapshot string `json:"snapshot"`
+}
+
+// handlePinCandidate pins the named snapshot as the release candidate, so
+// that readiness, issue gating and the go/no-go report are computed
+// against it instead of the latest snapshot. Pinning promotes the snapshot,
+// replacing any earlier promotion, and makes a new or rejected snapshot a
+// lifecycle candidate. It is a release-manager endpoint.
+func (s *Server) handlePinCandidate(w http.ResponseWriter, r *http.Request) {
+	var req pinCandidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Snapshot == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("snapshot is required"))
+		return
+	}
+	s.setCandidateState(w, r, req.Snapshot, model.CandidatePromoted)
+}
+
+// setCandidateState sets the state of the snapshot name as a candidate for
+// the release in the path and responds with the release's candidates.
+func (s *Server) setCandidateState(w http.ResponseWriter, r *http.Request, name, state string) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	snap, err := s.db.GetSnapshotByName(ctx, name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	if release.S3Application == "" || snap.Application != release.S3Application {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("snapshot %q is not a candidate for release %q", name, version))
+		return
+	}
+	// A rejected snapshot stays demoted until it is promoted or proposed
+	// again through its lifecycle.
+	if state == model.CandidateDefault && snap.Lifecycle == model.LifecycleRejected {
+		writeError(w, http.StatusConflict, fmt.Errorf("snapshot %q is rejected; promote it or make it a lifecycle candidate again", name))
+		return
+	}
+
+	changedBy, _, _ := s.principal(r)
+	if err := s.db.SetCandidateState(ctx, release.Name, snap.ID, state, changedBy); err != nil {
+		if errors.Is(err, db.ErrConflict) {
+			writeError(w, http.StatusConflict, fmt.Errorf("snapshot %q changed state meanwhile; retry", name))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.candidateCache.invalidate()
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "candidate state changed", "release", release.Name, "snapshot", name, "state", state)
+
+	candidates, err := s.db.ListReleaseCandidates(ctx, release.Name, release.S3Application, 50)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, candidates)
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleaseCandidates(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3", S3Application: "quay-v3-16"}); err != nil {
+		t.Fatal(err)
+	}
+	// snap-1 passes, snap-2 (the latest) fails.
+	for _, s := range []struct {
+		name   string
+		passed bool
+	}{{"quay-v3-16-snap-1", true}, {"quay-v3-16-snap-2", false}} {
+		snap, err := database.CreateSnapshot(ctx, "quay-v3-16", s.name, s.passed, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		status := "passed"
+		if !s.passed {
+			status = "failed"
+		}
+		if _, err := database.CreateTestSuite(ctx, snap.ID, "e2e", status, "", "", "", 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := database.CreateSnapshot(ctx, "omr-v2-0", "omr-v2-0-snap-1", true, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	selected := func(w *httptest.ResponseRecorder) string {
+		t.Helper()
+		var candidates []model.ReleaseCandidate
+		if err := json.NewDecoder(w.Body).Decode(&candidates); err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range candidates {
+			if c.Selected {
+				return c.Snapshot.Name
+			}
+		}
+		return ""
+	}
+	signal := func() string {
+		t.Helper()
+		w := do("GET", "/api/v1/releases/3.16.3/readiness", "", "")
+		var readiness model.ReadinessResponse
+		if err := json.NewDecoder(w.Body).Decode(&readiness); err != nil {
+			t.Fatal(err)
+		}
+		return readiness.Signal
+	}
+
+	w := do("GET", "/api/v1/releases/3.16.3/candidates", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("list candidates: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := selected(w); got != "quay-v3-16-snap-2" {
+		t.Errorf("default selection: got %q, want the latest snapshot", got)
+	}
+	if got := signal(); got != "yellow" {
+		t.Errorf("readiness with failing latest: got %q, want yellow", got)
+	}
+
+	const path = "/api/v1/releases/3.16.3/candidates/quay-v3-16-snap-1"
+	if w := do("PUT", path, `{"state":"promoted"}`, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("promote without token: got %d, want 401", w.Code)
+	}
+	if w := do("PUT", path, `{"state":"shipped"}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid state: got %d, want 400", w.Code)
+	}
+	if w := do("PUT", "/api/v1/releases/3.16.3/candidates/omr-v2-0-snap-1", `{"state":"promoted"}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("snapshot of another application: got %d, want 400", w.Code)
+	}
+	if w := do("PUT", "/api/v1/releases/3.16.3/candidates/missing", `{"state":"promoted"}`, "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown snapshot: got %d, want 404", w.Code)
+	}
+
+	w = do("PUT", path, `{"state":"promoted"}`, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("promote: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := selected(w); got != "quay-v3-16-snap-1" {
+		t.Errorf("after promote: got %q, want quay-v3-16-snap-1", got)
+	}
+	if got := signal(); got != "green" {
+		t.Errorf("readiness with promoted passing candidate: got %q, want green", got)
+	}
+
+	// Returning the promoted snapshot to a plain candidate and demoting the
+	// latest falls back to the newest remaining candidate.
+	if w := do("PUT", path, `{"state":"candidate"}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("reset: got %d", w.Code)
+	}
+	w = do("PUT", "/api/v1/releases/3.16.3/candidates/quay-v3-16-snap-2", `{"state":"demoted"}`, "secret")
+	if got := selected(w); got != "quay-v3-16-snap-1" {
+		t.Errorf("after demoting latest: got %q, want quay-v3-16-snap-1", got)
+	}
+
+	w = do("GET", "/api/v1/releases/3.16.3/snapshot", "", "")
+	var snap model.SnapshotRecord
+	if err := json.NewDecoder(w.Body).Decode(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Name != "quay-v3-16-snap-1" {
+		t.Errorf("release snapshot: got %q, want the selected candidate", snap.Name)
+	}
+
+	// Pinning a snapshot promotes it, even one that was demoted.
+	const pin = "/api/v1/releases/3.16.3/candidate"
+	if w := do("POST", pin, `{"snapshot":"quay-v3-16-snap-2"}`, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("pin without token: got %d, want 401", w.Code)
+	}
+	if w := do("POST", pin, `{}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("pin without snapshot: got %d, want 400", w.Code)
+	}
+	if w := do("POST", pin, `{"snapshot":"omr-v2-0-snap-1"}`, "secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("pin snapshot of another application: got %d, want 400", w.Code)
+	}
+	w = do("POST", pin, `{"snapshot":"quay-v3-16-snap-2"}`, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("pin: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := selected(w); got != "quay-v3-16-snap-2" {
+		t.Errorf("after pin: got %q, want quay-v3-16-snap-2", got)
+	}
+	if got := signal(); got != "yellow" {
+		t.Errorf("readiness with pinned failing candidate: got %q, want yellow", got)
+	}
+
+	// Pinning made the snapshot a lifecycle candidate; rejecting it demotes
+	// it, and reconsidering it lifts the demotion.
+	lifecycle := func() string {
+		t.Helper()
+		snap, err := database.GetSnapshotByName(ctx, "quay-v3-16-snap-2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return snap.Lifecycle
+	}
+	if got := lifecycle(); got != model.LifecycleCandidate {
+		t.Errorf("lifecycle after pin: got %q, want candidate", got)
+	}
+	const lc = "/api/v1/snapshots/quay-v3-16-snap-2/lifecycle"
+	if w := do("POST", lc, `{"state":"rejected","reason":"e2e failed"}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("reject: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := selected(do("GET", "/api/v1/releases/3.16.3/candidates", "", "")); got != "quay-v3-16-snap-1" {
+		t.Errorf("after rejecting pinned: got %q, want quay-v3-16-snap-1", got)
+	}
+	const path2 = "/api/v1/releases/3.16.3/candidates/quay-v3-16-snap-2"
+	if w := do("PUT", path2, `{"state":"candidate"}`, "secret"); w.Code != http.StatusConflict {
+		t.Errorf("reset rejected: got %d, want 409", w.Code)
+	}
+	if w := do("POST", lc, `{"state":"candidate"}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("reconsider: got %d: %s", w.Code, w.Body.String())
+	}
+	if got := selected(do("GET", "/api/v1/releases/3.16.3/candidates", "", "")); got != "quay-v3-16-snap-2" {
+		t.Errorf("after reconsidering: got %q, want quay-v3-16-snap-2", got)
+	}
+}
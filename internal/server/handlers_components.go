@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListComponents(w http.ResponseWriter, r *http.Request) {
+	components, err := s.db.ListComponents(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if components == nil {
+		components = []model.Component{}
+	}
+	for i := range components {
+		if components[i].JIRAComponents == nil {
+			components[i].JIRAComponents = []string{}
+		}
+	}
+	writeJSON(w, http.StatusOK, components)
+}
+
+// handleSetComponentJIRAComponents maps a dashboard component to the JIRA
+// components its issues are filed under. It is an admin endpoint; an empty
+// list restores the default of the JIRA component of the same name.
+func (s *Server) handleSetComponentJIRAComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+	var body struct {
+		JIRAComponents []string `json:"jira_components"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	var jiraComponents []string
+	for _, c := range body.JIRAComponents {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		// JIRA components are stored comma-separated, as labels are.
+		if strings.Contains(c, ",") {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("JIRA component %q contains a comma", c))
+			return
+		}
+		jiraComponents = append(jiraComponents, c)
+	}
+
+	if err := s.db.SetComponentJIRAComponents(ctx, name, jiraComponents); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("component %q", name))
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "component JIRA mapping changed", "component", name, "jira_components", jiraComponents)
+
+	component, err := s.db.GetComponentByName(ctx, name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("component %q", name))
+		return
+	}
+	if component.JIRAComponents == nil {
+		component.JIRAComponents = []string{}
+	}
+	writeJSON(w, http.StatusOK, component)
+}
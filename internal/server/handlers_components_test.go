@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestComponentIssueRollup(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"quay", "clair", "quay-operator"} {
+		if _, err := database.EnsureComponent(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, issue := range []model.JiraIssueRecord{
+		{Key: "PROJQUAY-1", Status: "New", Components: "quay", Labels: "release-blocker", Blocker: true},
+		{Key: "PROJQUAY-2", Status: "Verified", Components: "Quay,clair"},
+		{Key: "PROJQUAY-3", Status: "New", Components: "Operator"},
+		{Key: "PROJQUAY-4", Status: "New", Components: "Documentation"},
+		{Key: "PROJQUAY-5", Status: "New"},
+	} {
+		issue.FixVersion = "3.16.3"
+		issue.UpdatedAt = time.Now()
+		if err := database.UpsertJiraIssue(ctx, &issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	summaryComponents := func() []model.ComponentCount {
+		t.Helper()
+		w := do("GET", "/api/v1/releases/3.16.3/issues/summary", "", "")
+		var summary model.IssueSummary
+		if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+			t.Fatal(err)
+		}
+		return summary.Components
+	}
+
+	// Unmapped components match the JIRA component of the same name.
+	want := []model.ComponentCount{
+		{Component: "clair", Total: 1},
+		{Component: "quay", Total: 2, Open: 1, OpenBlockers: 1},
+	}
+	if got := summaryComponents(); !slices.Equal(got, want) {
+		t.Errorf("default mapping: got %+v, want %+v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name, path, body, token string
+		want                    int
+	}{
+		{"no token", "/api/v1/components/quay-operator/jira-components", `{"jira_components":[]}`, "", http.StatusUnauthorized},
+		{"bad body", "/api/v1/components/quay-operator/jira-components", `[]`, "secret", http.StatusBadRequest},
+		{"comma", "/api/v1/components/quay-operator/jira-components", `{"jira_components":["a,b"]}`, "secret", http.StatusBadRequest},
+		{"unknown component", "/api/v1/components/nope/jira-components", `{"jira_components":["a"]}`, "secret", http.StatusNotFound},
+	} {
+		if w := do("PUT", tc.path, tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	w := do("PUT", "/api/v1/components/quay-operator/jira-components", `{"jira_components":["Operator", " "]}`, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("set mapping: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var component model.Component
+	if err := json.NewDecoder(w.Body).Decode(&component); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(component.JIRAComponents, []string{"Operator"}) {
+		t.Errorf("mapped component: got %+v", component)
+	}
+
+	w = do("GET", "/api/v1/components", "", "")
+	var components []model.Component
+	if err := json.NewDecoder(w.Body).Decode(&components); err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 3 || components[1].Name != "quay" || len(components[1].JIRAComponents) != 0 {
+		t.Errorf("components: got %+v", components)
+	}
+
+	want = append(want, model.ComponentCount{Component: "quay-operator", Total: 1, Open: 1})
+	if got := summaryComponents(); !slices.Equal(got, want) {
+		t.Errorf("after mapping: got %+v, want %+v", got, want)
+	}
+
+	w = do("GET", "/api/v1/releases/overview", "", "")
+	var overviews []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overviews); err != nil {
+		t.Fatal(err)
+	}
+	if len(overviews) != 1 || overviews[0].IssueSummary == nil || !slices.Equal(overviews[0].IssueSummary.Components, want) {
+		t.Errorf("overview components: got %+v", overviews)
+	}
+}
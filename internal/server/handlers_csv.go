@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// wantCSV reports whether the format query parameter asks for CSV rather
+// than the default JSON.
+func wantCSV(r *http.Request) (bool, error) {
+	switch f := r.URL.Query().Get("format"); f {
+	case "", "json":
+		return false, nil
+	case "csv":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid format %q: must be json or csv", f)
+	}
+}
+
+// writeCSV writes header and rows as a CSV attachment named filename.
+// Cells are escaped with escapeCSVCell, since rows carry JIRA and test
+// report text that spreadsheets would otherwise evaluate.
+func writeCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	_ = cw.Write(header)
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = escapeCSVCell(cell)
+		}
+		_ = cw.Write(escaped)
+	}
+	cw.Flush() // writes to a buffer cannot fail
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	_, _ = w.Write(buf.Bytes())
+}
+
+// escapeCSVCell prefixes cell with a single quote if it starts with a
+// character that makes spreadsheets read it as a formula, so that exported
+// text such as an issue summary of "=HYPERLINK(...)" stays text.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+var issueCSVHeader = []string{
+	"key", "summary", "type", "status", "resolution", "priority", "severity",
+	"assignee", "qa_contact", "labels", "components", "blocker", "cve_id",
+	"cvss_score", "updated_at", "link",
+}
+
+func issueCSVRows(issues []model.JiraIssueRecord) [][]string {
+	rows := make([][]string, len(issues))
+	for i, is := range issues {
+		var cvss string
+		if is.CVSSScore > 0 {
+			cvss = strconv.FormatFloat(is.CVSSScore, 'f', -1, 64)
+		}
+		rows[i] = []string{
+			is.Key, is.Summary, is.IssueType, is.Status, is.Resolution, is.Priority, is.Severity,
+			is.Assignee, is.QAContact, is.Labels, is.Components, strconv.FormatBool(is.Blocker), is.CVEID,
+			cvss, is.UpdatedAt.UTC().Format(time.RFC3339), is.Link,
+		}
+	}
+	return rows
+}
+
+var testResultCSVHeader = []string{
+	"suite", "suite_status", "test", "status", "duration_ms", "retries", "flaky", "message",
+}
+
+// testResultCSVRows lists a snapshot's test cases, one row each. A suite
+// without recorded cases gets a single row with its own status.
+func testResultCSVRows(snap *model.SnapshotRecord) [][]string {
+	var rows [][]string
+	for _, suite := range snap.TestSuites {
+		if len(suite.TestCases) == 0 {
+			rows = append(rows, []string{
+				suite.Name, suite.Status, "", suite.Status,
+				strconv.FormatInt(suite.DurationMs, 10), "", "", "",
+			})
+			continue
+		}
+		for _, tc := range suite.TestCases {
+			rows = append(rows, []string{
+				suite.Name, suite.Status, tc.Name, tc.Status,
+				strconv.FormatFloat(tc.DurationMs, 'f', -1, 64), strconv.Itoa(tc.Retries),
+				strconv.FormatBool(tc.Flaky), tc.Message,
+			})
+		}
+	}
+	return rows
+}
+
+var historyCSVHeader = []string{
+	"recorded_at", "signal", "total", "open", "verified", "cves", "bugs", "blocking_cves",
+}
+
+func historyCSVRows(points []model.ReadinessPoint) [][]string {
+	rows := make([][]string, len(points))
+	for i, p := range points {
+		rows[i] = []string{
+			p.RecordedAt.UTC().Format(time.RFC3339), p.Signal,
+			strconv.Itoa(p.Total), strconv.Itoa(p.Open), strconv.Itoa(p.Verified),
+			strconv.Itoa(p.CVEs), strconv.Itoa(p.Bugs), strconv.Itoa(p.BlockingCVEs),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// getCSV fetches url and parses its CSV body.
+func getCSV(t *testing.T, srv *Server, url string) [][]string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s: got %d, body: %s", url, w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("%s: content type %q", url, ct)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("%s: %v", url, err)
+	}
+	return records
+}
+
+func TestCSVExport(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "3.16.3", S3Application: "quay-v3-16"}); err != nil {
+		t.Fatal(err)
+	}
+	err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
+		Key: "PROJQUAY-1", Summary: "fix bug, with a comma", Status: "Open",
+		Priority: "Major", FixVersion: "3.16.3", IssueType: "Bug", UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{
+		Key: "PROJQUAY-2", Summary: `=HYPERLINK("https://example.com","click")`, Status: "Open",
+		Priority: "Major", FixVersion: "3.16.3", IssueType: "Bug", UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = database.SaveSnapshot(ctx, &model.SnapshotRecord{
+		Application: "quay-v3-16",
+		Name:        "quay-v3-16-snap-1",
+		CreatedAt:   time.Now(),
+		TestSuites: []model.TestSuite{
+			{Name: "e2e", Status: "failed", Tests: 2, Passed: 1, Failed: 1, TestCases: []model.TestCase{
+				{Name: "login", Status: "passed", DurationMs: 12},
+				{Name: "push", Status: "failed", DurationMs: 30, Message: "timeout"},
+			}},
+			{Name: "smoke", Status: "passed", DurationMs: 5},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.CreateReadinessPoint(ctx, &model.ReadinessPoint{Release: "3.16.3", Signal: "yellow", Total: 1, Open: 1, Bugs: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := getCSV(t, srv, "/api/v1/releases/3.16.3/issues?format=csv")
+	if len(issues) != 3 || issues[0][0] != "key" || issues[1][0] != "PROJQUAY-1" || issues[1][1] != "fix bug, with a comma" {
+		t.Errorf("issues: got %q", issues)
+	}
+	if got := issues[2][1]; got != `'=HYPERLINK("https://example.com","click")` {
+		t.Errorf("formula summary: got %q, want it escaped", got)
+	}
+
+	tests := getCSV(t, srv, "/api/v1/snapshots/quay-v3-16-snap-1?format=csv")
+	if len(tests) != 4 || tests[0][0] != "suite" {
+		t.Fatalf("test results: got %q", tests)
+	}
+	if got := tests[2]; got[2] != "push" || got[3] != "failed" || got[7] != "timeout" {
+		t.Errorf("failed case: got %q", got)
+	}
+	if got := tests[3]; got[0] != "smoke" || got[2] != "" || got[3] != "passed" {
+		t.Errorf("suite without cases: got %q", got)
+	}
+
+	history := getCSV(t, srv, "/api/v1/releases/3.16.3/history?format=csv")
+	if len(history) != 2 || history[0][1] != "signal" || history[1][1] != "yellow" || history[1][3] != "1" {
+		t.Errorf("history: got %q", history)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/3.16.3/issues?format=xlsx", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad format: got %d, want 400", w.Code)
+	}
+}
+
+func TestEscapeCSVCell(t *testing.T) {
+	for cell, want := range map[string]string{
+		"":           "",
+		"timeout":    "timeout",
+		"=1+2":       "'=1+2",
+		"+1":         "'+1",
+		"-1":         "'-1",
+		"@SUM(A1)":   "'@SUM(A1)",
+		"\tindented": "'\tindented",
+		"\rreturn":   "'\rreturn",
+		"a=b":        "a=b",
+	} {
+		if got := escapeCSVCell(cell); got != want {
+			t.Errorf("escapeCSVCell(%q): got %q, want %q", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/gitaudit"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// changelogTimeout bounds how long a snapshot diff waits for changes that
+// are not cached yet; the components left out are looked up again on the
+// next request.
+const changelogTimeout = 15 * time.Second
+
+// Changelog lists the commits and merged pull requests between two
+// revisions of a repository. *gitaudit.Changelog implements it.
+type Changelog interface {
+	Changes(ctx context.Context, gitURL, from, to string) (*model.GitRange, error)
+}
+
+// handleSnapshotDiff compares snapshot {a} with the later snapshot {b} of
+// the same application.
+func (s *Server) handleSnapshotDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	from, err := s.db.GetSnapshotByName(ctx, r.PathValue("a"))
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", r.PathValue("a")))
+		return
+	}
+	to, err := s.db.GetSnapshotByName(ctx, r.PathValue("b"))
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", r.PathValue("b")))
+		return
+	}
+	if from.Application != to.Application {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("snapshots belong to different applications (%s, %s)", from.Application, to.Application))
+		return
+	}
+	diff := diffSnapshots(from, to)
+	if s.changelog != nil {
+		s.addChanges(ctx, diff.Components)
+	}
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// addChanges lists the commits and merged pull requests between the
+// revisions of each changed component. Lookups that fail are logged and
+// left out rather than failing the diff.
+func (s *Server) addChanges(ctx context.Context, changes []model.ComponentChange) {
+	ctx, cancel := context.WithTimeout(ctx, changelogTimeout)
+	defer cancel()
+	for i, c := range changes {
+		if c.CompareURL == "" {
+			continue
+		}
+		r, err := s.changelog.Changes(ctx, c.GitURL, c.FromRevision, c.ToRevision)
+		if err != nil {
+			if errors.Is(err, breaker.ErrOpen) || ctx.Err() != nil {
+				return
+			}
+			s.logger.WarnContext(ctx, "listing component changes failed", "component", c.Component, "error", err)
+			continue
+		}
+		if r != nil {
+			changes[i].Commits = r.Commits
+			changes[i].TotalCommits = r.TotalCommits
+			changes[i].PullRequests = r.PullRequests
+		}
+	}
+}
+
+// diffSnapshots lists what changed from one snapshot to another.
+func diffSnapshots(from, to *model.SnapshotRecord) model.SnapshotDiff {
+	diff := model.SnapshotDiff{
+		From:       snapshotMeta(from),
+		To:         snapshotMeta(to),
+		Components: []model.ComponentChange{},
+		Scenarios:  []model.ScenarioChange{},
+	}
+
+	fromComponents := make(map[string]model.ComponentRecord, len(from.Components))
+	for _, c := range from.Components {
+		fromComponents[c.Component] = c
+	}
+	for _, c := range to.Components {
+		old, ok := fromComponents[c.Component]
+		delete(fromComponents, c.Component)
+		switch {
+		case !ok:
+			diff.Components = append(diff.Components, model.ComponentChange{
+				Component: c.Component, Change: model.ComponentAdded,
+				ToImage: c.ImageURL, ToRevision: c.GitSHA, GitURL: c.GitURL,
+			})
+		case old.ImageURL != c.ImageURL || old.GitSHA != c.GitSHA:
+			change := model.ComponentChange{
+				Component: c.Component, Change: model.ComponentChanged,
+				FromImage: old.ImageURL, ToImage: c.ImageURL,
+				FromRevision: old.GitSHA, ToRevision: c.GitSHA,
+				GitURL: c.GitURL,
+			}
+			if old.GitSHA != c.GitSHA && old.GitURL == c.GitURL {
+				change.CompareURL = compareURL(c.GitURL, old.GitSHA, c.GitSHA)
+			}
+			diff.Components = append(diff.Components, change)
+		}
+	}
+	for _, old := range fromComponents {
+		diff.Components = append(diff.Components, model.ComponentChange{
+			Component: old.Component, Change: model.ComponentRemoved,
+			FromImage: old.ImageURL, FromRevision: old.GitSHA, GitURL: old.GitURL,
+		})
+	}
+	slices.SortFunc(diff.Components, func(a, b model.ComponentChange) int {
+		return strings.Compare(a.Component, b.Component)
+	})
+
+	fromSuites := make(map[string]model.TestSuite, len(from.TestSuites))
+	for _, ts := range from.TestSuites {
+		fromSuites[ts.Name] = ts
+		diff.Tests.From = addCounts(diff.Tests.From, suiteCounts(ts))
+	}
+	for _, ts := range to.TestSuites {
+		diff.Tests.To = addCounts(diff.Tests.To, suiteCounts(ts))
+		old, ok := fromSuites[ts.Name]
+		delete(fromSuites, ts.Name)
+		change := model.ScenarioChange{Name: ts.Name, ToStatus: ts.Status, Delta: suiteCounts(ts)}
+		switch {
+		case !ok:
+			change.Change = model.ScenarioAdded
+		case old.Status == ts.Status:
+			continue
+		case ts.Status == "failed":
+			change.Change = model.ScenarioRegressed
+		case old.Status == "failed":
+			change.Change = model.ScenarioRecovered
+		default:
+			continue
+		}
+		if ok {
+			change.FromStatus = old.Status
+			change.Delta = subCounts(change.Delta, suiteCounts(old))
+		}
+		diff.Scenarios = append(diff.Scenarios, change)
+	}
+	for _, old := range fromSuites {
+		diff.Scenarios = append(diff.Scenarios, model.ScenarioChange{
+			Name: old.Name, Change: model.ScenarioRemoved, FromStatus: old.Status,
+			Delta: subCounts(model.TestCounts{}, suiteCounts(old)),
+		})
+	}
+	slices.SortFunc(diff.Scenarios, func(a, b model.ScenarioChange) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	diff.Tests.Delta = subCounts(diff.Tests.To, diff.Tests.From)
+	return diff
+}
+
+// snapshotMeta returns the metadata of snap without its nested records.
+func snapshotMeta(snap *model.SnapshotRecord) model.SnapshotRecord {
+	return model.SnapshotRecord{
+		ID:           snap.ID,
+		Application:  snap.Application,
+		Name:         snap.Name,
+		TestsPassed:  snap.TestsPassed,
+		HasTests:     snap.HasTests,
+		CreatedAt:    snap.CreatedAt,
+		ImageDigests: snap.ImageDigests,
+	}
+}
+
+// compareURL links the GitHub comparison of two revisions of gitURL, or
+// returns "" if the repository is not on GitHub.
+func compareURL(gitURL, from, to string) string {
+	repo, ok := gitaudit.ParseRepo(gitURL)
+	if !ok || from == "" || to == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/compare/%s...%s", repo.Owner, repo.Name, from, to)
+}
+
+func suiteCounts(ts model.TestSuite) model.TestCounts {
+	return model.TestCounts{Tests: ts.Tests, Passed: ts.Passed, Failed: ts.Failed, Skipped: ts.Skipped}
+}
+
+func addCounts(a, b model.TestCounts) model.TestCounts {
+	return model.TestCounts{Tests: a.Tests + b.Tests, Passed: a.Passed + b.Passed, Failed: a.Failed + b.Failed, Skipped: a.Skipped + b.Skipped}
+}
+
+func subCounts(a, b model.TestCounts) model.TestCounts {
+	return model.TestCounts{Tests: a.Tests - b.Tests, Passed: a.Passed - b.Passed, Failed: a.Failed - b.Failed, Skipped: a.Skipped - b.Skipped}
+}
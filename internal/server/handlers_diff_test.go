@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	type suite struct {
+		name                  string
+		tests, passed, failed int
+	}
+	create := func(app, name string, components map[string]string, suites []suite) {
+		t.Helper()
+		snap, err := database.CreateSnapshot(ctx, app, name, true, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for comp, sha := range components {
+			if _, err := database.EnsureComponent(ctx, comp); err != nil {
+				t.Fatal(err)
+			}
+			if err := database.CreateSnapshotComponent(ctx, snap.ID, comp, sha, "quay.io/quay/"+comp+"@sha256:"+sha, "https://github.com/quay/"+comp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, s := range suites {
+			status := "passed"
+			if s.failed > 0 {
+				status = "failed"
+			}
+			if _, err := database.CreateTestSuite(ctx, snap.ID, s.name, status, "", "", "", s.tests, s.passed, s.failed, 0, 0, 0, 0, 0, 0, 0, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	create("quay-v3-17", "snap-1",
+		map[string]string{"quay": "aaa", "clair": "ccc", "builder": "bbb"},
+		[]suite{{"api", 10, 10, 0}, {"ui", 5, 3, 2}, {"upgrade", 4, 4, 0}, {"legacy", 2, 2, 0}})
+	create("quay-v3-17", "snap-2",
+		map[string]string{"quay": "aab", "clair": "ccc", "mirror": "mmm"},
+		[]suite{{"api", 11, 9, 2}, {"ui", 5, 5, 0}, {"upgrade", 4, 4, 0}, {"fips", 3, 3, 0}})
+	create("quay-v3-16", "other-app", nil, nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/snapshots/snap-1/diff/snap-2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("diff: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var diff model.SnapshotDiff
+	if err := json.NewDecoder(w.Body).Decode(&diff); err != nil {
+		t.Fatal(err)
+	}
+	if diff.From.Name != "snap-1" || diff.To.Name != "snap-2" || diff.To.Components != nil {
+		t.Errorf("snapshots: got %+v -> %+v", diff.From, diff.To)
+	}
+
+	wantComponents := []model.ComponentChange{
+		{Component: "builder", Change: model.ComponentRemoved, FromImage: "quay.io/quay/builder@sha256:bbb", FromRevision: "bbb", GitURL: "https://github.com/quay/builder"},
+		{Component: "mirror", Change: model.ComponentAdded, ToImage: "quay.io/quay/mirror@sha256:mmm", ToRevision: "mmm", GitURL: "https://github.com/quay/mirror"},
+		{Component: "quay", Change: model.ComponentChanged,
+			FromImage: "quay.io/quay/quay@sha256:aaa", ToImage: "quay.io/quay/quay@sha256:aab",
+			FromRevision: "aaa", ToRevision: "aab", GitURL: "https://github.com/quay/quay",
+			CompareURL: "https://github.com/quay/quay/compare/aaa...aab"},
+	}
+	if len(diff.Components) != len(wantComponents) {
+		t.Fatalf("components: got %+v", diff.Components)
+	}
+	for i, want := range wantComponents {
+		if !reflect.DeepEqual(diff.Components[i], want) {
+			t.Errorf("component %d: got %+v, want %+v", i, diff.Components[i], want)
+		}
+	}
+
+	wantScenarios := []model.ScenarioChange{
+		{Name: "api", Change: model.ScenarioRegressed, FromStatus: "passed", ToStatus: "failed", Delta: model.TestCounts{Tests: 1, Passed: -1, Failed: 2}},
+		{Name: "fips", Change: model.ScenarioAdded, ToStatus: "passed", Delta: model.TestCounts{Tests: 3, Passed: 3}},
+		{Name: "legacy", Change: model.ScenarioRemoved, FromStatus: "passed", Delta: model.TestCounts{Tests: -2, Passed: -2}},
+		{Name: "ui", Change: model.ScenarioRecovered, FromStatus: "failed", ToStatus: "passed", Delta: model.TestCounts{Passed: 2, Failed: -2}},
+	}
+	if len(diff.Scenarios) != len(wantScenarios) {
+		t.Fatalf("scenarios: got %+v", diff.Scenarios)
+	}
+	for i, want := range wantScenarios {
+		if diff.Scenarios[i] != want {
+			t.Errorf("scenario %d: got %+v, want %+v", i, diff.Scenarios[i], want)
+		}
+	}
+
+	wantTests := model.TestCountDiff{
+		From:  model.TestCounts{Tests: 21, Passed: 19, Failed: 2},
+		To:    model.TestCounts{Tests: 23, Passed: 21, Failed: 2},
+		Delta: model.TestCounts{Tests: 2, Passed: 2},
+	}
+	if diff.Tests != wantTests {
+		t.Errorf("tests: got %+v, want %+v", diff.Tests, wantTests)
+	}
+
+	if w := get("/api/v1/snapshots/snap-1/diff/other-app"); w.Code != http.StatusBadRequest {
+		t.Errorf("different applications: got %d, want 400", w.Code)
+	}
+	if w := get("/api/v1/snapshots/snap-1/diff/missing"); w.Code != http.StatusNotFound {
+		t.Errorf("missing snapshot: got %d, want 404", w.Code)
+	}
+}
+
+type fakeChangelog map[string]*model.GitRange // "gitURL@from...to" -> changes
+
+func (f fakeChangelog) Changes(ctx context.Context, gitURL, from, to string) (*model.GitRange, error) {
+	r, ok := f[gitURL+"@"+from+"..."+to]
+	if !ok {
+		return nil, errors.New("compare failed")
+	}
+	return r, nil
+}
+
+func TestSnapshotDiffChanges(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	for _, s := range []struct{ name, quay, clair string }{{"snap-1", "aaa", "ccc"}, {"snap-2", "aab", "ccd"}} {
+		snap, err := database.CreateSnapshot(ctx, "quay-v3-17", s.name, true, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for comp, sha := range map[string]string{"quay": s.quay, "clair": s.clair} {
+			if _, err := database.EnsureComponent(ctx, comp); err != nil {
+				t.Fatal(err)
+			}
+			if err := database.CreateSnapshotComponent(ctx, snap.ID, comp, sha, "", "https://github.com/quay/"+comp); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	merged := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	want := &model.GitRange{
+		Commits:      []model.Commit{{SHA: "aab", Subject: "Fix login", Author: "alice", URL: "https://github.com/quay/quay/commit/aab"}},
+		TotalCommits: 1,
+		PullRequests: []model.PullRequest{{Number: 12, Title: "Fix login", Author: "alice", URL: "https://github.com/quay/quay/pull/12", MergedAt: merged}},
+	}
+	srv.SetChangelog(fakeChangelog{"https://github.com/quay/quay@aaa...aab": want})
+
+	req := httptest.NewRequest("GET", "/api/v1/snapshots/snap-1/diff/snap-2", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("diff: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var diff model.SnapshotDiff
+	if err := json.NewDecoder(w.Body).Decode(&diff); err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Components) != 2 {
+		t.Fatalf("components: got %+v", diff.Components)
+	}
+	// The clair lookup fails and is left out without failing the diff.
+	if clair := diff.Components[0]; clair.Component != "clair" || clair.Commits != nil || clair.PullRequests != nil {
+		t.Errorf("clair: got %+v", clair)
+	}
+	quay := diff.Components[1]
+	if !reflect.DeepEqual(quay.Commits, want.Commits) || quay.TotalCommits != 1 {
+		t.Errorf("quay commits: got %d %+v, want %+v", quay.TotalCommits, quay.Commits, want.Commits)
+	}
+	if !reflect.DeepEqual(quay.PullRequests, want.PullRequests) {
+		t.Errorf("quay pull requests: got %+v, want %+v", quay.PullRequests, want.PullRequests)
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleListDigestSubscriptions lists the email digest subscriptions. It is
+// a release-manager endpoint, as it lists recipients' addresses.
+func (s *Server) handleListDigestSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := s.db.ListDigestSubscriptions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if subs == nil {
+		subs = []model.DigestSubscription{}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, subs)
+}
+
+type digestSubscriptionRequest struct {
+	Email     string `json:"email"`
+	Release   string `json:"release"`
+	Frequency string `json:"frequency"`
+}
+
+// handleCreateDigestSubscription subscribes a recipient to the daily or
+// weekly digest of a release, or of the releases matching a glob. It is a
+// release-manager endpoint.
+func (s *Server) handleCreateDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req digestSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid email: %w", err))
+		return
+	}
+	sub := model.DigestSubscription{
+		Email:     strings.ToLower(addr.Address),
+		Release:   strings.TrimSpace(req.Release),
+		Frequency: req.Frequency,
+	}
+	if sub.Release == "" {
+		writeError(w, http.StatusBadRequest, errors.New("release is required"))
+		return
+	}
+	if _, err := path.Match(sub.Release, ""); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("release pattern %q: %w", sub.Release, err))
+		return
+	}
+	if sub.Frequency != model.DigestDaily && sub.Frequency != model.DigestWeekly {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("frequency must be %s or %s", model.DigestDaily, model.DigestWeekly))
+		return
+	}
+
+	sub.CreatedBy, _, _ = s.principal(r)
+	if err := s.db.CreateDigestSubscription(ctx, &sub); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("%s digest of %q for %s", sub.Frequency, sub.Release, sub.Email))
+		return
+	}
+	s.logger.InfoContext(ctx, "digest subscription created", "id", sub.ID, "release", sub.Release,
+		"frequency", sub.Frequency, "by", sub.CreatedBy)
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+// handleDeleteDigestSubscription unsubscribes a recipient. It is a
+// release-manager endpoint.
+func (s *Server) handleDeleteDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid subscription ID"))
+		return
+	}
+	if err := s.db.DeleteDigestSubscription(ctx, id); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("digest subscription %d", id))
+		return
+	}
+	s.logger.InfoContext(ctx, "digest subscription deleted", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestDigestSubscriptions(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", `{"email":"qe@example.com","release":"quay-v3.16.*","frequency":"daily"}`, "", http.StatusUnauthorized},
+		{"bad email", `{"email":"qe","release":"quay-v3.16.*","frequency":"daily"}`, "secret", http.StatusBadRequest},
+		{"no release", `{"email":"qe@example.com","frequency":"daily"}`, "secret", http.StatusBadRequest},
+		{"bad pattern", `{"email":"qe@example.com","release":"quay-v3.16.[","frequency":"daily"}`, "secret", http.StatusBadRequest},
+		{"bad frequency", `{"email":"qe@example.com","release":"quay-v3.16.*","frequency":"hourly"}`, "secret", http.StatusBadRequest},
+		{"created", `{"email":"QE Team <QE@example.com>","release":"quay-v3.16.*","frequency":"daily"}`, "secret", http.StatusCreated},
+		{"duplicate", `{"email":"qe@example.com","release":"quay-v3.16.*","frequency":"daily"}`, "secret", http.StatusConflict},
+		{"other frequency", `{"email":"qe@example.com","release":"quay-v3.16.*","frequency":"weekly"}`, "secret", http.StatusCreated},
+	} {
+		if w := do("POST", "/api/v1/digests/subscriptions", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d; body: %s", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	if w := do("GET", "/api/v1/digests/subscriptions", "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("list without token: got %d", w.Code)
+	}
+	list := func() []model.DigestSubscription {
+		t.Helper()
+		w := do("GET", "/api/v1/digests/subscriptions", "", "secret")
+		var subs []model.DigestSubscription
+		if err := json.NewDecoder(w.Body).Decode(&subs); err != nil {
+			t.Fatal(err)
+		}
+		return subs
+	}
+	subs := list()
+	if len(subs) != 2 || subs[0].Email != "qe@example.com" || subs[0].Frequency != "daily" || subs[0].CreatedBy != "admin-token" {
+		t.Fatalf("subscriptions: got %+v", subs)
+	}
+
+	if w := do("DELETE", fmt.Sprintf("/api/v1/digests/subscriptions/%d", subs[0].ID), "", "secret"); w.Code != http.StatusNoContent {
+		t.Errorf("delete: got %d", w.Code)
+	}
+	if w := do("DELETE", fmt.Sprintf("/api/v1/digests/subscriptions/%d", subs[0].ID), "", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("delete again: got %d", w.Code)
+	}
+	if subs := list(); len(subs) != 1 || subs[0].Frequency != "weekly" {
+		t.Errorf("after delete: got %+v", subs)
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestEvents(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/events", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("without broker: got %d, want 404", w.Code)
+	}
+
+	broker := events.New()
+	srv.SetEvents(broker)
+	ts := httptest.NewServer(srv.http.Handler)
+	defer ts.Close()
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", ts.URL+"/api/v1/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" || resp.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("headers: got %v", resp.Header)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	// The retry hint is flushed once the stream is subscribed.
+	if !lines.Scan() || !strings.HasPrefix(lines.Text(), "retry:") {
+		t.Fatalf("first line: got %q", lines.Text())
+	}
+
+	broker.Publish(events.Event{Kind: events.KindSnapshot, Application: "quay-v3-16", Snapshot: "quay-v3-16-abc"})
+	var event, data string
+	for lines.Scan() && data == "" {
+		line := lines.Text()
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			event = v
+		}
+		if v, ok := strings.CutPrefix(line, "data: "); ok {
+			data = v
+		}
+	}
+	if event != "snapshot" {
+		t.Errorf("event: got %q, want snapshot", event)
+	}
+	var got events.Event
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Snapshot != "quay-v3-16-abc" || got.Application != "quay-v3-16" {
+		t.Errorf("data: got %+v", got)
+	}
+}
+
+func TestEventsInvalidateCaches(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	broker := events.New()
+	srv.SetEvents(broker)
+
+	loads := 0
+	load := func(context.Context) ([]model.ReleaseOverview, error) {
+		loads++
+		return nil, nil
+	}
+	ctx := t.Context()
+	for range 2 {
+		if _, err := srv.overviewCache.get(ctx, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("loads before the event: got %d, want 1", loads)
+	}
+
+	// No stream is open: the server drops its caches on its own.
+	broker.Publish(events.Event{Kind: events.KindIssues, Release: "quay-v3.16.0"})
+	deadline := time.Now().Add(5 * time.Second)
+	for loads == 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := srv.overviewCache.get(ctx, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("loads after the event: got %d, want 2", loads)
+	}
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Days of failures grouped, and number of groups returned, by default and
+// at most.
+const (
+	defaultFailureDays   = 14
+	maxFailureDays       = 90
+	defaultFailureGroups = 50
+	maxFailureGroups     = 500
+)
+
+// failureMessageMasks replace, in order, the parts of a failure message
+// that differ between runs of the same failure.
+var failureMessageMasks = []struct {
+	re   *regexp.Regexp
+	mask string
+}{
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`), "<date>"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`\b1\d{9}(\d{3})?\b`), "<time>"}, // Unix seconds or milliseconds
+	{regexp.MustCompile(`sha256:[0-9a-f]{64}`), "<digest>"},
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`), "<addr>"},
+	{regexp.MustCompile(`\b[0-9a-f]{12,}\b`), "<hex>"},
+}
+
+// maskFailureMessage masks the timestamps, UUIDs, digests, addresses and
+// other hex IDs in a failure message and collapses its whitespace, so that
+// the messages of the same failure in different runs compare equal.
+func maskFailureMessage(msg string) string {
+	for _, m := range failureMessageMasks {
+		msg = m.re.ReplaceAllString(msg, m.mask)
+	}
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// handleListFailureGroups groups the test case failures of the snapshots
+// of the last days days by masked message, so that a failure recurring
+// across snapshots and versions is triaged once. Groups failing in the
+// most snapshots come first. application limits the failures to one
+// application, and q the groups to those whose message or test names
+// contain it, ignoring case.
+func (s *Server) handleListFailureGroups(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	days, err := queryInt(q, "days")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if days < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid days %d: must not be negative", days))
+		return
+	}
+	if days == 0 {
+		days = defaultFailureDays
+	}
+	days = min(days, maxFailureDays)
+	limit, err := queryInt(q, "limit")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if limit == 0 {
+		limit = defaultFailureGroups
+	}
+	limit = min(limit, maxFailureGroups)
+
+	failures, err := s.db.ListTestFailures(r.Context(), q.Get("application"), time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	groups := groupFailures(failures)
+	if filter := strings.ToLower(strings.TrimSpace(q.Get("q"))); filter != "" {
+		groups = slices.DeleteFunc(groups, func(g model.FailureGroup) bool {
+			return !failureGroupMatches(g, filter)
+		})
+	}
+	if len(groups) > limit {
+		groups = groups[:limit]
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
+
+// groupFailures groups failures, which are ordered newest snapshot first,
+// by masked message. Failures without a message form one group whose
+// message is empty. Groups failing in more snapshots come first, then
+// those with more failures, then the most recent.
+func groupFailures(failures []model.TestFailure) []model.FailureGroup {
+	groups := []model.FailureGroup{}
+	index := make(map[string]int)
+	type seen struct {
+		snapshots, applications map[string]bool
+		tests                   map[model.FailedTest]bool
+	}
+	var sets []seen
+	for _, f := range failures {
+		msg := maskFailureMessage(f.Message)
+		i, ok := index[msg]
+		if !ok {
+			i = len(groups)
+			index[msg] = i
+			groups = append(groups, model.FailureGroup{Message: msg, LastSeen: f.CreatedAt, Latest: f})
+			sets = append(sets, seen{map[string]bool{}, map[string]bool{}, map[model.FailedTest]bool{}})
+		}
+		g, set := &groups[i], sets[i]
+		g.Failures++
+		g.FirstSeen = f.CreatedAt
+		if !set.snapshots[f.Snapshot] {
+			set.snapshots[f.Snapshot] = true
+			g.Snapshots = append(g.Snapshots, f.Snapshot)
+		}
+		if !set.applications[f.Application] {
+			set.applications[f.Application] = true
+			g.Applications = append(g.Applications, f.Application)
+		}
+		test := model.FailedTest{Scenario: f.Scenario, Test: f.Test}
+		if !set.tests[test] {
+			set.tests[test] = true
+			g.Tests = append(g.Tests, test)
+		}
+	}
+	for i := range groups {
+		slices.Sort(groups[i].Applications)
+		slices.SortFunc(groups[i].Tests, func(a, b model.FailedTest) int {
+			return cmp.Or(cmp.Compare(a.Scenario, b.Scenario), cmp.Compare(a.Test, b.Test))
+		})
+	}
+	slices.SortStableFunc(groups, func(a, b model.FailureGroup) int {
+		return cmp.Or(
+			cmp.Compare(len(b.Snapshots), len(a.Snapshots)),
+			cmp.Compare(b.Failures, a.Failures),
+			b.LastSeen.Compare(a.LastSeen),
+		)
+	})
+	return groups
+}
+
+// failureGroupMatches reports whether the message or a test name of g
+// contains filter, which is lower case.
+func failureGroupMatches(g model.FailureGroup, filter string) bool {
+	if strings.Contains(strings.ToLower(g.Message), filter) {
+		return true
+	}
+	return slices.ContainsFunc(g.Tests, func(t model.FailedTest) bool {
+		return strings.Contains(strings.ToLower(t.Test), filter)
+	})
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestMaskFailureMessage(t *testing.T) {
+	for msg, want := range map[string]string{
+		"AssertionError: mirror did not complete within 60s":                    "AssertionError: mirror did not complete within 60s",
+		"repo 3f2b8c1e-9a4d-4e6f-8b2a-1c3d5e7f9a0b not found":                   "repo <uuid> not found",
+		"timed out at 2026-10-14T08:15:02.123Z waiting for build":               "timed out at <time> waiting for build",
+		"2026-10-14 08:15:02+00:00 ERROR worker died":                           "<time> ERROR worker died",
+		"[08:15:02.5] expected 200, got 502":                                    "[<time>] expected 200, got 502",
+		"expired on 2026-10-14":                                                 "expired on <date>",
+		"token issued at 1760429702 rejected":                                   "token issued at <time> rejected",
+		"pull quay.io/quay/quay@sha256:" + fmt.Sprintf("%064x", 42) + " failed": "pull quay.io/quay/quay@<digest> failed",
+		"nil pointer dereference at 0xc000123abc":                               "nil pointer dereference at <addr>",
+		"commit 4b825dc642cb6eb9a060e54bf8d69288fbee4904 missing":               "commit <hex> missing",
+		"  expected\n\tfoo   bar ":                                              "expected foo bar",
+	} {
+		if got := maskFailureMessage(msg); got != want {
+			t.Errorf("%q: got %q, want %q", msg, got, want)
+		}
+	}
+}
+
+func TestListFailureGroups(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	start := time.Now().Add(-time.Hour)
+	for i, app := range []string{"quay-v3-16", "quay-v3-17", "quay-v3-17"} {
+		suite := model.TestSuite{
+			Name: "e2e", Status: "failed", Tests: 3, Failed: 2,
+			TestCases: []model.TestCase{
+				{Name: "test_mirror_sync", Status: "failed", Message: fmt.Sprintf("mirror of repo %08d-0000-4000-8000-000000000000 timed out", i)},
+				{Name: "test_gc", Status: "failed", Message: fmt.Sprintf("expected %d blobs", i)},
+				{Name: "test_login", Status: "skipped", Message: "not supported"},
+			},
+		}
+		if i == 0 {
+			suite.Tests++
+			suite.Failed++
+			suite.TestCases = append(suite.TestCases, model.TestCase{Name: "test_teardown", Status: "failed", Message: " \n"})
+		}
+		err := database.SaveSnapshot(ctx, &model.SnapshotRecord{
+			Application: app,
+			Name:        fmt.Sprintf("%s-snap-%d", app, i),
+			CreatedAt:   start.Add(time.Duration(i) * time.Minute),
+			TestSuites:  []model.TestSuite{suite},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Failures older than the window are left out.
+	err := database.SaveSnapshot(ctx, &model.SnapshotRecord{
+		Application: "quay-v3-15",
+		Name:        "quay-v3-15-old",
+		CreatedAt:   time.Now().AddDate(0, 0, -30),
+		TestSuites: []model.TestSuite{{
+			Name: "e2e", Status: "failed", Tests: 2, Failed: 2,
+			TestCases: []model.TestCase{
+				{Name: "test_mirror_sync", Status: "failed", Message: "mirror of repo 3f2b8c1e-9a4d-4e6f-8b2a-1c3d5e7f9a0b timed out"},
+				{Name: "test_teardown", Status: "failed"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(query string) []model.FailureGroup {
+		t.Helper()
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/failure-groups"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d, body: %s", query, w.Code, w.Body.String())
+		}
+		var groups []model.FailureGroup
+		if err := json.NewDecoder(w.Body).Decode(&groups); err != nil {
+			t.Fatal(err)
+		}
+		return groups
+	}
+
+	noMessage := func(groups []model.FailureGroup) model.FailureGroup {
+		t.Helper()
+		i := slices.IndexFunc(groups, func(g model.FailureGroup) bool { return g.Message == "" })
+		if i < 0 {
+			t.Fatalf("no group of failures without a message: got %+v", groups)
+		}
+		return groups[i]
+	}
+
+	groups := get("")
+	if len(groups) != 5 {
+		t.Fatalf("groups: got %+v", groups)
+	}
+	mirror := groups[0]
+	if mirror.Message != "mirror of repo <uuid> timed out" || mirror.Failures != 3 ||
+		!slices.Equal(mirror.Snapshots, []string{"quay-v3-17-snap-2", "quay-v3-17-snap-1", "quay-v3-16-snap-0"}) ||
+		!slices.Equal(mirror.Applications, []string{"quay-v3-16", "quay-v3-17"}) ||
+		!slices.Equal(mirror.Tests, []model.FailedTest{{Scenario: "e2e", Test: "test_mirror_sync"}}) ||
+		!mirror.FirstSeen.Before(mirror.LastSeen) || mirror.Latest.Snapshot != "quay-v3-17-snap-2" ||
+		mirror.Latest.Message != "mirror of repo 00000002-0000-4000-8000-000000000000 timed out" {
+		t.Errorf("mirror group: got %+v", mirror)
+	}
+	// The GC failures differ in their counts, so each is its own group,
+	// newest first.
+	for i, want := range []string{"expected 2 blobs", "expected 1 blobs", "expected 0 blobs"} {
+		if g := groups[i+1]; g.Message != want || g.Failures != 1 {
+			t.Errorf("group %d: got %+v, want %q", i+1, g, want)
+		}
+	}
+
+	// Failures without a message, or only whitespace, are grouped too.
+	if g := noMessage(groups); g.Failures != 1 || !slices.Equal(g.Tests, []model.FailedTest{{Scenario: "e2e", Test: "test_teardown"}}) {
+		t.Errorf("group without a message: got %+v", g)
+	}
+
+	if groups := get("?application=quay-v3-16&q=MIRROR"); len(groups) != 1 || groups[0].Failures != 1 {
+		t.Errorf("filtered: got %+v", groups)
+	}
+	if groups := get("?q=test_gc&limit=2"); len(groups) != 2 || groups[0].Message != "expected 2 blobs" {
+		t.Errorf("by test name: got %+v", groups)
+	}
+	if groups := get("?days=60"); len(groups) != 5 || groups[0].Failures != 4 || noMessage(groups).Failures != 2 {
+		t.Errorf("60 days: got %+v", groups)
+	}
+	if groups := get("?application=none"); groups == nil || len(groups) != 0 {
+		t.Errorf("no failures: got %#v", groups)
+	}
+
+	for _, query := range []string{"?days=-1", "?limit=x"} {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/failure-groups"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want 400", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleReleasesICS serves an iCalendar feed of the due, freeze, and
+// scheduled release dates of active releases, for subscribing from team
+// calendars. All events are all-day.
+func (s *Server) handleReleasesICS(w http.ResponseWriter, r *http.Request) {
+	events, err := s.calendarEvents(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	cal := &icsWriter{stamp: time.Now().UTC().Format("20060102T150405Z")}
+	cal.line("BEGIN:VCALENDAR")
+	cal.line("VERSION:2.0")
+	cal.line("PRODID:-//quay//release-readiness//EN")
+	cal.line("CALSCALE:GREGORIAN")
+	cal.line("X-WR-CALNAME:Release dates")
+	for _, e := range events {
+		cal.event(e.Release+"-"+e.Kind, e.Summary, e.Description, e.Start, e.End)
+	}
+	cal.line("END:VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="releases.ics"`)
+	_, _ = w.Write([]byte(cal.String()))
+}
+
+// handleReleasesCalendar returns the events of the iCalendar feed as JSON,
+// earliest first.
+func (s *Server) handleReleasesCalendar(w http.ResponseWriter, r *http.Request) {
+	events, err := s.calendarEvents(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	slices.SortStableFunc(events, func(a, b model.CalendarEvent) int { return a.Start.Compare(b.Start) })
+	writeJSON(w, http.StatusOK, events)
+}
+
+// calendarEvents returns the code freeze, due and scheduled release dates
+// of the releases that are neither released, archived nor hidden, release
+// by release.
+func (s *Server) calendarEvents(ctx context.Context) ([]model.CalendarEvent, error) {
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := []model.CalendarEvent{}
+	add := func(rel model.ReleaseVersion, kind, summary string, start, end time.Time) {
+		events = append(events, model.CalendarEvent{
+			Release:     rel.Name,
+			Kind:        kind,
+			Start:       start,
+			End:         end,
+			Summary:     summary,
+			Description: s.releaseDescription(rel),
+		})
+	}
+	for _, rel := range releases {
+		if rel.Released || rel.Archived || rel.Hidden {
+			continue
+		}
+		if rel.DueDate != nil {
+			due := *rel.DueDate
+			if s.freezeWindow > 0 {
+				add(rel, model.CalendarFreeze, rel.Name+" code freeze", due.Add(-s.freezeWindow), due)
+			}
+			add(rel, model.CalendarDue, rel.Name+" due", due, due.AddDate(0, 0, 1))
+		}
+		if rel.ReleaseDate != nil {
+			add(rel, model.CalendarRelease, rel.Name+" release", *rel.ReleaseDate, rel.ReleaseDate.AddDate(0, 0, 1))
+		}
+	}
+	return events, nil
+}
+
+// releaseDescription links a release's ticket, if it has one.
+func (s *Server) releaseDescription(rel model.ReleaseVersion) string {
+	if rel.ReleaseTicketKey == "" {
+		return ""
+	}
+	desc := "Release ticket: " + rel.ReleaseTicketKey
+	if s.jiraBaseURL != "" {
+		desc += " (" + strings.TrimSuffix(s.jiraBaseURL, "/") + "/browse/" + rel.ReleaseTicketKey + ")"
+	}
+	if rel.ReleaseTicketAssignee != "" {
+		desc += "\nAssignee: " + rel.ReleaseTicketAssignee
+	}
+	return desc
+}
+
+// icsWriter builds an RFC 5545 calendar: CRLF line endings, lines folded
+// at 75 octets, and escaped text values.
+type icsWriter struct {
+	strings.Builder
+	stamp string // DTSTAMP of every event
+}
+
+// event writes an all-day event covering the days from start up to, but
+// not including, end.
+func (c *icsWriter) event(uid, summary, description string, start, end time.Time) {
+	c.line("BEGIN:VEVENT")
+	c.line("UID:" + uid + "@release-readiness")
+	c.line("DTSTAMP:" + c.stamp)
+	c.line("DTSTART;VALUE=DATE:" + start.UTC().Format("20060102"))
+	c.line("DTEND;VALUE=DATE:" + end.UTC().Format("20060102"))
+	c.line("SUMMARY:" + icsEscape(summary))
+	if description != "" {
+		c.line("DESCRIPTION:" + icsEscape(description))
+	}
+	c.line("TRANSP:TRANSPARENT")
+	c.line("END:VEVENT")
+}
+
+func (c *icsWriter) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		// Don't split a UTF-8 sequence.
+		n := limit
+		for n > 0 && s[n]&0xC0 == 0x80 {
+			n--
+		}
+		c.WriteString(s[:n] + "\r\n ")
+		s = s[n:]
+		limit = 74 // continuation lines start with a space
+	}
+	c.WriteString(s + "\r\n")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
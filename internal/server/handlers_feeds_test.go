@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleasesICS(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetFreezeWindow(7 * 24 * time.Hour)
+	ctx := t.Context()
+
+	date := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	for _, rel := range []model.ReleaseVersion{
+		{Name: "3.16.3", DueDate: date(20), ReleaseDate: date(24), ReleaseTicketKey: "PROJQUAY-100", ReleaseTicketAssignee: "Jane Doe, QE"},
+		{Name: "3.16.2", DueDate: date(5), Released: true, ReleaseDate: date(6)},
+		{Name: "3.15.9", DueDate: date(5), Archived: true},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/feeds/releases.ics", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("feed: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+		t.Errorf("content type: got %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(body, "\r\n ", "")
+	for _, want := range []string{
+		"UID:3.16.3-freeze@release-readiness\r\n",
+		"DTSTART;VALUE=DATE:20260313\r\nDTEND;VALUE=DATE:20260320\r\nSUMMARY:3.16.3 code freeze\r\n",
+		"DTSTART;VALUE=DATE:20260320\r\nDTEND;VALUE=DATE:20260321\r\nSUMMARY:3.16.3 due\r\n",
+		"DTSTART;VALUE=DATE:20260324\r\nDTEND;VALUE=DATE:20260325\r\nSUMMARY:3.16.3 release\r\n",
+		`DESCRIPTION:Release ticket: PROJQUAY-100 (https://redhat.atlassian.net/browse/PROJQUAY-100)\nAssignee: Jane Doe\, QE` + "\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("feed missing %q:\n%s", want, unfolded)
+		}
+	}
+	if strings.Contains(body, "3.16.2") || strings.Contains(body, "3.15.9") {
+		t.Errorf("feed includes released or archived versions:\n%s", body)
+	}
+	if n := strings.Count(body, "BEGIN:VEVENT"); n != 3 {
+		t.Errorf("events: got %d, want 3", n)
+	}
+}
+
+func TestReleasesCalendar(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetFreezeWindow(7 * 24 * time.Hour)
+	ctx := t.Context()
+
+	date := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	for _, rel := range []model.ReleaseVersion{
+		{Name: "3.16.3", DueDate: date(20), ReleaseDate: date(24)},
+		{Name: "3.17.0", ReleaseDate: date(10)},
+		{Name: "3.16.2", DueDate: date(5), Released: true},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/releases/calendar", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("calendar: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var events []model.CalendarEvent
+	if err := json.NewDecoder(w.Body).Decode(&events); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Release+" "+e.Kind+" "+e.Start.Format(time.DateOnly)+".."+e.End.Format(time.DateOnly))
+	}
+	want := []string{
+		"3.17.0 release 2026-03-10..2026-03-11",
+		"3.16.3 freeze 2026-03-13..2026-03-20",
+		"3.16.3 due 2026-03-20..2026-03-21",
+		"3.16.3 release 2026-03-24..2026-03-25",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("events:\n got %q\nwant %q", got, want)
+	}
+
+	// The API path serves the same feed as /feeds/releases.ics.
+	req = httptest.NewRequest("GET", "/api/v1/releases/calendar.ics", nil)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("calendar.ics: got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if n := strings.Count(w.Body.String(), "BEGIN:VEVENT"); n != 4 {
+		t.Errorf("calendar.ics events: got %d, want 4", n)
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/ctrf"
+	"github.com/quay/release-readiness/internal/junit"
+	"github.com/quay/release-readiness/internal/konflux"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// maxSnapshotBytes caps the size of a pushed Snapshot CR, including the
+// JUnit reports pushed with it.
+const maxSnapshotBytes = 16 << 20
+
+// SnapshotIngester stores pushed snapshots and the test results pushed with
+// them, by scenario; see s3.Syncer.IngestSnapshot.
+type SnapshotIngester interface {
+	IngestSnapshot(ctx context.Context, snap *model.Snapshot, reports map[string]*ctrf.Report) (bool, error)
+}
+
+// SnapshotReingester retries snapshots whose ingestion failed; see
+// s3.Syncer.Reingest.
+type SnapshotReingester interface {
+	Reingest(ctx context.Context, key string) (bool, error)
+}
+
+// SnapshotRefresher re-reads the test results of ingested snapshots; see
+// s3.Syncer.Refresh.
+type SnapshotRefresher interface {
+	Refresh(ctx context.Context, snap *model.SnapshotRecord) ([]string, error)
+}
+
+// handleIngestSnapshot stores a Konflux Snapshot CR pushed by a pipeline,
+// along with the JUnit reports of any test scenarios pushed with it.
+// Pushing a snapshot that is already stored is a no-op, so pipelines can
+// retry safely.
+func (s *Server) handleIngestSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.ingester == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("snapshot ingestion is not configured"))
+		return
+	}
+
+	var cr konflux.SnapshotPush
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSnapshotBytes)).Decode(&cr); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	switch {
+	case cr.Kind != "" && cr.Kind != "Snapshot":
+		writeError(w, http.StatusBadRequest, fmt.Errorf("kind is %q, want Snapshot", cr.Kind))
+		return
+	case cr.Metadata.Name == "":
+		writeError(w, http.StatusBadRequest, fmt.Errorf("metadata.name is required"))
+		return
+	case cr.Spec.Application == "":
+		writeError(w, http.StatusBadRequest, fmt.Errorf("spec.application is required"))
+		return
+	}
+
+	reports, err := pushedReports(cr.TestResults)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	snap := konflux.Convert(cr.Spec, cr.Metadata.Name)
+	created, err := s.ingester.IngestSnapshot(ctx, &snap, reports)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	record, err := s.db.GetSnapshotByName(ctx, snap.Snapshot)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", snap.Snapshot))
+		return
+	}
+	if !created {
+		writeJSON(w, http.StatusOK, snapshotMeta(record))
+		return
+	}
+	s.candidateCache.invalidate()
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "snapshot pushed", "snapshot", snap.Snapshot, "application", snap.Application)
+	writeJSON(w, http.StatusCreated, snapshotMeta(record))
+}
+
+// pushedReports parses the JUnit reports pushed with a snapshot into a
+// CTRF report per scenario.
+func pushedReports(results []konflux.TestResult) (map[string]*ctrf.Report, error) {
+	reports := make(map[string]*ctrf.Report)
+	for i, tr := range results {
+		if tr.Scenario == "" || strings.Contains(tr.Scenario, "/") {
+			return nil, fmt.Errorf("testResults[%d]: invalid scenario %q", i, tr.Scenario)
+		}
+		suites, err := junit.Parse([]byte(tr.JUnit))
+		if err != nil {
+			return nil, fmt.Errorf("testResults[%d]: decode junit report of %s: %w", i, tr.Scenario, err)
+		}
+		report, ok := reports[tr.Scenario]
+		if !ok {
+			report = &ctrf.Report{Results: ctrf.Results{Tool: ctrf.Tool{Name: "junit"}}}
+			reports[tr.Scenario] = report
+		}
+		suites.Append(report)
+	}
+	return reports, nil
+}
+
+// handleListIngestFailures returns the snapshots whose ingestion failed,
+// most recently failed first. ?dead=true lists only the dead ones, which
+// polls no longer retry.
+func (s *Server) handleListIngestFailures(w http.ResponseWriter, r *http.Request) {
+	failures, err := s.db.ListIngestFailures(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if r.URL.Query().Get("dead") == "true" {
+		failures = slices.DeleteFunc(failures, func(f model.IngestFailure) bool { return !f.Dead })
+	}
+	writeJSON(w, http.StatusOK, failures)
+}
+
+type reingestResult struct {
+	Snapshot string `json:"snapshot"`
+	// Ingested is false if the snapshot had been stored meanwhile.
+	Ingested bool `json:"ingested"`
+}
+
+// handleRetryIngestFailure re-ingests a failed snapshot now, dead or not.
+// If it fails again, it is recorded as a new failure with a fresh count of
+// attempts. It is an admin endpoint.
+func (s *Server) handleRetryIngestFailure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid ingest failure ID"))
+		return
+	}
+	f, err := s.db.GetIngestFailure(ctx, id)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("ingest failure %d", id))
+		return
+	}
+	reingester := s.reingesters[f.Source]
+	if reingester == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("source %q is not configured", f.Source))
+		return
+	}
+	ingested, err := reingester.Reingest(ctx, f.Key)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("re-ingest %s: %w", f.Snapshot, err))
+		return
+	}
+	if ingested {
+		s.candidateCache.invalidate()
+		s.overviewCache.invalidate()
+	}
+	s.logger.InfoContext(ctx, "snapshot re-ingested", "snapshot", f.Snapshot, "source", f.Source, "ingested", ingested)
+	writeJSON(w, http.StatusOK, reingestResult{Snapshot: f.Snapshot, Ingested: ingested})
+}
+
+type refreshResult struct {
+	Snapshot    string   `json:"snapshot"`
+	Updated     []string `json:"updated"` // scenarios added or replaced
+	TestsPassed bool     `json:"tests_passed"`
+}
+
+// handleRefreshSnapshot re-reads the test results uploaded for a snapshot
+// after it was ingested. Refreshing is idempotent: unchanged results are
+// kept, so pipelines can call it after every upload.
+func (s *Server) handleRefreshSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+	snap, err := s.db.GetSnapshotByName(ctx, name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	refresher := s.refreshers[snap.Source]
+	if refresher == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("source %q is not configured", snap.Source))
+		return
+	}
+	updated, err := refresher.Refresh(ctx, snap)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	if len(updated) > 0 {
+		if snap, err = s.db.GetSnapshotByName(ctx, name); err != nil {
+			writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+			return
+		}
+		s.candidateCache.invalidate()
+		s.overviewCache.invalidate()
+		s.logger.InfoContext(ctx, "snapshot refreshed", "snapshot", name, "scenarios", updated)
+	}
+	if updated == nil {
+		updated = []string{}
+	}
+	writeJSON(w, http.StatusOK, refreshResult{Snapshot: name, Updated: updated, TestsPassed: snap.TestsPassed})
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+	s3client "github.com/quay/release-readiness/internal/s3"
+)
+
+func TestIngestSnapshot(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	push := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/ingest/snapshot", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer w")
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	const cr = `{
+		"apiVersion": "appstudio.redhat.com/v1alpha1",
+		"kind": "Snapshot",
+		"metadata": {"name": "quay-v3-17-abc12", "namespace": "quay-tenant"},
+		"spec": {
+			"application": "quay-v3-17",
+			"components": [{
+				"name": "quay-server",
+				"containerImage": "quay.io/quay/quay@sha256:abc",
+				"source": {"git": {"url": "https://github.com/quay/quay", "revision": "abc123"}}
+			}]
+		}
+	}`
+
+	srv.SetAPITokens([]APIToken{{Name: "ci", Token: "w", Role: RoleReporter}})
+	if w := push(cr); w.Code != http.StatusNotImplemented {
+		t.Errorf("without ingester: got %d, want 501", w.Code)
+	}
+
+	srv.SetSnapshotIngester(s3client.NewSyncer(nil, database, slog.Default()))
+
+	w := push(cr)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("push: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var got model.SnapshotRecord
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "quay-v3-17-abc12" || got.Application != "quay-v3-17" || got.HasTests {
+		t.Errorf("snapshot: got %+v", got)
+	}
+	stored, err := database.GetSnapshotByName(ctx, "quay-v3-17-abc12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Components) != 1 || stored.Components[0].GitSHA != "abc123" {
+		t.Errorf("components: got %+v", stored.Components)
+	}
+
+	if w := push(cr); w.Code != http.StatusOK {
+		t.Errorf("repeat push: got %d, want 200", w.Code)
+	}
+
+	// Test results pushed with a snapshot are stored as its test suites;
+	// the reports of a scenario are merged.
+	w = push(`{
+		"kind": "Snapshot",
+		"metadata": {"name": "quay-v3-17-def34"},
+		"spec": {"application": "quay-v3-17"},
+		"testResults": [
+			{"scenario": "api-tests", "junit": "<testsuite name=\"api\" tests=\"1\"><testcase name=\"test_a\"/></testsuite>"},
+			{"scenario": "api-tests", "junit": "<testsuite name=\"api\" tests=\"1\"><testcase name=\"test_b\"><failure message=\"boom\"/></testcase></testsuite>"}
+		]
+	}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("push with test results: got %d, body: %s", w.Code, w.Body.String())
+	}
+	stored, err = database.GetSnapshotByName(ctx, "quay-v3-17-def34")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.TestsPassed || len(stored.TestSuites) != 1 || stored.TestSuites[0].Name != "api-tests" ||
+		stored.TestSuites[0].Status != "failed" || stored.TestSuites[0].Tests != 2 {
+		t.Errorf("pushed test results: passed %v, suites %+v", stored.TestsPassed, stored.TestSuites)
+	}
+
+	for name, body := range map[string]string{
+		"not json":       `{`,
+		"wrong kind":     `{"kind":"Pod","metadata":{"name":"x"},"spec":{"application":"a"}}`,
+		"no name":        `{"kind":"Snapshot","spec":{"application":"a"}}`,
+		"no application": `{"kind":"Snapshot","metadata":{"name":"x"}}`,
+		"no scenario":    `{"kind":"Snapshot","metadata":{"name":"x"},"spec":{"application":"a"},"testResults":[{"junit":"<testsuite/>"}]}`,
+		"bad junit":      `{"kind":"Snapshot","metadata":{"name":"x"},"spec":{"application":"a"},"testResults":[{"scenario":"s","junit":"<nope"}]}`,
+	} {
+		if w := push(body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want 400", name, w.Code)
+		}
+	}
+}
+
+func TestIngestFailures(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	list := func(query string) []model.IngestFailure {
+		t.Helper()
+		w := do("GET", "/api/v1/ingest/failures"+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("list: got %d, body: %s", w.Code, w.Body.String())
+		}
+		var failures []model.IngestFailure
+		if err := json.NewDecoder(w.Body).Decode(&failures); err != nil {
+			t.Fatal(err)
+		}
+		return failures
+	}
+
+	const key = "quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json"
+	dead := &model.IngestFailure{Key: key, Application: "quay-v3-17", Snapshot: "quay-v3-17-snap-1", LastError: "database is locked"}
+	if err := database.RecordIngestFailure(ctx, dead, 1); err != nil {
+		t.Fatal(err)
+	}
+	retrying := &model.IngestFailure{Source: "omr", Key: "omr-v2-1/snapshots/omr-v2-1-snap-1/snapshot.json", Application: "omr-v2-1", Snapshot: "omr-v2-1-snap-1", LastError: "timeout"}
+	if err := database.RecordIngestFailure(ctx, retrying, 5); err != nil {
+		t.Fatal(err)
+	}
+	if f := list(""); len(f) != 2 {
+		t.Errorf("failures: got %+v", f)
+	}
+	if f := list("?dead=true"); len(f) != 1 || f[0].ID != dead.ID || !f[0].Dead || f[0].LastError != "database is locked" {
+		t.Errorf("dead failures: got %+v", f)
+	}
+
+	store := s3client.NewMemoryStore()
+	if err := store.PutJSON(key, map[string]any{"application": "quay-v3-17"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetSnapshotReingester("", s3client.NewSyncer(store, database, slog.Default()))
+
+	retry := func(id int64) string { return "/api/v1/ingest/failures/" + strconv.FormatInt(id, 10) + "/retry" }
+	for _, tc := range []struct {
+		name, path, token string
+		want              int
+	}{
+		{"no token", retry(dead.ID), "", http.StatusUnauthorized},
+		{"bad id", "/api/v1/ingest/failures/x/retry", "secret", http.StatusBadRequest},
+		{"unknown id", retry(999), "secret", http.StatusNotFound},
+		{"unconfigured source", retry(retrying.ID), "secret", http.StatusServiceUnavailable},
+	} {
+		if w := do("POST", tc.path, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	w := do("POST", retry(dead.ID), "secret")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"ingested":true`) {
+		t.Fatalf("retry: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if _, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1"); err != nil {
+		t.Errorf("re-ingested snapshot: %v", err)
+	}
+	if f := list(""); len(f) != 1 || f[0].ID != retrying.ID {
+		t.Errorf("failures after retry: got %+v", f)
+	}
+}
+
+func TestRefreshSnapshot(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAPITokens([]APIToken{{Name: "ci", Token: "w", Role: RoleReporter}})
+	ctx := t.Context()
+
+	refresh := func(name, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/snapshots/"+name+"/refresh", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	store := s3client.NewMemoryStore()
+	if err := store.PutJSON("quay-v3-17/snapshots/quay-v3-17-snap-1/snapshot.json", map[string]any{"application": "quay-v3-17"}); err != nil {
+		t.Fatal(err)
+	}
+	syncer := s3client.NewSyncer(store, database, slog.Default())
+	syncer.SyncOnce(ctx)
+	srv.SetSnapshotRefresher("", syncer)
+	if err := database.SaveSnapshot(ctx, &model.SnapshotRecord{Source: "omr", Application: "omr-v2-1", Name: "omr-v2-1-snap-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name, snapshot, token string
+		want                  int
+	}{
+		{"no token", "quay-v3-17-snap-1", "", http.StatusUnauthorized},
+		{"unknown snapshot", "quay-v9-9-snap-1", "w", http.StatusNotFound},
+		{"unconfigured source", "omr-v2-1-snap-1", "w", http.StatusServiceUnavailable},
+	} {
+		if w := refresh(tc.snapshot, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	if w := refresh("quay-v3-17-snap-1", "w"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"updated":[]`) {
+		t.Errorf("refresh without results: got %d, body: %s", w.Code, w.Body.String())
+	}
+	store.Put("quay-v3-17/snapshots/quay-v3-17-snap-1/junit/ui-tests/results.xml", []byte(`<testsuite name="cypress"><testcase name="logs in"/></testsuite>`))
+	w := refresh("quay-v3-17-snap-1", "w")
+	var got refreshResult
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || len(got.Updated) != 1 || got.Updated[0] != "ui-tests" || !got.TestsPassed {
+		t.Errorf("refresh: got %d, %+v", w.Code, got)
+	}
+}
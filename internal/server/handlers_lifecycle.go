@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleGetSnapshotLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+	snap, err := s.db.GetSnapshotByName(ctx, name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	s.writeSnapshotLifecycle(w, r, snap.ID, snap.Name, snap.Lifecycle)
+}
+
+type lifecycleRequest struct {
+	State  string `json:"state"` // one of the model.Lifecycle states
+	Reason string `json:"reason"`
+}
+
+// handleTransitionSnapshot moves a snapshot to another lifecycle state,
+// recording who moved it and why. Rejecting a snapshot requires a reason,
+// and demotes it as a release candidate. It is a release-manager endpoint.
+func (s *Server) handleTransitionSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+
+	var req lifecycleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	t := model.LifecycleTransition{
+		To:     strings.TrimSpace(req.State),
+		Reason: strings.TrimSpace(req.Reason),
+	}
+	if _, ok := model.LifecycleNext[t.To]; !ok {
+		states := slices.Sorted(maps.Keys(model.LifecycleNext))
+		writeError(w, http.StatusBadRequest, fmt.Errorf("state must be one of %s", strings.Join(states, ", ")))
+		return
+	}
+	if t.To == model.LifecycleRejected && t.Reason == "" {
+		writeError(w, http.StatusBadRequest, errors.New("reason is required to reject a snapshot"))
+		return
+	}
+
+	snap, err := s.db.GetSnapshotByName(ctx, name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	t.From = snap.Lifecycle
+	if next := model.LifecycleNext[t.From]; !slices.Contains(next, t.To) {
+		msg := fmt.Sprintf("snapshot %q is %s", name, t.From)
+		if len(next) > 0 {
+			msg += "; it can only become " + strings.Join(next, " or ")
+		}
+		writeError(w, http.StatusConflict, errors.New(msg))
+		return
+	}
+
+	t.ChangedBy, _, _ = s.principal(r)
+	if err := s.db.TransitionSnapshot(ctx, snap.ID, &t); err != nil {
+		if errors.Is(err, db.ErrConflict) {
+			writeError(w, http.StatusConflict, fmt.Errorf("snapshot %q changed state meanwhile; retry", name))
+			return
+		}
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", name))
+		return
+	}
+	s.candidateCache.invalidate()
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "snapshot lifecycle changed", "snapshot", name, "from", t.From, "to", t.To,
+		"by", t.ChangedBy, "reason", t.Reason)
+	s.writeSnapshotLifecycle(w, r, snap.ID, snap.Name, t.To)
+}
+
+// writeSnapshotLifecycle responds with the lifecycle state of a snapshot
+// and its transitions.
+func (s *Server) writeSnapshotLifecycle(w http.ResponseWriter, r *http.Request, id int64, name, state string) {
+	transitions, err := s.db.ListLifecycleTransitions(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if transitions == nil {
+		transitions = []model.LifecycleTransition{}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, model.SnapshotLifecycle{Snapshot: name, State: state, Transitions: transitions})
+}
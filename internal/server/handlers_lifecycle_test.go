@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestSnapshotLifecycle(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	const path = "/api/v1/snapshots/quay-v3-16-snap-1/lifecycle"
+	move := func(body string) model.SnapshotLifecycle {
+		t.Helper()
+		w := do("POST", path, body, "secret")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d: %s", body, w.Code, w.Body.String())
+		}
+		var lc model.SnapshotLifecycle
+		if err := json.NewDecoder(w.Body).Decode(&lc); err != nil {
+			t.Fatal(err)
+		}
+		return lc
+	}
+
+	w := do("GET", path, "", "")
+	var lc model.SnapshotLifecycle
+	if err := json.NewDecoder(w.Body).Decode(&lc); err != nil {
+		t.Fatal(err)
+	}
+	if lc.State != model.LifecycleNew || len(lc.Transitions) != 0 {
+		t.Errorf("new snapshot: got %+v", lc)
+	}
+
+	for _, tc := range []struct {
+		name, path, body, token string
+		want                    int
+	}{
+		{"no token", path, `{"state":"candidate"}`, "", http.StatusUnauthorized},
+		{"unknown state", path, `{"state":"shipped"}`, "secret", http.StatusBadRequest},
+		{"reject without reason", path, `{"state":"rejected"}`, "secret", http.StatusBadRequest},
+		{"skip candidate", path, `{"state":"accepted"}`, "secret", http.StatusConflict},
+		{"unknown snapshot", "/api/v1/snapshots/missing/lifecycle", `{"state":"candidate"}`, "secret", http.StatusNotFound},
+	} {
+		if w := do("POST", tc.path, tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	move(`{"state":"candidate"}`)
+	lc = move(`{"state":"rejected","reason":" mirror tests flaky "}`)
+	if lc.State != model.LifecycleRejected || len(lc.Transitions) != 2 {
+		t.Fatalf("after reject: got %+v", lc)
+	}
+	if got := lc.Transitions[1]; got.From != model.LifecycleCandidate || got.Reason != "mirror tests flaky" || got.ChangedBy != "admin-token" || got.ChangedAt.IsZero() {
+		t.Errorf("rejection: got %+v", got)
+	}
+
+	move(`{"state":"candidate"}`)
+	move(`{"state":"accepted"}`)
+	lc = move(`{"state":"released"}`)
+	if lc.State != model.LifecycleReleased || len(lc.Transitions) != 5 {
+		t.Errorf("after release: got %+v", lc)
+	}
+	if w := do("POST", path, `{"state":"rejected","reason":"too late"}`, "secret"); w.Code != http.StatusConflict {
+		t.Errorf("leave released: got %d, want 409", w.Code)
+	}
+
+	snap, err := database.GetSnapshotByName(ctx, "quay-v3-16-snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Lifecycle != model.LifecycleReleased || len(snap.LifecycleTransitions) != 5 {
+		t.Errorf("snapshot record: got %q with %d transitions", snap.Lifecycle, len(snap.LifecycleTransitions))
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// handleGetAppMapping lists the rules mapping fixVersions to S3
+// applications, in the order they are tried.
+func (s *Server) handleGetAppMapping(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.appMapping.Rules())
+}
+
+type appMappingTestRequest struct {
+	FixVersion  string `json:"fix_version"`
+	Application string `json:"application"`
+}
+
+type appMappingTestResponse struct {
+	FixVersion  string `json:"fix_version,omitempty"`
+	Application string `json:"application,omitempty"`
+	// Rule is the index of the rule that mapped FixVersion; it is unset if
+	// none did, or if an application is configured with FixVersion.
+	Rule *int `json:"rule,omitempty"`
+	// Configured is set if FixVersion is mapped by an application's
+	// configured fixVersions rather than by the rules.
+	Configured bool `json:"configured,omitempty"`
+	// FixVersions are the known releases, and the fixVersions configured
+	// for Application, that map to Application. It is omitted if none do.
+	FixVersions []string `json:"fix_versions,omitempty"`
+}
+
+// handleTestAppMapping maps a fixVersion to its application, or an
+// application back to the fixVersions of the known releases that map to
+// it, the way the JIRA sync would. It is an admin endpoint.
+func (s *Server) handleTestAppMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req appMappingTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if (req.FixVersion == "") == (req.Application == "") {
+		writeError(w, http.StatusBadRequest, errors.New("set one of fix_version and application"))
+		return
+	}
+	configs, err := s.db.ListApplicationConfigs(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	configured := make(map[string]string) // fixVersion → application
+	for _, c := range configs {
+		for _, fv := range c.FixVersions {
+			configured[fv] = c.Name
+		}
+	}
+
+	if req.FixVersion != "" {
+		resp := appMappingTestResponse{FixVersion: req.FixVersion}
+		if app, ok := configured[req.FixVersion]; ok {
+			resp.Application, resp.Configured = app, true
+		} else if app, rule := s.appMapping.Match(req.FixVersion); rule >= 0 {
+			resp.Application, resp.Rule = app, &rule
+		}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var fixVersions []string
+	for _, rel := range releases {
+		if _, ok := configured[rel.Name]; !ok {
+			fixVersions = append(fixVersions, rel.Name)
+		}
+	}
+	resp := appMappingTestResponse{
+		Application: req.Application,
+		FixVersions: s.appMapping.FixVersions(req.Application, fixVersions),
+	}
+	for fv, app := range configured {
+		if app == req.Application {
+			resp.FixVersions = append(resp.FixVersions, fv)
+		}
+	}
+	slices.Sort(resp.FixVersions)
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/jira"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestAppMapping(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	for _, name := range []string{"quay-v3.16.2", "quay-v3.16.3", "quay-v3.17.0"} {
+		if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.SaveApplicationConfig(ctx, &model.ApplicationConfig{Name: "quay-next", FixVersions: []string{"quay-v3.17.0"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	test := func(body string) appMappingTestResponse {
+		t.Helper()
+		w := do("POST", "/api/v1/admin/app-mapping/test", body, "secret")
+		if w.Code != http.StatusOK {
+			t.Fatalf("test %s: got %d, body: %s", body, w.Code, w.Body.String())
+		}
+		var resp appMappingTestResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", `{"fix_version":"3.16.3"}`, "", http.StatusUnauthorized},
+		{"neither", `{}`, "secret", http.StatusBadRequest},
+		{"both", `{"fix_version":"3.16.3","application":"quay-v3-16"}`, "secret", http.StatusBadRequest},
+	} {
+		if w := do("POST", "/api/v1/admin/app-mapping/test", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	if r := test(`{"fix_version":"3.16.3"}`); r.Application != "quay-v3-16" || r.Rule == nil || *r.Rule != 1 {
+		t.Errorf("plain version: got %+v", r)
+	}
+	if r := test(`{"fix_version":"quay-v3.17.0"}`); r.Application != "quay-next" || !r.Configured || r.Rule != nil {
+		t.Errorf("configured version: got %+v", r)
+	}
+	if r := test(`{"fix_version":"latest"}`); r.Application != "" || r.Rule != nil {
+		t.Errorf("unmapped version: got %+v", r)
+	}
+	if r := test(`{"application":"quay-v3-16"}`); !slices.Equal(r.FixVersions, []string{"quay-v3.16.2", "quay-v3.16.3"}) {
+		t.Errorf("reverse: got %+v", r)
+	}
+	if r := test(`{"application":"quay-v3-17"}`); len(r.FixVersions) != 0 {
+		t.Errorf("reverse of overridden application: got %+v", r)
+	}
+
+	m, err := jira.NewMapping([]jira.MappingRule{{FixVersion: `^quay-v(\d+)\.`, Application: "quay-$1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetAppMapping(m)
+	w := do("GET", "/api/v1/admin/app-mapping", "", "secret")
+	var rules []jira.MappingRule
+	if err := json.NewDecoder(w.Body).Decode(&rules); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Application != "quay-$1" {
+		t.Errorf("rules: got %+v", rules)
+	}
+	if r := test(`{"application":"quay-3"}`); !slices.Equal(r.FixVersions, []string{"quay-v3.16.2", "quay-v3.16.3"}) {
+		t.Errorf("reverse with custom rules: got %+v", r)
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/report"
+)
+
+// handleGetReleaseMatrix serves the readiness matrix of a release's
+// selected candidate snapshot as JSON, or as a self-contained HTML page
+// with format=html.
+func (s *Server) handleGetReleaseMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	format := cmp.Or(r.URL.Query().Get("format"), "json")
+	if format != "json" && format != "html" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid format %q: must be json or html", format))
+		return
+	}
+	version := r.PathValue("version")
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	if release.S3Application == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no S3 application mapped for release %q", version))
+		return
+	}
+	selected, err := s.selectedCandidates(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	candidate := selected[release.Name]
+	if candidate == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no snapshots found for application %s", release.S3Application))
+		return
+	}
+	snap, err := s.db.GetSnapshotByName(ctx, candidate.Name)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("snapshot %q", candidate.Name))
+		return
+	}
+	reqs, err := s.db.ListScenarioRequirements(ctx, snap.Application)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	matrix := readinessMatrix(release.Name, snap, reqs)
+	if format == "json" {
+		writeJSON(w, http.StatusOK, matrix)
+		return
+	}
+	var buf bytes.Buffer
+	if err := report.MatrixHTML(&buf, matrix); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// readinessMatrix breaks snap down by component and test scenario. A test
+// case counts towards a component when its suite is named after the
+// component, ignoring case, and towards every component when its suite
+// names none. Scenarios stored without their test cases, or with only
+// some of them, show the scenario's own result in every cell.
+func readinessMatrix(release string, snap *model.SnapshotRecord, reqs []model.ScenarioRequirement) *model.ReadinessMatrix {
+	informational := make(map[string]bool)
+	for _, req := range reqs {
+		informational[req.Scenario] = !req.Required
+	}
+	components := make(map[string]bool, len(snap.Components))
+	for _, c := range snap.Components {
+		components[strings.ToLower(c.Component)] = true
+	}
+
+	m := &model.ReadinessMatrix{
+		Release:   release,
+		Snapshot:  snap.Name,
+		Scenarios: make([]model.MatrixScenario, len(snap.TestSuites)),
+		Rows:      make([]model.MatrixRow, len(snap.Components)),
+	}
+	for i, suite := range snap.TestSuites {
+		m.Scenarios[i] = model.MatrixScenario{Name: suite.Name, Status: suite.Status, Required: !informational[suite.Name]}
+	}
+	for i, c := range snap.Components {
+		row := model.MatrixRow{Component: c.Component, Cells: make([]model.MatrixCell, len(snap.TestSuites))}
+		for j, suite := range snap.TestSuites {
+			row.Cells[j] = matrixCell(suite, strings.ToLower(c.Component), components)
+		}
+		m.Rows[i] = row
+	}
+	return m
+}
+
+// matrixCell returns the result of suite for component, given the lower
+// case names of all the snapshot's components.
+func matrixCell(suite model.TestSuite, component string, components map[string]bool) model.MatrixCell {
+	if len(suite.TestCases) == 0 || suite.Truncated {
+		return model.MatrixCell{
+			Status:  suite.Status,
+			Tests:   suite.Tests,
+			Passed:  suite.Passed,
+			Failed:  suite.Failed,
+			Skipped: suite.Skipped,
+		}
+	}
+	var cell model.MatrixCell
+	var pending int
+	for _, tc := range suite.TestCases {
+		owner := strings.ToLower(tc.Suite)
+		if owner == component {
+			cell.Scoped = true
+		} else if components[owner] {
+			continue
+		}
+		cell.Tests++
+		switch tc.Status {
+		case "passed":
+			cell.Passed++
+		case "failed":
+			cell.Failed++
+		case "skipped":
+			cell.Skipped++
+		case "pending":
+			pending++
+		}
+	}
+	switch {
+	case cell.Tests == 0:
+		cell.Status = model.MatrixUntested
+	case cell.Failed > 0:
+		cell.Status = model.MatrixFailed
+	case pending > 0 || suite.Status == model.MatrixPending:
+		cell.Status = model.MatrixPending
+	case cell.Passed > 0:
+		cell.Status = model.MatrixPassed
+	default:
+		cell.Status = model.MatrixSkipped
+	}
+	return cell
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReleaseMatrix(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3", S3Application: "quay-v3-16"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "omr-v2.0.10"}); err != nil {
+		t.Fatal(err)
+	}
+	err := database.SaveSnapshot(ctx, &model.SnapshotRecord{
+		Application: "quay-v3-16",
+		Name:        "quay-v3-16-snap-1",
+		CreatedAt:   time.Now(),
+		Components:  []model.ComponentRecord{{Component: "clair"}, {Component: "quay"}},
+		TestSuites: []model.TestSuite{{
+			Name: "api-tests", Status: "failed", Tests: 3, Passed: 2, Failed: 1,
+			TestCases: []model.TestCase{
+				{Name: "login", Status: "passed"},
+				{Name: "scan", Status: "passed", Suite: "Clair"},
+				{Name: "push", Status: "failed", Suite: "quay"},
+			},
+		}, {
+			Name: "perf-tests", Status: "passed", Tests: 1, Passed: 1,
+			TestCases: []model.TestCase{{Name: "pull", Status: "passed", Suite: "quay"}},
+		}, {
+			Name: "upgrade-tests", Status: "pending",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetScenarioRequirement(ctx, &model.ScenarioRequirement{Application: "quay-v3-16", Scenario: "perf-tests"}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	w := get("/api/v1/releases/quay-v3.16.3/matrix")
+	if w.Code != http.StatusOK {
+		t.Fatalf("matrix: got %d: %s", w.Code, w.Body.String())
+	}
+	var m model.ReadinessMatrix
+	if err := json.NewDecoder(w.Body).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	want := []model.MatrixScenario{
+		{Name: "api-tests", Status: "failed", Required: true},
+		{Name: "perf-tests", Status: "passed"},
+		{Name: "upgrade-tests", Status: "pending", Required: true},
+	}
+	if m.Snapshot != "quay-v3-16-snap-1" || !slices.Equal(m.Scenarios, want) || len(m.Rows) != 2 {
+		t.Fatalf("matrix: got %+v", m)
+	}
+	cells := map[string][]model.MatrixCell{}
+	for _, row := range m.Rows {
+		cells[row.Component] = row.Cells
+	}
+	for _, tc := range []struct {
+		component string
+		scenario  int
+		want      model.MatrixCell
+	}{
+		// The unattributed login case counts for both components.
+		{"clair", 0, model.MatrixCell{Status: "passed", Tests: 2, Passed: 2, Scoped: true}},
+		{"quay", 0, model.MatrixCell{Status: "failed", Tests: 2, Passed: 1, Failed: 1, Scoped: true}},
+		{"clair", 1, model.MatrixCell{Status: "untested"}},
+		{"quay", 1, model.MatrixCell{Status: "passed", Tests: 1, Passed: 1, Scoped: true}},
+		{"clair", 2, model.MatrixCell{Status: "pending"}},
+	} {
+		if got := cells[tc.component][tc.scenario]; got != tc.want {
+			t.Errorf("%s × %s: got %+v, want %+v", tc.component, want[tc.scenario].Name, got, tc.want)
+		}
+	}
+
+	w = get("/api/v1/releases/quay-v3.16.3/matrix?format=html")
+	if ct := w.Header().Get("Content-Type"); w.Code != http.StatusOK || !strings.HasPrefix(ct, "text/html") || !strings.Contains(w.Body.String(), "quay-v3.16.3 readiness matrix") {
+		t.Errorf("html: got %d, %s", w.Code, ct)
+	}
+
+	for path, code := range map[string]int{
+		"/api/v1/releases/quay-v3.16.3/matrix?format=pdf": http.StatusBadRequest,
+		"/api/v1/releases/quay-v9.9.9/matrix":             http.StatusNotFound,
+		"/api/v1/releases/omr-v2.0.10/matrix":             http.StatusNotFound,
+	} {
+		if w := get(path); w.Code != code {
+			t.Errorf("%s: got %d, want %d", path, w.Code, code)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleMetrics serves gauges in the Prometheus text exposition format, for
+// alerting on stale data: when each background sync last succeeded, how
+// old each application's latest snapshot is, and when unreleased releases
+// are due, so that the two can be joined on application.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	apps, err := s.db.LatestSnapshotPerApplication(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	now := time.Now()
+
+	var m metricsWriter
+	m.family("release_readiness_last_successful_sync_timestamp",
+		"Unix time the background job last finished without error; 0 if it has not yet.")
+	for _, t := range s.syncers {
+		st := t.Status()
+		var ts float64
+		if st.LastSuccessAt != nil {
+			ts = unixSeconds(*st.LastSuccessAt)
+		}
+		m.sample("release_readiness_last_successful_sync_timestamp", ts, "component", st.Name)
+	}
+
+	m.family("release_readiness_application_last_snapshot_age_seconds",
+		"Seconds since the latest snapshot of the application was created.")
+	for _, app := range apps {
+		if app.LatestSnapshot == nil {
+			continue
+		}
+		age := now.Sub(app.LatestSnapshot.CreatedAt).Seconds()
+		m.sample("release_readiness_application_last_snapshot_age_seconds", age, "application", app.Application)
+	}
+
+	m.family("release_readiness_release_due_timestamp",
+		"Unix time an unreleased release is due, labeled with its S3 application.")
+	slices.SortFunc(releases, func(a, b model.ReleaseVersion) int { return strings.Compare(a.Name, b.Name) })
+	for _, rel := range releases {
+		if rel.Released || rel.Archived || rel.Hidden || rel.DueDate == nil {
+			continue
+		}
+		m.sample("release_readiness_release_due_timestamp", unixSeconds(*rel.DueDate),
+			"release", rel.Name, "application", rel.S3Application)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write([]byte(m.String()))
+}
+
+// metricsWriter builds gauges in the Prometheus text exposition format.
+type metricsWriter struct {
+	strings.Builder
+}
+
+// family writes the HELP and TYPE lines of the gauge called name.
+func (m *metricsWriter) family(name, help string) {
+	fmt.Fprintf(m, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// sample writes a sample of the gauge called name, labeled with pairs of
+// label names and values.
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	m.WriteString(name)
+	for i := 0; i+1 < len(labels); i += 2 {
+		sep := ","
+		if i == 0 {
+			sep = "{"
+		}
+		fmt.Fprintf(m, "%s%s=\"%s\"", sep, labels[i], labelEscaper.Replace(labels[i+1]))
+	}
+	if len(labels) > 0 {
+		m.WriteByte('}')
+	}
+	m.WriteByte(' ')
+	m.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	m.WriteByte('\n')
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// unixSeconds returns t as fractional seconds since the Unix epoch.
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
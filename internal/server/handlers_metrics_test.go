@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/runstatus"
+)
+
+func TestMetrics(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	s3Status := runstatus.New("s3")
+	s3Status.Start().Finish()
+	srv.SetSyncers(s3Status, runstatus.New("jira"))
+
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-17", "quay-v3-17-snap-1", true, time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	for _, rel := range []model.ReleaseVersion{
+		{Name: "quay-v3.17.0", S3Application: "quay-v3-17", DueDate: &due},
+		{Name: "quay-v3.16.2", S3Application: "quay-v3-16", DueDate: &due, Released: true},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("content type: got %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE release_readiness_last_successful_sync_timestamp gauge\n",
+		`release_readiness_last_successful_sync_timestamp{component="jira"} 0` + "\n",
+		`release_readiness_application_last_snapshot_age_seconds{application="quay-v3-17"} 7`,
+		`release_readiness_release_due_timestamp{release="quay-v3.17.0",application="quay-v3-17"} 1773964800` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q:\n%s", want, body)
+		}
+	}
+	if !strings.Contains(body, `release_readiness_last_successful_sync_timestamp{component="s3"} 1`) {
+		t.Errorf("s3 sync timestamp missing:\n%s", body)
+	}
+	if strings.Contains(body, "quay-v3.16.2") {
+		t.Errorf("released release exported:\n%s", body)
+	}
+}
+
+func TestMetricsLabelEscaping(t *testing.T) {
+	var m metricsWriter
+	m.sample("g", 1.5, "a", `x"y\z`+"\n")
+	if got, want := m.String(), `g{a="x\"y\\z\n"} 1.5`+"\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
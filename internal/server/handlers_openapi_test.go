@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type openAPIDoc struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func TestOpenAPICoversRoutes(t *testing.T) {
+	src, err := os.ReadFile("routes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc openAPIDoc
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi version: got %q", doc.OpenAPI)
+	}
+
+	routes := regexp.MustCompile(`"(GET|PUT|POST|PATCH|DELETE) (/api/v1/[^"]*)"`).FindAllStringSubmatch(string(src), -1)
+	if len(routes) == 0 {
+		t.Fatal("no /api/v1 routes found in routes.go")
+	}
+	registered := make(map[string]bool)
+	for _, m := range routes {
+		method, path := strings.ToLower(m[1]), m[2]
+		registered[method+" "+path] = true
+		if _, ok := doc.Paths[path][method]; !ok {
+			t.Errorf("%s %s is not in openapi.json", m[1], path)
+		}
+	}
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			if !registered[method+" "+path] {
+				t.Errorf("openapi.json documents %s %s, which is not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestOpenAPIEndpoints(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("spec: got %d", w.Code)
+	}
+	var doc openAPIDoc
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	req = httptest.NewRequest("GET", "/api/docs", nil)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/api/v1/openapi.json") {
+		t.Errorf("docs: got %d, body: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func (s *Server) handleListReadinessOverrides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	overrides, err := s.db.ListReadinessOverrides(ctx, version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, overrides)
+}
+
+type overrideRequest struct {
+	Rule      string    `json:"rule"` // one of model.ReadinessRules
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// handleCreateReadinessOverride waives a readiness rule of an unreleased
+// release until the override expires. It is a release-manager endpoint.
+func (s *Server) handleCreateReadinessOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	override := model.ReadinessOverride{
+		Release:   version,
+		Rule:      strings.TrimSpace(req.Rule),
+		Reason:    strings.TrimSpace(req.Reason),
+		ExpiresAt: req.ExpiresAt.UTC().Truncate(time.Second),
+	}
+	if !slices.Contains(model.ReadinessRules, override.Rule) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("rule must be one of %s", strings.Join(model.ReadinessRules, ", ")))
+		return
+	}
+	if override.Reason == "" {
+		writeError(w, http.StatusBadRequest, errors.New("reason is required"))
+		return
+	}
+	if !override.ExpiresAt.After(time.Now()) {
+		writeError(w, http.StatusBadRequest, errors.New("expires_at must be in the future"))
+		return
+	}
+
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	if release.Released {
+		writeError(w, http.StatusConflict, fmt.Errorf("release %q has already been released", version))
+		return
+	}
+
+	override.CreatedBy, _, _ = s.principal(r)
+	if err := s.db.CreateReadinessOverride(ctx, &override); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "readiness rule waived", "release", version, "rule", override.Rule,
+		"by", override.CreatedBy, "expires_at", override.ExpiresAt)
+	writeJSON(w, http.StatusCreated, override)
+}
+
+// handleDeleteReadinessOverride revokes an override before it expires. It
+// is a release-manager endpoint.
+func (s *Server) handleDeleteReadinessOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid override ID"))
+		return
+	}
+	if err := s.db.DeleteReadinessOverride(ctx, version, id); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("override %d of release %q", id, version))
+		return
+	}
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "readiness override revoked", "release", version, "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// waiver returns the override among overrides that waives rule, if any.
+func waiver(overrides []model.ReadinessOverride, rule string) (model.ReadinessOverride, bool) {
+	i := slices.IndexFunc(overrides, func(o model.ReadinessOverride) bool { return o.Rule == rule })
+	if i < 0 {
+		return model.ReadinessOverride{}, false
+	}
+	return overrides[i], true
+}
+
+// waive records that o waived a rule the release fails. A release whose
+// only failing rules are waived is green, with waivers.
+func waive(readiness *model.ReadinessResponse, o model.ReadinessOverride) {
+	if slices.ContainsFunc(readiness.Waivers, func(w model.ReadinessOverride) bool { return w.ID == o.ID }) {
+		return
+	}
+	readiness.Waivers = append(readiness.Waivers, o)
+	if readiness.Rule == "" {
+		readiness.Message = "All checks passing (with waivers)"
+	}
+}
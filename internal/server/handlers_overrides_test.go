@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestReadinessWaivers(t *testing.T) {
+	release := &model.ReleaseVersion{Name: "3.16.3"}
+	snap := &model.SnapshotRecord{HasTests: true, TestsPassed: false}
+	summary := &model.IssueSummary{Total: 2, Open: 1}
+	tests := model.ReadinessOverride{ID: 1, Rule: model.RuleTestsFailing, Reason: "flaky"}
+	issues := model.ReadinessOverride{ID: 2, Rule: model.RuleOpenIssues, Reason: "docs only"}
+
+	for _, tt := range []struct {
+		name      string
+		overrides []model.ReadinessOverride
+		signal    string
+		rule      string
+		waivers   int
+	}{
+		{"none", nil, "red", model.RuleTestsFailing, 0},
+		{"tests waived", []model.ReadinessOverride{tests}, "yellow", model.RuleOpenIssues, 1},
+		{"issues waived", []model.ReadinessOverride{issues}, "yellow", model.RuleTestsFailing, 1},
+		{"both waived", []model.ReadinessOverride{tests, issues}, "green", "", 2},
+	} {
+		got := readinessPolicy{}.computeReadiness(release, summary, snap, tt.overrides)
+		if got.Signal != tt.signal || got.Rule != tt.rule || len(got.Waivers) != tt.waivers {
+			t.Errorf("%s: got %+v, want %s from %q with %d waivers", tt.name, got, tt.signal, tt.rule, tt.waivers)
+		}
+	}
+	if got := (readinessPolicy{}).computeReadiness(release, summary, snap, []model.ReadinessOverride{tests, issues}); got.Message != "All checks passing (with waivers)" {
+		t.Errorf("both waived: got message %q", got.Message)
+	}
+}
+
+func TestReadinessOverrides(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	due := time.Now().Add(48 * time.Hour)
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3", DueDate: &due}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	readiness := func() model.ReadinessResponse {
+		t.Helper()
+		w := do("GET", "/api/v1/releases/quay-v3.16.3/readiness", "", "")
+		var resp model.ReadinessResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	expires := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	body := func(rule, reason, expiresAt string) string {
+		return fmt.Sprintf(`{"rule":%q,"reason":%q,"expires_at":%q}`, rule, reason, expiresAt)
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", body("due_soon", "agreed slip", expires), "", http.StatusUnauthorized},
+		{"unknown rule", body("bogus", "agreed slip", expires), "secret", http.StatusBadRequest},
+		{"missing reason", body("due_soon", " ", expires), "secret", http.StatusBadRequest},
+		{"expired", body("due_soon", "agreed slip", time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)), "secret", http.StatusBadRequest},
+	} {
+		if w := do("POST", "/api/v1/releases/quay-v3.16.3/overrides", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+	if w := do("POST", "/api/v1/releases/quay-v9.9.9/overrides", body("due_soon", "agreed slip", expires), "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown release: got %d", w.Code)
+	}
+
+	if r := readiness(); r.Signal != "yellow" || r.Rule != model.RuleDueSoon {
+		t.Fatalf("readiness before override: got %+v", r)
+	}
+
+	// An expired override is ignored.
+	if err := database.CreateReadinessOverride(ctx, &model.ReadinessOverride{Release: "quay-v3.16.3", Rule: model.RuleDueSoon,
+		Reason: "old", ExpiresAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if r := readiness(); r.Signal != "yellow" || len(r.Waivers) != 0 {
+		t.Errorf("readiness with expired override: got %+v", r)
+	}
+
+	w := do("POST", "/api/v1/releases/quay-v3.16.3/overrides", body("due_soon", "agreed slip", expires), "secret")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create override: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var created model.ReadinessOverride
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 || created.CreatedBy != "admin-token" || created.Reason != "agreed slip" {
+		t.Errorf("created override: got %+v", created)
+	}
+
+	r := readiness()
+	if r.Signal != "green" || r.Message != "All checks passing (with waivers)" || len(r.Waivers) != 1 || r.Waivers[0].ID != created.ID {
+		t.Errorf("readiness with override: got %+v", r)
+	}
+
+	w = do("GET", "/api/v1/releases/overview", "", "")
+	var overview []model.ReleaseOverview
+	if err := json.NewDecoder(w.Body).Decode(&overview); err != nil {
+		t.Fatal(err)
+	}
+	if len(overview) != 1 || overview[0].Readiness.Signal != "green" || len(overview[0].Readiness.Waivers) != 1 {
+		t.Errorf("overview with override: got %+v", overview)
+	}
+
+	w = do("GET", "/api/v1/releases/quay-v3.16.3/overrides", "", "")
+	var listed []model.ReadinessOverride
+	if err := json.NewDecoder(w.Body).Decode(&listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID {
+		t.Errorf("list overrides: got %+v", listed)
+	}
+
+	path := fmt.Sprintf("/api/v1/releases/quay-v3.16.3/overrides/%d", created.ID)
+	if w := do("DELETE", path, "", "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("delete override: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if w := do("DELETE", path, "", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("delete again: got %d", w.Code)
+	}
+	if r := readiness(); r.Signal != "yellow" || len(r.Waivers) != 0 {
+		t.Errorf("readiness after revoking override: got %+v", r)
+	}
+}
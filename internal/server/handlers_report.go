@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/report"
+)
+
+// handleGetReleaseReport renders a release's go/no-go report as a
+// self-contained HTML page or a PDF, selected by the format parameter.
+func (s *Server) handleGetReleaseReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	format := cmp.Or(r.URL.Query().Get("format"), "html")
+	if format != "html" && format != "pdf" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid format %q: must be html or pdf", format))
+		return
+	}
+
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	issueSummary, _ := s.db.GetIssueSummary(ctx, version)
+	issues, err := s.db.ListJiraIssues(ctx, version, model.IssueFilter{})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	approvals, err := s.db.ListReleaseApprovals(ctx, release.Name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	roles := make([]string, len(approvals))
+	for i, a := range approvals {
+		roles[i] = a.Role
+	}
+	so := signOff(roles)
+
+	// The selected candidate carries the summaries readiness is computed
+	// from; the full record adds its components and image verifications.
+	var selected, snap *model.SnapshotRecord
+	if candidates, err := s.selectedCandidates(ctx); err == nil {
+		selected = candidates[release.Name]
+	}
+	if selected != nil {
+		if snap, err = s.db.GetSnapshotByName(ctx, selected.Name); err != nil && !errors.Is(err, db.ErrNotFound) {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	adv, err := s.releaseAdvisory(ctx, release.Name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var overrides []model.ReadinessOverride
+	if !release.Released {
+		if overrides, err = s.db.ListReadinessOverrides(ctx, release.Name); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	readiness := s.policy.computeReadiness(release, issueSummary, selected, overrides)
+	if !release.Released {
+		readiness.OutstandingApprovals = so.Outstanding
+		applyAdvisory(&readiness, adv, overrides)
+	}
+	rep := &report.Report{
+		Release:      *release,
+		Readiness:    readiness,
+		Checks:       s.policy.readinessChecks(release, issueSummary, selected, so, adv),
+		IssueSummary: issueSummary,
+		Issues:       issues,
+		Snapshot:     snap,
+		Approvals:    approvals,
+		SignOff:      *so,
+		GeneratedAt:  time.Now().UTC(),
+	}
+
+	var buf bytes.Buffer
+	render, contentType := report.HTML, "text/html; charset=utf-8"
+	if format == "pdf" {
+		render, contentType = report.PDF, "application/pdf"
+	}
+	if err := render(&buf, rep); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", release.Name+"-report."+format))
+	_, _ = w.Write(buf.Bytes())
+}
+
+// readinessChecks lists the rules computeReadiness applies to a release,
+// and whether the release meets each, for the go/no-go report. Gates the
+// policy disables, and the advisory check when no advisory is configured,
+// are left out.
+func (p readinessPolicy) readinessChecks(release *model.ReleaseVersion, issueSummary *model.IssueSummary, snap *model.SnapshotRecord, so *model.SignOff, adv *model.Advisory) []report.Check {
+	var summary model.IssueSummary
+	if issueSummary != nil {
+		summary = *issueSummary
+	}
+	var checks []report.Check
+
+	due := report.Check{Name: "Due date", Passed: true, Detail: "No due date"}
+	if release.DueDate != nil {
+		due.Detail = "Due " + release.DueDate.Format("2006-01-02")
+		due.Passed = release.Released || time.Now().Before(*release.DueDate)
+	}
+	checks = append(checks,
+		due,
+		report.Check{Name: "Release blockers", Passed: summary.OpenBlockers == 0, Detail: fmt.Sprintf("%d open", summary.OpenBlockers)},
+	)
+	if p.cveSeverity != "" {
+		n := openCVEsAtOrAbove(issueSummary, p.cveSeverity)
+		checks = append(checks, report.Check{Name: "CVEs rated " + p.cveSeverity + " or higher", Passed: n == 0, Detail: fmt.Sprintf("%d open", n)})
+	}
+
+	if snap == nil {
+		checks = append(checks, report.Check{Name: "Snapshot", Passed: true, Detail: "No snapshot selected"})
+	} else {
+		tests := report.Check{Name: "Integration tests", Passed: !snap.HasTests || snap.TestsPassed, Detail: "Passed"}
+		switch {
+		case !snap.HasTests:
+			tests.Detail = "No test results"
+		case !snap.TestsPassed:
+			tests.Detail = "Failing"
+		}
+		checks = append(checks, tests)
+		if p.requireImageDigests {
+			var images model.ImageDigestSummary
+			if snap.ImageDigests != nil {
+				images = *snap.ImageDigests
+			}
+			checks = append(checks, report.Check{
+				Name:   "Image digests",
+				Passed: images.Failed == 0 && images.Verified >= images.Components,
+				Detail: fmt.Sprintf("%d of %d verified, %d failed", images.Verified, images.Components, images.Failed),
+			})
+		}
+		if snap.ReleasePipelines != nil {
+			checks = append(checks, report.Check{
+				Name:   "Konflux release pipelines",
+				Passed: snap.ReleasePipelines.Failed == 0,
+				Detail: fmt.Sprintf("%d succeeded, %d failed, %d in progress", snap.ReleasePipelines.Succeeded, snap.ReleasePipelines.Failed, snap.ReleasePipelines.Progressing),
+			})
+		}
+		if p.requireEC {
+			var ec model.ECSummary
+			if snap.EC != nil {
+				ec = *snap.EC
+			}
+			checks = append(checks, report.Check{
+				Name:   "Enterprise Contract",
+				Passed: ec.Components > 0 && ec.Failed == 0,
+				Detail: fmt.Sprintf("%d of %d components failing", ec.Failed, ec.Components),
+			})
+		}
+	}
+
+	checks = append(checks,
+		report.Check{Name: "Open issues", Passed: summary.Open == 0, Detail: fmt.Sprintf("%d of %d open", summary.Open, summary.Total)},
+		report.Check{Name: "Sign-offs", Passed: release.Released || len(so.Outstanding) == 0, Detail: fmt.Sprintf("%d of %d approved", len(so.Approved), len(so.Approved)+len(so.Outstanding))},
+	)
+	if adv != nil {
+		checks = append(checks, advisoryCheck(release, adv))
+	}
+	return checks
+}
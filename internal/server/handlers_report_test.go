@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestGetReleaseReport(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	dueDate := time.Now().Add(10 * 24 * time.Hour)
+	err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{
+		Name:          "3.16.3",
+		S3Application: "quay-v3-16",
+		DueDate:       &dueDate,
+	})
+	if err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+	if _, err := database.CreateSnapshot(ctx, "quay-v3-16", "quay-v3-16-snap-1", true, time.Now()); err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/3.16.3/report", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("html: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("html content type: got %q", ct)
+	}
+	for _, want := range []string{"3.16.3", "Release blockers", "Integration tests", "quay-v3-16-snap-1"} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("html report missing %q", want)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/3.16.3/report?format=pdf", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("pdf: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Errorf("pdf content type: got %q", ct)
+	}
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")) {
+		t.Errorf("pdf body does not start with a PDF header")
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, `"3.16.3-report.pdf"`) {
+		t.Errorf("content disposition: got %q", cd)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/3.16.3/report?format=docx", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad format: got %d, want 400", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/releases/9.9.9/report", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown release: got %d, want 404", w.Code)
+	}
+}
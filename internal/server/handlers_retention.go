@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// RetentionPlanner previews snapshot pruning; see retention.Pruner.Plan.
+type RetentionPlanner interface {
+	Plan(ctx context.Context) (*model.RetentionPlan, error)
+}
+
+// handleRetentionPreview lists the snapshots the next retention run would
+// delete, without deleting anything.
+func (s *Server) handleRetentionPreview(w http.ResponseWriter, r *http.Request) {
+	if s.retention == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("snapshot retention not configured"))
+		return
+	}
+	plan, err := s.retention.Plan(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, plan)
+}
+
+func (s *Server) handleListAuditHolds(w http.ResponseWriter, r *http.Request) {
+	holds, err := s.db.ListAuditHolds(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if holds == nil {
+		holds = []model.AuditHold{}
+	}
+	writeJSON(w, http.StatusOK, holds)
+}
+
+type auditHoldRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleSetAuditHold keeps all of a release's snapshots from being pruned,
+// e.g. while the release is under audit. It is an admin endpoint; the body
+// is optional.
+func (s *Server) handleSetAuditHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if _, err := s.db.GetReleaseVersion(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	var req auditHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	hold, err := s.db.SetAuditHold(ctx, version, strings.TrimSpace(req.Reason))
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("audit hold for %q", version))
+		return
+	}
+	s.logger.InfoContext(ctx, "audit hold set", "release", version, "reason", hold.Reason)
+	writeJSON(w, http.StatusOK, hold)
+}
+
+// handleDeleteAuditHold lifts a release's audit hold. It is an admin
+// endpoint.
+func (s *Server) handleDeleteAuditHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	if err := s.db.DeleteAuditHold(ctx, version); err != nil {
+		writeStoreError(w, err, fmt.Sprintf("audit hold for %q", version))
+		return
+	}
+	s.logger.InfoContext(ctx, "audit hold lifted", "release", version)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakePlanner model.RetentionPlan
+
+func (f *fakePlanner) Plan(ctx context.Context) (*model.RetentionPlan, error) {
+	plan := model.RetentionPlan(*f)
+	return &plan, nil
+}
+
+func TestAuditHolds(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.15.0", Released: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tc := range []struct {
+		name, method, path, body, token string
+		want                            int
+	}{
+		{"no token", "PUT", "/api/v1/releases/quay-v3.15.0/audit-hold", "", "", http.StatusUnauthorized},
+		{"unknown release", "PUT", "/api/v1/releases/quay-v9.9.9/audit-hold", "", "secret", http.StatusNotFound},
+		{"bad body", "PUT", "/api/v1/releases/quay-v3.15.0/audit-hold", "{", "secret", http.StatusBadRequest},
+		{"lift missing hold", "DELETE", "/api/v1/releases/quay-v3.15.0/audit-hold", "", "secret", http.StatusNotFound},
+	} {
+		if w := do(tc.method, tc.path, tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	w := do("PUT", "/api/v1/releases/quay-v3.15.0/audit-hold", `{"reason":" CVE-2026-1234 review "}`, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("set hold: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var hold model.AuditHold
+	if err := json.NewDecoder(w.Body).Decode(&hold); err != nil {
+		t.Fatal(err)
+	}
+	if hold.Release != "quay-v3.15.0" || hold.Reason != "CVE-2026-1234 review" || hold.CreatedAt.IsZero() {
+		t.Errorf("hold: got %+v", hold)
+	}
+
+	w = do("GET", "/api/v1/retention/holds", "", "")
+	var holds []model.AuditHold
+	if err := json.NewDecoder(w.Body).Decode(&holds); err != nil {
+		t.Fatal(err)
+	}
+	if len(holds) != 1 || holds[0].Release != "quay-v3.15.0" {
+		t.Errorf("holds: got %+v", holds)
+	}
+
+	if w := do("DELETE", "/api/v1/releases/quay-v3.15.0/audit-hold", "", "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("lift hold: got %d, body: %s", w.Code, w.Body.String())
+	}
+	w = do("GET", "/api/v1/retention/holds", "", "")
+	if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+		t.Errorf("holds after lifting: got %s, want []", body)
+	}
+}
+
+func TestRetentionPreview(t *testing.T) {
+	srv, _ := setupTestServer(t)
+
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/retention/preview", nil))
+		return w
+	}
+	if w := get(); w.Code != http.StatusNotImplemented {
+		t.Errorf("without retention: got %d, want %d", w.Code, http.StatusNotImplemented)
+	}
+
+	srv.SetRetention(&fakePlanner{
+		Delete: []model.RetentionDeletion{{SnapshotID: 7, Snapshot: "quay-v3-15-abc", Application: "quay-v3-15", Reason: "max_count"}},
+		Kept:   4,
+	})
+	w := get()
+	if w.Code != http.StatusOK {
+		t.Fatalf("preview: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var plan model.RetentionPlan
+	if err := json.NewDecoder(w.Body).Decode(&plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].Snapshot != "quay-v3-15-abc" || plan.Kept != 4 {
+		t.Errorf("plan: got %+v", plan)
+	}
+}
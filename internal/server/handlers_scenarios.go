@@ -0,0 +1,56 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// handleListScenarioRequirements lists the scenarios of an application
+// marked required or informational. Scenarios not listed are required.
+func (s *Server) handleListScenarioRequirements(w http.ResponseWriter, r *http.Request) {
+	reqs, err := s.db.ListScenarioRequirements(r.Context(), r.PathValue("app"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if reqs == nil {
+		reqs = []model.ScenarioRequirement{}
+	}
+	writeJSON(w, http.StatusOK, reqs)
+}
+
+// handleSetScenarioRequirement marks a scenario of an application required
+// or informational. Only required scenarios fail a snapshot's
+// tests_passed, so the change is applied to the application's stored
+// snapshots as well as to new ones. It is an admin endpoint.
+func (s *Server) handleSetScenarioRequirement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		Required *bool `json:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if body.Required == nil {
+		writeError(w, http.StatusBadRequest, errors.New("required must be true or false"))
+		return
+	}
+	req := model.ScenarioRequirement{
+		Application: r.PathValue("app"),
+		Scenario:    r.PathValue("scenario"),
+		Required:    *body.Required,
+	}
+	if err := s.db.SetScenarioRequirement(ctx, &req); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.candidateCache.invalidate()
+	s.overviewCache.invalidate()
+	s.logger.InfoContext(ctx, "scenario requirement changed", "application", req.Application, "scenario", req.Scenario, "required", req.Required)
+	writeJSON(w, http.StatusOK, req)
+}
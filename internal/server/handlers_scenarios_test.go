@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestScenarioRequirements(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetAdmin("secret", nil)
+	ctx := t.Context()
+	err := database.SaveSnapshot(ctx, &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		TestSuites: []model.TestSuite{
+			{Name: "e2e", Status: "passed", Tests: 2, Passed: 2},
+			{Name: "perf", Status: "failed", Tests: 2, Passed: 1, Failed: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w
+	}
+	testsPassed := func() bool {
+		t.Helper()
+		snap, err := database.GetSnapshotByName(ctx, "quay-v3-17-snap-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return snap.TestsPassed
+	}
+
+	for _, tc := range []struct {
+		name, body, token string
+		want              int
+	}{
+		{"no token", `{"required":false}`, "", http.StatusUnauthorized},
+		{"missing required", `{}`, "secret", http.StatusBadRequest},
+	} {
+		if w := do("PUT", "/api/v1/applications/quay-v3-17/scenarios/perf", tc.body, tc.token); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d (body: %s)", tc.name, w.Code, tc.want, w.Body.String())
+		}
+	}
+
+	if w := do("PUT", "/api/v1/applications/quay-v3-17/scenarios/perf", `{"required":false}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("mark informational: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if !testsPassed() {
+		t.Error("tests_passed after marking the failing scenario informational: got false")
+	}
+
+	w := do("GET", "/api/v1/applications/quay-v3-17/scenarios", "", "")
+	var reqs []model.ScenarioRequirement
+	if err := json.NewDecoder(w.Body).Decode(&reqs); err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || reqs[0].Scenario != "perf" || reqs[0].Required {
+		t.Errorf("requirements: got %+v", reqs)
+	}
+
+	if w := do("PUT", "/api/v1/applications/quay-v3-17/scenarios/perf", `{"required":true}`, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("mark required: got %d, body: %s", w.Code, w.Body.String())
+	}
+	if testsPassed() {
+		t.Error("tests_passed after marking the failing scenario required: got true")
+	}
+}
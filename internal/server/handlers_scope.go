@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// defaultLateScopeDays is how many days before a release's due date an
+// added issue counts as late scope creep, unless late_days says otherwise.
+const defaultLateScopeDays = 14
+
+// handleGetScopeChanges lists the issues that entered or left a release
+// after its first sync, counting those added late relative to the due date.
+func (s *Server) handleGetScopeChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version := r.PathValue("version")
+	release, err := s.db.GetReleaseVersion(ctx, version)
+	if err != nil {
+		writeStoreError(w, err, fmt.Sprintf("release %q", version))
+		return
+	}
+	lateDays := defaultLateScopeDays
+	if r.URL.Query().Has("late_days") {
+		if lateDays, err = queryInt(r.URL.Query(), "late_days"); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	changes, err := s.db.ListScopeChanges(ctx, version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summarizeScopeChanges(release, changes, lateDays))
+}
+
+// summarizeScopeChanges counts the additions and removals among changes.
+// Additions within lateDays of the release's due date, or after it, are
+// late.
+func summarizeScopeChanges(release *model.ReleaseVersion, changes []model.ScopeChange, lateDays int) model.ScopeChanges {
+	if changes == nil {
+		changes = []model.ScopeChange{}
+	}
+	sc := model.ScopeChanges{Release: release.Name, DueDate: release.DueDate, Changes: changes}
+	if release.DueDate != nil {
+		since := release.DueDate.AddDate(0, 0, -lateDays)
+		sc.LateSince = &since
+	}
+	for _, c := range changes {
+		switch c.Change {
+		case model.ScopeAdded:
+			sc.Added++
+			if sc.LateSince != nil && !c.ChangedAt.Before(*sc.LateSince) {
+				sc.LateAdded++
+			}
+		case model.ScopeRemoved:
+			sc.Removed++
+		}
+	}
+	return sc
+}
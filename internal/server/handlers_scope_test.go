@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestGetScopeChanges(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+
+	due := time.Now().AddDate(0, 0, 3).UTC().Truncate(time.Second)
+	if err := database.UpsertReleaseVersion(ctx, &model.ReleaseVersion{Name: "quay-v3.16.3", DueDate: &due}); err != nil {
+		t.Fatal(err)
+	}
+	// The issue stored before the first sync is the baseline.
+	if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{Key: "PROJQUAY-1", FixVersion: "quay-v3.16.3", Summary: "Fix GC", UpdatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SaveJiraSyncState(ctx, model.JiraSyncState{FixVersion: "quay-v3.16.3", SyncedAt: &due}); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"PROJQUAY-2", "PROJQUAY-3", "PROJQUAY-2"} {
+		if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{Key: key, FixVersion: "quay-v3.16.3", Summary: "Late fix", UpdatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.DeleteJiraIssue(ctx, "PROJQUAY-1", "quay-v3.16.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string) model.ScopeChanges {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d, body: %s", path, w.Code, w.Body.String())
+		}
+		var sc model.ScopeChanges
+		if err := json.NewDecoder(w.Body).Decode(&sc); err != nil {
+			t.Fatal(err)
+		}
+		return sc
+	}
+
+	sc := get("/api/v1/releases/quay-v3.16.3/scope-changes")
+	if sc.Added != 2 || sc.Removed != 1 || sc.LateAdded != 2 || len(sc.Changes) != 3 {
+		t.Errorf("scope changes: got %+v", sc)
+	}
+	if c := sc.Changes[2]; c.IssueKey != "PROJQUAY-1" || c.Change != model.ScopeRemoved || c.Summary != "Fix GC" {
+		t.Errorf("removal: got %+v", c)
+	}
+	if sc.LateSince == nil || !sc.LateSince.Equal(due.AddDate(0, 0, -defaultLateScopeDays)) {
+		t.Errorf("late since: got %v", sc.LateSince)
+	}
+
+	if sc := get("/api/v1/releases/quay-v3.16.3/scope-changes?late_days=1"); sc.LateAdded != 0 {
+		t.Errorf("late_days=1: got %d late additions, want 0", sc.LateAdded)
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/releases/quay-v3.16.3/scope-changes?late_days=x": http.StatusBadRequest,
+		"/api/v1/releases/quay-v9.9.9/scope-changes":              http.StatusNotFound,
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("%s: got %d, want %d", path, w.Code, want)
+		}
+	}
+}
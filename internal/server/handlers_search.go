@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Number of search results of each type returned by default and at most.
+const (
+	defaultSearchResults = 10
+	maxSearchResults     = 50
+)
+
+// handleSearch searches issue keys and summaries, snapshot names,
+// component names and failed test cases for the words of q, returning up
+// to limit results of each type.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := strings.TrimSpace(q.Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing search query q"))
+		return
+	}
+	limit, err := queryInt(q, "limit")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if limit == 0 {
+		limit = defaultSearchResults
+	}
+	limit = min(limit, maxSearchResults)
+
+	results, err := s.db.Search(r.Context(), query, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if results == nil {
+		results = []model.SearchResult{}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestSearch(t *testing.T) {
+	srv, database := setupTestServer(t)
+	ctx := t.Context()
+	err := database.SaveSnapshot(ctx, &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		CreatedAt:   time.Now(),
+		Components:  []model.ComponentRecord{{Component: "quay-mirror-worker"}},
+		TestSuites: []model.TestSuite{{
+			Name: "e2e", Status: "failed", Tests: 1, Failed: 1,
+			TestCases: []model.TestCase{{Name: "test_mirror_sync", Status: "failed", Message: "mirror timed out"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.UpsertJiraIssue(ctx, &model.JiraIssueRecord{Key: "PROJQUAY-1", Summary: "Mirror worker leaks memory", FixVersion: "quay-v3.17.0", UpdatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/search?q=mirror", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var results []model.SearchResult
+	if err := json.NewDecoder(w.Body).Decode(&results); err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, r := range results {
+		types = append(types, r.Type)
+	}
+	if len(results) != 3 || results[0].FixVersion != "quay-v3.17.0" || results[1].Snapshot != "quay-v3-17-snap-1" || results[2].Scenario != "e2e" {
+		t.Errorf("results: got %+v", results)
+	}
+	if want := []string{model.SearchIssue, model.SearchComponent, model.SearchFailure}; !slices.Equal(types, want) {
+		t.Errorf("types: got %v, want %v", types, want)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/search?q=nothing+here", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "[]\n" {
+		t.Errorf("no match: got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	for _, query := range []string{"", "?q=+", "?q=mirror&limit=-1"} {
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/search"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: got %d, want 400", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// timelineSnapshotLimit caps the snapshot events loaded per application.
+const timelineSnapshotLimit = 500
+
+func (s *Server) handleGetProductTimeline(w http.ResponseWriter, r *http.Request) {
+	product := strings.ToLower(r.PathValue("product"))
+	timeline, err := s.buildTimeline(r.Context(), product)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(timeline.Lanes) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no releases found for product %q", product))
+		return
+	}
+	writeJSON(w, http.StatusOK, timeline)
+}
+
+// buildTimeline lays out the releases of product that are neither archived
+// nor hidden. Lanes are ordered by when each release shipped or is due; a
+// snapshot is attributed to the first lane of its application that had not
+// shipped (or come due) by the day it was built, so z-streams sharing an
+// application split its snapshots between them.
+func (s *Server) buildTimeline(ctx context.Context, product string) (*model.Timeline, error) {
+	releases, err := s.db.ListAllReleaseVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	releases = slices.DeleteFunc(releases, func(rel model.ReleaseVersion) bool {
+		return rel.Archived || rel.Hidden || rel.Product() != product
+	})
+	slices.SortStableFunc(releases, func(a, b model.ReleaseVersion) int {
+		ea, eb := laneEnd(a), laneEnd(b)
+		switch {
+		case ea == nil && eb == nil:
+			return strings.Compare(a.Name, b.Name)
+		case ea == nil:
+			return 1
+		case eb == nil:
+			return -1
+		}
+		if c := ea.Compare(*eb); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	timeline := &model.Timeline{Product: product, Lanes: make([]model.TimelineLane, len(releases))}
+	for i, rel := range releases {
+		timeline.Lanes[i] = model.TimelineLane{
+			Release:     rel.Name,
+			Application: rel.S3Application,
+			Released:    rel.Released,
+			Events:      s.milestoneEvents(rel),
+		}
+	}
+
+	loaded := make(map[string]bool)
+	for _, rel := range releases {
+		app := rel.S3Application
+		if app == "" || loaded[app] {
+			continue
+		}
+		loaded[app] = true
+		snapshots, err := s.db.ListSnapshots(ctx, app, timelineSnapshotLimit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list snapshots for %s: %w", app, err)
+		}
+		for _, snap := range snapshots {
+			for i, rel := range releases {
+				if rel.S3Application != app {
+					continue
+				}
+				// Dates have day precision; a snapshot built on the release
+				// day still belongs to that release.
+				if end := laneEnd(rel); end != nil && !snap.CreatedAt.Before(end.AddDate(0, 0, 1)) {
+					continue
+				}
+				timeline.Lanes[i].Events = append(timeline.Lanes[i].Events, snapshotEvent(snap))
+				break
+			}
+		}
+	}
+
+	for i := range timeline.Lanes {
+		lane := &timeline.Lanes[i]
+		slices.SortStableFunc(lane.Events, func(a, b model.TimelineEvent) int {
+			return a.Start.Compare(b.Start)
+		})
+		for _, ev := range lane.Events {
+			start, end := ev.Start, ev.Start
+			if ev.End != nil {
+				end = *ev.End
+			}
+			if lane.Start == nil || start.Before(*lane.Start) {
+				lane.Start = &start
+			}
+			if lane.End == nil || end.After(*lane.End) {
+				lane.End = &end
+			}
+		}
+	}
+	return timeline, nil
+}
+
+// milestoneEvents returns the freeze window, due date, and release date of
+// rel, as far as they are known.
+func (s *Server) milestoneEvents(rel model.ReleaseVersion) []model.TimelineEvent {
+	events := []model.TimelineEvent{}
+	if rel.DueDate != nil {
+		if s.freezeWindow > 0 {
+			due := *rel.DueDate
+			events = append(events, model.TimelineEvent{
+				Kind:  model.TimelineFreeze,
+				Start: due.Add(-s.freezeWindow),
+				End:   &due,
+				Label: "Code freeze",
+			})
+		}
+		events = append(events, model.TimelineEvent{Kind: model.TimelineDue, Start: *rel.DueDate, Label: "Due"})
+	}
+	if rel.Released && rel.ReleaseDate != nil {
+		events = append(events, model.TimelineEvent{Kind: model.TimelineReleased, Start: *rel.ReleaseDate, Label: "Released"})
+	}
+	return events
+}
+
+func snapshotEvent(snap model.SnapshotRecord) model.TimelineEvent {
+	ev := model.TimelineEvent{Kind: model.TimelineSnapshot, Start: snap.CreatedAt, Label: snap.Name}
+	if snap.HasTests {
+		ev.Status = "failed"
+		if snap.TestsPassed {
+			ev.Status = "passed"
+		}
+	}
+	return ev
+}
+
+// laneEnd returns the date a release shipped or, until then, is due.
+func laneEnd(rel model.ReleaseVersion) *time.Time {
+	if rel.Released && rel.ReleaseDate != nil {
+		return rel.ReleaseDate
+	}
+	return rel.DueDate
+}
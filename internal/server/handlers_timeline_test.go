@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestProductTimeline(t *testing.T) {
+	srv, database := setupTestServer(t)
+	srv.SetFreezeWindow(7 * 24 * time.Hour)
+	ctx := t.Context()
+
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	for _, rel := range []model.ReleaseVersion{
+		{Name: "3.16.2", S3Application: "quay-v3-16", DueDate: day(12), Released: true, ReleaseDate: day(10)},
+		{Name: "3.16.3", S3Application: "quay-v3-16", DueDate: day(26)},
+		{Name: "3.15.9", S3Application: "quay-v3-15", Archived: true},
+		{Name: "omr-v2.0.10", S3Application: "omr-v2-0", DueDate: day(20)},
+	} {
+		if err := database.UpsertReleaseVersion(ctx, &rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// snap-a is built on the day 3.16.2 ships, snap-b the day after.
+	for name, created := range map[string]time.Time{
+		"quay-v3-16-snap-a": day(10).Add(15 * time.Hour),
+		"quay-v3-16-snap-b": day(11).Add(time.Hour),
+	} {
+		if _, err := database.CreateSnapshot(ctx, "quay-v3-16", name, true, created); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/products/quay/timeline", nil)
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("timeline: got %d, body: %s", w.Code, w.Body.String())
+	}
+	var timeline model.Timeline
+	if err := json.NewDecoder(w.Body).Decode(&timeline); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(timeline.Lanes) != 2 {
+		t.Fatalf("lanes: got %d, want 2 (archived and other products excluded)", len(timeline.Lanes))
+	}
+	shipped, next := timeline.Lanes[0], timeline.Lanes[1]
+	if shipped.Release != "3.16.2" || next.Release != "3.16.3" {
+		t.Fatalf("lane order: got %s, %s", shipped.Release, next.Release)
+	}
+
+	kinds := func(lane model.TimelineLane) []string {
+		var out []string
+		for _, ev := range lane.Events {
+			out = append(out, ev.Kind+":"+ev.Label)
+		}
+		return out
+	}
+	wantShipped := []string{"freeze:Code freeze", "released:Released", "snapshot:quay-v3-16-snap-a", "due:Due"}
+	if got := kinds(shipped); !slices.Equal(got, wantShipped) {
+		t.Errorf("3.16.2 events: got %v, want %v", got, wantShipped)
+	}
+	wantNext := []string{"snapshot:quay-v3-16-snap-b", "freeze:Code freeze", "due:Due"}
+	if got := kinds(next); !slices.Equal(got, wantNext) {
+		t.Errorf("3.16.3 events: got %v, want %v", got, wantNext)
+	}
+	if !shipped.Start.Equal(day(12).AddDate(0, 0, -7)) || !shipped.End.Equal(*day(12)) {
+		t.Errorf("3.16.2 span: got %v – %v", shipped.Start, shipped.End)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/products/clair/timeline", nil)
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown product: got %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Number of snapshots a scenario trend covers by default and at most.
+const (
+	defaultTrendRuns = 30
+	maxTrendRuns     = 200
+)
+
+// handleGetScenarioTrend returns a test scenario's pass rate and duration
+// over the newest snapshots of an application that ran it, to catch suites
+// that fail more often or slowly get slower.
+func (s *Server) handleGetScenarioTrend(w http.ResponseWriter, r *http.Request) {
+	app, scenario := r.PathValue("app"), r.PathValue("scenario")
+	limit, err := queryInt(r.URL.Query(), "limit")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if limit == 0 {
+		limit = defaultTrendRuns
+	}
+	limit = min(limit, maxTrendRuns)
+
+	runs, err := s.db.ListScenarioRuns(r.Context(), app, scenario, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(runs) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no runs of scenario %q in application %q", scenario, app))
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioTrend(app, scenario, runs))
+}
+
+// scenarioTrend aggregates runs, which are ordered oldest first.
+func scenarioTrend(app, scenario string, runs []model.ScenarioRun) model.ScenarioTrend {
+	trend := model.ScenarioTrend{Application: app, Scenario: scenario, Runs: runs}
+	var passed, executed int
+	var total int64
+	for _, run := range runs {
+		passed += run.Passed
+		executed += run.Tests - run.Skipped
+		total += run.DurationMs
+	}
+	if executed > 0 {
+		rate := float64(passed) / float64(executed)
+		trend.PassRate = &rate
+	}
+	trend.MeanDurationMs = total / int64(len(runs))
+
+	if len(runs) >= 2 {
+		half := len(runs) / 2
+		older, newer := meanDuration(runs[:half]), meanDuration(runs[len(runs)-half:])
+		if older > 0 {
+			change := (newer - older) / older
+			trend.DurationChange = &change
+		}
+	}
+	return trend
+}
+
+func meanDuration(runs []model.ScenarioRun) float64 {
+	var total int64
+	for _, run := range runs {
+		total += run.DurationMs
+	}
+	return float64(total) / float64(len(runs))
+}
+
+// passRateWindows are the windows, in days, a scenario's pass rate is
+// given over.
+var passRateWindows = []int{7, 30, 90}
+
+// handleListScenarioPassRates returns the pass rate of each test scenario
+// of an application over the last 7, 30 and 90 days, and the snapshot it
+// last failed in, to tell flaky scenarios from broken ones.
+func (s *Server) handleListScenarioPassRates(w http.ResponseWriter, r *http.Request) {
+	ctx, app := r.Context(), r.PathValue("app")
+	rates, err := s.db.ListScenarioPassRates(ctx, app, passRateWindows, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	reqs, err := s.db.ListScenarioRequirements(ctx, app)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	informational := make(map[string]bool)
+	for _, req := range reqs {
+		informational[req.Scenario] = !req.Required
+	}
+	for i := range rates {
+		rates[i].Required = !informational[rates[i].Scenario]
+	}
+	if rates == nil {
+		rates = []model.ScenarioPassRate{}
+	}
+	writeJSON(w, http.StatusOK, rates)
+}
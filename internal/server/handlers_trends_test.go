@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+func TestGetScenarioTrend(t *testing.T) {
+	srv, database := setupTestServer(t)
+	start := time.Now().Add(-time.Hour)
+	// Four snapshots of an e2e suite getting slower, one with a failure.
+	for i, d := range []int64{100, 100, 150, 150} {
+		failed := 0
+		if i == 2 {
+			failed = 1
+		}
+		err := database.SaveSnapshot(t.Context(), &model.SnapshotRecord{
+			Application: "quay-v3-17",
+			Name:        fmt.Sprintf("quay-v3-17-snap-%d", i),
+			CreatedAt:   start.Add(time.Duration(i) * time.Minute),
+			TestSuites: []model.TestSuite{{
+				Name: "e2e", Status: "passed", Tests: 5, Passed: 4 - failed, Failed: failed, Skipped: 1, DurationMs: d,
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/applications/quay-v3-17/scenarios/e2e/trend", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var trend model.ScenarioTrend
+	if err := json.NewDecoder(w.Body).Decode(&trend); err != nil {
+		t.Fatal(err)
+	}
+	if len(trend.Runs) != 4 || trend.Runs[0].Snapshot != "quay-v3-17-snap-0" || trend.Runs[3].DurationMs != 150 {
+		t.Fatalf("runs: got %+v", trend.Runs)
+	}
+	if r := trend.Runs[2].PassRate; r == nil || *r != 0.75 {
+		t.Errorf("run pass rate: got %v, want 0.75", r)
+	}
+	if trend.PassRate == nil || *trend.PassRate != 15.0/16 || trend.MeanDurationMs != 125 {
+		t.Errorf("aggregates: got pass rate %v, mean %d", trend.PassRate, trend.MeanDurationMs)
+	}
+	if trend.DurationChange == nil || *trend.DurationChange != 0.5 {
+		t.Errorf("duration change: got %v, want 0.5", trend.DurationChange)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/applications/quay-v3-17/scenarios/e2e/trend?limit=2", nil))
+	if err := json.NewDecoder(w.Body).Decode(&trend); err != nil {
+		t.Fatal(err)
+	}
+	if len(trend.Runs) != 2 || trend.Runs[0].Snapshot != "quay-v3-17-snap-2" {
+		t.Errorf("limited runs: got %+v", trend.Runs)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/applications/quay-v3-17/scenarios/upgrade/trend", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown scenario: got %d, want 404", w.Code)
+	}
+}
+
+func TestListScenarioPassRates(t *testing.T) {
+	srv, database := setupTestServer(t)
+	now := time.Now()
+	// e2e fails 60 and 3 days ago; upgrade is informational and last ran,
+	// passing, 100 days ago.
+	for i, run := range []struct {
+		age      int
+		e2eFails bool
+	}{{100, false}, {60, true}, {20, false}, {3, true}, {1, false}} {
+		e2e := model.TestSuite{Name: "e2e", Status: "passed", Tests: 4, Passed: 4}
+		if run.e2eFails {
+			e2e = model.TestSuite{Name: "e2e", Status: "failed", Tests: 4, Passed: 2, Failed: 1, Skipped: 1}
+		}
+		suites := []model.TestSuite{e2e}
+		if run.age == 100 {
+			suites = append(suites, model.TestSuite{Name: "upgrade", Status: "passed", Tests: 1, Passed: 1})
+		}
+		err := database.SaveSnapshot(t.Context(), &model.SnapshotRecord{
+			Application: "quay-v3-17",
+			Name:        fmt.Sprintf("quay-v3-17-snap-%d", i),
+			CreatedAt:   now.AddDate(0, 0, -run.age),
+			TestSuites:  suites,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.SetScenarioRequirement(t.Context(), &model.ScenarioRequirement{Application: "quay-v3-17", Scenario: "e2e", Required: false}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/applications/quay-v3-17/pass-rates", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, body: %s", w.Code, w.Body.String())
+	}
+	var rates []model.ScenarioPassRate
+	if err := json.NewDecoder(w.Body).Decode(&rates); err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 1 || rates[0].Scenario != "e2e" || rates[0].Required {
+		t.Fatalf("got %+v", rates)
+	}
+	for i, want := range []struct {
+		days, runs, failed int
+		rate               float64
+	}{{7, 2, 1, 6.0 / 7}, {30, 3, 1, 10.0 / 11}, {90, 4, 2, 12.0 / 14}} {
+		got := rates[0].Windows[i]
+		if got.Days != want.days || got.Runs != want.runs || got.FailedRuns != want.failed || got.PassRate == nil || *got.PassRate != want.rate {
+			t.Errorf("window %d: got %+v, pass rate %v, want %+v", i, got, got.PassRate, want)
+		}
+	}
+	if f := rates[0].LastFailure; f == nil || f.Snapshot != "quay-v3-17-snap-3" || f.Failed != 1 {
+		t.Errorf("last failure: got %+v", f)
+	}
+
+	w = httptest.NewRecorder()
+	srv.http.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/applications/quay-v3-99/pass-rates", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "[]\n" {
+		t.Errorf("unknown application: got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/quay/release-readiness/internal/jira"
+)
+
+// maxWebhookBytes caps the size of a webhook delivery. JIRA payloads carry
+// the full issue and its changelog.
+const maxWebhookBytes = 4 << 20
+
+// JiraWebhook applies JIRA webhook events; see jira.Syncer.ApplyWebhook.
+type JiraWebhook interface {
+	ApplyWebhook(ctx context.Context, event jira.WebhookEvent) error
+}
+
+// handleJiraWebhook applies an issue event delivered by a JIRA webhook.
+// Deliveries must prove they know the shared secret by signing the body
+// (JIRA Cloud's X-Hub-Signature: sha256=<hmac>). For JIRA instances that
+// cannot sign, the secret may be passed as the secret query parameter
+// instead, if that was allowed; it then shows up in access logs.
+func (s *Server) handleJiraWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.jiraWebhook == nil || s.jiraWebhookSecret == "" {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("JIRA webhook is not configured"))
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("read body: %w", err))
+		return
+	}
+	if !validWebhookSecret(r, body, s.jiraWebhookSecret, s.jiraWebhookQuerySecret) {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("invalid or missing webhook secret"))
+		return
+	}
+
+	var event jira.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if event.Issue.Key == "" {
+		// Not an issue event, e.g. a version or sprint change.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := s.jiraWebhook.ApplyWebhook(ctx, event); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.overviewCache.invalidate()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validWebhookSecret reports whether r carries a valid signature of body
+// or, if query is set, the secret itself in its query.
+func validWebhookSecret(r *http.Request, body []byte, secret string, query bool) bool {
+	if sig, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature"), "sha256="); ok {
+		got, err := hex.DecodeString(sig)
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(got, mac.Sum(nil))
+	}
+	if !query {
+		return false
+	}
+	given := r.URL.Query().Get("secret")
+	return given != "" && hmac.Equal([]byte(given), []byte(secret))
+}
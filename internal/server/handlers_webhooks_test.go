@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/jira"
+)
+
+type fakeJiraWebhook struct {
+	events []jira.WebhookEvent
+}
+
+func (f *fakeJiraWebhook) ApplyWebhook(ctx context.Context, event jira.WebhookEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestJiraWebhook(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	const body = `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJQUAY-7","fields":{"summary":"crash"}}}`
+
+	deliver := func(query, signature, body string) int {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/webhooks/jira"+query, strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature", signature)
+		}
+		w := httptest.NewRecorder()
+		srv.http.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+	sign := func(secret, body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	if code := deliver("", "", body); code != http.StatusNotImplemented {
+		t.Errorf("unconfigured: got %d, want 501", code)
+	}
+
+	webhook := &fakeJiraWebhook{}
+	srv.SetJiraWebhook(webhook, "s3cret", false)
+	for _, tc := range []struct {
+		name             string
+		query, signature string
+		want             int
+	}{
+		{"no secret", "", "", http.StatusUnauthorized},
+		{"wrong signature", "", sign("nope", body), http.StatusUnauthorized},
+		{"bad signature encoding", "", "sha256=zz", http.StatusUnauthorized},
+		{"query secret not allowed", "?secret=s3cret", "", http.StatusUnauthorized},
+		{"signature", "", sign("s3cret", body), http.StatusNoContent},
+	} {
+		if code := deliver(tc.query, tc.signature, body); code != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, code, tc.want)
+		}
+	}
+	if len(webhook.events) != 1 || webhook.events[0].Issue.Key != "PROJQUAY-7" || webhook.events[0].Issue.Fields.Summary != "crash" {
+		t.Errorf("events: got %+v", webhook.events)
+	}
+
+	const other = `{"webhookEvent":"jira:version_released","version":{}}`
+	if code := deliver("", sign("s3cret", other), other); code != http.StatusNoContent {
+		t.Errorf("non-issue event: got %d", code)
+	}
+	if code := deliver("", sign("s3cret", "{"), `{`); code != http.StatusBadRequest {
+		t.Errorf("invalid body: got %d", code)
+	}
+	if len(webhook.events) != 1 {
+		t.Errorf("events after non-issue deliveries: got %d, want 1", len(webhook.events))
+	}
+
+	srv.SetJiraWebhook(webhook, "s3cret", true)
+	if code := deliver("?secret=nope", "", body); code != http.StatusUnauthorized {
+		t.Errorf("wrong query secret: got %d, want 401", code)
+	}
+	if code := deliver("?secret=s3cret", "", body); code != http.StatusNoContent {
+		t.Errorf("allowed query secret: got %d, want 204", code)
+	}
+	if len(webhook.events) != 2 {
+		t.Errorf("events: got %d, want 2", len(webhook.events))
+	}
+}
@@ -1,30 +1,94 @@
 package server
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/quay/release-readiness/internal/requestid"
+	"github.com/quay/release-readiness/internal/tracing"
 )
 
+// requestIDMiddleware accepts a well-formed X-Request-ID from the client or
+// generates one, stores it in the request context, and echoes it back.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// loggingMiddleware writes one access log line per request. The request ID
+// is added by the logger's handler from the request context, as it is for
+// any log line the handlers write. Server errors log at error level.
 func loggingMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
 		next.ServeHTTP(rw, r)
-		logger.Info("http request",
-			"method", r.Method,
-			"path", r.URL.Path,
-			"status", rw.status,
-			"duration", time.Since(start).Round(time.Millisecond),
+		level := slog.LevelInfo
+		if rw.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(r.Context(), level, "http request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rw.status),
+			slog.Int64("bytes", rw.bytes),
+			slog.Duration("latency", time.Since(start).Round(time.Millisecond)),
+			slog.String("remote_addr", r.RemoteAddr),
 		)
 	})
 }
 
+// tracingMiddleware records a server span per request, continuing the
+// caller's trace if it sent a traceparent header. The span is named after
+// the matched route pattern, e.g. "GET /api/v1/releases/{version}".
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tracing.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, span := tracing.StartServer(r, r.Method+" "+r.URL.Path,
+			slog.String("http.request.method", r.Method),
+			slog.String("url.path", r.URL.Path),
+			slog.String("request_id", requestid.FromContext(r.Context())),
+		)
+		defer span.End()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rw, r)
+		if r.Pattern != "" {
+			span.SetName(r.Pattern)
+			span.SetAttributes(slog.String("http.route", r.Pattern))
+		}
+		span.SetAttributes(slog.Int("http.response.status_code", rw.status))
+		if rw.status >= http.StatusInternalServerError {
+			span.RecordError(fmt.Errorf("HTTP %d", rw.status))
+		}
+	})
+}
+
 func recoveryMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				logger.Error("panic recovered", "error", err)
+				logger.ErrorContext(r.Context(), "panic recovered", "error", err)
 				http.Error(w, "internal server error", http.StatusInternalServerError)
 			}
 		}()
@@ -32,12 +96,239 @@ func recoveryMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 	})
 }
 
+// responseWriter records the status and body size for the access log.
 type responseWriter struct {
 	http.ResponseWriter
-	status int
+	status      int
+	bytes       int64
+	wroteHeader bool
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
-	rw.status = code
+	if !rw.wroteHeader {
+		rw.wroteHeader = true
+		rw.status = code
+	}
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// etagMiddleware tags successful JSON and calendar responses with a hash of
+// their body, and answers a matching If-None-Match with 304 Not Modified.
+// The body is still built, usually from the response caches, but pollers
+// such as kiosk displays do not download it again while it is unchanged.
+func etagMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ew := &etagWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+		ew.finish(r)
+	})
+}
+
+// etagWriter holds back a taggable response until its body is complete.
+// Other responses, such as artifact downloads, pass straight through.
+type etagWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	buffering   bool
+	body        bytes.Buffer
+}
+
+func (ew *etagWriter) WriteHeader(code int) {
+	if ew.wroteHeader {
+		return
+	}
+	ew.wroteHeader = true
+	if code == http.StatusOK && taggable(ew.Header()) {
+		ew.buffering = true
+		return
+	}
+	ew.ResponseWriter.WriteHeader(code)
+}
+
+func (ew *etagWriter) Write(p []byte) (int, error) {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.buffering {
+		return ew.body.Write(p)
+	}
+	return ew.ResponseWriter.Write(p)
+}
+
+func (ew *etagWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
+
+func (ew *etagWriter) finish(r *http.Request) {
+	if !ew.buffering {
+		return
+	}
+	sum := sha256.Sum256(ew.body.Bytes())
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	h := ew.Header()
+	h.Set("ETag", tag)
+	if etagMatches(r.Header.Get("If-None-Match"), tag) {
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		ew.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	ew.ResponseWriter.WriteHeader(http.StatusOK)
+	_, _ = ew.ResponseWriter.Write(ew.body.Bytes())
+}
+
+// taggable reports whether a response with header h gets an ETag.
+func taggable(h http.Header) bool {
+	if h.Get("ETag") != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType == "application/json" || mediaType == "text/calendar"
+}
+
+// etagMatches reports whether an If-None-Match header lists tag. As the
+// header asks, weak tags match their strong form.
+func etagMatches(header, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == tag || t == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipMiddleware compresses text responses for clients that accept gzip.
+// Strong ETags are weakened on compressed responses, since the bytes sent
+// no longer match the representation they were computed from.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipWriter{ResponseWriter: w}
+		defer gw.close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// gzipWriter decides on the first write whether to compress, based on the
+// status and headers the handler set by then.
+type gzipWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	gz          *gzip.Writer
+}
+
+func (gw *gzipWriter) WriteHeader(code int) {
+	if gw.wroteHeader {
+		return
+	}
+	gw.wroteHeader = true
+	h := gw.Header()
+	if code == http.StatusOK && compressible(h) {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		if tag := h.Get("ETag"); tag != "" && !strings.HasPrefix(tag, "W/") {
+			h.Set("ETag", "W/"+tag)
+		}
+		gw.gz = gzipWriters.Get().(*gzip.Writer)
+		gw.gz.Reset(gw.ResponseWriter)
+	}
+	gw.ResponseWriter.WriteHeader(code)
+}
+
+func (gw *gzipWriter) Write(p []byte) (int, error) {
+	if !gw.wroteHeader {
+		if gw.Header().Get("Content-Type") == "" {
+			gw.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		gw.WriteHeader(http.StatusOK)
+	}
+	if gw.gz != nil {
+		return gw.gz.Write(p)
+	}
+	return gw.ResponseWriter.Write(p)
+}
+
+// Flush sends whatever has been compressed so far, for streamed responses.
+func (gw *gzipWriter) Flush() {
+	if gw.gz != nil {
+		_ = gw.gz.Flush()
+	}
+	if f, ok := gw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (gw *gzipWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
+
+func (gw *gzipWriter) close() {
+	if gw.gz == nil {
+		return
+	}
+	_ = gw.gz.Close()
+	gzipWriters.Put(gw.gz)
+	gw.gz = nil
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimSpace(params), "=")
+		if !ok || strings.TrimSpace(name) != "q" {
+			return true
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && q > 0
+	}
+	return false
+}
+
+// compressible reports whether a response with header h is worth
+// compressing: text-like media types that are not already encoded.
+func compressible(h http.Header) bool {
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch mediaType {
+	case "application/json", "application/javascript", "text/javascript",
+		"image/svg+xml", "text/calendar", "text/csv", "text/css", "text/html", "text/plain":
+		return true
+	}
+	return false
+}
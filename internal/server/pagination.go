@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setPageHeaders describes a page of a list response: X-Total-Count is the
+// number of items across all pages, and the Link header (RFC 8288) links
+// the first, previous, next and last pages of limit items around the one
+// starting at offset. The links are relative to the request and keep its
+// other query parameters. Without a limit the response is the whole list
+// and only X-Total-Count is set.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, total, limit, offset int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if limit <= 0 {
+		return
+	}
+	link := func(rel string, offset int) string {
+		q := r.URL.Query()
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, q.Encode(), rel)
+	}
+	last := 0
+	if total > 0 {
+		last = (total - 1) / limit * limit
+	}
+	links := []string{link("first", 0)}
+	if offset > 0 {
+		links = append(links, link("prev", max(offset-limit, 0)))
+	}
+	if offset+limit < total {
+		links = append(links, link("next", offset+limit))
+	}
+	links = append(links, link("last", last))
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
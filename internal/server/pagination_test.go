@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSetPageHeaders(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, limit, offset int
+		want                 string
+	}{
+		{"first page", 45, 20, 0,
+			`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="first", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=20>; rel="next", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=40>; rel="last"`},
+		{"middle page", 45, 20, 10,
+			`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="first", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="prev", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=30>; rel="next", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=40>; rel="last"`},
+		{"last page", 40, 20, 20,
+			`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="first", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="prev", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=20>; rel="last"`},
+		{"empty", 0, 20, 0,
+			`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="first", ` +
+				`</api/v1/snapshots?application=quay&limit=20&offset=0>; rel="last"`},
+		{"unpaged", 45, 0, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/v1/snapshots?application=quay&offset=5", nil)
+			setPageHeaders(w, r, tt.total, tt.limit, tt.offset)
+			if got, want := w.Header().Get("X-Total-Count"), strconv.Itoa(tt.total); got != want {
+				t.Errorf("X-Total-Count: got %q, want %q", got, want)
+			}
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link:\n got %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
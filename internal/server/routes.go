@@ -8,22 +8,112 @@ import (
 	"github.com/quay/release-readiness/web"
 )
 
+// registerRoutes wires the API. Reads go through s.read, which is public
+// unless SetPublicReads(false); endpoints that change state need the
+// reporter, release-manager or admin role. The health check, whoami and
+// the SPA are always public.
 func (s *Server) registerRoutes(mux *http.ServeMux) {
 	// Health & Config
 	mux.HandleFunc("GET /api/v1/health", s.handleHealth)
-	mux.HandleFunc("GET /api/v1/config", s.handleConfig)
+	mux.HandleFunc("GET /api/v1/whoami", s.handleWhoAmI)
+	mux.Handle("GET /api/v1/config", s.read(s.handleConfig))
+	mux.Handle("GET /api/v1/version", s.read(s.handleVersion))
+	mux.Handle("GET /api/v1/openapi.json", s.read(s.handleOpenAPISpec))
+	mux.HandleFunc("GET /api/docs", s.handleAPIDocs)
 
 	// Snapshots API
-	mux.HandleFunc("GET /api/v1/snapshots", s.handleListSnapshots)
-	mux.HandleFunc("GET /api/v1/snapshots/{snapshotId}/suites/{suiteId}/artifacts", s.handleDownloadSuiteArtifacts)
+	mux.Handle("GET /api/v1/snapshots", s.read(s.handleListSnapshots))
+	mux.Handle("GET /api/v1/snapshots/{name}", s.read(s.handleGetSnapshot))
+	mux.Handle("GET /api/v1/snapshots/{name}/ec", s.read(s.handleGetSnapshotEC))
+	mux.Handle("POST /api/v1/snapshots/{name}/refresh", s.requireReporter(s.handleRefreshSnapshot))
+	mux.Handle("GET /api/v1/snapshots/{name}/lifecycle", s.read(s.handleGetSnapshotLifecycle))
+	mux.Handle("POST /api/v1/snapshots/{name}/lifecycle", s.requireReleaseManager(s.handleTransitionSnapshot))
+	mux.Handle("GET /api/v1/snapshots/{name}/components/{component}", s.read(s.handleGetSnapshotComponent))
+	mux.Handle("GET /api/v1/snapshots/{snapshotId}/suites/{suiteId}/artifacts", s.read(s.handleDownloadSuiteArtifacts))
+	mux.Handle("GET /api/v1/snapshots/{a}/diff/{b}", s.read(s.handleSnapshotDiff))
+	mux.Handle("GET /api/v1/applications", s.read(s.handleListApplications))
+	mux.Handle("POST /api/v1/applications", s.requireAdmin(s.handleSaveApplication))
+	mux.Handle("GET /api/v1/applications/{app}/pass-rates", s.read(s.handleListScenarioPassRates))
+	mux.Handle("GET /api/v1/applications/{app}/scenarios", s.read(s.handleListScenarioRequirements))
+	mux.Handle("PUT /api/v1/applications/{app}/scenarios/{scenario}", s.requireAdmin(s.handleSetScenarioRequirement))
+	mux.Handle("GET /api/v1/applications/{app}/scenarios/{scenario}/trend", s.read(s.handleGetScenarioTrend))
+	mux.Handle("POST /api/v1/ingest/snapshot", s.requireReporter(s.handleIngestSnapshot))
+	mux.Handle("GET /api/v1/ingest/failures", s.read(s.handleListIngestFailures))
+	mux.Handle("POST /api/v1/ingest/failures/{id}/retry", s.requireAdmin(s.handleRetryIngestFailure))
 
 	// Releases API (version-centric)
-	mux.HandleFunc("GET /api/v1/releases/overview", s.handleReleasesOverview)
-	mux.HandleFunc("GET /api/v1/releases/{version}", s.handleGetRelease)
-	mux.HandleFunc("GET /api/v1/releases/{version}/snapshot", s.handleGetReleaseSnapshot)
-	mux.HandleFunc("GET /api/v1/releases/{version}/issues", s.handleListReleaseIssues)
-	mux.HandleFunc("GET /api/v1/releases/{version}/issues/summary", s.handleGetReleaseIssueSummary)
-	mux.HandleFunc("GET /api/v1/releases/{version}/readiness", s.handleGetReleaseReadiness)
+	mux.Handle("GET /api/v1/releases/overview", s.read(s.handleReleasesOverview))
+	mux.Handle("GET /api/v1/releases/{version}", s.read(s.handleGetRelease))
+	mux.Handle("GET /api/v1/releases/{version}/snapshot", s.read(s.handleGetReleaseSnapshot))
+	mux.Handle("GET /api/v1/releases/{version}/issues", s.read(s.handleListReleaseIssues))
+	mux.Handle("GET /api/v1/releases/{version}/issues/summary", s.read(s.handleGetReleaseIssueSummary))
+	mux.Handle("GET /api/v1/releases/{version}/cves", s.read(s.handleListReleaseCVEs))
+	mux.Handle("GET /api/v1/releases/{version}/readiness", s.read(s.handleGetReleaseReadiness))
+	mux.Handle("GET /api/v1/releases/{version}/report", s.read(s.handleGetReleaseReport))
+	mux.Handle("GET /api/v1/releases/{version}/matrix", s.read(s.handleGetReleaseMatrix))
+	mux.Handle("GET /api/v1/releases/{version}/audit", s.read(s.handleGetReleaseAudit))
+	mux.Handle("GET /api/v1/releases/{version}/history", s.read(s.handleGetReleaseHistory))
+	mux.Handle("GET /api/v1/releases/{version}/scope-changes", s.read(s.handleGetScopeChanges))
+	mux.Handle("GET /api/v1/releases/{version}/backports", s.read(s.handleListReleaseBackports))
+	mux.Handle("GET /api/v1/releases/{version}/blocked-issues", s.read(s.handleListBlockedIssues))
+	mux.Handle("GET /api/v1/releases/{version}/candidates", s.read(s.handleListReleaseCandidates))
+	mux.Handle("PUT /api/v1/releases/{version}/candidates/{snapshot}", s.requireReleaseManager(s.handleSetCandidateState))
+	mux.Handle("POST /api/v1/releases/{version}/candidate", s.requireReleaseManager(s.handlePinCandidate))
+	mux.Handle("GET /api/v1/releases/{version}/approvals", s.read(s.handleListReleaseApprovals))
+	mux.Handle("POST /api/v1/releases/{version}/approvals", s.requireReleaseManager(s.handleCreateReleaseApproval))
+	mux.Handle("GET /api/v1/releases/{version}/overrides", s.read(s.handleListReadinessOverrides))
+	mux.Handle("POST /api/v1/releases/{version}/overrides", s.requireReleaseManager(s.handleCreateReadinessOverride))
+	mux.Handle("DELETE /api/v1/releases/{version}/overrides/{id}", s.requireReleaseManager(s.handleDeleteReadinessOverride))
+	mux.Handle("PUT /api/v1/releases/{version}/audit-hold", s.requireAdmin(s.handleSetAuditHold))
+	mux.Handle("DELETE /api/v1/releases/{version}/audit-hold", s.requireAdmin(s.handleDeleteAuditHold))
+	mux.Handle("POST /api/v1/releases/{version}/archive", s.requireAdmin(s.handleArchiveRelease))
+	mux.Handle("POST /api/v1/releases/{version}/unarchive", s.requireAdmin(s.handleUnarchiveRelease))
+	mux.Handle("GET /api/v1/releases/{version}/advisory", s.read(s.handleGetReleaseAdvisory))
+	mux.Handle("PUT /api/v1/releases/{version}/advisory", s.requireReleaseManager(s.handleSetReleaseAdvisory))
+	mux.Handle("DELETE /api/v1/releases/{version}/advisory", s.requireReleaseManager(s.handleDeleteReleaseAdvisory))
+
+	// Issue buckets
+	mux.Handle("GET /api/v1/issue-buckets", s.read(s.handleListIssueBuckets))
+	mux.Handle("PUT /api/v1/issue-buckets", s.requireAdmin(s.handleSetIssueBuckets))
+
+	// Components
+	mux.Handle("GET /api/v1/components", s.read(s.handleListComponents))
+	mux.Handle("PUT /api/v1/components/{name}/jira-components", s.requireAdmin(s.handleSetComponentJIRAComponents))
+
+	// Search and triage
+	mux.Handle("GET /api/v1/search", s.read(s.handleSearch))
+	mux.Handle("GET /api/v1/failure-groups", s.read(s.handleListFailureGroups))
+
+	// Planning
+	mux.Handle("GET /api/v1/products/{product}/timeline", s.read(s.handleGetProductTimeline))
+
+	// Feeds
+	mux.Handle("GET /feeds/releases.ics", s.read(s.handleReleasesICS))
+	mux.Handle("GET /api/v1/releases/calendar.ics", s.read(s.handleReleasesICS))
+	mux.Handle("GET /api/v1/releases/calendar", s.read(s.handleReleasesCalendar))
+
+	// Email digests
+	mux.Handle("GET /api/v1/digests/subscriptions", s.requireReleaseManager(s.handleListDigestSubscriptions))
+	mux.Handle("POST /api/v1/digests/subscriptions", s.requireReleaseManager(s.handleCreateDigestSubscription))
+	mux.Handle("DELETE /api/v1/digests/subscriptions/{id}", s.requireReleaseManager(s.handleDeleteDigestSubscription))
+
+	// Webhooks authenticate with their own shared secret.
+	mux.HandleFunc("POST /api/v1/webhooks/jira", s.handleJiraWebhook)
+
+	// Sync
+	mux.Handle("GET /api/v1/sync/status", s.read(s.handleSyncStatus))
+	mux.Handle("GET /api/v1/events", s.read(s.handleEvents))
+	mux.Handle("GET /metrics", s.read(s.handleMetrics))
+
+	// Retention
+	mux.Handle("GET /api/v1/retention/preview", s.read(s.handleRetentionPreview))
+	mux.Handle("GET /api/v1/retention/holds", s.read(s.handleListAuditHolds))
+
+	// Admin API
+	mux.Handle("GET /api/v1/admin/log-level", s.requireAdmin(s.handleGetLogLevel))
+	mux.Handle("PUT /api/v1/admin/log-level", s.requireAdmin(s.handleSetLogLevel))
+	mux.Handle("GET /api/v1/admin/app-mapping", s.requireAdmin(s.handleGetAppMapping))
+	mux.Handle("POST /api/v1/admin/app-mapping/test", s.requireAdmin(s.handleTestAppMapping))
 
 	// SPA — serve React app from embedded dist/
 	distSub, _ := fs.Sub(web.DistFS, "dist")
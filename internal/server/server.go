@@ -5,29 +5,114 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
-	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/jira"
+	"github.com/quay/release-readiness/internal/logging"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/runstatus"
 	s3client "github.com/quay/release-readiness/internal/s3"
 )
 
 type Server struct {
-	db          *db.DB
-	s3          *s3client.Client
+	db Store
+	// s3 is the object store of the -s3-* flags and sources those of the
+	// configured sources, by name; snapshots' artifacts are read from the
+	// one they were synced from.
+	s3          s3client.ObjectStore
+	sources     map[string]s3client.ObjectStore
 	http        *http.Server
 	logger      *slog.Logger
 	jiraBaseURL string
 	jiraProject string
+
+	overviewCache *ttlCache[[]model.ReleaseOverview]
+	// candidateCache maps release names to their selected candidate snapshot.
+	candidateCache *ttlCache[map[string]*model.SnapshotRecord]
+
+	policy readinessPolicy
+
+	// freezeWindow is how long before its due date a release enters code
+	// freeze, as shown on the timeline. Zero hides freeze windows.
+	freezeWindow time.Duration
+
+	// issueStaleAfter is how long after their last JIRA sync the issue
+	// summaries of releases still synced are marked stale. Zero never
+	// marks them.
+	issueStaleAfter time.Duration
+
+	// breakers guard external dependencies; reported by /api/v1/sync/status.
+	breakers []*breaker.Breaker
+	// syncers track the background jobs; also reported there.
+	syncers []*runstatus.Tracker
+
+	// tokens, and groups listed in groupsHeader by an authenticating proxy,
+	// grant roles that authorize API calls; endpoints needing a role that
+	// none grants are disabled. privateReads makes read endpoints require
+	// one.
+	tokens       []APIToken
+	groupsHeader string
+	groupRoles   map[string]string
+	privateReads bool
+	logLevels    *logging.Levels
+
+	// ingester stores snapshots pushed to POST /api/v1/ingest/snapshot.
+	ingester SnapshotIngester
+	// reingesters retry the snapshots of each source whose ingestion
+	// failed, keyed by source name.
+	reingesters map[string]SnapshotReingester
+	// refreshers re-read the test results of the snapshots of each
+	// source, keyed by source name.
+	refreshers map[string]SnapshotRefresher
+
+	// jiraWebhook applies JIRA webhook events signed with jiraWebhookSecret,
+	// or carrying it in the query if jiraWebhookQuerySecret is set.
+	jiraWebhook            JiraWebhook
+	jiraWebhookSecret      string
+	jiraWebhookQuerySecret bool
+
+	// appMapping maps fixVersions to S3 applications for the mapping test
+	// API.
+	appMapping *jira.Mapping
+
+	// retention previews snapshot pruning for /api/v1/retention/preview.
+	retention RetentionPlanner
+
+	// changelog lists the pull requests merged between component
+	// revisions in snapshot diffs.
+	changelog Changelog
+
+	// events feeds /api/v1/events; closing is closed on shutdown to end
+	// the open streams.
+	events  *events.Broker
+	closing chan struct{}
 }
 
-func New(database *db.DB, s3c *s3client.Client, addr, jiraBaseURL, jiraProject string, logger *slog.Logger) *Server {
-	s := &Server{db: database, s3: s3c, logger: logger, jiraBaseURL: jiraBaseURL, jiraProject: jiraProject}
+// New creates a Server. s3c may be nil if no object store is configured.
+func New(database Store, s3c s3client.ObjectStore, addr, jiraBaseURL, jiraProject string, logger *slog.Logger) *Server {
+	s := &Server{
+		db:             database,
+		s3:             s3c,
+		logger:         logger,
+		jiraBaseURL:    jiraBaseURL,
+		jiraProject:    jiraProject,
+		overviewCache:  newTTLCache[[]model.ReleaseOverview](cacheTTL),
+		candidateCache: newTTLCache[map[string]*model.SnapshotRecord](cacheTTL),
+		appMapping:     jira.DefaultMapping,
+		closing:        make(chan struct{}),
+	}
 	mux := http.NewServeMux()
 	s.registerRoutes(mux)
 
 	var handler http.Handler = mux
-	handler = loggingMiddleware(logger, handler)
+	handler = gzipMiddleware(handler)
 	handler = recoveryMiddleware(logger, handler)
+	handler = tracingMiddleware(handler)
+	handler = loggingMiddleware(logger, handler)
+	handler = requestIDMiddleware(handler)
 
 	s.http = &http.Server{
 		Addr:         addr,
@@ -36,11 +121,155 @@ func New(database *db.DB, s3c *s3client.Client, addr, jiraBaseURL, jiraProject s
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	s.http.RegisterOnShutdown(func() { close(s.closing) })
 
 	return s
 }
 
+// AddSource registers the object store of a configured source, for
+// artifact downloads of the snapshots synced from it.
+func (s *Server) AddSource(name string, store s3client.ObjectStore) {
+	if s.sources == nil {
+		s.sources = make(map[string]s3client.ObjectStore)
+	}
+	s.sources[name] = store
+}
+
+// SetBreakers registers the circuit breakers reported by the sync status API.
+func (s *Server) SetBreakers(breakers ...*breaker.Breaker) {
+	s.breakers = breakers
+}
+
+// SetSyncers registers the background jobs reported by the sync status
+// API and the metrics.
+func (s *Server) SetSyncers(syncers ...*runstatus.Tracker) {
+	s.syncers = syncers
+}
+
+// SetRequireImageDigests makes readiness withhold green until the component
+// images of a release's latest snapshot have been verified in their registry.
+func (s *Server) SetRequireImageDigests(require bool) {
+	s.policy.requireImageDigests = require
+}
+
+// SetRequireEC makes readiness red while components of a release's latest
+// snapshot fail Enterprise Contract, and withholds green until the snapshot
+// has EC results.
+func (s *Server) SetRequireEC(require bool) {
+	s.policy.requireEC = require
+}
+
+// SetCVESeverityGate makes readiness red while a release has open CVE issues
+// at or above severity (Low, Moderate, Important or Critical). An empty
+// severity disables the gate.
+func (s *Server) SetCVESeverityGate(severity string) error {
+	if severity == "" {
+		s.policy.cveSeverity = ""
+		return nil
+	}
+	rank := severityRank(severity)
+	if rank == 0 {
+		return fmt.Errorf("unknown CVE severity %q (want %s)", severity, strings.Join(cveSeverities, ", "))
+	}
+	s.policy.cveSeverity = cveSeverities[rank-1]
+	return nil
+}
+
+// SetFreezeWindow sets how long before its due date a release enters code
+// freeze.
+func (s *Server) SetFreezeWindow(d time.Duration) {
+	s.freezeWindow = d
+}
+
+// SetIssueStaleAfter sets how long after their last JIRA sync the issue
+// summaries of releases still synced are marked stale.
+func (s *Server) SetIssueStaleAfter(d time.Duration) {
+	s.issueStaleAfter = d
+}
+
+// SetSnapshotIngester enables the snapshot push endpoint.
+func (s *Server) SetSnapshotIngester(ingester SnapshotIngester) {
+	s.ingester = ingester
+}
+
+// SetSnapshotReingester enables re-ingesting the failed snapshots of the
+// named source, which is empty for the default one.
+func (s *Server) SetSnapshotReingester(source string, r SnapshotReingester) {
+	if s.reingesters == nil {
+		s.reingesters = make(map[string]SnapshotReingester)
+	}
+	s.reingesters[source] = r
+}
+
+// SetSnapshotRefresher enables refreshing the test results of the snapshots
+// of the named source, which is empty for the default one.
+func (s *Server) SetSnapshotRefresher(source string, r SnapshotRefresher) {
+	if s.refreshers == nil {
+		s.refreshers = make(map[string]SnapshotRefresher)
+	}
+	s.refreshers[source] = r
+}
+
+// SetJiraWebhook enables the JIRA webhook endpoint for deliveries signed
+// with secret, or, if querySecret is set, passing it as the secret query
+// parameter. An empty secret leaves it disabled.
+func (s *Server) SetJiraWebhook(webhook JiraWebhook, secret string, querySecret bool) {
+	s.jiraWebhook = webhook
+	s.jiraWebhookSecret = secret
+	s.jiraWebhookQuerySecret = querySecret
+}
+
+// SetAppMapping sets the fixVersion to application mapping rules the
+// mapping API reports and tests; they should be the JIRA sync's.
+func (s *Server) SetAppMapping(m *jira.Mapping) {
+	s.appMapping = m
+}
+
+// SetRetention enables the snapshot retention preview endpoint.
+func (s *Server) SetRetention(planner RetentionPlanner) {
+	s.retention = planner
+}
+
+// SetChangelog makes snapshot diffs list the pull requests merged between
+// the revisions of each changed component.
+func (s *Server) SetChangelog(c Changelog) {
+	s.changelog = c
+}
+
+// SetEvents enables the live update stream, relaying the events published
+// on b. Until shutdown, each event also drops the cached overview and
+// candidates: clients refetch on the event, so they must not get the
+// cached reads from before the change.
+func (s *Server) SetEvents(b *events.Broker) {
+	s.events = b
+	events, cancel := b.Subscribe()
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case <-events:
+				s.overviewCache.invalidate()
+				s.candidateCache.invalidate()
+			case <-s.closing:
+				return
+			}
+		}
+	}()
+}
+
+// SetAdmin accepts token as an API token with the admin role, if it is not
+// empty,
+// and lets the admin API change the log levels in levels at runtime.
+func (s *Server) SetAdmin(token string, levels *logging.Levels) {
+	if token != "" {
+		s.tokens = append(s.tokens, APIToken{Name: "admin-token", Token: token, Role: RoleAdmin})
+	}
+	s.logLevels = levels
+}
+
 func (s *Server) Run(ctx context.Context) error {
+	s.warmCaches(ctx)
+
 	go func() {
 		s.logger.Info("listening", "addr", s.http.Addr)
 		if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -60,3 +289,19 @@ func (s *Server) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// warmCaches pre-computes the read models behind the overview page so the
+// first requests after a deploy are served from memory. Failures are logged
+// and otherwise ignored; the caches will load lazily instead.
+func (s *Server) warmCaches(ctx context.Context) {
+	start := time.Now()
+	if _, err := s.selectedCandidates(ctx); err != nil {
+		s.logger.Warn("warm candidate cache", "error", err)
+		return
+	}
+	if _, err := s.releasesOverview(ctx); err != nil {
+		s.logger.Warn("warm releases overview cache", "error", err)
+		return
+	}
+	s.logger.Info("caches warmed", "duration", time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// Store is the read-side persistence contract the HTTP handlers depend on.
+// *db.DB implements it; storetest.Store provides a mock for handler tests.
+type Store interface {
+	Ping() error
+
+	ListSnapshots(ctx context.Context, application string, limit, offset int) ([]model.SnapshotRecord, error)
+	CountSnapshots(ctx context.Context, application string) (int, error)
+	GetSnapshotByName(ctx context.Context, name string) (*model.SnapshotRecord, error)
+	GetSnapshotByID(ctx context.Context, id int64) (*model.SnapshotRecord, error)
+	TransitionSnapshot(ctx context.Context, snapshotID int64, t *model.LifecycleTransition) error
+	ListLifecycleTransitions(ctx context.Context, snapshotID int64) ([]model.LifecycleTransition, error)
+	GetTestSuiteByID(ctx context.Context, id int64) (*model.TestSuiteMeta, error)
+	GetECReport(ctx context.Context, name string) (*model.ECReport, error)
+	ListScenarioRuns(ctx context.Context, application, scenario string, limit int) ([]model.ScenarioRun, error)
+	ListScenarioPassRates(ctx context.Context, application string, windows []int, now time.Time) ([]model.ScenarioPassRate, error)
+	ListScenarioRequirements(ctx context.Context, application string) ([]model.ScenarioRequirement, error)
+	ListTestFailures(ctx context.Context, application string, since time.Time) ([]model.TestFailure, error)
+	LatestSnapshotPerApplication(ctx context.Context) ([]model.ApplicationSummary, error)
+	ListApplicationConfigs(ctx context.Context) ([]model.ApplicationConfig, error)
+	SaveApplicationConfig(ctx context.Context, c *model.ApplicationConfig) error
+	SetScenarioRequirement(ctx context.Context, r *model.ScenarioRequirement) error
+	ListIngestFailures(ctx context.Context) ([]model.IngestFailure, error)
+	GetIngestFailure(ctx context.Context, id int64) (*model.IngestFailure, error)
+
+	GetReleaseVersion(ctx context.Context, name string) (*model.ReleaseVersion, error)
+	ListAllReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error)
+	SetReleaseVersionHidden(ctx context.Context, name string, hidden bool) error
+
+	ListJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) ([]model.JiraIssueRecord, error)
+	CountJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) (int, error)
+	ListReleaseCVEs(ctx context.Context, fixVersion string) ([]model.JiraIssueRecord, error)
+	GetIssueSummary(ctx context.Context, fixVersion string) (*model.IssueSummary, error)
+	GetIssueSummariesBatch(ctx context.Context, fixVersions []string) (map[string]*model.IssueSummary, error)
+	ListJiraSyncStates(ctx context.Context) (map[string]model.JiraSyncState, error)
+
+	GetReleaseAudit(ctx context.Context, release string) (*model.ReleaseAudit, error)
+
+	ListReleaseCandidates(ctx context.Context, release, application string, limit int) ([]model.ReleaseCandidate, error)
+	ListSelectedCandidates(ctx context.Context) (map[string]*model.SnapshotRecord, error)
+	SetCandidateState(ctx context.Context, release string, snapshotID int64, state, changedBy string) error
+
+	CreateReleaseApproval(ctx context.Context, a *model.Approval) error
+	ListReleaseApprovals(ctx context.Context, release string) ([]model.Approval, error)
+	ListApprovedRoles(ctx context.Context) (map[string][]string, error)
+
+	CreateReadinessOverride(ctx context.Context, o *model.ReadinessOverride) error
+	ListReadinessOverrides(ctx context.Context, release string) ([]model.ReadinessOverride, error)
+	ListActiveReadinessOverrides(ctx context.Context) (map[string][]model.ReadinessOverride, error)
+	DeleteReadinessOverride(ctx context.Context, release string, id int64) error
+
+	CreateDigestSubscription(ctx context.Context, sub *model.DigestSubscription) error
+	ListDigestSubscriptions(ctx context.Context) ([]model.DigestSubscription, error)
+	DeleteDigestSubscription(ctx context.Context, id int64) error
+
+	ListIssueBuckets(ctx context.Context) ([]model.IssueBucket, error)
+	ReplaceIssueBuckets(ctx context.Context, buckets []model.IssueBucket) error
+
+	ListComponents(ctx context.Context) ([]model.Component, error)
+	GetComponentByName(ctx context.Context, name string) (*model.Component, error)
+	SetComponentJIRAComponents(ctx context.Context, component string, jiraComponents []string) error
+
+	ListReadinessHistory(ctx context.Context, release string) ([]model.ReadinessPoint, error)
+	ListScopeChanges(ctx context.Context, fixVersion string) ([]model.ScopeChange, error)
+
+	ListAuditHolds(ctx context.Context) ([]model.AuditHold, error)
+	SetAuditHold(ctx context.Context, release, reason string) (*model.AuditHold, error)
+	DeleteAuditHold(ctx context.Context, release string) error
+
+	ListReleaseAdvisories(ctx context.Context) ([]model.Advisory, error)
+	GetReleaseAdvisory(ctx context.Context, release string) (*model.Advisory, error)
+	SetReleaseAdvisory(ctx context.Context, release string, advisoryID int64) (*model.Advisory, error)
+	DeleteReleaseAdvisory(ctx context.Context, release string) error
+
+	Search(ctx context.Context, query string, limit int) ([]model.SearchResult, error)
+}
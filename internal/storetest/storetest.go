@@ -0,0 +1,841 @@
+// Package storetest provides a function-field mock of the persistence
+// contracts used by the server, syncers, demo generator, release auditor,
+// image verifier, notifier, digester, history recorder, snapshot pruner, and
+// webhook dispatcher (server.Store, s3.Store, jira.Store, demo.Store,
+// gitaudit.Store, registry.Store, notify.Store, digest.Store, history.Store,
+// retention.Store, webhooks.Store). Set the func field for each method a test
+// expects to be called; calling a method whose field is nil returns
+// ErrUnexpectedCall so that tests notice unplanned database access.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ErrUnexpectedCall is returned by methods whose func field is not set.
+var ErrUnexpectedCall = errors.New("storetest: unexpected call")
+
+// Store is a mock store. The zero value rejects every call.
+type Store struct {
+	PingFunc func() error
+
+	ListSnapshotsFunc                func(ctx context.Context, application string, limit, offset int) ([]model.SnapshotRecord, error)
+	CountSnapshotsFunc               func(ctx context.Context, application string) (int, error)
+	GetSnapshotByNameFunc            func(ctx context.Context, name string) (*model.SnapshotRecord, error)
+	GetSnapshotByIDFunc              func(ctx context.Context, id int64) (*model.SnapshotRecord, error)
+	TransitionSnapshotFunc           func(ctx context.Context, snapshotID int64, t *model.LifecycleTransition) error
+	ListLifecycleTransitionsFunc     func(ctx context.Context, snapshotID int64) ([]model.LifecycleTransition, error)
+	GetTestSuiteByIDFunc             func(ctx context.Context, id int64) (*model.TestSuiteMeta, error)
+	GetECReportFunc                  func(ctx context.Context, name string) (*model.ECReport, error)
+	ListScenarioRunsFunc             func(ctx context.Context, application, scenario string, limit int) ([]model.ScenarioRun, error)
+	ListScenarioPassRatesFunc        func(ctx context.Context, application string, windows []int, now time.Time) ([]model.ScenarioPassRate, error)
+	SetScenarioRequirementFunc       func(ctx context.Context, r *model.ScenarioRequirement) error
+	LatestSnapshotPerApplicationFunc func(ctx context.Context) ([]model.ApplicationSummary, error)
+	ListApplicationConfigsFunc       func(ctx context.Context) ([]model.ApplicationConfig, error)
+	SaveApplicationConfigFunc        func(ctx context.Context, c *model.ApplicationConfig) error
+	SnapshotExistsByNameFunc         func(ctx context.Context, name string) (bool, error)
+	SaveSnapshotFunc                 func(ctx context.Context, snap *model.SnapshotRecord) error
+	ListS3SyncStatesFunc             func(ctx context.Context) (map[string]string, error)
+	SaveS3SyncStateFunc              func(ctx context.Context, key, etag string) error
+	SaveSnapshotReleaseFunc          func(ctx context.Context, r *model.SnapshotRelease) error
+	ListScenarioRequirementsFunc     func(ctx context.Context, application string) ([]model.ScenarioRequirement, error)
+	ListTestFailuresFunc             func(ctx context.Context, application string, since time.Time) ([]model.TestFailure, error)
+	ListIngestFailuresFunc           func(ctx context.Context) ([]model.IngestFailure, error)
+	GetIngestFailureFunc             func(ctx context.Context, id int64) (*model.IngestFailure, error)
+	RecordIngestFailureFunc          func(ctx context.Context, f *model.IngestFailure, maxAttempts int) error
+	ClearIngestFailureFunc           func(ctx context.Context, source, key string) error
+	ListSnapshotsSinceFunc           func(ctx context.Context, source string, since time.Time) ([]model.SnapshotRecord, error)
+	ListTestSuitesFunc               func(ctx context.Context, snapshotID int64) ([]model.TestSuite, error)
+	RefreshTestSuitesFunc            func(ctx context.Context, snapshotID int64, suites []model.TestSuite) ([]string, error)
+	CreateSnapshotFunc               func(ctx context.Context, application, name string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error)
+	EnsureComponentFunc              func(ctx context.Context, name string) (*model.Component, error)
+	CreateSnapshotComponentFunc      func(ctx context.Context, snapshotID int64, component, gitSHA, imageURL, gitURL string) error
+	CreateTestSuiteFunc              func(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64, truncated bool) (int64, error)
+	CreateTestCaseFunc               func(ctx context.Context, testSuiteID int64, name, status string, durationMs float64, message, trace, filePath, suite string, retries int, flaky bool) error
+	CreateVulnerabilityReportFunc    func(ctx context.Context, snapshotID int64, component, arch string, total, critical, high, medium, low, unknown, fixable int) (int64, error)
+	CreateVulnerabilityFunc          func(ctx context.Context, reportID int64, name, severity, packageName, packageVersion, fixedInVersion, description, link string) error
+
+	GetReleaseVersionFunc         func(ctx context.Context, name string) (*model.ReleaseVersion, error)
+	ListAllReleaseVersionsFunc    func(ctx context.Context) ([]model.ReleaseVersion, error)
+	ListActiveReleaseVersionsFunc func(ctx context.Context) ([]model.ReleaseVersion, error)
+	ListHiddenReleaseVersionsFunc func(ctx context.Context) ([]string, error)
+	SetReleaseVersionHiddenFunc   func(ctx context.Context, name string, hidden bool) error
+	UpsertReleaseVersionFunc      func(ctx context.Context, v *model.ReleaseVersion) error
+
+	ListJiraIssuesFunc         func(ctx context.Context, fixVersion string, filter model.IssueFilter) ([]model.JiraIssueRecord, error)
+	CountJiraIssuesFunc        func(ctx context.Context, fixVersion string, filter model.IssueFilter) (int, error)
+	ListReleaseCVEsFunc        func(ctx context.Context, fixVersion string) ([]model.JiraIssueRecord, error)
+	GetIssueSummaryFunc        func(ctx context.Context, fixVersion string) (*model.IssueSummary, error)
+	GetIssueSummariesBatchFunc func(ctx context.Context, fixVersions []string) (map[string]*model.IssueSummary, error)
+	UpsertJiraIssueFunc        func(ctx context.Context, issue *model.JiraIssueRecord) error
+	DeleteJiraIssuesNotInFunc  func(ctx context.Context, fixVersion string, keys []string) error
+	ListJiraIssueVersionsFunc  func(ctx context.Context, key string) ([]string, error)
+	DeleteJiraIssueFunc        func(ctx context.Context, key, fixVersion string) error
+	ArchiveReleaseIssuesFunc   func(ctx context.Context, fixVersion string) (bool, error)
+	ListJiraSyncStatesFunc     func(ctx context.Context) (map[string]model.JiraSyncState, error)
+	SaveJiraSyncStateFunc      func(ctx context.Context, state model.JiraSyncState) error
+
+	LatestSnapshotBeforeFunc   func(ctx context.Context, application string, before time.Time) (*model.SnapshotRecord, error)
+	ListSnapshotComponentsFunc func(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error)
+	GetReleaseAuditFunc        func(ctx context.Context, release string) (*model.ReleaseAudit, error)
+	ReleaseAuditExistsFunc     func(ctx context.Context, release string) (bool, error)
+	SaveReleaseAuditFunc       func(ctx context.Context, audit *model.ReleaseAudit) error
+
+	SaveImageVerificationsFunc func(ctx context.Context, snapshotID int64, results []model.ImageVerification) error
+
+	ListReleaseCandidatesFunc  func(ctx context.Context, release, application string, limit int) ([]model.ReleaseCandidate, error)
+	ListSelectedCandidatesFunc func(ctx context.Context) (map[string]*model.SnapshotRecord, error)
+	SetCandidateStateFunc      func(ctx context.Context, release string, snapshotID int64, state, changedBy string) error
+
+	CreateReleaseApprovalFunc func(ctx context.Context, a *model.Approval) error
+	ListReleaseApprovalsFunc  func(ctx context.Context, release string) ([]model.Approval, error)
+	ListApprovedRolesFunc     func(ctx context.Context) (map[string][]string, error)
+
+	CreateReadinessOverrideFunc      func(ctx context.Context, o *model.ReadinessOverride) error
+	ListReadinessOverridesFunc       func(ctx context.Context, release string) ([]model.ReadinessOverride, error)
+	ListActiveReadinessOverridesFunc func(ctx context.Context) (map[string][]model.ReadinessOverride, error)
+	DeleteReadinessOverrideFunc      func(ctx context.Context, release string, id int64) error
+
+	ListIssueBucketsFunc    func(ctx context.Context) ([]model.IssueBucket, error)
+	ReplaceIssueBucketsFunc func(ctx context.Context, buckets []model.IssueBucket) error
+
+	ListComponentsFunc             func(ctx context.Context) ([]model.Component, error)
+	GetComponentByNameFunc         func(ctx context.Context, name string) (*model.Component, error)
+	SetComponentJIRAComponentsFunc func(ctx context.Context, component string, jiraComponents []string) error
+
+	ListNotificationStatesFunc func(ctx context.Context) (map[string]model.NotificationState, error)
+	SaveNotificationStateFunc  func(ctx context.Context, state model.NotificationState) error
+
+	CreateDigestSubscriptionFunc func(ctx context.Context, sub *model.DigestSubscription) error
+	ListDigestSubscriptionsFunc  func(ctx context.Context) ([]model.DigestSubscription, error)
+	DeleteDigestSubscriptionFunc func(ctx context.Context, id int64) error
+	ListDigestStatesFunc         func(ctx context.Context, frequency string) (map[string]model.DigestState, error)
+	SaveDigestStateFunc          func(ctx context.Context, state model.DigestState) error
+
+	ListWebhookStatesFunc func(ctx context.Context) (map[string]model.WebhookState, error)
+	SaveWebhookStateFunc  func(ctx context.Context, state model.WebhookState) error
+
+	CreateReadinessPointFunc  func(ctx context.Context, p *model.ReadinessPoint) error
+	ListReadinessHistoryFunc  func(ctx context.Context, release string) ([]model.ReadinessPoint, error)
+	LatestReadinessPointsFunc func(ctx context.Context) (map[string]model.ReadinessPoint, error)
+	ListScopeChangesFunc      func(ctx context.Context, fixVersion string) ([]model.ScopeChange, error)
+
+	ListRetentionSnapshotsFunc func(ctx context.Context) ([]model.SnapshotRecord, error)
+	ListCandidateStatesFunc    func(ctx context.Context) (map[string]map[int64]string, error)
+	DeleteSnapshotsFunc        func(ctx context.Context, ids []int64) error
+	ListAuditHoldsFunc         func(ctx context.Context) ([]model.AuditHold, error)
+	SetAuditHoldFunc           func(ctx context.Context, release, reason string) (*model.AuditHold, error)
+	DeleteAuditHoldFunc        func(ctx context.Context, release string) error
+
+	ListReleaseAdvisoriesFunc func(ctx context.Context) ([]model.Advisory, error)
+	GetReleaseAdvisoryFunc    func(ctx context.Context, release string) (*model.Advisory, error)
+	SetReleaseAdvisoryFunc    func(ctx context.Context, release string, advisoryID int64) (*model.Advisory, error)
+	DeleteReleaseAdvisoryFunc func(ctx context.Context, release string) error
+
+	SearchFunc func(ctx context.Context, query string, limit int) ([]model.SearchResult, error)
+}
+
+func (s *Store) Ping() error {
+	if s.PingFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.PingFunc()
+}
+
+// --- Snapshots ---
+
+func (s *Store) ListSnapshots(ctx context.Context, application string, limit, offset int) ([]model.SnapshotRecord, error) {
+	if s.ListSnapshotsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListSnapshotsFunc(ctx, application, limit, offset)
+}
+
+func (s *Store) CountSnapshots(ctx context.Context, application string) (int, error) {
+	if s.CountSnapshotsFunc == nil {
+		return 0, ErrUnexpectedCall
+	}
+	return s.CountSnapshotsFunc(ctx, application)
+}
+
+func (s *Store) GetSnapshotByName(ctx context.Context, name string) (*model.SnapshotRecord, error) {
+	if s.GetSnapshotByNameFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetSnapshotByNameFunc(ctx, name)
+}
+
+func (s *Store) GetSnapshotByID(ctx context.Context, id int64) (*model.SnapshotRecord, error) {
+	if s.GetSnapshotByIDFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetSnapshotByIDFunc(ctx, id)
+}
+
+func (s *Store) TransitionSnapshot(ctx context.Context, snapshotID int64, t *model.LifecycleTransition) error {
+	if s.TransitionSnapshotFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.TransitionSnapshotFunc(ctx, snapshotID, t)
+}
+
+func (s *Store) ListLifecycleTransitions(ctx context.Context, snapshotID int64) ([]model.LifecycleTransition, error) {
+	if s.ListLifecycleTransitionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListLifecycleTransitionsFunc(ctx, snapshotID)
+}
+
+func (s *Store) GetTestSuiteByID(ctx context.Context, id int64) (*model.TestSuiteMeta, error) {
+	if s.GetTestSuiteByIDFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetTestSuiteByIDFunc(ctx, id)
+}
+
+func (s *Store) ListScenarioRuns(ctx context.Context, application, scenario string, limit int) ([]model.ScenarioRun, error) {
+	if s.ListScenarioRunsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListScenarioRunsFunc(ctx, application, scenario, limit)
+}
+
+func (s *Store) ListScenarioPassRates(ctx context.Context, application string, windows []int, now time.Time) ([]model.ScenarioPassRate, error) {
+	if s.ListScenarioPassRatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListScenarioPassRatesFunc(ctx, application, windows, now)
+}
+
+func (s *Store) SetScenarioRequirement(ctx context.Context, r *model.ScenarioRequirement) error {
+	if s.SetScenarioRequirementFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SetScenarioRequirementFunc(ctx, r)
+}
+
+func (s *Store) LatestSnapshotPerApplication(ctx context.Context) ([]model.ApplicationSummary, error) {
+	if s.LatestSnapshotPerApplicationFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.LatestSnapshotPerApplicationFunc(ctx)
+}
+
+func (s *Store) ListApplicationConfigs(ctx context.Context) ([]model.ApplicationConfig, error) {
+	if s.ListApplicationConfigsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListApplicationConfigsFunc(ctx)
+}
+
+func (s *Store) SaveApplicationConfig(ctx context.Context, c *model.ApplicationConfig) error {
+	if s.SaveApplicationConfigFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveApplicationConfigFunc(ctx, c)
+}
+
+func (s *Store) GetECReport(ctx context.Context, name string) (*model.ECReport, error) {
+	if s.GetECReportFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetECReportFunc(ctx, name)
+}
+
+func (s *Store) SnapshotExistsByName(ctx context.Context, name string) (bool, error) {
+	if s.SnapshotExistsByNameFunc == nil {
+		return false, ErrUnexpectedCall
+	}
+	return s.SnapshotExistsByNameFunc(ctx, name)
+}
+
+func (s *Store) SaveSnapshot(ctx context.Context, snap *model.SnapshotRecord) error {
+	if s.SaveSnapshotFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveSnapshotFunc(ctx, snap)
+}
+
+func (s *Store) ListS3SyncStates(ctx context.Context) (map[string]string, error) {
+	if s.ListS3SyncStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListS3SyncStatesFunc(ctx)
+}
+
+func (s *Store) SaveS3SyncState(ctx context.Context, key, etag string) error {
+	if s.SaveS3SyncStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveS3SyncStateFunc(ctx, key, etag)
+}
+
+func (s *Store) SaveSnapshotRelease(ctx context.Context, r *model.SnapshotRelease) error {
+	if s.SaveSnapshotReleaseFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveSnapshotReleaseFunc(ctx, r)
+}
+
+func (s *Store) ListScenarioRequirements(ctx context.Context, application string) ([]model.ScenarioRequirement, error) {
+	if s.ListScenarioRequirementsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListScenarioRequirementsFunc(ctx, application)
+}
+
+func (s *Store) ListTestFailures(ctx context.Context, application string, since time.Time) ([]model.TestFailure, error) {
+	if s.ListTestFailuresFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListTestFailuresFunc(ctx, application, since)
+}
+
+func (s *Store) ListIngestFailures(ctx context.Context) ([]model.IngestFailure, error) {
+	if s.ListIngestFailuresFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListIngestFailuresFunc(ctx)
+}
+
+func (s *Store) GetIngestFailure(ctx context.Context, id int64) (*model.IngestFailure, error) {
+	if s.GetIngestFailureFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetIngestFailureFunc(ctx, id)
+}
+
+func (s *Store) RecordIngestFailure(ctx context.Context, f *model.IngestFailure, maxAttempts int) error {
+	if s.RecordIngestFailureFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.RecordIngestFailureFunc(ctx, f, maxAttempts)
+}
+
+func (s *Store) ClearIngestFailure(ctx context.Context, source, key string) error {
+	if s.ClearIngestFailureFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.ClearIngestFailureFunc(ctx, source, key)
+}
+
+func (s *Store) ListSnapshotsSince(ctx context.Context, source string, since time.Time) ([]model.SnapshotRecord, error) {
+	if s.ListSnapshotsSinceFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListSnapshotsSinceFunc(ctx, source, since)
+}
+
+func (s *Store) ListTestSuites(ctx context.Context, snapshotID int64) ([]model.TestSuite, error) {
+	if s.ListTestSuitesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListTestSuitesFunc(ctx, snapshotID)
+}
+
+func (s *Store) RefreshTestSuites(ctx context.Context, snapshotID int64, suites []model.TestSuite) ([]string, error) {
+	if s.RefreshTestSuitesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.RefreshTestSuitesFunc(ctx, snapshotID, suites)
+}
+
+func (s *Store) CreateSnapshot(ctx context.Context, application, name string, testsPassed bool, createdAt time.Time) (*model.SnapshotRecord, error) {
+	if s.CreateSnapshotFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.CreateSnapshotFunc(ctx, application, name, testsPassed, createdAt)
+}
+
+func (s *Store) EnsureComponent(ctx context.Context, name string) (*model.Component, error) {
+	if s.EnsureComponentFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.EnsureComponentFunc(ctx, name)
+}
+
+func (s *Store) CreateSnapshotComponent(ctx context.Context, snapshotID int64, component, gitSHA, imageURL, gitURL string) error {
+	if s.CreateSnapshotComponentFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateSnapshotComponentFunc(ctx, snapshotID, component, gitSHA, imageURL, gitURL)
+}
+
+func (s *Store) CreateTestSuite(ctx context.Context, snapshotID int64, name, status, pipelineRun, toolName, toolVersion string, tests, passed, failed, skipped, pending, other, flaky int, startTime, stopTime, durationMs int64, truncated bool) (int64, error) {
+	if s.CreateTestSuiteFunc == nil {
+		return 0, ErrUnexpectedCall
+	}
+	return s.CreateTestSuiteFunc(ctx, snapshotID, name, status, pipelineRun, toolName, toolVersion, tests, passed, failed, skipped, pending, other, flaky, startTime, stopTime, durationMs, truncated)
+}
+
+func (s *Store) CreateTestCase(ctx context.Context, testSuiteID int64, name, status string, durationMs float64, message, trace, filePath, suite string, retries int, flaky bool) error {
+	if s.CreateTestCaseFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateTestCaseFunc(ctx, testSuiteID, name, status, durationMs, message, trace, filePath, suite, retries, flaky)
+}
+
+func (s *Store) CreateVulnerabilityReport(ctx context.Context, snapshotID int64, component, arch string, total, critical, high, medium, low, unknown, fixable int) (int64, error) {
+	if s.CreateVulnerabilityReportFunc == nil {
+		return 0, ErrUnexpectedCall
+	}
+	return s.CreateVulnerabilityReportFunc(ctx, snapshotID, component, arch, total, critical, high, medium, low, unknown, fixable)
+}
+
+func (s *Store) CreateVulnerability(ctx context.Context, reportID int64, name, severity, packageName, packageVersion, fixedInVersion, description, link string) error {
+	if s.CreateVulnerabilityFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateVulnerabilityFunc(ctx, reportID, name, severity, packageName, packageVersion, fixedInVersion, description, link)
+}
+
+// --- Releases ---
+
+func (s *Store) GetReleaseVersion(ctx context.Context, name string) (*model.ReleaseVersion, error) {
+	if s.GetReleaseVersionFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetReleaseVersionFunc(ctx, name)
+}
+
+func (s *Store) ListAllReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error) {
+	if s.ListAllReleaseVersionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListAllReleaseVersionsFunc(ctx)
+}
+
+func (s *Store) ListActiveReleaseVersions(ctx context.Context) ([]model.ReleaseVersion, error) {
+	if s.ListActiveReleaseVersionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListActiveReleaseVersionsFunc(ctx)
+}
+
+func (s *Store) ListHiddenReleaseVersions(ctx context.Context) ([]string, error) {
+	if s.ListHiddenReleaseVersionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListHiddenReleaseVersionsFunc(ctx)
+}
+
+func (s *Store) SetReleaseVersionHidden(ctx context.Context, name string, hidden bool) error {
+	if s.SetReleaseVersionHiddenFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SetReleaseVersionHiddenFunc(ctx, name, hidden)
+}
+
+func (s *Store) UpsertReleaseVersion(ctx context.Context, v *model.ReleaseVersion) error {
+	if s.UpsertReleaseVersionFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.UpsertReleaseVersionFunc(ctx, v)
+}
+
+// --- JIRA issues ---
+
+func (s *Store) ListJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) ([]model.JiraIssueRecord, error) {
+	if s.ListJiraIssuesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListJiraIssuesFunc(ctx, fixVersion, filter)
+}
+
+func (s *Store) CountJiraIssues(ctx context.Context, fixVersion string, filter model.IssueFilter) (int, error) {
+	if s.CountJiraIssuesFunc == nil {
+		return 0, ErrUnexpectedCall
+	}
+	return s.CountJiraIssuesFunc(ctx, fixVersion, filter)
+}
+
+func (s *Store) ListReleaseCVEs(ctx context.Context, fixVersion string) ([]model.JiraIssueRecord, error) {
+	if s.ListReleaseCVEsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReleaseCVEsFunc(ctx, fixVersion)
+}
+
+func (s *Store) GetIssueSummary(ctx context.Context, fixVersion string) (*model.IssueSummary, error) {
+	if s.GetIssueSummaryFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetIssueSummaryFunc(ctx, fixVersion)
+}
+
+func (s *Store) GetIssueSummariesBatch(ctx context.Context, fixVersions []string) (map[string]*model.IssueSummary, error) {
+	if s.GetIssueSummariesBatchFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetIssueSummariesBatchFunc(ctx, fixVersions)
+}
+
+func (s *Store) UpsertJiraIssue(ctx context.Context, issue *model.JiraIssueRecord) error {
+	if s.UpsertJiraIssueFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.UpsertJiraIssueFunc(ctx, issue)
+}
+
+func (s *Store) DeleteJiraIssuesNotIn(ctx context.Context, fixVersion string, keys []string) error {
+	if s.DeleteJiraIssuesNotInFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteJiraIssuesNotInFunc(ctx, fixVersion, keys)
+}
+
+func (s *Store) ListJiraIssueVersions(ctx context.Context, key string) ([]string, error) {
+	if s.ListJiraIssueVersionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListJiraIssueVersionsFunc(ctx, key)
+}
+
+func (s *Store) DeleteJiraIssue(ctx context.Context, key, fixVersion string) error {
+	if s.DeleteJiraIssueFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteJiraIssueFunc(ctx, key, fixVersion)
+}
+
+func (s *Store) ArchiveReleaseIssues(ctx context.Context, fixVersion string) (bool, error) {
+	if s.ArchiveReleaseIssuesFunc == nil {
+		return false, ErrUnexpectedCall
+	}
+	return s.ArchiveReleaseIssuesFunc(ctx, fixVersion)
+}
+
+func (s *Store) ListJiraSyncStates(ctx context.Context) (map[string]model.JiraSyncState, error) {
+	if s.ListJiraSyncStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListJiraSyncStatesFunc(ctx)
+}
+
+func (s *Store) SaveJiraSyncState(ctx context.Context, state model.JiraSyncState) error {
+	if s.SaveJiraSyncStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveJiraSyncStateFunc(ctx, state)
+}
+
+// --- Release audits ---
+
+func (s *Store) LatestSnapshotBefore(ctx context.Context, application string, before time.Time) (*model.SnapshotRecord, error) {
+	if s.LatestSnapshotBeforeFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.LatestSnapshotBeforeFunc(ctx, application, before)
+}
+
+func (s *Store) ListSnapshotComponents(ctx context.Context, snapshotID int64) ([]model.ComponentRecord, error) {
+	if s.ListSnapshotComponentsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListSnapshotComponentsFunc(ctx, snapshotID)
+}
+
+func (s *Store) GetReleaseAudit(ctx context.Context, release string) (*model.ReleaseAudit, error) {
+	if s.GetReleaseAuditFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetReleaseAuditFunc(ctx, release)
+}
+
+func (s *Store) ReleaseAuditExists(ctx context.Context, release string) (bool, error) {
+	if s.ReleaseAuditExistsFunc == nil {
+		return false, ErrUnexpectedCall
+	}
+	return s.ReleaseAuditExistsFunc(ctx, release)
+}
+
+func (s *Store) SaveReleaseAudit(ctx context.Context, audit *model.ReleaseAudit) error {
+	if s.SaveReleaseAuditFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveReleaseAuditFunc(ctx, audit)
+}
+
+func (s *Store) SaveImageVerifications(ctx context.Context, snapshotID int64, results []model.ImageVerification) error {
+	if s.SaveImageVerificationsFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveImageVerificationsFunc(ctx, snapshotID, results)
+}
+
+func (s *Store) ListReleaseCandidates(ctx context.Context, release, application string, limit int) ([]model.ReleaseCandidate, error) {
+	if s.ListReleaseCandidatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReleaseCandidatesFunc(ctx, release, application, limit)
+}
+
+func (s *Store) ListSelectedCandidates(ctx context.Context) (map[string]*model.SnapshotRecord, error) {
+	if s.ListSelectedCandidatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListSelectedCandidatesFunc(ctx)
+}
+
+func (s *Store) SetCandidateState(ctx context.Context, release string, snapshotID int64, state, changedBy string) error {
+	if s.SetCandidateStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SetCandidateStateFunc(ctx, release, snapshotID, state, changedBy)
+}
+
+func (s *Store) CreateReleaseApproval(ctx context.Context, a *model.Approval) error {
+	if s.CreateReleaseApprovalFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateReleaseApprovalFunc(ctx, a)
+}
+
+func (s *Store) ListReleaseApprovals(ctx context.Context, release string) ([]model.Approval, error) {
+	if s.ListReleaseApprovalsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReleaseApprovalsFunc(ctx, release)
+}
+
+func (s *Store) ListApprovedRoles(ctx context.Context) (map[string][]string, error) {
+	if s.ListApprovedRolesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListApprovedRolesFunc(ctx)
+}
+
+func (s *Store) CreateReadinessOverride(ctx context.Context, o *model.ReadinessOverride) error {
+	if s.CreateReadinessOverrideFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateReadinessOverrideFunc(ctx, o)
+}
+
+func (s *Store) ListReadinessOverrides(ctx context.Context, release string) ([]model.ReadinessOverride, error) {
+	if s.ListReadinessOverridesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReadinessOverridesFunc(ctx, release)
+}
+
+func (s *Store) ListActiveReadinessOverrides(ctx context.Context) (map[string][]model.ReadinessOverride, error) {
+	if s.ListActiveReadinessOverridesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListActiveReadinessOverridesFunc(ctx)
+}
+
+func (s *Store) DeleteReadinessOverride(ctx context.Context, release string, id int64) error {
+	if s.DeleteReadinessOverrideFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteReadinessOverrideFunc(ctx, release, id)
+}
+
+func (s *Store) ListIssueBuckets(ctx context.Context) ([]model.IssueBucket, error) {
+	if s.ListIssueBucketsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListIssueBucketsFunc(ctx)
+}
+
+func (s *Store) ReplaceIssueBuckets(ctx context.Context, buckets []model.IssueBucket) error {
+	if s.ReplaceIssueBucketsFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.ReplaceIssueBucketsFunc(ctx, buckets)
+}
+
+func (s *Store) ListComponents(ctx context.Context) ([]model.Component, error) {
+	if s.ListComponentsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListComponentsFunc(ctx)
+}
+
+func (s *Store) GetComponentByName(ctx context.Context, name string) (*model.Component, error) {
+	if s.GetComponentByNameFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetComponentByNameFunc(ctx, name)
+}
+
+func (s *Store) SetComponentJIRAComponents(ctx context.Context, component string, jiraComponents []string) error {
+	if s.SetComponentJIRAComponentsFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SetComponentJIRAComponentsFunc(ctx, component, jiraComponents)
+}
+
+func (s *Store) ListNotificationStates(ctx context.Context) (map[string]model.NotificationState, error) {
+	if s.ListNotificationStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListNotificationStatesFunc(ctx)
+}
+
+func (s *Store) SaveNotificationState(ctx context.Context, state model.NotificationState) error {
+	if s.SaveNotificationStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveNotificationStateFunc(ctx, state)
+}
+
+func (s *Store) CreateDigestSubscription(ctx context.Context, sub *model.DigestSubscription) error {
+	if s.CreateDigestSubscriptionFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateDigestSubscriptionFunc(ctx, sub)
+}
+
+func (s *Store) ListDigestSubscriptions(ctx context.Context) ([]model.DigestSubscription, error) {
+	if s.ListDigestSubscriptionsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListDigestSubscriptionsFunc(ctx)
+}
+
+func (s *Store) DeleteDigestSubscription(ctx context.Context, id int64) error {
+	if s.DeleteDigestSubscriptionFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteDigestSubscriptionFunc(ctx, id)
+}
+
+func (s *Store) ListDigestStates(ctx context.Context, frequency string) (map[string]model.DigestState, error) {
+	if s.ListDigestStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListDigestStatesFunc(ctx, frequency)
+}
+
+func (s *Store) SaveDigestState(ctx context.Context, state model.DigestState) error {
+	if s.SaveDigestStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveDigestStateFunc(ctx, state)
+}
+
+func (s *Store) ListWebhookStates(ctx context.Context) (map[string]model.WebhookState, error) {
+	if s.ListWebhookStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListWebhookStatesFunc(ctx)
+}
+
+func (s *Store) SaveWebhookState(ctx context.Context, state model.WebhookState) error {
+	if s.SaveWebhookStateFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.SaveWebhookStateFunc(ctx, state)
+}
+
+func (s *Store) CreateReadinessPoint(ctx context.Context, p *model.ReadinessPoint) error {
+	if s.CreateReadinessPointFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.CreateReadinessPointFunc(ctx, p)
+}
+
+func (s *Store) ListReadinessHistory(ctx context.Context, release string) ([]model.ReadinessPoint, error) {
+	if s.ListReadinessHistoryFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReadinessHistoryFunc(ctx, release)
+}
+
+func (s *Store) LatestReadinessPoints(ctx context.Context) (map[string]model.ReadinessPoint, error) {
+	if s.LatestReadinessPointsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.LatestReadinessPointsFunc(ctx)
+}
+
+func (s *Store) ListScopeChanges(ctx context.Context, fixVersion string) ([]model.ScopeChange, error) {
+	if s.ListScopeChangesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListScopeChangesFunc(ctx, fixVersion)
+}
+
+func (s *Store) ListRetentionSnapshots(ctx context.Context) ([]model.SnapshotRecord, error) {
+	if s.ListRetentionSnapshotsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListRetentionSnapshotsFunc(ctx)
+}
+
+func (s *Store) ListCandidateStates(ctx context.Context) (map[string]map[int64]string, error) {
+	if s.ListCandidateStatesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListCandidateStatesFunc(ctx)
+}
+
+func (s *Store) DeleteSnapshots(ctx context.Context, ids []int64) error {
+	if s.DeleteSnapshotsFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteSnapshotsFunc(ctx, ids)
+}
+
+func (s *Store) ListAuditHolds(ctx context.Context) ([]model.AuditHold, error) {
+	if s.ListAuditHoldsFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListAuditHoldsFunc(ctx)
+}
+
+func (s *Store) SetAuditHold(ctx context.Context, release, reason string) (*model.AuditHold, error) {
+	if s.SetAuditHoldFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.SetAuditHoldFunc(ctx, release, reason)
+}
+
+func (s *Store) DeleteAuditHold(ctx context.Context, release string) error {
+	if s.DeleteAuditHoldFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteAuditHoldFunc(ctx, release)
+}
+
+func (s *Store) ListReleaseAdvisories(ctx context.Context) ([]model.Advisory, error) {
+	if s.ListReleaseAdvisoriesFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.ListReleaseAdvisoriesFunc(ctx)
+}
+
+func (s *Store) GetReleaseAdvisory(ctx context.Context, release string) (*model.Advisory, error) {
+	if s.GetReleaseAdvisoryFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.GetReleaseAdvisoryFunc(ctx, release)
+}
+
+func (s *Store) SetReleaseAdvisory(ctx context.Context, release string, advisoryID int64) (*model.Advisory, error) {
+	if s.SetReleaseAdvisoryFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.SetReleaseAdvisoryFunc(ctx, release, advisoryID)
+}
+
+func (s *Store) DeleteReleaseAdvisory(ctx context.Context, release string) error {
+	if s.DeleteReleaseAdvisoryFunc == nil {
+		return ErrUnexpectedCall
+	}
+	return s.DeleteReleaseAdvisoryFunc(ctx, release)
+}
+
+func (s *Store) Search(ctx context.Context, query string, limit int) ([]model.SearchResult, error) {
+	if s.SearchFunc == nil {
+		return nil, ErrUnexpectedCall
+	}
+	return s.SearchFunc(ctx, query, limit)
+}
@@ -0,0 +1,58 @@
+package storetest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/demo"
+	"github.com/quay/release-readiness/internal/digest"
+	"github.com/quay/release-readiness/internal/gitaudit"
+	"github.com/quay/release-readiness/internal/history"
+	"github.com/quay/release-readiness/internal/jira"
+	"github.com/quay/release-readiness/internal/notify"
+	"github.com/quay/release-readiness/internal/registry"
+	"github.com/quay/release-readiness/internal/retention"
+	"github.com/quay/release-readiness/internal/s3"
+	"github.com/quay/release-readiness/internal/server"
+	"github.com/quay/release-readiness/internal/storetest"
+	"github.com/quay/release-readiness/internal/webhooks"
+)
+
+// Both the real database and the mock must satisfy every persistence contract.
+var (
+	_ server.Store    = (*db.DB)(nil)
+	_ s3.Store        = (*db.DB)(nil)
+	_ jira.Store      = (*db.DB)(nil)
+	_ demo.Store      = (*db.DB)(nil)
+	_ gitaudit.Store  = (*db.DB)(nil)
+	_ registry.Store  = (*db.DB)(nil)
+	_ notify.Store    = (*db.DB)(nil)
+	_ digest.Store    = (*db.DB)(nil)
+	_ history.Store   = (*db.DB)(nil)
+	_ retention.Store = (*db.DB)(nil)
+	_ webhooks.Store  = (*db.DB)(nil)
+
+	_ server.Store    = (*storetest.Store)(nil)
+	_ s3.Store        = (*storetest.Store)(nil)
+	_ jira.Store      = (*storetest.Store)(nil)
+	_ demo.Store      = (*storetest.Store)(nil)
+	_ gitaudit.Store  = (*storetest.Store)(nil)
+	_ registry.Store  = (*storetest.Store)(nil)
+	_ notify.Store    = (*storetest.Store)(nil)
+	_ digest.Store    = (*storetest.Store)(nil)
+	_ history.Store   = (*storetest.Store)(nil)
+	_ retention.Store = (*storetest.Store)(nil)
+	_ webhooks.Store  = (*storetest.Store)(nil)
+)
+
+func TestUnexpectedCall(t *testing.T) {
+	var s storetest.Store
+	if err := s.Ping(); !errors.Is(err, storetest.ErrUnexpectedCall) {
+		t.Errorf("Ping: got %v, want ErrUnexpectedCall", err)
+	}
+	s.PingFunc = func() error { return nil }
+	if err := s.Ping(); err != nil {
+		t.Errorf("Ping: got %v, want nil", err)
+	}
+}
@@ -0,0 +1,230 @@
+// Package tekton resolves the PipelineRuns that ran integration tests into
+// their status, timing and tasks through the Tekton Results API.
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/konflux"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// ErrNotFound is returned when Tekton Results has no record of a run.
+var ErrNotFound = errors.New("pipeline run not found")
+
+// Record data types of PipelineRuns and TaskRuns, across the Tekton API
+// versions Results stores.
+var (
+	pipelineRunTypes = []string{"tekton.dev/v1.PipelineRun", "tekton.dev/v1beta1.PipelineRun"}
+	taskRunTypes     = []string{"tekton.dev/v1.TaskRun", "tekton.dev/v1beta1.TaskRun"}
+)
+
+// Config holds the settings needed to reach a Tekton Results API server.
+type Config struct {
+	URL   string // e.g. https://tekton-results.apps.example.com
+	Token string // bearer token, e.g. a service account token
+}
+
+// Client reads PipelineRun and TaskRun records from Tekton Results.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+// New creates a Tekton Results client.
+func New(cfg Config) *Client {
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	c := &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breaker:    breaker.New("tekton", breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown),
+	}
+	c.breaker.SetFailurePredicate(func(err error) bool {
+		var se *statusError
+		if errors.As(err, &se) {
+			return se.statusCode >= 500
+		}
+		return err != nil && !errors.Is(err, ErrNotFound)
+	})
+	return c
+}
+
+// Breaker returns the circuit breaker guarding calls to Tekton Results.
+func (c *Client) Breaker() *breaker.Breaker {
+	return c.breaker
+}
+
+// object is the part of a PipelineRun or TaskRun the client reads.
+type object struct {
+	Metadata struct {
+		Name      string            `json:"name"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Status struct {
+		StartTime      *time.Time          `json:"startTime"`
+		CompletionTime *time.Time          `json:"completionTime"`
+		Conditions     []konflux.Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// GetPipelineRun returns the PipelineRun called name in namespace along
+// with its TaskRuns, ordered by start time.
+func (c *Client) GetPipelineRun(ctx context.Context, namespace, name string) (*model.PipelineRun, error) {
+	runs, err := c.records(ctx, namespace, fmt.Sprintf("data_type in %s && data.metadata.name == %q", celList(pipelineRunTypes), name))
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("%s/%s: %w", namespace, name, ErrNotFound)
+	}
+	pr := runs[0]
+	run := &model.PipelineRun{
+		Namespace:      namespace,
+		Name:           name,
+		StartTime:      pr.Status.StartTime,
+		CompletionTime: pr.Status.CompletionTime,
+		Tasks:          []model.PipelineTask{},
+	}
+	run.Status, run.Reason, run.Message = state(pr.Status.Conditions)
+
+	taskRuns, err := c.records(ctx, namespace, fmt.Sprintf("data_type in %s && data.metadata.labels[%q] == %q",
+		celList(taskRunTypes), "tekton.dev/pipelineRun", name))
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range taskRuns {
+		task := model.PipelineTask{
+			Name:           tr.Metadata.Labels["tekton.dev/pipelineTask"],
+			StartTime:      tr.Status.StartTime,
+			CompletionTime: tr.Status.CompletionTime,
+		}
+		if task.Name == "" {
+			task.Name = tr.Metadata.Name
+		}
+		task.Status, task.Reason, task.Message = state(tr.Status.Conditions)
+		run.Tasks = append(run.Tasks, task)
+	}
+	// Tasks that never started (skipped) go last.
+	slices.SortStableFunc(run.Tasks, func(a, b model.PipelineTask) int {
+		switch {
+		case a.StartTime == nil && b.StartTime == nil:
+			return 0
+		case a.StartTime == nil:
+			return 1
+		case b.StartTime == nil:
+			return -1
+		}
+		return a.StartTime.Compare(*b.StartTime)
+	})
+	return run, nil
+}
+
+// records lists the records in namespace matching the CEL filter, across
+// all results, and decodes them.
+func (c *Client) records(ctx context.Context, namespace, filter string) ([]object, error) {
+	q := url.Values{"filter": {filter}, "page_size": {"100"}}
+	var objects []object
+	for {
+		var page struct {
+			Records []struct {
+				Data struct {
+					Type  string `json:"type"`
+					Value []byte `json:"value"` // base64 in the JSON mapping
+				} `json:"data"`
+			} `json:"records"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		u := c.cfg.URL + "/apis/results.tekton.dev/v1alpha2/parents/" + url.PathEscape(namespace) + "/results/-/records?" + q.Encode()
+		err := c.breaker.Do(func() error {
+			return c.get(ctx, u, &page)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page.Records {
+			var o object
+			if err := json.Unmarshal(r.Data.Value, &o); err != nil {
+				return nil, fmt.Errorf("decode %s record: %w", r.Data.Type, err)
+			}
+			objects = append(objects, o)
+		}
+		if page.NextPageToken == "" {
+			return objects, nil
+		}
+		q.Set("page_token", page.NextPageToken)
+	}
+}
+
+func (c *Client) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return &statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+}
+
+// state maps the Succeeded condition of a run to its state, reason and
+// message. A run without the condition has not started yet.
+func state(conditions []konflux.Condition) (status, reason, message string) {
+	for _, c := range conditions {
+		if c.Type != "Succeeded" {
+			continue
+		}
+		switch c.Status {
+		case "True":
+			return model.PipelineRunSucceeded, c.Reason, c.Message
+		case "False":
+			return model.PipelineRunFailed, c.Reason, c.Message
+		default:
+			return model.PipelineRunRunning, c.Reason, c.Message
+		}
+	}
+	return model.PipelineRunRunning, "", ""
+}
+
+// celList formats values as a CEL list literal.
+func celList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("tekton results returned %d: %s", e.statusCode, e.body)
+}
@@ -0,0 +1,133 @@
+package tekton
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeResults serves PipelineRun and TaskRun records for the namespace
+// quay-tenant, one record per page.
+func fakeResults(t *testing.T) *httptest.Server {
+	t.Helper()
+	pipelineRun := `{
+		"metadata": {"name": "quay-e2e-abc12", "namespace": "quay-tenant"},
+		"status": {
+			"startTime": "2026-03-02T10:00:00Z",
+			"completionTime": "2026-03-02T10:30:00Z",
+			"conditions": [{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"}]
+		}
+	}`
+	taskRuns := []string{`{
+		"metadata": {"name": "quay-e2e-abc12-run-tests", "labels": {"tekton.dev/pipelineTask": "run-tests"}},
+		"status": {
+			"startTime": "2026-03-02T10:05:00Z",
+			"completionTime": "2026-03-02T10:30:00Z",
+			"conditions": [{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "\"step-e2e\" exited with code 1"}]
+		}
+	}`, `{
+		"metadata": {"name": "quay-e2e-abc12-provision", "labels": {"tekton.dev/pipelineTask": "provision"}},
+		"status": {
+			"startTime": "2026-03-02T10:00:00Z",
+			"completionTime": "2026-03-02T10:05:00Z",
+			"conditions": [{"type": "Succeeded", "status": "True", "reason": "Succeeded"}]
+		}
+	}`}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/apis/results.tekton.dev/v1alpha2/parents/quay-tenant/results/-/records" {
+			http.NotFound(w, r)
+			return
+		}
+		filter := r.URL.Query().Get("filter")
+		type record struct {
+			Data struct {
+				Type  string `json:"type"`
+				Value []byte `json:"value"`
+			} `json:"data"`
+		}
+		var out struct {
+			Records       []record `json:"records"`
+			NextPageToken string   `json:"nextPageToken,omitempty"`
+		}
+		var rec record
+		switch {
+		case strings.Contains(filter, `data.metadata.name == "quay-e2e-abc12"`):
+			rec.Data.Type, rec.Data.Value = "tekton.dev/v1.PipelineRun", []byte(pipelineRun)
+			out.Records = append(out.Records, rec)
+		case strings.Contains(filter, `data.metadata.labels["tekton.dev/pipelineRun"] == "quay-e2e-abc12"`):
+			i := 0
+			if r.URL.Query().Get("page_token") == "next" {
+				i = 1
+			} else {
+				out.NextPageToken = "next"
+			}
+			rec.Data.Type, rec.Data.Value = "tekton.dev/v1.TaskRun", []byte(taskRuns[i])
+			out.Records = append(out.Records, rec)
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestGetPipelineRun(t *testing.T) {
+	srv := fakeResults(t)
+	t.Cleanup(srv.Close)
+	c := New(Config{URL: srv.URL + "/", Token: "token"})
+
+	run, err := c.GetPipelineRun(t.Context(), "quay-tenant", "quay-e2e-abc12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != "failed" || run.Reason != "Failed" || !strings.Contains(run.Message, "Failed: 1") {
+		t.Errorf("run = %+v", run)
+	}
+	if run.StartTime == nil || run.CompletionTime == nil {
+		t.Errorf("times = %v, %v", run.StartTime, run.CompletionTime)
+	}
+	if len(run.Tasks) != 2 || run.Tasks[0].Name != "provision" || run.Tasks[1].Name != "run-tests" {
+		t.Fatalf("tasks = %+v", run.Tasks)
+	}
+	if task := run.Tasks[1]; task.Status != "failed" || !strings.Contains(task.Message, "exited with code 1") {
+		t.Errorf("failed task = %+v", task)
+	}
+
+	if _, err := c.GetPipelineRun(t.Context(), "quay-tenant", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing run: got %v, want ErrNotFound", err)
+	}
+	if _, err := c.GetPipelineRun(t.Context(), "other-tenant", "quay-e2e-abc12"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown namespace: got %v, want ErrNotFound", err)
+	}
+	if st := c.Breaker().Status(); st.ConsecutiveFailures != 0 {
+		t.Errorf("breaker: got %+v after not-found errors", st)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref             string
+		namespace, name string
+		wantErr         bool
+	}{
+		{ref: "https://konflux-ui.apps.example.com/ns/quay-tenant/applications/quay-v3-17/pipelineruns/quay-e2e-abc12", namespace: "quay-tenant", name: "quay-e2e-abc12"},
+		{ref: "https://konflux-ui.apps.example.com/application-pipeline/workspaces/quay/applications/quay-v3-17/pipelineruns/quay-e2e-abc12/logs", namespace: "default-tenant", name: "quay-e2e-abc12"},
+		{ref: "quay-tenant/quay-e2e-abc12", namespace: "quay-tenant", name: "quay-e2e-abc12"},
+		{ref: "quay-e2e-abc12", namespace: "default-tenant", name: "quay-e2e-abc12"},
+		{ref: "https://ci.example.com/job/42", wantErr: true},
+		{ref: "a/b/c", wantErr: true},
+	}
+	for _, tt := range tests {
+		namespace, name, err := ParseRef(tt.ref, "default-tenant")
+		if (err != nil) != tt.wantErr || namespace != tt.namespace || name != tt.name {
+			t.Errorf("ParseRef(%q) = %q, %q, %v", tt.ref, namespace, name, err)
+		}
+	}
+	if _, _, err := ParseRef("quay-e2e-abc12", ""); err == nil {
+		t.Error("bare name without default namespace: expected error")
+	}
+}
@@ -0,0 +1,128 @@
+package tekton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// batchSize caps the test suites resolved per cycle, so a backlog of old
+// snapshots is worked through over several cycles.
+const batchSize = 100
+
+// Store is the persistence contract the Enricher depends on.
+type Store interface {
+	ListPendingPipelineRuns(ctx context.Context, limit int) ([]model.PipelineRunRef, error)
+	SavePipelineRun(ctx context.Context, testSuiteID int64, run *model.PipelineRun) error
+}
+
+// Resolver looks up a PipelineRun. *Client implements it.
+type Resolver interface {
+	GetPipelineRun(ctx context.Context, namespace, name string) (*model.PipelineRun, error)
+}
+
+// Enricher periodically resolves the PipelineRuns named by test suites and
+// stores what it finds with the snapshot.
+type Enricher struct {
+	store     Store
+	resolver  Resolver
+	namespace string
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewEnricher creates an Enricher. namespace is used for runs referenced
+// by bare name.
+func NewEnricher(store Store, resolver Resolver, namespace string, logger *slog.Logger) *Enricher {
+	return &Enricher{store: store, resolver: resolver, namespace: namespace, logger: logger, now: time.Now}
+}
+
+// EnrichOnce resolves the test suites whose PipelineRun is unresolved or
+// was still running. A run that cannot be found is stored with an unknown
+// state so it is not looked up again.
+func (e *Enricher) EnrichOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	refs, err := e.store.ListPendingPipelineRuns(ctx, batchSize)
+	if err != nil {
+		e.logger.ErrorContext(ctx, "list pending pipeline runs", "error", err)
+		return
+	}
+	for _, ref := range refs {
+		run, err := e.resolve(ctx, ref.PipelineRun)
+		if errors.Is(err, breaker.ErrOpen) {
+			e.logger.WarnContext(ctx, "tekton results unavailable, skipping enrichment", "error", err)
+			return
+		}
+		if err != nil {
+			e.logger.ErrorContext(ctx, "resolve pipeline run", "snapshot", ref.Snapshot, "suite", ref.Suite, "error", err)
+			continue
+		}
+		run.ResolvedAt = e.now()
+		if err := e.store.SavePipelineRun(ctx, ref.TestSuiteID, run); err != nil {
+			e.logger.ErrorContext(ctx, "save pipeline run", "snapshot", ref.Snapshot, "suite", ref.Suite, "error", err)
+			continue
+		}
+		e.logger.DebugContext(ctx, "pipeline run resolved", "snapshot", ref.Snapshot, "suite", ref.Suite,
+			"pipeline_run", run.Name, "status", run.Status)
+	}
+}
+
+// resolve looks up the PipelineRun that ref names. Unusable references and
+// runs unknown to Tekton Results come back as a run in the unknown state.
+func (e *Enricher) resolve(ctx context.Context, ref string) (*model.PipelineRun, error) {
+	namespace, name, err := ParseRef(ref, e.namespace)
+	if err != nil {
+		return &model.PipelineRun{Name: ref, Status: model.PipelineRunUnknown, Message: err.Error(), Tasks: []model.PipelineTask{}}, nil
+	}
+	run, err := e.resolver.GetPipelineRun(ctx, namespace, name)
+	if errors.Is(err, ErrNotFound) {
+		return &model.PipelineRun{Namespace: namespace, Name: name, Status: model.PipelineRunUnknown,
+			Message: "not found in Tekton Results", Tasks: []model.PipelineTask{}}, nil
+	}
+	return run, err
+}
+
+// ParseRef extracts the namespace and name of a PipelineRun from a Konflux
+// UI URL (…/ns/{namespace}/…/pipelineruns/{name}), a "namespace/name" pair
+// or a bare name, which is looked up in defaultNamespace.
+func ParseRef(ref, defaultNamespace string) (namespace, name string, err error) {
+	ref = strings.TrimSpace(ref)
+	if u, err := url.Parse(ref); err == nil && u.Scheme != "" && u.Host != "" {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := 0; i+1 < len(segments); i++ {
+			switch segments[i] {
+			case "ns", "namespaces":
+				if namespace == "" {
+					namespace = segments[i+1]
+				}
+			case "pipelineruns", "pipelinerun":
+				name = segments[i+1]
+			}
+		}
+		if name == "" {
+			return "", "", fmt.Errorf("no pipeline run name in %q", ref)
+		}
+	} else if ns, n, ok := strings.Cut(ref, "/"); ok {
+		namespace, name = ns, n
+	} else {
+		name = ref
+	}
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid pipeline run reference %q", ref)
+	}
+	if namespace == "" {
+		return "", "", fmt.Errorf("no namespace for pipeline run %q", ref)
+	}
+	return namespace, name, nil
+}
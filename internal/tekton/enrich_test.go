@@ -0,0 +1,101 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+// fakeResolver maps "namespace/name" to a run.
+type fakeResolver map[string]*model.PipelineRun
+
+func (f fakeResolver) GetPipelineRun(ctx context.Context, namespace, name string) (*model.PipelineRun, error) {
+	run, ok := f[namespace+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", namespace, name, ErrNotFound)
+	}
+	return run, nil
+}
+
+func TestEnrichOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	snap := &model.SnapshotRecord{
+		Application: "quay-v3-17",
+		Name:        "quay-v3-17-snap-1",
+		CreatedAt:   time.Now(),
+		TestSuites: []model.TestSuite{
+			{Name: "e2e", Status: "failed", PipelineRun: "https://konflux-ui.example.com/ns/quay-tenant/applications/quay-v3-17/pipelineruns/quay-e2e-abc12"},
+			{Name: "upgrade", Status: "passed", PipelineRun: "quay-upgrade-def34"},
+			{Name: "gone", Status: "passed", PipelineRun: "quay-tenant/pruned-run"},
+			{Name: "manual", Status: "passed"},
+		},
+	}
+	if err := database.SaveSnapshot(ctx, snap); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	resolver := fakeResolver{
+		"quay-tenant/quay-e2e-abc12": {
+			Namespace: "quay-tenant", Name: "quay-e2e-abc12", Status: model.PipelineRunFailed,
+			Reason: "Failed", StartTime: &start, CompletionTime: &end,
+			Tasks: []model.PipelineTask{{Name: "run-tests", Status: model.PipelineRunFailed, StartTime: &start, CompletionTime: &end}},
+		},
+		"quay-tenant/quay-upgrade-def34": {
+			Namespace: "quay-tenant", Name: "quay-upgrade-def34", Status: model.PipelineRunRunning, StartTime: &start,
+		},
+	}
+	enricher := NewEnricher(database, resolver, "quay-tenant", slog.Default())
+	enricher.EnrichOnce(ctx)
+
+	got, err := database.GetSnapshotByName(ctx, snap.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := map[string]*model.PipelineRun{}
+	for _, s := range got.TestSuites {
+		runs[s.Name] = s.Run
+	}
+	if e2e := runs["e2e"]; e2e == nil || e2e.Status != model.PipelineRunFailed || e2e.DurationMs != 30*60*1000 ||
+		len(e2e.Tasks) != 1 || e2e.Tasks[0].DurationMs != 30*60*1000 {
+		t.Errorf("e2e run = %+v", e2e)
+	}
+	if runs["upgrade"] == nil || runs["upgrade"].Status != model.PipelineRunRunning {
+		t.Errorf("upgrade run = %+v", runs["upgrade"])
+	}
+	if runs["gone"] == nil || runs["gone"].Status != model.PipelineRunUnknown {
+		t.Errorf("gone run = %+v", runs["gone"])
+	}
+	if runs["manual"] != nil {
+		t.Errorf("suite without a pipeline run: got %+v", runs["manual"])
+	}
+
+	// Only the run that was still running is looked up again.
+	pending, err := database.ListPendingPipelineRuns(ctx, batchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Suite != "upgrade" {
+		t.Fatalf("pending = %+v", pending)
+	}
+	resolver["quay-tenant/quay-upgrade-def34"] = &model.PipelineRun{
+		Namespace: "quay-tenant", Name: "quay-upgrade-def34", Status: model.PipelineRunSucceeded,
+		StartTime: &start, CompletionTime: &end,
+	}
+	enricher.EnrichOnce(ctx)
+	if pending, err := database.ListPendingPipelineRuns(ctx, batchSize); err != nil || len(pending) != 0 {
+		t.Errorf("pending after the run finished: got %+v, %v", pending, err)
+	}
+}
@@ -0,0 +1,331 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quay/release-readiness/internal/version"
+)
+
+// Export batching defaults.
+const (
+	queueSize     = 2048
+	maxBatch      = 512
+	flushInterval = 5 * time.Second
+)
+
+// Config holds the OTLP exporter settings.
+type Config struct {
+	// Endpoint is the collector's OTLP/HTTP base URL, e.g.
+	// http://otel-collector:4318. Spans are posted to {Endpoint}/v1/traces
+	// unless it already ends in that path.
+	Endpoint string
+	// Headers are sent with every export, e.g. for authentication.
+	Headers map[string]string
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+}
+
+// Provider batches ended spans and exports them in the background.
+type Provider struct {
+	url         string
+	headers     map[string]string
+	serviceName string
+	httpClient  *http.Client
+	logger      *slog.Logger
+
+	queue   chan *Span
+	flush   chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	stopped sync.Once
+}
+
+// Configure starts exporting spans to cfg.Endpoint and makes Start record
+// them. Call Shutdown on the returned Provider to flush queued spans.
+func Configure(cfg Config, logger *slog.Logger) (*Provider, error) {
+	endpoint := strings.TrimRight(cfg.Endpoint, "/")
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, fmt.Errorf("OTLP endpoint %q: want an http:// or https:// URL", cfg.Endpoint)
+	}
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	name := cfg.ServiceName
+	if name == "" {
+		name = "release-readiness"
+	}
+	p := &Provider{
+		url:         endpoint,
+		headers:     cfg.Headers,
+		serviceName: name,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		logger:      logger,
+		queue:       make(chan *Span, queueSize),
+		flush:       make(chan chan struct{}),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go p.run()
+	current.Store(p)
+	return p, nil
+}
+
+// ParseHeaders parses OTEL_EXPORTER_OTLP_HEADERS-style "key=value" pairs
+// separated by commas.
+func ParseHeaders(s string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("header %q: want key=value", pair)
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
+
+// Shutdown stops recording spans and exports those still queued.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	current.CompareAndSwap(p, nil)
+	p.stopped.Do(func() { close(p.stop) })
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ForceFlush exports the spans queued so far.
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case p.flush <- ack:
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue hands an ended span to the exporter, dropping it if the queue is
+// full rather than slowing down the caller.
+func (p *Provider) enqueue(s *Span) {
+	select {
+	case p.queue <- s:
+	default:
+	}
+}
+
+func (p *Provider) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	var batch []*Span
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.export(batch); err != nil {
+			p.logger.Warn("export spans", "spans", len(batch), "error", err)
+		}
+		batch = batch[:0]
+	}
+	drain := func() {
+		for {
+			select {
+			case s := <-p.queue:
+				batch = append(batch, s)
+				if len(batch) >= maxBatch {
+					export()
+				}
+			default:
+				return
+			}
+		}
+	}
+	for {
+		select {
+		case s := <-p.queue:
+			batch = append(batch, s)
+			if len(batch) >= maxBatch {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case ack := <-p.flush:
+			drain()
+			export()
+			close(ack)
+		case <-p.stop:
+			drain()
+			export()
+			return
+		}
+	}
+}
+
+func (p *Provider) export(spans []*Span) error {
+	body, err := json.Marshal(p.encode(spans))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.httpClient.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("collector returned %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// OTLP JSON encoding of an ExportTraceServiceRequest. IDs are hex and
+// 64-bit integers are decimal strings, as the OTLP JSON mapping requires.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              Kind           `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// statusError is the OTLP status code of a failed span.
+const statusError = 2
+
+func (p *Provider) encode(spans []*Span) otlpRequest {
+	bi := version.Get()
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.sc.traceID[:]),
+			SpanID:            hex.EncodeToString(s.sc.spanID[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		if s.parentID != ([8]byte{}) {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		for _, a := range s.attrs {
+			span.Attributes = append(span.Attributes, keyValue(a))
+		}
+		if s.failed {
+			span.Status = &otlpStatus{Code: statusError, Message: s.errMsg}
+		}
+		s.mu.Unlock()
+		out = append(out, span)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{
+			keyValue(slog.String("service.name", p.serviceName)),
+			keyValue(slog.String("service.version", bi.Version)),
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/quay/release-readiness", Version: bi.Version},
+			Spans: out,
+		}},
+	}}}
+}
+
+func keyValue(a slog.Attr) otlpKeyValue {
+	kv := otlpKeyValue{Key: a.Key}
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindBool:
+		b := v.Bool()
+		kv.Value.BoolValue = &b
+	case slog.KindInt64:
+		i := strconv.FormatInt(v.Int64(), 10)
+		kv.Value.IntValue = &i
+	case slog.KindUint64:
+		i := strconv.FormatUint(v.Uint64(), 10)
+		kv.Value.IntValue = &i
+	case slog.KindFloat64:
+		f := v.Float64()
+		kv.Value.DoubleValue = &f
+	default:
+		s := v.String()
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
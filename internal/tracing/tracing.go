@@ -0,0 +1,186 @@
+// Package tracing records spans for HTTP requests, sync cycles, JIRA calls
+// and database queries, and exports them to an OpenTelemetry collector over
+// OTLP/HTTP with JSON encoding. Until Configure is called, starting a span
+// is a no-op that returns a nil *Span, whose methods do nothing.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Header is the W3C Trace Context header carrying the parent span.
+const Header = "traceparent"
+
+// Kind is the OTLP span kind.
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+)
+
+var current atomic.Pointer[Provider]
+
+// Enabled reports whether spans are being recorded.
+func Enabled() bool {
+	return current.Load() != nil
+}
+
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+type ctxKey struct{}
+
+// Span is an operation being timed. A nil *Span is valid and records
+// nothing.
+type Span struct {
+	provider *Provider
+	sc       spanContext
+	parentID [8]byte
+	name     string
+	kind     Kind
+	start    time.Time
+	end      time.Time
+
+	mu      sync.Mutex
+	attrs   []slog.Attr
+	errMsg  string
+	failed  bool
+	endOnce sync.Once
+}
+
+// Start begins an internal span, a child of the span in ctx if there is
+// one, and returns a context carrying it.
+func Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, *Span) {
+	return start(ctx, name, KindInternal, attrs)
+}
+
+// StartClient begins a span for an outgoing call. Pass the returned
+// context to Inject to propagate it.
+func StartClient(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, *Span) {
+	return start(ctx, name, KindClient, attrs)
+}
+
+// StartServer begins a span for an incoming request, continuing the trace
+// named by its traceparent header if it has a valid one.
+func StartServer(r *http.Request, name string, attrs ...slog.Attr) (context.Context, *Span) {
+	ctx := r.Context()
+	if sc, ok := parseTraceparent(r.Header.Get(Header)); ok {
+		ctx = context.WithValue(ctx, ctxKey{}, sc)
+	}
+	return start(ctx, name, KindServer, attrs)
+}
+
+func start(ctx context.Context, name string, kind Kind, attrs []slog.Attr) (context.Context, *Span) {
+	p := current.Load()
+	if p == nil {
+		return ctx, nil
+	}
+	s := &Span{
+		provider: p,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+		attrs:    attrs,
+	}
+	if parent, ok := ctx.Value(ctxKey{}).(spanContext); ok {
+		s.sc.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		_, _ = rand.Read(s.sc.traceID[:])
+	}
+	_, _ = rand.Read(s.sc.spanID[:])
+	return context.WithValue(ctx, ctxKey{}, s.sc), s
+}
+
+// SetName renames the span, e.g. once the route of a request is known.
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.name = name
+	s.mu.Unlock()
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...slog.Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// RecordError marks the span failed with err. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.failed = true
+	s.errMsg = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and queues it for export. Later calls do nothing.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.endOnce.Do(func() {
+		s.mu.Lock()
+		s.end = time.Now()
+		s.mu.Unlock()
+		s.provider.enqueue(s)
+	})
+}
+
+// TraceID returns the span's trace ID in hex, or "" for a nil span.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.sc.traceID[:])
+}
+
+// Inject sets the traceparent header of an outgoing request to the span in
+// ctx, if there is one.
+func Inject(ctx context.Context, h http.Header) {
+	sc, ok := ctx.Value(ctxKey{}).(spanContext)
+	if !ok {
+		return
+	}
+	h.Set(Header, "00-"+hex.EncodeToString(sc.traceID[:])+"-"+hex.EncodeToString(sc.spanID[:])+"-01")
+}
+
+// parseTraceparent parses a version 00 traceparent header.
+func parseTraceparent(v string) (spanContext, bool) {
+	var sc spanContext
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+	if sc.traceID == ([16]byte{}) || sc.spanID == ([8]byte{}) {
+		return sc, false
+	}
+	return sc, true
+}
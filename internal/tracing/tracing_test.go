@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop")
+	if span != nil {
+		t.Fatalf("span without a provider: got %+v", span)
+	}
+	span.SetAttributes(slog.String("k", "v"))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	h := http.Header{}
+	Inject(ctx, h)
+	if h.Get(Header) != "" {
+		t.Errorf("traceparent without a span: got %q", h.Get(Header))
+	}
+}
+
+func TestExport(t *testing.T) {
+	var mu sync.Mutex
+	var got []otlpSpan
+	var auth, service string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("export: %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		auth = r.Header.Get("Authorization")
+		for _, rs := range req.ResourceSpans {
+			service = *rs.Resource.Attributes[0].Value.StringValue
+			for _, ss := range rs.ScopeSpans {
+				got = append(got, ss.Spans...)
+			}
+		}
+	}))
+	defer collector.Close()
+
+	headers, err := ParseHeaders("Authorization=Bearer abc, x-tenant = quay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := Configure(Config{Endpoint: collector.URL + "/", Headers: headers, ServiceName: "rr-test"}, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An incoming request continues the caller's trace.
+	req := httptest.NewRequest("GET", "/api/v1/health", nil)
+	req.Header.Set(Header, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	ctx, server := StartServer(req, "GET /api/v1/health")
+	ctx, client := StartClient(ctx, "jira search", slog.String("jira.jql", "project = X"))
+	out := http.Header{}
+	Inject(ctx, out)
+	client.SetAttributes(slog.Int("jira.issues", 3), slog.Bool("cached", false))
+	client.RecordError(errors.New("JIRA API returned 503"))
+	client.End()
+	server.End()
+	server.End()
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Error("still enabled after Shutdown")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if auth != "Bearer abc" || service != "rr-test" {
+		t.Errorf("export: Authorization %q, service.name %q", auth, service)
+	}
+	if len(got) != 2 {
+		t.Fatalf("spans: got %d, want 2", len(got))
+	}
+	c, s := got[0], got[1]
+	if s.TraceID != "0af7651916cd43dd8448eb211c80319c" || s.ParentSpanID != "b7ad6b7169203331" || s.Kind != KindServer {
+		t.Errorf("server span: %+v", s)
+	}
+	if c.TraceID != s.TraceID || c.ParentSpanID != s.SpanID || c.Kind != KindClient {
+		t.Errorf("client span is not a child of the server span: %+v", c)
+	}
+	if c.Status == nil || c.Status.Code != statusError || !strings.Contains(c.Status.Message, "503") {
+		t.Errorf("client status: %+v", c.Status)
+	}
+	if len(c.Attributes) != 3 || *c.Attributes[1].Value.IntValue != "3" || *c.Attributes[2].Value.BoolValue {
+		t.Errorf("client attributes: %+v", c.Attributes)
+	}
+	if want := "00-" + c.TraceID + "-" + c.SpanID + "-01"; out.Get(Header) != want {
+		t.Errorf("traceparent: got %q, want %q", out.Get(Header), want)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	for _, v := range []string{
+		"",
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0af7651916cd43dd8448eb211c8031zz-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd-b7ad6b7169203331-01",
+	} {
+		if _, ok := parseTraceparent(v); ok {
+			t.Errorf("parseTraceparent(%q) accepted an invalid header", v)
+		}
+	}
+}
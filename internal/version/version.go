@@ -0,0 +1,56 @@
+// Package version reports the dashboard's build identity. The variables are
+// set at link time, e.g.
+//
+//	go build -ldflags "-X github.com/quay/release-readiness/internal/version.Version=v1.2.0 \
+//	  -X github.com/quay/release-readiness/internal/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/quay/release-readiness/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When they are not set, Commit and Date fall back to the VCS stamp that the
+// Go toolchain embeds when building from a git checkout.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// Info describes the running build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build info, filling gaps from the embedded VCS stamp.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date, GoVersion: runtime.Version()}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date = s.Value
+			}
+		case "vcs.modified":
+			if Commit == "" {
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,134 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+)
+
+// Delivery retries: a failed delivery is retried after retryDelay, then
+// twice that, up to maxAttempts attempts in all.
+const maxAttempts = 3
+
+var retryDelay = 10 * time.Second
+
+// delivery is a payload bound for one hook.
+type delivery struct {
+	hook    Hook
+	payload Payload
+}
+
+func (dl delivery) host() string {
+	u, _ := url.Parse(dl.hook.URL)
+	return u.Host
+}
+
+// sender posts payloads, through a circuit breaker per receiving host.
+type sender struct {
+	httpClient *http.Client
+	breakers   map[string]*breaker.Breaker
+}
+
+func newSender(hooks []Hook) *sender {
+	s := &sender{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		breakers:   make(map[string]*breaker.Breaker),
+	}
+	for _, h := range hooks {
+		host := delivery{hook: h}.host()
+		if _, ok := s.breakers[host]; ok {
+			continue
+		}
+		b := breaker.New("webhook "+host, breaker.DefaultThreshold, breaker.DefaultCooldown, breaker.DefaultMaxCooldown)
+		b.SetFailurePredicate(func(err error) bool {
+			var se *statusError
+			if errors.As(err, &se) {
+				return se.serverFault()
+			}
+			return err != nil
+		})
+		s.breakers[host] = b
+	}
+	return s
+}
+
+// Breakers returns the circuit breakers guarding calls to the hosts of
+// the hooks.
+func (d *Dispatcher) Breakers() []*breaker.Breaker {
+	var bs []*breaker.Breaker
+	for _, h := range d.hooks {
+		b := d.sender.breakers[delivery{hook: h}.host()]
+		if !slices.Contains(bs, b) {
+			bs = append(bs, b)
+		}
+	}
+	return bs
+}
+
+// send posts dl's payload to its hook. Any 2xx response is success.
+func (s *sender) send(ctx context.Context, dl delivery) error {
+	body, err := json.Marshal(dl.payload)
+	if err != nil {
+		return err
+	}
+	return s.breakers[dl.host()].Do(func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.hook.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "release-readiness")
+		req.Header.Set("X-Release-Readiness-Event", dl.payload.Event)
+		req.Header.Set("X-Release-Readiness-Delivery", dl.payload.ID)
+		if dl.hook.Secret != "" {
+			req.Header.Set("X-Release-Readiness-Signature", Sign(dl.hook.Secret, body))
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+			return &statusError{statusCode: resp.StatusCode, body: string(msg)}
+		}
+		return nil
+	})
+}
+
+// Sign returns the X-Release-Readiness-Signature header value of body: its
+// HMAC-SHA256 under secret, hex-encoded and prefixed with "sha256=".
+// Receivers recompute it over the raw request body to authenticate it.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook returned %d: %s", e.statusCode, e.body)
+}
+
+// serverFault reports whether the response blames the receiver rather
+// than the payload: a server error or rate limiting.
+func (e *statusError) serverFault() bool {
+	return e.statusCode >= 500 || e.statusCode == http.StatusTooManyRequests
+}
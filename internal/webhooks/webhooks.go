@@ -0,0 +1,315 @@
+// Package webhooks posts JSON payloads to configured outbound webhooks when
+// a snapshot is ingested, a release's readiness changes, or a release is
+// released, so that downstream automation can react without polling.
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/quay/release-readiness/internal/breaker"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+	"github.com/quay/release-readiness/internal/requestid"
+)
+
+// Event names, as given in hook filters and payloads.
+const (
+	EventSnapshotIngested = "snapshot.ingested"
+	EventReadinessChanged = "readiness.changed"
+	EventReleaseReleased  = "release.released"
+)
+
+var eventNames = []string{EventSnapshotIngested, EventReadinessChanged, EventReleaseReleased}
+
+// Store is the persistence contract the Dispatcher depends on.
+type Store interface {
+	ListWebhookStates(ctx context.Context) (map[string]model.WebhookState, error)
+	SaveWebhookState(ctx context.Context, state model.WebhookState) error
+}
+
+// Source reports the current readiness of every release.
+// *server.Server implements it.
+type Source interface {
+	ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error)
+}
+
+// Hook is an outbound webhook.
+type Hook struct {
+	URL string `json:"url"`
+	// Secret signs each payload with HMAC-SHA256 in the
+	// X-Release-Readiness-Signature header. Payloads are unsigned if it is
+	// empty.
+	Secret string `json:"secret,omitempty"`
+	// Events lists the events the hook receives; all of them if empty.
+	Events []string `json:"events,omitempty"`
+	// Release and Application are globs (path.Match syntax) restricting
+	// release events to matching releases and snapshot events to matching
+	// applications.
+	Release     string `json:"release,omitempty"`
+	Application string `json:"application,omitempty"`
+}
+
+// LoadHooks reads a JSON array of hooks from the file at path.
+func LoadHooks(path string) ([]Hook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []Hook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i, h := range hooks {
+		if err := h.validate(); err != nil {
+			return nil, fmt.Errorf("%s: hook %d: %w", path, i+1, err)
+		}
+	}
+	return hooks, nil
+}
+
+func (h Hook) validate() error {
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url %q is not an absolute http(s) URL", h.URL)
+	}
+	for _, e := range h.Events {
+		if !slices.Contains(eventNames, e) {
+			return fmt.Errorf("unknown event %q", e)
+		}
+	}
+	for _, p := range []string{h.Release, h.Application} {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// wants reports whether the hook receives p.
+func (h Hook) wants(p Payload) bool {
+	if len(h.Events) > 0 && !slices.Contains(h.Events, p.Event) {
+		return false
+	}
+	pattern, name := h.Release, p.Release
+	if p.Event == EventSnapshotIngested {
+		pattern, name = h.Application, p.Application
+	}
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// Payload is the JSON body posted to hooks.
+type Payload struct {
+	// ID identifies the delivery; it is repeated across retries.
+	ID    string    `json:"id"`
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	// Application and Snapshot are set for snapshot.ingested.
+	Application string `json:"application,omitempty"`
+	Snapshot    string `json:"snapshot,omitempty"`
+	// Release is set for release events, as are the readiness Signal and
+	// Message; PreviousSignal is set for readiness.changed.
+	Release        string `json:"release,omitempty"`
+	Signal         string `json:"signal,omitempty"`
+	PreviousSignal string `json:"previous_signal,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+// Dispatcher fires hooks on snapshot events from the events broker and on
+// the readiness transitions it finds by periodically comparing each
+// release's readiness with what it last saw. The first time a release is
+// seen its state is recorded without firing.
+//
+// Deliveries are queued and sent in the background, with retries; a
+// transition is recorded as soon as its deliveries are queued, so hooks get
+// each event at most once.
+type Dispatcher struct {
+	store  Store
+	source Source
+	hooks  []Hook
+	sender *sender
+	queue  chan delivery
+	logger *slog.Logger
+}
+
+// queueSize bounds how many deliveries may wait to be sent before new ones
+// are dropped.
+const queueSize = 256
+
+// NewDispatcher creates a Dispatcher for hooks.
+func NewDispatcher(store Store, source Source, hooks []Hook, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		source: source,
+		hooks:  hooks,
+		sender: newSender(hooks),
+		queue:  make(chan delivery, queueSize),
+		logger: logger,
+	}
+}
+
+// Run delivers queued payloads in the background, fires hooks on the
+// snapshot events published to broker, and checks readiness immediately
+// and then every interval until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context, broker *events.Broker, interval time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.deliverQueued(ctx)
+	}()
+	defer func() { <-done }()
+
+	var snapshots <-chan events.Event
+	if broker != nil {
+		ch, cancel := broker.Subscribe()
+		defer cancel()
+		snapshots = ch
+	}
+
+	d.CheckOnce(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.InfoContext(ctx, "stopping")
+			return
+		case e := <-snapshots:
+			d.HandleEvent(requestid.Ensure(ctx), e)
+		case <-ticker.C:
+			d.CheckOnce(ctx)
+		}
+	}
+}
+
+// HandleEvent fires snapshot.ingested for a newly ingested snapshot. Other
+// events are ignored.
+func (d *Dispatcher) HandleEvent(ctx context.Context, e events.Event) {
+	if e.Kind != events.KindSnapshot || e.Refresh {
+		return
+	}
+	d.fire(ctx, Payload{Event: EventSnapshotIngested, Time: e.Time, Application: e.Application, Snapshot: e.Snapshot})
+}
+
+// CheckOnce fires readiness.changed for each active release whose signal
+// changed since the last check, and release.released for each release that
+// was released meanwhile.
+func (d *Dispatcher) CheckOnce(ctx context.Context) {
+	ctx = requestid.Ensure(ctx)
+	overviews, err := d.source.ReleasesOverview(ctx)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "compute readiness", "error", err)
+		return
+	}
+	states, err := d.store.ListWebhookStates(ctx)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "list webhook states", "error", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, o := range overviews {
+		if o.Release.Archived {
+			continue
+		}
+		prev, seen := states[o.Release.Name]
+		cur := model.WebhookState{Release: o.Release.Name, Signal: o.Readiness.Signal, Released: o.Release.Released}
+		if cur == prev {
+			continue
+		}
+		if seen {
+			p := Payload{Time: now, Release: cur.Release, Signal: cur.Signal, Message: o.Readiness.Message}
+			switch {
+			case cur.Released && !prev.Released:
+				p.Event = EventReleaseReleased
+				d.fire(ctx, p)
+			case !cur.Released && cur.Signal != prev.Signal:
+				p.Event, p.PreviousSignal = EventReadinessChanged, prev.Signal
+				d.fire(ctx, p)
+			}
+		}
+		if err := d.store.SaveWebhookState(ctx, cur); err != nil {
+			d.logger.ErrorContext(ctx, "save webhook state", "release", cur.Release, "error", err)
+		}
+	}
+}
+
+// fire queues p for each hook that wants it.
+func (d *Dispatcher) fire(ctx context.Context, p Payload) {
+	p.ID = requestid.New()
+	if p.Time.IsZero() {
+		p.Time = time.Now().UTC()
+	}
+	for _, h := range d.hooks {
+		if !h.wants(p) {
+			continue
+		}
+		select {
+		case d.queue <- delivery{hook: h, payload: p}:
+		default:
+			d.logger.ErrorContext(ctx, "webhook queue full, dropping delivery", "event", p.Event, "id", p.ID)
+		}
+	}
+}
+
+// deliverQueued sends queued deliveries until ctx is cancelled.
+func (d *Dispatcher) deliverQueued(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case dl := <-d.queue:
+			d.deliver(requestid.Ensure(ctx), dl)
+		}
+	}
+}
+
+// deliver sends dl, retrying server errors and network failures.
+func (d *Dispatcher) deliver(ctx context.Context, dl delivery) {
+	host := dl.host()
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = d.sender.send(ctx, dl); err == nil || !retryable(err) {
+			break
+		}
+		if attempt < maxAttempts {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(attempt) * retryDelay):
+			}
+		}
+	}
+	if err != nil {
+		d.logger.ErrorContext(ctx, "deliver webhook", "host", host, "event", dl.payload.Event, "id", dl.payload.ID, "error", err)
+		return
+	}
+	d.logger.InfoContext(ctx, "delivered webhook", "host", host, "event", dl.payload.Event, "id", dl.payload.ID)
+}
+
+// retryable reports whether a failed delivery is worth retrying.
+func retryable(err error) bool {
+	if errors.Is(err, breaker.ErrOpen) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.serverFault()
+	}
+	return true
+}
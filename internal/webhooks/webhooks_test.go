@@ -0,0 +1,182 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quay/release-readiness/internal/db"
+	"github.com/quay/release-readiness/internal/events"
+	"github.com/quay/release-readiness/internal/model"
+)
+
+type fakeSource []model.ReleaseOverview
+
+func (f *fakeSource) ReleasesOverview(ctx context.Context) ([]model.ReleaseOverview, error) {
+	return *f, nil
+}
+
+// queued drains the deliveries queued so far.
+func queued(d *Dispatcher) []delivery {
+	var dls []delivery
+	for {
+		select {
+		case dl := <-d.queue:
+			dls = append(dls, dl)
+		default:
+			return dls
+		}
+	}
+}
+
+func TestCheckOnce(t *testing.T) {
+	database, err := db.Open(db.MemoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+	ctx := t.Context()
+
+	overview := func(name, signal string, released bool) model.ReleaseOverview {
+		return model.ReleaseOverview{
+			Release:   model.ReleaseVersion{Name: name, Released: released},
+			Readiness: model.ReadinessResponse{Signal: signal, Message: "2 open blockers"},
+		}
+	}
+	source := &fakeSource{overview("quay-v3.16.3", "yellow", false), overview("omr-v2.1.0", "green", false)}
+	d := NewDispatcher(database, source, []Hook{
+		{URL: "https://gate.example/hook"},
+		{URL: "https://other.example/hook", Events: []string{EventReleaseReleased}, Release: "omr-*"},
+	}, slog.Default())
+
+	// First sight records a baseline without firing.
+	d.CheckOnce(ctx)
+	if dls := queued(d); len(dls) != 0 {
+		t.Fatalf("baseline: queued %+v", dls)
+	}
+
+	*source = fakeSource{overview("quay-v3.16.3", "red", false), overview("omr-v2.1.0", "green", true)}
+	d.CheckOnce(ctx)
+	dls := queued(d)
+	if len(dls) != 3 {
+		t.Fatalf("queued %+v, want 3 deliveries", dls)
+	}
+	if p := dls[0].payload; dls[0].hook.URL != "https://gate.example/hook" || p.Event != EventReadinessChanged ||
+		p.Release != "quay-v3.16.3" || p.Signal != "red" || p.PreviousSignal != "yellow" || p.Message != "2 open blockers" || p.ID == "" {
+		t.Errorf("readiness.changed: got %+v", dls[0])
+	}
+	for _, dl := range dls[1:] {
+		if dl.payload.Event != EventReleaseReleased || dl.payload.Release != "omr-v2.1.0" {
+			t.Errorf("release.released: got %+v", dl)
+		}
+	}
+
+	d.CheckOnce(ctx)
+	if dls := queued(d); len(dls) != 0 {
+		t.Errorf("unchanged: queued %+v", dls)
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	d := NewDispatcher(nil, nil, []Hook{
+		{URL: "https://gate.example/hook", Application: "quay-*"},
+		{URL: "https://other.example/hook", Events: []string{EventReadinessChanged}},
+	}, slog.Default())
+
+	d.HandleEvent(t.Context(), events.Event{Kind: events.KindSnapshot, Application: "quay-v3-16", Snapshot: "quay-v3-16-snap-1"})
+	d.HandleEvent(t.Context(), events.Event{Kind: events.KindSnapshot, Application: "quay-v3-16", Snapshot: "quay-v3-16-snap-1", Refresh: true})
+	d.HandleEvent(t.Context(), events.Event{Kind: events.KindSnapshot, Application: "omr-v2", Snapshot: "omr-v2-snap-1"})
+	d.HandleEvent(t.Context(), events.Event{Kind: events.KindIssues, Release: "quay-v3.16.3"})
+	dls := queued(d)
+	if len(dls) != 1 {
+		t.Fatalf("queued %+v, want one delivery", dls)
+	}
+	if p := dls[0].payload; p.Event != EventSnapshotIngested || p.Snapshot != "quay-v3-16-snap-1" || p.Time.IsZero() {
+		t.Errorf("snapshot.ingested: got %+v", p)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	retryDelay = 0
+	var attempts int
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/flaky":
+			if attempts == 1 {
+				http.Error(w, "try again", http.StatusServiceUnavailable)
+				return
+			}
+		case "/rejects":
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		if sig := r.Header.Get("X-Release-Readiness-Signature"); sig != Sign("s3cret", body) {
+			t.Errorf("signature: got %q", sig)
+		}
+		if ev := r.Header.Get("X-Release-Readiness-Event"); ev != EventReadinessChanged {
+			t.Errorf("event header: got %q", ev)
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	hook := Hook{URL: srv.URL + "/flaky", Secret: "s3cret"}
+	d := NewDispatcher(nil, nil, []Hook{hook}, slog.Default())
+	p := Payload{ID: "abc", Event: EventReadinessChanged, Release: "quay-v3.16.3", Signal: "red"}
+	d.deliver(t.Context(), delivery{hook: hook, payload: p})
+	if attempts != 2 || got.ID != "abc" || got.Signal != "red" {
+		t.Errorf("flaky hook: %d attempts, got %+v", attempts, got)
+	}
+
+	// Rejected payloads are not retried.
+	attempts = 0
+	d.deliver(t.Context(), delivery{hook: Hook{URL: srv.URL + "/rejects"}, payload: p})
+	if attempts != 1 {
+		t.Errorf("rejecting hook: %d attempts, want 1", attempts)
+	}
+	if bs := d.Breakers(); len(bs) != 1 || bs[0].Status().ConsecutiveFailures != 0 {
+		t.Errorf("breakers: got %+v", bs)
+	}
+}
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		p := filepath.Join(dir, "hooks.json")
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	hooks, err := LoadHooks(write(`[{"url":"https://gate.example/hook","secret":"s","events":["release.released"],"release":"quay-v3.16.*"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || hooks[0].Secret != "s" || hooks[0].Events[0] != EventReleaseReleased {
+		t.Errorf("hooks: got %+v", hooks)
+	}
+
+	for _, bad := range []string{
+		`{}`,
+		`[{"url":"gate.example/hook"}]`,
+		`[{"url":"https://gate.example/hook","events":["snapshot.deleted"]}]`,
+		`[{"url":"https://gate.example/hook","release":"["}]`,
+	} {
+		if _, err := LoadHooks(write(bad)); err == nil {
+			t.Errorf("LoadHooks(%s): got nil error", bad)
+		}
+	}
+}
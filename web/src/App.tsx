@@ -2,6 +2,7 @@ import {
 	Bullseye,
 	Button,
 	Content,
+	Label,
 	Masthead,
 	MastheadBrand,
 	MastheadContent,
@@ -21,12 +22,16 @@ import {
 import { lazy, Suspense, useEffect, useState } from "react";
 import { BrowserRouter, Route, Routes } from "react-router-dom";
 import "@patternfly/react-core/dist/styles/base.css";
+import BuildInfoFooter from "./components/BuildInfoFooter";
 import ErrorBoundary from "./components/ErrorBoundary";
+import SearchBox from "./components/SearchBox";
+import { useLiveUpdates } from "./hooks/useLiveUpdates";
 import "./theme.css";
 
 const ReleasesOverview = lazy(() => import("./pages/ReleasesOverview"));
 const ReleaseDetail = lazy(() => import("./pages/ReleaseDetail"));
 const SnapshotsList = lazy(() => import("./pages/SnapshotsList"));
+const SnapshotDetail = lazy(() => import("./pages/SnapshotDetail"));
 
 type Theme = "light" | "dark";
 
@@ -39,6 +44,7 @@ function getInitialTheme(): Theme {
 
 function AppLayout({ children }: { children: React.ReactNode }) {
 	const [theme, setTheme] = useState<Theme>(getInitialTheme);
+	const live = useLiveUpdates();
 
 	useEffect(() => {
 		const root = document.documentElement;
@@ -75,7 +81,17 @@ function AppLayout({ children }: { children: React.ReactNode }) {
 			<MastheadContent>
 				<Toolbar>
 					<ToolbarContent>
+						<ToolbarItem>
+							<SearchBox />
+						</ToolbarItem>
 						<ToolbarItem align={{ default: "alignEnd" }}>
+							{live && (
+								<Label color="green" isCompact>
+									Live
+								</Label>
+							)}
+						</ToolbarItem>
+						<ToolbarItem>
 							<Popover
 								headerContent="About this dashboard"
 								bodyContent={
@@ -119,7 +135,12 @@ function AppLayout({ children }: { children: React.ReactNode }) {
 		</Masthead>
 	);
 
-	return <Page masthead={header}>{children}</Page>;
+	return (
+		<Page masthead={header}>
+			{children}
+			<BuildInfoFooter />
+		</Page>
+	);
 }
 
 export default function App() {
@@ -141,6 +162,7 @@ export default function App() {
 								path="/releases/:version/snapshots"
 								element={<SnapshotsList />}
 							/>
+							<Route path="/snapshots/:name" element={<SnapshotDetail />} />
 						</Routes>
 					</Suspense>
 				</ErrorBoundary>
@@ -1,11 +1,25 @@
 import type {
+	Advisory,
+	Approval,
+	ApprovalRole,
+	Backport,
+	CandidateState,
 	DashboardConfig,
+	ECReport,
 	IssueSummary,
 	JiraIssue,
+	ReadinessPoint,
 	ReadinessResponse,
+	ReleaseCandidate,
 	ReleaseOverview,
 	ReleaseVersion,
+	ScenarioTrend,
+	ScopeChanges,
+	SearchResult,
+	SnapshotDiff,
 	SnapshotRecord,
+	VersionInfo,
+	WhoAmI,
 } from "./types";
 
 const BASE = "/api/v1";
@@ -22,6 +36,27 @@ export function getConfig(): Promise<DashboardConfig> {
 	return fetchJSON(`${BASE}/config`);
 }
 
+export function getVersion(): Promise<VersionInfo> {
+	return fetchJSON(`${BASE}/version`);
+}
+
+/** Returns who an authenticating proxy in front of the server says we are. */
+export function getWhoAmI(): Promise<WhoAmI> {
+	return fetchJSON(`${BASE}/whoami`);
+}
+
+/**
+ * Headers for a JSON request authorized by token, or by the authenticating
+ * proxy if token is empty.
+ */
+function authHeaders(token: string): Record<string, string> {
+	const headers: Record<string, string> = {
+		"Content-Type": "application/json",
+	};
+	if (token) headers.Authorization = `Bearer ${token}`;
+	return headers;
+}
+
 export function listSnapshots(
 	application?: string,
 	limit = 50,
@@ -34,6 +69,63 @@ export function listSnapshots(
 	return fetchJSON(`${BASE}/snapshots?${params}`);
 }
 
+/** URL of a release's issues as CSV. */
+export function releaseIssuesCsvUrl(version: string): string {
+	return `${BASE}/releases/${encodeURIComponent(version)}/issues?format=csv`;
+}
+
+/** URL of a release's readiness history as CSV. */
+export function releaseHistoryCsvUrl(version: string): string {
+	return `${BASE}/releases/${encodeURIComponent(version)}/history?format=csv`;
+}
+
+/** URL of a snapshot's test results as CSV, one row per test case. */
+export function snapshotTestsCsvUrl(name: string): string {
+	return `${BASE}/snapshots/${encodeURIComponent(name)}?format=csv`;
+}
+
+/** URL of a release's go/no-go report, rendered as HTML or PDF. */
+export function releaseReportUrl(
+	version: string,
+	format: "html" | "pdf" = "html",
+): string {
+	return `${BASE}/releases/${encodeURIComponent(version)}/report?format=${format}`;
+}
+
+/** Compares snapshot `from` with the later snapshot `to` of the same application. */
+export function diffSnapshots(from: string, to: string): Promise<SnapshotDiff> {
+	return fetchJSON(
+		`${BASE}/snapshots/${encodeURIComponent(from)}/diff/${encodeURIComponent(to)}`,
+	);
+}
+
+/** Returns a test scenario's pass rate and duration over recent snapshots. */
+/** Searches issues, snapshots, components and test failures for query. */
+export function search(query: string): Promise<SearchResult[]> {
+	return fetchJSON(`${BASE}/search?${new URLSearchParams({ q: query })}`);
+}
+
+export function getScenarioTrend(
+	app: string,
+	scenario: string,
+	limit?: number,
+): Promise<ScenarioTrend> {
+	const params = limit ? `?limit=${limit}` : "";
+	return fetchJSON(
+		`${BASE}/applications/${encodeURIComponent(app)}/scenarios/${encodeURIComponent(scenario)}/trend${params}`,
+	);
+}
+
+/** Returns a snapshot with its components, test results and scans. */
+export function getSnapshot(name: string): Promise<SnapshotRecord> {
+	return fetchJSON(`${BASE}/snapshots/${encodeURIComponent(name)}`);
+}
+
+/** Returns the Enterprise Contract results of a snapshot. */
+export function getSnapshotEC(name: string): Promise<ECReport> {
+	return fetchJSON(`${BASE}/snapshots/${encodeURIComponent(name)}/ec`);
+}
+
 // --- Release-centric API ---
 
 export function listReleasesOverview(): Promise<ReleaseOverview[]> {
@@ -50,18 +142,34 @@ export function getReleaseSnapshot(version: string): Promise<SnapshotRecord> {
 
 export function listReleaseIssues(
 	version: string,
-	filters?: { label?: string; status?: string; type?: string },
+	filters?: {
+		label?: string;
+		status?: string;
+		type?: string;
+		assignee?: string;
+		resolution?: string;
+		limit?: number;
+		offset?: number;
+	},
 ): Promise<JiraIssue[]> {
 	const params = new URLSearchParams();
 	if (filters?.label) params.set("label", filters.label);
 	if (filters?.status) params.set("status", filters.status);
 	if (filters?.type) params.set("type", filters.type);
+	if (filters?.assignee) params.set("assignee", filters.assignee);
+	if (filters?.resolution) params.set("resolution", filters.resolution);
+	if (filters?.limit) params.set("limit", String(filters.limit));
+	if (filters?.offset) params.set("offset", String(filters.offset));
 	const qs = params.toString();
 	return fetchJSON(
 		`${BASE}/releases/${encodeURIComponent(version)}/issues${qs ? `?${qs}` : ""}`,
 	);
 }
 
+export function listReleaseCVEs(version: string): Promise<JiraIssue[]> {
+	return fetchJSON(`${BASE}/releases/${encodeURIComponent(version)}/cves`);
+}
+
 export function getReleaseIssueSummary(version: string): Promise<IssueSummary> {
 	return fetchJSON(
 		`${BASE}/releases/${encodeURIComponent(version)}/issues/summary`,
@@ -74,6 +182,96 @@ export function getReleaseReadiness(
 	return fetchJSON(`${BASE}/releases/${encodeURIComponent(version)}/readiness`);
 }
 
+/** The release's Errata Tool advisory; rejects if none is configured. */
+export function getReleaseAdvisory(version: string): Promise<Advisory> {
+	return fetchJSON(`${BASE}/releases/${encodeURIComponent(version)}/advisory`);
+}
+
+export function listReleaseBackports(
+	version: string,
+	pendingOnly = false,
+): Promise<Backport[]> {
+	return fetchJSON(
+		`${BASE}/releases/${encodeURIComponent(version)}/backports${pendingOnly ? "?pending=true" : ""}`,
+	);
+}
+
+export function listBlockedIssues(version: string): Promise<JiraIssue[]> {
+	return fetchJSON(
+		`${BASE}/releases/${encodeURIComponent(version)}/blocked-issues`,
+	);
+}
+
+export function listReleaseCandidates(
+	version: string,
+): Promise<ReleaseCandidate[]> {
+	return fetchJSON(
+		`${BASE}/releases/${encodeURIComponent(version)}/candidates`,
+	);
+}
+
+/** Promotes, demotes, or resets a candidate. Requires the release-manager role. */
+export async function setCandidateState(
+	version: string,
+	snapshot: string,
+	state: CandidateState,
+	token: string,
+): Promise<ReleaseCandidate[]> {
+	const res = await fetch(
+		`${BASE}/releases/${encodeURIComponent(version)}/candidates/${encodeURIComponent(snapshot)}`,
+		{
+			method: "PUT",
+			headers: authHeaders(token),
+			body: JSON.stringify({ state }),
+		},
+	);
+	if (!res.ok) {
+		const body = (await res.json().catch(() => null)) as {
+			error?: string;
+		} | null;
+		throw new Error(body?.error ?? `${res.status} ${res.statusText}`);
+	}
+	return res.json() as Promise<ReleaseCandidate[]>;
+}
+
+/** How a release's readiness and issue counts changed, oldest first. */
+export function getReleaseHistory(version: string): Promise<ReadinessPoint[]> {
+	return fetchJSON(`${BASE}/releases/${encodeURIComponent(version)}/history`);
+}
+
+export function getScopeChanges(version: string): Promise<ScopeChanges> {
+	return fetchJSON(
+		`${BASE}/releases/${encodeURIComponent(version)}/scope-changes`,
+	);
+}
+
+export function listReleaseApprovals(version: string): Promise<Approval[]> {
+	return fetchJSON(`${BASE}/releases/${encodeURIComponent(version)}/approvals`);
+}
+
+/** Records a sign-off on a release. Requires the release-manager role. */
+export async function createReleaseApproval(
+	version: string,
+	approval: { approver: string; role: ApprovalRole; comment?: string },
+	token: string,
+): Promise<Approval> {
+	const res = await fetch(
+		`${BASE}/releases/${encodeURIComponent(version)}/approvals`,
+		{
+			method: "POST",
+			headers: authHeaders(token),
+			body: JSON.stringify(approval),
+		},
+	);
+	if (!res.ok) {
+		const body = (await res.json().catch(() => null)) as {
+			error?: string;
+		} | null;
+		throw new Error(body?.error ?? `${res.status} ${res.statusText}`);
+	}
+	return res.json() as Promise<Approval>;
+}
+
 export function downloadSuiteArtifacts(
 	snapshotId: number,
 	suiteId: number,
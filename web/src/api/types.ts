@@ -5,6 +5,14 @@ export interface ComponentRecord {
 	git_sha: string;
 	image_url: string;
 	git_url: string;
+	sbom?: ComponentSBOM;
+}
+
+export interface ComponentSBOM {
+	reference?: string;
+	format?: "spdx" | "cyclonedx";
+	packages: number;
+	licenses?: string[];
 }
 
 export interface TestCase {
@@ -21,6 +29,29 @@ export interface TestCase {
 	flaky: boolean;
 }
 
+export interface PipelineTask {
+	name: string;
+	status: "running" | "succeeded" | "failed";
+	reason?: string;
+	message?: string;
+	start_time?: string;
+	completion_time?: string;
+	duration_ms: number;
+}
+
+export interface PipelineRun {
+	namespace: string;
+	name: string;
+	status: "running" | "succeeded" | "failed" | "unknown";
+	reason?: string;
+	message?: string;
+	start_time?: string;
+	completion_time?: string;
+	duration_ms: number;
+	tasks: PipelineTask[];
+	resolved_at: string;
+}
+
 export interface TestSuite {
 	id: number;
 	snapshot_id: number;
@@ -41,6 +72,7 @@ export interface TestSuite {
 	duration_ms: number;
 	created_at: string;
 	test_cases?: TestCase[];
+	run?: PipelineRun;
 }
 
 export interface Vulnerability {
@@ -71,20 +103,140 @@ export interface VulnerabilityReport {
 	vulnerabilities?: Vulnerability[];
 }
 
+export interface ImageVerification {
+	component: string;
+	image_url: string;
+	tag?: string;
+	status: "ok" | "missing" | "mismatch" | "error";
+	message?: string;
+	checked_at: string;
+}
+
+export interface ImageDigestSummary {
+	components: number;
+	verified: number;
+	failed: number;
+	missing: number;
+}
+
+export interface SnapshotRelease {
+	name: string;
+	snapshot: string;
+	release_plan: string;
+	target: string;
+	status: "progressing" | "succeeded" | "failed";
+	reason?: string;
+	message?: string;
+	start_time?: string;
+	completion_time?: string;
+}
+
+export interface ReleasePipelineSummary {
+	succeeded: number;
+	failed: number;
+	progressing: number;
+}
+
+export interface ECFinding {
+	code?: string;
+	title?: string;
+	message: string;
+	solution?: string;
+}
+
+export interface ECResult {
+	component: string;
+	image_url: string;
+	success: boolean;
+	violations: ECFinding[];
+	warnings: ECFinding[];
+}
+
+export interface ECReport {
+	snapshot: string;
+	success: boolean;
+	components: ECResult[];
+}
+
+export interface ECSummary {
+	components: number;
+	failed: number;
+}
+
 export interface SnapshotRecord {
 	id: number;
 	application: string;
 	name: string;
+	source?: string;
+	lifecycle?: LifecycleState;
 	tests_passed: boolean;
 	has_tests: boolean;
 	created_at: string;
 	components?: ComponentRecord[];
 	test_suites?: TestSuite[];
 	vulnerability_reports?: VulnerabilityReport[];
+	image_verifications?: ImageVerification[];
+	image_digests?: ImageDigestSummary;
+	releases?: SnapshotRelease[];
+	scenario_transitions?: ScenarioTransition[];
+	lifecycle_transitions?: LifecycleTransition[];
+	release_pipelines?: ReleasePipelineSummary;
+	ec?: ECSummary;
+}
+
+export interface ScenarioTransition {
+	scenario: string;
+	from: string;
+	to: string;
+	changed_at: string;
+}
+
+export type LifecycleState = "new" | "candidate" | "rejected" | "accepted" | "released";
+
+export interface LifecycleTransition {
+	from: LifecycleState;
+	to: LifecycleState;
+	reason?: string;
+	changed_by?: string;
+	changed_at: string;
+}
+
+export interface SnapshotLifecycle {
+	snapshot: string;
+	state: LifecycleState;
+	transitions: LifecycleTransition[];
+}
+
+export type MatrixStatus = "passed" | "failed" | "pending" | "skipped" | "untested";
+
+export interface MatrixCell {
+	status: MatrixStatus;
+	tests: number;
+	passed: number;
+	failed: number;
+	skipped: number;
+	scoped: boolean;
+}
+
+export interface ReadinessMatrix {
+	release: string;
+	snapshot: string;
+	scenarios: { name: string; status: string; required: boolean }[];
+	rows: { component: string; cells: MatrixCell[] }[];
+}
+
+export type CandidateState = "candidate" | "promoted" | "demoted";
+
+export interface ReleaseCandidate {
+	snapshot: SnapshotRecord;
+	state: CandidateState;
+	changed_at?: string;
+	selected: boolean;
 }
 
 export interface JiraIssue {
 	key: string;
+	project: string;
 	summary: string;
 	status: string;
 	priority: string;
@@ -95,7 +247,38 @@ export interface JiraIssue {
 	resolution: string;
 	link: string;
 	qa_contact: string;
+	severity?: string;
+	clones?: string;
+	components?: string;
 	updated_at: string;
+	cve_id?: string;
+	cvss_score?: number;
+	embargoed?: boolean;
+	blocker?: boolean;
+	blocked_by?: IssueBlocker[];
+	subtasks?: IssueSubtask[];
+	subtask_completion?: number;
+}
+
+export interface IssueSubtask {
+	key: string;
+	summary: string;
+	status: string;
+	link: string;
+}
+
+export interface IssueBlocker {
+	key: string;
+	summary: string;
+	status: string;
+	link: string;
+}
+
+export interface Backport {
+	issue: JiraIssue;
+	source: JiraIssue;
+	match: "clone" | "summary";
+	pending: boolean;
 }
 
 export interface IssueSummary {
@@ -104,6 +287,49 @@ export interface IssueSummary {
 	open: number;
 	cves: number;
 	bugs: number;
+	open_blockers: number;
+	blocked: number;
+	subtasks: number;
+	subtasks_done: number;
+	open_cve_severities?: Record<string, number>;
+	cve_severities?: CVESeverityCount[];
+	buckets?: BucketCount[];
+	components?: ComponentCount[];
+	projects?: ProjectCount[];
+	stale?: boolean;
+	as_of?: string;
+}
+
+export interface CVESeverityCount {
+	severity: string;
+	total: number;
+	open: number;
+	embargoed: number;
+	max_cvss?: number;
+}
+
+export interface IssueBucket {
+	name: string;
+	labels: string[];
+}
+
+export interface BucketCount {
+	name: string;
+	total: number;
+	open: number;
+}
+
+export interface ComponentCount {
+	component: string;
+	total: number;
+	open: number;
+	open_blockers: number;
+}
+
+export interface ProjectCount {
+	project: string;
+	total: number;
+	open: number;
 }
 
 export interface ReleaseVersion {
@@ -116,11 +342,103 @@ export interface ReleaseVersion {
 	release_ticket_assignee?: string;
 	s3_application?: string;
 	due_date?: string;
+	hidden: boolean;
 }
 
 export interface ReadinessResponse {
 	signal: "green" | "yellow" | "red";
 	message: string;
+	blocking_cves?: number;
+	open_blockers?: number;
+	outstanding_approvals?: ApprovalRole[];
+	advisory_state?: string;
+	/** The rule that set the signal; absent when green. */
+	rule?: string;
+	/** Active overrides waiving rules the release currently fails. */
+	waivers?: ReadinessOverride[];
+	/** Due-date projection; only returned by the readiness endpoint. */
+	schedule?: ScheduleRisk;
+}
+
+/** How fast a release's open issues are closing, against its due date. */
+export interface ScheduleRisk {
+	days_to_due: number;
+	open_issues: number;
+	/** Net open issues closed per day over the last 14 days. */
+	burn_rate: number;
+	burn_days: number;
+	projected_completion?: string;
+	at_risk: boolean;
+}
+
+/** A readiness rule waived for a release until it expires. */
+export interface ReadinessOverride {
+	id: number;
+	release: string;
+	rule: string;
+	reason: string;
+	created_by?: string;
+	created_at: string;
+	expires_at: string;
+}
+
+/** The Errata Tool advisory configured for a release. */
+export interface Advisory {
+	release: string;
+	advisory_id: number;
+	name?: string;
+	type?: string;
+	state?: string;
+	synopsis?: string;
+	builds: string[];
+	cves: string[];
+	synced_at?: string;
+	sync_error?: string;
+}
+
+export type ApprovalRole = "QE" | "Dev" | "PM";
+
+export interface Approval {
+	id: number;
+	release: string;
+	approver: string;
+	role: ApprovalRole;
+	comment?: string;
+	created_at: string;
+}
+
+export interface ReadinessPoint {
+	release: string;
+	signal: "green" | "yellow" | "red";
+	total: number;
+	open: number;
+	verified: number;
+	cves: number;
+	bugs: number;
+	blocking_cves: number;
+	recorded_at: string;
+}
+
+export interface ScopeChange {
+	issue_key: string;
+	summary: string;
+	change: "added" | "removed";
+	changed_at: string;
+}
+
+export interface ScopeChanges {
+	release: string;
+	due_date?: string;
+	late_since?: string;
+	added: number;
+	removed: number;
+	late_added: number;
+	changes: ScopeChange[];
+}
+
+export interface SignOff {
+	approved: ApprovalRole[];
+	outstanding: ApprovalRole[];
 }
 
 export interface ReleaseOverview {
@@ -128,9 +446,165 @@ export interface ReleaseOverview {
 	issue_summary?: IssueSummary;
 	readiness: ReadinessResponse;
 	snapshot?: SnapshotRecord;
+	sign_off?: SignOff;
+}
+
+export type Role = "viewer" | "reporter" | "release-manager" | "admin";
+
+/** Who requests are authenticated as; both fields are absent if anonymous. */
+export interface WhoAmI {
+	name?: string;
+	role?: Role;
 }
 
 export interface DashboardConfig {
 	jira_base_url: string;
+	/** Comma-separated JIRA project keys. */
 	jira_project: string;
 }
+
+export interface VersionInfo {
+	version: string;
+	commit: string;
+	date: string;
+	modified?: boolean;
+	go_version: string;
+}
+
+export interface TestCounts {
+	tests: number;
+	passed: number;
+	failed: number;
+	skipped: number;
+}
+
+export interface ComponentChange {
+	component: string;
+	change: "added" | "removed" | "changed";
+	from_image?: string;
+	to_image?: string;
+	from_revision?: string;
+	to_revision?: string;
+	git_url?: string;
+	compare_url?: string;
+	commits?: Commit[];
+	total_commits?: number;
+	pull_requests?: PullRequest[];
+}
+
+export interface Commit {
+	sha: string;
+	subject: string;
+	author: string;
+	url: string;
+}
+
+export interface PullRequest {
+	number: number;
+	title: string;
+	author: string;
+	url: string;
+	merged_at: string;
+}
+
+export interface ScenarioChange {
+	name: string;
+	change: "regressed" | "recovered" | "added" | "removed";
+	from_status?: string;
+	to_status?: string;
+	delta: TestCounts;
+}
+
+export interface ScenarioRun {
+	snapshot: string;
+	created_at: string;
+	status: string;
+	tests: number;
+	passed: number;
+	failed: number;
+	skipped: number;
+	duration_ms: number;
+	pass_rate: number | null;
+}
+
+export interface ScenarioTrend {
+	application: string;
+	scenario: string;
+	runs: ScenarioRun[];
+	pass_rate: number | null;
+	mean_duration_ms: number;
+	duration_change: number | null;
+}
+
+export interface ScenarioPassRate {
+	scenario: string;
+	required: boolean;
+	windows: PassRateWindow[];
+	last_failure: ScenarioFailure | null;
+}
+
+export interface PassRateWindow {
+	days: number;
+	runs: number;
+	failed_runs: number;
+	pass_rate: number | null;
+}
+
+export interface ScenarioFailure {
+	snapshot: string;
+	created_at: string;
+	failed: number;
+}
+
+export interface SnapshotDiff {
+	from: SnapshotRecord;
+	to: SnapshotRecord;
+	components: ComponentChange[];
+	scenarios: ScenarioChange[];
+	tests: { from: TestCounts; to: TestCounts; delta: TestCounts };
+}
+
+/** A match of GET /api/v1/search; type tells which optional fields are set. */
+export interface SearchResult {
+	type: "issue" | "snapshot" | "component" | "failure";
+	/** Issue key, or the name of the snapshot, component or failed test case. */
+	name: string;
+	/** Issue summary, component description or start of the failure message. */
+	summary?: string;
+	fix_version?: string;
+	status?: string;
+	link?: string;
+	/** Snapshot of a failure, or the newest snapshot including a component. */
+	snapshot?: string;
+	application?: string;
+	created_at?: string;
+	scenario?: string;
+}
+
+/** A failed test case and the snapshot it failed in. */
+export interface TestFailure {
+	application: string;
+	snapshot: string;
+	created_at: string;
+	scenario: string;
+	test: string;
+	message: string;
+}
+
+/** Recent failures sharing a failure message, from GET /api/v1/failure-groups. */
+export interface FailureGroup {
+	/**
+	 * The message with run-specific parts masked, e.g. <uuid> or <time>;
+	 * empty for the group of failures without a message.
+	 */
+	message: string;
+	failures: number;
+	/** Newest first. */
+	snapshots: string[];
+	applications: string[];
+	tests: { scenario: string; test: string }[];
+	first_seen: string;
+	last_seen: string;
+	/** The newest failure, with its message as reported. */
+	latest: TestFailure;
+}
@@ -0,0 +1,186 @@
+import {
+	Alert,
+	Button,
+	Card,
+	CardBody,
+	CardTitle,
+	Flex,
+	FlexItem,
+	FormSelect,
+	FormSelectOption,
+	Label,
+	TextInput,
+} from "@patternfly/react-core";
+import { CheckCircleIcon, OutlinedClockIcon } from "@patternfly/react-icons";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { useState } from "react";
+import { createReleaseApproval, listReleaseApprovals } from "../api/client";
+import type { ApprovalRole } from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { useHasRole } from "../hooks/useRole";
+
+const TOKEN_KEY = "rr-admin-token";
+const ROLES: ApprovalRole[] = ["QE", "Dev", "PM"];
+
+/**
+ * Shows which roles have signed off on a release and the recorded
+ * approvals, and lets a release manager record a new one while the release
+ * is unreleased.
+ */
+export default function ApprovalsCard({
+	version,
+	released,
+	onChange,
+}: {
+	version: string;
+	released: boolean;
+	onChange: () => void;
+}) {
+	const { data, refetch } = useCachedFetch(`approvals:${version}`, () =>
+		listReleaseApprovals(version),
+	);
+	const [token, setToken] = useState(
+		() => sessionStorage.getItem(TOKEN_KEY) ?? "",
+	);
+	const authorized = useHasRole("release-manager");
+	const [approver, setApprover] = useState("");
+	const [role, setRole] = useState<ApprovalRole>("QE");
+	const [comment, setComment] = useState("");
+	const [busy, setBusy] = useState(false);
+	const [error, setError] = useState<string | null>(null);
+
+	const approvals = data ?? [];
+	if (released && approvals.length === 0) return null;
+
+	const approvedRoles = new Set(approvals.map((a) => a.role));
+
+	const submit = () => {
+		setBusy(true);
+		setError(null);
+		createReleaseApproval(version, { approver, role, comment }, token)
+			.then(() => {
+				sessionStorage.setItem(TOKEN_KEY, token);
+				setComment("");
+				refetch();
+				onChange();
+			})
+			.catch((err) => setError(err instanceof Error ? err.message : String(err)))
+			.finally(() => setBusy(false));
+	};
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>
+				<Flex
+					justifyContent={{ default: "justifyContentSpaceBetween" }}
+					alignItems={{ default: "alignItemsCenter" }}
+				>
+					<FlexItem>Sign-off</FlexItem>
+					<FlexItem>
+						<Flex spaceItems={{ default: "spaceItemsSm" }}>
+							{ROLES.map((r) =>
+								approvedRoles.has(r) ? (
+									<Label key={r} color="green" icon={<CheckCircleIcon />}>
+										{r}
+									</Label>
+								) : (
+									<Label key={r} color="grey" icon={<OutlinedClockIcon />}>
+										{r}
+									</Label>
+								),
+							)}
+						</Flex>
+					</FlexItem>
+				</Flex>
+			</CardTitle>
+			<CardBody>
+				{error && (
+					<Alert
+						variant="danger"
+						isInline
+						isPlain
+						title={error}
+						style={{ marginBottom: "0.5rem" }}
+					/>
+				)}
+				{approvals.length > 0 && (
+					<Table variant="compact">
+						<Thead>
+							<Tr>
+								<Th>Role</Th>
+								<Th>Approver</Th>
+								<Th>Comment</Th>
+								<Th>Approved</Th>
+							</Tr>
+						</Thead>
+						<Tbody>
+							{approvals.map((a) => (
+								<Tr key={a.id}>
+									<Td>{a.role}</Td>
+									<Td>{a.approver}</Td>
+									<Td>{a.comment}</Td>
+									<Td>{new Date(a.created_at).toLocaleString()}</Td>
+								</Tr>
+							))}
+						</Tbody>
+					</Table>
+				)}
+				{!released && (
+					<Flex
+						spaceItems={{ default: "spaceItemsSm" }}
+						style={{ marginTop: "0.5rem" }}
+					>
+						<FlexItem>
+							<TextInput
+								aria-label="Approver"
+								placeholder="Approver"
+								value={approver}
+								onChange={(_e, v) => setApprover(v)}
+								style={{ width: "12rem" }}
+							/>
+						</FlexItem>
+						<FlexItem>
+							<FormSelect
+								aria-label="Role"
+								value={role}
+								onChange={(_e, v) => setRole(v as ApprovalRole)}
+							>
+								{ROLES.map((r) => (
+									<FormSelectOption key={r} value={r} label={r} />
+								))}
+							</FormSelect>
+						</FlexItem>
+						<FlexItem grow={{ default: "grow" }}>
+							<TextInput
+								aria-label="Comment"
+								placeholder="Comment"
+								value={comment}
+								onChange={(_e, v) => setComment(v)}
+							/>
+						</FlexItem>
+						<FlexItem>
+							<TextInput
+								type="password"
+								aria-label="API token"
+								placeholder="API token"
+								value={token}
+								onChange={(_e, v) => setToken(v)}
+								style={{ width: "14rem" }}
+							/>
+						</FlexItem>
+						<FlexItem>
+							<Button
+								variant="primary"
+								size="sm"
+								isDisabled={(!token && !authorized) || !approver.trim() || busy}
+								onClick={submit}
+							>
+								Approve
+							</Button>
+						</FlexItem>
+					</Flex>
+				)}
+			</CardBody>
+		</Card>
+	);
+}
@@ -0,0 +1,79 @@
+import { Card, CardBody, CardTitle, Label } from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { listReleaseBackports } from "../api/client";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { formatReleaseName } from "../utils/links";
+import StatusLabel from "./StatusLabel";
+
+/**
+ * Lists issues of a release that were fixed in a newer stream but are still
+ * open here, so z-stream backports are not missed.
+ */
+export default function BackportsCard({ version }: { version: string }) {
+	const { data } = useCachedFetch(`backports:${version}`, () =>
+		listReleaseBackports(version, true),
+	);
+	const backports = data ?? [];
+	if (backports.length === 0) return null;
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>Pending Backports ({backports.length})</CardTitle>
+			<CardBody>
+				<Table variant="compact">
+					<Thead>
+						<Tr>
+							<Th>Issue</Th>
+							<Th>Status</Th>
+							<Th>Newer stream</Th>
+							<Th>Source status</Th>
+							<Th>Matched by</Th>
+						</Tr>
+					</Thead>
+					<Tbody>
+						{backports.map((b) => (
+							<Tr
+								key={`${b.issue.key}-${b.source.key}-${b.source.fix_version}`}
+							>
+								<Td>
+									<a
+										href={b.issue.link}
+										target="_blank"
+										rel="noopener noreferrer"
+									>
+										{b.issue.key}
+									</a>{" "}
+									{b.issue.summary}
+								</Td>
+								<Td>
+									<StatusLabel status={b.issue.status} />
+								</Td>
+								<Td>
+									<a
+										href={b.source.link}
+										target="_blank"
+										rel="noopener noreferrer"
+									>
+										{b.source.key}
+									</a>{" "}
+									({formatReleaseName(b.source.fix_version)})
+								</Td>
+								<Td>
+									<StatusLabel status={b.source.status} />
+								</Td>
+								<Td>
+									<Label
+										color={b.match === "clone" ? "blue" : "grey"}
+										isCompact
+									>
+										{b.match === "clone" ? "Clone link" : "Summary"}
+									</Label>
+								</Td>
+							</Tr>
+						))}
+					</Tbody>
+				</Table>
+			</CardBody>
+		</Card>
+	);
+}
@@ -0,0 +1,80 @@
+import { Card, CardBody, CardTitle, Label } from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { listBlockedIssues } from "../api/client";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { isDone } from "../utils/format";
+import StatusLabel from "./StatusLabel";
+
+/**
+ * Lists issues of a release blocked by or depending on unresolved issues.
+ * Issues already done in JIRA are flagged: they count as open until their
+ * blockers are resolved.
+ */
+export default function BlockedIssuesCard({ version }: { version: string }) {
+	const { data } = useCachedFetch(`blocked-issues:${version}`, () =>
+		listBlockedIssues(version),
+	);
+	const issues = data ?? [];
+	if (issues.length === 0) return null;
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>Blocked Issues ({issues.length})</CardTitle>
+			<CardBody>
+				<Table variant="compact">
+					<Thead>
+						<Tr>
+							<Th>Issue</Th>
+							<Th>Status</Th>
+							<Th>Blocked by</Th>
+						</Tr>
+					</Thead>
+					<Tbody>
+						{issues.map((issue) => (
+							<Tr key={issue.key}>
+								<Td>
+									<a
+										href={issue.link}
+										target="_blank"
+										rel="noopener noreferrer"
+									>
+										{issue.key}
+									</a>{" "}
+									{issue.summary}
+								</Td>
+								<Td>
+									<StatusLabel status={issue.status} />
+									{isDone(issue.status) && (
+										<Label
+											color="orange"
+											isCompact
+											style={{ marginLeft: 4 }}
+										>
+											Counted as open
+										</Label>
+									)}
+								</Td>
+								<Td>
+									{(issue.blocked_by ?? [])
+										.filter((b) => !isDone(b.status))
+										.map((b) => (
+											<div key={b.key}>
+												<a
+													href={b.link}
+													target="_blank"
+													rel="noopener noreferrer"
+												>
+													{b.key}
+												</a>{" "}
+												{b.summary} <StatusLabel status={b.status} />
+											</div>
+										))}
+								</Td>
+							</Tr>
+						))}
+					</Tbody>
+				</Table>
+			</CardBody>
+		</Card>
+	);
+}
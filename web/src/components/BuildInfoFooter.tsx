@@ -0,0 +1,22 @@
+import { getVersion } from "../api/client";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+
+const VERSION_TTL_MS = 60 * 60_000;
+
+/** Shows the dashboard build (version, commit, date) so bug reports can cite it. */
+export default function BuildInfoFooter() {
+	const { data } = useCachedFetch("version", getVersion, VERSION_TTL_MS);
+	if (!data) return null;
+
+	const parts = [data.version];
+	if (data.commit) {
+		parts.push(data.commit.substring(0, 12) + (data.modified ? "-dirty" : ""));
+	}
+	if (data.date) parts.push(data.date);
+
+	return (
+		<footer className="rr-build-info">
+			Release Readiness {parts.join(" · ")}
+		</footer>
+	);
+}
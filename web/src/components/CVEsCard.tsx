@@ -0,0 +1,120 @@
+import { Card, CardBody, CardTitle, Label } from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { listReleaseCVEs } from "../api/client";
+import type { IssueSummary } from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import StatusLabel from "./StatusLabel";
+
+const severityColor: Record<string, "red" | "orange" | "yellow" | "grey"> = {
+	Critical: "red",
+	Important: "orange",
+	Moderate: "yellow",
+	Low: "grey",
+};
+
+function SeverityLabel({ severity }: { severity?: string }) {
+	return (
+		<Label color={severityColor[severity ?? ""] ?? "grey"} isCompact>
+			{severity || "Unrated"}
+		</Label>
+	);
+}
+
+/**
+ * Lists a release's CVE issues with their CVE ID, CVSS score and embargo
+ * state, under a per-severity breakdown from the issue summary.
+ */
+export default function CVEsCard({
+	version,
+	summary,
+}: {
+	version: string;
+	summary: IssueSummary | null;
+}) {
+	const { data } = useCachedFetch(`cves:${version}`, () =>
+		listReleaseCVEs(version),
+	);
+	const cves = data ?? [];
+	if (cves.length === 0) return null;
+	const severities = summary?.cve_severities ?? [];
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>CVEs ({cves.length})</CardTitle>
+			<CardBody>
+				{severities.length > 0 && (
+					<Table variant="compact" style={{ marginBottom: "1rem" }}>
+						<Thead>
+							<Tr>
+								<Th>Severity</Th>
+								<Th>Open</Th>
+								<Th>Total</Th>
+								<Th>Embargoed</Th>
+								<Th>Max CVSS</Th>
+							</Tr>
+						</Thead>
+						<Tbody>
+							{severities.map((s) => (
+								<Tr key={s.severity}>
+									<Td>
+										<SeverityLabel severity={s.severity} />
+									</Td>
+									<Td>{s.open}</Td>
+									<Td>{s.total}</Td>
+									<Td>{s.embargoed}</Td>
+									<Td>
+										{s.max_cvss ? s.max_cvss.toFixed(1) : "—"}
+									</Td>
+								</Tr>
+							))}
+						</Tbody>
+					</Table>
+				)}
+				<Table variant="compact">
+					<Thead>
+						<Tr>
+							<Th>Issue</Th>
+							<Th>CVE</Th>
+							<Th>Severity</Th>
+							<Th>CVSS</Th>
+							<Th>Status</Th>
+						</Tr>
+					</Thead>
+					<Tbody>
+						{cves.map((c) => (
+							<Tr key={c.key}>
+								<Td>
+									<a
+										href={c.link}
+										target="_blank"
+										rel="noopener noreferrer"
+									>
+										{c.key}
+									</a>{" "}
+									{c.summary}
+								</Td>
+								<Td>
+									{c.cve_id || "—"}{" "}
+									{c.embargoed && (
+										<Label color="purple" isCompact>
+											Embargoed
+										</Label>
+									)}
+								</Td>
+								<Td>
+									<SeverityLabel severity={c.severity} />
+								</Td>
+								<Td>
+									{c.cvss_score ? c.cvss_score.toFixed(1) : "—"}
+								</Td>
+								<Td>
+									<StatusLabel status={c.status} />
+								</Td>
+							</Tr>
+						))}
+					</Tbody>
+				</Table>
+			</CardBody>
+		</Card>
+	);
+}
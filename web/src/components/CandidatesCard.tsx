@@ -0,0 +1,208 @@
+import {
+	Alert,
+	Button,
+	Card,
+	CardBody,
+	CardTitle,
+	Flex,
+	FlexItem,
+	Label,
+	TextInput,
+} from "@patternfly/react-core";
+import {
+	CheckCircleIcon,
+	ExclamationCircleIcon,
+	StarIcon,
+} from "@patternfly/react-icons";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { useState } from "react";
+import { listReleaseCandidates, setCandidateState } from "../api/client";
+import type { CandidateState, ReleaseCandidate } from "../api/types";
+import { seedCache, useCachedFetch } from "../hooks/useCachedFetch";
+import { useHasRole } from "../hooks/useRole";
+
+const TOKEN_KEY = "rr-admin-token";
+
+/**
+ * Lists the snapshots considered for a release with their test results, and
+ * lets a release manager promote or demote them, with an API token or as
+ * a member of a group granted the role.
+ * The selected candidate is the one that feeds readiness.
+ */
+export default function CandidatesCard({
+	version,
+	onChange,
+}: {
+	version: string;
+	onChange: () => void;
+}) {
+	const key = `candidates:${version}`;
+	const { data, refetch } = useCachedFetch(key, () =>
+		listReleaseCandidates(version),
+	);
+	const [token, setToken] = useState(
+		() => sessionStorage.getItem(TOKEN_KEY) ?? "",
+	);
+	const authorized = useHasRole("release-manager");
+	const [busy, setBusy] = useState<string | null>(null);
+	const [error, setError] = useState<string | null>(null);
+
+	const candidates = data ?? [];
+	if (candidates.length === 0) return null;
+
+	const update = (snapshot: string, state: CandidateState) => {
+		setBusy(snapshot);
+		setError(null);
+		setCandidateState(version, snapshot, state, token)
+			.then((updated) => {
+				sessionStorage.setItem(TOKEN_KEY, token);
+				seedCache(key, updated);
+				refetch();
+				onChange();
+			})
+			.catch((err) => setError(err instanceof Error ? err.message : String(err)))
+			.finally(() => setBusy(null));
+	};
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>
+				<Flex
+					justifyContent={{ default: "justifyContentSpaceBetween" }}
+					alignItems={{ default: "alignItemsCenter" }}
+				>
+					<FlexItem>Candidate Snapshots</FlexItem>
+					<FlexItem>
+						<TextInput
+							type="password"
+							aria-label="API token"
+							placeholder="API token"
+							value={token}
+							onChange={(_e, v) => setToken(v)}
+							style={{ width: "14rem" }}
+						/>
+					</FlexItem>
+				</Flex>
+			</CardTitle>
+			<CardBody>
+				{error && (
+					<Alert
+						variant="danger"
+						isInline
+						isPlain
+						title={error}
+						style={{ marginBottom: "0.5rem" }}
+					/>
+				)}
+				<Table variant="compact">
+					<Thead>
+						<Tr>
+							<Th>Snapshot</Th>
+							<Th>Created</Th>
+							<Th>Tests</Th>
+							<Th>State</Th>
+							<Th screenReaderText="Actions" />
+						</Tr>
+					</Thead>
+					<Tbody>
+						{candidates.map((c) => (
+							<CandidateRow
+								key={c.snapshot.id}
+								candidate={c}
+								disabled={(!token && !authorized) || busy !== null}
+								onUpdate={(state) => update(c.snapshot.name, state)}
+							/>
+						))}
+					</Tbody>
+				</Table>
+			</CardBody>
+		</Card>
+	);
+}
+
+function CandidateRow({
+	candidate,
+	disabled,
+	onUpdate,
+}: {
+	candidate: ReleaseCandidate;
+	disabled: boolean;
+	onUpdate: (state: CandidateState) => void;
+}) {
+	const { snapshot, state, selected } = candidate;
+	return (
+		<Tr isRowSelected={selected}>
+			<Td>
+				{snapshot.name}
+				{selected && (
+					<Label
+						color="blue"
+						icon={<StarIcon />}
+						isCompact
+						style={{ marginLeft: "0.5rem" }}
+					>
+						Feeds readiness
+					</Label>
+				)}
+			</Td>
+			<Td>{new Date(snapshot.created_at).toLocaleString()}</Td>
+			<Td>
+				{!snapshot.has_tests ? (
+					<Label color="grey">N/A</Label>
+				) : snapshot.tests_passed ? (
+					<Label color="green" icon={<CheckCircleIcon />}>
+						Passed
+					</Label>
+				) : (
+					<Label color="red" icon={<ExclamationCircleIcon />}>
+						Failed
+					</Label>
+				)}
+			</Td>
+			<Td>
+				{state === "promoted" ? (
+					<Label color="blue">Promoted</Label>
+				) : state === "demoted" ? (
+					<Label color="orange">Demoted</Label>
+				) : (
+					<Label color="grey">Candidate</Label>
+				)}
+			</Td>
+			<Td isActionCell>
+				<Flex spaceItems={{ default: "spaceItemsSm" }}>
+					{state !== "promoted" && (
+						<Button
+							variant="secondary"
+							size="sm"
+							isDisabled={disabled}
+							onClick={() => onUpdate("promoted")}
+						>
+							Promote
+						</Button>
+					)}
+					{state !== "demoted" && (
+						<Button
+							variant="secondary"
+							isDanger
+							size="sm"
+							isDisabled={disabled}
+							onClick={() => onUpdate("demoted")}
+						>
+							Demote
+						</Button>
+					)}
+					{state !== "candidate" && (
+						<Button
+							variant="link"
+							size="sm"
+							isDisabled={disabled}
+							onClick={() => onUpdate("candidate")}
+						>
+							Reset
+						</Button>
+					)}
+				</Flex>
+			</Td>
+		</Tr>
+	);
+}
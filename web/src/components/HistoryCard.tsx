@@ -0,0 +1,101 @@
+import { Card, CardBody, CardTitle } from "@patternfly/react-core";
+import { getReleaseHistory, releaseHistoryCsvUrl } from "../api/client";
+import type { ReadinessPoint } from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+
+const WIDTH = 720;
+const HEIGHT = 160;
+const PAD = 24;
+
+const SIGNAL_COLORS: Record<ReadinessPoint["signal"], string> = {
+	green: "var(--pf-t--global--color--status--success--default)",
+	yellow: "var(--pf-t--global--color--status--warning--default)",
+	red: "var(--pf-t--global--color--status--danger--default)",
+};
+
+/**
+ * Charts a release's open issues over time, shading the background with the
+ * readiness signal in effect. Each recorded point holds until the next, so
+ * the line is drawn as steps up to now.
+ */
+export default function HistoryCard({ version }: { version: string }) {
+	const { data } = useCachedFetch(`history:${version}`, () =>
+		getReleaseHistory(version),
+	);
+	const points = data ?? [];
+	if (points.length < 2) return null;
+
+	const times = points.map((p) => new Date(p.recorded_at).getTime());
+	const start = times[0];
+	const end = Math.max(Date.now(), times[times.length - 1]);
+	const maxOpen = Math.max(1, ...points.map((p) => p.open));
+	const x = (t: number) =>
+		PAD + ((t - start) / Math.max(1, end - start)) * (WIDTH - 2 * PAD);
+	const y = (open: number) =>
+		HEIGHT - PAD - (open / maxOpen) * (HEIGHT - 2 * PAD);
+
+	let path = `M ${x(times[0])} ${y(points[0].open)}`;
+	for (let i = 1; i < points.length; i++) {
+		path += ` H ${x(times[i])} V ${y(points[i].open)}`;
+	}
+	path += ` H ${x(end)}`;
+
+	const last = points[points.length - 1];
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>
+				Open issues over time ({last.open} open of {last.total}){" "}
+				<a href={releaseHistoryCsvUrl(version)} download>
+					<small>Export CSV</small>
+				</a>
+			</CardTitle>
+			<CardBody>
+				<svg
+					viewBox={`0 0 ${WIDTH} ${HEIGHT}`}
+					width="100%"
+					role="img"
+					aria-label={`Open issues of ${version} over time`}
+				>
+					{points.map((p, i) => (
+						<rect
+							key={p.recorded_at}
+							x={x(times[i])}
+							y={PAD}
+							width={
+								x(i + 1 < points.length ? times[i + 1] : end) - x(times[i])
+							}
+							height={HEIGHT - 2 * PAD}
+							fill={SIGNAL_COLORS[p.signal]}
+							opacity={0.12}
+						>
+							<title>
+								{`${new Date(p.recorded_at).toLocaleString()}: ${p.signal}, ${p.open} open, ${p.blocking_cves} blocking CVEs`}
+							</title>
+						</rect>
+					))}
+					<path
+						d={path}
+						fill="none"
+						stroke="var(--pf-t--global--text--color--regular)"
+						strokeWidth={2}
+					/>
+					<text x={PAD} y={PAD - 6} fontSize={11}>
+						{maxOpen}
+					</text>
+					<text x={PAD} y={HEIGHT - 6} fontSize={11}>
+						{new Date(start).toLocaleDateString()}
+					</text>
+					<text
+						x={WIDTH - PAD}
+						y={HEIGHT - 6}
+						fontSize={11}
+						textAnchor="end"
+					>
+						{new Date(end).toLocaleDateString()}
+					</text>
+				</svg>
+			</CardBody>
+		</Card>
+	);
+}
@@ -0,0 +1,102 @@
+import {
+	Content,
+	DescriptionList,
+	DescriptionListDescription,
+	DescriptionListGroup,
+	DescriptionListTerm,
+} from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import type { PipelineRun } from "../api/types";
+import { formatDuration } from "../utils/format";
+import StatusLabel from "./StatusLabel";
+
+function duration(ms: number): string {
+	return ms > 0 ? formatDuration(ms / 1000) : "\u2014";
+}
+
+/** Status, timing and task breakdown of the PipelineRun behind a test suite. */
+export default function PipelineRunDetails({
+	run,
+	link,
+}: {
+	run: PipelineRun;
+	link?: string;
+}) {
+	const name = run.namespace ? `${run.namespace}/${run.name}` : run.name;
+	return (
+		<div style={{ marginBottom: "1rem" }}>
+			<DescriptionList
+				isCompact
+				isHorizontal
+				columnModifier={{ default: "2Col" }}
+			>
+				<DescriptionListGroup>
+					<DescriptionListTerm>PipelineRun</DescriptionListTerm>
+					<DescriptionListDescription>
+						{link?.startsWith("http") ? (
+							<a href={link} target="_blank" rel="noopener noreferrer">
+								{name}
+							</a>
+						) : (
+							name
+						)}
+					</DescriptionListDescription>
+				</DescriptionListGroup>
+				<DescriptionListGroup>
+					<DescriptionListTerm>Status</DescriptionListTerm>
+					<DescriptionListDescription>
+						<StatusLabel status={run.status} />
+					</DescriptionListDescription>
+				</DescriptionListGroup>
+				<DescriptionListGroup>
+					<DescriptionListTerm>Started</DescriptionListTerm>
+					<DescriptionListDescription>
+						{run.start_time
+							? new Date(run.start_time).toLocaleString()
+							: "\u2014"}
+					</DescriptionListDescription>
+				</DescriptionListGroup>
+				<DescriptionListGroup>
+					<DescriptionListTerm>Duration</DescriptionListTerm>
+					<DescriptionListDescription>
+						{duration(run.duration_ms)}
+					</DescriptionListDescription>
+				</DescriptionListGroup>
+			</DescriptionList>
+			{run.status !== "succeeded" && run.message && (
+				<Content component="p" style={{ marginTop: "0.5rem" }}>
+					{run.reason ? <strong>{run.reason}: </strong> : null}
+					{run.message}
+				</Content>
+			)}
+			{run.tasks.length > 0 && (
+				<Table
+					variant="compact"
+					borders={false}
+					aria-label="Pipeline tasks"
+				>
+					<Thead>
+						<Tr>
+							<Th>Task</Th>
+							<Th modifier="fitContent">Status</Th>
+							<Th modifier="fitContent">Duration</Th>
+							<Th>Message</Th>
+						</Tr>
+					</Thead>
+					<Tbody>
+						{run.tasks.map((task, i) => (
+							<Tr key={`${task.name}-${i}`}>
+								<Td>{task.name}</Td>
+								<Td>
+									<StatusLabel status={task.status} />
+								</Td>
+								<Td>{duration(task.duration_ms)}</Td>
+								<Td>{task.status === "failed" ? task.message : ""}</Td>
+							</Tr>
+						))}
+					</Tbody>
+				</Table>
+			)}
+		</div>
+	);
+}
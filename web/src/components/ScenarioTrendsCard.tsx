@@ -0,0 +1,159 @@
+import { Card, CardBody, CardTitle, Label } from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { getScenarioTrend } from "../api/client";
+import type { SnapshotRecord } from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { formatDuration } from "../utils/format";
+
+const SPARK_WIDTH = 120;
+const SPARK_HEIGHT = 24;
+
+// A scenario whose newer runs take this much longer than its older ones is
+// flagged as slowing down.
+const SLOWDOWN_THRESHOLD = 0.2;
+
+/**
+ * Shows the pass rate and duration of each test scenario of a snapshot over
+ * the application's recent snapshots, to catch suites that slowly get
+ * slower.
+ */
+export default function ScenarioTrendsCard({
+	snapshot,
+}: {
+	snapshot: SnapshotRecord;
+}) {
+	const suites = snapshot.test_suites ?? [];
+	if (suites.length === 0) return null;
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>Scenario Trends</CardTitle>
+			<CardBody>
+				<Table variant="compact">
+					<Thead>
+						<Tr>
+							<Th>Scenario</Th>
+							<Th>Pass rate</Th>
+							<Th>Duration</Th>
+							<Th>Change</Th>
+						</Tr>
+					</Thead>
+					<Tbody>
+						{suites.map((ts) => (
+							<ScenarioTrendRow
+								key={ts.id}
+								app={snapshot.application}
+								scenario={ts.name}
+							/>
+						))}
+					</Tbody>
+				</Table>
+			</CardBody>
+		</Card>
+	);
+}
+
+function ScenarioTrendRow({
+	app,
+	scenario,
+}: {
+	app: string;
+	scenario: string;
+}) {
+	const { data: trend } = useCachedFetch(`trend:${app}:${scenario}`, () =>
+		getScenarioTrend(app, scenario),
+	);
+	if (!trend) {
+		return (
+			<Tr>
+				<Td>{scenario}</Td>
+				<Td>{"\u2014"}</Td>
+				<Td>{"\u2014"}</Td>
+				<Td>{"\u2014"}</Td>
+			</Tr>
+		);
+	}
+
+	const rates = trend.runs.map((r) => r.pass_rate);
+	const durations = trend.runs.map((r) => r.duration_ms);
+	const change = trend.duration_change;
+	return (
+		<Tr>
+			<Td>{scenario}</Td>
+			<Td>
+				<Sparkline
+					values={rates}
+					min={0}
+					max={1}
+					label={`Pass rate of ${scenario} over ${trend.runs.length} snapshots`}
+				/>{" "}
+				{trend.pass_rate === null
+					? "\u2014"
+					: `${Math.round(trend.pass_rate * 100)}%`}
+			</Td>
+			<Td>
+				<Sparkline
+					values={durations}
+					min={0}
+					label={`Duration of ${scenario} over ${trend.runs.length} snapshots`}
+				/>{" "}
+				{formatDuration(trend.mean_duration_ms / 1000)}
+			</Td>
+			<Td>
+				{change === null ? (
+					"\u2014"
+				) : (
+					<Label
+						isCompact
+						color={change >= SLOWDOWN_THRESHOLD ? "orange" : "grey"}
+					>
+						{change >= 0 ? "+" : ""}
+						{Math.round(change * 100)}%
+					</Label>
+				)}
+			</Td>
+		</Tr>
+	);
+}
+
+/** Draws values as a line, skipping nulls; max defaults to the largest value. */
+function Sparkline({
+	values,
+	min,
+	max,
+	label,
+}: {
+	values: (number | null)[];
+	min: number;
+	max?: number;
+	label: string;
+}) {
+	const present = values.filter((v): v is number => v !== null);
+	const top = max ?? Math.max(min + 1, ...present);
+	const x = (i: number) =>
+		values.length < 2
+			? SPARK_WIDTH / 2
+			: (i / (values.length - 1)) * SPARK_WIDTH;
+	const y = (v: number) =>
+		SPARK_HEIGHT - 2 - ((v - min) / (top - min)) * (SPARK_HEIGHT - 4);
+	const points = values
+		.map((v, i) => (v === null ? null : `${x(i)},${y(v)}`))
+		.filter((p) => p !== null)
+		.join(" ");
+	return (
+		<svg
+			width={SPARK_WIDTH}
+			height={SPARK_HEIGHT}
+			role="img"
+			aria-label={label}
+			style={{ verticalAlign: "middle" }}
+		>
+			<polyline
+				points={points}
+				fill="none"
+				stroke="var(--pf-t--global--text--color--regular)"
+				strokeWidth={1.5}
+			/>
+		</svg>
+	);
+}
@@ -0,0 +1,129 @@
+import { Card, CardBody, CardTitle } from "@patternfly/react-core";
+import { getScopeChanges } from "../api/client";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+
+const WIDTH = 720;
+const HEIGHT = 160;
+const PAD = 24;
+
+/**
+ * Charts the net number of issues added to a release since its first sync,
+ * with the late window before the due date shaded, so late scope creep
+ * stands out.
+ */
+export default function ScopeChangesCard({ version }: { version: string }) {
+	const { data } = useCachedFetch(`scope-changes:${version}`, () =>
+		getScopeChanges(version),
+	);
+	if (!data || data.changes.length === 0) return null;
+
+	const changes = data.changes;
+	const times = changes.map((c) => new Date(c.changed_at).getTime());
+	const due = data.due_date ? new Date(data.due_date).getTime() : undefined;
+	const lateSince = data.late_since
+		? new Date(data.late_since).getTime()
+		: undefined;
+	const start = Math.min(times[0], lateSince ?? times[0]);
+	const end = Math.max(Date.now(), times[times.length - 1], due ?? 0);
+
+	const net: number[] = [];
+	let n = 0;
+	for (const c of changes) {
+		n += c.change === "added" ? 1 : -1;
+		net.push(n);
+	}
+	const minNet = Math.min(0, ...net);
+	const maxNet = Math.max(1, ...net);
+
+	const x = (t: number) =>
+		PAD + ((t - start) / Math.max(1, end - start)) * (WIDTH - 2 * PAD);
+	const y = (v: number) =>
+		HEIGHT -
+		PAD -
+		((v - minNet) / Math.max(1, maxNet - minNet)) * (HEIGHT - 2 * PAD);
+
+	let path = `M ${x(start)} ${y(0)}`;
+	for (let i = 0; i < changes.length; i++) {
+		path += ` H ${x(times[i])} V ${y(net[i])}`;
+	}
+	path += ` H ${x(end)}`;
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>
+				Scope changes (+{data.added} / −{data.removed}
+				{data.late_since ? `, ${data.late_added} added late` : ""})
+			</CardTitle>
+			<CardBody>
+				<svg
+					viewBox={`0 0 ${WIDTH} ${HEIGHT}`}
+					width="100%"
+					role="img"
+					aria-label={`Issues added to and removed from ${version} over time`}
+				>
+					{lateSince !== undefined && (
+						<rect
+							x={x(lateSince)}
+							y={PAD}
+							width={x(end) - x(lateSince)}
+							height={HEIGHT - 2 * PAD}
+							fill="var(--pf-t--global--color--status--warning--default)"
+							opacity={0.12}
+						>
+							<title>Late window</title>
+						</rect>
+					)}
+					{due !== undefined && (
+						<line
+							x1={x(due)}
+							x2={x(due)}
+							y1={PAD}
+							y2={HEIGHT - PAD}
+							stroke="var(--pf-t--global--color--status--danger--default)"
+							strokeDasharray="4 2"
+						>
+							<title>{`Due ${new Date(due).toLocaleDateString()}`}</title>
+						</line>
+					)}
+					<path
+						d={path}
+						fill="none"
+						stroke="var(--pf-t--global--text--color--regular)"
+						strokeWidth={2}
+					/>
+					{changes.map((c, i) => (
+						<circle
+							key={`${c.issue_key}-${c.changed_at}-${c.change}`}
+							cx={x(times[i])}
+							cy={y(net[i])}
+							r={3}
+							fill={
+								c.change === "added"
+									? "var(--pf-t--global--color--status--warning--default)"
+									: "var(--pf-t--global--color--status--success--default)"
+							}
+						>
+							<title>
+								{`${new Date(c.changed_at).toLocaleString()}: ${c.change} ${c.issue_key} ${c.summary}`}
+							</title>
+						</circle>
+					))}
+					<text x={PAD} y={PAD - 6} fontSize={11}>
+						{maxNet}
+					</text>
+					<text x={PAD} y={HEIGHT - 6} fontSize={11}>
+						{new Date(start).toLocaleDateString()}
+					</text>
+					<text
+						x={WIDTH - PAD}
+						y={HEIGHT - 6}
+						fontSize={11}
+						textAnchor="end"
+					>
+						{new Date(end).toLocaleDateString()}
+					</text>
+				</svg>
+			</CardBody>
+		</Card>
+	);
+}
@@ -0,0 +1,167 @@
+import {
+	Menu,
+	MenuContent,
+	MenuGroup,
+	MenuItem,
+	MenuList,
+	Popper,
+	SearchInput,
+} from "@patternfly/react-core";
+import { useEffect, useRef, useState } from "react";
+import { useNavigate } from "react-router-dom";
+import { search } from "../api/client";
+import type { SearchResult } from "../api/types";
+
+const DEBOUNCE_MS = 250;
+
+const GROUPS: { type: SearchResult["type"]; label: string }[] = [
+	{ type: "issue", label: "Issues" },
+	{ type: "snapshot", label: "Snapshots" },
+	{ type: "component", label: "Components" },
+	{ type: "failure", label: "Test failures" },
+];
+
+/** Dashboard page a result opens, if any; issues open in JIRA instead. */
+function resultPath(r: SearchResult): string | null {
+	switch (r.type) {
+		case "issue":
+			return r.fix_version
+				? `/releases/${encodeURIComponent(r.fix_version)}`
+				: null;
+		case "snapshot":
+			return `/snapshots/${encodeURIComponent(r.name)}`;
+		case "component":
+		case "failure":
+			return r.snapshot ? `/snapshots/${encodeURIComponent(r.snapshot)}` : null;
+	}
+}
+
+/** Second line of a result in the menu. */
+function resultDescription(r: SearchResult): string {
+	switch (r.type) {
+		case "issue":
+			return [r.summary, r.fix_version, r.status].filter(Boolean).join(" · ");
+		case "snapshot":
+			return r.application ?? "";
+		case "component":
+			return r.summary || (r.snapshot ? `Latest in ${r.snapshot}` : "");
+		case "failure":
+			return [r.scenario, r.snapshot, r.summary].filter(Boolean).join(" · ");
+	}
+}
+
+/**
+ * Searches issues, snapshots, components and test failures as the user
+ * types, listing the matches by type under the input.
+ */
+export default function SearchBox() {
+	const navigate = useNavigate();
+	const [query, setQuery] = useState("");
+	const [results, setResults] = useState<SearchResult[] | null>(null);
+	const [isOpen, setIsOpen] = useState(false);
+	const inputRef = useRef<HTMLDivElement>(null);
+	const menuRef = useRef<HTMLDivElement>(null);
+
+	useEffect(() => {
+		const q = query.trim();
+		if (q === "") {
+			setResults(null);
+			return;
+		}
+		let cancelled = false;
+		const timer = setTimeout(() => {
+			search(q)
+				.then((r) => {
+					if (!cancelled) {
+						setResults(r);
+						setIsOpen(true);
+					}
+				})
+				.catch(() => {
+					if (!cancelled) setResults([]);
+				});
+		}, DEBOUNCE_MS);
+		return () => {
+			cancelled = true;
+			clearTimeout(timer);
+		};
+	}, [query]);
+
+	const close = () => {
+		setIsOpen(false);
+		setQuery("");
+	};
+
+	const open = (r: SearchResult) => {
+		if (r.type === "issue" && r.link) {
+			window.open(r.link, "_blank", "noopener");
+		} else {
+			const path = resultPath(r);
+			if (path) navigate(path);
+		}
+		close();
+	};
+
+	const menu = (
+		<Menu ref={menuRef} isScrollable>
+			<MenuContent maxMenuHeight="60vh">
+				{results !== null && results.length === 0 && (
+					<MenuList>
+						<MenuItem isDisabled>No matches</MenuItem>
+					</MenuList>
+				)}
+				{GROUPS.map(({ type, label }) => {
+					const matches = (results ?? []).filter((r) => r.type === type);
+					if (matches.length === 0) return null;
+					return (
+						<MenuGroup key={type} label={label}>
+							<MenuList>
+								{matches.map((r) => (
+									<MenuItem
+										key={`${r.type}:${r.name}:${r.fix_version ?? r.snapshot ?? ""}`}
+										description={resultDescription(r)}
+										onClick={() => open(r)}
+									>
+										{r.name}
+									</MenuItem>
+								))}
+							</MenuList>
+						</MenuGroup>
+					);
+				})}
+			</MenuContent>
+		</Menu>
+	);
+
+	return (
+		<Popper
+			trigger={
+				<div ref={inputRef} style={{ width: 320 }}>
+					<SearchInput
+						aria-label="Search issues, snapshots, components and test failures"
+						placeholder="Search issues, snapshots, failures..."
+						value={query}
+						onChange={(_e, val) => setQuery(val)}
+						onClear={close}
+						onFocus={() => setIsOpen(results !== null)}
+					/>
+				</div>
+			}
+			triggerRef={inputRef}
+			popper={menu}
+			popperRef={menuRef}
+			isVisible={isOpen && results !== null}
+			onDocumentClick={(event) => {
+				const target = event?.target as Node | null;
+				if (
+					target &&
+					!inputRef.current?.contains(target) &&
+					!menuRef.current?.contains(target)
+				) {
+					setIsOpen(false);
+				}
+			}}
+			enableFlip={false}
+		/>
+	);
+}
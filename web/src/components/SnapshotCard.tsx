@@ -0,0 +1,735 @@
+import {
+	Button,
+	Card,
+	CardBody,
+	CardTitle,
+	Flex,
+	FlexItem,
+	Label,
+	Tab,
+	Tabs,
+	TabTitleText,
+	Tooltip,
+} from "@patternfly/react-core";
+import {
+	CheckCircleIcon,
+	DownloadIcon,
+	ExclamationCircleIcon,
+} from "@patternfly/react-icons";
+import {
+	ExpandableRowContent,
+	Table,
+	Tbody,
+	Td,
+	Th,
+	Thead,
+	Tr,
+} from "@patternfly/react-table";
+import { useMemo, useState } from "react";
+import { Link } from "react-router-dom";
+import {
+	downloadSuiteArtifacts,
+	getSnapshotEC,
+	snapshotTestsCsvUrl,
+} from "../api/client";
+import type {
+	ComponentSBOM,
+	ImageVerification,
+	SnapshotRecord,
+	VulnerabilityReport,
+} from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { quayImageUrl } from "../utils/links";
+import GitShaLink from "./GitShaLink";
+import PipelineRunDetails from "./PipelineRunDetails";
+import StatusLabel from "./StatusLabel";
+import TestCasesTable from "./TestCasesTable";
+import VulnerabilitiesTable from "./VulnerabilitiesTable";
+
+/**
+ * Shows a snapshot's test verdict with tabs for its components, test suites,
+ * releases, Enterprise Contract results and security scans. Given the
+ * release it was selected for, the snapshot name links to its detail page.
+ */
+export default function SnapshotCard({
+	snapshot,
+	title = "Selected Snapshot",
+	release,
+}: {
+	snapshot: SnapshotRecord;
+	title?: string;
+	release?: string;
+}) {
+	const { data: ecReport } = useCachedFetch(`ec:${snapshot.name}`, () =>
+		getSnapshotEC(snapshot.name),
+	);
+
+	const [activeSnapshotTab, setActiveSnapshotTab] = useState<string | number>(
+		"components",
+	);
+	const [expandedSuites, setExpandedSuites] = useState<Set<number>>(new Set());
+	const [expandedComponents, setExpandedComponents] = useState<Set<string>>(
+		new Set(),
+	);
+	const [activeArchTab, setActiveArchTab] = useState<Record<string, string>>(
+		{},
+	);
+	const verifications = useMemo(
+		() =>
+			new Map(
+				(snapshot.image_verifications ?? []).map((v) => [v.component, v]),
+			),
+		[snapshot.image_verifications],
+	);
+	const hasSBOMs = (snapshot.components ?? []).some((c) => c.sbom);
+
+	const groupedVulnReports = useMemo(() => {
+		const reports = snapshot.vulnerability_reports;
+		if (!reports || reports.length === 0) return [];
+
+		const map = new Map<string, VulnerabilityReport[]>();
+		for (const rpt of reports) {
+			const existing = map.get(rpt.component);
+			if (existing) {
+				existing.push(rpt);
+			} else {
+				map.set(rpt.component, [rpt]);
+			}
+		}
+
+		return [...map.entries()]
+			.map(([component, compReports]) => ({
+				component,
+				reports: compReports.sort((a, b) => a.arch.localeCompare(b.arch)),
+				total: compReports.reduce((s, r) => s + r.total, 0),
+				critical: compReports.reduce((s, r) => s + r.critical, 0),
+				high: compReports.reduce((s, r) => s + r.high, 0),
+				medium: compReports.reduce((s, r) => s + r.medium, 0),
+				low: compReports.reduce((s, r) => s + r.low, 0),
+				fixable: compReports.reduce((s, r) => s + r.fixable, 0),
+			}))
+			.sort((a, b) => a.component.localeCompare(b.component));
+	}, [snapshot.vulnerability_reports]);
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>{title}</CardTitle>
+			<CardBody>
+				<Flex
+					justifyContent={{ default: "justifyContentSpaceEvenly" }}
+					flexWrap={{ default: "nowrap" }}
+				>
+					<FlexItem style={{ textAlign: "center" }}>
+						<div className="rr-label">Snapshot</div>
+						<div>
+							{release ? (
+								<Link
+									to={`/snapshots/${encodeURIComponent(snapshot.name)}?release=${encodeURIComponent(release)}`}
+								>
+									{snapshot.name}
+								</Link>
+							) : (
+								snapshot.name
+							)}
+						</div>
+					</FlexItem>
+					<FlexItem style={{ textAlign: "center" }}>
+						<div className="rr-label">Tests</div>
+						<div>
+							{!snapshot.has_tests ? (
+								<Label color="grey">N/A</Label>
+							) : snapshot.tests_passed ? (
+								<Label color="green" icon={<CheckCircleIcon />}>
+									Passed
+								</Label>
+							) : (
+								<Label color="red" icon={<ExclamationCircleIcon />}>
+									Failed
+								</Label>
+							)}
+						</div>
+					</FlexItem>
+					<FlexItem style={{ textAlign: "center" }}>
+						<div className="rr-label">Created</div>
+						<div>{new Date(snapshot.created_at).toLocaleString()}</div>
+					</FlexItem>
+				</Flex>
+
+				<Tabs
+					activeKey={activeSnapshotTab}
+					onSelect={(_e, key) => setActiveSnapshotTab(key)}
+					isFilled
+					style={{ marginTop: "1rem" }}
+				>
+					{snapshot.components && snapshot.components.length > 0 && (
+						<Tab
+							eventKey="components"
+							title={
+								<TabTitleText>
+									Components ({snapshot.components.length})
+									{(snapshot.image_digests?.missing ?? 0) > 0 && (
+										<Label color="red" isCompact style={{ marginLeft: 4 }}>
+											Images garbage-collected
+										</Label>
+									)}
+								</TabTitleText>
+							}
+						>
+							<Table variant="compact">
+								<Thead>
+									<Tr>
+										<Th>Component</Th>
+										<Th>Git SHA</Th>
+										<Th>Image</Th>
+										{verifications.size > 0 && <Th>Registry</Th>}
+										{hasSBOMs && <Th>SBOM</Th>}
+									</Tr>
+								</Thead>
+								<Tbody>
+									{snapshot.components.map((c) => {
+										const imgUrl = quayImageUrl(c.image_url);
+										const imgDisplay = c.image_url.includes("/")
+											? (c.image_url.split("/").pop()?.split("@")[0] ??
+												c.image_url)
+											: c.image_url;
+										return (
+											<Tr key={c.id}>
+												<Td>{c.component}</Td>
+												<Td>
+													<GitShaLink
+														component={c.component}
+														sha={c.git_sha}
+														gitUrl={c.git_url}
+													/>
+												</Td>
+												<Td>
+													{imgUrl ? (
+														<a
+															href={imgUrl}
+															target="_blank"
+															rel="noopener noreferrer"
+														>
+															<code style={{ fontSize: "0.85em" }}>
+																{imgDisplay}
+															</code>
+														</a>
+													) : (
+														<code style={{ fontSize: "0.85em" }}>
+															{c.image_url}
+														</code>
+													)}
+												</Td>
+												{verifications.size > 0 && (
+													<Td>
+														<ImageVerificationLabel
+															verification={verifications.get(c.component)}
+														/>
+													</Td>
+												)}
+												{hasSBOMs && (
+													<Td>
+														<SBOMSummary sbom={c.sbom} />
+													</Td>
+												)}
+											</Tr>
+										);
+									})}
+								</Tbody>
+							</Table>
+						</Tab>
+					)}
+
+					{snapshot.test_suites && snapshot.test_suites.length > 0 && (
+						<Tab
+							eventKey="testSuites"
+							title={
+								<TabTitleText>
+									Test Suites ({snapshot.test_suites.length})
+								</TabTitleText>
+							}
+						>
+							<div style={{ textAlign: "right" }}>
+								<a href={snapshotTestsCsvUrl(snapshot.name)} download>
+									Export CSV
+								</a>
+							</div>
+							<Table variant="compact">
+								<Thead>
+									<Tr>
+										<Th screenReaderText="Toggle" />
+										<Th>Suite</Th>
+										<Th>Status</Th>
+										<Th>Tool</Th>
+										<Th modifier="fitContent">Passed</Th>
+										<Th modifier="fitContent">Failed</Th>
+										<Th modifier="fitContent">Skipped</Th>
+										<Th modifier="fitContent">Total</Th>
+										<Th screenReaderText="Actions" />
+									</Tr>
+								</Thead>
+								{snapshot.test_suites.map((ts) => {
+									const isSuiteExpanded = expandedSuites.has(ts.id);
+									return (
+										<Tbody key={ts.id} isExpanded={isSuiteExpanded}>
+											<Tr>
+												<Td
+													expand={{
+														rowIndex: ts.id,
+														isExpanded: isSuiteExpanded,
+														onToggle: () =>
+															setExpandedSuites((prev) => {
+																const next = new Set(prev);
+																if (next.has(ts.id)) {
+																	next.delete(ts.id);
+																} else {
+																	next.add(ts.id);
+																}
+																return next;
+															}),
+													}}
+												/>
+												<Td>{ts.name}</Td>
+												<Td>
+													<StatusLabel status={ts.status} />
+												</Td>
+												<Td>
+													{ts.tool_name}
+													{ts.tool_version ? ` ${ts.tool_version}` : ""}
+												</Td>
+												<Td>{ts.tests === 0 ? "\u2014" : ts.passed}</Td>
+												<Td>{ts.tests === 0 ? "\u2014" : ts.failed}</Td>
+												<Td>{ts.tests === 0 ? "\u2014" : ts.skipped}</Td>
+												<Td>{ts.tests === 0 ? "\u2014" : ts.tests}</Td>
+												<Td modifier="fitContent">
+													<Tooltip content="Download artifacts">
+														<Button
+															variant="plain"
+															aria-label="Download artifacts"
+															style={{ padding: 0 }}
+															onClick={() =>
+																downloadSuiteArtifacts(snapshot.id, ts.id)
+															}
+														>
+															<DownloadIcon />
+														</Button>
+													</Tooltip>
+												</Td>
+											</Tr>
+											{isSuiteExpanded && (
+												<Tr isExpanded>
+													<Td colSpan={9}>
+														<ExpandableRowContent>
+															{ts.run && (
+																<PipelineRunDetails
+																	run={ts.run}
+																	link={ts.pipeline_run}
+																/>
+															)}
+															{ts.test_cases &&
+															ts.test_cases.length > 0 ? (
+																<TestCasesTable
+																	testCases={ts.test_cases}
+																/>
+															) : (
+																<em>No test cases recorded.</em>
+															)}
+														</ExpandableRowContent>
+													</Td>
+												</Tr>
+											)}
+										</Tbody>
+									);
+								})}
+							</Table>
+						</Tab>
+					)}
+					{snapshot.releases && snapshot.releases.length > 0 && (
+						<Tab
+							eventKey="releases"
+							title={
+								<TabTitleText>
+									Releases ({snapshot.releases.length})
+								</TabTitleText>
+							}
+						>
+							<Table variant="compact">
+								<Thead>
+									<Tr>
+										<Th>Release</Th>
+										<Th>Release Plan</Th>
+										<Th>Target</Th>
+										<Th>Status</Th>
+										<Th>Started</Th>
+										<Th>Completed</Th>
+									</Tr>
+								</Thead>
+								<Tbody>
+									{snapshot.releases.map((rel) => (
+										<Tr key={rel.name}>
+											<Td>{rel.name}</Td>
+											<Td>{rel.release_plan}</Td>
+											<Td>{rel.target || "\u2014"}</Td>
+											<Td>
+												{rel.message ? (
+													<Tooltip content={rel.message}>
+														<span>
+															<StatusLabel status={rel.status} />
+														</span>
+													</Tooltip>
+												) : (
+													<StatusLabel status={rel.status} />
+												)}
+											</Td>
+											<Td>
+												{rel.start_time
+													? new Date(rel.start_time).toLocaleString()
+													: "\u2014"}
+											</Td>
+											<Td>
+												{rel.completion_time
+													? new Date(rel.completion_time).toLocaleString()
+													: "\u2014"}
+											</Td>
+										</Tr>
+									))}
+								</Tbody>
+							</Table>
+						</Tab>
+					)}
+					{ecReport && ecReport.components.length > 0 && (
+						<Tab
+							eventKey="enterpriseContract"
+							title={
+								<TabTitleText>
+									Enterprise Contract ({ecReport.components.length})
+								</TabTitleText>
+							}
+						>
+							<Table variant="compact">
+								<Thead>
+									<Tr>
+										<Th>Component</Th>
+										<Th>Result</Th>
+										<Th>Rule</Th>
+										<Th>Finding</Th>
+									</Tr>
+								</Thead>
+								<Tbody>
+									{ecReport.components.flatMap((c) => [
+										<Tr key={c.component}>
+											<Td>
+												<Tooltip content={c.image_url}>
+													<strong>{c.component}</strong>
+												</Tooltip>
+											</Td>
+											<Td>
+												<StatusLabel
+													status={c.success ? "passed" : "failed"}
+												/>
+											</Td>
+											<Td>
+												{c.violations.length} violations,{" "}
+												{c.warnings.length} warnings
+											</Td>
+											<Td />
+										</Tr>,
+										...[
+											...c.violations.map((f) => ({
+												...f,
+												kind: "violation",
+											})),
+											...c.warnings.map((f) => ({ ...f, kind: "warning" })),
+										].map((f, i) => (
+											<Tr key={`${c.component}-${i}`}>
+												<Td />
+												<Td>
+													<Label
+														isCompact
+														color={f.kind === "violation" ? "red" : "orange"}
+													>
+														{f.kind}
+													</Label>
+												</Td>
+												<Td>
+													{f.title ? (
+														<Tooltip content={f.title}>
+															<code>{f.code || "\u2014"}</code>
+														</Tooltip>
+													) : (
+														<code>{f.code || "\u2014"}</code>
+													)}
+												</Td>
+												<Td>
+													{f.message}
+													{f.solution && (
+														<div>
+															<small>{f.solution}</small>
+														</div>
+													)}
+												</Td>
+											</Tr>
+										)),
+									])}
+								</Tbody>
+							</Table>
+						</Tab>
+					)}
+					{groupedVulnReports.length > 0 && (
+						<Tab
+							eventKey="securityScans"
+							title={
+								<TabTitleText>
+									Security Scans ({groupedVulnReports.length})
+								</TabTitleText>
+							}
+						>
+							<Table variant="compact">
+								<Thead>
+									<Tr>
+										<Th screenReaderText="Toggle" />
+										<Th>Component</Th>
+										<Th modifier="fitContent">Architectures</Th>
+										<Th modifier="fitContent">Critical</Th>
+										<Th modifier="fitContent">High</Th>
+										<Th modifier="fitContent">Medium</Th>
+										<Th modifier="fitContent">Low</Th>
+										<Th modifier="fitContent">Total</Th>
+										<Th modifier="fitContent">Fixable</Th>
+									</Tr>
+								</Thead>
+								{groupedVulnReports.map((group, groupIdx) => {
+									const isExpanded = expandedComponents.has(
+										group.component,
+									);
+									const selectedArch =
+										activeArchTab[group.component] ??
+										group.reports[0]?.arch;
+									const selectedReport = group.reports.find(
+										(r) => r.arch === selectedArch,
+									);
+									return (
+										<Tbody key={group.component} isExpanded={isExpanded}>
+											<Tr>
+												<Td
+													expand={{
+														rowIndex: groupIdx,
+														isExpanded,
+														onToggle: () =>
+															setExpandedComponents((prev) => {
+																const next = new Set(prev);
+																if (next.has(group.component)) {
+																	next.delete(group.component);
+																} else {
+																	next.add(group.component);
+																}
+																return next;
+															}),
+													}}
+												/>
+												<Td>{group.component}</Td>
+												<Td>{group.reports.length}</Td>
+												<Td>
+													<SeverityCount
+														count={group.critical}
+														severity="Critical"
+													/>
+												</Td>
+												<Td>
+													<SeverityCount
+														count={group.high}
+														severity="High"
+													/>
+												</Td>
+												<Td>
+													<SeverityCount
+														count={group.medium}
+														severity="Medium"
+													/>
+												</Td>
+												<Td>
+													<SeverityCount
+														count={group.low}
+														severity="Low"
+													/>
+												</Td>
+												<Td>{group.total}</Td>
+												<Td>{group.fixable}</Td>
+											</Tr>
+											{isExpanded && selectedReport && (
+												<Tr isExpanded>
+													<Td colSpan={9}>
+														<ExpandableRowContent>
+															<Tabs
+																isFilled
+																activeKey={selectedArch}
+																onSelect={(_e, key) =>
+																	setActiveArchTab((prev) => ({
+																		...prev,
+																		[group.component]: String(key),
+																	}))
+																}
+															>
+																{group.reports.map((rpt) => (
+																	<Tab
+																		key={rpt.arch}
+																		eventKey={rpt.arch}
+																		title={
+																			<TabTitleText>
+																				{rpt.arch} ({rpt.total})
+																			</TabTitleText>
+																		}
+																	>
+																		<div style={{ padding: "1rem 0" }}>
+																			<Flex
+																				spaceItems={{
+																					default: "spaceItemsLg",
+																				}}
+																				style={{ marginBottom: "1rem" }}
+																			>
+																				<FlexItem>
+																					Critical:{" "}
+																					<SeverityCount
+																						count={rpt.critical}
+																						severity="Critical"
+																					/>
+																				</FlexItem>
+																				<FlexItem>
+																					High:{" "}
+																					<SeverityCount
+																						count={rpt.high}
+																						severity="High"
+																					/>
+																				</FlexItem>
+																				<FlexItem>
+																					Medium:{" "}
+																					<SeverityCount
+																						count={rpt.medium}
+																						severity="Medium"
+																					/>
+																				</FlexItem>
+																				<FlexItem>
+																					Low:{" "}
+																					<SeverityCount
+																						count={rpt.low}
+																						severity="Low"
+																					/>
+																				</FlexItem>
+																				<FlexItem>
+																					Total: {rpt.total}
+																				</FlexItem>
+																				<FlexItem>
+																					Fixable: {rpt.fixable}
+																				</FlexItem>
+																			</Flex>
+																			{rpt.vulnerabilities &&
+																			rpt.vulnerabilities.length > 0 ? (
+																				<VulnerabilitiesTable
+																					vulnerabilities={
+																						rpt.vulnerabilities
+																					}
+																				/>
+																			) : (
+																				<em>
+																					No vulnerabilities recorded.
+																				</em>
+																			)}
+																		</div>
+																	</Tab>
+																))}
+															</Tabs>
+														</ExpandableRowContent>
+													</Td>
+												</Tr>
+											)}
+										</Tbody>
+									);
+								})}
+							</Table>
+						</Tab>
+					)}
+				</Tabs>
+			</CardBody>
+		</Card>
+	);
+}
+
+const severityLabelColor: Record<string, "red" | "orange" | "yellow" | "grey"> =
+	{
+		Critical: "red",
+		High: "red",
+		Medium: "orange",
+		Low: "yellow",
+	};
+
+function SeverityCount({
+	count,
+	severity,
+}: {
+	count: number;
+	severity: string;
+}) {
+	if (count === 0) return <>{"\u2014"}</>;
+	return (
+		<Label color={severityLabelColor[severity] ?? "grey"} isCompact>
+			{count}
+		</Label>
+	);
+}
+
+const verificationLabels: Record<
+	ImageVerification["status"],
+	{ text: string; color: "green" | "red" | "orange" | "grey" }
+> = {
+	ok: { text: "Verified", color: "green" },
+	missing: { text: "Garbage-collected", color: "red" },
+	mismatch: { text: "Tag moved", color: "orange" },
+	error: { text: "Unchecked", color: "grey" },
+};
+
+/** Shows whether a component image still resolves in its registry. */
+function ImageVerificationLabel({
+	verification,
+}: {
+	verification?: ImageVerification;
+}) {
+	if (!verification) return <>{"\u2014"}</>;
+	const { text, color } = verificationLabels[verification.status];
+	const label = (
+		<Label color={color} isCompact>
+			{text}
+			{verification.tag ? ` (${verification.tag})` : ""}
+		</Label>
+	);
+	if (!verification.message) return label;
+	return <Tooltip content={verification.message}>{label}</Tooltip>;
+}
+
+/** Shows a component's SBOM package count and most common licenses. */
+function SBOMSummary({ sbom }: { sbom?: ComponentSBOM }) {
+	if (!sbom) return <>{"\u2014"}</>;
+	const summary = sbom.format ? (
+		<Tooltip
+			content={
+				sbom.licenses && sbom.licenses.length > 0
+					? `Licenses: ${sbom.licenses.join(", ")}`
+					: "No licenses declared"
+			}
+		>
+			<span>{sbom.packages} packages</span>
+		</Tooltip>
+	) : (
+		"\u2014"
+	);
+	return (
+		<>
+			{summary}
+			{sbom.reference && (
+				<div>
+					<code style={{ fontSize: "0.85em" }}>
+						{sbom.reference.split("/").pop()}
+					</code>
+				</div>
+			)}
+		</>
+	);
+}
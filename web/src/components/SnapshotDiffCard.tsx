@@ -0,0 +1,164 @@
+import {
+	Card,
+	CardBody,
+	CardTitle,
+	ExpandableSection,
+	Label,
+} from "@patternfly/react-core";
+import { Table, Tbody, Td, Th, Thead, Tr } from "@patternfly/react-table";
+import { useState } from "react";
+import { diffSnapshots } from "../api/client";
+import type { ComponentChange, PullRequest } from "../api/types";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import GitShaLink from "./GitShaLink";
+
+const CHANGE_COLORS = {
+	added: "green",
+	removed: "red",
+	changed: "blue",
+} as const;
+
+/**
+ * Lists the components that changed from snapshot `from` to snapshot `to`,
+ * with the commits and pull requests in between when the server lists them.
+ */
+export default function SnapshotDiffCard({
+	from,
+	to,
+}: {
+	from: string;
+	to: string;
+}) {
+	const { data: diff, error } = useCachedFetch(`diff:${from}:${to}`, () =>
+		diffSnapshots(from, to),
+	);
+
+	return (
+		<Card isCompact style={{ marginBottom: "1rem" }}>
+			<CardTitle>Changes since {from}</CardTitle>
+			<CardBody>
+				{error ? (
+					error.message
+				) : !diff ? (
+					"Loading\u2026"
+				) : diff.components.length === 0 ? (
+					"No component changes."
+				) : (
+					<Table variant="compact">
+						<Thead>
+							<Tr>
+								<Th>Component</Th>
+								<Th>Change</Th>
+								<Th>Revisions</Th>
+								<Th>Commits</Th>
+								<Th>Pull requests</Th>
+							</Tr>
+						</Thead>
+						<Tbody>
+							{diff.components.map((c) => (
+								<Tr key={c.component}>
+									<Td>{c.component}</Td>
+									<Td>
+										<Label isCompact color={CHANGE_COLORS[c.change]}>
+											{c.change}
+										</Label>
+									</Td>
+									<Td>
+										<Revisions change={c} />
+									</Td>
+									<Td>
+										<CommitList change={c} />
+									</Td>
+									<Td>
+										<PullRequestList prs={c.pull_requests} />
+									</Td>
+								</Tr>
+							))}
+						</Tbody>
+					</Table>
+				)}
+			</CardBody>
+		</Card>
+	);
+}
+
+function Revisions({ change }: { change: ComponentChange }) {
+	const from = change.from_revision ? (
+		<GitShaLink
+			component={change.component}
+			sha={change.from_revision}
+			gitUrl={change.git_url}
+		/>
+	) : null;
+	const to = change.to_revision ? (
+		<GitShaLink
+			component={change.component}
+			sha={change.to_revision}
+			gitUrl={change.git_url}
+		/>
+	) : null;
+	return (
+		<>
+			{from}
+			{from && to && " \u2192 "}
+			{to}
+			{change.compare_url && (
+				<>
+					{" "}
+					<a
+						href={change.compare_url}
+						target="_blank"
+						rel="noopener noreferrer"
+					>
+						compare
+					</a>
+				</>
+			)}
+		</>
+	);
+}
+
+function CommitList({ change }: { change: ComponentChange }) {
+	const [expanded, setExpanded] = useState(false);
+	const commits = change.commits ?? [];
+	if (commits.length === 0) return <>{"\u2014"}</>;
+	const total = change.total_commits ?? commits.length;
+	return (
+		<ExpandableSection
+			toggleText={
+				total > commits.length
+					? `${commits.length} of ${total} commits`
+					: `${total} commit${total === 1 ? "" : "s"}`
+			}
+			isExpanded={expanded}
+			onToggle={(_e, val) => setExpanded(val)}
+		>
+			<ul style={{ margin: 0, paddingLeft: "1rem" }}>
+				{commits.map((c) => (
+					<li key={c.sha}>
+						<a href={c.url} target="_blank" rel="noopener noreferrer">
+							<code>{c.sha.substring(0, 12)}</code>
+						</a>{" "}
+						{c.subject} ({c.author})
+					</li>
+				))}
+			</ul>
+		</ExpandableSection>
+	);
+}
+
+function PullRequestList({ prs }: { prs?: PullRequest[] }) {
+	if (!prs || prs.length === 0) return <>{"\u2014"}</>;
+	return (
+		<ul style={{ margin: 0, paddingLeft: "1rem" }}>
+			{prs.map((pr) => (
+				<li key={pr.number}>
+					<a href={pr.url} target="_blank" rel="noopener noreferrer">
+						#{pr.number}
+					</a>{" "}
+					{pr.title} ({pr.author})
+				</li>
+			))}
+		</ul>
+	);
+}
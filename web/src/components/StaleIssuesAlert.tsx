@@ -0,0 +1,31 @@
+import { Alert } from "@patternfly/react-core";
+import type { IssueSummary } from "../api/types";
+
+/**
+ * Warns that issue counts are out of date when JIRA has not been synced
+ * for a while, giving the time of the oldest stale summary.
+ */
+export default function StaleIssuesAlert({
+	summaries,
+}: {
+	summaries: (IssueSummary | null | undefined)[];
+}) {
+	let asOf = "";
+	for (const s of summaries) {
+		if (s?.stale && s.as_of && (asOf === "" || s.as_of < asOf)) {
+			asOf = s.as_of;
+		}
+	}
+	if (asOf === "") return null;
+	return (
+		<Alert
+			variant="warning"
+			isInline
+			title={`Issue counts may be out of date: JIRA was last synced ${new Date(asOf).toLocaleString()}`}
+			style={{ marginBottom: "1rem" }}
+		>
+			The counts shown are from the last successful sync and update once
+			syncing recovers.
+		</Alert>
+	);
+}
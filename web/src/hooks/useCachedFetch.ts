@@ -6,6 +6,8 @@ interface CacheEntry<T> {
 }
 
 const cache = new Map<string, CacheEntry<unknown>>();
+// Refetch callbacks of the mounted hooks, so live updates can refresh them.
+const listeners = new Set<() => void>();
 const MAX_CACHE_SIZE = 100;
 
 const DEFAULT_TTL_MS = 60_000;
@@ -26,6 +28,20 @@ export function seedCache<T>(key: string, data: T): void {
 	cacheSet(key, { data, timestamp: Date.now() });
 }
 
+/** Drop an entry so the next fetch for key goes to the network. */
+export function invalidateCache(key: string): void {
+	cache.delete(key);
+}
+
+/**
+ * Mark every entry stale and refetch the data of all mounted hooks. Their
+ * current data stays on screen until the new data arrives.
+ */
+export function refreshAll(): void {
+	for (const entry of cache.values()) entry.timestamp = 0;
+	for (const refetch of listeners) refetch();
+}
+
 export function useCachedFetch<T>(
 	key: string | null,
 	fetcher: () => Promise<T>,
@@ -81,6 +97,10 @@ export function useCachedFetch<T>(
 
 	useEffect(() => {
 		doFetch();
+		listeners.add(doFetch);
+		return () => {
+			listeners.delete(doFetch);
+		};
 	}, [doFetch]);
 
 	return { data, loading, error, refetch: doFetch };
@@ -0,0 +1,36 @@
+import { useEffect, useState } from "react";
+import { refreshAll } from "./useCachedFetch";
+
+// Syncs announce changes in bursts, one event per release or snapshot, so
+// the page is refreshed once the burst settles.
+const DEBOUNCE_MS = 2_000;
+
+/**
+ * Subscribe to the server's change events and refresh the data on screen
+ * when snapshots are ingested or JIRA issues change. Returns whether the
+ * event stream is connected; EventSource reconnects on its own.
+ */
+export function useLiveUpdates(): boolean {
+	const [connected, setConnected] = useState(false);
+
+	useEffect(() => {
+		const source = new EventSource("/api/v1/events");
+		let timer: ReturnType<typeof setTimeout> | undefined;
+		const onChange = () => {
+			clearTimeout(timer);
+			timer = setTimeout(refreshAll, DEBOUNCE_MS);
+		};
+		source.onopen = () => setConnected(true);
+		// EventSource retries dropped streams itself. A server without live
+		// updates answers 404, which ends the stream for good.
+		source.onerror = () => setConnected(false);
+		source.addEventListener("snapshot", onChange);
+		source.addEventListener("issues", onChange);
+		return () => {
+			clearTimeout(timer);
+			source.close();
+		};
+	}, []);
+
+	return connected;
+}
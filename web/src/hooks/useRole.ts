@@ -0,0 +1,16 @@
+import { getWhoAmI } from "../api/client";
+import type { Role } from "../api/types";
+import { useCachedFetch } from "./useCachedFetch";
+
+const ROLES: Role[] = ["viewer", "reporter", "release-manager", "admin"];
+const WHOAMI_TTL_MS = 5 * 60_000;
+
+/**
+ * Reports whether an authenticating proxy grants the user role, in which
+ * case actions needing it can be taken without an API token.
+ */
+export function useHasRole(role: Role): boolean {
+	const { data } = useCachedFetch("whoami", getWhoAmI, WHOAMI_TTL_MS);
+	if (!data?.role) return false;
+	return ROLES.indexOf(data.role) >= ROLES.indexOf(role);
+}
@@ -14,27 +14,21 @@ import {
 	MenuToggle,
 	PageSection,
 	Popover,
+	Progress,
+	ProgressMeasureLocation,
 	ProgressStep,
 	ProgressStepper,
 	Select,
 	SelectList,
 	SelectOption,
 	Spinner,
-	Tab,
-	Tabs,
-	TabTitleText,
 	Title,
-	Tooltip,
 } from "@patternfly/react-core";
 import {
-	CheckCircleIcon,
 	ColumnsIcon,
-	DownloadIcon,
-	ExclamationCircleIcon,
 	OutlinedQuestionCircleIcon,
 } from "@patternfly/react-icons";
 import {
-	ExpandableRowContent,
 	Table,
 	Tbody,
 	Td,
@@ -46,34 +40,43 @@ import {
 import { useMemo, useState } from "react";
 import { Link, useParams } from "react-router-dom";
 import {
-	downloadSuiteArtifacts,
 	getRelease,
+	getReleaseAdvisory,
 	getReleaseIssueSummary,
 	getReleaseReadiness,
 	getReleaseSnapshot,
 	listReleaseIssues,
+	releaseIssuesCsvUrl,
+	releaseReportUrl,
 } from "../api/client";
 import type {
+	Advisory,
 	DashboardConfig,
 	IssueSummary,
 	JiraIssue,
 	ReadinessResponse,
 	ReleaseVersion,
 	SnapshotRecord,
-	VulnerabilityReport,
 } from "../api/types";
-import GitShaLink from "../components/GitShaLink";
+import ApprovalsCard from "../components/ApprovalsCard";
+import BackportsCard from "../components/BackportsCard";
+import BlockedIssuesCard from "../components/BlockedIssuesCard";
+import CVEsCard from "../components/CVEsCard";
+import CandidatesCard from "../components/CandidatesCard";
+import HistoryCard from "../components/HistoryCard";
 import PriorityLabel from "../components/PriorityLabel";
+import ScopeChangesCard from "../components/ScopeChangesCard";
+import SnapshotCard from "../components/SnapshotCard";
+import StaleIssuesAlert from "../components/StaleIssuesAlert";
 import StatusLabel from "../components/StatusLabel";
-import TestCasesTable from "../components/TestCasesTable";
-import VulnerabilitiesTable from "../components/VulnerabilitiesTable";
-import { useCachedFetch } from "../hooks/useCachedFetch";
+import { invalidateCache, useCachedFetch } from "../hooks/useCachedFetch";
 import {
 	type ColumnDef,
 	useColumnManagement,
 } from "../hooks/useColumnManagement";
 import { useConfig } from "../hooks/useConfig";
-import { formatReleaseName, jiraIssueUrl, quayImageUrl } from "../utils/links";
+import { isDone } from "../utils/format";
+import { formatReleaseName, jiraIssueUrl } from "../utils/links";
 
 export default function ReleaseDetail() {
 	const { version } = useParams<{ version: string }>();
@@ -83,7 +86,7 @@ export default function ReleaseDetail() {
 		version ? `release:${version}` : null,
 		() => getRelease(version!),
 	);
-	const { data: snapshot } = useCachedFetch(
+	const { data: snapshot, refetch: refetchSnapshot } = useCachedFetch(
 		version ? `snapshot:${version}` : null,
 		() => getReleaseSnapshot(version!),
 	);
@@ -95,49 +98,29 @@ export default function ReleaseDetail() {
 		version ? `issueSummary:${version}` : null,
 		() => getReleaseIssueSummary(version!),
 	);
-	const { data: readinessSignal } = useCachedFetch(
+	const { data: readinessSignal, refetch: refetchReadiness } = useCachedFetch(
 		version ? `readiness:${version}` : null,
 		() => getReleaseReadiness(version!),
 	);
-
-	const [activeSnapshotTab, setActiveSnapshotTab] = useState<string | number>(
-		"components",
-	);
-	const [expandedSuites, setExpandedSuites] = useState<Set<number>>(new Set());
-	const [expandedComponents, setExpandedComponents] = useState<Set<string>>(
-		new Set(),
-	);
-	const [activeArchTab, setActiveArchTab] = useState<Record<string, string>>(
-		{},
+	const { data: advisory } = useCachedFetch(
+		version ? `advisory:${version}` : null,
+		() => getReleaseAdvisory(version!),
 	);
 
-	const groupedVulnReports = useMemo(() => {
-		const reports = snapshot?.vulnerability_reports;
-		if (!reports || reports.length === 0) return [];
-
-		const map = new Map<string, VulnerabilityReport[]>();
-		for (const rpt of reports) {
-			const existing = map.get(rpt.component);
-			if (existing) {
-				existing.push(rpt);
-			} else {
-				map.set(rpt.component, [rpt]);
-			}
-		}
+	// Promoting or demoting a candidate changes which snapshot feeds readiness.
+	const onCandidateChange = () => {
+		invalidateCache(`snapshot:${version}`);
+		invalidateCache(`readiness:${version}`);
+		refetchSnapshot();
+		refetchReadiness();
+	};
 
-		return [...map.entries()]
-			.map(([component, compReports]) => ({
-				component,
-				reports: compReports.sort((a, b) => a.arch.localeCompare(b.arch)),
-				total: compReports.reduce((s, r) => s + r.total, 0),
-				critical: compReports.reduce((s, r) => s + r.critical, 0),
-				high: compReports.reduce((s, r) => s + r.high, 0),
-				medium: compReports.reduce((s, r) => s + r.medium, 0),
-				low: compReports.reduce((s, r) => s + r.low, 0),
-				fixable: compReports.reduce((s, r) => s + r.fixable, 0),
-			}))
-			.sort((a, b) => a.component.localeCompare(b.component));
-	}, [snapshot?.vulnerability_reports]);
+	// A new approval changes the outstanding sign-offs reported by readiness.
+	const onApprovalChange = () => {
+		invalidateCache(`readiness:${version}`);
+		invalidateCache("releasesOverview");
+		refetchReadiness();
+	};
 
 	if (loadingRelease && !release) {
 		return (
@@ -186,391 +169,68 @@ export default function ReleaseDetail() {
 					<FlexItem>
 						<Title headingLevel="h1">{displayName}</Title>
 					</FlexItem>
-					{release.s3_application && (
-						<FlexItem>
-							<Link to={`/releases/${encodeURIComponent(version!)}/snapshots`}>
-								View all snapshots
-							</Link>
-						</FlexItem>
-					)}
+					<FlexItem>
+						<Flex spaceItems={{ default: "spaceItemsMd" }}>
+							<FlexItem>
+								Go/no-go report:{" "}
+								<a
+									href={releaseReportUrl(version!)}
+									target="_blank"
+									rel="noopener noreferrer"
+								>
+									HTML
+								</a>
+								{" \u00b7 "}
+								<a href={releaseReportUrl(version!, "pdf")} download>
+									PDF
+								</a>
+							</FlexItem>
+							{release.s3_application && (
+								<FlexItem>
+									<Link
+										to={`/releases/${encodeURIComponent(version!)}/snapshots`}
+									>
+										View all snapshots
+									</Link>
+								</FlexItem>
+							)}
+						</Flex>
+					</FlexItem>
 				</Flex>
 
+				<StaleIssuesAlert summaries={[issueSummary]} />
+
 				<ReleaseSignal
 					release={release}
 					readiness={readinessSignal ?? null}
+					advisory={advisory ?? null}
 					jiraBaseUrl={config?.jira_base_url}
 					snapshot={snapshot ?? null}
 					issueSummary={issueSummary ?? null}
 				/>
 
-				{snapshot && (
-					<Card isCompact style={{ marginBottom: "1rem" }}>
-						<CardTitle>Latest Snapshot</CardTitle>
-						<CardBody>
-							<Flex
-								justifyContent={{ default: "justifyContentSpaceEvenly" }}
-								flexWrap={{ default: "nowrap" }}
-							>
-								<FlexItem style={{ textAlign: "center" }}>
-									<div className="rr-label">Snapshot</div>
-									<div>{snapshot.name}</div>
-								</FlexItem>
-								<FlexItem style={{ textAlign: "center" }}>
-									<div className="rr-label">Tests</div>
-									<div>
-										{!snapshot.has_tests ? (
-											<Label color="grey">N/A</Label>
-										) : snapshot.tests_passed ? (
-											<Label color="green" icon={<CheckCircleIcon />}>
-												Passed
-											</Label>
-										) : (
-											<Label color="red" icon={<ExclamationCircleIcon />}>
-												Failed
-											</Label>
-										)}
-									</div>
-								</FlexItem>
-								<FlexItem style={{ textAlign: "center" }}>
-									<div className="rr-label">Created</div>
-									<div>{new Date(snapshot.created_at).toLocaleString()}</div>
-								</FlexItem>
-							</Flex>
+				{snapshot && <SnapshotCard snapshot={snapshot} release={version} />}
 
-							<Tabs
-								activeKey={activeSnapshotTab}
-								onSelect={(_e, key) => setActiveSnapshotTab(key)}
-								isFilled
-								style={{ marginTop: "1rem" }}
-							>
-								{snapshot.components && snapshot.components.length > 0 && (
-									<Tab
-										eventKey="components"
-										title={
-											<TabTitleText>
-												Components ({snapshot.components.length})
-											</TabTitleText>
-										}
-									>
-										<Table variant="compact">
-											<Thead>
-												<Tr>
-													<Th>Component</Th>
-													<Th>Git SHA</Th>
-													<Th>Image</Th>
-												</Tr>
-											</Thead>
-											<Tbody>
-												{snapshot.components.map((c) => {
-													const imgUrl = quayImageUrl(c.image_url);
-													const imgDisplay = c.image_url.includes("/")
-														? (c.image_url.split("/").pop()?.split("@")[0] ??
-															c.image_url)
-														: c.image_url;
-													return (
-														<Tr key={c.id}>
-															<Td>{c.component}</Td>
-															<Td>
-																<GitShaLink
-																	component={c.component}
-																	sha={c.git_sha}
-																	gitUrl={c.git_url}
-																/>
-															</Td>
-															<Td>
-																{imgUrl ? (
-																	<a
-																		href={imgUrl}
-																		target="_blank"
-																		rel="noopener noreferrer"
-																	>
-																		<code style={{ fontSize: "0.85em" }}>
-																			{imgDisplay}
-																		</code>
-																	</a>
-																) : (
-																	<code style={{ fontSize: "0.85em" }}>
-																		{c.image_url}
-																	</code>
-																)}
-															</Td>
-														</Tr>
-													);
-												})}
-											</Tbody>
-										</Table>
-									</Tab>
-								)}
+				{version && (
+					<CandidatesCard version={version} onChange={onCandidateChange} />
+				)}
 
-								{snapshot.test_suites && snapshot.test_suites.length > 0 && (
-									<Tab
-										eventKey="testSuites"
-										title={
-											<TabTitleText>
-												Test Suites ({snapshot.test_suites.length})
-											</TabTitleText>
-										}
-									>
-										<Table variant="compact">
-											<Thead>
-												<Tr>
-													<Th screenReaderText="Toggle" />
-													<Th>Suite</Th>
-													<Th>Status</Th>
-													<Th>Tool</Th>
-													<Th modifier="fitContent">Passed</Th>
-													<Th modifier="fitContent">Failed</Th>
-													<Th modifier="fitContent">Skipped</Th>
-													<Th modifier="fitContent">Total</Th>
-													<Th screenReaderText="Actions" />
-												</Tr>
-											</Thead>
-											{snapshot.test_suites.map((ts) => {
-												const isSuiteExpanded = expandedSuites.has(ts.id);
-												return (
-													<Tbody key={ts.id} isExpanded={isSuiteExpanded}>
-														<Tr>
-															<Td
-																expand={{
-																	rowIndex: ts.id,
-																	isExpanded: isSuiteExpanded,
-																	onToggle: () =>
-																		setExpandedSuites((prev) => {
-																			const next = new Set(prev);
-																			if (next.has(ts.id)) {
-																				next.delete(ts.id);
-																			} else {
-																				next.add(ts.id);
-																			}
-																			return next;
-																		}),
-																}}
-															/>
-															<Td>{ts.name}</Td>
-															<Td>
-																<StatusLabel status={ts.status} />
-															</Td>
-															<Td>
-																{ts.tool_name}
-																{ts.tool_version ? ` ${ts.tool_version}` : ""}
-															</Td>
-															<Td>{ts.tests === 0 ? "\u2014" : ts.passed}</Td>
-															<Td>{ts.tests === 0 ? "\u2014" : ts.failed}</Td>
-															<Td>{ts.tests === 0 ? "\u2014" : ts.skipped}</Td>
-															<Td>{ts.tests === 0 ? "\u2014" : ts.tests}</Td>
-															<Td modifier="fitContent">
-																<Tooltip content="Download artifacts">
-																	<Button
-																		variant="plain"
-																		aria-label="Download artifacts"
-																		style={{ padding: 0 }}
-																		onClick={() =>
-																			downloadSuiteArtifacts(snapshot.id, ts.id)
-																		}
-																	>
-																		<DownloadIcon />
-																	</Button>
-																</Tooltip>
-															</Td>
-														</Tr>
-														{isSuiteExpanded && (
-															<Tr isExpanded>
-																<Td colSpan={9}>
-																	<ExpandableRowContent>
-																		{ts.test_cases &&
-																		ts.test_cases.length > 0 ? (
-																			<TestCasesTable
-																				testCases={ts.test_cases}
-																			/>
-																		) : (
-																			<em>No test cases recorded.</em>
-																		)}
-																	</ExpandableRowContent>
-																</Td>
-															</Tr>
-														)}
-													</Tbody>
-												);
-											})}
-										</Table>
-									</Tab>
-								)}
-								{groupedVulnReports.length > 0 && (
-									<Tab
-										eventKey="securityScans"
-										title={
-											<TabTitleText>
-												Security Scans ({groupedVulnReports.length})
-											</TabTitleText>
-										}
-									>
-										<Table variant="compact">
-											<Thead>
-												<Tr>
-													<Th screenReaderText="Toggle" />
-													<Th>Component</Th>
-													<Th modifier="fitContent">Architectures</Th>
-													<Th modifier="fitContent">Critical</Th>
-													<Th modifier="fitContent">High</Th>
-													<Th modifier="fitContent">Medium</Th>
-													<Th modifier="fitContent">Low</Th>
-													<Th modifier="fitContent">Total</Th>
-													<Th modifier="fitContent">Fixable</Th>
-												</Tr>
-											</Thead>
-											{groupedVulnReports.map((group, groupIdx) => {
-												const isExpanded = expandedComponents.has(
-													group.component,
-												);
-												const selectedArch =
-													activeArchTab[group.component] ??
-													group.reports[0]?.arch;
-												const selectedReport = group.reports.find(
-													(r) => r.arch === selectedArch,
-												);
-												return (
-													<Tbody key={group.component} isExpanded={isExpanded}>
-														<Tr>
-															<Td
-																expand={{
-																	rowIndex: groupIdx,
-																	isExpanded,
-																	onToggle: () =>
-																		setExpandedComponents((prev) => {
-																			const next = new Set(prev);
-																			if (next.has(group.component)) {
-																				next.delete(group.component);
-																			} else {
-																				next.add(group.component);
-																			}
-																			return next;
-																		}),
-																}}
-															/>
-															<Td>{group.component}</Td>
-															<Td>{group.reports.length}</Td>
-															<Td>
-																<SeverityCount
-																	count={group.critical}
-																	severity="Critical"
-																/>
-															</Td>
-															<Td>
-																<SeverityCount
-																	count={group.high}
-																	severity="High"
-																/>
-															</Td>
-															<Td>
-																<SeverityCount
-																	count={group.medium}
-																	severity="Medium"
-																/>
-															</Td>
-															<Td>
-																<SeverityCount
-																	count={group.low}
-																	severity="Low"
-																/>
-															</Td>
-															<Td>{group.total}</Td>
-															<Td>{group.fixable}</Td>
-														</Tr>
-														{isExpanded && selectedReport && (
-															<Tr isExpanded>
-																<Td colSpan={9}>
-																	<ExpandableRowContent>
-																		<Tabs
-																			isFilled
-																			activeKey={selectedArch}
-																			onSelect={(_e, key) =>
-																				setActiveArchTab((prev) => ({
-																					...prev,
-																					[group.component]: String(key),
-																				}))
-																			}
-																		>
-																			{group.reports.map((rpt) => (
-																				<Tab
-																					key={rpt.arch}
-																					eventKey={rpt.arch}
-																					title={
-																						<TabTitleText>
-																							{rpt.arch} ({rpt.total})
-																						</TabTitleText>
-																					}
-																				>
-																					<div style={{ padding: "1rem 0" }}>
-																						<Flex
-																							spaceItems={{
-																								default: "spaceItemsLg",
-																							}}
-																							style={{ marginBottom: "1rem" }}
-																						>
-																							<FlexItem>
-																								Critical:{" "}
-																								<SeverityCount
-																									count={rpt.critical}
-																									severity="Critical"
-																								/>
-																							</FlexItem>
-																							<FlexItem>
-																								High:{" "}
-																								<SeverityCount
-																									count={rpt.high}
-																									severity="High"
-																								/>
-																							</FlexItem>
-																							<FlexItem>
-																								Medium:{" "}
-																								<SeverityCount
-																									count={rpt.medium}
-																									severity="Medium"
-																								/>
-																							</FlexItem>
-																							<FlexItem>
-																								Low:{" "}
-																								<SeverityCount
-																									count={rpt.low}
-																									severity="Low"
-																								/>
-																							</FlexItem>
-																							<FlexItem>
-																								Total: {rpt.total}
-																							</FlexItem>
-																							<FlexItem>
-																								Fixable: {rpt.fixable}
-																							</FlexItem>
-																						</Flex>
-																						{rpt.vulnerabilities &&
-																						rpt.vulnerabilities.length > 0 ? (
-																							<VulnerabilitiesTable
-																								vulnerabilities={
-																									rpt.vulnerabilities
-																								}
-																							/>
-																						) : (
-																							<em>
-																								No vulnerabilities recorded.
-																							</em>
-																						)}
-																					</div>
-																				</Tab>
-																			))}
-																		</Tabs>
-																	</ExpandableRowContent>
-																</Td>
-															</Tr>
-														)}
-													</Tbody>
-												);
-											})}
-										</Table>
-									</Tab>
-								)}
-							</Tabs>
-						</CardBody>
-					</Card>
+				{version && release && (
+					<ApprovalsCard
+						version={version}
+						released={release.released}
+						onChange={onApprovalChange}
+					/>
+				)}
+
+				{version && <HistoryCard version={version} />}
+				{version && <ScopeChangesCard version={version} />}
+
+				{version && <BlockedIssuesCard version={version} />}
+				{version && <BackportsCard version={version} />}
+
+				{version && (
+					<CVEsCard version={version} summary={issueSummary ?? null} />
 				)}
 
 				{(issues ?? []).length > 0 && (
@@ -588,12 +248,14 @@ export default function ReleaseDetail() {
 function ReleaseSignal({
 	release,
 	readiness,
+	advisory,
 	jiraBaseUrl,
 	snapshot,
 	issueSummary,
 }: {
 	release: ReleaseVersion;
 	readiness: ReadinessResponse | null;
+	advisory: Advisory | null;
 	jiraBaseUrl?: string;
 	snapshot: SnapshotRecord | null;
 	issueSummary: IssueSummary | null;
@@ -659,6 +321,25 @@ function ReleaseSignal({
 							<Label color={signalColor} isCompact>
 								{readiness.message}
 							</Label>
+							{readiness.waivers && readiness.waivers.length > 0 && (
+								<Popover
+									headerContent="Waived rules"
+									bodyContent={
+										<ul>
+											{readiness.waivers.map((w) => (
+												<li key={w.id}>
+													<strong>{w.rule}</strong>: {w.reason} (until{" "}
+													{new Date(w.expires_at).toLocaleDateString()})
+												</li>
+											))}
+										</ul>
+									}
+								>
+									<Button variant="link" isInline>
+										{readiness.waivers.length} waived
+									</Button>
+								</Popover>
+							)}
 						</FlexItem>
 					)}
 					<FlexItem style={{ textAlign: "center" }}>
@@ -668,6 +349,23 @@ function ReleaseSignal({
 							{daysUntil !== null && ` (${daysUntil} days)`}
 						</div>
 					</FlexItem>
+					{readiness?.schedule && readiness.schedule.open_issues > 0 && (
+						<FlexItem style={{ textAlign: "center" }}>
+							<div className="rr-label">Projected</div>
+							<div>
+								{readiness.schedule.projected_completion
+									? new Date(
+											readiness.schedule.projected_completion,
+										).toLocaleDateString()
+									: "Not burning down"}{" "}
+								{readiness.schedule.at_risk && (
+									<Label color="orange" isCompact>
+										At risk
+									</Label>
+								)}
+							</div>
+						</FlexItem>
+					)}
 					{release.release_ticket_key && (
 						<FlexItem style={{ textAlign: "center" }}>
 							<div className="rr-label">Ticket</div>
@@ -692,6 +390,17 @@ function ReleaseSignal({
 							<div>{release.release_ticket_assignee}</div>
 						</FlexItem>
 					)}
+					{advisory && (
+						<FlexItem style={{ textAlign: "center" }}>
+							<div className="rr-label">Advisory</div>
+							<div title={advisory.sync_error ?? advisory.synopsis}>
+								{advisory.name || `#${advisory.advisory_id}`}{" "}
+								<Label color={advisoryColor(advisory.state)} isCompact>
+									{advisory.state || "Not synced"}
+								</Label>
+							</div>
+						</FlexItem>
+					)}
 					{release.released && (
 						<FlexItem style={{ textAlign: "center" }}>
 							<div className="rr-label">Status</div>
@@ -726,27 +435,22 @@ function ReleaseSignal({
 	);
 }
 
-const severityLabelColor: Record<string, "red" | "orange" | "yellow" | "grey"> =
-	{
-		Critical: "red",
-		High: "red",
-		Medium: "orange",
-		Low: "yellow",
-	};
-
-function SeverityCount({
-	count,
-	severity,
-}: {
-	count: number;
-	severity: string;
-}) {
-	if (count === 0) return <>{"\u2014"}</>;
-	return (
-		<Label color={severityLabelColor[severity] ?? "grey"} isCompact>
-			{count}
-		</Label>
-	);
+/** Colours an Errata Tool state: ready once past QE, red when dropped. */
+function advisoryColor(state?: string): "green" | "red" | "orange" | "grey" {
+	switch (state) {
+		case "REL_PREP":
+		case "PUSH_READY":
+		case "IN_PUSH":
+		case "SHIPPED_LIVE":
+			return "green";
+		case "DROPPED_NO_SHIP":
+			return "red";
+		case undefined:
+		case "":
+			return "grey";
+		default:
+			return "orange";
+	}
 }
 
 const ISSUES_COLUMNS: ColumnDef[] = [
@@ -755,6 +459,7 @@ const ISSUES_COLUMNS: ColumnDef[] = [
 	{ key: "summary", label: "Summary" },
 	{ key: "priority", label: "Priority" },
 	{ key: "status", label: "Status" },
+	{ key: "subtasks", label: "Sub-tasks" },
 	{ key: "assignee", label: "Assignee" },
 	{ key: "qaContact", label: "QA Contact" },
 ];
@@ -772,9 +477,14 @@ function buildJQL(
 	config: DashboardConfig | undefined,
 	version: string,
 ): string | undefined {
-	if (!config?.jira_project) return undefined;
-	const project = config.jira_project;
-	return `project=${project} AND "Target Version"="${version}"`;
+	const projects = config?.jira_project
+		.split(",")
+		.map((p) => p.trim())
+		.filter(Boolean);
+	if (!projects?.length) return undefined;
+	const project =
+		projects.length === 1 ? `=${projects[0]}` : ` in (${projects.join(", ")})`;
+	return `project${project} AND "Target Version"="${version}"`;
 }
 
 function IssuesCard({
@@ -831,6 +541,11 @@ function IssuesCard({
 							alignItems={{ default: "alignItemsCenter" }}
 							spaceItems={{ default: "spaceItemsMd" }}
 						>
+							<FlexItem>
+								<a href={releaseIssuesCsvUrl(version)} download>
+									Export CSV
+								</a>
+							</FlexItem>
 							<FlexItem>
 								<Button
 									variant="plain"
@@ -913,6 +628,9 @@ function IssuesTable({
 				case "status":
 					cmp = a.status.localeCompare(b.status);
 					break;
+				case "subtasks":
+					cmp = (a.subtask_completion ?? 2) - (b.subtask_completion ?? 2);
+					break;
 				case "assignee":
 					cmp = a.assignee.localeCompare(b.assignee);
 					break;
@@ -970,6 +688,14 @@ function IssuesTable({
 								Status
 							</Th>
 						)}
+						{isColumnVisible("subtasks") && (
+							<Th
+								sort={getSortParams("subtasks")}
+								style={{ whiteSpace: "nowrap" }}
+							>
+								Sub-tasks
+							</Th>
+						)}
 						{isColumnVisible("assignee") && (
 							<Th
 								sort={getSortParams("assignee")}
@@ -1018,6 +744,19 @@ function IssuesTable({
 									<StatusLabel status={issue.status} />
 								</Td>
 							)}
+							{isColumnVisible("subtasks") && (
+								<Td>
+									{issue.subtasks && issue.subtask_completion !== undefined && (
+										<Progress
+											value={issue.subtask_completion * 100}
+											label={`${issue.subtasks.filter((t) => isDone(t.status)).length}/${issue.subtasks.length}`}
+											measureLocation={ProgressMeasureLocation.outside}
+											aria-label={`${issue.key} sub-tasks done`}
+											size="sm"
+										/>
+									)}
+								</Td>
+							)}
 							{isColumnVisible("assignee") && <Td>{issue.assignee}</Td>}
 							{isColumnVisible("qaContact") && <Td>{issue.qa_contact}</Td>}
 						</Tr>
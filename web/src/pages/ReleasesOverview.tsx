@@ -41,8 +41,10 @@ import type {
 	ReadinessResponse,
 	ReleaseOverview,
 	ReleaseVersion,
+	SignOff,
 	SnapshotRecord,
 } from "../api/types";
+import StaleIssuesAlert from "../components/StaleIssuesAlert";
 import { seedCache, useCachedFetch } from "../hooks/useCachedFetch";
 import { useConfig } from "../hooks/useConfig";
 import { formatReleaseName, jiraIssueUrl } from "../utils/links";
@@ -178,6 +180,9 @@ export default function ReleasesOverview() {
 					</ToolbarGroup>
 				</ToolbarContent>
 			</Toolbar>
+			<StaleIssuesAlert
+				summaries={active.map((ov) => ov.issue_summary)}
+			/>
 
 			<Gallery hasGutter minWidths={{ default: galleryMinWidth }}>
 				{active.filter(filterOverview).map((ov) => (
@@ -187,6 +192,7 @@ export default function ReleasesOverview() {
 						issueSummary={ov.issue_summary}
 						readinessSignal={ov.readiness}
 						snapshot={ov.snapshot}
+						signOff={ov.sign_off}
 						viewMode={viewMode}
 						jiraBaseUrl={config?.jira_base_url}
 					/>
@@ -208,6 +214,8 @@ export default function ReleasesOverview() {
 								issueSummary={ov.issue_summary}
 								readinessSignal={ov.readiness}
 								snapshot={ov.snapshot}
+								signOff={ov.sign_off}
+						signOff={ov.sign_off}
 								viewMode={viewMode}
 								jiraBaseUrl={config?.jira_base_url}
 							/>
@@ -224,6 +232,7 @@ function ReleaseCard({
 	issueSummary,
 	readinessSignal,
 	snapshot,
+	signOff,
 	viewMode,
 	jiraBaseUrl,
 }: {
@@ -231,6 +240,7 @@ function ReleaseCard({
 	issueSummary?: IssueSummary;
 	readinessSignal?: ReadinessResponse;
 	snapshot?: SnapshotRecord;
+	signOff?: SignOff;
 	viewMode: ViewMode;
 	jiraBaseUrl?: string;
 }) {
@@ -353,6 +363,14 @@ function ReleaseCard({
 								</DescriptionListDescription>
 							</DescriptionListGroup>
 						)}
+						{signOff && (
+							<DescriptionListGroup>
+								<DescriptionListTerm>Sign-off</DescriptionListTerm>
+								<DescriptionListDescription>
+									<SignOffLabel signOff={signOff} />
+								</DescriptionListDescription>
+							</DescriptionListGroup>
+						)}
 					</DescriptionList>
 				) : (
 					<Flex direction={{ default: "column" }}>
@@ -410,12 +428,62 @@ function ReleaseCard({
 										</div>
 									</FlexItem>
 								)}
+								{signOff && (
+									<FlexItem>
+										<span className="rr-label">Sign-off</span>
+										<div>
+											<SignOffLabel signOff={signOff} />
+										</div>
+									</FlexItem>
+								)}
+								{issueSummary && issueSummary.open_blockers > 0 && (
+									<FlexItem>
+										<span className="rr-label">Blockers</span>
+										<div>
+											<Label color="red" isCompact>
+												{issueSummary.open_blockers} open
+											</Label>
+										</div>
+									</FlexItem>
+								)}
 								{issueSummary && issueSummary.cves > 0 && (
 									<FlexItem>
 										<span className="rr-label">CVEs</span>
 										<div>{issueSummary.cves}</div>
 									</FlexItem>
 								)}
+								{issueSummary?.buckets
+									?.filter((b) => b.open > 0)
+									.map((b) => (
+										<FlexItem key={b.name}>
+											<span className="rr-label">{b.name}</span>
+											<div>{b.open}</div>
+										</FlexItem>
+									))}
+								{issueSummary?.components
+									?.filter((c) => c.open > 0)
+									.map((c) => (
+										<FlexItem key={c.component}>
+											<span className="rr-label">{c.component}</span>
+											<div>
+												{c.open_blockers > 0 ? (
+													<Label color="red" isCompact>
+														{c.open} open, {c.open_blockers} blocking
+													</Label>
+												) : (
+													c.open
+												)}
+											</div>
+										</FlexItem>
+									))}
+								{issueSummary?.projects &&
+									issueSummary.projects.length > 1 &&
+									issueSummary.projects.map((p) => (
+										<FlexItem key={p.project}>
+											<span className="rr-label">{p.project} open</span>
+											<div>{p.open}</div>
+										</FlexItem>
+									))}
 							</Flex>
 						</FlexItem>
 						{issueSummary && issueSummary.total > 0 && (
@@ -434,3 +502,24 @@ function ReleaseCard({
 		</Card>
 	);
 }
+
+/** Shows how many of the sign-off roles have approved, naming the missing ones. */
+function SignOffLabel({ signOff }: { signOff: SignOff }) {
+	const total = signOff.approved.length + signOff.outstanding.length;
+	if (signOff.outstanding.length === 0) {
+		return (
+			<Label color="green" icon={<CheckCircleIcon />} isCompact>
+				Signed off
+			</Label>
+		);
+	}
+	return (
+		<Label
+			color={signOff.approved.length > 0 ? "blue" : "grey"}
+			isCompact
+			title={`Awaiting ${signOff.outstanding.join(", ")}`}
+		>
+			{signOff.approved.length}/{total} approved
+		</Label>
+	);
+}
@@ -0,0 +1,103 @@
+import {
+	Breadcrumb,
+	BreadcrumbItem,
+	EmptyState,
+	EmptyStateBody,
+	PageSection,
+	Spinner,
+	Title,
+} from "@patternfly/react-core";
+import { Link, useParams, useSearchParams } from "react-router-dom";
+import { getSnapshot } from "../api/client";
+import ScenarioTrendsCard from "../components/ScenarioTrendsCard";
+import SnapshotCard from "../components/SnapshotCard";
+import SnapshotDiffCard from "../components/SnapshotDiffCard";
+import { useCachedFetch } from "../hooks/useCachedFetch";
+import { formatReleaseName } from "../utils/links";
+
+/**
+ * Shows a single snapshot. The optional release query parameter keeps the
+ * release it was reached from in the breadcrumb; the optional compare
+ * parameter names an older snapshot to show the changes since.
+ */
+export default function SnapshotDetail() {
+	const { name } = useParams<{ name: string }>();
+	const [searchParams] = useSearchParams();
+	const version = searchParams.get("release");
+	const compare = searchParams.get("compare");
+
+	const { data: snapshot, loading } = useCachedFetch(
+		name ? `snapshotByName:${name}` : null,
+		() => getSnapshot(name!),
+	);
+
+	if (loading && !snapshot) {
+		return (
+			<PageSection>
+				<div style={{ textAlign: "center" }}>
+					<Spinner />
+				</div>
+			</PageSection>
+		);
+	}
+
+	return (
+		<>
+			<PageSection>
+				<Breadcrumb>
+					<BreadcrumbItem>
+						<Link to="/">Releases</Link>
+					</BreadcrumbItem>
+					{version && (
+						<>
+							<BreadcrumbItem>
+								<Link to={`/releases/${encodeURIComponent(version)}`}>
+									{formatReleaseName(version)}
+								</Link>
+							</BreadcrumbItem>
+							<BreadcrumbItem>
+								<Link
+									to={`/releases/${encodeURIComponent(version)}/snapshots`}
+								>
+									Snapshots
+								</Link>
+							</BreadcrumbItem>
+						</>
+					)}
+					<BreadcrumbItem isActive>{name}</BreadcrumbItem>
+				</Breadcrumb>
+			</PageSection>
+
+			<PageSection>
+				{!snapshot ? (
+					<EmptyState>
+						<Title headingLevel="h2" size="lg">
+							Snapshot not found
+						</Title>
+						<EmptyStateBody>
+							No data found for snapshot &quot;{name}&quot;.
+						</EmptyStateBody>
+					</EmptyState>
+				) : (
+					<>
+						<Title headingLevel="h1" style={{ marginBottom: "1rem" }}>
+							{snapshot.name}
+						</Title>
+						{compare && (
+							<SnapshotDiffCard from={compare} to={snapshot.name} />
+						)}
+						<SnapshotCard
+							snapshot={snapshot}
+							title={
+								snapshot.source
+									? `Snapshot of ${snapshot.application} (${snapshot.source})`
+									: `Snapshot of ${snapshot.application}`
+							}
+						/>
+						<ScenarioTrendsCard snapshot={snapshot} />
+					</>
+				)}
+			</PageSection>
+		</>
+	);
+}
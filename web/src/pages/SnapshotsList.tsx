@@ -123,12 +123,19 @@ export default function SnapshotsList() {
 									<Th>Application</Th>
 									<Th>Tests</Th>
 									<Th>Created</Th>
+									<Th>Changes</Th>
 								</Tr>
 							</Thead>
 							<Tbody>
-								{snapshots.map((s) => (
+								{snapshots.map((s, i) => (
 									<Tr key={s.id}>
-										<Td>{s.name}</Td>
+										<Td>
+											<Link
+												to={`/snapshots/${encodeURIComponent(s.name)}?release=${encodeURIComponent(version ?? "")}`}
+											>
+												{s.name}
+											</Link>
+										</Td>
 										<Td>{s.application}</Td>
 										<Td>
 											<StatusLabel
@@ -142,6 +149,13 @@ export default function SnapshotsList() {
 											/>
 										</Td>
 										<Td>{new Date(s.created_at).toLocaleString()}</Td>
+										<Td>
+											<ChangesLink
+												snapshot={s}
+												previous={previousSnapshot(snapshots, i)}
+												version={version}
+											/>
+										</Td>
 									</Tr>
 								))}
 							</Tbody>
@@ -164,3 +178,35 @@ export default function SnapshotsList() {
 		</>
 	);
 }
+
+/**
+ * Returns the snapshot of the same application listed after snapshots[i],
+ * which is the one created before it.
+ */
+function previousSnapshot(
+	snapshots: SnapshotRecord[],
+	i: number,
+): SnapshotRecord | undefined {
+	return snapshots
+		.slice(i + 1)
+		.find((s) => s.application === snapshots[i].application);
+}
+
+function ChangesLink({
+	snapshot,
+	previous,
+	version,
+}: {
+	snapshot: SnapshotRecord;
+	previous?: SnapshotRecord;
+	version?: string;
+}) {
+	if (!previous) return <>{"\u2014"}</>;
+	return (
+		<Link
+			to={`/snapshots/${encodeURIComponent(snapshot.name)}?release=${encodeURIComponent(version ?? "")}&compare=${encodeURIComponent(previous.name)}`}
+		>
+			Since {previous.name}
+		</Link>
+	);
+}
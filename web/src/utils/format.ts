@@ -4,3 +4,10 @@ export function formatDuration(seconds: number): string {
 	const s = Math.round(seconds % 60);
 	return `${m}m ${s}s`;
 }
+
+const doneStatuses = ["closed", "verified", "done"];
+
+/** Reports whether a JIRA status counts as done, as the server does. */
+export function isDone(status: string): boolean {
+	return doneStatuses.includes(status.toLowerCase());
+}
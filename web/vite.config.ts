@@ -26,6 +26,10 @@ export default defineConfig({
 				target: "http://localhost:8088",
 				changeOrigin: true,
 			},
+			"/feeds": {
+				target: "http://localhost:8088",
+				changeOrigin: true,
+			},
 		},
 	},
 });